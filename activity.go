@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"spark-todo/internal/todo"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// activityRetentionDays 为动态流的保留天数，更早的记录由维护任务清理。
+const activityRetentionDays = 90
+
+// GetActivity 返回最近的动态（按时间倒序，每条附带按用户语言生成的描述）：
+// - before：上一页返回的 nextCursor，0 表示从最新开始
+// - since：只返回该时间（UnixMilli）之后的动态，0 表示不限；"今天做了什么"面板传今天零点
+// - limit：每页条数，0 为默认 50，最多 200
+func (a *App) GetActivity(before int64, since int64, limit int) (todo.ActivityPage, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.ActivityPage{}, err
+	}
+	page, err := a.store.ListActivity(a.ctx, before, since, limit)
+	if err != nil {
+		return todo.ActivityPage{}, a.localize(err)
+	}
+	for i := range page.Items {
+		item := &page.Items[i]
+		args := []any{item.Title}
+		switch item.Action {
+		case todo.ActivityTaskMoved, todo.ActivityTaskRenamed, todo.ActivityGroupRenamed:
+			args = append(args, item.Detail)
+		}
+		item.Text = a.tr("activity."+item.Action, args...)
+	}
+	return page, nil
+}
+
+// pruneActivity 清理超过保留天数的动态，失败只记录日志。
+func (a *App) pruneActivity(ctx context.Context) {
+	if a.store == nil || a.store.ReadOnly() {
+		return
+	}
+	before := time.Now().AddDate(0, 0, -activityRetentionDays)
+	if _, err := a.store.PruneActivity(ctx, before.UnixMilli()); err != nil {
+		runtime.LogErrorf(a.ctx, "failed to prune activity: %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"spark-todo/internal/restapi"
+	"spark-todo/internal/todo"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// NewAPIToken 为新创建的令牌：Secret 为令牌本身，只在创建时返回这一次。
+type NewAPIToken struct {
+	Token  todo.APIToken `json:"token"`
+	Secret string        `json:"secret"`
+}
+
+// ListAPITokens 返回本地 REST 接口（设置项 apiPort 不为 0 时开启）的全部访问令牌（不含令牌本身）。
+func (a *App) ListAPITokens() ([]todo.APIToken, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return nil, err
+	}
+	tokens, err := a.store.ListAPITokens(a.ctx)
+	if err != nil {
+		return nil, a.localize(err)
+	}
+	return tokens, nil
+}
+
+// CreateAPIToken 为第三方脚本创建访问令牌，scope 为 "read"（只读）或 "readwrite"（读写）。
+// 库中只保存令牌摘要，界面应提示用户立即复制保存。
+func (a *App) CreateAPIToken(name string, scope string) (NewAPIToken, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return NewAPIToken{}, err
+	}
+	t, secret, err := a.store.CreateAPIToken(a.ctx, name, scope, time.Now())
+	if err != nil {
+		return NewAPIToken{}, a.localize(err)
+	}
+	return NewAPIToken{Token: t, Secret: secret}, nil
+}
+
+// RevokeAPIToken 吊销令牌，使用该令牌的脚本随即失去访问权限。
+func (a *App) RevokeAPIToken(id int64) error {
+	if err := a.ensureStoreReady(); err != nil {
+		return err
+	}
+	return a.localize(a.store.RevokeAPIToken(a.ctx, id))
+}
+
+// applyAPIPort 按设置中的端口（重新）启动本地 REST 接口（见 restapi.Server），只监听 127.0.0.1；port 为 0 时关闭。
+// 接口修改数据后发出 "api:changed" 事件，让界面刷新。
+func (a *App) applyAPIPort(port int) {
+	a.apiMu.Lock()
+	defer a.apiMu.Unlock()
+
+	if a.apiServer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		_ = a.apiServer.Shutdown(ctx)
+		cancel()
+		a.apiServer = nil
+	}
+	if port == 0 || a.store == nil {
+		return
+	}
+
+	ln, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+	if err != nil {
+		runtime.LogErrorf(a.ctx, "local api: listen on port %d: %v", port, err)
+		return
+	}
+	handler := restapi.NewServer(a.store, a.lang)
+	handler.OnChange = func() { runtime.EventsEmit(a.ctx, "api:changed") }
+	srv := &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+	a.apiServer = srv
+	go func() {
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			runtime.LogErrorf(a.ctx, "local api: %v", err)
+		}
+	}()
+}
@@ -4,11 +4,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"sync"
 	"sync/atomic"
 	"time"
 
+	"spark-todo/internal/hotkey"
+	"spark-todo/internal/i18n"
 	"spark-todo/internal/todo"
 	"spark-todo/internal/version"
 
@@ -31,6 +36,9 @@ type App struct {
 	// store 封装了 SQLite 读写与迁移逻辑。
 	store *todo.Store
 
+	// dataDir 为用户数据目录（todo.db 所在目录），回滚备份等文件也放在这里。
+	dataDir string
+
 	// startupErr 记录启动阶段失败原因（如无法确定 DB 路径、打开 DB 失败等），
 	// 供后续 API 调用时返回更友好的错误信息。
 	startupErr error
@@ -38,18 +46,72 @@ type App struct {
 	// waterReminderShowing 用于防止"喝水提醒"弹窗重复叠加。
 	//（例如用户未关闭弹窗时定时器再次触发，或多次前端初始化导致的重复调用）
 	waterReminderShowing atomic.Bool
+	// waterSnoozeTimer 在"喝水提醒"被推迟时到点重新提醒（见 SnoozeWaterReminder），由 waterSnoozeMu 保护。
+	waterSnoozeMu    sync.Mutex
+	waterSnoozeTimer *time.Timer
+
+	// pomodoroCancel 停止当前番茄钟的每秒进度推送（见 startPomodoroTicker），由 pomodoroMu 保护。
+	pomodoroMu     sync.Mutex
+	pomodoroCancel context.CancelFunc
+
+	// language 缓存当前界面语言（string），用于翻译后端产生的文案；
+	// 在 startup 与 SetLanguage 时更新，避免每次出错都读库。
+	language atomic.Value
+
+	// systemTheme 缓存最近一次检测到的系统外观（string："light" | "dark"）。
+	systemTheme atomic.Value
+	// systemA11y 缓存最近一次检测到的系统辅助功能偏好（AccessibilityPrefs）。
+	systemA11y atomic.Value
+
+	// hotkeys 管理全局快捷键注册（startup 中创建）。
+	hotkeys *hotkey.Manager
+	// hotkeyStatus 缓存各动作最近一次的注册结果（[]HotkeyStatus）。
+	hotkeyStatus atomic.Value
+
+	// bgCancel 用于在退出时停止后台任务（系统外观监听、跨天重置等）。
+	bgCancel context.CancelFunc
+
+	// updateChecker 用于检查应用更新；更新源变化时整体替换（见 live_settings.go），读取时须 Load
+	updateChecker atomic.Pointer[version.UpdateChecker]
 
-	// updateChecker 用于检查应用更新
-	updateChecker *version.UpdateChecker
+	// updateMu 保护 updateCancel。
+	updateMu sync.Mutex
+	// updateCancel 用于取消进行中的更新下载（nil 表示当前没有下载）。
+	updateCancel context.CancelFunc
+
+	// shareMu 保证同一时间只有一轮共享分组同步（手动同步与后台同步互斥）。
+	shareMu sync.Mutex
+
+	// cloudMu 保证同一时间只有一次云备份（手动备份与定时备份互斥）。
+	cloudMu sync.Mutex
+
+	// apiMu 保护 apiServer。
+	apiMu sync.Mutex
+	// apiServer 为正在运行的本地 REST 接口（nil 表示未开启，见 applyAPIPort）。
+	apiServer *http.Server
+
+	// settingsMu 串行化 applySettings，保护 appliedSettings / settingsApplied。
+	settingsMu sync.Mutex
+	// appliedSettings 为各子系统最近一次应用的设置，用于判断哪些设置发生了变化。
+	appliedSettings todo.Settings
+	settingsApplied bool
+	// dayWake 用于在时区变化时唤醒 watchDayChange（容量 1，NewApp 中创建）。
+	dayWake chan struct{}
+
+	// history 为任务/分组修改的撤销/重做栈（见 UndoLast）。
+	history history
+
+	// readOnlyFlag 表示以 --readonly 参数启动：整个运行期间保持只读模式，不能在界面中关闭。
+	readOnlyFlag bool
 }
 
 // NewApp 创建 App 实例。
 //
 // 实际初始化（打开数据库、读取设置）在 startup 回调中完成，因为只有那里能拿到 Wails runtime ctx。
 func NewApp() *App {
-	return &App{
-		updateChecker: version.NewUpdateChecker(""),
-	}
+	a := &App{dayWake: make(chan struct{}, 1)}
+	a.updateChecker.Store(version.NewUpdateChecker(""))
+	return a
 }
 
 // startup 在应用启动时被 Wails 调用。
@@ -61,31 +123,60 @@ func NewApp() *App {
 func (a *App) startup(ctx context.Context) {
 	a.ctx = ctx
 
+	bgCtx, cancel := context.WithCancel(ctx)
+	a.bgCancel = cancel
+	a.hotkeys = hotkey.NewManager(a.onHotkey)
+
 	dbPath, err := todo.DefaultDBPath("Spark-Todo")
 	if err != nil {
 		runtime.LogErrorf(ctx, "failed to resolve db path: %v", err)
-		a.startupErr = fmt.Errorf("初始化失败：无法确定数据库路径：%w", err)
+		a.startupErr = a.wrapErr("app.initDBPathFailed", err)
 		return
 	}
+	a.dataDir = filepath.Dir(dbPath)
 
 	s, err := todo.Open(dbPath)
 	if err != nil {
 		runtime.LogErrorf(ctx, "failed to open db: %v", err)
-		a.startupErr = fmt.Errorf("初始化失败：无法打开数据库：%w", err)
+		a.startupErr = a.wrapErr("app.initDBOpenFailed", err)
 		return
 	}
 	a.store = s
 	a.startupErr = nil
+	a.store.SetBusyHandler(a.onDatabaseBusy)
+	if a.readOnlyFlag {
+		if err := a.store.SetReadOnly(ctx, true); err != nil {
+			runtime.LogErrorf(ctx, "failed to enable read-only mode: %v", err)
+		}
+	}
+	go a.detectSystemAppearance()
+	go a.watchDayChange(bgCtx)
+	go a.checkHolidayCoverage(bgCtx)
+	go a.runTrashMaintenance(bgCtx)
+	go a.runShareSync(bgCtx)
+	go a.runCloudBackup(bgCtx)
+	go a.runReminders(bgCtx)
+	a.restoreWaterSnooze()
+	a.resumePomodoroTicker()
 
 	settings, err := a.store.GetSettings(ctx)
 	if err == nil {
-		runtime.WindowSetAlwaysOnTop(ctx, settings.AlwaysOnTop)
+		a.applySettings(settings, true)
 	}
 }
 
 // shutdown 在应用退出时被 Wails 调用，用于释放资源。
 func (a *App) shutdown(ctx context.Context) {
 	_ = ctx
+	if a.bgCancel != nil {
+		a.bgCancel()
+	}
+	a.scheduleWaterReminder(0)
+	a.stopPomodoroTicker()
+	if a.hotkeys != nil {
+		a.hotkeys.Close()
+	}
+	a.applyAPIPort(0)
 	if a.store != nil {
 		_ = a.store.Close()
 	}
@@ -102,72 +193,361 @@ func (a *App) ensureStoreReady() error {
 	if a.startupErr != nil {
 		return a.startupErr
 	}
-	return errors.New("应用尚未初始化完成")
+	return errors.New(a.tr("app.notReady"))
+}
+
+// lang 返回当前界面语言；设置尚未加载时使用默认语言。
+func (a *App) lang() string {
+	if v, ok := a.language.Load().(string); ok {
+		return v
+	}
+	return i18n.Default
+}
+
+// tr 按当前界面语言翻译后端文案。
+func (a *App) tr(key string, args ...any) string {
+	return i18n.T(a.lang(), key, args...)
+}
+
+// wrapErr 以当前语言的文案包装底层错误（保留 %w 链，便于日志与 errors.Is 判断）。
+func (a *App) wrapErr(key string, err error) error {
+	return fmt.Errorf("%s: %w", a.tr(key), err)
+}
+
+// localize 将 Store 返回的业务错误（*todo.Error）翻译为当前界面语言；其它错误原样返回。
+//
+// 所有直接把 Store 错误返回给前端的 API 都应经过这里。
+func (a *App) localize(err error) error {
+	var te *todo.Error
+	if errors.As(err, &te) {
+		return &localizedError{msg: te.Localize(a.lang()), err: err}
+	}
+	return err
+}
+
+// localizedError 携带翻译后的文案，同时保留原始错误链（errors.Is(err, todo.ErrTaskNotFound) 仍然成立）。
+type localizedError struct {
+	msg string
+	err error
+}
+
+func (e *localizedError) Error() string { return e.msg }
+func (e *localizedError) Unwrap() error { return e.err }
+
+// onDatabaseBusy 在写操作遇到锁冲突（数据库正被其他程序写入）时记录日志并通知前端（"db:busy"）。
+func (a *App) onDatabaseBusy(e todo.BusyEvent) {
+	if e.GaveUp {
+		runtime.LogWarningf(a.ctx, "database busy: %s gave up after %d retries (%dms)", e.Op, e.Attempt, e.WaitedMs)
+	} else {
+		runtime.LogWarningf(a.ctx, "database busy: %s retry %d (%dms)", e.Op, e.Attempt, e.WaitedMs)
+	}
+	runtime.EventsEmit(a.ctx, "db:busy", e)
+}
+
+// formatError 为 Wails 的 ErrorFormatter：带附加数据的业务错误以对象 {message, code, data} 返回，
+// 前端可据此提供进一步操作（如跳转到重名的分组）；其余错误仍只返回文案。
+//
+// 只读模式下被 SQLite 拒绝的写操作统一返回 ErrReadOnly 的文案，无需每个接口单独转换；
+// 数据库被其他程序长时间占用导致的写失败同理返回 ErrDatabaseBusy 的文案。
+func (a *App) formatError(err error) any {
+	if todo.IsReadOnly(err) {
+		return a.localize(todo.ErrReadOnly).Error()
+	}
+	if todo.IsBusy(err) {
+		return a.localize(todo.ErrDatabaseBusy).Error()
+	}
+	var te *todo.Error
+	if errors.As(err, &te) && len(te.Data) > 0 {
+		return map[string]any{"message": err.Error(), "code": te.Code, "data": te.Data}
+	}
+	return err.Error()
 }
 
 // GetBoard 返回前端渲染所需的聚合数据：
 // - groups：分组列表
-// - tasks：任务列表
+// - tasks：任务列表（内容只含简短预览，完整内容与关联记录通过 GetTaskDetail 按需加载）
 // - settings：用户设置
 // - statuses：状态枚举（用于下拉选项/校验）
-func (a *App) GetBoard() (todo.Board, error) {
+// - tags：全部标签（任务上的标签名在 task.tags 中），用于标签筛选栏
+//
+// since 为 0 时返回全量数据；否则为上次返回的 syncedAt，只返回此后变化的分组/任务及已删除的 ID，
+// 供窗口重新获得焦点时低成本地增量刷新。
+func (a *App) GetBoard(since int64) (todo.Board, error) {
 	if err := a.ensureStoreReady(); err != nil {
 		return todo.Board{}, err
 	}
 
-	groups, err := a.store.ListGroups(a.ctx)
+	syncedAt := time.Now().UnixMilli()
+	settings, err := a.store.GetSettings(a.ctx)
 	if err != nil {
-		return todo.Board{}, err
+		return todo.Board{}, a.localize(err)
+	}
+	sort := settings.ViewSorts[settings.ViewMode]
+
+	board := todo.Board{
+		Settings:        settings,
+		Statuses:        []todo.Status{todo.StatusTodo, todo.StatusDoing, todo.StatusDone},
+		Filter:          settings.ViewFilters[settings.ViewMode],
+		Accent:          todo.ResolveAccentColor(settings),
+		ThemePresets:    todo.ThemePresets(),
+		SyncedAt:        syncedAt,
+		Delta:           since > 0,
+		DeletedGroupIDs: []int64{},
+		DeletedTaskIDs:  []int64{},
+	}
+	if since > 0 {
+		changes, err := a.store.ChangesSince(a.ctx, since, sort)
+		if err != nil {
+			return todo.Board{}, a.localize(err)
+		}
+		board.Groups, board.ArchivedGroups, board.Tasks = todo.ExcludeArchived(changes.Groups, changes.Tasks)
+		board.DeletedGroupIDs, board.DeletedTaskIDs = changes.DeletedGroupIDs, changes.DeletedTaskIDs
+	} else {
+		groups, err := a.store.ListGroups(a.ctx)
+		if err != nil {
+			return todo.Board{}, a.localize(err)
+		}
+		tasks, err := a.store.ListTasks(a.ctx, sort)
+		if err != nil {
+			return todo.Board{}, a.localize(err)
+		}
+		board.Groups, board.ArchivedGroups, board.Tasks = todo.ExcludeArchived(groups, tasks)
 	}
-	tasks, err := a.store.ListTasks(a.ctx)
+	todo.SlimContent(board.Tasks)
+	todo.FillCountdown(board.Tasks, time.Now(), todo.Location(settings))
+	if err := a.store.FillAging(a.ctx, board.Tasks, time.Now(), todo.Location(settings)); err != nil {
+		return todo.Board{}, a.localize(err)
+	}
+	if err := a.store.FillTags(a.ctx, board.Tasks); err != nil {
+		return todo.Board{}, a.localize(err)
+	}
+	if err := a.store.FillLatestNotes(a.ctx, board.Tasks); err != nil {
+		return todo.Board{}, a.localize(err)
+	}
+	if board.Tags, err = a.store.ListTags(a.ctx); err != nil {
+		return todo.Board{}, a.localize(err)
+	}
+	if settings.ShowLunar {
+		todo.FillLunar(board.Tasks, todo.Location(settings))
+	}
+	return board, nil
+}
+
+// GetQuadrants 返回按"重要/紧急"四象限划分好的主任务及各象限计数
+// （遵循"隐藏已完成"设置与四象限视图的排序设置）。
+func (a *App) GetQuadrants() ([]todo.Quadrant, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return nil, err
+	}
+
+	settings, err := a.store.GetSettings(a.ctx)
 	if err != nil {
-		return todo.Board{}, err
+		return nil, a.localize(err)
+	}
+	quadrants, err := a.store.ListQuadrants(a.ctx, settings.HideDone, settings.ViewSorts[todo.ViewQuadrants])
+	if err != nil {
+		return nil, a.localize(err)
 	}
+	return quadrants, nil
+}
+
+// GetSwimlanes 返回按状态 × 分组（by="group"）或状态 × 标签（by="tag"）划分好的主任务及各格计数
+// （遵循"隐藏已完成"设置与泳道视图的排序设置）。
+func (a *App) GetSwimlanes(by string) (todo.Swimlanes, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.Swimlanes{}, err
+	}
+
 	settings, err := a.store.GetSettings(a.ctx)
 	if err != nil {
-		return todo.Board{}, err
+		return todo.Swimlanes{}, a.localize(err)
 	}
+	lanes, err := a.store.ListSwimlanes(a.ctx, by, settings.HideDone, settings.ViewSorts[todo.ViewSwimlanes])
+	if err != nil {
+		return todo.Swimlanes{}, a.localize(err)
+	}
+	return lanes, nil
+}
 
-	return todo.Board{
-		Groups:   groups,
-		Tasks:    tasks,
-		Settings: settings,
-		Statuses: []todo.Status{todo.StatusTodo, todo.StatusDoing, todo.StatusDone},
-	}, nil
+// SetTaskRecurrence 为主任务设置重复规则（rule.freq："daily" | "weekly" | "monthly" | "yearly"；
+// rule.mode："schedule" 按原计划 | "completion" 按完成时间；rule.skipHolidays：截止日遇周末/法定假日时顺延；
+// rule.lunar：按农历每年重复；rule.weekdays：每周重复时指定星期，如每周一、三、五）。任务完成时会自动生成下一次的任务。
+func (a *App) SetTaskRecurrence(taskID int64, rule todo.Recurrence) (todo.Recurrence, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.Recurrence{}, err
+	}
+	rule.TaskID = taskID
+	r, err := a.store.SetRecurrence(a.ctx, rule)
+	return r, a.localize(err)
+}
+
+// ClearTaskRecurrence 取消任务的重复规则。
+func (a *App) ClearTaskRecurrence(taskID int64) error {
+	if err := a.ensureStoreReady(); err != nil {
+		return err
+	}
+	return a.localize(a.store.ClearRecurrence(a.ctx, taskID))
+}
+
+// SetQuadrant 将多个任务一次性移到指定象限（四象限视图中框选后拖动），
+// 成功后发出一次 "tasks:changed" 事件（参数为更新后的任务列表）。
+func (a *App) SetQuadrant(ids []int64, important bool, urgent bool) ([]todo.Task, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return nil, err
+	}
+	tasks, err := a.store.SetQuadrant(a.ctx, ids, important, urgent)
+	if err != nil {
+		return nil, a.localize(err)
+	}
+	runtime.EventsEmit(a.ctx, "tasks:changed", tasks)
+	return tasks, nil
+}
+
+// ReorderTasks 按拖动后的顺序重排分组中的顶层任务（见 Store.ReorderTasks），
+// 成功后发出 "tasks:reordered" 事件（参数为分组 ID），让其它窗口刷新。
+func (a *App) ReorderTasks(groupID int64, orderedIDs []int64) error {
+	if err := a.ensureStoreReady(); err != nil {
+		return err
+	}
+	if err := a.store.ReorderTasks(a.ctx, groupID, orderedIDs); err != nil {
+		return a.localize(err)
+	}
+	runtime.EventsEmit(a.ctx, "tasks:reordered", groupID)
+	return nil
 }
 
 // UpsertGroup 新增或更新一个分组：
-// - id==0 表示新增
-// - id>0 表示按 ID 更新名称
-func (a *App) UpsertGroup(id int64, name string) (todo.Group, error) {
+// - group.ID==0 表示新增
+// - group.ID>0 表示按 ID 更新名称、颜色、图标与上级分组
+// 新增与修改都可以通过 UndoLast 撤销。
+func (a *App) UpsertGroup(group todo.Group) (todo.Group, error) {
 	if err := a.ensureStoreReady(); err != nil {
 		return todo.Group{}, err
 	}
-	return a.store.UpsertGroup(a.ctx, id, name)
+	var before todo.Group
+	if group.ID > 0 {
+		if g, err := a.store.GetGroup(a.ctx, group.ID); err == nil {
+			before = g
+		}
+	}
+	g, err := a.store.UpsertGroup(a.ctx, group)
+	if err != nil {
+		return todo.Group{}, a.localize(err)
+	}
+	switch {
+	case group.ID == 0:
+		a.recordCreated(a.tr("history.createGroup", g.Name), todo.TrashKindGroup, g.ID,
+			func() error { return a.store.DeleteGroup(a.ctx, g.ID) })
+	case before.ID > 0 && (before.Name != g.Name || before.ParentID != g.ParentID || before.Color != g.Color || before.Icon != g.Icon):
+		a.pushHistory(historyEntry{
+			label: a.tr("history.updateGroup", g.Name),
+			undo:  func() error { _, err := a.store.UpsertGroup(a.ctx, before); return err },
+			redo:  func() error { _, err := a.store.UpsertGroup(a.ctx, g); return err },
+		})
+	}
+	return g, nil
+}
+
+// ReorderGroups 按拖动后的顺序重排侧边栏中的分组（见 Store.ReorderGroups），返回重排后的分组列表。
+func (a *App) ReorderGroups(orderedIDs []int64) ([]todo.Group, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return nil, err
+	}
+	groups, err := a.store.ReorderGroups(a.ctx, orderedIDs)
+	return groups, a.localize(err)
 }
 
-// DeleteGroup 删除分组（以及外键级联删除其下任务）。
+// ListGroupTree 返回分组的层级结构（见 Store.ListGroupTree），供侧边栏渲染可折叠的分组。
+func (a *App) ListGroupTree() ([]todo.GroupNode, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return nil, err
+	}
+	tree, err := a.store.ListGroupTree(a.ctx)
+	return tree, a.localize(err)
+}
+
+// ArchiveGroup 归档（archived 为 true）或取消归档分组。
+// 归档的分组及其任务不再出现在看板中，但不会被删除，可在归档页中查看与恢复。
+func (a *App) ArchiveGroup(id int64, archived bool) (todo.Group, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.Group{}, err
+	}
+	g, err := a.store.SetGroupArchived(a.ctx, id, archived)
+	return g, a.localize(err)
+}
+
+// FavoriteGroup 标记（favorite 为 true）或取消标记常用分组。
+// 常用分组在分组列表中排在最前，并作为快速添加的默认分组。
+func (a *App) FavoriteGroup(id int64, favorite bool) (todo.Group, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.Group{}, err
+	}
+	g, err := a.store.SetGroupFavorite(a.ctx, id, favorite)
+	return g, a.localize(err)
+}
+
+// GetArchive 返回已归档的分组及其任务（按当前视图的排序方式）。
+func (a *App) GetArchive() (todo.Archive, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.Archive{}, err
+	}
+
+	settings, err := a.store.GetSettings(a.ctx)
+	if err != nil {
+		return todo.Archive{}, a.localize(err)
+	}
+	archive, err := a.store.ListArchive(a.ctx, settings.ViewSorts[settings.ViewMode])
+	return archive, a.localize(err)
+}
+
+// DeleteGroup 删除分组（以及外键级联删除其下任务），删除的内容进入回收站，可通过 UndoLast 撤销。
 func (a *App) DeleteGroup(id int64) error {
 	if err := a.ensureStoreReady(); err != nil {
 		return err
 	}
-	return a.store.DeleteGroup(a.ctx, id)
+	remove := func() error { return a.store.DeleteGroup(a.ctx, id) }
+	if err := remove(); err != nil {
+		return a.localize(err)
+	}
+	a.recordDeleted("history.deleteGroup", todo.TrashKindGroup, id, remove)
+	return nil
 }
 
-// UpsertTask 新增或更新任务。
+// UpsertTask 新增或更新任务，可通过 UndoLast 撤销。
 func (a *App) UpsertTask(task todo.Task) (todo.Task, error) {
 	if err := a.ensureStoreReady(); err != nil {
 		return todo.Task{}, err
 	}
-	return a.store.UpsertTask(a.ctx, task)
+	var before *todo.Task
+	if task.ID > 0 {
+		if snapshot, err := a.taskSnapshot(task.ID); err == nil {
+			before = &snapshot
+		}
+	}
+	t, err := a.store.UpsertTask(a.ctx, task)
+	if err == nil {
+		a.recordTaskVisit(t.ID, todo.RecentEdited)
+		if task.ID == 0 || before != nil {
+			a.recordTaskUpsert(before, t)
+		}
+		if t.Status == todo.StatusDone {
+			a.awardCompletion(t)
+		}
+	}
+	return t, a.localize(err)
 }
 
-// DeleteTask 删除任务。
+// DeleteTask 删除任务（连同子任务），删除的内容进入回收站，可通过 UndoLast 撤销。
 func (a *App) DeleteTask(id int64) error {
 	if err := a.ensureStoreReady(); err != nil {
 		return err
 	}
-	return a.store.DeleteTask(a.ctx, id)
+	remove := func() error { return a.store.DeleteTask(a.ctx, id) }
+	if err := remove(); err != nil {
+		return a.localize(err)
+	}
+	a.recordDeleted("history.deleteTask", todo.TrashKindTask, id, remove)
+	return nil
 }
 
 // SetHideDone 更新“隐藏已完成”开关，并返回更新后的 Settings（便于前端就地更新 UI）。
@@ -178,18 +558,18 @@ func (a *App) SetHideDone(hide bool) (todo.Settings, error) {
 
 	settings, err := a.store.GetSettings(a.ctx)
 	if err != nil {
-		return todo.Settings{}, err
+		return todo.Settings{}, a.localize(err)
 	}
 	settings.HideDone = hide
 	if err := a.store.SetSettings(a.ctx, settings); err != nil {
-		return todo.Settings{}, err
+		return todo.Settings{}, a.localize(err)
 	}
-	return settings, nil
+	return a.reloadSettings()
 }
 
 // SetAlwaysOnTop 更新“置顶悬浮”开关：
 // - 持久化到 settings 表
-// - 立即调用 runtime.WindowSetAlwaysOnTop 让窗口生效
+// - 经 reloadSettings 的窗口订阅者立即调用 runtime.WindowSetAlwaysOnTop 让窗口生效
 func (a *App) SetAlwaysOnTop(on bool) (todo.Settings, error) {
 	if err := a.ensureStoreReady(); err != nil {
 		return todo.Settings{}, err
@@ -197,14 +577,13 @@ func (a *App) SetAlwaysOnTop(on bool) (todo.Settings, error) {
 
 	settings, err := a.store.GetSettings(a.ctx)
 	if err != nil {
-		return todo.Settings{}, err
+		return todo.Settings{}, a.localize(err)
 	}
 	settings.AlwaysOnTop = on
 	if err := a.store.SetSettings(a.ctx, settings); err != nil {
-		return todo.Settings{}, err
+		return todo.Settings{}, a.localize(err)
 	}
-	runtime.WindowSetAlwaysOnTop(a.ctx, on)
-	return settings, nil
+	return a.reloadSettings()
 }
 
 // SetViewMode 更新视图模式（"list" 或 "cards"）。
@@ -215,16 +594,18 @@ func (a *App) SetViewMode(mode string) (todo.Settings, error) {
 
 	settings, err := a.store.GetSettings(a.ctx)
 	if err != nil {
-		return todo.Settings{}, err
+		return todo.Settings{}, a.localize(err)
 	}
 	settings.ViewMode = mode
 	if err := a.store.SetSettings(a.ctx, settings); err != nil {
-		return todo.Settings{}, err
+		return todo.Settings{}, a.localize(err)
 	}
-	return settings, nil
+	return a.reloadSettings()
 }
 
-// SetTheme 更新主题（"light" 或 "dark"）。
+// SetTheme 更新主题（"light" / "dark" / "system"）。
+//
+// "system" 表示跟随系统外观；切换后发出 theme:changed 事件告知实际应使用的主题。
 func (a *App) SetTheme(theme string) (todo.Settings, error) {
 	if err := a.ensureStoreReady(); err != nil {
 		return todo.Settings{}, err
@@ -232,12 +613,231 @@ func (a *App) SetTheme(theme string) (todo.Settings, error) {
 
 	settings, err := a.store.GetSettings(a.ctx)
 	if err != nil {
-		return todo.Settings{}, err
+		return todo.Settings{}, a.localize(err)
 	}
 	settings.Theme = theme
 	if err := a.store.SetSettings(a.ctx, settings); err != nil {
+		return todo.Settings{}, a.localize(err)
+	}
+	return a.reloadSettings()
+}
+
+// SetAccessibility 更新高对比度与减少动画设置。
+//
+// 两者取值均为 "on" | "off" | "system"（"system" 跟随操作系统的辅助功能设置）。
+// 更新后广播 theme:changed，让前端立即应用实际生效的外观。
+func (a *App) SetAccessibility(highContrast string, reducedMotion string) (todo.Settings, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.Settings{}, err
+	}
+
+	settings, err := a.store.GetSettings(a.ctx)
+	if err != nil {
+		return todo.Settings{}, a.localize(err)
+	}
+	settings.HighContrast = highContrast
+	settings.ReducedMotion = reducedMotion
+	if err := a.store.SetSettings(a.ctx, settings); err != nil {
+		return todo.Settings{}, a.localize(err)
+	}
+	return a.reloadSettings()
+}
+
+// SetLanguage 更新界面语言（"zh-CN" 或 "en-US"）。
+//
+// 后端产生的文案（错误提示、提醒弹窗等）随即切换为新语言（见 settingsSubscribers）。
+func (a *App) SetLanguage(lang string) (todo.Settings, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.Settings{}, err
+	}
+
+	settings, err := a.store.GetSettings(a.ctx)
+	if err != nil {
+		return todo.Settings{}, a.localize(err)
+	}
+	settings.Language = i18n.Normalize(lang)
+	if err := a.store.SetSettings(a.ctx, settings); err != nil {
+		return todo.Settings{}, a.localize(err)
+	}
+	return a.reloadSettings()
+}
+
+// SetDateFormat 更新日期格式（"iso" 或 "locale"）。
+func (a *App) SetDateFormat(format string) (todo.Settings, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.Settings{}, err
+	}
+
+	settings, err := a.store.GetSettings(a.ctx)
+	if err != nil {
+		return todo.Settings{}, a.localize(err)
+	}
+	settings.DateFormat = format
+	if err := a.store.SetSettings(a.ctx, settings); err != nil {
+		return todo.Settings{}, a.localize(err)
+	}
+	return a.reloadSettings()
+}
+
+// SetWeekStart 更新每周第一天（"monday" 或 "sunday"）。
+func (a *App) SetWeekStart(weekStart string) (todo.Settings, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.Settings{}, err
+	}
+
+	settings, err := a.store.GetSettings(a.ctx)
+	if err != nil {
+		return todo.Settings{}, a.localize(err)
+	}
+	settings.WeekStart = weekStart
+	if err := a.store.SetSettings(a.ctx, settings); err != nil {
+		return todo.Settings{}, a.localize(err)
+	}
+	return a.reloadSettings()
+}
+
+// TimezoneInfo 描述当前生效的时区。
+type TimezoneInfo struct {
+	Setting   string `json:"setting"`   // 用户设置（为空表示跟随系统）
+	Effective string `json:"effective"` // 实际生效的时区名
+	System    string `json:"system"`    // 系统时区名
+	Offset    int    `json:"offset"`    // 当前生效时区相对 UTC 的偏移（分钟）
+}
+
+// SetTimezone 更新时区设置（IANA 时区名，如 "Asia/Shanghai"；空字符串表示跟随系统）。
+func (a *App) SetTimezone(tz string) (todo.Settings, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.Settings{}, err
+	}
+
+	settings, err := a.store.GetSettings(a.ctx)
+	if err != nil {
+		return todo.Settings{}, a.localize(err)
+	}
+	settings.Timezone = tz
+	if err := a.store.SetSettings(a.ctx, settings); err != nil {
+		return todo.Settings{}, a.localize(err)
+	}
+	return a.reloadSettings()
+}
+
+// GetTimezone 返回用户设置、实际生效与系统时区，供设置页展示。
+func (a *App) GetTimezone() (TimezoneInfo, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return TimezoneInfo{}, err
+	}
+
+	settings, err := a.store.GetSettings(a.ctx)
+	if err != nil {
+		return TimezoneInfo{}, a.localize(err)
+	}
+	// time.Local 的名字固定为 "Local"，系统时区改用当前时区缩写（如 CST）展示
+	now := time.Now()
+	system, _ := now.Zone()
+	effective, offset := now.In(todo.Location(settings)).Zone()
+	if settings.Timezone != "" {
+		effective = settings.Timezone
+	}
+	return TimezoneInfo{
+		Setting:   settings.Timezone,
+		Effective: effective,
+		System:    system,
+		Offset:    offset / 60,
+	}, nil
+}
+
+// SetUIScale 更新界面缩放（"small" / "normal" / "large"）。
+//
+// 通过 settings:changed 事件通知所有窗口，使无障碍偏好立即在各处生效。
+func (a *App) SetUIScale(scale string) (todo.Settings, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.Settings{}, err
+	}
+
+	settings, err := a.store.GetSettings(a.ctx)
+	if err != nil {
+		return todo.Settings{}, a.localize(err)
+	}
+	settings.UIScale = scale
+	if err := a.store.SetSettings(a.ctx, settings); err != nil {
+		return todo.Settings{}, a.localize(err)
+	}
+	return a.reloadSettings()
+}
+
+// SetViewSort 设置并记住某个视图的排序方式：
+// - view："list" | "cards" | "quadrants" | "swimlanes"
+// - mode："updated" | "created" | "due" | "title" | "manual" | "quadrant"
+// 列表/卡片视图的排序在 GetBoard 中按当前 viewMode 生效，四象限、泳道视图的排序分别在 GetQuadrants、GetSwimlanes 中生效。
+func (a *App) SetViewSort(view string, mode string) (todo.Settings, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.Settings{}, err
+	}
+
+	settings, err := a.store.GetSettings(a.ctx)
+	if err != nil {
+		return todo.Settings{}, a.localize(err)
+	}
+	if settings.ViewSorts == nil {
+		settings.ViewSorts = map[string]string{}
+	}
+	settings.ViewSorts[view] = mode
+	if err := a.store.SetSettings(a.ctx, settings); err != nil {
+		return todo.Settings{}, a.localize(err)
+	}
+	return a.reloadSettings()
+}
+
+// SetViewFilter 记住某个视图当前的筛选条件（分组、状态、标签、搜索文本），
+// 下次打开窗口时通过 GetBoard 的 filter / settings.viewFilters 恢复。
+func (a *App) SetViewFilter(view string, filter todo.ViewFilter) (todo.Settings, error) {
+	if err := a.ensureStoreReady(); err != nil {
 		return todo.Settings{}, err
 	}
+
+	settings, err := a.store.GetSettings(a.ctx)
+	if err != nil {
+		return todo.Settings{}, a.localize(err)
+	}
+	if settings.ViewFilters == nil {
+		settings.ViewFilters = map[string]todo.ViewFilter{}
+	}
+	settings.ViewFilters[view] = filter
+	if err := a.store.SetSettings(a.ctx, settings); err != nil {
+		return todo.Settings{}, a.localize(err)
+	}
+	return a.reloadSettings()
+}
+
+// SetAccent 更新配色预设与自定义强调色：
+// - preset：预设名（见 Board.themePresets）
+// - color：自定义强调色（#rgb / #rrggbb）；为空表示使用预设颜色
+func (a *App) SetAccent(preset string, color string) (todo.Settings, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.Settings{}, err
+	}
+
+	settings, err := a.store.GetSettings(a.ctx)
+	if err != nil {
+		return todo.Settings{}, a.localize(err)
+	}
+	settings.ThemePreset = preset
+	settings.AccentColor = color
+	if err := a.store.SetSettings(a.ctx, settings); err != nil {
+		return todo.Settings{}, a.localize(err)
+	}
+	return a.reloadSettings()
+}
+
+// reloadSettings 重新读取落库后的设置（枚举值已规范化）用于返回给前端，
+// 并经 applySettings 让各子系统按新设置重新配置、广播 settings:changed 事件。
+// 所有修改设置的接口保存后都应调用它，设置才能在运行时生效。
+func (a *App) reloadSettings() (todo.Settings, error) {
+	settings, err := a.store.GetSettings(a.ctx)
+	if err != nil {
+		return todo.Settings{}, a.localize(err)
+	}
+	a.applySettings(settings, false)
 	return settings, nil
 }
 
@@ -245,7 +845,7 @@ func (a *App) SetTheme(theme string) (todo.Settings, error) {
 // - 持久化到 settings 表
 // - 简洁模式控制窗口是否显示边框（Frameless 属性）
 // 注意：Wails 的 Frameless 属性在窗口创建时设置，运行时无法动态修改。
-// 此方法仅保存设置，实际边框切换需要重启应用才能生效。
+// 这是唯一需要重启才能生效的设置：此方法保存并广播设置，实际边框切换在下次启动时生效。
 func (a *App) SetConciseMode(on bool) (todo.Settings, error) {
 	if err := a.ensureStoreReady(); err != nil {
 		return todo.Settings{}, err
@@ -253,12 +853,50 @@ func (a *App) SetConciseMode(on bool) (todo.Settings, error) {
 
 	settings, err := a.store.GetSettings(a.ctx)
 	if err != nil {
-		return todo.Settings{}, err
+		return todo.Settings{}, a.localize(err)
 	}
 	settings.ConciseMode = on
 	if err := a.store.SetSettings(a.ctx, settings); err != nil {
+		return todo.Settings{}, a.localize(err)
+	}
+	return a.reloadSettings()
+}
+
+// SetUpdateURL 更新自定义更新源地址：
+// - 空字符串恢复为内置默认地址
+// - 非法地址直接返回错误，不会落库
+// - 保存成功后更新订阅者立即替换更新检查器，下一次检查即生效
+func (a *App) SetUpdateURL(updateURL string) (todo.Settings, error) {
+	if err := a.ensureStoreReady(); err != nil {
 		return todo.Settings{}, err
 	}
+
+	settings, err := a.store.GetSettings(a.ctx)
+	if err != nil {
+		return todo.Settings{}, a.localize(err)
+	}
+	settings.UpdateURL = updateURL
+	if err := a.store.SetSettings(a.ctx, settings); err != nil {
+		return todo.Settings{}, a.localize(err)
+	}
+	return a.reloadSettings()
+}
+
+// ResetSettings 将设置恢复为默认值：
+// - scope 为 "all"（或空）时恢复全部设置
+// - 也可只恢复某一类："window" / "appearance" / "reminders" / "update" / "hotkeys" / "data" / "api"
+// 恢复后经 applySettings 立即应用到各子系统（置顶、语言、更新源、快捷键等）。
+// 注意：简洁模式（窗口边框）仍需重启应用才能生效。
+func (a *App) ResetSettings(scope string) (todo.Settings, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.Settings{}, err
+	}
+
+	settings, err := a.store.ResetSettings(a.ctx, scope)
+	if err != nil {
+		return todo.Settings{}, a.localize(err)
+	}
+	a.applySettings(settings, false)
 	return settings, nil
 }
 
@@ -272,13 +910,13 @@ func (a *App) Quit() {
 // Restart 重启应用程序。
 func (a *App) Restart() error {
 	if a.ctx == nil {
-		return errors.New("应用尚未初始化完成")
+		return errors.New(a.tr("app.notReady"))
 	}
 
 	// 获取当前可执行文件路径
 	executable, err := os.Executable()
 	if err != nil {
-		return fmt.Errorf("获取可执行文件路径失败: %w", err)
+		return a.wrapErr("app.executableFailed", err)
 	}
 
 	// 在后台启动新进程
@@ -287,7 +925,7 @@ func (a *App) Restart() error {
 	cmd.Stderr = os.Stderr
 
 	if err := cmd.Start(); err != nil {
-		return fmt.Errorf("启动新进程失败: %w", err)
+		return a.wrapErr("app.restartFailed", err)
 	}
 
 	// 延迟退出当前进程，给新进程一点启动时间
@@ -304,7 +942,7 @@ func (a *App) Restart() error {
 // 该提醒应出现在"电脑屏幕中间"，与 todoP1 面板位置无关，因此由后端调用系统级弹窗实现。
 func (a *App) ShowWaterReminder() error {
 	if a.ctx == nil {
-		return errors.New("应用尚未初始化完成")
+		return errors.New(a.tr("app.notReady"))
 	}
 
 	if !a.waterReminderShowing.CompareAndSwap(false, true) {
@@ -313,59 +951,377 @@ func (a *App) ShowWaterReminder() error {
 	defer a.waterReminderShowing.Store(false)
 
 	// 记录“上一次提醒时间”，避免用户短时间内反复打开应用导致重复弹窗。
-	// 规则：若距离上次提醒未满 1 小时，则本次不打扰。
+	// 规则：用户选择了“稍后提醒”时，到推迟的时间才提醒；否则若距离上次提醒未满 1 小时，则本次不打扰。
+	var snoozeUntil int64
 	if a.store != nil {
-		lastAt, err := a.store.GetLastWaterReminderAt(a.ctx)
-		if err != nil {
+		var err error
+		if snoozeUntil, err = a.store.GetWaterSnoozeUntil(a.ctx); err != nil {
+			runtime.LogErrorf(a.ctx, "failed to read water reminder snooze: %v", err)
+		}
+		if snoozeUntil > 0 {
+			if time.Now().UnixMilli() < snoozeUntil {
+				return nil
+			}
+		} else if lastAt, err := a.store.GetLastWaterReminderAt(a.ctx); err != nil {
 			runtime.LogErrorf(a.ctx, "failed to read last water reminder time: %v", err)
 		} else if lastAt > 0 && time.Since(time.UnixMilli(lastAt)) < time.Hour {
 			return nil
 		}
 	}
 
-	if err := showWaterReminderSystemCentered(a.ctx, "喝水提醒", "喝水小提醒：该喝水了"); err != nil {
+	if err := showSystemMessage(a.ctx, a.tr("water.title"), a.tr("water.message")); err != nil {
 		return err
 	}
+	a.notify(a.ctx, todo.Notification{Kind: todo.NotificationWater, Title: a.tr("water.title"), Body: a.tr("water.message")})
 
 	if a.store != nil {
 		if err := a.store.SetLastWaterReminderAt(a.ctx, time.Now().UnixMilli()); err != nil {
 			// 持久化失败不影响本次提醒展示，避免前端降级为 Toast（会影响体验）。
 			runtime.LogErrorf(a.ctx, "failed to persist last water reminder time: %v", err)
 		}
+		if snoozeUntil > 0 {
+			if err := a.store.ClearWaterSnooze(a.ctx); err != nil {
+				runtime.LogErrorf(a.ctx, "failed to clear water reminder snooze: %v", err)
+			}
+		}
 	}
 
 	return nil
 }
 
+// SnoozeWaterReminder 将"喝水提醒"推迟 minutes 分钟：到点由后端重新弹出（不受 1 小时间隔限制），
+// 推迟时间会保存，重启应用后仍然有效。返回下一次提醒时间（UnixMilli）。
+func (a *App) SnoozeWaterReminder(minutes int) (int64, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return 0, err
+	}
+	until, err := a.store.SnoozeWaterReminder(a.ctx, time.Now(), minutes)
+	if err != nil {
+		return 0, a.localize(err)
+	}
+	a.scheduleWaterReminder(until)
+	return until, nil
+}
+
+// restoreWaterSnooze 在启动时恢复尚未到点的"喝水提醒"推迟。
+func (a *App) restoreWaterSnooze() {
+	until, err := a.store.GetWaterSnoozeUntil(a.ctx)
+	if err != nil {
+		runtime.LogErrorf(a.ctx, "failed to read water reminder snooze: %v", err)
+		return
+	}
+	if until > 0 {
+		a.scheduleWaterReminder(until)
+	}
+}
+
+// scheduleWaterReminder 安排在 until（UnixMilli）重新弹出"喝水提醒"，替换之前的安排；until 为 0 时只取消。
+func (a *App) scheduleWaterReminder(until int64) {
+	a.waterSnoozeMu.Lock()
+	defer a.waterSnoozeMu.Unlock()
+	if a.waterSnoozeTimer != nil {
+		a.waterSnoozeTimer.Stop()
+		a.waterSnoozeTimer = nil
+	}
+	if until <= 0 {
+		return
+	}
+	a.waterSnoozeTimer = time.AfterFunc(time.Until(time.UnixMilli(until)), func() {
+		if err := a.ShowWaterReminder(); err != nil {
+			runtime.LogErrorf(a.ctx, "failed to show snoozed water reminder: %v", err)
+		}
+	})
+}
+
 // GetVersion 获取当前应用版本
 func (a *App) GetVersion() string {
 	return version.Version
 }
 
+// GetBuildInfo 获取当前构建信息（版本、提交、构建时间等），用于问题反馈
+func (a *App) GetBuildInfo() version.BuildInfo {
+	return version.GetBuildInfo()
+}
+
 // CheckUpdate 检查更新
 func (a *App) CheckUpdate() (*version.UpdateCheckResult, error) {
 	if a.ctx == nil {
-		return nil, errors.New("应用尚未初始化完成")
+		return nil, errors.New(a.tr("app.notReady"))
 	}
 
 	// 创建带超时的上下文
 	ctx, cancel := context.WithTimeout(a.ctx, 15*time.Second)
 	defer cancel()
 
-	result, err := a.updateChecker.CheckUpdate(ctx)
+	result, err := a.updateChecker.Load().CheckUpdate(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("检查更新失败: %w", err)
+		return nil, a.wrapErr("update.checkFailed", err)
+	}
+	if result.HasUpdate && result.LatestRelease != nil {
+		// 同一个新版本只进入通知中心一次
+		v := result.LatestRelease.Version
+		a.notify(a.ctx, todo.Notification{
+			Kind:      todo.NotificationUpdate,
+			Title:     a.tr("notify.updateTitle"),
+			Body:      a.tr("notify.updateBody", v),
+			Data:      map[string]any{"version": v},
+			DedupeKey: "update:" + v,
+		})
 	}
 
 	return result, nil
 }
 
+// GetChangelog 返回 sinceVersion 之后（不含）到最新版本的全部发布说明，按版本号降序。
+//
+// sinceVersion 为空时使用当前版本；升级后传入旧版本号即可查看跨越的所有版本说明。
+func (a *App) GetChangelog(sinceVersion string) ([]version.ReleaseInfo, error) {
+	if a.ctx == nil {
+		return nil, errors.New(a.tr("app.notReady"))
+	}
+
+	ctx, cancel := context.WithTimeout(a.ctx, 15*time.Second)
+	defer cancel()
+
+	releases, err := a.updateChecker.Load().GetChangelog(ctx, sinceVersion)
+	if err != nil {
+		return nil, a.wrapErr("update.changelogFailed", err)
+	}
+	return releases, nil
+}
+
+// InstallUpdate 下载并安装最新版本。
+//
+// 下载过程中通过 `update:progress` 事件推送进度（version.DownloadProgress），
+// 可调用 CancelUpdate 中途取消。
+// 安装包在运行前必须通过 SHA256SUMS（以及配置了公钥时的签名）校验，
+// 校验失败直接返回错误，不会执行任何下载内容。
+// 安装程序启动后当前进程退出，由安装程序完成覆盖安装。
+func (a *App) InstallUpdate() error {
+	if a.ctx == nil {
+		return errors.New(a.tr("app.notReady"))
+	}
+
+	checker := a.updateChecker.Load()
+	checkCtx, cancel := context.WithTimeout(a.ctx, 15*time.Second)
+	result, err := checker.CheckUpdate(checkCtx)
+	cancel()
+	if err != nil {
+		return a.wrapErr("update.checkFailed", err)
+	}
+	if !result.HasUpdate || result.LatestRelease == nil {
+		return errors.New(a.tr("update.upToDate"))
+	}
+
+	dlCtx, dlCancel := context.WithCancel(a.ctx)
+	a.updateMu.Lock()
+	if a.updateCancel != nil {
+		a.updateMu.Unlock()
+		dlCancel()
+		return errors.New(a.tr("update.inProgress"))
+	}
+	a.updateCancel = dlCancel
+	a.updateMu.Unlock()
+	defer func() {
+		a.updateMu.Lock()
+		a.updateCancel = nil
+		a.updateMu.Unlock()
+		dlCancel()
+	}()
+
+	destDir := filepath.Join(os.TempDir(), "Spark-Todo-update")
+	installer, err := checker.DownloadUpdate(dlCtx, result.LatestRelease, destDir, func(p version.DownloadProgress) {
+		runtime.EventsEmit(a.ctx, "update:progress", p)
+	})
+	if err != nil {
+		if errors.Is(dlCtx.Err(), context.Canceled) {
+			return errors.New(a.tr("update.canceled"))
+		}
+		runtime.LogErrorf(a.ctx, "failed to download update: %v", err)
+		return a.wrapErr("update.downloadFailed", err)
+	}
+
+	// 新版本可能带有数据库迁移：安装前先快照 todo.db（含设置），迁移出错时可手动恢复。
+	// 备份失败则放弃本次更新，避免在没有退路的情况下升级。
+	snapshot, err := a.backupBeforeUpdate(result.LatestRelease.Version)
+	if err != nil {
+		runtime.LogErrorf(a.ctx, "failed to back up database before update: %v", err)
+		return a.wrapErr("update.backupFailed", err)
+	}
+
+	// 保留当前版本的可执行文件，并记下数据库快照，便于新版本出问题时 RollbackUpdate。
+	// 备份失败不阻塞更新，只记录日志。
+	if a.dataDir != "" {
+		if _, err := version.SaveRollback(a.rollbackDir(), snapshot); err != nil {
+			runtime.LogErrorf(a.ctx, "failed to save rollback copy: %v", err)
+		}
+	}
+
+	cmd := exec.Command(installer)
+	if err := cmd.Start(); err != nil {
+		return a.wrapErr("update.installerFailed", err)
+	}
+
+	go func() {
+		time.Sleep(500 * time.Millisecond)
+		runtime.Quit(a.ctx)
+	}()
+	return nil
+}
+
+// GetRollbackInfo 返回可回滚到的上一版本信息；没有备份时返回 nil。
+func (a *App) GetRollbackInfo() (*version.RollbackInfo, error) {
+	if a.dataDir == "" {
+		return nil, errors.New(a.tr("app.notReady"))
+	}
+	info, err := version.LoadRollback(a.rollbackDir())
+	if err != nil {
+		return nil, a.wrapErr("update.rollbackInfo", err)
+	}
+	return info, nil
+}
+
+// RollbackUpdate 回滚到上一次自动更新前的版本，并重启应用。
+//
+// 新版本可能已迁移数据库，因此同时恢复 InstallUpdate 时拍下的数据库快照（见 version.Rollback）：
+// 更新后新增或修改的数据会丢失，但恢复前会先把当前数据库备份到备份目录，可从中找回。
+// 没有记录快照（例如由更早的版本安装的更新）时拒绝回滚。
+func (a *App) RollbackUpdate() error {
+	if a.ctx == nil || a.dataDir == "" {
+		return errors.New(a.tr("app.notReady"))
+	}
+	if err := a.ensureStoreReady(); err != nil {
+		return err
+	}
+	if a.store.ReadOnly() {
+		return a.localize(todo.ErrReadOnly)
+	}
+
+	exe, err := version.Rollback(a.rollbackDir(), func(path string) error {
+		name := todo.BackupFileName("pre-rollback", time.Now())
+		if err := a.store.Backup(a.ctx, filepath.Join(a.backupDir(), name)); err != nil {
+			return err
+		}
+		// 停止后台任务，避免新版本在退出前再写入已恢复的数据库
+		if a.bgCancel != nil {
+			a.bgCancel()
+		}
+		return a.store.RestoreSnapshot(a.ctx, path)
+	})
+	if err != nil {
+		runtime.LogErrorf(a.ctx, "failed to roll back: %v", err)
+		return a.wrapErr("update.rollbackFailed", err)
+	}
+
+	cmd := exec.Command(exe)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		return a.wrapErr("update.rollbackStart", err)
+	}
+
+	go func() {
+		time.Sleep(500 * time.Millisecond)
+		runtime.Quit(a.ctx)
+	}()
+	return nil
+}
+
+// backupBeforeUpdate 在安装更新前把数据库快照到备份目录，返回快照路径。
+func (a *App) backupBeforeUpdate(newVersion string) (string, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return "", err
+	}
+	path := filepath.Join(a.backupDir(), todo.BackupFileName("pre-update-"+newVersion, time.Now()))
+	if err := a.store.Backup(a.ctx, path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// backupDir 返回数据库备份目录。
+func (a *App) backupDir() string {
+	return filepath.Join(a.dataDir, "backups")
+}
+
+// rollbackDir 返回回滚备份目录。
+func (a *App) rollbackDir() string {
+	return filepath.Join(a.dataDir, "rollback")
+}
+
+// CancelUpdate 取消进行中的更新下载；没有下载时为空操作。
+func (a *App) CancelUpdate() {
+	a.updateMu.Lock()
+	defer a.updateMu.Unlock()
+	if a.updateCancel != nil {
+		a.updateCancel()
+	}
+}
+
 // OpenURL 在浏览器中打开 URL
 func (a *App) OpenURL(url string) error {
 	if a.ctx == nil {
-		return errors.New("应用尚未初始化完成")
+		return errors.New(a.tr("app.notReady"))
 	}
 
 	runtime.BrowserOpenURL(a.ctx, url)
 	return nil
 }
+
+// OpenTaskLink 在浏览器中打开任务的关联链接。
+// 链接取自数据库（保存时已校验为 http/https），前端无需传入任意字符串。
+func (a *App) OpenTaskLink(taskID int64) error {
+	if err := a.ensureStoreReady(); err != nil {
+		return err
+	}
+	link, err := a.store.TaskLink(a.ctx, taskID)
+	if err != nil {
+		return a.localize(err)
+	}
+	runtime.BrowserOpenURL(a.ctx, link)
+	return nil
+}
+
+// GetTaskContent 返回任务的完整内容。
+// 看板中的长内容只包含预览（Task.ContentTruncated 为 true），打开编辑前需先加载完整内容。
+func (a *App) GetTaskContent(id int64) (string, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return "", err
+	}
+	content, err := a.store.GetTaskContent(a.ctx, id)
+	return content, a.localize(err)
+}
+
+// RenderContent 将编辑中的内容按 format（"plain" | "markdown"）渲染为安全的 HTML，用于编辑器预览。
+func (a *App) RenderContent(content string, format string) (string, error) {
+	format, err := todo.ParseContentFormat(format)
+	if err != nil {
+		return "", a.localize(err)
+	}
+	return todo.RenderContent(content, format), nil
+}
+
+// GetTaskDetail 返回任务详情：完整内容、子任务、从内容中提取的链接、标签与附件；并记入"最近访问"。
+func (a *App) GetTaskDetail(id int64) (todo.TaskDetail, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.TaskDetail{}, err
+	}
+	d, err := a.store.GetTaskDetail(a.ctx, id)
+	if err == nil {
+		a.recordTaskVisit(id, todo.RecentViewed)
+	}
+	return d, a.localize(err)
+}
+
+// OpenContentLink 在浏览器中打开任务内容中的链接；link 必须是 GetTaskDetail 返回的链接之一。
+func (a *App) OpenContentLink(taskID int64, link string) error {
+	if err := a.ensureStoreReady(); err != nil {
+		return err
+	}
+	link, err := a.store.ContentLink(a.ctx, taskID, link)
+	if err != nil {
+		return a.localize(err)
+	}
+	runtime.BrowserOpenURL(a.ctx, link)
+	return nil
+}
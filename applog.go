@@ -0,0 +1,56 @@
+package main
+
+import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/wailsapp/wails/v2/pkg/logger"
+)
+
+// 日志文件放在数据目录 logs/ 下（诊断包会收集其中的 *.log）。
+// 超过 maxLogFileBytes 时在启动时轮转为 .old.log，只保留一份旧日志。
+const (
+	logFileName     = "spark-todo.log"
+	oldLogFileName  = "spark-todo.old.log"
+	maxLogFileBytes = 1 << 20
+)
+
+// appLogger 将 Wails 运行时日志写入日志文件（同时保留标准输出，便于开发时查看）。
+type appLogger struct {
+	l *log.Logger
+}
+
+// newAppLogger 打开数据目录 dataDir 下的日志文件，并让标准库 log（本地服务器等使用）也写入其中。
+// 打开失败时返回 Wails 默认的控制台日志。
+func newAppLogger(dataDir string) logger.Logger {
+	f, err := openLogFile(filepath.Join(dataDir, "logs"))
+	if err != nil {
+		log.Printf("open log file: %v", err)
+		return logger.NewDefaultLogger()
+	}
+	w := io.MultiWriter(os.Stdout, f)
+	log.SetOutput(w)
+	return &appLogger{l: log.New(w, "", log.LstdFlags)}
+}
+
+// openLogFile 在 dir 下以追加方式打开日志文件，必要时先轮转。
+func openLogFile(dir string) (*os.File, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, logFileName)
+	if info, err := os.Stat(path); err == nil && info.Size() > maxLogFileBytes {
+		_ = os.Rename(path, filepath.Join(dir, oldLogFileName))
+	}
+	return os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+}
+
+func (a *appLogger) Print(message string)   { a.l.Print(message) }
+func (a *appLogger) Trace(message string)   { a.l.Print("TRACE | " + message) }
+func (a *appLogger) Debug(message string)   { a.l.Print("DEBUG | " + message) }
+func (a *appLogger) Info(message string)    { a.l.Print("INFO  | " + message) }
+func (a *appLogger) Warning(message string) { a.l.Print("WARN  | " + message) }
+func (a *appLogger) Error(message string)   { a.l.Print("ERROR | " + message) }
+func (a *appLogger) Fatal(message string)   { a.l.Fatal("FATAL | " + message) }
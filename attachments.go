@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image/png"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"spark-todo/internal/thumb"
+	"spark-todo/internal/todo"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// coverThumbSide 为封面缩略图长边的像素数（卡片视图按 2x 屏幕显示足够清晰）。
+const coverThumbSide = 320
+
+// pngSignature 为 PNG 文件头。
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// errNoClipboardImage 表示剪贴板中没有可用的图片。
+var errNoClipboardImage = errors.New("no image in clipboard")
+
+// AttachClipboardImage 读取系统剪贴板中的图片（如截图），以 PNG 保存到附件目录并关联到任务。
+func (a *App) AttachClipboardImage(taskID int64) (todo.Attachment, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.Attachment{}, err
+	}
+	if _, err := a.store.GetTask(a.ctx, taskID); err != nil {
+		return todo.Attachment{}, a.localize(err)
+	}
+
+	data, err := readClipboardPNG()
+	if errors.Is(err, errNoClipboardImage) {
+		return todo.Attachment{}, errors.New(a.tr("attachment.noClipboardImage"))
+	}
+	if err != nil {
+		return todo.Attachment{}, a.wrapErr("attachment.clipboardFailed", err)
+	}
+	cfg, err := png.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return todo.Attachment{}, a.wrapErr("attachment.clipboardFailed", err)
+	}
+
+	dir := a.attachmentDir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return todo.Attachment{}, a.wrapErr("attachment.saveFailed", err)
+	}
+	name := fmt.Sprintf("task-%d-%s.png", taskID, time.Now().Format("20060102-150405.000"))
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return todo.Attachment{}, a.wrapErr("attachment.saveFailed", err)
+	}
+
+	att, err := a.store.AddAttachment(a.ctx, todo.Attachment{
+		TaskID: taskID,
+		File:   name,
+		Mime:   "image/png",
+		Size:   int64(len(data)),
+		Width:  cfg.Width,
+		Height: cfg.Height,
+	})
+	if err != nil {
+		_ = os.Remove(path)
+		return todo.Attachment{}, a.localize(err)
+	}
+	return att, nil
+}
+
+// GetAttachments 返回任务的全部附件。
+func (a *App) GetAttachments(taskID int64) ([]todo.Attachment, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return nil, err
+	}
+	list, err := a.store.ListAttachments(a.ctx, taskID)
+	return list, a.localize(err)
+}
+
+// DeleteAttachment 删除附件（是封面时一并取消封面），并删除附件目录中的文件与缩略图。
+func (a *App) DeleteAttachment(id int64) error {
+	if err := a.ensureStoreReady(); err != nil {
+		return err
+	}
+	att, err := a.store.DeleteAttachment(a.ctx, id)
+	if err != nil {
+		return a.localize(err)
+	}
+	a.removeAttachmentFiles([]string{att.File})
+	return nil
+}
+
+// removeAttachmentFiles 删除附件目录中的文件及其缓存的缩略图；删除失败只记日志（记录已删除，文件成为孤儿不影响使用）。
+// 文件名来自数据库或回收站快照，只接受不含路径的文件名，避免导入的数据指向附件目录之外。
+func (a *App) removeAttachmentFiles(files []string) {
+	if len(files) == 0 {
+		return
+	}
+	dir := a.attachmentDir()
+	thumbs, _ := os.ReadDir(filepath.Join(dir, "thumbs"))
+	for _, name := range files {
+		if name == "" || name != filepath.Base(name) || name == "." || name == ".." {
+			runtime.LogWarningf(a.ctx, "skip removing attachment with unexpected name %q", name)
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, name)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			runtime.LogWarningf(a.ctx, "failed to remove attachment %s: %v", name, err)
+		}
+		// 缩略图命名见 thumbnail
+		prefix := strings.TrimSuffix(name, filepath.Ext(name)) + "-"
+		for _, t := range thumbs {
+			side, ok := strings.CutPrefix(t.Name(), prefix)
+			if side, ok2 := strings.CutSuffix(side, ".jpg"); ok && ok2 {
+				if _, err := strconv.Atoi(side); err == nil {
+					_ = os.Remove(filepath.Join(dir, "thumbs", t.Name()))
+				}
+			}
+		}
+	}
+}
+
+// SetTaskCover 将任务的某个附件设为封面（attachmentID 为 0 时取消封面），返回更新后的任务。
+func (a *App) SetTaskCover(taskID int64, attachmentID int64) (todo.Task, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.Task{}, err
+	}
+	t, err := a.store.SetTaskCover(a.ctx, taskID, attachmentID)
+	return t, a.localize(err)
+}
+
+// GetCoverThumbnail 返回任务封面缩略图的 data URL（JPEG）；任务没有封面时返回空字符串。
+//
+// 缩略图首次请求时生成并缓存在 attachments/thumbs 目录，卡片视图无需加载原图。
+func (a *App) GetCoverThumbnail(taskID int64) (string, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return "", err
+	}
+	t, err := a.store.GetTask(a.ctx, taskID)
+	if err != nil {
+		return "", a.localize(err)
+	}
+	if t.CoverID == 0 {
+		return "", nil
+	}
+	att, err := a.store.GetAttachment(a.ctx, t.CoverID)
+	if err != nil {
+		return "", a.localize(err)
+	}
+	data, err := a.thumbnail(att)
+	if err != nil {
+		return "", a.wrapErr("attachment.thumbnailFailed", err)
+	}
+	return "data:image/jpeg;base64," + base64.StdEncoding.EncodeToString(data), nil
+}
+
+// thumbnail 返回附件的缩略图：优先读取缓存，缓存缺失或早于原图时重新生成。
+func (a *App) thumbnail(att todo.Attachment) ([]byte, error) {
+	src := filepath.Join(a.attachmentDir(), att.File)
+	cache := filepath.Join(a.attachmentDir(), "thumbs",
+		fmt.Sprintf("%s-%d.jpg", strings.TrimSuffix(att.File, filepath.Ext(att.File)), coverThumbSide))
+
+	srcInfo, err := os.Stat(src)
+	if err != nil {
+		return nil, err
+	}
+	if info, err := os.Stat(cache); err == nil && !info.ModTime().Before(srcInfo.ModTime()) {
+		if data, err := os.ReadFile(cache); err == nil {
+			return data, nil
+		}
+	}
+
+	f, err := os.Open(src)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	data, err := thumb.Make(f, coverThumbSide)
+	if err != nil {
+		return nil, err
+	}
+	// 缓存写入失败不影响本次返回
+	if err := os.MkdirAll(filepath.Dir(cache), 0o755); err == nil {
+		_ = os.WriteFile(cache, data, 0o644)
+	}
+	return data, nil
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"spark-todo/internal/todo"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// 备份文件扩展名：未加密的备份就是 SQLite 数据库文件，加密备份使用单独的扩展名以便区分。
+const (
+	backupExt          = "db"
+	encryptedBackupExt = "sparkbak"
+)
+
+// ExportBackup 导出数据库完整备份，通过"另存为"对话框保存。
+//
+// passphrase 非空时用口令加密备份（AES-256-GCM），放在云盘等位置也不会暴露任务内容；
+// 口令无法找回，忘记口令将无法恢复该备份。附件文件不包含在备份内。
+// 用户取消对话框时返回空字符串且不报错。
+func (a *App) ExportBackup(passphrase string) (string, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return "", err
+	}
+	data, err := a.store.ExportBackup(a.ctx, passphrase)
+	if err != nil {
+		return "", a.wrapErr("backup.failed", a.localize(err))
+	}
+	ext := backupExt
+	if passphrase != "" {
+		ext = encryptedBackupExt
+	}
+	name := fmt.Sprintf("spark-todo-backup-%s.%s", time.Now().Format("20060102-150405"), ext)
+	return a.saveExport(name, ext, data)
+}
+
+// ImportBackup 从备份恢复数据库（替换当前全部任务、分组与设置）。
+//
+// path 为空时弹出文件选择框；选择的是加密备份且 passphrase 为空时返回"需要口令"错误，
+// 错误的附加数据中带有 path，前端询问口令后以该 path 重新调用即可，无需再次选择文件。
+// 恢复前会先把当前数据库备份到备份目录，恢复后发出 backup:restored 事件，前端应重新加载全部数据。
+// 返回恢复所用的备份文件路径；用户取消对话框时返回空字符串且不报错。
+func (a *App) ImportBackup(path string, passphrase string) (string, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return "", err
+	}
+	if a.store.ReadOnly() {
+		return "", a.localize(todo.ErrReadOnly)
+	}
+	if path == "" {
+		var err error
+		path, err = runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+			Title:   a.tr("backup.importTitle"),
+			Filters: []runtime.FileFilter{{DisplayName: "Spark-Todo backup (*.db, *.sparkbak)", Pattern: "*.db;*.sparkbak"}},
+		})
+		if err != nil {
+			return "", a.wrapErr("backup.restoreFailed", err)
+		}
+		if path == "" {
+			return "", nil
+		}
+	}
+
+	if err := a.restoreBackupFile(path, passphrase); err != nil {
+		return "", err
+	}
+	runtime.EventsEmit(a.ctx, "backup:restored", path)
+	return path, nil
+}
+
+// restoreBackupFile 先把当前数据库备份到备份目录，再用 path 恢复，并重新应用恢复后的设置。
+func (a *App) restoreBackupFile(path string, passphrase string) error {
+	name := todo.BackupFileName("pre-restore", time.Now())
+	if err := a.store.Backup(a.ctx, filepath.Join(a.backupDir(), name)); err != nil {
+		return a.wrapErr("backup.safetyFailed", err)
+	}
+	if err := a.store.RestoreBackupFile(a.ctx, path, passphrase); err != nil {
+		return a.localize(err)
+	}
+
+	if settings, err := a.store.GetSettings(a.ctx); err == nil {
+		a.applySettings(settings, true)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"spark-todo/internal/todo"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// BulkUpdateStatus 将多选的任务一次性改为 status，成功后发出一次 "tasks:changed" 事件（参数为更新后的任务列表）。
+func (a *App) BulkUpdateStatus(ids []int64, status todo.Status) ([]todo.Task, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return nil, err
+	}
+	tasks, err := a.store.BulkUpdateStatus(a.ctx, ids, status)
+	if err != nil {
+		return nil, a.localize(err)
+	}
+	runtime.EventsEmit(a.ctx, "tasks:changed", tasks)
+	if status == todo.StatusDone {
+		for _, t := range tasks {
+			a.awardCompletion(t)
+		}
+	}
+	return tasks, nil
+}
+
+// BulkMoveToGroup 将多选的任务（连同子任务）一次性移到分组 groupID，成功后发出一次 "tasks:changed" 事件。
+func (a *App) BulkMoveToGroup(ids []int64, groupID int64) ([]todo.Task, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return nil, err
+	}
+	tasks, err := a.store.BulkMoveToGroup(a.ctx, ids, groupID)
+	if err != nil {
+		return nil, a.localize(err)
+	}
+	runtime.EventsEmit(a.ctx, "tasks:changed", tasks)
+	return tasks, nil
+}
+
+// BulkSetFlags 一次性设置多选任务的"重要/紧急"标记，成功后发出一次 "tasks:changed" 事件。
+func (a *App) BulkSetFlags(ids []int64, important bool, urgent bool) ([]todo.Task, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return nil, err
+	}
+	tasks, err := a.store.BulkSetFlags(a.ctx, ids, important, urgent)
+	if err != nil {
+		return nil, a.localize(err)
+	}
+	runtime.EventsEmit(a.ctx, "tasks:changed", tasks)
+	return tasks, nil
+}
+
+// BulkDelete 一次性删除多选的任务（连同子任务），返回进入回收站的任务数；整批删除可通过一次 UndoLast 撤销。
+func (a *App) BulkDelete(ids []int64) (int, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return 0, err
+	}
+	deleted, err := a.store.BulkDelete(a.ctx, ids)
+	if err != nil {
+		return 0, a.localize(err)
+	}
+	if len(deleted) > 0 {
+		a.recordBulkDeleted(deleted)
+	}
+	return len(deleted), nil
+}
@@ -0,0 +1,48 @@
+//go:build !windows
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"os/exec"
+	"regexp"
+	"runtime"
+)
+
+// osascriptPNGRe 匹配 osascript 输出的 «data PNGf89504E47...»。
+var osascriptPNGRe = regexp.MustCompile(`«data PNGf([0-9A-Fa-f]+)»`)
+
+// readClipboardPNG 读取剪贴板中的 PNG 图片：
+// - macOS：osascript 读取 «class PNGf»
+// - Linux：依次尝试 wl-paste（Wayland）与 xclip（X11）
+// 剪贴板中没有图片（或缺少上述工具）时返回 errNoClipboardImage。
+func readClipboardPNG() ([]byte, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		out, err := exec.Command("osascript", "-e", "the clipboard as «class PNGf»").Output()
+		if err != nil {
+			return nil, errNoClipboardImage
+		}
+		m := osascriptPNGRe.FindSubmatch(out)
+		if m == nil {
+			return nil, errNoClipboardImage
+		}
+		data := make([]byte, hex.DecodedLen(len(m[1])))
+		if _, err := hex.Decode(data, m[1]); err != nil {
+			return nil, err
+		}
+		return data, nil
+	default:
+		for _, cmd := range [][]string{
+			{"wl-paste", "--no-newline", "--type", "image/png"},
+			{"xclip", "-selection", "clipboard", "-t", "image/png", "-o"},
+		} {
+			out, err := exec.Command(cmd[0], cmd[1:]...).Output()
+			if err == nil && bytes.HasPrefix(out, pngSignature) {
+				return out, nil
+			}
+		}
+		return nil, errNoClipboardImage
+	}
+}
@@ -0,0 +1,149 @@
+//go:build windows
+
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	user32                         = windows.NewLazySystemDLL("user32.dll")
+	kernel32                       = windows.NewLazySystemDLL("kernel32.dll")
+	procOpenClipboard              = user32.NewProc("OpenClipboard")
+	procCloseClipboard             = user32.NewProc("CloseClipboard")
+	procGetClipboardData           = user32.NewProc("GetClipboardData")
+	procIsClipboardFormatAvailable = user32.NewProc("IsClipboardFormatAvailable")
+	procRegisterClipboardFormatW   = user32.NewProc("RegisterClipboardFormatW")
+	procGlobalLock                 = kernel32.NewProc("GlobalLock")
+	procGlobalUnlock               = kernel32.NewProc("GlobalUnlock")
+	procGlobalSize                 = kernel32.NewProc("GlobalSize")
+	procRtlMoveMemory              = kernel32.NewProc("RtlMoveMemory")
+)
+
+const (
+	cfDIB        = 8
+	biRGB        = 0
+	biBitfields  = 3
+	dibHeaderLen = 40
+)
+
+// readClipboardPNG 读取剪贴板中的图片并返回 PNG 数据：
+// 优先使用浏览器/截图工具放入的 "PNG" 格式（保留透明度），否则把 CF_DIB 位图转码为 PNG。
+// 剪贴板中没有图片时返回 errNoClipboardImage。
+func readClipboardPNG() ([]byte, error) {
+	if r, _, err := procOpenClipboard.Call(0); r == 0 {
+		return nil, err
+	}
+	defer procCloseClipboard.Call()
+
+	name, err := windows.UTF16PtrFromString("PNG")
+	if err != nil {
+		return nil, err
+	}
+	if cfPNG, _, _ := procRegisterClipboardFormatW.Call(uintptr(unsafe.Pointer(name))); cfPNG != 0 {
+		if data, err := clipboardData(cfPNG); err == nil {
+			return data, nil
+		}
+	}
+
+	data, err := clipboardData(cfDIB)
+	if err != nil {
+		return nil, err
+	}
+	img, err := decodeDIB(data)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// clipboardData 复制剪贴板中 format 格式的数据（调用方需已打开剪贴板）。
+func clipboardData(format uintptr) ([]byte, error) {
+	if r, _, _ := procIsClipboardFormatAvailable.Call(format); r == 0 {
+		return nil, errNoClipboardImage
+	}
+	h, _, err := procGetClipboardData.Call(format)
+	if h == 0 {
+		return nil, err
+	}
+	size, _, _ := procGlobalSize.Call(h)
+	ptr, _, err := procGlobalLock.Call(h)
+	if ptr == 0 {
+		return nil, err
+	}
+	defer procGlobalUnlock.Call(h)
+	if size == 0 {
+		return nil, errNoClipboardImage
+	}
+	// 通过 RtlMoveMemory 复制到 Go 内存，避免把 uintptr 直接转换为指针
+	data := make([]byte, size)
+	procRtlMoveMemory.Call(uintptr(unsafe.Pointer(&data[0])), ptr, size)
+	return data, nil
+}
+
+// decodeDIB 解析 CF_DIB 数据（BITMAPINFOHEADER + 像素），支持 24/32 位未压缩位图。
+func decodeDIB(data []byte) (image.Image, error) {
+	if len(data) < dibHeaderLen {
+		return nil, errors.New("dib: header too short")
+	}
+	headerSize := int(binary.LittleEndian.Uint32(data[0:4]))
+	width := int(int32(binary.LittleEndian.Uint32(data[4:8])))
+	height := int(int32(binary.LittleEndian.Uint32(data[8:12])))
+	bpp := int(binary.LittleEndian.Uint16(data[14:16]))
+	compression := binary.LittleEndian.Uint32(data[16:20])
+	if (bpp != 24 && bpp != 32) || (compression != biRGB && compression != biBitfields) {
+		return nil, errors.New("dib: unsupported format")
+	}
+
+	topDown := height < 0
+	if topDown {
+		height = -height
+	}
+	offset := headerSize
+	if compression == biBitfields && headerSize == dibHeaderLen {
+		offset += 12 // 紧跟在 BITMAPINFOHEADER 后的 RGB 掩码
+	}
+	stride := (width*bpp + 31) / 32 * 4
+	if width <= 0 || height <= 0 || offset+stride*height > len(data) {
+		return nil, errors.New("dib: truncated pixel data")
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	hasAlpha := false
+	step := bpp / 8
+	for y := 0; y < height; y++ {
+		row := y
+		if !topDown {
+			row = height - 1 - y
+		}
+		px := data[offset+row*stride:]
+		for x := 0; x < width; x++ {
+			p := px[x*step:]
+			c := color.NRGBA{R: p[2], G: p[1], B: p[0], A: 255}
+			if bpp == 32 {
+				c.A = p[3]
+				hasAlpha = hasAlpha || p[3] != 0
+			}
+			img.SetNRGBA(x, y, c)
+		}
+	}
+	// 很多程序写入 32 位位图时 alpha 全为 0，此时按不透明处理
+	if bpp == 32 && !hasAlpha {
+		for i := 3; i < len(img.Pix); i += 4 {
+			img.Pix[i] = 255
+		}
+	}
+	return img, nil
+}
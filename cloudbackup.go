@@ -0,0 +1,369 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"spark-todo/internal/keychain"
+	"spark-todo/internal/s3"
+	"spark-todo/internal/todo"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// 云备份密钥在系统凭据库中的名称。
+const (
+	keychainService          = "Spark-Todo"
+	cloudSecretAccount       = "cloudBackup.secretKey"
+	cloudPassphraseAccount   = "cloudBackup.passphrase"
+	cloudBackupCheckInterval = 10 * time.Minute
+)
+
+// CloudBackupInfo 为设置页"云备份"一栏的数据。
+type CloudBackupInfo struct {
+	Config todo.CloudBackupConfig `json:"config"`
+	Status todo.CloudBackupStatus `json:"status"`
+	// HasSecret / HasPassphrase 表示凭据库中已保存访问密钥 / 加密口令（密钥本身不返回给前端）
+	HasSecret     bool `json:"hasSecret"`
+	HasPassphrase bool `json:"hasPassphrase"`
+	// KeychainSupported 为 false 时当前系统无法保存密钥，云备份不可用
+	KeychainSupported bool `json:"keychainSupported"`
+}
+
+// GetCloudBackup 返回云备份配置、最近一次备份结果与密钥保存情况。
+func (a *App) GetCloudBackup() (CloudBackupInfo, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return CloudBackupInfo{}, err
+	}
+	cfg, err := a.store.GetCloudBackupConfig(a.ctx)
+	if err != nil {
+		return CloudBackupInfo{}, a.localize(err)
+	}
+	status, err := a.store.GetCloudBackupStatus(a.ctx)
+	if err != nil {
+		return CloudBackupInfo{}, a.localize(err)
+	}
+	info := CloudBackupInfo{Config: cfg, Status: status, KeychainSupported: true}
+	if _, err := keychain.Get(keychainService, cloudSecretAccount); err == nil {
+		info.HasSecret = true
+	} else if errors.Is(err, keychain.ErrUnsupported) {
+		info.KeychainSupported = false
+	}
+	if _, err := keychain.Get(keychainService, cloudPassphraseAccount); err == nil {
+		info.HasPassphrase = true
+	}
+	return info, nil
+}
+
+// SetCloudBackup 保存云备份配置（S3 / MinIO / Backblaze B2 等 S3 兼容存储）。
+//
+// secretKey / passphrase 保存到系统凭据库，为空时保留已保存的值；保存前会列出一次存储桶以验证配置。
+func (a *App) SetCloudBackup(cfg todo.CloudBackupConfig, secretKey string, passphrase string) (CloudBackupInfo, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return CloudBackupInfo{}, err
+	}
+	if secretKey == "" {
+		secret, err := a.keychainGet(cloudSecretAccount)
+		if err != nil {
+			return CloudBackupInfo{}, err
+		}
+		if secret == "" {
+			return CloudBackupInfo{}, a.localize(todo.ErrCloudSecretMissing)
+		}
+		secretKey = secret
+	}
+	if passphrase != "" {
+		if err := todo.ValidateBackupPassphrase(passphrase); err != nil {
+			return CloudBackupInfo{}, a.localize(err)
+		}
+	} else if cfg.Encrypt {
+		saved, err := a.keychainGet(cloudPassphraseAccount)
+		if err != nil {
+			return CloudBackupInfo{}, err
+		}
+		if saved == "" {
+			return CloudBackupInfo{}, a.localize(todo.ErrCloudPassphraseMissing)
+		}
+	}
+
+	cfg, err := todo.NormalizeCloudBackupConfig(cfg)
+	if err != nil {
+		return CloudBackupInfo{}, a.localize(err)
+	}
+	if _, err := cloudClient(cfg, secretKey).List(a.ctx, cfg.Prefix); err != nil {
+		return CloudBackupInfo{}, a.localize(cloudError(err))
+	}
+
+	if err := keychain.Set(keychainService, cloudSecretAccount, secretKey); err != nil {
+		return CloudBackupInfo{}, a.localize(keychainError(err))
+	}
+	if passphrase != "" {
+		if err := keychain.Set(keychainService, cloudPassphraseAccount, passphrase); err != nil {
+			return CloudBackupInfo{}, a.localize(keychainError(err))
+		}
+	}
+	if _, err := a.store.SetCloudBackupConfig(a.ctx, cfg); err != nil {
+		return CloudBackupInfo{}, a.localize(err)
+	}
+	return a.GetCloudBackup()
+}
+
+// ClearCloudBackup 删除云备份配置并从凭据库中删除密钥与口令；远端已有的备份保留。
+func (a *App) ClearCloudBackup() error {
+	if err := a.ensureStoreReady(); err != nil {
+		return err
+	}
+	if err := a.store.DeleteCloudBackupConfig(a.ctx); err != nil {
+		return a.localize(err)
+	}
+	for _, account := range []string{cloudSecretAccount, cloudPassphraseAccount} {
+		if err := keychain.Delete(keychainService, account); err != nil {
+			return a.localize(keychainError(err))
+		}
+	}
+	return nil
+}
+
+// RunCloudBackup 立即上传一份备份到云端（不受定时备份开关影响），返回备份结果。
+func (a *App) RunCloudBackup() (todo.CloudBackupStatus, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.CloudBackupStatus{}, err
+	}
+	return a.cloudBackup(a.ctx)
+}
+
+// ListCloudBackups 列出云端的备份（新的在前）。
+func (a *App) ListCloudBackups() ([]s3.Object, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return nil, err
+	}
+	cfg, c, err := a.cloudTarget()
+	if err != nil {
+		return nil, err
+	}
+	objects, err := listCloudBackups(a.ctx, cfg, c)
+	return objects, a.localize(err)
+}
+
+// RestoreCloudBackup 下载云端备份 key 并恢复（替换当前全部数据，恢复前先备份当前数据库）。
+//
+// 加密备份使用凭据库中保存的口令；passphrase 非空时改用该口令（例如备份是用旧口令加密的）。
+// 恢复后发出 backup:restored 事件，前端应重新加载全部数据。
+func (a *App) RestoreCloudBackup(key string, passphrase string) error {
+	if err := a.ensureStoreReady(); err != nil {
+		return err
+	}
+	if a.store.ReadOnly() {
+		return a.localize(todo.ErrReadOnly)
+	}
+	cfg, c, err := a.cloudTarget()
+	if err != nil {
+		return err
+	}
+	if !cfg.IsBackupKey(key) {
+		return a.localize(todo.ErrCloudBackupNotFound.With(key))
+	}
+	data, err := c.Get(a.ctx, key)
+	var se *s3.Error
+	if errors.As(err, &se) && se.Status == http.StatusNotFound {
+		return a.localize(todo.ErrCloudBackupNotFound.With(key))
+	}
+	if err != nil {
+		return a.localize(cloudError(err))
+	}
+	if passphrase == "" && todo.IsEncryptedBackup(data) {
+		if passphrase, err = a.keychainGet(cloudPassphraseAccount); err != nil {
+			return err
+		}
+		if passphrase == "" {
+			return a.localize(todo.ErrBackupPassphraseRequired)
+		}
+	}
+
+	dir, err := os.MkdirTemp("", "spark-todo-cloud-")
+	if err != nil {
+		return a.wrapErr("backup.restoreFailed", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, filepath.Base(key))
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return a.wrapErr("backup.restoreFailed", err)
+	}
+	if err := a.restoreBackupFile(path, passphrase); err != nil {
+		return err
+	}
+	runtime.EventsEmit(a.ctx, "backup:restored", key)
+	return nil
+}
+
+// cloudBackup 上传一份备份并按保留份数清理旧备份，记录结果。
+func (a *App) cloudBackup(ctx context.Context) (todo.CloudBackupStatus, error) {
+	a.cloudMu.Lock()
+	defer a.cloudMu.Unlock()
+
+	cfg, c, err := a.cloudTarget()
+	if err != nil {
+		return todo.CloudBackupStatus{}, err
+	}
+	passphrase := ""
+	if cfg.Encrypt {
+		if passphrase, err = a.keychainGet(cloudPassphraseAccount); err != nil {
+			return todo.CloudBackupStatus{}, err
+		}
+		if passphrase == "" {
+			return todo.CloudBackupStatus{}, a.localize(todo.ErrCloudPassphraseMissing)
+		}
+	}
+
+	now := time.Now()
+	key := cfg.ObjectKey(now, cfg.Encrypt)
+	backupErr := func() error {
+		data, err := a.store.ExportBackup(ctx, passphrase)
+		if err != nil {
+			return err
+		}
+		if err := c.Put(ctx, key, data); err != nil {
+			return cloudError(err)
+		}
+		return pruneCloudBackups(ctx, cfg, c)
+	}()
+	status, err := a.store.RecordCloudBackup(ctx, now, key, a.localize(backupErr))
+	if backupErr != nil {
+		return todo.CloudBackupStatus{}, a.localize(backupErr)
+	}
+	if err != nil {
+		return todo.CloudBackupStatus{}, a.localize(err)
+	}
+	return status, nil
+}
+
+// runCloudBackup 定期检查并执行定时云备份，直到 ctx 取消；完成后发出 cloudBackup:done 事件。
+func (a *App) runCloudBackup(ctx context.Context) {
+	ticker := time.NewTicker(cloudBackupCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		// 只读模式下无法记录备份结果，暂停定时备份
+		if a.store.ReadOnly() {
+			continue
+		}
+		cfg, err := a.store.GetCloudBackupConfig(ctx)
+		if err != nil {
+			continue
+		}
+		status, err := a.store.GetCloudBackupStatus(ctx)
+		if err != nil || !cfg.Due(status, time.Now()) {
+			continue
+		}
+		if status, err = a.cloudBackup(ctx); err != nil {
+			runtime.LogErrorf(a.ctx, "cloud backup failed: %v", err)
+			a.notify(ctx, todo.Notification{Kind: todo.NotificationBackup, Title: a.tr("notify.backupFailedTitle"), Body: err.Error()})
+			continue
+		}
+		runtime.LogInfof(a.ctx, "cloud backup uploaded: %s", status.LastKey)
+		runtime.EventsEmit(a.ctx, "cloudBackup:done", status)
+	}
+}
+
+// cloudTarget 返回云备份配置与存储桶客户端；未配置或缺少密钥时返回相应错误。
+func (a *App) cloudTarget() (todo.CloudBackupConfig, *s3.Client, error) {
+	cfg, err := a.store.GetCloudBackupConfig(a.ctx)
+	if err != nil {
+		return todo.CloudBackupConfig{}, nil, a.localize(err)
+	}
+	if !cfg.Configured() {
+		return todo.CloudBackupConfig{}, nil, a.localize(todo.ErrCloudBackupNotConfigured)
+	}
+	secret, err := a.keychainGet(cloudSecretAccount)
+	if err != nil {
+		return todo.CloudBackupConfig{}, nil, err
+	}
+	if secret == "" {
+		return todo.CloudBackupConfig{}, nil, a.localize(todo.ErrCloudSecretMissing)
+	}
+	return cfg, cloudClient(cfg, secret), nil
+}
+
+// keychainGet 读取凭据库中的密钥；不存在时返回空字符串。
+func (a *App) keychainGet(account string) (string, error) {
+	v, err := keychain.Get(keychainService, account)
+	if errors.Is(err, keychain.ErrNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", a.localize(keychainError(err))
+	}
+	return v, nil
+}
+
+// cloudClient 创建访问配置中存储桶的客户端（单次请求超时 5 分钟，足够上传较大的数据库）。
+func cloudClient(cfg todo.CloudBackupConfig, secret string) *s3.Client {
+	return &s3.Client{
+		Endpoint:  cfg.Endpoint,
+		Region:    cfg.Region,
+		Bucket:    cfg.Bucket,
+		AccessKey: cfg.AccessKey,
+		SecretKey: secret,
+		PathStyle: cfg.PathStyle,
+		HTTP:      &http.Client{Timeout: 5 * time.Minute},
+	}
+}
+
+// listCloudBackups 列出本应用上传的备份（新的在前）。
+func listCloudBackups(ctx context.Context, cfg todo.CloudBackupConfig, c *s3.Client) ([]s3.Object, error) {
+	objects, err := c.List(ctx, cfg.Prefix)
+	if err != nil {
+		return nil, cloudError(err)
+	}
+	out := []s3.Object{}
+	for _, o := range objects {
+		if cfg.IsBackupKey(o.Key) {
+			out = append(out, o)
+		}
+	}
+	// 对象名带 UTC 时间戳，按名称倒序即按时间倒序
+	sort.Slice(out, func(i, j int) bool { return out[i].Key > out[j].Key })
+	return out, nil
+}
+
+// pruneCloudBackups 删除超出保留份数的旧备份（Keep 为 0 时全部保留）。
+func pruneCloudBackups(ctx context.Context, cfg todo.CloudBackupConfig, c *s3.Client) error {
+	if cfg.Keep <= 0 {
+		return nil
+	}
+	objects, err := listCloudBackups(ctx, cfg, c)
+	if err != nil {
+		return err
+	}
+	for i := cfg.Keep; i < len(objects); i++ {
+		if err := c.Delete(ctx, objects[i].Key); err != nil {
+			return cloudError(err)
+		}
+	}
+	return nil
+}
+
+// cloudError 将对象存储访问错误转换为 ErrCloudStorage。
+func cloudError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return todo.ErrCloudStorage.With(err.Error())
+}
+
+// keychainError 将凭据库不可用转换为 ErrKeychainUnsupported，其余错误原样返回。
+func keychainError(err error) error {
+	if errors.Is(err, keychain.ErrUnsupported) {
+		return todo.ErrKeychainUnsupported
+	}
+	return err
+}
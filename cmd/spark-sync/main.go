@@ -0,0 +1,60 @@
+// spark-sync 是 Spark-Todo 的可选同步服务端：提供账号与共享分组，桌面端登录后双向同步共享分组中的任务。
+//
+// 用法：
+//
+//	spark-sync -addr :8765 -db spark-sync.db [-trust-proxy]
+//
+// 服务端只提供 HTTP；部署在公网时请放在 HTTPS 反向代理之后，并加上 -trust-proxy 使登录限流按真实来源 IP 计数。
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"spark-todo/internal/share"
+	"spark-todo/internal/todo"
+)
+
+func main() {
+	addr := flag.String("addr", ":8765", "listen address")
+	dbPath := flag.String("db", "spark-sync.db", "SQLite database path")
+	trustProxy := flag.Bool("trust-proxy", false, "take the client IP from the last X-Forwarded-For entry (when behind a reverse proxy)")
+	flag.Parse()
+
+	store, err := todo.OpenHub(*dbPath)
+	if err != nil {
+		log.Fatalf("open database: %v", err)
+	}
+	defer store.Close()
+
+	handler := share.NewServer(store)
+	handler.TrustProxy = *trustProxy
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       60 * time.Second,
+		WriteTimeout:      60 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("spark-sync listening on %s (db: %s)", *addr, *dbPath)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("serve: %v", err)
+	}
+}
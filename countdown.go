@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"spark-todo/internal/todo"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// TakeCountdownMilestones 返回刚到达 30/7/1 天提醒节点的倒计时事项（每个节点只返回一次）；
+// 前端在启动时调用一次，之后每天零点由 "countdown:milestones" 事件推送。关闭提醒设置时返回空列表。
+func (a *App) TakeCountdownMilestones() ([]todo.CountdownMilestone, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return nil, err
+	}
+	items, err := a.takeCountdownMilestones(a.ctx)
+	return items, a.localize(err)
+}
+
+// takeCountdownMilestones 按设置中的时区与提醒开关读取并标记到达节点的倒计时事项，并逐条记入通知中心。
+func (a *App) takeCountdownMilestones(ctx context.Context) ([]todo.CountdownMilestone, error) {
+	settings, err := a.store.GetSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !settings.CountdownReminders {
+		return []todo.CountdownMilestone{}, nil
+	}
+	items, err := a.store.TakeCountdownMilestones(ctx, time.Now(), todo.Location(settings))
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range items {
+		a.notify(ctx, todo.Notification{
+			Kind:   todo.NotificationCountdown,
+			Title:  a.tr("notify.countdownTitle"),
+			Body:   a.tr("notify.countdownBody", m.Task.Title, m.DaysLeft),
+			TaskID: m.Task.ID,
+			Data:   map[string]any{"milestone": m.Milestone, "daysLeft": m.DaysLeft},
+		})
+	}
+	return items, nil
+}
+
+// notifyCountdownMilestones 在跨天时检查倒计时节点，有新到达的节点时发出 countdown:milestones 事件。
+func (a *App) notifyCountdownMilestones(ctx context.Context) {
+	items, err := a.takeCountdownMilestones(ctx)
+	if err != nil {
+		runtime.LogErrorf(a.ctx, "failed to check countdown milestones: %v", err)
+		return
+	}
+	if len(items) > 0 {
+		runtime.EventsEmit(a.ctx, "countdown:milestones", items)
+	}
+}
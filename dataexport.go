@@ -0,0 +1,195 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"spark-todo/internal/report"
+	"spark-todo/internal/todo"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ExportData 将全部分组、任务、标签与设置导出为带版本号的 JSON 文件，通过"另存为"对话框保存。
+// 用户取消对话框时返回空字符串且不报错。
+func (a *App) ExportData() (string, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return "", err
+	}
+	data, err := a.store.ExportData(a.ctx, time.Now())
+	if err != nil {
+		return "", a.wrapErr("data.exportFailed", err)
+	}
+	name := fmt.Sprintf("spark-todo-%s.json", time.Now().Format("20060102"))
+	return a.saveExport(name, "json", data)
+}
+
+// ReplaceImportLoss 返回替换导入将丢失、且导出文件无法带回的现有数据（附件与目标关联）的数量，
+// 前端在用户确认替换导入前展示。
+func (a *App) ReplaceImportLoss() (todo.ImportLoss, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.ImportLoss{}, err
+	}
+	loss, err := a.store.ReplaceImportLoss(a.ctx)
+	return loss, a.localize(err)
+}
+
+// ImportData 从 ExportData 导出的 JSON 文件导入数据：path 为空时弹出"打开文件"对话框（取消时返回零值且不报错）。
+//
+// mode 为 "merge"（默认，合并到现有数据）或 "replace"（替换全部分组、任务、标签与设置，更新源与快捷键除外）；
+// 替换前先把当前数据库备份到备份目录，确认前应先用 ReplaceImportLoss 提示将丢失的数据。
+// 导入在单个事务中完成，文件无效或写入失败时不做任何修改。
+// 成功后发出 "data:imported" 事件（参数为导入结果）。
+func (a *App) ImportData(path string, mode string) (todo.ImportSummary, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.ImportSummary{}, err
+	}
+	if a.store.ReadOnly() {
+		return todo.ImportSummary{}, a.localize(todo.ErrReadOnly)
+	}
+	if path == "" {
+		var err error
+		path, err = runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+			Title:   a.tr("data.importTitle"),
+			Filters: []runtime.FileFilter{exportFilters["json"]},
+		})
+		if err != nil {
+			return todo.ImportSummary{}, a.wrapErr("data.importFailed", err)
+		}
+		if path == "" {
+			return todo.ImportSummary{}, nil
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return todo.ImportSummary{}, a.wrapErr("data.importFailed", err)
+	}
+
+	if strings.EqualFold(strings.TrimSpace(mode), todo.ImportModeReplace) {
+		name := todo.BackupFileName("pre-import", time.Now())
+		if err := a.store.Backup(a.ctx, filepath.Join(a.backupDir(), name)); err != nil {
+			return todo.ImportSummary{}, a.wrapErr("backup.safetyFailed", err)
+		}
+	}
+	summary, err := a.store.ImportData(a.ctx, data, mode)
+	if err != nil {
+		return todo.ImportSummary{}, a.localize(err)
+	}
+	if summary.SettingsSaved {
+		if settings, err := a.store.GetSettings(a.ctx); err == nil {
+			a.applySettings(settings, true)
+		}
+	}
+	runtime.EventsEmit(a.ctx, "data:imported", summary)
+	return summary, nil
+}
+
+// ImportCSV 从 CSV 文件导入任务：path 为空时弹出"打开文件"对话框（取消时返回零值且不报错）。
+//
+// mapping 将字段（"title" | "content" | "status" | "group" | "important" | "urgent" | "due" | "id" | "parent"）
+// 映射到 CSV 表头中的列名，未指定的字段按列名自动识别；没有分组列的行导入到 groupID（0 表示第一个分组）。
+// 返回新建/跳过/出错的行数与出错行的明细；有任务导入时发出 "data:imported" 事件（参数为导入结果）。
+func (a *App) ImportCSV(path string, groupID int64, mapping map[string]string) (todo.TaskImportSummary, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.TaskImportSummary{}, err
+	}
+	if a.store.ReadOnly() {
+		return todo.TaskImportSummary{}, a.localize(todo.ErrReadOnly)
+	}
+	if path == "" {
+		var err error
+		path, err = runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+			Title:   a.tr("data.csvImportTitle"),
+			Filters: []runtime.FileFilter{exportFilters[report.FormatCSV]},
+		})
+		if err != nil {
+			return todo.TaskImportSummary{}, a.wrapErr("data.importFailed", err)
+		}
+		if path == "" {
+			return todo.TaskImportSummary{}, nil
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return todo.TaskImportSummary{}, a.wrapErr("data.importFailed", err)
+	}
+
+	summary, err := a.store.ImportCSV(a.ctx, data, groupID, mapping)
+	if err != nil {
+		return todo.TaskImportSummary{}, a.localize(err)
+	}
+	for i, e := range summary.Errors {
+		summary.Errors[i].Message = a.localize(e.Err).Error()
+	}
+	if summary.Created > 0 {
+		runtime.EventsEmit(a.ctx, "data:imported", summary)
+	}
+	return summary, nil
+}
+
+// TodoTxtExport 为 todo.txt 导出的结果：Path 为保存的文件路径，Summary 为 todo.txt 无法表示而丢失的信息，
+// 界面据此提示用户（内容不会导出，子任务失去父子关系）。
+type TodoTxtExport struct {
+	Path    string                    `json:"path"`
+	Summary todo.TodoTxtExportSummary `json:"summary"`
+}
+
+// ExportTodoTxt 将全部任务导出为 todo.txt 格式（优先级对应重要/紧急，+项目对应分组，@上下文对应标签），
+// 通过"另存为"对话框保存。用户取消对话框时返回零值且不报错。
+func (a *App) ExportTodoTxt() (TodoTxtExport, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return TodoTxtExport{}, err
+	}
+	data, summary, err := a.store.ExportTodoTxt(a.ctx)
+	if err != nil {
+		return TodoTxtExport{}, a.wrapErr("data.exportFailed", err)
+	}
+	path, err := a.saveExport("todo.txt", "txt", data)
+	if err != nil || path == "" {
+		return TodoTxtExport{}, err
+	}
+	return TodoTxtExport{Path: path, Summary: summary}, nil
+}
+
+// ImportTodoTxt 从 todo.txt 文件导入任务：path 为空时弹出"打开文件"对话框（取消时返回零值且不报错）。
+// 没有 +项目 的任务导入到 groupID（0 表示第一个分组）。返回值与事件同 ImportCSV。
+func (a *App) ImportTodoTxt(path string, groupID int64) (todo.TaskImportSummary, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.TaskImportSummary{}, err
+	}
+	if a.store.ReadOnly() {
+		return todo.TaskImportSummary{}, a.localize(todo.ErrReadOnly)
+	}
+	if path == "" {
+		var err error
+		path, err = runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+			Title:   a.tr("data.todoTxtImportTitle"),
+			Filters: []runtime.FileFilter{exportFilters["txt"]},
+		})
+		if err != nil {
+			return todo.TaskImportSummary{}, a.wrapErr("data.importFailed", err)
+		}
+		if path == "" {
+			return todo.TaskImportSummary{}, nil
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return todo.TaskImportSummary{}, a.wrapErr("data.importFailed", err)
+	}
+
+	summary, err := a.store.ImportTodoTxt(a.ctx, data, groupID)
+	if err != nil {
+		return todo.TaskImportSummary{}, a.localize(err)
+	}
+	for i, e := range summary.Errors {
+		summary.Errors[i].Message = a.localize(e.Err).Error()
+	}
+	if summary.Created > 0 {
+		runtime.EventsEmit(a.ctx, "data:imported", summary)
+	}
+	return summary, nil
+}
@@ -0,0 +1,28 @@
+package main
+
+import "spark-todo/internal/todo"
+
+// AddTaskDependency 将 blockedByID 设为任务 taskID 的前置任务（不能依赖自己，也不能形成循环依赖）。
+func (a *App) AddTaskDependency(taskID int64, blockedByID int64) error {
+	if err := a.ensureStoreReady(); err != nil {
+		return err
+	}
+	return a.localize(a.store.AddTaskDependency(a.ctx, taskID, blockedByID))
+}
+
+// RemoveTaskDependency 取消任务 taskID 对 blockedByID 的依赖。
+func (a *App) RemoveTaskDependency(taskID int64, blockedByID int64) error {
+	if err := a.ensureStoreReady(); err != nil {
+		return err
+	}
+	return a.localize(a.store.RemoveTaskDependency(a.ctx, taskID, blockedByID))
+}
+
+// GetTaskDependencies 返回任务的前置任务与以它为前置的任务。
+func (a *App) GetTaskDependencies(taskID int64) (todo.TaskDependencies, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.TaskDependencies{}, err
+	}
+	deps, err := a.store.GetTaskDependencies(a.ctx, taskID)
+	return deps, a.localize(err)
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"time"
+
+	"spark-todo/internal/todo"
+	"spark-todo/internal/version"
+)
+
+// maxDiagnosticLogBytes 限制单个日志文件写入诊断包的大小（只保留末尾部分）。
+const maxDiagnosticLogBytes = 1 << 20
+
+// diagnosticManifest 为诊断包的 manifest.json：说明包的来源与运行环境。
+type diagnosticManifest struct {
+	Build       version.BuildInfo `json:"build"`
+	OS          string            `json:"os"`
+	Arch        string            `json:"arch"`
+	Language    string            `json:"language"`
+	GeneratedAt int64             `json:"generatedAt"`
+	Files       []string          `json:"files"`
+}
+
+// ExportDiagnosticBundle 将运行环境、数据库结构、设置、脱敏数据与日志打包为 zip，
+// 通过"另存为"对话框保存，供用户附加到问题反馈中。
+//
+// 任务标题、分组名只保留加盐哈希与长度，任务内容只保留长度，
+// 设置中的筛选搜索词与标签也会被清除，不会泄露个人内容。
+// 用户取消对话框时返回空字符串且不报错。
+func (a *App) ExportDiagnosticBundle() (string, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return "", err
+	}
+
+	schema, err := a.store.SchemaInfo(a.ctx)
+	if err != nil {
+		return "", a.wrapErr("diag.failed", err)
+	}
+	data, err := a.store.RedactedData(a.ctx)
+	if err != nil {
+		return "", a.wrapErr("diag.failed", err)
+	}
+	settings, err := a.store.GetSettings(a.ctx)
+	if err != nil {
+		return "", a.wrapErr("diag.failed", a.localize(err))
+	}
+	redactSettings(&settings)
+
+	now := time.Now()
+	logs, logNames := a.diagnosticLogs()
+	manifest := diagnosticManifest{
+		Build:       version.GetBuildInfo(),
+		OS:          goruntime.GOOS,
+		Arch:        goruntime.GOARCH,
+		Language:    a.lang(),
+		GeneratedAt: now.UnixMilli(),
+		Files:       append([]string{"schema.json", "settings.json", "data.json"}, logNames...),
+	}
+	entries := []struct {
+		name  string
+		value any
+	}{
+		{"manifest.json", manifest},
+		{"schema.json", schema},
+		{"settings.json", settings},
+		{"data.json", data},
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, e := range entries {
+		raw, err := json.MarshalIndent(e.value, "", "  ")
+		if err != nil {
+			return "", a.wrapErr("diag.failed", err)
+		}
+		if err := writeZipEntry(zw, e.name, raw, now); err != nil {
+			return "", a.wrapErr("diag.failed", err)
+		}
+	}
+	for _, name := range logNames {
+		if err := writeZipEntry(zw, name, logs[name], now); err != nil {
+			return "", a.wrapErr("diag.failed", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return "", a.wrapErr("diag.failed", err)
+	}
+
+	name := fmt.Sprintf("spark-todo-diagnostics-%s.zip", now.Format("20060102-150405"))
+	return a.saveExport(name, "zip", buf.Bytes())
+}
+
+// redactSettings 清除设置中可能含有个人内容的字段（视图筛选的搜索词与标签）。
+func redactSettings(s *todo.Settings) {
+	for view, f := range s.ViewFilters {
+		if f.Search != "" {
+			f.Search = "<redacted>"
+		}
+		if len(f.Tags) > 0 {
+			f.Tags = []string{fmt.Sprintf("<%d tags>", len(f.Tags))}
+		}
+		s.ViewFilters[view] = f
+	}
+}
+
+// diagnosticLogs 读取数据目录 logs/ 下的 *.log 文件（由 newAppLogger 写入；超过上限时只保留末尾），
+// 返回 包内路径 -> 内容 以及按名称排序的路径列表；目录不存在时均为空。
+func (a *App) diagnosticLogs() (map[string][]byte, []string) {
+	out := map[string][]byte{}
+	names := []string{}
+	if a.dataDir == "" {
+		return out, names
+	}
+	matches, _ := filepath.Glob(filepath.Join(a.dataDir, "logs", "*.log"))
+	for _, path := range matches {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if len(raw) > maxDiagnosticLogBytes {
+			raw = raw[len(raw)-maxDiagnosticLogBytes:]
+		}
+		name := "logs/" + filepath.Base(path)
+		out[name] = raw
+		names = append(names, name)
+	}
+	return out, names
+}
+
+// writeZipEntry 向 zw 写入一个文件。
+func writeZipEntry(zw *zip.Writer, name string, data []byte, modified time.Time) error {
+	w, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
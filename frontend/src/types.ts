@@ -15,6 +15,8 @@ export type TaskModalState = {
     parentId: number;
     title: string;
     content: string;
+    // 打开编辑时的完整内容，保存时据此判断内容是否改过（见 Task.contentChanged）
+    originalContent: string;
     status: StatusValue;
     important: boolean;
     urgent: boolean;
@@ -1,36 +1,370 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
-import {version} from '../models';
 import {todo} from '../models';
+import {version} from '../models';
+import {main} from '../models';
+import {holiday} from '../models';
+import {s3} from '../models';
+import {lunar} from '../models';
+
+export function AddTaskDependency(arg1:number,arg2:number):Promise<void>;
+
+export function AddTaskNote(arg1:number,arg2:string):Promise<todo.TaskNote>;
+
+export function AddToMyDay(arg1:number):Promise<void>;
+
+export function ArchiveGroup(arg1:number,arg2:boolean):Promise<todo.Group>;
+
+export function AttachClipboardImage(arg1:number):Promise<todo.Attachment>;
+
+export function BulkDelete(arg1:Array<number>):Promise<number>;
+
+export function BulkMoveToGroup(arg1:Array<number>,arg2:number):Promise<Array<todo.Task>>;
+
+export function BulkSetFlags(arg1:Array<number>,arg2:boolean,arg3:boolean):Promise<Array<todo.Task>>;
+
+export function BulkUpdateStatus(arg1:Array<number>,arg2:todo.Status):Promise<Array<todo.Task>>;
+
+export function CancelUpdate():Promise<void>;
 
 export function CheckUpdate():Promise<version.UpdateCheckResult>;
 
+export function ClearCloudBackup():Promise<void>;
+
+export function ClearTaskRecurrence(arg1:number):Promise<void>;
+
+export function ClearTaskReminder(arg1:number):Promise<void>;
+
+export function CreateAPIToken(arg1:string,arg2:string):Promise<main.NewAPIToken>;
+
+export function CreateTag(arg1:string):Promise<todo.Tag>;
+
+export function DeleteAttachment(arg1:number):Promise<void>;
+
+export function DeleteGoal(arg1:number):Promise<void>;
+
 export function DeleteGroup(arg1:number):Promise<void>;
 
+export function DeleteSubtask(arg1:number):Promise<todo.Task>;
+
+export function DeleteTag(arg1:number):Promise<void>;
+
 export function DeleteTask(arg1:number):Promise<void>;
 
-export function GetBoard():Promise<todo.Board>;
+export function DeleteTaskNote(arg1:number):Promise<void>;
+
+export function DeleteTrashItem(arg1:number):Promise<void>;
+
+export function EmptyTrash():Promise<number>;
+
+export function ExportBackup(arg1:string):Promise<string>;
+
+export function ExportBoard(arg1:number,arg2:string):Promise<string>;
+
+export function ExportCSV():Promise<string>;
+
+export function ExportData():Promise<string>;
+
+export function ExportDiagnosticBundle():Promise<string>;
+
+export function ExportGroupAsHTML(arg1:number):Promise<string>;
+
+export function ExportMarkdown(arg1:number):Promise<string>;
+
+export function ExportTimesheet(arg1:string,arg2:string):Promise<string>;
+
+export function ExportTodoTxt():Promise<main.TodoTxtExport>;
+
+export function ExportXLSX():Promise<string>;
+
+export function FavoriteGroup(arg1:number,arg2:boolean):Promise<todo.Group>;
+
+export function FinishReview():Promise<todo.ReviewStatus>;
+
+export function GenerateWeeklyReport(arg1:string,arg2:string):Promise<string>;
+
+export function GetAchievements():Promise<todo.GameStats>;
+
+export function GetActivity(arg1:number,arg2:number,arg3:number):Promise<todo.ActivityPage>;
+
+export function GetArchive():Promise<todo.Archive>;
+
+export function GetAttachments(arg1:number):Promise<Array<todo.Attachment>>;
+
+export function GetBoard(arg1:number):Promise<todo.Board>;
+
+export function GetBuildInfo():Promise<version.BuildInfo>;
+
+export function GetBurndown(arg1:number,arg2:string):Promise<todo.Burndown>;
+
+export function GetChangelog(arg1:string):Promise<Array<version.ReleaseInfo>>;
+
+export function GetCloudBackup():Promise<main.CloudBackupInfo>;
+
+export function GetCoverThumbnail(arg1:number):Promise<string>;
+
+export function GetEffectiveTheme():Promise<main.ThemeChange>;
+
+export function GetFocusStats(arg1:string):Promise<todo.FocusStats>;
+
+export function GetGoals():Promise<Array<todo.Goal>>;
+
+export function GetHistory():Promise<main.HistoryState>;
+
+export function GetHolidayCalendar():Promise<holiday.Info>;
+
+export function GetHotkeys():Promise<Array<main.HotkeyStatus>>;
+
+export function GetOverdue():Promise<todo.Overdue>;
+
+export function GetPomodoro():Promise<todo.PomodoroSession>;
+
+export function GetQuadrants():Promise<Array<todo.Quadrant>>;
+
+export function GetReadOnly():Promise<main.ReadOnlyStatus>;
+
+export function GetRecentTasks(arg1:number):Promise<Array<todo.RecentTask>>;
+
+export function GetRemoteSharedGroups():Promise<Array<todo.HostedGroup>>;
+
+export function GetReviewStatus():Promise<todo.ReviewStatus>;
+
+export function GetRollbackInfo():Promise<version.RollbackInfo>;
+
+export function GetSession():Promise<todo.SessionState>;
+
+export function GetShareStatus():Promise<main.ShareStatus>;
+
+export function GetStorageUsage():Promise<main.StorageUsage>;
+
+export function GetSwimlanes(arg1:string):Promise<todo.Swimlanes>;
+
+export function GetSystemAccessibility():Promise<main.AccessibilityPrefs>;
+
+export function GetSystemTheme():Promise<string>;
+
+export function GetTags():Promise<Array<todo.Tag>>;
+
+export function GetTaskContent(arg1:number):Promise<string>;
+
+export function GetTaskDependencies(arg1:number):Promise<todo.TaskDependencies>;
+
+export function GetTaskDetail(arg1:number):Promise<todo.TaskDetail>;
+
+export function GetTaskNotes(arg1:number):Promise<Array<todo.TaskNote>>;
+
+export function GetTasksByTags(arg1:Array<string>):Promise<Array<todo.Task>>;
+
+export function GetTimeReport(arg1:string,arg2:string):Promise<todo.TimeReport>;
+
+export function GetTimeSpent(arg1:number):Promise<todo.TimeSpent>;
+
+export function GetTimer():Promise<todo.TimeEntry>;
+
+export function GetTimezone():Promise<main.TimezoneInfo>;
+
+export function GetToday():Promise<todo.Today>;
+
+export function GetTrash():Promise<Array<todo.TrashItem>>;
+
+export function GetUpcoming():Promise<Array<todo.AgendaDay>>;
 
 export function GetVersion():Promise<string>;
 
+export function GetWeekAgenda(arg1:string):Promise<todo.WeekAgenda>;
+
+export function ImportBackup(arg1:string,arg2:string):Promise<string>;
+
+export function ImportCSV(arg1:string,arg2:number,arg3:Record<string, string>):Promise<todo.TaskImportSummary>;
+
+export function ImportData(arg1:string,arg2:string):Promise<todo.ImportSummary>;
+
+export function ImportHolidayCalendar():Promise<holiday.Info>;
+
+export function ImportTodoTxt(arg1:string,arg2:number):Promise<todo.TaskImportSummary>;
+
+export function InstallUpdate():Promise<void>;
+
+export function InviteToSharedGroup(arg1:number,arg2:string):Promise<todo.HostedGroup>;
+
+export function JoinSharedGroup(arg1:string,arg2:string):Promise<todo.SharedGroup>;
+
+export function LeaveSharedGroup(arg1:number):Promise<void>;
+
+export function LinkGoalTask(arg1:number,arg2:number):Promise<todo.Goal>;
+
+export function ListAPITokens():Promise<Array<todo.APIToken>>;
+
+export function ListCloudBackups():Promise<Array<s3.Object>>;
+
+export function ListGroupTree():Promise<Array<todo.GroupNode>>;
+
+export function ListNotifications(arg1:number,arg2:boolean,arg3:number):Promise<todo.NotificationPage>;
+
+export function ListOverdueTasks():Promise<Array<todo.Task>>;
+
+export function ListReminders():Promise<Array<todo.Reminder>>;
+
+export function ListTasksDueBetween(arg1:number,arg2:number):Promise<Array<todo.Task>>;
+
+export function LunarToSolar(arg1:lunar.Date):Promise<todo.LunarDate>;
+
+export function MarkNotificationsRead(arg1:Array<number>):Promise<number>;
+
+export function MergeTags(arg1:number,arg2:number):Promise<todo.Tag>;
+
+export function NextReviewItem():Promise<todo.ReviewItem>;
+
+export function NextWorkingDay(arg1:number):Promise<number>;
+
+export function OpenContentLink(arg1:number,arg2:string):Promise<void>;
+
+export function OpenTaskLink(arg1:number):Promise<void>;
+
 export function OpenURL(arg1:string):Promise<void>;
 
+export function PausePomodoro():Promise<todo.PomodoroSession>;
+
+export function PrintBoard(arg1:number):Promise<void>;
+
 export function Quit():Promise<void>;
 
+export function RedoLast():Promise<main.HistoryState>;
+
+export function RefreshSystemTheme():Promise<void>;
+
+export function RemoveFromMyDay(arg1:number):Promise<void>;
+
+export function RemoveTaskDependency(arg1:number,arg2:number):Promise<void>;
+
+export function RenameTag(arg1:number,arg2:string):Promise<todo.Tag>;
+
+export function RenderContent(arg1:string,arg2:string):Promise<string>;
+
+export function ReorderGroups(arg1:Array<number>):Promise<Array<todo.Group>>;
+
+export function ReorderTasks(arg1:number,arg2:Array<number>):Promise<void>;
+
+export function ReplaceImportLoss():Promise<todo.ImportLoss>;
+
+export function ResetHolidayCalendar():Promise<holiday.Info>;
+
+export function ResetSettings(arg1:string):Promise<todo.Settings>;
+
 export function Restart():Promise<void>;
 
+export function RestoreCloudBackup(arg1:string,arg2:string):Promise<void>;
+
+export function RestoreTrashItem(arg1:number):Promise<void>;
+
+export function ResumePomodoro():Promise<todo.PomodoroSession>;
+
+export function ReviewTask(arg1:number,arg2:string,arg3:number):Promise<todo.ReviewItem>;
+
+export function RevokeAPIToken(arg1:number):Promise<void>;
+
+export function RollbackUpdate():Promise<void>;
+
+export function RunCloudBackup():Promise<todo.CloudBackupStatus>;
+
+export function RunSelfTest():Promise<main.SelfTestReport>;
+
+export function SaveSession(arg1:todo.SessionState):Promise<todo.SessionState>;
+
+export function SearchTasks(arg1:string,arg2:Array<string>):Promise<todo.SearchResult>;
+
+export function SetAccent(arg1:string,arg2:string):Promise<todo.Settings>;
+
+export function SetAccessibility(arg1:string,arg2:string):Promise<todo.Settings>;
+
 export function SetAlwaysOnTop(arg1:boolean):Promise<todo.Settings>;
 
+export function SetCloudBackup(arg1:todo.CloudBackupConfig,arg2:string,arg3:string):Promise<main.CloudBackupInfo>;
+
 export function SetConciseMode(arg1:boolean):Promise<todo.Settings>;
 
+export function SetDateFormat(arg1:string):Promise<todo.Settings>;
+
+export function SetGamification(arg1:boolean):Promise<todo.Settings>;
+
 export function SetHideDone(arg1:boolean):Promise<todo.Settings>;
 
+export function SetHotkey(arg1:string,arg2:string):Promise<main.HotkeyStatus>;
+
+export function SetLanguage(arg1:string):Promise<todo.Settings>;
+
+export function SetQuadrant(arg1:Array<number>,arg2:boolean,arg3:boolean):Promise<Array<todo.Task>>;
+
+export function SetReadOnly(arg1:boolean):Promise<main.ReadOnlyStatus>;
+
+export function SetShowLunar(arg1:boolean):Promise<todo.Settings>;
+
+export function SetTaskCover(arg1:number,arg2:number):Promise<todo.Task>;
+
+export function SetTaskRecurrence(arg1:number,arg2:todo.Recurrence):Promise<todo.Recurrence>;
+
+export function SetTaskReminder(arg1:number,arg2:number):Promise<todo.Reminder>;
+
+export function SetTaskTags(arg1:number,arg2:Array<string>):Promise<Array<todo.Tag>>;
+
 export function SetTheme(arg1:string):Promise<todo.Settings>;
 
+export function SetTimezone(arg1:string):Promise<todo.Settings>;
+
+export function SetTrashRetention(arg1:number):Promise<todo.Settings>;
+
+export function SetUIScale(arg1:string):Promise<todo.Settings>;
+
+export function SetUpdateURL(arg1:string):Promise<todo.Settings>;
+
+export function SetViewFilter(arg1:string,arg2:todo.ViewFilter):Promise<todo.Settings>;
+
 export function SetViewMode(arg1:string):Promise<todo.Settings>;
 
+export function SetViewSort(arg1:string,arg2:string):Promise<todo.Settings>;
+
+export function SetWeekStart(arg1:string):Promise<todo.Settings>;
+
+export function ShareGroup(arg1:number):Promise<todo.SharedGroup>;
+
+export function ShareSignIn(arg1:string,arg2:string,arg3:string,arg4:boolean):Promise<main.ShareStatus>;
+
+export function ShareSignOut():Promise<void>;
+
 export function ShowWaterReminder():Promise<void>;
 
-export function UpsertGroup(arg1:number,arg2:string):Promise<todo.Group>;
+export function SnoozeReminder(arg1:number,arg2:number):Promise<todo.Reminder>;
+
+export function SnoozeWaterReminder(arg1:number):Promise<number>;
+
+export function SolarToLunar(arg1:string):Promise<todo.LunarDate>;
+
+export function StartPomodoro(arg1:number,arg2:number):Promise<todo.PomodoroSession>;
+
+export function StartReview():Promise<todo.ReviewStatus>;
+
+export function StartTimer(arg1:number):Promise<todo.TimeEntry>;
+
+export function StopPomodoro():Promise<todo.PomodoroSession>;
+
+export function StopTimer():Promise<todo.TimeEntry>;
+
+export function SyncSharedGroups():Promise<main.ShareSyncResult>;
+
+export function TakeCountdownMilestones():Promise<Array<todo.CountdownMilestone>>;
+
+export function ToggleSubtask(arg1:number):Promise<main.SubTaskToggle>;
+
+export function UndoLast():Promise<main.HistoryState>;
+
+export function UnlinkGoalTask(arg1:number,arg2:number):Promise<todo.Goal>;
+
+export function UpdateTaskNote(arg1:number,arg2:string):Promise<todo.TaskNote>;
+
+export function UpsertGoal(arg1:todo.Goal):Promise<todo.Goal>;
+
+export function UpsertGroup(arg1:todo.Group):Promise<todo.Group>;
+
+export function UpsertSubtask(arg1:number,arg2:number,arg3:string):Promise<todo.Task>;
 
 export function UpsertTask(arg1:todo.Task):Promise<todo.Task>;
@@ -2,64 +2,724 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AddTaskDependency(arg1, arg2) {
+  return window['go']['main']['App']['AddTaskDependency'](arg1, arg2);
+}
+
+export function AddTaskNote(arg1, arg2) {
+  return window['go']['main']['App']['AddTaskNote'](arg1, arg2);
+}
+
+export function AddToMyDay(arg1) {
+  return window['go']['main']['App']['AddToMyDay'](arg1);
+}
+
+export function ArchiveGroup(arg1, arg2) {
+  return window['go']['main']['App']['ArchiveGroup'](arg1, arg2);
+}
+
+export function AttachClipboardImage(arg1) {
+  return window['go']['main']['App']['AttachClipboardImage'](arg1);
+}
+
+export function BulkDelete(arg1) {
+  return window['go']['main']['App']['BulkDelete'](arg1);
+}
+
+export function BulkMoveToGroup(arg1, arg2) {
+  return window['go']['main']['App']['BulkMoveToGroup'](arg1, arg2);
+}
+
+export function BulkSetFlags(arg1, arg2, arg3) {
+  return window['go']['main']['App']['BulkSetFlags'](arg1, arg2, arg3);
+}
+
+export function BulkUpdateStatus(arg1, arg2) {
+  return window['go']['main']['App']['BulkUpdateStatus'](arg1, arg2);
+}
+
+export function CancelUpdate() {
+  return window['go']['main']['App']['CancelUpdate']();
+}
+
 export function CheckUpdate() {
   return window['go']['main']['App']['CheckUpdate']();
 }
 
+export function ClearCloudBackup() {
+  return window['go']['main']['App']['ClearCloudBackup']();
+}
+
+export function ClearTaskRecurrence(arg1) {
+  return window['go']['main']['App']['ClearTaskRecurrence'](arg1);
+}
+
+export function ClearTaskReminder(arg1) {
+  return window['go']['main']['App']['ClearTaskReminder'](arg1);
+}
+
+export function CreateAPIToken(arg1, arg2) {
+  return window['go']['main']['App']['CreateAPIToken'](arg1, arg2);
+}
+
+export function CreateTag(arg1) {
+  return window['go']['main']['App']['CreateTag'](arg1);
+}
+
+export function DeleteAttachment(arg1) {
+  return window['go']['main']['App']['DeleteAttachment'](arg1);
+}
+
+export function DeleteGoal(arg1) {
+  return window['go']['main']['App']['DeleteGoal'](arg1);
+}
+
 export function DeleteGroup(arg1) {
   return window['go']['main']['App']['DeleteGroup'](arg1);
 }
 
+export function DeleteSubtask(arg1) {
+  return window['go']['main']['App']['DeleteSubtask'](arg1);
+}
+
+export function DeleteTag(arg1) {
+  return window['go']['main']['App']['DeleteTag'](arg1);
+}
+
 export function DeleteTask(arg1) {
   return window['go']['main']['App']['DeleteTask'](arg1);
 }
 
-export function GetBoard() {
-  return window['go']['main']['App']['GetBoard']();
+export function DeleteTaskNote(arg1) {
+  return window['go']['main']['App']['DeleteTaskNote'](arg1);
+}
+
+export function DeleteTrashItem(arg1) {
+  return window['go']['main']['App']['DeleteTrashItem'](arg1);
+}
+
+export function EmptyTrash() {
+  return window['go']['main']['App']['EmptyTrash']();
+}
+
+export function ExportBackup(arg1) {
+  return window['go']['main']['App']['ExportBackup'](arg1);
+}
+
+export function ExportBoard(arg1, arg2) {
+  return window['go']['main']['App']['ExportBoard'](arg1, arg2);
+}
+
+export function ExportCSV() {
+  return window['go']['main']['App']['ExportCSV']();
+}
+
+export function ExportData() {
+  return window['go']['main']['App']['ExportData']();
+}
+
+export function ExportDiagnosticBundle() {
+  return window['go']['main']['App']['ExportDiagnosticBundle']();
+}
+
+export function ExportGroupAsHTML(arg1) {
+  return window['go']['main']['App']['ExportGroupAsHTML'](arg1);
+}
+
+export function ExportMarkdown(arg1) {
+  return window['go']['main']['App']['ExportMarkdown'](arg1);
+}
+
+export function ExportTimesheet(arg1, arg2) {
+  return window['go']['main']['App']['ExportTimesheet'](arg1, arg2);
+}
+
+export function ExportTodoTxt() {
+  return window['go']['main']['App']['ExportTodoTxt']();
+}
+
+export function ExportXLSX() {
+  return window['go']['main']['App']['ExportXLSX']();
+}
+
+export function FavoriteGroup(arg1, arg2) {
+  return window['go']['main']['App']['FavoriteGroup'](arg1, arg2);
+}
+
+export function FinishReview() {
+  return window['go']['main']['App']['FinishReview']();
+}
+
+export function GenerateWeeklyReport(arg1, arg2) {
+  return window['go']['main']['App']['GenerateWeeklyReport'](arg1, arg2);
+}
+
+export function GetAchievements() {
+  return window['go']['main']['App']['GetAchievements']();
+}
+
+export function GetActivity(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetActivity'](arg1, arg2, arg3);
+}
+
+export function GetArchive() {
+  return window['go']['main']['App']['GetArchive']();
+}
+
+export function GetAttachments(arg1) {
+  return window['go']['main']['App']['GetAttachments'](arg1);
+}
+
+export function GetBoard(arg1) {
+  return window['go']['main']['App']['GetBoard'](arg1);
+}
+
+export function GetBuildInfo() {
+  return window['go']['main']['App']['GetBuildInfo']();
+}
+
+export function GetBurndown(arg1, arg2) {
+  return window['go']['main']['App']['GetBurndown'](arg1, arg2);
+}
+
+export function GetChangelog(arg1) {
+  return window['go']['main']['App']['GetChangelog'](arg1);
+}
+
+export function GetCloudBackup() {
+  return window['go']['main']['App']['GetCloudBackup']();
+}
+
+export function GetCoverThumbnail(arg1) {
+  return window['go']['main']['App']['GetCoverThumbnail'](arg1);
+}
+
+export function GetEffectiveTheme() {
+  return window['go']['main']['App']['GetEffectiveTheme']();
+}
+
+export function GetFocusStats(arg1) {
+  return window['go']['main']['App']['GetFocusStats'](arg1);
+}
+
+export function GetGoals() {
+  return window['go']['main']['App']['GetGoals']();
+}
+
+export function GetHistory() {
+  return window['go']['main']['App']['GetHistory']();
+}
+
+export function GetHolidayCalendar() {
+  return window['go']['main']['App']['GetHolidayCalendar']();
+}
+
+export function GetHotkeys() {
+  return window['go']['main']['App']['GetHotkeys']();
+}
+
+export function GetOverdue() {
+  return window['go']['main']['App']['GetOverdue']();
+}
+
+export function GetPomodoro() {
+  return window['go']['main']['App']['GetPomodoro']();
+}
+
+export function GetQuadrants() {
+  return window['go']['main']['App']['GetQuadrants']();
+}
+
+export function GetReadOnly() {
+  return window['go']['main']['App']['GetReadOnly']();
+}
+
+export function GetRecentTasks(arg1) {
+  return window['go']['main']['App']['GetRecentTasks'](arg1);
+}
+
+export function GetRemoteSharedGroups() {
+  return window['go']['main']['App']['GetRemoteSharedGroups']();
+}
+
+export function GetReviewStatus() {
+  return window['go']['main']['App']['GetReviewStatus']();
+}
+
+export function GetRollbackInfo() {
+  return window['go']['main']['App']['GetRollbackInfo']();
+}
+
+export function GetSession() {
+  return window['go']['main']['App']['GetSession']();
+}
+
+export function GetShareStatus() {
+  return window['go']['main']['App']['GetShareStatus']();
+}
+
+export function GetStorageUsage() {
+  return window['go']['main']['App']['GetStorageUsage']();
+}
+
+export function GetSwimlanes(arg1) {
+  return window['go']['main']['App']['GetSwimlanes'](arg1);
+}
+
+export function GetSystemAccessibility() {
+  return window['go']['main']['App']['GetSystemAccessibility']();
+}
+
+export function GetSystemTheme() {
+  return window['go']['main']['App']['GetSystemTheme']();
+}
+
+export function GetTags() {
+  return window['go']['main']['App']['GetTags']();
+}
+
+export function GetTaskContent(arg1) {
+  return window['go']['main']['App']['GetTaskContent'](arg1);
+}
+
+export function GetTaskDependencies(arg1) {
+  return window['go']['main']['App']['GetTaskDependencies'](arg1);
+}
+
+export function GetTaskDetail(arg1) {
+  return window['go']['main']['App']['GetTaskDetail'](arg1);
+}
+
+export function GetTaskNotes(arg1) {
+  return window['go']['main']['App']['GetTaskNotes'](arg1);
+}
+
+export function GetTasksByTags(arg1) {
+  return window['go']['main']['App']['GetTasksByTags'](arg1);
+}
+
+export function GetTimeReport(arg1, arg2) {
+  return window['go']['main']['App']['GetTimeReport'](arg1, arg2);
+}
+
+export function GetTimeSpent(arg1) {
+  return window['go']['main']['App']['GetTimeSpent'](arg1);
+}
+
+export function GetTimer() {
+  return window['go']['main']['App']['GetTimer']();
+}
+
+export function GetTimezone() {
+  return window['go']['main']['App']['GetTimezone']();
+}
+
+export function GetToday() {
+  return window['go']['main']['App']['GetToday']();
+}
+
+export function GetTrash() {
+  return window['go']['main']['App']['GetTrash']();
+}
+
+export function GetUpcoming() {
+  return window['go']['main']['App']['GetUpcoming']();
 }
 
 export function GetVersion() {
   return window['go']['main']['App']['GetVersion']();
 }
 
+export function GetWeekAgenda(arg1) {
+  return window['go']['main']['App']['GetWeekAgenda'](arg1);
+}
+
+export function ImportBackup(arg1, arg2) {
+  return window['go']['main']['App']['ImportBackup'](arg1, arg2);
+}
+
+export function ImportCSV(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ImportCSV'](arg1, arg2, arg3);
+}
+
+export function ImportData(arg1, arg2) {
+  return window['go']['main']['App']['ImportData'](arg1, arg2);
+}
+
+export function ImportHolidayCalendar() {
+  return window['go']['main']['App']['ImportHolidayCalendar']();
+}
+
+export function ImportTodoTxt(arg1, arg2) {
+  return window['go']['main']['App']['ImportTodoTxt'](arg1, arg2);
+}
+
+export function InstallUpdate() {
+  return window['go']['main']['App']['InstallUpdate']();
+}
+
+export function InviteToSharedGroup(arg1, arg2) {
+  return window['go']['main']['App']['InviteToSharedGroup'](arg1, arg2);
+}
+
+export function JoinSharedGroup(arg1, arg2) {
+  return window['go']['main']['App']['JoinSharedGroup'](arg1, arg2);
+}
+
+export function LeaveSharedGroup(arg1) {
+  return window['go']['main']['App']['LeaveSharedGroup'](arg1);
+}
+
+export function LinkGoalTask(arg1, arg2) {
+  return window['go']['main']['App']['LinkGoalTask'](arg1, arg2);
+}
+
+export function ListAPITokens() {
+  return window['go']['main']['App']['ListAPITokens']();
+}
+
+export function ListCloudBackups() {
+  return window['go']['main']['App']['ListCloudBackups']();
+}
+
+export function ListGroupTree() {
+  return window['go']['main']['App']['ListGroupTree']();
+}
+
+export function ListNotifications(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ListNotifications'](arg1, arg2, arg3);
+}
+
+export function ListOverdueTasks() {
+  return window['go']['main']['App']['ListOverdueTasks']();
+}
+
+export function ListReminders() {
+  return window['go']['main']['App']['ListReminders']();
+}
+
+export function ListTasksDueBetween(arg1, arg2) {
+  return window['go']['main']['App']['ListTasksDueBetween'](arg1, arg2);
+}
+
+export function LunarToSolar(arg1) {
+  return window['go']['main']['App']['LunarToSolar'](arg1);
+}
+
+export function MarkNotificationsRead(arg1) {
+  return window['go']['main']['App']['MarkNotificationsRead'](arg1);
+}
+
+export function MergeTags(arg1, arg2) {
+  return window['go']['main']['App']['MergeTags'](arg1, arg2);
+}
+
+export function NextReviewItem() {
+  return window['go']['main']['App']['NextReviewItem']();
+}
+
+export function NextWorkingDay(arg1) {
+  return window['go']['main']['App']['NextWorkingDay'](arg1);
+}
+
+export function OpenContentLink(arg1, arg2) {
+  return window['go']['main']['App']['OpenContentLink'](arg1, arg2);
+}
+
+export function OpenTaskLink(arg1) {
+  return window['go']['main']['App']['OpenTaskLink'](arg1);
+}
+
 export function OpenURL(arg1) {
   return window['go']['main']['App']['OpenURL'](arg1);
 }
 
+export function PausePomodoro() {
+  return window['go']['main']['App']['PausePomodoro']();
+}
+
+export function PrintBoard(arg1) {
+  return window['go']['main']['App']['PrintBoard'](arg1);
+}
+
 export function Quit() {
   return window['go']['main']['App']['Quit']();
 }
 
+export function RedoLast() {
+  return window['go']['main']['App']['RedoLast']();
+}
+
+export function RefreshSystemTheme() {
+  return window['go']['main']['App']['RefreshSystemTheme']();
+}
+
+export function RemoveFromMyDay(arg1) {
+  return window['go']['main']['App']['RemoveFromMyDay'](arg1);
+}
+
+export function RemoveTaskDependency(arg1, arg2) {
+  return window['go']['main']['App']['RemoveTaskDependency'](arg1, arg2);
+}
+
+export function RenameTag(arg1, arg2) {
+  return window['go']['main']['App']['RenameTag'](arg1, arg2);
+}
+
+export function RenderContent(arg1, arg2) {
+  return window['go']['main']['App']['RenderContent'](arg1, arg2);
+}
+
+export function ReorderGroups(arg1) {
+  return window['go']['main']['App']['ReorderGroups'](arg1);
+}
+
+export function ReorderTasks(arg1, arg2) {
+  return window['go']['main']['App']['ReorderTasks'](arg1, arg2);
+}
+
+export function ReplaceImportLoss() {
+  return window['go']['main']['App']['ReplaceImportLoss']();
+}
+
+export function ResetHolidayCalendar() {
+  return window['go']['main']['App']['ResetHolidayCalendar']();
+}
+
+export function ResetSettings(arg1) {
+  return window['go']['main']['App']['ResetSettings'](arg1);
+}
+
 export function Restart() {
   return window['go']['main']['App']['Restart']();
 }
 
+export function RestoreCloudBackup(arg1, arg2) {
+  return window['go']['main']['App']['RestoreCloudBackup'](arg1, arg2);
+}
+
+export function RestoreTrashItem(arg1) {
+  return window['go']['main']['App']['RestoreTrashItem'](arg1);
+}
+
+export function ResumePomodoro() {
+  return window['go']['main']['App']['ResumePomodoro']();
+}
+
+export function ReviewTask(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ReviewTask'](arg1, arg2, arg3);
+}
+
+export function RevokeAPIToken(arg1) {
+  return window['go']['main']['App']['RevokeAPIToken'](arg1);
+}
+
+export function RollbackUpdate() {
+  return window['go']['main']['App']['RollbackUpdate']();
+}
+
+export function RunCloudBackup() {
+  return window['go']['main']['App']['RunCloudBackup']();
+}
+
+export function RunSelfTest() {
+  return window['go']['main']['App']['RunSelfTest']();
+}
+
+export function SaveSession(arg1) {
+  return window['go']['main']['App']['SaveSession'](arg1);
+}
+
+export function SearchTasks(arg1, arg2) {
+  return window['go']['main']['App']['SearchTasks'](arg1, arg2);
+}
+
+export function SetAccent(arg1, arg2) {
+  return window['go']['main']['App']['SetAccent'](arg1, arg2);
+}
+
+export function SetAccessibility(arg1, arg2) {
+  return window['go']['main']['App']['SetAccessibility'](arg1, arg2);
+}
+
 export function SetAlwaysOnTop(arg1) {
   return window['go']['main']['App']['SetAlwaysOnTop'](arg1);
 }
 
+export function SetCloudBackup(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SetCloudBackup'](arg1, arg2, arg3);
+}
+
 export function SetConciseMode(arg1) {
   return window['go']['main']['App']['SetConciseMode'](arg1);
 }
 
+export function SetDateFormat(arg1) {
+  return window['go']['main']['App']['SetDateFormat'](arg1);
+}
+
+export function SetGamification(arg1) {
+  return window['go']['main']['App']['SetGamification'](arg1);
+}
+
 export function SetHideDone(arg1) {
   return window['go']['main']['App']['SetHideDone'](arg1);
 }
 
+export function SetHotkey(arg1, arg2) {
+  return window['go']['main']['App']['SetHotkey'](arg1, arg2);
+}
+
+export function SetLanguage(arg1) {
+  return window['go']['main']['App']['SetLanguage'](arg1);
+}
+
+export function SetQuadrant(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SetQuadrant'](arg1, arg2, arg3);
+}
+
+export function SetReadOnly(arg1) {
+  return window['go']['main']['App']['SetReadOnly'](arg1);
+}
+
+export function SetShowLunar(arg1) {
+  return window['go']['main']['App']['SetShowLunar'](arg1);
+}
+
+export function SetTaskCover(arg1, arg2) {
+  return window['go']['main']['App']['SetTaskCover'](arg1, arg2);
+}
+
+export function SetTaskRecurrence(arg1, arg2) {
+  return window['go']['main']['App']['SetTaskRecurrence'](arg1, arg2);
+}
+
+export function SetTaskReminder(arg1, arg2) {
+  return window['go']['main']['App']['SetTaskReminder'](arg1, arg2);
+}
+
+export function SetTaskTags(arg1, arg2) {
+  return window['go']['main']['App']['SetTaskTags'](arg1, arg2);
+}
+
 export function SetTheme(arg1) {
   return window['go']['main']['App']['SetTheme'](arg1);
 }
 
+export function SetTimezone(arg1) {
+  return window['go']['main']['App']['SetTimezone'](arg1);
+}
+
+export function SetTrashRetention(arg1) {
+  return window['go']['main']['App']['SetTrashRetention'](arg1);
+}
+
+export function SetUIScale(arg1) {
+  return window['go']['main']['App']['SetUIScale'](arg1);
+}
+
+export function SetUpdateURL(arg1) {
+  return window['go']['main']['App']['SetUpdateURL'](arg1);
+}
+
+export function SetViewFilter(arg1, arg2) {
+  return window['go']['main']['App']['SetViewFilter'](arg1, arg2);
+}
+
 export function SetViewMode(arg1) {
   return window['go']['main']['App']['SetViewMode'](arg1);
 }
 
+export function SetViewSort(arg1, arg2) {
+  return window['go']['main']['App']['SetViewSort'](arg1, arg2);
+}
+
+export function SetWeekStart(arg1) {
+  return window['go']['main']['App']['SetWeekStart'](arg1);
+}
+
+export function ShareGroup(arg1) {
+  return window['go']['main']['App']['ShareGroup'](arg1);
+}
+
+export function ShareSignIn(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['ShareSignIn'](arg1, arg2, arg3, arg4);
+}
+
+export function ShareSignOut() {
+  return window['go']['main']['App']['ShareSignOut']();
+}
+
 export function ShowWaterReminder() {
   return window['go']['main']['App']['ShowWaterReminder']();
 }
 
-export function UpsertGroup(arg1, arg2) {
-  return window['go']['main']['App']['UpsertGroup'](arg1, arg2);
+export function SnoozeReminder(arg1, arg2) {
+  return window['go']['main']['App']['SnoozeReminder'](arg1, arg2);
+}
+
+export function SnoozeWaterReminder(arg1) {
+  return window['go']['main']['App']['SnoozeWaterReminder'](arg1);
+}
+
+export function SolarToLunar(arg1) {
+  return window['go']['main']['App']['SolarToLunar'](arg1);
+}
+
+export function StartPomodoro(arg1, arg2) {
+  return window['go']['main']['App']['StartPomodoro'](arg1, arg2);
+}
+
+export function StartReview() {
+  return window['go']['main']['App']['StartReview']();
+}
+
+export function StartTimer(arg1) {
+  return window['go']['main']['App']['StartTimer'](arg1);
+}
+
+export function StopPomodoro() {
+  return window['go']['main']['App']['StopPomodoro']();
+}
+
+export function StopTimer() {
+  return window['go']['main']['App']['StopTimer']();
+}
+
+export function SyncSharedGroups() {
+  return window['go']['main']['App']['SyncSharedGroups']();
+}
+
+export function TakeCountdownMilestones() {
+  return window['go']['main']['App']['TakeCountdownMilestones']();
+}
+
+export function ToggleSubtask(arg1) {
+  return window['go']['main']['App']['ToggleSubtask'](arg1);
+}
+
+export function UndoLast() {
+  return window['go']['main']['App']['UndoLast']();
+}
+
+export function UnlinkGoalTask(arg1, arg2) {
+  return window['go']['main']['App']['UnlinkGoalTask'](arg1, arg2);
+}
+
+export function UpdateTaskNote(arg1, arg2) {
+  return window['go']['main']['App']['UpdateTaskNote'](arg1, arg2);
+}
+
+export function UpsertGoal(arg1) {
+  return window['go']['main']['App']['UpsertGoal'](arg1);
+}
+
+export function UpsertGroup(arg1) {
+  return window['go']['main']['App']['UpsertGroup'](arg1);
+}
+
+export function UpsertSubtask(arg1, arg2, arg3) {
+  return window['go']['main']['App']['UpsertSubtask'](arg1, arg2, arg3);
 }
 
 export function UpsertTask(arg1) {
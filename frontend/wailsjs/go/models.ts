@@ -1,55 +1,2294 @@
+export namespace holiday {
+	
+	export class Info {
+	    version: string;
+	    years: number[];
+	    custom: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Info(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.version = source["version"];
+	        this.years = source["years"];
+	        this.custom = source["custom"];
+	    }
+	}
+
+}
+
+export namespace lunar {
+	
+	export class Date {
+	    year: number;
+	    month: number;
+	    day: number;
+	    leap: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Date(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.year = source["year"];
+	        this.month = source["month"];
+	        this.day = source["day"];
+	        this.leap = source["leap"];
+	    }
+	}
+
+}
+
+export namespace main {
+	
+	export class AccessibilityPrefs {
+	    highContrast: boolean;
+	    reducedMotion: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new AccessibilityPrefs(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.highContrast = source["highContrast"];
+	        this.reducedMotion = source["reducedMotion"];
+	    }
+	}
+	export class CloudBackupInfo {
+	    config: todo.CloudBackupConfig;
+	    status: todo.CloudBackupStatus;
+	    hasSecret: boolean;
+	    hasPassphrase: boolean;
+	    keychainSupported: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new CloudBackupInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.config = this.convertValues(source["config"], todo.CloudBackupConfig);
+	        this.status = this.convertValues(source["status"], todo.CloudBackupStatus);
+	        this.hasSecret = source["hasSecret"];
+	        this.hasPassphrase = source["hasPassphrase"];
+	        this.keychainSupported = source["keychainSupported"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class FolderUsage {
+	    path: string;
+	    files: number;
+	    bytes: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new FolderUsage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.files = source["files"];
+	        this.bytes = source["bytes"];
+	    }
+	}
+	export class HistoryState {
+	    applied: string;
+	    canUndo: boolean;
+	    canRedo: boolean;
+	    undoLabel: string;
+	    redoLabel: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new HistoryState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.applied = source["applied"];
+	        this.canUndo = source["canUndo"];
+	        this.canRedo = source["canRedo"];
+	        this.undoLabel = source["undoLabel"];
+	        this.redoLabel = source["redoLabel"];
+	    }
+	}
+	export class HotkeyStatus {
+	    action: string;
+	    combo: string;
+	    registered: boolean;
+	    conflict: boolean;
+	    unsupported: boolean;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new HotkeyStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.action = source["action"];
+	        this.combo = source["combo"];
+	        this.registered = source["registered"];
+	        this.conflict = source["conflict"];
+	        this.unsupported = source["unsupported"];
+	        this.error = source["error"];
+	    }
+	}
+	export class NewAPIToken {
+	    token: todo.APIToken;
+	    secret: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new NewAPIToken(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.token = this.convertValues(source["token"], todo.APIToken);
+	        this.secret = source["secret"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ReadOnlyStatus {
+	    readOnly: boolean;
+	    locked: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ReadOnlyStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.readOnly = source["readOnly"];
+	        this.locked = source["locked"];
+	    }
+	}
+	export class SelfTestCheck {
+	    name: string;
+	    status: string;
+	    message: string;
+	    durationMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SelfTestCheck(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.status = source["status"];
+	        this.message = source["message"];
+	        this.durationMs = source["durationMs"];
+	    }
+	}
+	export class SelfTestReport {
+	    passed: boolean;
+	    checks: SelfTestCheck[];
+	    ranAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SelfTestReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.passed = source["passed"];
+	        this.checks = this.convertValues(source["checks"], SelfTestCheck);
+	        this.ranAt = source["ranAt"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ShareStatus {
+	    server: string;
+	    username: string;
+	    loggedIn: boolean;
+	    groups: todo.SharedGroup[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ShareStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.server = source["server"];
+	        this.username = source["username"];
+	        this.loggedIn = source["loggedIn"];
+	        this.groups = this.convertValues(source["groups"], todo.SharedGroup);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ShareSyncResult {
+	    groups: number;
+	    applied: number;
+	    errors: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ShareSyncResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.groups = source["groups"];
+	        this.applied = source["applied"];
+	        this.errors = source["errors"];
+	    }
+	}
+	export class StorageUsage {
+	    data: todo.UsageStats;
+	    dataDir: string;
+	    databaseBytes: number;
+	    backups: FolderUsage;
+	    attachments: FolderUsage;
+	    rollback: FolderUsage;
+	    suggestions: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new StorageUsage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.data = this.convertValues(source["data"], todo.UsageStats);
+	        this.dataDir = source["dataDir"];
+	        this.databaseBytes = source["databaseBytes"];
+	        this.backups = this.convertValues(source["backups"], FolderUsage);
+	        this.attachments = this.convertValues(source["attachments"], FolderUsage);
+	        this.rollback = this.convertValues(source["rollback"], FolderUsage);
+	        this.suggestions = source["suggestions"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SubTaskToggle {
+	    subTask: todo.Task;
+	    parent: todo.Task;
+	
+	    static createFrom(source: any = {}) {
+	        return new SubTaskToggle(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.subTask = this.convertValues(source["subTask"], todo.Task);
+	        this.parent = this.convertValues(source["parent"], todo.Task);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ThemeChange {
+	    theme: string;
+	    system: string;
+	    highContrast: boolean;
+	    reducedMotion: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ThemeChange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.theme = source["theme"];
+	        this.system = source["system"];
+	        this.highContrast = source["highContrast"];
+	        this.reducedMotion = source["reducedMotion"];
+	    }
+	}
+	export class TimezoneInfo {
+	    setting: string;
+	    effective: string;
+	    system: string;
+	    offset: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TimezoneInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.setting = source["setting"];
+	        this.effective = source["effective"];
+	        this.system = source["system"];
+	        this.offset = source["offset"];
+	    }
+	}
+	export class TodoTxtExport {
+	    path: string;
+	    summary: todo.TodoTxtExportSummary;
+	
+	    static createFrom(source: any = {}) {
+	        return new TodoTxtExport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.summary = this.convertValues(source["summary"], todo.TodoTxtExportSummary);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace s3 {
+	
+	export class Object {
+	    key: string;
+	    size: number;
+	    // Go type: time
+	    lastModified: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Object(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.key = source["key"];
+	        this.size = source["size"];
+	        this.lastModified = this.convertValues(source["lastModified"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
 export namespace todo {
 	
-	export class Settings {
-	    hideDone: boolean;
-	    alwaysOnTop: boolean;
-	    viewMode: string;
-	    conciseMode: boolean;
-	    theme: string;
+	export class APIToken {
+	    id: number;
+	    name: string;
+	    scope: string;
+	    hint: string;
+	    createdAt: number;
+	    lastUsedAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new APIToken(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.scope = source["scope"];
+	        this.hint = source["hint"];
+	        this.createdAt = source["createdAt"];
+	        this.lastUsedAt = source["lastUsedAt"];
+	    }
+	}
+	export class Achievement {
+	    id: string;
+	    goal: number;
+	    progress: number;
+	    unlocked: boolean;
+	    unlockedAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Achievement(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.goal = source["goal"];
+	        this.progress = source["progress"];
+	        this.unlocked = source["unlocked"];
+	        this.unlockedAt = source["unlockedAt"];
+	    }
+	}
+	export class Activity {
+	    id: number;
+	    action: string;
+	    taskId: number;
+	    groupId: number;
+	    groupName: string;
+	    title: string;
+	    detail: string;
+	    text: string;
+	    createdAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Activity(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.action = source["action"];
+	        this.taskId = source["taskId"];
+	        this.groupId = source["groupId"];
+	        this.groupName = source["groupName"];
+	        this.title = source["title"];
+	        this.detail = source["detail"];
+	        this.text = source["text"];
+	        this.createdAt = source["createdAt"];
+	    }
+	}
+	export class ActivityPage {
+	    items: Activity[];
+	    nextCursor: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ActivityPage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.items = this.convertValues(source["items"], Activity);
+	        this.nextCursor = source["nextCursor"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TaskNote {
+	    id: number;
+	    taskId: number;
+	    body: string;
+	    createdAt: number;
+	    updatedAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TaskNote(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.taskId = source["taskId"];
+	        this.body = source["body"];
+	        this.createdAt = source["createdAt"];
+	        this.updatedAt = source["updatedAt"];
+	    }
+	}
+	export class LengthWarning {
+	    field: string;
+	    limit: number;
+	    length: number;
+	    truncated: boolean;
+	    overflow?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LengthWarning(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.field = source["field"];
+	        this.limit = source["limit"];
+	        this.length = source["length"];
+	        this.truncated = source["truncated"];
+	        this.overflow = source["overflow"];
+	    }
+	}
+	export class Progress {
+	    done: number;
+	    total: number;
+	    percent: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Progress(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.done = source["done"];
+	        this.total = source["total"];
+	        this.percent = source["percent"];
+	    }
+	}
+	export class Task {
+	    id: number;
+	    groupId: number;
+	    parentId: number;
+	    title: string;
+	    content: string;
+	    status: string;
+	    important: boolean;
+	    urgent: boolean;
+	    color: string;
+	    emoji: string;
+	    url: string;
+	    dueAt: number;
+	    completedAt: number;
+	    createdAt: number;
+	    updatedAt: number;
+	    subTasks?: Task[];
+	    contentFormat: string;
+	    contentTruncated: boolean;
+	    contentChanged?: boolean;
+	    coverId: number;
+	    progress?: Progress;
+	    warnings?: LengthWarning[];
+	    nextOccurrenceId?: number;
+	    dueLunar?: string;
+	    countdown: boolean;
+	    daysLeft?: number;
+	    statusSince?: number;
+	    daysInStatus: number;
+	    tags?: string[];
+	    latestNote?: TaskNote;
+	    noteCount?: number;
+	    blocked: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Task(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.groupId = source["groupId"];
+	        this.parentId = source["parentId"];
+	        this.title = source["title"];
+	        this.content = source["content"];
+	        this.status = source["status"];
+	        this.important = source["important"];
+	        this.urgent = source["urgent"];
+	        this.color = source["color"];
+	        this.emoji = source["emoji"];
+	        this.url = source["url"];
+	        this.dueAt = source["dueAt"];
+	        this.completedAt = source["completedAt"];
+	        this.createdAt = source["createdAt"];
+	        this.updatedAt = source["updatedAt"];
+	        this.subTasks = this.convertValues(source["subTasks"], Task);
+	        this.contentFormat = source["contentFormat"];
+	        this.contentTruncated = source["contentTruncated"];
+	        this.contentChanged = source["contentChanged"];
+	        this.coverId = source["coverId"];
+	        this.progress = this.convertValues(source["progress"], Progress);
+	        this.warnings = this.convertValues(source["warnings"], LengthWarning);
+	        this.nextOccurrenceId = source["nextOccurrenceId"];
+	        this.dueLunar = source["dueLunar"];
+	        this.countdown = source["countdown"];
+	        this.daysLeft = source["daysLeft"];
+	        this.statusSince = source["statusSince"];
+	        this.daysInStatus = source["daysInStatus"];
+	        this.tags = source["tags"];
+	        this.latestNote = this.convertValues(source["latestNote"], TaskNote);
+	        this.noteCount = source["noteCount"];
+	        this.blocked = source["blocked"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class AgendaItem {
+	    task: Task;
+	    time: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new AgendaItem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.task = this.convertValues(source["task"], Task);
+	        this.time = source["time"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class AgendaDay {
+	    date: string;
+	    weekday: number;
+	    label: string;
+	    items: AgendaItem[];
+	
+	    static createFrom(source: any = {}) {
+	        return new AgendaDay(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.weekday = source["weekday"];
+	        this.label = source["label"];
+	        this.items = this.convertValues(source["items"], AgendaItem);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class Group {
+	    id: number;
+	    name: string;
+	    parentId: number;
+	    archived: boolean;
+	    favorite: boolean;
+	    color: string;
+	    icon: string;
+	    sortOrder: number;
+	    createdAt: number;
+	    updatedAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Group(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.parentId = source["parentId"];
+	        this.archived = source["archived"];
+	        this.favorite = source["favorite"];
+	        this.color = source["color"];
+	        this.icon = source["icon"];
+	        this.sortOrder = source["sortOrder"];
+	        this.createdAt = source["createdAt"];
+	        this.updatedAt = source["updatedAt"];
+	    }
+	}
+	export class Archive {
+	    groups: Group[];
+	    tasks: Task[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Archive(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.groups = this.convertValues(source["groups"], Group);
+	        this.tasks = this.convertValues(source["tasks"], Task);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Attachment {
+	    id: number;
+	    taskId: number;
+	    file: string;
+	    mime: string;
+	    size: number;
+	    width: number;
+	    height: number;
+	    createdAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Attachment(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.taskId = source["taskId"];
+	        this.file = source["file"];
+	        this.mime = source["mime"];
+	        this.size = source["size"];
+	        this.width = source["width"];
+	        this.height = source["height"];
+	        this.createdAt = source["createdAt"];
+	    }
+	}
+	export class Tag {
+	    id: number;
+	    name: string;
+	    taskCount: number;
+	    createdAt: number;
+	    updatedAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Tag(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.taskCount = source["taskCount"];
+	        this.createdAt = source["createdAt"];
+	        this.updatedAt = source["updatedAt"];
+	    }
+	}
+	export class ThemePreset {
+	    name: string;
+	    accent: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ThemePreset(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.accent = source["accent"];
+	    }
+	}
+	export class ViewFilter {
+	    groupId: number;
+	    status: string;
+	    tags: string[];
+	    search: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ViewFilter(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.groupId = source["groupId"];
+	        this.status = source["status"];
+	        this.tags = source["tags"];
+	        this.search = source["search"];
+	    }
+	}
+	export class Settings {
+	    hideDone: boolean;
+	    alwaysOnTop: boolean;
+	    viewMode: string;
+	    conciseMode: boolean;
+	    theme: string;
+	    updateUrl: string;
+	    language: string;
+	    dateFormat: string;
+	    weekStart: string;
+	    timezone: string;
+	    uiScale: string;
+	    themePreset: string;
+	    accentColor: string;
+	    highContrast: string;
+	    reducedMotion: string;
+	    hotkeyShowWindow: string;
+	    hotkeyQuickAdd: string;
+	    viewSorts: Record<string, string>;
+	    viewFilters: Record<string, ViewFilter>;
+	    gamification: boolean;
+	    showLunar: boolean;
+	    countdownReminders: boolean;
+	    trashRetentionDays: number;
+	    contentLimit: number;
+	    titleLimit: number;
+	    groupNameLimit: number;
+	    lengthLimitMode: string;
+	    apiPort: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Settings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hideDone = source["hideDone"];
+	        this.alwaysOnTop = source["alwaysOnTop"];
+	        this.viewMode = source["viewMode"];
+	        this.conciseMode = source["conciseMode"];
+	        this.theme = source["theme"];
+	        this.updateUrl = source["updateUrl"];
+	        this.language = source["language"];
+	        this.dateFormat = source["dateFormat"];
+	        this.weekStart = source["weekStart"];
+	        this.timezone = source["timezone"];
+	        this.uiScale = source["uiScale"];
+	        this.themePreset = source["themePreset"];
+	        this.accentColor = source["accentColor"];
+	        this.highContrast = source["highContrast"];
+	        this.reducedMotion = source["reducedMotion"];
+	        this.hotkeyShowWindow = source["hotkeyShowWindow"];
+	        this.hotkeyQuickAdd = source["hotkeyQuickAdd"];
+	        this.viewSorts = source["viewSorts"];
+	        this.viewFilters = this.convertValues(source["viewFilters"], ViewFilter, true);
+	        this.gamification = source["gamification"];
+	        this.showLunar = source["showLunar"];
+	        this.countdownReminders = source["countdownReminders"];
+	        this.trashRetentionDays = source["trashRetentionDays"];
+	        this.contentLimit = source["contentLimit"];
+	        this.titleLimit = source["titleLimit"];
+	        this.groupNameLimit = source["groupNameLimit"];
+	        this.lengthLimitMode = source["lengthLimitMode"];
+	        this.apiPort = source["apiPort"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Board {
+	    groups: Group[];
+	    tasks: Task[];
+	    archivedGroups: Group[];
+	    settings: Settings;
+	    statuses: string[];
+	    filter: ViewFilter;
+	    accent: string;
+	    themePresets: ThemePreset[];
+	    tags: Tag[];
+	    syncedAt: number;
+	    delta: boolean;
+	    deletedGroupIds: number[];
+	    deletedTaskIds: number[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Board(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.groups = this.convertValues(source["groups"], Group);
+	        this.tasks = this.convertValues(source["tasks"], Task);
+	        this.archivedGroups = this.convertValues(source["archivedGroups"], Group);
+	        this.settings = this.convertValues(source["settings"], Settings);
+	        this.statuses = source["statuses"];
+	        this.filter = this.convertValues(source["filter"], ViewFilter);
+	        this.accent = source["accent"];
+	        this.themePresets = this.convertValues(source["themePresets"], ThemePreset);
+	        this.tags = this.convertValues(source["tags"], Tag);
+	        this.syncedAt = source["syncedAt"];
+	        this.delta = source["delta"];
+	        this.deletedGroupIds = source["deletedGroupIds"];
+	        this.deletedTaskIds = source["deletedTaskIds"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class VelocityWeek {
+	    weekStart: string;
+	    completed: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new VelocityWeek(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.weekStart = source["weekStart"];
+	        this.completed = source["completed"];
+	    }
+	}
+	export class BurndownPoint {
+	    date: string;
+	    open: number;
+	    closed: number;
+	    created: number;
+	    completed: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new BurndownPoint(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.open = source["open"];
+	        this.closed = source["closed"];
+	        this.created = source["created"];
+	        this.completed = source["completed"];
+	    }
+	}
+	export class Burndown {
+	    groupId: number;
+	    range: string;
+	    points: BurndownPoint[];
+	    weeks: VelocityWeek[];
+	    avgPerWeek: number;
+	    completionPct: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Burndown(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.groupId = source["groupId"];
+	        this.range = source["range"];
+	        this.points = this.convertValues(source["points"], BurndownPoint);
+	        this.weeks = this.convertValues(source["weeks"], VelocityWeek);
+	        this.avgPerWeek = source["avgPerWeek"];
+	        this.completionPct = source["completionPct"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class CloudBackupConfig {
+	    enabled: boolean;
+	    endpoint: string;
+	    region: string;
+	    bucket: string;
+	    prefix: string;
+	    accessKey: string;
+	    pathStyle: boolean;
+	    encrypt: boolean;
+	    hours: number;
+	    keep: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CloudBackupConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.endpoint = source["endpoint"];
+	        this.region = source["region"];
+	        this.bucket = source["bucket"];
+	        this.prefix = source["prefix"];
+	        this.accessKey = source["accessKey"];
+	        this.pathStyle = source["pathStyle"];
+	        this.encrypt = source["encrypt"];
+	        this.hours = source["hours"];
+	        this.keep = source["keep"];
+	    }
+	}
+	export class CloudBackupStatus {
+	    lastBackupAt: number;
+	    lastKey: string;
+	    lastError: string;
+	    lastErrorAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CloudBackupStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.lastBackupAt = source["lastBackupAt"];
+	        this.lastKey = source["lastKey"];
+	        this.lastError = source["lastError"];
+	        this.lastErrorAt = source["lastErrorAt"];
+	    }
+	}
+	export class CountdownMilestone {
+	    task: Task;
+	    daysLeft: number;
+	    milestone: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CountdownMilestone(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.task = this.convertValues(source["task"], Task);
+	        this.daysLeft = source["daysLeft"];
+	        this.milestone = source["milestone"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class FocusDay {
+	    date: string;
+	    minutes: number;
+	    pomodoros: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new FocusDay(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.minutes = source["minutes"];
+	        this.pomodoros = source["pomodoros"];
+	    }
+	}
+	export class FocusGroup {
+	    groupId: number;
+	    name: string;
+	    minutes: number;
+	    percent: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new FocusGroup(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.groupId = source["groupId"];
+	        this.name = source["name"];
+	        this.minutes = source["minutes"];
+	        this.percent = source["percent"];
+	    }
+	}
+	export class FocusStats {
+	    range: string;
+	    totalMinutes: number;
+	    pomodoros: number;
+	    days: FocusDay[];
+	    bestDay?: FocusDay;
+	    groups: FocusGroup[];
+	
+	    static createFrom(source: any = {}) {
+	        return new FocusStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.range = source["range"];
+	        this.totalMinutes = source["totalMinutes"];
+	        this.pomodoros = source["pomodoros"];
+	        this.days = this.convertValues(source["days"], FocusDay);
+	        this.bestDay = this.convertValues(source["bestDay"], FocusDay);
+	        this.groups = this.convertValues(source["groups"], FocusGroup);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class GameStats {
+	    points: number;
+	    level: number;
+	    levelPoints: number;
+	    nextLevelPoints: number;
+	    completions: number;
+	    streak: number;
+	    achievements: Achievement[];
+	    newlyUnlocked: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new GameStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.points = source["points"];
+	        this.level = source["level"];
+	        this.levelPoints = source["levelPoints"];
+	        this.nextLevelPoints = source["nextLevelPoints"];
+	        this.completions = source["completions"];
+	        this.streak = source["streak"];
+	        this.achievements = this.convertValues(source["achievements"], Achievement);
+	        this.newlyUnlocked = source["newlyUnlocked"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Goal {
+	    id: number;
+	    title: string;
+	    targetDate: number;
+	    targetCount: number;
+	    taskIds: number[];
+	    done: number;
+	    target: number;
+	    percent: number;
+	    createdAt: number;
+	    updatedAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Goal(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.title = source["title"];
+	        this.targetDate = source["targetDate"];
+	        this.targetCount = source["targetCount"];
+	        this.taskIds = source["taskIds"];
+	        this.done = source["done"];
+	        this.target = source["target"];
+	        this.percent = source["percent"];
+	        this.createdAt = source["createdAt"];
+	        this.updatedAt = source["updatedAt"];
+	    }
+	}
+	
+	export class GroupNode {
+	    id: number;
+	    name: string;
+	    parentId: number;
+	    archived: boolean;
+	    favorite: boolean;
+	    color: string;
+	    icon: string;
+	    sortOrder: number;
+	    createdAt: number;
+	    updatedAt: number;
+	    children: GroupNode[];
+	
+	    static createFrom(source: any = {}) {
+	        return new GroupNode(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.parentId = source["parentId"];
+	        this.archived = source["archived"];
+	        this.favorite = source["favorite"];
+	        this.color = source["color"];
+	        this.icon = source["icon"];
+	        this.sortOrder = source["sortOrder"];
+	        this.createdAt = source["createdAt"];
+	        this.updatedAt = source["updatedAt"];
+	        this.children = this.convertValues(source["children"], GroupNode);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class HostedGroup {
+	    shareId: string;
+	    name: string;
+	    owner: string;
+	    members: string[];
+	    createdAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new HostedGroup(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.shareId = source["shareId"];
+	        this.name = source["name"];
+	        this.owner = source["owner"];
+	        this.members = source["members"];
+	        this.createdAt = source["createdAt"];
+	    }
+	}
+	export class ImportLoss {
+	    attachments: number;
+	    goalLinks: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ImportLoss(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.attachments = source["attachments"];
+	        this.goalLinks = source["goalLinks"];
+	    }
+	}
+	export class ImportRowError {
+	    row: number;
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ImportRowError(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.row = source["row"];
+	        this.message = source["message"];
+	    }
+	}
+	export class ImportSummary {
+	    mode: string;
+	    groups: number;
+	    mergedGroups: number;
+	    tasks: number;
+	    skippedTasks: number;
+	    tags: number;
+	    settingsSaved: boolean;
+	    skippedSettings: string[];
+	    truncated: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ImportSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.mode = source["mode"];
+	        this.groups = source["groups"];
+	        this.mergedGroups = source["mergedGroups"];
+	        this.tasks = source["tasks"];
+	        this.skippedTasks = source["skippedTasks"];
+	        this.tags = source["tags"];
+	        this.settingsSaved = source["settingsSaved"];
+	        this.skippedSettings = source["skippedSettings"];
+	        this.truncated = source["truncated"];
+	    }
+	}
+	
+	export class LunarDate {
+	    year: number;
+	    month: number;
+	    day: number;
+	    leap: boolean;
+	    solar: string;
+	    yearName: string;
+	    text: string;
+	    festival: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LunarDate(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.year = source["year"];
+	        this.month = source["month"];
+	        this.day = source["day"];
+	        this.leap = source["leap"];
+	        this.solar = source["solar"];
+	        this.yearName = source["yearName"];
+	        this.text = source["text"];
+	        this.festival = source["festival"];
+	    }
+	}
+	export class Notification {
+	    id: number;
+	    kind: string;
+	    title: string;
+	    body: string;
+	    taskId: number;
+	    data?: Record<string, any>;
+	    createdAt: number;
+	    readAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Notification(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.kind = source["kind"];
+	        this.title = source["title"];
+	        this.body = source["body"];
+	        this.taskId = source["taskId"];
+	        this.data = source["data"];
+	        this.createdAt = source["createdAt"];
+	        this.readAt = source["readAt"];
+	    }
+	}
+	export class NotificationPage {
+	    items: Notification[];
+	    nextCursor: number;
+	    unread: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new NotificationPage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.items = this.convertValues(source["items"], Notification);
+	        this.nextCursor = source["nextCursor"];
+	        this.unread = source["unread"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class OverdueItem {
+	    task: Task;
+	    daysOverdue: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new OverdueItem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.task = this.convertValues(source["task"], Task);
+	        this.daysOverdue = source["daysOverdue"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Overdue {
+	    count: number;
+	    items: OverdueItem[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Overdue(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.count = source["count"];
+	        this.items = this.convertValues(source["items"], OverdueItem);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class PomodoroSession {
+	    id: number;
+	    taskId: number;
+	    taskTitle: string;
+	    state: string;
+	    plannedSeconds: number;
+	    focusedSeconds: number;
+	    remainingSeconds: number;
+	    startedAt: number;
+	    endedAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PomodoroSession(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.taskId = source["taskId"];
+	        this.taskTitle = source["taskTitle"];
+	        this.state = source["state"];
+	        this.plannedSeconds = source["plannedSeconds"];
+	        this.focusedSeconds = source["focusedSeconds"];
+	        this.remainingSeconds = source["remainingSeconds"];
+	        this.startedAt = source["startedAt"];
+	        this.endedAt = source["endedAt"];
+	    }
+	}
+	
+	export class Quadrant {
+	    key: string;
+	    important: boolean;
+	    urgent: boolean;
+	    count: number;
+	    openCount: number;
+	    tasks: Task[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Quadrant(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.key = source["key"];
+	        this.important = source["important"];
+	        this.urgent = source["urgent"];
+	        this.count = source["count"];
+	        this.openCount = source["openCount"];
+	        this.tasks = this.convertValues(source["tasks"], Task);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RecentTask {
+	    task: Task;
+	    groupName: string;
+	    kind: string;
+	    visitedAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RecentTask(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.task = this.convertValues(source["task"], Task);
+	        this.groupName = source["groupName"];
+	        this.kind = source["kind"];
+	        this.visitedAt = source["visitedAt"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Recurrence {
+	    taskId: number;
+	    freq: string;
+	    interval: number;
+	    mode: string;
+	    createdAt: number;
+	    updatedAt: number;
+	    skipHolidays: boolean;
+	    lunar: boolean;
+	    weekdays?: number[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Recurrence(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.taskId = source["taskId"];
+	        this.freq = source["freq"];
+	        this.interval = source["interval"];
+	        this.mode = source["mode"];
+	        this.createdAt = source["createdAt"];
+	        this.updatedAt = source["updatedAt"];
+	        this.skipHolidays = source["skipHolidays"];
+	        this.lunar = source["lunar"];
+	        this.weekdays = source["weekdays"];
+	    }
+	}
+	export class Reminder {
+	    id: number;
+	    taskId: number;
+	    taskTitle: string;
+	    remindAt: number;
+	    firedAt: number;
+	    createdAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Reminder(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.taskId = source["taskId"];
+	        this.taskTitle = source["taskTitle"];
+	        this.remindAt = source["remindAt"];
+	        this.firedAt = source["firedAt"];
+	        this.createdAt = source["createdAt"];
+	    }
+	}
+	export class ReviewItem {
+	    task: Task;
+	    reasons: string[];
+	    remaining: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ReviewItem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.task = this.convertValues(source["task"], Task);
+	        this.reasons = source["reasons"];
+	        this.remaining = source["remaining"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ReviewStatus {
+	    lastReviewAt: number;
+	    startedAt: number;
+	    pending: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ReviewStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.lastReviewAt = source["lastReviewAt"];
+	        this.startedAt = source["startedAt"];
+	        this.pending = source["pending"];
+	    }
+	}
+	export class SearchHit {
+	    task: Task;
+	    groupName: string;
+	    location: string;
+	    trashId: number;
+	    deletedAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SearchHit(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.task = this.convertValues(source["task"], Task);
+	        this.groupName = source["groupName"];
+	        this.location = source["location"];
+	        this.trashId = source["trashId"];
+	        this.deletedAt = source["deletedAt"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SearchResult {
+	    query: string;
+	    hits: SearchHit[];
+	    truncated: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new SearchResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.query = source["query"];
+	        this.hits = this.convertValues(source["hits"], SearchHit);
+	        this.truncated = source["truncated"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SessionState {
+	    groupId: number;
+	    view: string;
+	    scrollTaskId: number;
+	    editingTaskId: number;
+	    savedAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SessionState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.groupId = source["groupId"];
+	        this.view = source["view"];
+	        this.scrollTaskId = source["scrollTaskId"];
+	        this.editingTaskId = source["editingTaskId"];
+	        this.savedAt = source["savedAt"];
+	    }
+	}
+	
+	export class SharedGroup {
+	    groupId: number;
+	    groupName: string;
+	    shareId: string;
+	    rev: number;
+	    pending: number;
+	    syncedAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SharedGroup(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.groupId = source["groupId"];
+	        this.groupName = source["groupName"];
+	        this.shareId = source["shareId"];
+	        this.rev = source["rev"];
+	        this.pending = source["pending"];
+	        this.syncedAt = source["syncedAt"];
+	    }
+	}
+	export class StatusChange {
+	    from: string;
+	    to: string;
+	    changedAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new StatusChange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.from = source["from"];
+	        this.to = source["to"];
+	        this.changedAt = source["changedAt"];
+	    }
+	}
+	export class SwimlaneCell {
+	    status: string;
+	    count: number;
+	    tasks: Task[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SwimlaneCell(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.status = source["status"];
+	        this.count = source["count"];
+	        this.tasks = this.convertValues(source["tasks"], Task);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Swimlane {
+	    id: number;
+	    name: string;
+	    count: number;
+	    cells: SwimlaneCell[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Swimlane(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.count = source["count"];
+	        this.cells = this.convertValues(source["cells"], SwimlaneCell);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class Swimlanes {
+	    by: string;
+	    statuses: string[];
+	    lanes: Swimlane[];
 	
 	    static createFrom(source: any = {}) {
-	        return new Settings(source);
+	        return new Swimlanes(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.hideDone = source["hideDone"];
-	        this.alwaysOnTop = source["alwaysOnTop"];
-	        this.viewMode = source["viewMode"];
-	        this.conciseMode = source["conciseMode"];
-	        this.theme = source["theme"];
+	        this.by = source["by"];
+	        this.statuses = source["statuses"];
+	        this.lanes = this.convertValues(source["lanes"], Swimlane);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class Task {
+	
+	
+	export class TaskDependencies {
+	    blockedBy: Task[];
+	    blocking: Task[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TaskDependencies(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.blockedBy = this.convertValues(source["blockedBy"], Task);
+	        this.blocking = this.convertValues(source["blocking"], Task);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TaskDetail {
+	    task: Task;
+	    contentHtml: string;
+	    links: string[];
+	    tags: Tag[];
+	    attachments: Attachment[];
+	    recurrence?: Recurrence;
+	    statusHistory: StatusChange[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TaskDetail(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.task = this.convertValues(source["task"], Task);
+	        this.contentHtml = source["contentHtml"];
+	        this.links = source["links"];
+	        this.tags = this.convertValues(source["tags"], Tag);
+	        this.attachments = this.convertValues(source["attachments"], Attachment);
+	        this.recurrence = this.convertValues(source["recurrence"], Recurrence);
+	        this.statusHistory = this.convertValues(source["statusHistory"], StatusChange);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TaskImportSummary {
+	    created: number;
+	    skipped: number;
+	    errored: number;
+	    errors: ImportRowError[];
+	    createdGroups: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TaskImportSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.created = source["created"];
+	        this.skipped = source["skipped"];
+	        this.errored = source["errored"];
+	        this.errors = this.convertValues(source["errors"], ImportRowError);
+	        this.createdGroups = source["createdGroups"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class TimeEntry {
 	    id: number;
-	    groupId: number;
-	    parentId: number;
-	    title: string;
-	    content: string;
-	    status: string;
-	    important: boolean;
-	    urgent: boolean;
-	    createdAt: number;
-	    updatedAt: number;
-	    subTasks?: Task[];
+	    taskId: number;
+	    taskTitle: string;
+	    source: string;
+	    startedAt: number;
+	    endedAt: number;
+	    seconds: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new Task(source);
+	        return new TimeEntry(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
-	        this.groupId = source["groupId"];
-	        this.parentId = source["parentId"];
+	        this.taskId = source["taskId"];
+	        this.taskTitle = source["taskTitle"];
+	        this.source = source["source"];
+	        this.startedAt = source["startedAt"];
+	        this.endedAt = source["endedAt"];
+	        this.seconds = source["seconds"];
+	    }
+	}
+	export class TimeReportTask {
+	    taskId: number;
+	    title: string;
+	    group: string;
+	    seconds: number;
+	    percent: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TimeReportTask(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.taskId = source["taskId"];
 	        this.title = source["title"];
-	        this.content = source["content"];
-	        this.status = source["status"];
-	        this.important = source["important"];
-	        this.urgent = source["urgent"];
-	        this.createdAt = source["createdAt"];
-	        this.updatedAt = source["updatedAt"];
-	        this.subTasks = this.convertValues(source["subTasks"], Task);
+	        this.group = source["group"];
+	        this.seconds = source["seconds"];
+	        this.percent = source["percent"];
+	    }
+	}
+	export class TimeReport {
+	    from: string;
+	    to: string;
+	    totalSeconds: number;
+	    tasks: TimeReportTask[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TimeReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.from = source["from"];
+	        this.to = source["to"];
+	        this.totalSeconds = source["totalSeconds"];
+	        this.tasks = this.convertValues(source["tasks"], TimeReportTask);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -70,40 +2309,43 @@ export namespace todo {
 		    return a;
 		}
 	}
-	export class Group {
-	    id: number;
-	    name: string;
-	    createdAt: number;
-	    updatedAt: number;
+	
+	export class TimeSpent {
+	    taskId: number;
+	    seconds: number;
+	    entries: number;
+	    running: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new Group(source);
+	        return new TimeSpent(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.name = source["name"];
-	        this.createdAt = source["createdAt"];
-	        this.updatedAt = source["updatedAt"];
+	        this.taskId = source["taskId"];
+	        this.seconds = source["seconds"];
+	        this.entries = source["entries"];
+	        this.running = source["running"];
 	    }
 	}
-	export class Board {
-	    groups: Group[];
-	    tasks: Task[];
-	    settings: Settings;
-	    statuses: string[];
+	export class Today {
+	    date: string;
+	    overdue: Task[];
+	    dueToday: Task[];
+	    myDay: Task[];
+	    count: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new Board(source);
+	        return new Today(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.groups = this.convertValues(source["groups"], Group);
-	        this.tasks = this.convertValues(source["tasks"], Task);
-	        this.settings = this.convertValues(source["settings"], Settings);
-	        this.statuses = source["statuses"];
+	        this.date = source["date"];
+	        this.overdue = this.convertValues(source["overdue"], Task);
+	        this.dueToday = this.convertValues(source["dueToday"], Task);
+	        this.myDay = this.convertValues(source["myDay"], Task);
+	        this.count = source["count"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -124,19 +2366,150 @@ export namespace todo {
 		    return a;
 		}
 	}
+	export class TodoTxtExportSummary {
+	    tasks: number;
+	    contentDropped: number;
+	    subtasksFlattened: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TodoTxtExportSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.tasks = source["tasks"];
+	        this.contentDropped = source["contentDropped"];
+	        this.subtasksFlattened = source["subtasksFlattened"];
+	    }
+	}
+	export class TrashItem {
+	    id: number;
+	    kind: string;
+	    itemId: number;
+	    title: string;
+	    taskCount: number;
+	    deletedAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TrashItem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.kind = source["kind"];
+	        this.itemId = source["itemId"];
+	        this.title = source["title"];
+	        this.taskCount = source["taskCount"];
+	        this.deletedAt = source["deletedAt"];
+	    }
+	}
+	export class UsageStats {
+	    groups: number;
+	    tasks: number;
+	    subTasks: number;
+	    doneTasks: number;
+	    databaseBytes: number;
+	    freeBytes: number;
+	    oldestTaskAt: number;
+	    newestTaskAt: number;
+	    oldestDoneAt: number;
+	    goals: number;
+	    goalsAchieved: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new UsageStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.groups = source["groups"];
+	        this.tasks = source["tasks"];
+	        this.subTasks = source["subTasks"];
+	        this.doneTasks = source["doneTasks"];
+	        this.databaseBytes = source["databaseBytes"];
+	        this.freeBytes = source["freeBytes"];
+	        this.oldestTaskAt = source["oldestTaskAt"];
+	        this.newestTaskAt = source["newestTaskAt"];
+	        this.oldestDoneAt = source["oldestDoneAt"];
+	        this.goals = source["goals"];
+	        this.goalsAchieved = source["goalsAchieved"];
+	    }
+	}
+	
+	
+	export class WeekAgenda {
+	    start: string;
+	    end: string;
+	    days: AgendaDay[];
 	
+	    static createFrom(source: any = {}) {
+	        return new WeekAgenda(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.start = source["start"];
+	        this.end = source["end"];
+	        this.days = this.convertValues(source["days"], AgendaDay);
+	    }
 	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 
 }
 
 export namespace version {
 	
+	export class BuildInfo {
+	    version: string;
+	    commit: string;
+	    dirty: boolean;
+	    buildDate: string;
+	    builder: string;
+	    goVersion: string;
+	    platform: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BuildInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.version = source["version"];
+	        this.commit = source["commit"];
+	        this.dirty = source["dirty"];
+	        this.buildDate = source["buildDate"];
+	        this.builder = source["builder"];
+	        this.goVersion = source["goVersion"];
+	        this.platform = source["platform"];
+	    }
+	}
 	export class ReleaseInfo {
 	    version: string;
 	    name: string;
 	    description: string;
 	    publishedAt: string;
 	    downloadUrl: string;
+	    assetName: string;
+	    checksumUrl: string;
+	    signatureUrl: string;
 	    pageUrl: string;
 	    required: boolean;
 	
@@ -151,10 +2524,31 @@ export namespace version {
 	        this.description = source["description"];
 	        this.publishedAt = source["publishedAt"];
 	        this.downloadUrl = source["downloadUrl"];
+	        this.assetName = source["assetName"];
+	        this.checksumUrl = source["checksumUrl"];
+	        this.signatureUrl = source["signatureUrl"];
 	        this.pageUrl = source["pageUrl"];
 	        this.required = source["required"];
 	    }
 	}
+	export class RollbackInfo {
+	    version: string;
+	    savedAt: number;
+	    path: string;
+	    database?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RollbackInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.version = source["version"];
+	        this.savedAt = source["savedAt"];
+	        this.path = source["path"];
+	        this.database = source["database"];
+	    }
+	}
 	export class UpdateCheckResult {
 	    hasUpdate: boolean;
 	    currentVersion: string;
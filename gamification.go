@@ -0,0 +1,64 @@
+package main
+
+import (
+	"time"
+
+	"spark-todo/internal/todo"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// GetAchievements 返回积分、等级与成就进度（未开启积分功能时仍返回已有记录）。
+func (a *App) GetAchievements() (todo.GameStats, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.GameStats{}, err
+	}
+	settings, err := a.store.GetSettings(a.ctx)
+	if err != nil {
+		return todo.GameStats{}, a.localize(err)
+	}
+	st, err := a.store.GetGameStats(a.ctx, time.Now(), todo.Location(settings))
+	return st, a.localize(err)
+}
+
+// SetGamification 开启或关闭积分与成就功能。
+func (a *App) SetGamification(enabled bool) (todo.Settings, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.Settings{}, err
+	}
+
+	settings, err := a.store.GetSettings(a.ctx)
+	if err != nil {
+		return todo.Settings{}, a.localize(err)
+	}
+	settings.Gamification = enabled
+	if err := a.store.SetSettings(a.ctx, settings); err != nil {
+		return todo.Settings{}, a.localize(err)
+	}
+	return a.reloadSettings()
+}
+
+// awardCompletion 在开启积分功能时为刚完成的任务计分；解锁新成就时通过 achievements:unlocked 事件通知前端。
+//
+// 计分失败只记录日志，不影响任务保存本身。
+func (a *App) awardCompletion(t todo.Task) {
+	settings, err := a.store.GetSettings(a.ctx)
+	if err != nil || !settings.Gamification {
+		return
+	}
+	st, err := a.store.AwardCompletion(a.ctx, t, time.Now(), todo.Location(settings))
+	if err != nil {
+		runtime.LogErrorf(a.ctx, "failed to award points: %v", err)
+		return
+	}
+	if len(st.NewlyUnlocked) > 0 {
+		runtime.EventsEmit(a.ctx, "achievements:unlocked", st)
+		a.notify(a.ctx, todo.Notification{
+			Kind:   todo.NotificationAchievement,
+			Title:  a.tr("notify.achievementTitle"),
+			Body:   a.tr("notify.achievementBody", len(st.NewlyUnlocked)),
+			TaskID: t.ID,
+			Data:   map[string]any{"achievements": st.NewlyUnlocked},
+		})
+	}
+}
@@ -5,6 +5,7 @@ go 1.24.0
 require (
 	github.com/wailsapp/wails/v2 v2.11.0
 	golang.org/x/sys v0.36.0
+	golang.org/x/text v0.22.0
 	modernc.org/sqlite v1.42.2
 )
 
@@ -38,7 +39,6 @@ require (
 	golang.org/x/crypto v0.33.0 // indirect
 	golang.org/x/exp v0.0.0-20250620022241-b7579e27df2b // indirect
 	golang.org/x/net v0.35.0 // indirect
-	golang.org/x/text v0.22.0 // indirect
 	modernc.org/libc v1.66.10 // indirect
 	modernc.org/mathutil v1.7.1 // indirect
 	modernc.org/memory v1.11.0 // indirect
@@ -0,0 +1,47 @@
+package main
+
+import "spark-todo/internal/todo"
+
+// GetGoals 返回全部目标及其进度。
+func (a *App) GetGoals() ([]todo.Goal, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return nil, err
+	}
+	goals, err := a.store.ListGoals(a.ctx)
+	return goals, a.localize(err)
+}
+
+// UpsertGoal 新增或更新目标（标题、目标日期、目标完成数）。
+func (a *App) UpsertGoal(goal todo.Goal) (todo.Goal, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.Goal{}, err
+	}
+	g, err := a.store.UpsertGoal(a.ctx, goal)
+	return g, a.localize(err)
+}
+
+// DeleteGoal 删除目标（不影响关联的任务）。
+func (a *App) DeleteGoal(id int64) error {
+	if err := a.ensureStoreReady(); err != nil {
+		return err
+	}
+	return a.localize(a.store.DeleteGoal(a.ctx, id))
+}
+
+// LinkGoalTask 将任务关联到目标，返回更新后的目标进度。
+func (a *App) LinkGoalTask(goalID int64, taskID int64) (todo.Goal, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.Goal{}, err
+	}
+	g, err := a.store.LinkGoalTask(a.ctx, goalID, taskID)
+	return g, a.localize(err)
+}
+
+// UnlinkGoalTask 取消任务与目标的关联，返回更新后的目标进度。
+func (a *App) UnlinkGoalTask(goalID int64, taskID int64) (todo.Goal, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.Goal{}, err
+	}
+	g, err := a.store.UnlinkGoalTask(a.ctx, goalID, taskID)
+	return g, a.localize(err)
+}
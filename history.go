@@ -0,0 +1,237 @@
+package main
+
+import (
+	"errors"
+	"sync"
+
+	"spark-todo/internal/todo"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// maxHistory 为撤销栈的最大深度，超出时丢弃最早的操作。
+const maxHistory = 50
+
+// historyEntry 为一次可撤销的修改。
+type historyEntry struct {
+	label string // 本地化的操作说明，如"删除任务「写周报」"
+	undo  func() error
+	redo  func() error
+}
+
+// history 为进程内的撤销/重做栈（不持久化，重启后清空）。
+type history struct {
+	mu   sync.Mutex
+	undo []historyEntry
+	redo []historyEntry
+}
+
+// HistoryState 描述撤销/重做栈的当前状态，供前端更新按钮与提示。
+type HistoryState struct {
+	Applied   string `json:"applied"` // 刚刚撤销/重做的操作说明；GetHistory 中为空
+	CanUndo   bool   `json:"canUndo"`
+	CanRedo   bool   `json:"canRedo"`
+	UndoLabel string `json:"undoLabel"` // 下一次撤销的操作说明
+	RedoLabel string `json:"redoLabel"` // 下一次重做的操作说明
+}
+
+// state 返回当前状态（调用方需持有 h.mu）。
+func (h *history) state(applied string) HistoryState {
+	st := HistoryState{Applied: applied, CanUndo: len(h.undo) > 0, CanRedo: len(h.redo) > 0}
+	if st.CanUndo {
+		st.UndoLabel = h.undo[len(h.undo)-1].label
+	}
+	if st.CanRedo {
+		st.RedoLabel = h.redo[len(h.redo)-1].label
+	}
+	return st
+}
+
+// pushHistory 记录一次新的修改：清空重做栈，并发出 history:changed 事件。
+func (a *App) pushHistory(e historyEntry) {
+	h := &a.history
+	h.mu.Lock()
+	h.undo = append(h.undo, e)
+	if len(h.undo) > maxHistory {
+		h.undo = h.undo[len(h.undo)-maxHistory:]
+	}
+	h.redo = nil
+	st := h.state("")
+	h.mu.Unlock()
+	runtime.EventsEmit(a.ctx, "history:changed", st)
+}
+
+// GetHistory 返回撤销/重做栈的当前状态。
+func (a *App) GetHistory() HistoryState {
+	a.history.mu.Lock()
+	defer a.history.mu.Unlock()
+	return a.history.state("")
+}
+
+// UndoLast 撤销最近一次任务/分组的新增、修改或删除。
+//
+// 撤销新增相当于删除（进入回收站），撤销删除从回收站恢复（沿用原 ID）。
+// 目标已被其他操作改变而无法撤销时（如分组已被删除）返回错误，并丢弃这一步，避免卡住后续撤销。
+func (a *App) UndoLast() (HistoryState, error) {
+	return a.stepHistory(true)
+}
+
+// RedoLast 重做最近一次被撤销的操作（规则同 UndoLast）。
+func (a *App) RedoLast() (HistoryState, error) {
+	return a.stepHistory(false)
+}
+
+// stepHistory 执行一次撤销（undo 为 true）或重做，成功后把操作移到另一个栈，并发出 history:changed 事件。
+func (a *App) stepHistory(undo bool) (HistoryState, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return HistoryState{}, err
+	}
+
+	h := &a.history
+	h.mu.Lock()
+	from, to, emptyKey := &h.undo, &h.redo, "history.nothingToUndo"
+	if !undo {
+		from, to, emptyKey = &h.redo, &h.undo, "history.nothingToRedo"
+	}
+	if len(*from) == 0 {
+		h.mu.Unlock()
+		return HistoryState{}, errors.New(a.tr(emptyKey))
+	}
+	e := (*from)[len(*from)-1]
+	apply := e.redo
+	if undo {
+		apply = e.undo
+	}
+	err := apply()
+	// 只读模式下什么都没改，保留这一步以便退出只读后再试
+	if err != nil && a.store.ReadOnly() {
+		h.mu.Unlock()
+		return HistoryState{}, a.localize(err)
+	}
+	*from = (*from)[:len(*from)-1]
+	if err == nil {
+		*to = append(*to, e)
+	}
+	st := h.state(e.label)
+	h.mu.Unlock()
+
+	runtime.EventsEmit(a.ctx, "history:changed", st)
+	if err != nil {
+		return HistoryState{}, a.localize(err)
+	}
+	return st, nil
+}
+
+// taskSnapshot 返回任务当前的完整内容（用于撤销修改时还原）。
+func (a *App) taskSnapshot(id int64) (todo.Task, error) {
+	t, err := a.store.GetTask(a.ctx, id)
+	if err != nil {
+		return todo.Task{}, err
+	}
+	tasks := []todo.Task{t}
+	if err := a.store.LoadFullContent(a.ctx, tasks); err != nil {
+		return todo.Task{}, err
+	}
+	return tasks[0], nil
+}
+
+// recordTaskUpsert 记录一次任务新增（before 为 nil）或修改，after 为保存后的任务。
+func (a *App) recordTaskUpsert(before *todo.Task, after todo.Task) {
+	if before == nil {
+		a.recordCreated(a.tr("history.createTask", after.Title), todo.TrashKindTask, after.ID,
+			func() error { return a.store.DeleteTask(a.ctx, after.ID) })
+		return
+	}
+	snapshot, err := a.taskSnapshot(after.ID)
+	if err != nil {
+		runtime.LogErrorf(a.ctx, "failed to record task history: %v", err)
+		return
+	}
+	prev := *before
+	a.pushHistory(historyEntry{
+		label: a.tr("history.updateTask", after.Title),
+		undo:  func() error { _, err := a.store.UpsertTask(a.ctx, prev); return err },
+		redo:  func() error { _, err := a.store.UpsertTask(a.ctx, snapshot); return err },
+	})
+}
+
+// recordCreated 记录一次新增：撤销时调用 remove 删除（进入回收站），重做时从回收站恢复。
+func (a *App) recordCreated(label, kind string, id int64, remove func() error) {
+	var trashID int64
+	a.pushHistory(historyEntry{
+		label: label,
+		undo: func() error {
+			if err := remove(); err != nil {
+				return err
+			}
+			it, err := a.store.LatestTrashItem(a.ctx, kind, id)
+			trashID = it.ID
+			return err
+		},
+		redo: func() error { return a.store.RestoreTrash(a.ctx, trashID) },
+	})
+}
+
+// recordDeleted 记录一次刚完成的删除（labelKey 为操作说明的翻译键，参数为回收站中的标题）：
+// 撤销时从回收站恢复，重做时再次调用 remove 删除。
+func (a *App) recordDeleted(labelKey, kind string, id int64, remove func() error) {
+	it, err := a.store.LatestTrashItem(a.ctx, kind, id)
+	if err != nil {
+		runtime.LogErrorf(a.ctx, "failed to record delete history: %v", err)
+		return
+	}
+	trashID := it.ID
+	a.pushHistory(historyEntry{
+		label: a.tr(labelKey, it.Title),
+		undo:  func() error { return a.store.RestoreTrash(a.ctx, trashID) },
+		redo: func() error {
+			if err := remove(); err != nil {
+				return err
+			}
+			it, err := a.store.LatestTrashItem(a.ctx, kind, id)
+			trashID = it.ID
+			return err
+		},
+	})
+}
+
+// recordBulkDeleted 记录一次刚完成的批量删除（ids 为进入回收站的任务）：
+// 撤销时逐个从回收站恢复，重做时再次批量删除。
+func (a *App) recordBulkDeleted(ids []int64) {
+	trashIDs, err := a.latestTrashIDs(ids)
+	if err != nil {
+		runtime.LogErrorf(a.ctx, "failed to record delete history: %v", err)
+		return
+	}
+	a.pushHistory(historyEntry{
+		label: a.tr("history.bulkDelete", len(ids)),
+		undo: func() error {
+			for _, id := range trashIDs {
+				if err := a.store.RestoreTrash(a.ctx, id); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		redo: func() error {
+			if _, err := a.store.BulkDelete(a.ctx, ids); err != nil {
+				return err
+			}
+			trashIDs, err = a.latestTrashIDs(ids)
+			return err
+		},
+	})
+}
+
+// latestTrashIDs 返回 ids 对应任务最近一次进入回收站的条目 ID。
+func (a *App) latestTrashIDs(ids []int64) ([]int64, error) {
+	out := make([]int64, len(ids))
+	for i, id := range ids {
+		it, err := a.store.LatestTrashItem(a.ctx, todo.TrashKindTask, id)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = it.ID
+	}
+	return out, nil
+}
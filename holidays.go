@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"spark-todo/internal/holiday"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// maxHolidayCalendarBytes 限制导入的节假日日历文件大小（正常每年不到 2KB）。
+const maxHolidayCalendarBytes = 1 << 20
+
+// checkHolidayCoverage 在节假日日历没有收录今年（或 12 月时的明年）时记录警告：
+// 这些日期只能按周一至周五推算工作日，需要导入新日历。在启动与每天零点时调用。
+func (a *App) checkHolidayCoverage(ctx context.Context) {
+	cal, err := a.store.HolidayCalendar(ctx)
+	if err != nil {
+		runtime.LogErrorf(a.ctx, "failed to load holiday calendar: %v", err)
+		return
+	}
+	now := time.Now()
+	years := []int{now.Year()}
+	if now.Month() == time.December {
+		years = append(years, now.Year()+1)
+	}
+	for _, year := range years {
+		if !cal.Covers(year) {
+			runtime.LogWarningf(a.ctx, "holiday calendar %s has no data for %d; workdays fall back to Monday-Friday until an updated calendar is imported", cal.Info().Version, year)
+		}
+	}
+}
+
+// GetHolidayCalendar 返回当前使用的节假日日历概要（版本、覆盖年份、是否导入过自定义日历）。
+func (a *App) GetHolidayCalendar() (holiday.Info, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return holiday.Info{}, err
+	}
+	cal, err := a.store.HolidayCalendar(a.ctx)
+	if err != nil {
+		return holiday.Info{}, a.localize(err)
+	}
+	return cal.Info(), nil
+}
+
+// ImportHolidayCalendar 弹出文件选择框导入节假日日历 JSON（格式同内置的 internal/holiday/cn.json），
+// 用于在国务院公布新一年放假安排后更新日历。用户取消时返回当前日历概要且不报错。
+func (a *App) ImportHolidayCalendar() (holiday.Info, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return holiday.Info{}, err
+	}
+	path, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title:   a.tr("holiday.importTitle"),
+		Filters: []runtime.FileFilter{{DisplayName: "JSON (*.json)", Pattern: "*.json"}},
+	})
+	if err != nil {
+		return holiday.Info{}, a.wrapErr("holiday.importFailed", err)
+	}
+	if path == "" {
+		return a.GetHolidayCalendar()
+	}
+
+	st, err := os.Stat(path)
+	if err != nil {
+		return holiday.Info{}, a.wrapErr("holiday.importFailed", err)
+	}
+	if st.Size() > maxHolidayCalendarBytes {
+		return holiday.Info{}, a.wrapErr("holiday.importFailed", os.ErrInvalid)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return holiday.Info{}, a.wrapErr("holiday.importFailed", err)
+	}
+	info, err := a.store.ImportHolidayCalendar(a.ctx, data)
+	return info, a.localize(err)
+}
+
+// ResetHolidayCalendar 删除导入的日历，恢复使用内置日历。
+func (a *App) ResetHolidayCalendar() (holiday.Info, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return holiday.Info{}, err
+	}
+	if err := a.store.ResetHolidayCalendar(a.ctx); err != nil {
+		return holiday.Info{}, a.localize(err)
+	}
+	return a.GetHolidayCalendar()
+}
+
+// NextWorkingDay 返回 from（UnixMilli，0 表示现在）之后的第一个工作日（跳过周末与法定假日，
+// 调休上班日算工作日），保留原时刻；供截止时间选择器的"下一个工作日"快捷项使用。
+func (a *App) NextWorkingDay(from int64) (int64, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return 0, err
+	}
+	next, err := a.store.NextWorkingDay(a.ctx, from)
+	return next, a.localize(err)
+}
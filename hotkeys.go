@@ -0,0 +1,177 @@
+package main
+
+import (
+	"errors"
+	"strings"
+
+	"spark-todo/internal/hotkey"
+	"spark-todo/internal/todo"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// 全局快捷键动作。
+const (
+	HotkeyActionShowWindow = "showWindow" // 显示并聚焦主窗口
+	HotkeyActionQuickAdd   = "quickAdd"   // 显示主窗口并发出 hotkey:quickAdd 事件（携带默认分组 ID），由前端打开快速添加
+)
+
+// hotkeyActions 为动作与注册 id 的对应关系（id 仅在本进程内使用，需非零）。
+var hotkeyActions = []struct {
+	id     int
+	action string
+	combo  func(*todo.Settings) *string
+}{
+	{1, HotkeyActionShowWindow, func(s *todo.Settings) *string { return &s.HotkeyShowWindow }},
+	{2, HotkeyActionQuickAdd, func(s *todo.Settings) *string { return &s.HotkeyQuickAdd }},
+}
+
+// HotkeyStatus 描述某个动作的快捷键注册结果，供设置界面提示用户。
+type HotkeyStatus struct {
+	Action      string `json:"action"`
+	Combo       string `json:"combo"`           // 规范写法；空字符串表示未设置
+	Registered  bool   `json:"registered"`      // 是否已成功向系统注册
+	Conflict    bool   `json:"conflict"`        // 组合已被其他程序占用
+	Unsupported bool   `json:"unsupported"`     // 当前系统不支持全局快捷键（组合照常保存）
+	Error       string `json:"error,omitempty"` // 本地化的失败原因
+}
+
+// registerHotkey 尝试为 action 注册 combo 并返回结构化结果；combo 为空时注销该动作。
+func (a *App) registerHotkey(id int, action string, combo string) HotkeyStatus {
+	st := HotkeyStatus{Action: action, Combo: combo}
+	if combo == "" {
+		a.hotkeys.Unregister(id)
+		return st
+	}
+
+	hk, err := hotkey.Parse(combo)
+	if err != nil {
+		st.Error = a.localize(todo.ErrInvalidHotkey.With(combo)).Error()
+		return st
+	}
+	switch err := a.hotkeys.Register(id, hk); {
+	case err == nil:
+		st.Registered = true
+	case errors.Is(err, hotkey.ErrConflict):
+		st.Conflict = true
+		st.Error = a.tr("hotkey.conflict", combo)
+	case errors.Is(err, hotkey.ErrUnsupported):
+		st.Unsupported = true
+		st.Error = a.tr("hotkey.unsupported")
+	default:
+		runtime.LogErrorf(a.ctx, "failed to register hotkey %s: %v", combo, err)
+		st.Error = a.tr("hotkey.failed", combo)
+	}
+	return st
+}
+
+// applyHotkeys 按设置注册全部快捷键，并缓存注册结果（由设置订阅者在启动与快捷键设置变化时调用）。
+func (a *App) applyHotkeys(settings todo.Settings) {
+	statuses := make([]HotkeyStatus, 0, len(hotkeyActions))
+	for _, h := range hotkeyActions {
+		st := a.registerHotkey(h.id, h.action, *h.combo(&settings))
+		if st.Error != "" && !st.Unsupported {
+			runtime.LogErrorf(a.ctx, "hotkey %s (%s): %s", h.action, st.Combo, st.Error)
+		}
+		statuses = append(statuses, st)
+	}
+	a.hotkeyStatus.Store(statuses)
+}
+
+// GetHotkeys 返回各动作快捷键的当前注册结果。
+func (a *App) GetHotkeys() []HotkeyStatus {
+	if v, ok := a.hotkeyStatus.Load().([]HotkeyStatus); ok {
+		return append([]HotkeyStatus(nil), v...)
+	}
+	return []HotkeyStatus{}
+}
+
+// SetHotkey 为 action 设置全局快捷键（combo 为空表示取消）。
+//
+// 先尝试向系统注册：若组合被其他程序占用或注册失败，返回 Conflict/Error 并且不保存，
+// 原组合保持有效，设置界面据此提示用户换一个组合；注册成功才写入设置。
+// 当前系统不支持全局快捷键时照常保存，返回 Unsupported 供界面说明。
+func (a *App) SetHotkey(action string, combo string) (HotkeyStatus, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return HotkeyStatus{}, err
+	}
+
+	for i, h := range hotkeyActions {
+		if h.action != action {
+			continue
+		}
+
+		settings, err := a.store.GetSettings(a.ctx)
+		if err != nil {
+			return HotkeyStatus{}, a.localize(err)
+		}
+		prev := *h.combo(&settings)
+		// 与 SetSettings 相同的校验：得到规范写法，非法组合直接返回错误
+		normalized, err := hotkey.Normalize(combo)
+		if err != nil {
+			return HotkeyStatus{}, a.localize(todo.ErrInvalidHotkey.With(strings.TrimSpace(combo)))
+		}
+		*h.combo(&settings) = normalized
+
+		// 同一组合不能分配给两个动作
+		for _, other := range hotkeyActions {
+			if other.action != action && normalized != "" && *other.combo(&settings) == normalized {
+				return HotkeyStatus{Action: action, Combo: normalized, Conflict: true, Error: a.tr("hotkey.conflict", normalized)}, nil
+			}
+		}
+
+		st := a.registerHotkey(h.id, action, normalized)
+		if st.Error != "" && !st.Unsupported {
+			// 恢复原组合（注册失败时系统中不会残留新组合）
+			a.registerHotkey(h.id, action, prev)
+			return st, nil
+		}
+		if err := a.store.SetSettings(a.ctx, settings); err != nil {
+			a.registerHotkey(h.id, action, prev)
+			return HotkeyStatus{}, a.localize(err)
+		}
+		// 先更新注册结果，reloadSettings 中的快捷键订阅者据此判断无需重新注册
+		statuses := a.GetHotkeys()
+		if i < len(statuses) {
+			statuses[i] = st
+			a.hotkeyStatus.Store(statuses)
+		}
+		if _, err := a.reloadSettings(); err != nil {
+			return HotkeyStatus{}, err
+		}
+		return st, nil
+	}
+	return HotkeyStatus{}, errors.New(a.tr("hotkey.invalidAction", action))
+}
+
+// onHotkey 在快捷键按下时执行对应动作（运行在快捷键消息循环线程中）。
+func (a *App) onHotkey(id int) {
+	for _, h := range hotkeyActions {
+		if h.id != id {
+			continue
+		}
+		runtime.WindowUnminimise(a.ctx)
+		runtime.WindowShow(a.ctx)
+		if h.action == HotkeyActionQuickAdd {
+			runtime.EventsEmit(a.ctx, "hotkey:quickAdd", a.quickAddGroupID())
+		}
+		return
+	}
+}
+
+// quickAddGroupID 返回快速添加的默认分组：常用分组优先，其次为第一个未归档的分组；没有可用分组时为 0。
+func (a *App) quickAddGroupID() int64 {
+	if a.store == nil {
+		return 0
+	}
+	groups, err := a.store.ListGroups(a.ctx)
+	if err != nil {
+		return 0
+	}
+	for _, g := range groups {
+		if !g.Archived {
+			return g.ID
+		}
+	}
+	return 0
+}
@@ -0,0 +1,227 @@
+// Package holiday 提供中国法定节假日与调休工作日日历，用于按工作日推算截止时间。
+//
+// 程序内置 cn.json（国务院办公厅公布的放假安排，目前收录 2025–2026 年），
+// 用户可导入同格式的新日历来补充或更正某些年份。
+// 日历中没有收录的年份按周一至周五为工作日处理（见 Covers），需要在每年公布新安排后更新。
+package holiday
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// dateLayout 为日历文件中的日期格式。
+const dateLayout = "2006-01-02"
+
+// maxScanDays 限制 NextWorkday 向后查找的天数，防止日历数据异常（如全年放假）时死循环。
+const maxScanDays = 366
+
+//go:embed cn.json
+var bundledData []byte
+
+// File 为日历文件格式：按年份列出放假区间与调休上班日。
+type File struct {
+	Version string          `json:"version"`
+	Years   map[string]Year `json:"years"`
+}
+
+// Year 为某一年的放假安排。
+type Year struct {
+	Holidays []Period `json:"holidays"`
+	Workdays []string `json:"workdays"` // 调休上班的周末，YYYY-MM-DD
+}
+
+// Period 为一段连续的假期（含首尾两天）。
+type Period struct {
+	Name  string `json:"name"`
+	Start string `json:"start"` // YYYY-MM-DD
+	End   string `json:"end"`   // YYYY-MM-DD
+}
+
+// Info 为日历概要，供设置界面显示当前使用的版本与覆盖年份。
+type Info struct {
+	Version string `json:"version"`
+	Years   []int  `json:"years"`
+	Custom  bool   `json:"custom"` // 是否合并了用户导入的日历
+}
+
+// Calendar 为解析后的日历。零值不可用，请通过 Parse / Bundled 获取。
+type Calendar struct {
+	version  string
+	years    map[int]bool
+	holidays map[string]string // 日期 -> 假期名称
+	workdays map[string]bool   // 调休上班日
+	custom   bool
+}
+
+// Parse 解析并校验日历文件：日期须为 YYYY-MM-DD 且属于所在年份，区间首尾不能颠倒。
+func Parse(data []byte) (*Calendar, error) {
+	var f File
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("parse holiday calendar: %w", err)
+	}
+	if len(f.Years) == 0 {
+		return nil, fmt.Errorf("holiday calendar has no years")
+	}
+
+	c := &Calendar{
+		version:  strings.TrimSpace(f.Version),
+		years:    map[int]bool{},
+		holidays: map[string]string{},
+		workdays: map[string]bool{},
+	}
+	for key, y := range f.Years {
+		year, err := strconv.Atoi(key)
+		if err != nil || year < 1900 || year > 2999 {
+			return nil, fmt.Errorf("invalid year %q", key)
+		}
+		c.years[year] = true
+
+		for _, p := range y.Holidays {
+			start, err := parseDate(p.Start, year)
+			if err != nil {
+				return nil, err
+			}
+			end, err := parseDate(p.End, year)
+			if err != nil {
+				return nil, err
+			}
+			if end.Before(start) {
+				return nil, fmt.Errorf("holiday %q ends before it starts", p.Name)
+			}
+			for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+				c.holidays[d.Format(dateLayout)] = strings.TrimSpace(p.Name)
+			}
+		}
+		for _, w := range y.Workdays {
+			d, err := parseDate(w, year)
+			if err != nil {
+				return nil, err
+			}
+			c.workdays[d.Format(dateLayout)] = true
+		}
+	}
+	return c, nil
+}
+
+// parseDate 解析 YYYY-MM-DD，并要求日期属于 year（跨年的假期应拆到两个年份中）。
+func parseDate(v string, year int) (time.Time, error) {
+	d, err := time.Parse(dateLayout, strings.TrimSpace(v))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", v)
+	}
+	if d.Year() != year {
+		return time.Time{}, fmt.Errorf("date %q is not in year %d", v, year)
+	}
+	return d, nil
+}
+
+// Bundled 返回程序内置的日历。
+var Bundled = sync.OnceValue(func() *Calendar {
+	c, err := Parse(bundledData)
+	if err != nil {
+		panic("holiday: invalid bundled calendar: " + err.Error())
+	}
+	return c
+})
+
+// Merge 返回以 c 为基础、用 update 覆盖的新日历：update 中出现的年份整年替换 c 中的数据，其余年份保留。
+func (c *Calendar) Merge(update *Calendar) *Calendar {
+	merged := &Calendar{
+		version:  c.version,
+		years:    map[int]bool{},
+		holidays: map[string]string{},
+		workdays: map[string]bool{},
+		custom:   true,
+	}
+	if update.version != "" {
+		merged.version = update.version
+	}
+	keep := func(date string) bool {
+		year, _ := strconv.Atoi(date[:4])
+		return !update.years[year]
+	}
+	for year := range c.years {
+		merged.years[year] = true
+	}
+	for date, name := range c.holidays {
+		if keep(date) {
+			merged.holidays[date] = name
+		}
+	}
+	for date := range c.workdays {
+		if keep(date) {
+			merged.workdays[date] = true
+		}
+	}
+	for year := range update.years {
+		merged.years[year] = true
+	}
+	for date, name := range update.holidays {
+		merged.holidays[date] = name
+	}
+	for date := range update.workdays {
+		merged.workdays[date] = true
+	}
+	return merged
+}
+
+// Info 返回日历概要。
+func (c *Calendar) Info() Info {
+	years := make([]int, 0, len(c.years))
+	for y := range c.years {
+		years = append(years, y)
+	}
+	slices.Sort(years)
+	return Info{Version: c.version, Years: years, Custom: c.custom}
+}
+
+// Covers 报告日历是否收录了 year 年的放假安排；未收录的年份按周一至周五判断工作日。
+func (c *Calendar) Covers(year int) bool {
+	return c.years[year]
+}
+
+// Holiday 返回 t 所在日期的假期名称；不是法定假日时返回 ("", false)。
+func (c *Calendar) Holiday(t time.Time) (string, bool) {
+	name, ok := c.holidays[t.Format(dateLayout)]
+	return name, ok
+}
+
+// IsWorkday 判断 t 所在日期（按 t 自身的时区）是否为工作日：
+// 调休上班日是工作日，法定假日不是，其余按周一至周五判断。
+func (c *Calendar) IsWorkday(t time.Time) bool {
+	key := t.Format(dateLayout)
+	if c.workdays[key] {
+		return true
+	}
+	if _, ok := c.holidays[key]; ok {
+		return false
+	}
+	wd := t.Weekday()
+	return wd != time.Saturday && wd != time.Sunday
+}
+
+// NextWorkday 返回 t 之后（不含 t 当天）的第一个工作日，保留 t 的时刻。
+func (c *Calendar) NextWorkday(t time.Time) time.Time {
+	for i := 1; i <= maxScanDays; i++ {
+		d := t.AddDate(0, 0, i)
+		if c.IsWorkday(d) {
+			return d
+		}
+	}
+	return t.AddDate(0, 0, 1)
+}
+
+// OnOrAfterWorkday 返回 t 当天（若是工作日）或之后的第一个工作日，保留 t 的时刻。
+func (c *Calendar) OnOrAfterWorkday(t time.Time) time.Time {
+	if c.IsWorkday(t) {
+		return t
+	}
+	return c.NextWorkday(t)
+}
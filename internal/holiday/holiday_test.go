@@ -0,0 +1,23 @@
+package holiday
+
+import "testing"
+
+func TestBundledCoverage(t *testing.T) {
+	cal := Bundled()
+	for _, year := range []int{2025, 2026} {
+		if !cal.Covers(year) {
+			t.Errorf("bundled calendar does not cover %d", year)
+		}
+	}
+	if cal.Covers(2027) {
+		t.Error("bundled calendar covers 2027; update the package doc")
+	}
+
+	update, err := Parse([]byte(`{"version":"x","years":{"2027":{"holidays":[{"name":"元旦","start":"2027-01-01","end":"2027-01-01"}]}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if merged := cal.Merge(update); !merged.Covers(2025) || !merged.Covers(2027) {
+		t.Errorf("merged years = %v", merged.Info().Years)
+	}
+}
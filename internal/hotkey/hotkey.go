@@ -0,0 +1,162 @@
+// Package hotkey 解析全局快捷键组合（如 "Ctrl+Alt+T"），并在支持的平台上向系统注册。
+package hotkey
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Modifier 为修饰键位掩码（取值与 Win32 RegisterHotKey 的 MOD_* 一致）。
+type Modifier uint32
+
+const (
+	ModAlt   Modifier = 0x1
+	ModCtrl  Modifier = 0x2
+	ModShift Modifier = 0x4
+	ModWin   Modifier = 0x8
+)
+
+var (
+	// ErrConflict 表示组合键已被其他程序（或本程序的其它动作）占用。
+	ErrConflict = errors.New("hotkey already registered")
+	// ErrUnsupported 表示当前平台不支持注册全局快捷键。
+	ErrUnsupported = errors.New("global hotkeys are not supported on this platform")
+)
+
+// Hotkey 是解析后的快捷键组合。
+type Hotkey struct {
+	Mods Modifier
+	Key  string // 规范化的主键名，如 "T"、"F5"、"Space"
+	vk   uint32 // 虚拟键码
+}
+
+// modifierNames 为修饰键的规范名称与别名（按输出顺序排列）。
+var modifierNames = []struct {
+	mod     Modifier
+	name    string
+	aliases []string
+}{
+	{ModCtrl, "Ctrl", []string{"ctrl", "control", "cmdorctrl"}},
+	{ModAlt, "Alt", []string{"alt", "option"}},
+	{ModShift, "Shift", []string{"shift"}},
+	{ModWin, "Win", []string{"win", "super", "meta", "cmd"}},
+}
+
+// namedKeys 为非字母数字主键的规范名称与虚拟键码。
+var namedKeys = map[string]struct {
+	name string
+	vk   uint32
+}{
+	"space":  {"Space", 0x20},
+	"enter":  {"Enter", 0x0D},
+	"return": {"Enter", 0x0D},
+	"tab":    {"Tab", 0x09},
+	"esc":    {"Esc", 0x1B},
+	"escape": {"Esc", 0x1B},
+	"up":     {"Up", 0x26},
+	"down":   {"Down", 0x28},
+	"left":   {"Left", 0x25},
+	"right":  {"Right", 0x27},
+	"home":   {"Home", 0x24},
+	"end":    {"End", 0x23},
+	"insert": {"Insert", 0x2D},
+	"delete": {"Delete", 0x2E},
+}
+
+// Parse 解析 "Ctrl+Alt+T" 形式的组合（不区分大小写，"+" 两侧可有空白）。
+//
+// 要求：恰好一个主键（A-Z、0-9、F1-F24 或 namedKeys 中的键）；
+// 除 F1-F24 外至少带一个修饰键，避免单键全局快捷键干扰正常输入。
+func Parse(s string) (Hotkey, error) {
+	var hk Hotkey
+	parts := strings.Split(s, "+")
+	for _, raw := range parts {
+		p := strings.ToLower(strings.TrimSpace(raw))
+		if p == "" {
+			return Hotkey{}, fmt.Errorf("invalid hotkey %q", s)
+		}
+		if mod, ok := parseModifier(p); ok {
+			if hk.Mods&mod != 0 {
+				return Hotkey{}, fmt.Errorf("duplicate modifier in hotkey %q", s)
+			}
+			hk.Mods |= mod
+			continue
+		}
+		if hk.Key != "" {
+			return Hotkey{}, fmt.Errorf("hotkey %q has more than one key", s)
+		}
+		name, vk, ok := parseKey(p)
+		if !ok {
+			return Hotkey{}, fmt.Errorf("unknown key %q in hotkey %q", strings.TrimSpace(raw), s)
+		}
+		hk.Key, hk.vk = name, vk
+	}
+	if hk.Key == "" {
+		return Hotkey{}, fmt.Errorf("hotkey %q has no key", s)
+	}
+	if hk.Mods == 0 && !isFunctionKey(hk.vk) {
+		return Hotkey{}, fmt.Errorf("hotkey %q needs a modifier", s)
+	}
+	return hk, nil
+}
+
+// Normalize 校验并返回规范写法（如 "alt + ctrl + t" -> "Ctrl+Alt+T"）；空字符串表示未设置。
+func Normalize(s string) (string, error) {
+	if strings.TrimSpace(s) == "" {
+		return "", nil
+	}
+	hk, err := Parse(s)
+	if err != nil {
+		return "", err
+	}
+	return hk.String(), nil
+}
+
+// String 返回规范写法：修饰键按 Ctrl、Alt、Shift、Win 排序，最后是主键。
+func (hk Hotkey) String() string {
+	var parts []string
+	for _, m := range modifierNames {
+		if hk.Mods&m.mod != 0 {
+			parts = append(parts, m.name)
+		}
+	}
+	return strings.Join(append(parts, hk.Key), "+")
+}
+
+func parseModifier(p string) (Modifier, bool) {
+	for _, m := range modifierNames {
+		for _, a := range m.aliases {
+			if p == a {
+				return m.mod, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func parseKey(p string) (string, uint32, bool) {
+	if len(p) == 1 {
+		c := p[0]
+		switch {
+		case c >= 'a' && c <= 'z':
+			return strings.ToUpper(p), uint32(c - 'a' + 'A'), true
+		case c >= '0' && c <= '9':
+			return p, uint32(c), true
+		}
+	}
+	if len(p) >= 2 && p[0] == 'f' {
+		if n, err := strconv.Atoi(p[1:]); err == nil && n >= 1 && n <= 24 {
+			return fmt.Sprintf("F%d", n), uint32(0x70 + n - 1), true
+		}
+	}
+	if k, ok := namedKeys[p]; ok {
+		return k.name, k.vk, true
+	}
+	return "", 0, false
+}
+
+func isFunctionKey(vk uint32) bool {
+	return vk >= 0x70 && vk <= 0x87
+}
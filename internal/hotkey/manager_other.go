@@ -0,0 +1,23 @@
+//go:build !windows
+
+package hotkey
+
+// Manager 在非 Windows 平台上不注册任何快捷键：所有 Register 调用都返回 ErrUnsupported。
+type Manager struct{}
+
+// NewManager 创建快捷键管理器（非 Windows 平台为空实现）。
+func NewManager(onTrigger func(id int)) *Manager {
+	_ = onTrigger
+	return &Manager{}
+}
+
+// Register 始终返回 ErrUnsupported。
+func (m *Manager) Register(id int, hk Hotkey) error {
+	return ErrUnsupported
+}
+
+// Unregister 无操作。
+func (m *Manager) Unregister(id int) {}
+
+// Close 无操作。
+func (m *Manager) Close() {}
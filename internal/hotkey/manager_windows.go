@@ -0,0 +1,153 @@
+//go:build windows
+// +build windows
+
+package hotkey
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	wmHotkey = 0x0312
+	// wmCall 为自定义线程消息：唤醒消息循环执行 calls 中排队的函数。
+	wmCall = 0x8000 + 1 // WM_APP + 1
+
+	modNoRepeat = 0x4000
+	pmNoRemove  = 0x0000
+
+	errHotkeyAlreadyRegistered = windows.Errno(1409)
+)
+
+var (
+	user32                 = windows.NewLazySystemDLL("user32.dll")
+	procRegisterHotKey     = user32.NewProc("RegisterHotKey")
+	procUnregisterHotKey   = user32.NewProc("UnregisterHotKey")
+	procGetMessageW        = user32.NewProc("GetMessageW")
+	procPeekMessageW       = user32.NewProc("PeekMessageW")
+	procPostThreadMessageW = user32.NewProc("PostThreadMessageW")
+)
+
+type msg struct {
+	hwnd    uintptr
+	message uint32
+	wParam  uintptr
+	lParam  uintptr
+	time    uint32
+	ptX     int32
+	ptY     int32
+}
+
+// Manager 管理全局快捷键的注册与触发回调。
+//
+// RegisterHotKey(hwnd=NULL) 的 WM_HOTKEY 会投递到"调用注册的线程"的消息队列，
+// 因此所有注册/注销都在一个锁定 OS 线程的专用 goroutine 中执行，该 goroutine 同时运行消息循环。
+type Manager struct {
+	onTrigger func(id int)
+
+	startOnce sync.Once
+	threadID  uint32
+
+	mu    sync.Mutex
+	calls []func()
+}
+
+// NewManager 创建快捷键管理器；onTrigger 在快捷键按下时于消息循环线程中被调用，应尽快返回。
+func NewManager(onTrigger func(id int)) *Manager {
+	return &Manager{onTrigger: onTrigger}
+}
+
+// Register 以 id 注册快捷键；id 已注册时先注销旧组合。
+// 组合已被其它程序占用时返回 ErrConflict。
+func (m *Manager) Register(id int, hk Hotkey) error {
+	var err error
+	if callErr := m.call(func() {
+		procUnregisterHotKey.Call(0, uintptr(id))
+		r, _, e := procRegisterHotKey.Call(0, uintptr(id), uintptr(uint32(hk.Mods)|modNoRepeat), uintptr(hk.vk))
+		if r == 0 {
+			if errors.Is(e, errHotkeyAlreadyRegistered) {
+				err = ErrConflict
+			} else {
+				err = e
+			}
+		}
+	}); callErr != nil {
+		return callErr
+	}
+	return err
+}
+
+// Unregister 注销 id 对应的快捷键（未注册时无副作用）。
+func (m *Manager) Unregister(id int) {
+	_ = m.call(func() {
+		procUnregisterHotKey.Call(0, uintptr(id))
+	})
+}
+
+// Close 停止消息循环；线程退出时系统会自动注销该线程注册的全部快捷键。
+func (m *Manager) Close() {
+	if m.threadID != 0 {
+		procPostThreadMessageW.Call(uintptr(m.threadID), 0x0012 /* WM_QUIT */, 0, 0)
+	}
+}
+
+// call 在消息循环线程中同步执行 fn。
+func (m *Manager) call(fn func()) error {
+	m.startOnce.Do(m.start)
+
+	done := make(chan struct{})
+	m.mu.Lock()
+	m.calls = append(m.calls, func() {
+		defer close(done)
+		fn()
+	})
+	m.mu.Unlock()
+
+	if r, _, e := procPostThreadMessageW.Call(uintptr(m.threadID), wmCall, 0, 0); r == 0 {
+		return e
+	}
+	<-done
+	return nil
+}
+
+// start 启动消息循环 goroutine，并等待其消息队列就绪。
+func (m *Manager) start() {
+	ready := make(chan struct{})
+	go func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+
+		// 线程首次调用 PeekMessage 时系统才会为其创建消息队列，之后 PostThreadMessage 才能成功。
+		var q msg
+		procPeekMessageW.Call(uintptr(unsafe.Pointer(&q)), 0, 0, 0, pmNoRemove)
+		m.threadID = windows.GetCurrentThreadId()
+		close(ready)
+
+		for {
+			var mm msg
+			r, _, _ := procGetMessageW.Call(uintptr(unsafe.Pointer(&mm)), 0, 0, 0)
+			if int32(r) <= 0 {
+				return
+			}
+			switch mm.message {
+			case wmHotkey:
+				if m.onTrigger != nil {
+					m.onTrigger(int(mm.wParam))
+				}
+			case wmCall:
+				m.mu.Lock()
+				calls := m.calls
+				m.calls = nil
+				m.mu.Unlock()
+				for _, fn := range calls {
+					fn()
+				}
+			}
+		}
+	}()
+	<-ready
+}
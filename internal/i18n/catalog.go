@@ -0,0 +1,587 @@
+package i18n
+
+// catalog 为 语言 -> key -> 文案 的消息目录。
+//
+// 新增文案时需同时补齐所有语言；缺失的翻译会回退到 Default。
+var catalog = map[string]map[string]string{
+	ZhCN: {
+		"app.notReady":         "应用尚未初始化完成",
+		"app.initDBPathFailed": "初始化失败：无法确定数据库路径",
+		"app.initDBOpenFailed": "初始化失败：无法打开数据库",
+		"app.executableFailed": "获取可执行文件路径失败",
+		"app.restartFailed":    "启动新进程失败",
+
+		"water.title":   "喝水提醒",
+		"water.message": "喝水小提醒：该喝水了",
+
+		"update.checkFailed":     "检查更新失败",
+		"update.changelogFailed": "获取更新日志失败",
+		"update.upToDate":        "当前已是最新版本",
+		"update.inProgress":      "更新正在下载中",
+		"update.canceled":        "更新下载已取消",
+		"update.downloadFailed":  "下载或校验更新失败",
+		"update.backupFailed":    "更新前备份数据库失败",
+		"update.installerFailed": "启动安装程序失败",
+		"update.rollbackInfo":    "读取回滚信息失败",
+		"update.rollbackFailed":  "回滚失败",
+		"update.rollbackStart":   "启动旧版本失败",
+
+		"report.weeklyTitle":   "周报",
+		"report.weeklySummary": "本周完成 %d 项，进行中 %d 项，结转 %d 项，新建 %d 项。",
+		"report.completed":     "本周完成",
+		"report.inProgress":    "进行中",
+		"report.carriedOver":   "结转到下周",
+		"report.timeTracked":   "时间记录",
+		"report.trackedTotal":  "本周记录 %s 小时",
+		"report.none":          "（无）",
+		"report.completedAt":   "完成于 %s",
+		"report.dueAt":         "截止 %s",
+		"report.generatedAt":   "生成于 %s · Spark Todo",
+		"report.boardTitle":    "任务清单",
+		"report.groupSummary":  "未完成 %d · 已完成 %d",
+		"report.flagLegend":    "★ 重要 · ⚡ 紧急",
+		"report.doing":         "进行中",
+		"report.status.todo":   "待办",
+		"report.status.doing":  "进行中",
+		"report.status.done":   "已完成",
+		"report.col.id":        "ID",
+		"report.col.group":     "分组",
+		"report.col.parent":    "父任务ID",
+		"report.col.title":     "标题",
+		"report.col.content":   "内容",
+		"report.col.status":    "状态",
+		"report.col.important": "重要",
+		"report.col.urgent":    "紧急",
+		"report.col.due":       "截止时间",
+		"report.col.completed": "完成时间",
+		"report.col.created":   "创建时间",
+		"report.col.updated":   "更新时间",
+		"report.saveTitle":     "保存报告",
+		"report.failed":        "生成报告失败",
+		"report.saveFailed":    "保存文件失败",
+
+		"report.pdfUnavailable": "未找到可用于生成 PDF 的浏览器（需要 Microsoft Edge 或 Google Chrome）",
+
+		"diag.failed": "生成诊断包失败",
+
+		"notify.countdownTitle":    "倒计时提醒",
+		"notify.countdownBody":     "%s：还有 %d 天",
+		"notify.achievementTitle":  "解锁新成就",
+		"notify.achievementBody":   "解锁了 %d 个新成就",
+		"notify.updateTitle":       "发现新版本",
+		"notify.updateBody":        "新版本 %s 已发布",
+		"notify.backupFailedTitle": "云备份失败",
+		"notify.reminderTitle":     "任务提醒",
+		"notify.pomodoroTitle":     "番茄钟完成",
+		"notify.pomodoroBody":      "%s：专注了 %d 分钟",
+
+		"selfTest.databaseOk":          "数据库读写正常",
+		"selfTest.databaseReadOnly":    "只读模式下跳过写入检查",
+		"selfTest.databaseFailed":      "数据库读写失败",
+		"selfTest.notificationOk":      "提醒可以正常送达",
+		"selfTest.notificationTimeout": "%d 秒内界面未确认收到测试提醒",
+		"selfTest.hotkeysOk":           "%d 个快捷键已注册",
+		"selfTest.hotkeysNone":         "未设置全局快捷键",
+		"selfTest.updateOk":            "已连接更新服务器，当前已是最新版本",
+		"selfTest.updateAvailable":     "已连接更新服务器，有新版本 %s",
+		"selfTest.updateFailed":        "无法连接更新服务器",
+
+		"history.createTask":    "新建任务「%s」",
+		"history.updateTask":    "修改任务「%s」",
+		"history.deleteTask":    "删除任务「%s」",
+		"history.bulkDelete":    "删除 %d 个任务",
+		"history.createGroup":   "新建分组「%s」",
+		"history.updateGroup":   "修改分组「%s」",
+		"history.deleteGroup":   "删除分组「%s」",
+		"history.nothingToUndo": "没有可撤销的操作",
+		"history.nothingToRedo": "没有可重做的操作",
+
+		"backup.failed":        "生成备份失败",
+		"backup.importTitle":   "从备份恢复",
+		"backup.restoreFailed": "从备份恢复失败",
+		"backup.safetyFailed":  "恢复前备份当前数据失败",
+
+		"holiday.importTitle":  "导入节假日日历",
+		"holiday.importFailed": "读取节假日日历失败",
+
+		"data.exportFailed":       "导出数据失败",
+		"data.importTitle":        "导入数据",
+		"data.importFailed":       "读取数据文件失败",
+		"data.csvImportTitle":     "从 CSV 导入任务",
+		"data.todoTxtImportTitle": "从 todo.txt 导入任务",
+
+		"activity.task.created":     "新建了 %s",
+		"activity.task.restored":    "恢复了 %s",
+		"activity.task.started":     "开始了 %s",
+		"activity.task.completed":   "完成了 %s",
+		"activity.task.reopened":    "重新打开了 %s",
+		"activity.task.moved":       "将 %s 移到了 %s",
+		"activity.task.renamed":     "将 %[2]s 改名为 %[1]s",
+		"activity.task.deleted":     "删除了 %s",
+		"activity.group.created":    "新建了分组 %s",
+		"activity.group.renamed":    "将分组 %[2]s 改名为 %[1]s",
+		"activity.group.archived":   "归档了分组 %s",
+		"activity.group.unarchived": "取消归档了分组 %s",
+		"activity.group.deleted":    "删除了分组 %s",
+
+		"attachment.noClipboardImage": "剪贴板中没有图片",
+		"attachment.clipboardFailed":  "读取剪贴板图片失败",
+		"attachment.saveFailed":       "保存附件失败",
+		"attachment.thumbnailFailed":  "生成缩略图失败",
+
+		"report.timesheetTitle": "工时表",
+		"report.timesheetTotal": "合计 %s 小时",
+		"report.col.date":       "日期",
+		"report.col.task":       "任务",
+		"report.col.minutes":    "分钟",
+		"report.col.hours":      "小时",
+
+		"report.snapshotSummary": "共 %d 项任务，已完成 %d 项",
+		"report.snapshotNote":    "只读快照",
+
+		"hotkey.invalidAction": "未知的快捷键动作: %q",
+		"hotkey.conflict":      "快捷键 %s 已被其他程序占用，请换一个组合",
+		"hotkey.unsupported":   "当前系统不支持全局快捷键",
+		"hotkey.failed":        "注册快捷键 %s 失败",
+
+		"todo.invalidStatus":        "无效的任务状态: %q",
+		"todo.groupNameEmpty":       "组名不能为空",
+		"todo.groupNameTooLong":     "组名过长（最多 %d 字）",
+		"todo.groupNameTaken":       "组名已存在",
+		"todo.groupNotFound":        "组不存在（id=%d）",
+		"todo.invalidGroupId":       "无效的组ID",
+		"todo.groupCycle":           "不能把分组移到它自身或它的下级分组中",
+		"todo.groupRequired":        "请选择一个组",
+		"todo.taskTitleEmpty":       "任务标题不能为空",
+		"todo.taskTitleTooLong":     "任务标题过长（最多 %d 字）",
+		"todo.taskContentTooLong":   "任务内容过长（最多 %d 字）",
+		"todo.parentTaskNotFound":   "父任务不存在",
+		"todo.taskNotFound":         "任务不存在（id=%d）",
+		"todo.invalidTaskId":        "无效的任务ID",
+		"todo.taskNotInGroup":       "任务 %d 不是分组 %d 中的顶层任务",
+		"todo.invalidSettingsScope": "无效的设置分类: %q",
+		"todo.updateUrlTooLong":     "更新地址过长（最多 %d 字）",
+		"todo.invalidUpdateUrl":     "更新地址必须是有效的 https 链接（仅本机地址可用 http）",
+		"todo.invalidTimezone":      "无效的时区: %q",
+		"todo.invalidColor":         "无效的颜色（需为 #rrggbb 格式）: %q",
+		"todo.invalidHotkey":        "无效的快捷键: %q（需为 Ctrl+Alt+T 这类组合）",
+		"todo.invalidDate":          "无效的日期: %q（需为 YYYY-MM-DD）",
+		"todo.invalidSortMode":      "无效的排序方式: %q",
+		"todo.invalidView":          "无效的视图: %q",
+		"todo.invalidRange":         "无效的时间范围: %q",
+
+		"todo.invalidRetention":  "回收站保留天数须在 %d 到 %d 之间（0 表示永不自动清理）",
+		"todo.trashItemNotFound": "回收站条目不存在（id=%d）",
+
+		"todo.invalidReviewAction": "无效的回顾操作: %q",
+
+		"todo.goalTitleEmpty":    "目标标题不能为空",
+		"todo.goalTitleTooLong":  "目标标题过长（最多 %d 字）",
+		"todo.goalNotFound":      "目标不存在（id=%d）",
+		"todo.invalidGoalTarget": "目标完成数不能为负数: %d",
+
+		"todo.tagNameEmpty":    "标签名不能为空",
+		"todo.tagNameTooLong":  "标签名过长（最多 %d 字）",
+		"todo.tagNameTaken":    "标签 %q 已存在，可改用合并",
+		"todo.tagNotFound":     "标签不存在（id=%d）",
+		"todo.tagMergeSelf":    "不能将标签合并到自身",
+		"todo.tooManyTaskTags": "每个任务最多 %d 个标签",
+
+		"todo.invalidEmoji": "无效的表情: %q（只能填写一个表情符号）",
+
+		"todo.invalidTaskUrl": "任务链接必须是有效的 http/https 地址: %q",
+		"todo.taskUrlTooLong": "任务链接过长（最多 %d 字）",
+		"todo.taskNoLink":     "任务没有设置链接（id=%d）",
+		"todo.linkNotInTask":  "链接不在任务内容中: %q",
+
+		"todo.invalidContentLimit":  "任务内容上限须在 %d 到 %d 字之间",
+		"todo.invalidContentFormat": "无效的内容格式: %q",
+
+		"todo.attachmentNotFound": "附件不存在（id=%d）",
+
+		"todo.invalidTitleLimit":     "任务标题上限须在 %d 到 %d 字之间",
+		"todo.invalidGroupNameLimit": "组名上限须在 %d 到 %d 字之间",
+
+		"todo.invalidRecurFreq":       "无效的重复周期: %q",
+		"todo.invalidRecurInterval":   "重复间隔须在 %d 到 %d 之间",
+		"todo.invalidRecurMode":       "无效的重复方式: %q",
+		"todo.recurSubTask":           "子任务不能单独设置重复",
+		"todo.recurLunarNotYearly":    "农历重复只支持每年重复",
+		"todo.invalidRecurWeekday":    "无效的星期: %d（须在 0 到 6 之间，0 为周日）",
+		"todo.recurWeekdaysNotWeekly": "只有每周重复可以指定星期",
+
+		"todo.invalidHolidayCalendar": "节假日日历格式错误: %s",
+
+		"todo.invalidLunarDate": "无效的农历日期: %s",
+		"todo.lunarOutOfRange":  "农历只支持 %d 到 %d 年",
+
+		"todo.invalidSwimlaneBy": "无效的泳道划分方式: %q",
+
+		"todo.groupNotShared":        "分组未共享（ID=%d）",
+		"todo.groupAlreadyShared":    "分组已共享（ID=%d）",
+		"todo.invalidShareUsername":  "用户名须为 3-32 位字母、数字、下划线、点或短横线",
+		"todo.sharePasswordTooShort": "密码至少需要 %d 位",
+		"todo.shareUsernameTaken":    "用户名已被注册: %s",
+		"todo.shareBadCredentials":   "用户名或密码错误",
+		"todo.shareTooManyAttempts":  "尝试次数过多，请 %d 分钟后再试",
+		"todo.shareUnauthorized":     "登录已失效，请重新登录同步服务器",
+		"todo.shareAccountNotFound":  "同步服务器上没有该用户: %s",
+		"todo.shareNotMember":        "共享分组不存在或你不是成员: %s",
+		"todo.shareNotLoggedIn":      "尚未登录同步服务器",
+		"todo.shareUnreachable":      "无法连接同步服务器: %s",
+		"todo.shareServer":           "同步服务器出错: %s",
+		"todo.invalidShareServer":    "无效的同步服务器地址（须为 https，仅本机地址可用 http）: %q",
+		"todo.readOnly":              "当前为只读模式，无法修改数据",
+		"todo.readOnlyLocked":        "应用以 --readonly 参数启动，无法关闭只读模式",
+		"todo.databaseBusy":          "数据库正被其他程序占用，请稍后重试",
+
+		"todo.backupPassphraseTooShort": "备份口令至少需要 %d 个字符",
+		"todo.backupPassphraseRequired": "该备份已加密，请输入口令",
+		"todo.backupBadPassphrase":      "口令错误，或备份文件已损坏",
+		"todo.invalidBackup":            "不是有效的 Spark-Todo 备份文件",
+
+		"todo.invalidCloudEndpoint":     "无效的对象存储服务地址: %q",
+		"todo.invalidCloudBucket":       "无效的存储桶名称: %q",
+		"todo.cloudAccessKeyEmpty":      "请填写访问密钥 ID（Access Key）",
+		"todo.invalidCloudBackupHours":  "备份间隔需在 %d 到 %d 小时之间",
+		"todo.invalidCloudBackupKeep":   "保留份数需在 %d 到 %d 之间",
+		"todo.cloudBackupNotConfigured": "尚未配置云备份",
+		"todo.cloudSecretMissing":       "请填写访问密钥（Secret Key）",
+		"todo.cloudPassphraseMissing":   "已开启加密，请设置备份口令",
+		"todo.cloudStorage":             "对象存储出错: %s",
+		"todo.keychainUnsupported":      "当前系统不支持在凭据库中保存密钥",
+		"todo.cloudBackupNotFound":      "云端备份不存在: %s",
+
+		"todo.invalidSearchScope": "无效的搜索范围: %q",
+
+		"todo.apiTokenNameEmpty":   "令牌名称不能为空",
+		"todo.apiTokenNameTooLong": "令牌名称不能超过 %d 个字符",
+		"todo.invalidApiScope":     "无效的令牌权限: %q",
+		"todo.apiTokenNotFound":    "令牌不存在: %d",
+		"todo.apiTokenInvalid":     "访问令牌无效或已被吊销",
+		"todo.apiTokenForbidden":   "该令牌为只读权限，不能修改数据",
+		"todo.invalidApiPort":      "本地接口端口须在 %d 到 %d 之间（0 表示关闭）",
+
+		"todo.notSubTask": "任务 %d 不是子任务",
+
+		"todo.invalidReminderTime": "无效的提醒时间",
+		"todo.reminderNotFound":    "任务 %d 没有设置提醒",
+		"todo.reminderGone":        "提醒已被取消",
+		"todo.invalidSnooze":       "稍后提醒的时长须在 %d 到 %d 分钟之间",
+
+		"todo.invalidPomodoroMinutes": "番茄钟时长须在 %d 到 %d 分钟之间",
+		"todo.pomodoroActive":         "已有进行中的番茄钟，请先结束它",
+		"todo.noPomodoro":             "没有可以操作的番茄钟",
+
+		"todo.timerRunning": "已有进行中的计时，请先停止它",
+		"todo.noTimer":      "没有进行中的计时",
+
+		"todo.noteEmpty":    "备注不能为空",
+		"todo.noteTooLong":  "备注过长（最多 %d 字）",
+		"todo.noteNotFound": "备注不存在（id=%d）",
+
+		"todo.dependencySelf":     "任务不能依赖自己",
+		"todo.dependencyCycle":    "不能形成循环依赖",
+		"todo.dependencyNotFound": "任务 %d 没有依赖任务 %d",
+
+		"todo.invalidImportFile":        "不是有效的 Spark-Todo 数据文件",
+		"todo.unsupportedImportVersion": "不支持的数据文件版本：%d",
+		"todo.invalidImportMode":        "无效的导入方式：%s",
+		"todo.importBrokenReference":    "数据文件中的引用无效（id=%d）",
+
+		"todo.csvNoTitleColumn":  "CSV 文件中没有标题列",
+		"todo.csvColumnNotFound": "CSV 文件中没有名为 %q 的列",
+		"todo.invalidCsvField":   "无效的导入字段: %q",
+		"todo.invalidCsvBool":    "无效的是/否值: %q",
+	},
+	EnUS: {
+		"app.notReady":         "The app has not finished initializing",
+		"app.initDBPathFailed": "Initialization failed: cannot determine the database path",
+		"app.initDBOpenFailed": "Initialization failed: cannot open the database",
+		"app.executableFailed": "Failed to locate the executable",
+		"app.restartFailed":    "Failed to start a new process",
+
+		"water.title":   "Water reminder",
+		"water.message": "Friendly reminder: time to drink some water",
+
+		"update.checkFailed":     "Failed to check for updates",
+		"update.changelogFailed": "Failed to load the changelog",
+		"update.upToDate":        "You are on the latest version",
+		"update.inProgress":      "An update is already downloading",
+		"update.canceled":        "Update download canceled",
+		"update.downloadFailed":  "Failed to download or verify the update",
+		"update.backupFailed":    "Failed to back up the database before updating",
+		"update.installerFailed": "Failed to start the installer",
+		"update.rollbackInfo":    "Failed to read rollback information",
+		"update.rollbackFailed":  "Rollback failed",
+		"update.rollbackStart":   "Failed to start the previous version",
+
+		"report.weeklyTitle":   "Weekly report",
+		"report.weeklySummary": "Completed %d, in progress %d, carried over %d, created %d this week.",
+		"report.completed":     "Completed this week",
+		"report.inProgress":    "In progress",
+		"report.carriedOver":   "Carried over to next week",
+		"report.timeTracked":   "Time tracked",
+		"report.trackedTotal":  "%s hours tracked this week",
+		"report.none":          "(none)",
+		"report.completedAt":   "completed %s",
+		"report.dueAt":         "due %s",
+		"report.generatedAt":   "Generated %s · Spark Todo",
+		"report.boardTitle":    "Task list",
+		"report.groupSummary":  "%d open · %d done",
+		"report.flagLegend":    "★ important · ⚡ urgent",
+		"report.doing":         "in progress",
+		"report.status.todo":   "To do",
+		"report.status.doing":  "In progress",
+		"report.status.done":   "Done",
+		"report.col.id":        "ID",
+		"report.col.group":     "Group",
+		"report.col.parent":    "Parent ID",
+		"report.col.title":     "Title",
+		"report.col.content":   "Content",
+		"report.col.status":    "Status",
+		"report.col.important": "Important",
+		"report.col.urgent":    "Urgent",
+		"report.col.due":       "Due",
+		"report.col.completed": "Completed",
+		"report.col.created":   "Created",
+		"report.col.updated":   "Updated",
+		"report.saveTitle":     "Save report",
+		"report.failed":        "Failed to generate the report",
+		"report.saveFailed":    "Failed to save the file",
+
+		"report.pdfUnavailable": "No browser available to generate the PDF (Microsoft Edge or Google Chrome is required)",
+
+		"diag.failed": "Failed to generate the diagnostic bundle",
+
+		"notify.countdownTitle":    "Countdown reminder",
+		"notify.countdownBody":     "%s: %d days left",
+		"notify.achievementTitle":  "Achievement unlocked",
+		"notify.achievementBody":   "Unlocked %d new achievements",
+		"notify.updateTitle":       "Update available",
+		"notify.updateBody":        "Version %s has been released",
+		"notify.backupFailedTitle": "Cloud backup failed",
+		"notify.reminderTitle":     "Task reminder",
+		"notify.pomodoroTitle":     "Pomodoro complete",
+		"notify.pomodoroBody":      "%s: focused for %d minutes",
+
+		"selfTest.databaseOk":          "Database reads and writes work",
+		"selfTest.databaseReadOnly":    "Write check skipped in read-only mode",
+		"selfTest.databaseFailed":      "Database read/write failed",
+		"selfTest.notificationOk":      "Reminders are delivered",
+		"selfTest.notificationTimeout": "The window did not confirm the test reminder within %d seconds",
+		"selfTest.hotkeysOk":           "%d hotkeys registered",
+		"selfTest.hotkeysNone":         "No global hotkeys are set",
+		"selfTest.updateOk":            "Connected to the update server; you are on the latest version",
+		"selfTest.updateAvailable":     "Connected to the update server; version %s is available",
+		"selfTest.updateFailed":        "Cannot reach the update server",
+
+		"history.createTask":    "Create task \"%s\"",
+		"history.updateTask":    "Edit task \"%s\"",
+		"history.deleteTask":    "Delete task \"%s\"",
+		"history.bulkDelete":    "Delete %d tasks",
+		"history.createGroup":   "Create group \"%s\"",
+		"history.updateGroup":   "Edit group \"%s\"",
+		"history.deleteGroup":   "Delete group \"%s\"",
+		"history.nothingToUndo": "Nothing to undo",
+		"history.nothingToRedo": "Nothing to redo",
+
+		"backup.failed":        "Failed to create the backup",
+		"backup.importTitle":   "Restore from backup",
+		"backup.restoreFailed": "Failed to restore from the backup",
+		"backup.safetyFailed":  "Failed to back up the current data before restoring",
+
+		"holiday.importTitle":  "Import holiday calendar",
+		"holiday.importFailed": "Failed to read the holiday calendar",
+
+		"data.exportFailed":       "Failed to export the data",
+		"data.importTitle":        "Import data",
+		"data.importFailed":       "Failed to read the data file",
+		"data.csvImportTitle":     "Import tasks from CSV",
+		"data.todoTxtImportTitle": "Import tasks from todo.txt",
+
+		"activity.task.created":     "Created %s",
+		"activity.task.restored":    "Restored %s",
+		"activity.task.started":     "Started %s",
+		"activity.task.completed":   "Completed %s",
+		"activity.task.reopened":    "Reopened %s",
+		"activity.task.moved":       "Moved %s to %s",
+		"activity.task.renamed":     "Renamed %[2]s to %[1]s",
+		"activity.task.deleted":     "Deleted %s",
+		"activity.group.created":    "Created group %s",
+		"activity.group.renamed":    "Renamed group %[2]s to %[1]s",
+		"activity.group.archived":   "Archived group %s",
+		"activity.group.unarchived": "Unarchived group %s",
+		"activity.group.deleted":    "Deleted group %s",
+
+		"attachment.noClipboardImage": "There is no image in the clipboard",
+		"attachment.clipboardFailed":  "Failed to read the image from the clipboard",
+		"attachment.saveFailed":       "Failed to save the attachment",
+		"attachment.thumbnailFailed":  "Failed to generate the thumbnail",
+
+		"report.timesheetTitle": "Timesheet",
+		"report.timesheetTotal": "Total: %s hours",
+		"report.col.date":       "Date",
+		"report.col.task":       "Task",
+		"report.col.minutes":    "Minutes",
+		"report.col.hours":      "Hours",
+
+		"report.snapshotSummary": "%d tasks, %d done",
+		"report.snapshotNote":    "Read-only snapshot",
+
+		"hotkey.invalidAction": "Unknown hotkey action: %q",
+		"hotkey.conflict":      "%s is already used by another application; please choose a different combination",
+		"hotkey.unsupported":   "Global hotkeys are not supported on this system",
+		"hotkey.failed":        "Failed to register hotkey %s",
+
+		"todo.invalidStatus":        "Invalid task status: %q",
+		"todo.groupNameEmpty":       "Group name cannot be empty",
+		"todo.groupNameTooLong":     "Group name is too long (max %d characters)",
+		"todo.groupNameTaken":       "A group with this name already exists",
+		"todo.groupNotFound":        "Group not found (id=%d)",
+		"todo.invalidGroupId":       "Invalid group ID",
+		"todo.groupCycle":           "A group cannot be moved into itself or one of its subgroups",
+		"todo.groupRequired":        "Please choose a group",
+		"todo.taskTitleEmpty":       "Task title cannot be empty",
+		"todo.taskTitleTooLong":     "Task title is too long (max %d characters)",
+		"todo.taskContentTooLong":   "Task content is too long (max %d characters)",
+		"todo.parentTaskNotFound":   "Parent task not found",
+		"todo.taskNotFound":         "Task not found (id=%d)",
+		"todo.invalidTaskId":        "Invalid task ID",
+		"todo.taskNotInGroup":       "Task %d is not a top-level task of group %d",
+		"todo.invalidSettingsScope": "Invalid settings category: %q",
+		"todo.updateUrlTooLong":     "Update URL is too long (max %d characters)",
+		"todo.invalidUpdateUrl":     "Update URL must be a valid https link (http is allowed only for localhost)",
+		"todo.invalidTimezone":      "Invalid time zone: %q",
+		"todo.invalidColor":         "Invalid color (expected #rrggbb): %q",
+		"todo.invalidHotkey":        "Invalid hotkey: %q (expected a combination like Ctrl+Alt+T)",
+		"todo.invalidDate":          "Invalid date: %q (expected YYYY-MM-DD)",
+		"todo.invalidSortMode":      "Invalid sort mode: %q",
+		"todo.invalidView":          "Invalid view: %q",
+		"todo.invalidRange":         "Invalid time range: %q",
+
+		"todo.invalidRetention":  "Trash retention must be between %d and %d days (0 keeps items forever)",
+		"todo.trashItemNotFound": "Trash item not found (id=%d)",
+
+		"todo.invalidReviewAction": "Invalid review action: %q",
+
+		"todo.goalTitleEmpty":    "Goal title cannot be empty",
+		"todo.goalTitleTooLong":  "Goal title is too long (max %d characters)",
+		"todo.goalNotFound":      "Goal not found (id=%d)",
+		"todo.invalidGoalTarget": "Goal target count cannot be negative: %d",
+
+		"todo.tagNameEmpty":    "Tag name cannot be empty",
+		"todo.tagNameTooLong":  "Tag name is too long (max %d characters)",
+		"todo.tagNameTaken":    "Tag %q already exists; merge the tags instead",
+		"todo.tagNotFound":     "Tag not found (id=%d)",
+		"todo.tagMergeSelf":    "A tag cannot be merged into itself",
+		"todo.tooManyTaskTags": "A task can have at most %d tags",
+
+		"todo.invalidEmoji": "Invalid emoji: %q (expected a single emoji)",
+
+		"todo.invalidTaskUrl": "Task link must be a valid http/https URL: %q",
+		"todo.taskUrlTooLong": "Task link is too long (max %d characters)",
+		"todo.taskNoLink":     "Task has no link (id=%d)",
+		"todo.linkNotInTask":  "Link not found in the task content: %q",
+
+		"todo.invalidContentLimit":  "Task content limit must be between %d and %d characters",
+		"todo.invalidContentFormat": "Invalid content format: %q",
+
+		"todo.attachmentNotFound": "Attachment not found (id=%d)",
+
+		"todo.invalidTitleLimit":     "Task title limit must be between %d and %d characters",
+		"todo.invalidGroupNameLimit": "Group name limit must be between %d and %d characters",
+
+		"todo.invalidRecurFreq":       "Invalid repeat frequency: %q",
+		"todo.invalidRecurInterval":   "Repeat interval must be between %d and %d",
+		"todo.invalidRecurMode":       "Invalid repeat mode: %q",
+		"todo.recurSubTask":           "Subtasks cannot repeat on their own",
+		"todo.recurLunarNotYearly":    "Lunar repeats are only supported yearly",
+		"todo.invalidRecurWeekday":    "Invalid weekday: %d (must be 0-6, 0 is Sunday)",
+		"todo.recurWeekdaysNotWeekly": "Weekdays can only be set for weekly repeats",
+
+		"todo.invalidHolidayCalendar": "Invalid holiday calendar: %s",
+
+		"todo.invalidLunarDate": "Invalid lunar date: %s",
+		"todo.lunarOutOfRange":  "Lunar dates are only supported from %d to %d",
+
+		"todo.invalidSwimlaneBy": "Invalid swimlane grouping: %q",
+
+		"todo.groupNotShared":        "Group is not shared (ID=%d)",
+		"todo.groupAlreadyShared":    "Group is already shared (ID=%d)",
+		"todo.invalidShareUsername":  "Usernames must be 3-32 letters, digits, underscores, dots or dashes",
+		"todo.sharePasswordTooShort": "Password must be at least %d characters",
+		"todo.shareUsernameTaken":    "Username is already taken: %s",
+		"todo.shareBadCredentials":   "Wrong username or password",
+		"todo.shareTooManyAttempts":  "Too many attempts, please try again in %d minutes",
+		"todo.shareUnauthorized":     "Your session has expired, please sign in to the sync server again",
+		"todo.shareAccountNotFound":  "No such user on the sync server: %s",
+		"todo.shareNotMember":        "Shared group not found or you are not a member: %s",
+		"todo.shareNotLoggedIn":      "Not signed in to a sync server",
+		"todo.shareUnreachable":      "Cannot reach the sync server: %s",
+		"todo.shareServer":           "Sync server error: %s",
+		"todo.invalidShareServer":    "Invalid sync server address (https required; http only for localhost): %q",
+		"todo.readOnly":              "Read-only mode is on; changes are not allowed",
+		"todo.readOnlyLocked":        "The app was started with --readonly; read-only mode cannot be turned off",
+		"todo.databaseBusy":          "The database is in use by another program; please try again shortly",
+
+		"todo.backupPassphraseTooShort": "The backup passphrase must be at least %d characters",
+		"todo.backupPassphraseRequired": "This backup is encrypted; please enter the passphrase",
+		"todo.backupBadPassphrase":      "Wrong passphrase, or the backup file is damaged",
+		"todo.invalidBackup":            "Not a valid Spark-Todo backup file",
+
+		"todo.invalidCloudEndpoint":     "Invalid object storage endpoint: %q",
+		"todo.invalidCloudBucket":       "Invalid bucket name: %q",
+		"todo.cloudAccessKeyEmpty":      "Please enter the access key ID",
+		"todo.invalidCloudBackupHours":  "The backup interval must be between %d and %d hours",
+		"todo.invalidCloudBackupKeep":   "The number of backups to keep must be between %d and %d",
+		"todo.cloudBackupNotConfigured": "Cloud backup is not configured",
+		"todo.cloudSecretMissing":       "Please enter the secret access key",
+		"todo.cloudPassphraseMissing":   "Encryption is on; please set a backup passphrase",
+		"todo.cloudStorage":             "Object storage error: %s",
+		"todo.keychainUnsupported":      "Saving secrets to the system keychain is not supported on this system",
+		"todo.cloudBackupNotFound":      "Cloud backup not found: %s",
+
+		"todo.invalidSearchScope": "Invalid search scope: %q",
+
+		"todo.apiTokenNameEmpty":   "Token name cannot be empty",
+		"todo.apiTokenNameTooLong": "Token name cannot exceed %d characters",
+		"todo.invalidApiScope":     "Invalid token scope: %q",
+		"todo.apiTokenNotFound":    "Token not found: %d",
+		"todo.apiTokenInvalid":     "The access token is invalid or has been revoked",
+		"todo.apiTokenForbidden":   "This token is read-only and cannot modify data",
+		"todo.invalidApiPort":      "The local API port must be between %d and %d (0 turns it off)",
+
+		"todo.notSubTask": "Task %d is not a subtask",
+
+		"todo.invalidReminderTime": "Invalid reminder time",
+		"todo.reminderNotFound":    "Task %d has no reminder",
+		"todo.reminderGone":        "The reminder has been cancelled",
+		"todo.invalidSnooze":       "Snooze duration must be between %d and %d minutes",
+
+		"todo.invalidPomodoroMinutes": "Pomodoro length must be between %d and %d minutes",
+		"todo.pomodoroActive":         "A pomodoro is already in progress; stop it first",
+		"todo.noPomodoro":             "There is no pomodoro to apply this to",
+
+		"todo.timerRunning": "A timer is already running; stop it first",
+		"todo.noTimer":      "No timer is running",
+
+		"todo.noteEmpty":    "Note cannot be empty",
+		"todo.noteTooLong":  "Note is too long (max %d characters)",
+		"todo.noteNotFound": "Note not found (id=%d)",
+
+		"todo.dependencySelf":     "A task cannot depend on itself",
+		"todo.dependencyCycle":    "This would create a circular dependency",
+		"todo.dependencyNotFound": "Task %d is not blocked by task %d",
+
+		"todo.invalidImportFile":        "Not a valid Spark-Todo data file",
+		"todo.unsupportedImportVersion": "Unsupported data file version: %d",
+		"todo.invalidImportMode":        "Invalid import mode: %s",
+		"todo.importBrokenReference":    "Invalid reference in data file (id=%d)",
+
+		"todo.csvNoTitleColumn":  "The CSV file has no title column",
+		"todo.csvColumnNotFound": "The CSV file has no column named %q",
+		"todo.invalidCsvField":   "Invalid import field: %q",
+		"todo.invalidCsvBool":    "Invalid yes/no value: %q",
+	},
+}
@@ -0,0 +1,55 @@
+// Package i18n 提供后端消息目录：后端产生、会直接展示给用户的文案
+// （错误提示、提醒弹窗标题、摘要等）都通过这里按用户语言取值。
+package i18n
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 支持的语言（BCP 47 标签，与 settings 表中 language 的取值一致）。
+const (
+	ZhCN = "zh-CN"
+	EnUS = "en-US"
+
+	// Default 为默认语言，也是缺失翻译时的回退语言。
+	Default = ZhCN
+)
+
+// Supported 返回全部支持的语言。
+func Supported() []string {
+	return []string{ZhCN, EnUS}
+}
+
+// Normalize 将语言标签规范化为受支持的值（忽略大小写，"zh"/"en" 前缀就近匹配），其它输入回退到 Default。
+func Normalize(lang string) string {
+	lang = strings.TrimSpace(lang)
+	for _, l := range Supported() {
+		if strings.EqualFold(lang, l) {
+			return l
+		}
+	}
+	switch {
+	case strings.HasPrefix(strings.ToLower(lang), "en"):
+		return EnUS
+	default:
+		return Default
+	}
+}
+
+// T 按语言查找 key 对应的文案，并用 args 做 fmt.Sprintf 格式化。
+//
+// 查找顺序：指定语言 -> Default -> key 本身（便于发现漏翻译的 key）。
+func T(lang, key string, args ...any) string {
+	msg, ok := catalog[Normalize(lang)][key]
+	if !ok {
+		msg, ok = catalog[Default][key]
+	}
+	if !ok {
+		msg = key
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
@@ -0,0 +1,16 @@
+// Package keychain 在系统凭据库中保存密钥等敏感信息（Windows 凭据管理器），避免明文写入数据库。
+package keychain
+
+import "errors"
+
+var (
+	// ErrNotFound 表示凭据库中没有该条目。
+	ErrNotFound = errors.New("keychain item not found")
+	// ErrUnsupported 表示当前平台不支持系统凭据库。
+	ErrUnsupported = errors.New("keychain is not supported on this platform")
+)
+
+// target 返回凭据在系统凭据库中的名称。
+func target(service, account string) string {
+	return service + ":" + account
+}
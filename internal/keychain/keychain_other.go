@@ -0,0 +1,18 @@
+//go:build !windows
+
+package keychain
+
+// Set 在非 Windows 平台上始终返回 ErrUnsupported。
+func Set(service, account, secret string) error {
+	return ErrUnsupported
+}
+
+// Get 在非 Windows 平台上始终返回 ErrUnsupported。
+func Get(service, account string) (string, error) {
+	return "", ErrUnsupported
+}
+
+// Delete 在非 Windows 平台上无操作。
+func Delete(service, account string) error {
+	return nil
+}
@@ -0,0 +1,107 @@
+//go:build windows
+// +build windows
+
+package keychain
+
+import (
+	"errors"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	credTypeGeneric         = 1
+	credPersistLocalMachine = 2
+	// credMaxBlobBytes 为 CRED_MAX_CREDENTIAL_BLOB_SIZE（5*512 字节）。
+	credMaxBlobBytes = 5 * 512
+)
+
+var (
+	advapi32        = windows.NewLazySystemDLL("advapi32.dll")
+	procCredWriteW  = advapi32.NewProc("CredWriteW")
+	procCredReadW   = advapi32.NewProc("CredReadW")
+	procCredDeleteW = advapi32.NewProc("CredDeleteW")
+	procCredFree    = advapi32.NewProc("CredFree")
+)
+
+// credential 对应 Win32 的 CREDENTIALW。
+type credential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        windows.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+// Set 保存（或覆盖）service/account 对应的密钥。
+func Set(service, account, secret string) error {
+	if len(secret) > credMaxBlobBytes {
+		return errors.New("keychain secret is too long")
+	}
+	name, err := windows.UTF16PtrFromString(target(service, account))
+	if err != nil {
+		return err
+	}
+	user, err := windows.UTF16PtrFromString(account)
+	if err != nil {
+		return err
+	}
+	blob := []byte(secret)
+	cred := credential{
+		Type:               credTypeGeneric,
+		TargetName:         name,
+		CredentialBlobSize: uint32(len(blob)),
+		Persist:            credPersistLocalMachine,
+		UserName:           user,
+	}
+	if len(blob) > 0 {
+		cred.CredentialBlob = &blob[0]
+	}
+	if r, _, err := procCredWriteW.Call(uintptr(unsafe.Pointer(&cred)), 0); r == 0 {
+		return err
+	}
+	return nil
+}
+
+// Get 读取 service/account 对应的密钥；不存在时返回 ErrNotFound。
+func Get(service, account string) (string, error) {
+	name, err := windows.UTF16PtrFromString(target(service, account))
+	if err != nil {
+		return "", err
+	}
+	var pcred *credential
+	if r, _, err := procCredReadW.Call(uintptr(unsafe.Pointer(name)), credTypeGeneric, 0, uintptr(unsafe.Pointer(&pcred))); r == 0 {
+		if errors.Is(err, windows.ERROR_NOT_FOUND) {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+	defer procCredFree.Call(uintptr(unsafe.Pointer(pcred)))
+	if pcred.CredentialBlobSize == 0 {
+		return "", nil
+	}
+	return string(unsafe.Slice(pcred.CredentialBlob, pcred.CredentialBlobSize)), nil
+}
+
+// Delete 删除 service/account 对应的密钥；不存在时不报错。
+func Delete(service, account string) error {
+	name, err := windows.UTF16PtrFromString(target(service, account))
+	if err != nil {
+		return err
+	}
+	if r, _, err := procCredDeleteW.Call(uintptr(unsafe.Pointer(name)), credTypeGeneric, 0); r == 0 {
+		if errors.Is(err, windows.ERROR_NOT_FOUND) {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
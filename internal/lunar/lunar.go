@@ -0,0 +1,194 @@
+// Package lunar 提供农历（夏历）与公历的互相转换，支持 1900–2100 年。
+//
+// 数据表为常用的 lunarInfo 压缩格式，每年一个值：
+//   - 低 4 位：闰月月份（0 表示无闰月）
+//   - 第 16 位：闰月为大月（30 天）
+//   - 第 15–4 位：正月到腊月依次是否为大月
+//
+// 农历 1900 年正月初一为公历 1900-01-31。
+package lunar
+
+import (
+	"fmt"
+	"time"
+)
+
+// 支持的农历年份范围。
+const (
+	MinYear = 1900
+	MaxYear = 2100
+)
+
+// lunarInfo 为 1900–2100 年的农历数据，格式见包注释。
+var lunarInfo = [...]uint32{
+	0x04bd8, 0x04ae0, 0x0a570, 0x054d5, 0x0d260, 0x0d950, 0x16554, 0x056a0, 0x09ad0, 0x055d2, // 1900-1909
+	0x04ae0, 0x0a5b6, 0x0a4d0, 0x0d250, 0x1d255, 0x0b540, 0x0d6a0, 0x0ada2, 0x095b0, 0x14977, // 1910-1919
+	0x04970, 0x0a4b0, 0x0b4b5, 0x06a50, 0x06d40, 0x1ab54, 0x02b60, 0x09570, 0x052f2, 0x04970, // 1920-1929
+	0x06566, 0x0d4a0, 0x0ea50, 0x16a95, 0x05ad0, 0x02b60, 0x186e3, 0x092e0, 0x1c8d7, 0x0c950, // 1930-1939
+	0x0d4a0, 0x1d8a6, 0x0b550, 0x056a0, 0x1a5b4, 0x025d0, 0x092d0, 0x0d2b2, 0x0a950, 0x0b557, // 1940-1949
+	0x06ca0, 0x0b550, 0x15355, 0x04da0, 0x0a5b0, 0x14573, 0x052b0, 0x0a9a8, 0x0e950, 0x06aa0, // 1950-1959
+	0x0aea6, 0x0ab50, 0x04b60, 0x0aae4, 0x0a570, 0x05260, 0x0f263, 0x0d950, 0x05b57, 0x056a0, // 1960-1969
+	0x096d0, 0x04dd5, 0x04ad0, 0x0a4d0, 0x0d4d4, 0x0d250, 0x0d558, 0x0b540, 0x0b6a0, 0x195a6, // 1970-1979
+	0x095b0, 0x049b0, 0x0a974, 0x0a4b0, 0x0b27a, 0x06a50, 0x06d40, 0x0af46, 0x0ab60, 0x09570, // 1980-1989
+	0x04af5, 0x04970, 0x064b0, 0x074a3, 0x0ea50, 0x06b58, 0x05ac0, 0x0ab60, 0x096d5, 0x092e0, // 1990-1999
+	0x0c960, 0x0d954, 0x0d4a0, 0x0da50, 0x07552, 0x056a0, 0x0abb7, 0x025d0, 0x092d0, 0x0cab5, // 2000-2009
+	0x0a950, 0x0b4a0, 0x0baa4, 0x0ad50, 0x055d9, 0x04ba0, 0x0a5b0, 0x15176, 0x052b0, 0x0a930, // 2010-2019
+	0x07954, 0x06aa0, 0x0ad50, 0x05b52, 0x04b60, 0x0a6e6, 0x0a4e0, 0x0d260, 0x0ea65, 0x0d530, // 2020-2029
+	0x05aa0, 0x076a3, 0x096d0, 0x04afb, 0x04ad0, 0x0a4d0, 0x1d0b6, 0x0d250, 0x0d520, 0x0dd45, // 2030-2039
+	0x0b5a0, 0x056d0, 0x055b2, 0x049b0, 0x0a577, 0x0a4b0, 0x0aa50, 0x1b255, 0x06d20, 0x0ada0, // 2040-2049
+	0x14b63, 0x09370, 0x049f8, 0x04970, 0x064b0, 0x168a6, 0x0ea50, 0x06b20, 0x1a6c4, 0x0aae0, // 2050-2059
+	0x0a2e0, 0x0d2e3, 0x0c960, 0x0d557, 0x0d4a0, 0x0da50, 0x05d55, 0x056a0, 0x0a6d0, 0x055d4, // 2060-2069
+	0x052d0, 0x0a9b8, 0x0a950, 0x0b4a0, 0x0b6a6, 0x0ad50, 0x055a0, 0x0aba4, 0x0a5b0, 0x052b0, // 2070-2079
+	0x0b273, 0x06930, 0x07337, 0x06aa0, 0x0ad50, 0x14b55, 0x04b60, 0x0a570, 0x054e4, 0x0d160, // 2080-2089
+	0x0e968, 0x0d520, 0x0daa0, 0x16aa6, 0x056d0, 0x04ae0, 0x0a9d4, 0x0a2d0, 0x0d150, 0x0f252, // 2090-2099
+	0x0d520, // 2100
+}
+
+// baseDate 为农历 1900 年正月初一对应的公历日期。
+var baseDate = time.Date(1900, 1, 31, 0, 0, 0, 0, time.UTC)
+
+// Date 为农历日期。
+type Date struct {
+	Year  int  `json:"year"`  // 农历年（以正月初一为界，与公历年份不完全一致）
+	Month int  `json:"month"` // 1-12
+	Day   int  `json:"day"`   // 1-30
+	Leap  bool `json:"leap"`  // 是否为闰月
+}
+
+// LeapMonth 返回农历 year 年的闰月月份；没有闰月时返回 0。
+func LeapMonth(year int) int {
+	return int(lunarInfo[year-MinYear] & 0xf)
+}
+
+// MonthDays 返回农历 year 年 month 月（leap 为闰月）的天数（29 或 30）；该月不存在时返回 0。
+func MonthDays(year, month int, leap bool) int {
+	if year < MinYear || year > MaxYear || month < 1 || month > 12 {
+		return 0
+	}
+	info := lunarInfo[year-MinYear]
+	if leap {
+		if LeapMonth(year) != month {
+			return 0
+		}
+		if info&0x10000 != 0 {
+			return 30
+		}
+		return 29
+	}
+	if info&(0x10000>>month) != 0 {
+		return 30
+	}
+	return 29
+}
+
+// yearDays 返回农历 year 年的总天数（含闰月）。
+func yearDays(year int) int {
+	days := 0
+	for m := 1; m <= 12; m++ {
+		days += MonthDays(year, m, false)
+	}
+	if lm := LeapMonth(year); lm > 0 {
+		days += MonthDays(year, lm, true)
+	}
+	return days
+}
+
+// FromSolar 将公历日期转换为农历日期（只取 t 在其自身时区中的年月日）。
+func FromSolar(t time.Time) (Date, error) {
+	y, m, d := t.Date()
+	offset := int(time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Sub(baseDate).Hours() / 24)
+	if offset < 0 {
+		return Date{}, fmt.Errorf("date %04d-%02d-%02d is out of lunar range", y, m, d)
+	}
+
+	year := MinYear
+	for ; year <= MaxYear; year++ {
+		n := yearDays(year)
+		if offset < n {
+			break
+		}
+		offset -= n
+	}
+	if year > MaxYear {
+		return Date{}, fmt.Errorf("date %04d-%02d-%02d is out of lunar range", y, m, d)
+	}
+
+	leapMonth := LeapMonth(year)
+	for month := 1; month <= 12; month++ {
+		n := MonthDays(year, month, false)
+		if offset < n {
+			return Date{Year: year, Month: month, Day: offset + 1}, nil
+		}
+		offset -= n
+		if month == leapMonth {
+			n = MonthDays(year, month, true)
+			if offset < n {
+				return Date{Year: year, Month: month, Day: offset + 1, Leap: true}, nil
+			}
+			offset -= n
+		}
+	}
+	// 按 yearDays 扣减后不会走到这里
+	return Date{}, fmt.Errorf("date %04d-%02d-%02d is out of lunar range", y, m, d)
+}
+
+// ToSolar 将农历日期转换为公历日期（返回 loc 时区当天 0 点）。
+func ToSolar(d Date, loc *time.Location) (time.Time, error) {
+	if err := d.Validate(); err != nil {
+		return time.Time{}, err
+	}
+	offset := 0
+	for y := MinYear; y < d.Year; y++ {
+		offset += yearDays(y)
+	}
+	leapMonth := LeapMonth(d.Year)
+	for m := 1; m < d.Month; m++ {
+		offset += MonthDays(d.Year, m, false)
+		if m == leapMonth {
+			offset += MonthDays(d.Year, m, true)
+		}
+	}
+	if d.Leap {
+		offset += MonthDays(d.Year, d.Month, false)
+	}
+	offset += d.Day - 1
+
+	solar := baseDate.AddDate(0, 0, offset)
+	return time.Date(solar.Year(), solar.Month(), solar.Day(), 0, 0, 0, 0, loc), nil
+}
+
+// Validate 校验农历日期是否存在（年份在支持范围内、闰月确实存在、日期不超过当月天数）。
+func (d Date) Validate() error {
+	if d.Year < MinYear || d.Year > MaxYear {
+		return fmt.Errorf("lunar year %d is out of range %d-%d", d.Year, MinYear, MaxYear)
+	}
+	n := MonthDays(d.Year, d.Month, d.Leap)
+	if n == 0 {
+		return fmt.Errorf("lunar month %d (leap=%t) does not exist in %d", d.Month, d.Leap, d.Year)
+	}
+	if d.Day < 1 || d.Day > n {
+		return fmt.Errorf("lunar day %d is out of range 1-%d", d.Day, n)
+	}
+	return nil
+}
+
+// AddYears 返回 t 对应的农历日期在 years 年后（同月同日）的公历时间，保留 t 的时刻与时区。
+//
+// 闰月日期按当年的正常月份计算；目标月份只有 29 天而原日期为三十时取廿九。
+func AddYears(t time.Time, years int) (time.Time, error) {
+	d, err := FromSolar(t)
+	if err != nil {
+		return time.Time{}, err
+	}
+	next := Date{Year: d.Year + years, Month: d.Month}
+	if next.Year < MinYear || next.Year > MaxYear {
+		return time.Time{}, fmt.Errorf("lunar year %d is out of range %d-%d", next.Year, MinYear, MaxYear)
+	}
+	next.Day = min(d.Day, MonthDays(next.Year, next.Month, false))
+
+	solar, err := ToSolar(next, t.Location())
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(solar.Year(), solar.Month(), solar.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location()), nil
+}
@@ -0,0 +1,80 @@
+package lunar
+
+var (
+	heavenlyStems   = []string{"甲", "乙", "丙", "丁", "戊", "己", "庚", "辛", "壬", "癸"}
+	earthlyBranches = []string{"子", "丑", "寅", "卯", "辰", "巳", "午", "未", "申", "酉", "戌", "亥"}
+	zodiacs         = []string{"鼠", "牛", "虎", "兔", "龙", "蛇", "马", "羊", "猴", "鸡", "狗", "猪"}
+	monthNames      = []string{"正", "二", "三", "四", "五", "六", "七", "八", "九", "十", "冬", "腊"}
+	dayDigits       = []string{"一", "二", "三", "四", "五", "六", "七", "八", "九", "十"}
+)
+
+// festivals 为按农历月日固定的传统节日（闰月不算）；除夕另见 Festival。
+var festivals = map[[2]int]string{
+	{1, 1}:   "春节",
+	{1, 15}:  "元宵节",
+	{2, 2}:   "龙抬头",
+	{5, 5}:   "端午节",
+	{7, 7}:   "七夕",
+	{7, 15}:  "中元节",
+	{8, 15}:  "中秋节",
+	{9, 9}:   "重阳节",
+	{12, 8}:  "腊八节",
+	{12, 23}: "小年",
+}
+
+// YearName 返回干支纪年与生肖，如 "乙巳蛇年"。
+func (d Date) YearName() string {
+	i := d.Year - 4
+	return heavenlyStems[i%10] + earthlyBranches[i%12] + zodiacs[i%12] + "年"
+}
+
+// MonthName 返回月份名称，如 "正月"、"闰六月"、"腊月"。
+func (d Date) MonthName() string {
+	name := monthNames[d.Month-1] + "月"
+	if d.Leap {
+		name = "闰" + name
+	}
+	return name
+}
+
+// DayName 返回日期名称，如 "初一"、"十五"、"廿三"、"三十"。
+func (d Date) DayName() string {
+	switch {
+	case d.Day == 10:
+		return "初十"
+	case d.Day == 20:
+		return "二十"
+	case d.Day == 30:
+		return "三十"
+	case d.Day < 10:
+		return "初" + dayDigits[d.Day-1]
+	case d.Day < 20:
+		return "十" + dayDigits[d.Day-11]
+	default:
+		return "廿" + dayDigits[d.Day-21]
+	}
+}
+
+// String 返回月日名称，如 "八月十五"。
+func (d Date) String() string {
+	return d.MonthName() + d.DayName()
+}
+
+// Festival 返回该日的传统节日名称（含除夕）；不是节日时返回空字符串。
+func (d Date) Festival() string {
+	if d.Leap {
+		return ""
+	}
+	if d.Month == 12 && d.Day == MonthDays(d.Year, 12, false) {
+		return "除夕"
+	}
+	return festivals[[2]int{d.Month, d.Day}]
+}
+
+// Label 返回适合与公历日期并列显示的简短文字：节日显示节日名，否则显示月日（如 "六月初五"）。
+func (d Date) Label() string {
+	if f := d.Festival(); f != "" {
+		return f
+	}
+	return d.String()
+}
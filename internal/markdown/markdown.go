@@ -0,0 +1,224 @@
+// Package markdown 将任务内容中的 Markdown 渲染为安全的 HTML 片段。
+//
+// 只支持常用的安全子集：标题、段落、换行、无序/有序列表（含任务清单）、引用、分隔线、
+// 代码块、行内代码、粗体、斜体、删除线与 http/https 链接。
+// 原文中的一切 HTML 都会被转义，不会出现原始标签、事件属性或 javascript: 等链接，
+// 因此渲染结果可以直接交给 webview 显示。
+package markdown
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	headingRe  = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	hrRe       = regexp.MustCompile(`^\s*([-*_])(\s*[-*_]){2,}\s*$`)
+	ulItemRe   = regexp.MustCompile(`^\s*[-*+]\s+(.*)$`)
+	olItemRe   = regexp.MustCompile(`^\s*\d{1,9}[.)]\s+(.*)$`)
+	taskItemRe = regexp.MustCompile(`^\[([ xX])\]\s+(.*)$`)
+	quoteRe    = regexp.MustCompile(`^\s*>\s?(.*)$`)
+	fenceRe    = regexp.MustCompile("^\\s*(```|~~~)")
+)
+
+// linkTrailingPunct 为自动链接末尾通常属于正文的标点。
+const linkTrailingPunct = `.,;:!?'")]}`
+
+// Render 将 src 渲染为 HTML 片段。
+func Render(src string) string {
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	var b strings.Builder
+	renderBlocks(&b, strings.Split(src, "\n"))
+	return b.String()
+}
+
+// renderBlocks 逐行解析块级元素。
+func renderBlocks(b *strings.Builder, lines []string) {
+	var para []string
+	flushPara := func() {
+		if len(para) == 0 {
+			return
+		}
+		b.WriteString("<p>")
+		for i, l := range para {
+			if i > 0 {
+				b.WriteString("<br>")
+			}
+			b.WriteString(inline(strings.TrimSpace(l)))
+		}
+		b.WriteString("</p>\n")
+		para = nil
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case strings.TrimSpace(line) == "":
+			flushPara()
+
+		case fenceRe.MatchString(line):
+			flushPara()
+			fence := fenceRe.FindStringSubmatch(line)[1]
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence); i++ {
+				code = append(code, lines[i])
+			}
+			b.WriteString("<pre><code>")
+			b.WriteString(html.EscapeString(strings.Join(code, "\n")))
+			b.WriteString("</code></pre>\n")
+
+		case headingRe.MatchString(line):
+			flushPara()
+			m := headingRe.FindStringSubmatch(line)
+			level := strconv.Itoa(len(m[1]))
+			b.WriteString("<h" + level + ">" + inline(m[2]) + "</h" + level + ">\n")
+
+		case hrRe.MatchString(line):
+			flushPara()
+			b.WriteString("<hr>\n")
+
+		case quoteRe.MatchString(line):
+			flushPara()
+			var quoted []string
+			for ; i < len(lines) && quoteRe.MatchString(lines[i]); i++ {
+				quoted = append(quoted, quoteRe.FindStringSubmatch(lines[i])[1])
+			}
+			i--
+			b.WriteString("<blockquote>\n")
+			renderBlocks(b, quoted)
+			b.WriteString("</blockquote>\n")
+
+		case ulItemRe.MatchString(line) || olItemRe.MatchString(line):
+			flushPara()
+			re, tag := ulItemRe, "ul"
+			if !ulItemRe.MatchString(line) {
+				re, tag = olItemRe, "ol"
+			}
+			b.WriteString("<" + tag + ">\n")
+			for ; i < len(lines) && re.MatchString(lines[i]); i++ {
+				b.WriteString("<li>" + listItem(re.FindStringSubmatch(lines[i])[1]) + "</li>\n")
+			}
+			i--
+			b.WriteString("</" + tag + ">\n")
+
+		default:
+			para = append(para, line)
+		}
+	}
+	flushPara()
+}
+
+// listItem 渲染列表项内容；"[ ] xxx" / "[x] xxx" 渲染为只读复选框。
+func listItem(text string) string {
+	m := taskItemRe.FindStringSubmatch(text)
+	if m == nil {
+		return inline(text)
+	}
+	box := `<input type="checkbox" disabled>`
+	if m[1] != " " {
+		box = `<input type="checkbox" checked disabled>`
+	}
+	return box + " " + inline(m[2])
+}
+
+// inlineSpans 为成对出现的行内标记及对应的 HTML 标签（按匹配优先级排列）。
+var inlineSpans = []struct {
+	mark string
+	tag  string
+}{
+	{"**", "strong"},
+	{"__", "strong"},
+	{"~~", "del"},
+	{"*", "em"},
+}
+
+// inline 渲染行内元素；除生成的标签外，所有文本都会被转义。
+func inline(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		rest := s[i:]
+
+		// 行内代码：内部不再解析
+		if rest[0] == '`' {
+			if end := strings.IndexByte(rest[1:], '`'); end >= 0 {
+				b.WriteString("<code>" + html.EscapeString(rest[1:1+end]) + "</code>")
+				i += end + 2
+				continue
+			}
+		}
+
+		// [文本](链接)：只接受 http/https，其它链接按普通文本输出
+		if rest[0] == '[' {
+			if text, link, n, ok := parseLink(rest); ok {
+				if isWebURL(link) {
+					b.WriteString(`<a href="` + html.EscapeString(link) + `">` + inline(text) + `</a>`)
+				} else {
+					b.WriteString(inline(text))
+				}
+				i += n
+				continue
+			}
+		}
+
+		// 自动链接
+		if strings.HasPrefix(rest, "http://") || strings.HasPrefix(rest, "https://") {
+			end := strings.IndexAny(rest, " \t<>\"'`")
+			if end < 0 {
+				end = len(rest)
+			}
+			link := strings.TrimRight(rest[:end], linkTrailingPunct)
+			if isWebURL(link) {
+				b.WriteString(`<a href="` + html.EscapeString(link) + `">` + html.EscapeString(link) + `</a>`)
+				i += len(link)
+				continue
+			}
+		}
+
+		matched := false
+		for _, sp := range inlineSpans {
+			if !strings.HasPrefix(rest, sp.mark) {
+				continue
+			}
+			inner := rest[len(sp.mark):]
+			end := strings.Index(inner, sp.mark)
+			if end <= 0 || strings.TrimSpace(inner[:end]) != inner[:end] {
+				continue
+			}
+			b.WriteString("<" + sp.tag + ">" + inline(inner[:end]) + "</" + sp.tag + ">")
+			i += len(sp.mark)*2 + end
+			matched = true
+			break
+		}
+		if matched {
+			continue
+		}
+
+		b.WriteString(html.EscapeString(rest[:1]))
+		i++
+	}
+	return b.String()
+}
+
+// parseLink 解析 s 开头的 [text](url)，返回文本、链接与消耗的字节数。
+func parseLink(s string) (text, link string, n int, ok bool) {
+	closeText := strings.Index(s, "](")
+	if closeText < 0 {
+		return "", "", 0, false
+	}
+	closeURL := strings.IndexByte(s[closeText+2:], ')')
+	if closeURL < 0 {
+		return "", "", 0, false
+	}
+	text = s[1:closeText]
+	link = strings.TrimSpace(s[closeText+2 : closeText+2+closeURL])
+	return text, link, closeText + 3 + closeURL, true
+}
+
+// isWebURL 判断 v 是否为带主机名的 http/https 绝对地址。
+func isWebURL(v string) bool {
+	u, err := url.Parse(v)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
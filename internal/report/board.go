@@ -0,0 +1,11 @@
+package report
+
+import "spark-todo/internal/todo"
+
+// Board 将看板/分组导出为 format（"md" | "html"）格式的文档。
+//
+// HTML 版本针对打印排版（分组不跨页断开、隐藏无关装饰），
+// b.AutoPrint 为 true 时在浏览器打开后自动弹出打印对话框。
+func Board(b todo.BoardExport, format string, lang string) ([]byte, error) {
+	return render("board", format, lang, b)
+}
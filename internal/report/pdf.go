@@ -0,0 +1,116 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// FormatPDF 为 PDF 输出：先渲染 HTML，再交给无头浏览器打印为 PDF。
+const FormatPDF = "pdf"
+
+// pdfTimeout 为单次 PDF 渲染的超时时间。
+const pdfTimeout = 60 * time.Second
+
+// ErrNoPDFRenderer 表示系统中没有找到可用于渲染 PDF 的浏览器（Edge / Chrome / Chromium）。
+var ErrNoPDFRenderer = errors.New("no headless browser available for PDF rendering")
+
+// PDF 将 HTML 文档打印为 PDF。
+//
+// 不在程序中内置排版引擎（中文字体嵌入、分页等都很重），而是复用系统已安装的 Chromium 内核浏览器：
+// Windows 10/11 自带 Microsoft Edge，其它平台查找 Chrome / Chromium / Edge。
+// 这样 PDF 与 HTML 打印版排版完全一致，在任何设备上打开效果都相同。
+func PDF(ctx context.Context, html []byte) ([]byte, error) {
+	browser := findPDFBrowser()
+	if browser == "" {
+		return nil, ErrNoPDFRenderer
+	}
+
+	dir, err := os.MkdirTemp("", "spark-todo-pdf-*")
+	if err != nil {
+		return nil, fmt.Errorf("create temp dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	in := filepath.Join(dir, "report.html")
+	out := filepath.Join(dir, "report.pdf")
+	if err := os.WriteFile(in, html, 0o644); err != nil {
+		return nil, fmt.Errorf("write html: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, pdfTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, browser,
+		"--headless",
+		"--disable-gpu",
+		"--no-first-run",
+		"--disable-extensions",
+		// 独立的临时配置目录：避免与用户正在运行的浏览器实例共享配置而被"转交"给已有进程
+		"--user-data-dir="+filepath.Join(dir, "profile"),
+		"--no-pdf-header-footer",
+		"--print-to-pdf-no-header",
+		"--print-to-pdf="+out,
+		FileURL(in),
+	)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("render pdf with %s: %w: %s", filepath.Base(browser), err, strings.TrimSpace(string(output)))
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		return nil, fmt.Errorf("read pdf: %w", err)
+	}
+	return data, nil
+}
+
+// findPDFBrowser 返回可用于无头打印的浏览器可执行文件路径；找不到时返回空字符串。
+func findPDFBrowser() string {
+	var candidates []string
+	switch runtime.GOOS {
+	case "windows":
+		for _, env := range []string{"ProgramFiles(x86)", "ProgramFiles", "LocalAppData"} {
+			base := os.Getenv(env)
+			if base == "" {
+				continue
+			}
+			candidates = append(candidates,
+				filepath.Join(base, `Microsoft\Edge\Application\msedge.exe`),
+				filepath.Join(base, `Google\Chrome\Application\chrome.exe`),
+			)
+		}
+	case "darwin":
+		candidates = []string{
+			"/Applications/Google Chrome.app/Contents/MacOS/Google Chrome",
+			"/Applications/Microsoft Edge.app/Contents/MacOS/Microsoft Edge",
+			"/Applications/Chromium.app/Contents/MacOS/Chromium",
+		}
+	default:
+		for _, name := range []string{"google-chrome", "google-chrome-stable", "chromium", "chromium-browser", "microsoft-edge"} {
+			if p, err := exec.LookPath(name); err == nil {
+				candidates = append(candidates, p)
+			}
+		}
+	}
+	for _, p := range candidates {
+		if st, err := os.Stat(p); err == nil && !st.IsDir() {
+			return p
+		}
+	}
+	return ""
+}
+
+// FileURL 将本地路径转换为 file:// URL（Windows 盘符路径补上前导斜杠：file:///C:/...）。
+func FileURL(path string) string {
+	p := filepath.ToSlash(path)
+	if !strings.HasPrefix(p, "/") {
+		p = "/" + p
+	}
+	return (&url.URL{Scheme: "file", Path: p}).String()
+}
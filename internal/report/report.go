@@ -0,0 +1,109 @@
+// Package report 将任务数据渲染为可分享的文档（Markdown / HTML）。
+//
+// 模板随程序嵌入（templates/ 目录），文案通过 i18n 按用户语言输出。
+package report
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+
+	"spark-todo/internal/i18n"
+)
+
+// 输出格式。
+const (
+	FormatMarkdown = "md"
+	FormatHTML     = "html"
+	FormatXLSX     = "xlsx" // 仅用于任务表格导出（TasksXLSX），不走模板渲染
+	FormatCSV      = "csv"  // 表格类导出（如工时表），不走模板渲染
+)
+
+//go:embed templates/*
+var templateFS embed.FS
+
+// ParseFormat 校验输出格式（忽略大小写，"markdown" 视为 "md"）。
+func ParseFormat(format string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "", FormatMarkdown, "markdown":
+		return FormatMarkdown, nil
+	case FormatHTML, "htm":
+		return FormatHTML, nil
+	default:
+		return "", fmt.Errorf("unsupported report format %q", format)
+	}
+}
+
+// funcs 返回模板可用的函数：t 按 lang 翻译 i18n 的 report.* 文案，hours 将分钟数格式化为小时，
+// safeHTML 原样输出已由后端渲染为安全 HTML 的任务内容（见 todo.RenderContent），md 转义 Markdown 行内语法字符。
+func funcs(lang string) map[string]any {
+	return map[string]any{
+		"t": func(key string, args ...any) string {
+			return i18n.T(lang, "report."+key, args...)
+		},
+		"lang":  func() string { return lang },
+		"hours": formatHours,
+		"md":    escapeMarkdown,
+		"safeHTML": func(s string) htmltemplate.HTML {
+			return htmltemplate.HTML(s)
+		},
+	}
+}
+
+// formatHours 将分钟数格式化为保留两位小数的小时数（便于按小时计费）。
+func formatHours(minutes int) string {
+	return fmt.Sprintf("%.2f", float64(minutes)/60)
+}
+
+// markdownEscaper 转义会被解释为 Markdown 语法的字符（如标题中的 "*"、"[ ]"、"#"），保证标题按原样显示。
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`,
+	"<", `\<`, ">", `\>`, "#", `\#`, "|", `\|`, "~", `\~`,
+)
+
+// escapeMarkdown 转义 s 中的 Markdown 行内语法字符，并把换行折叠为空格（保持列表项在一行内）。
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(strings.Join(strings.Fields(s), " "))
+}
+
+// csvCell 在以 "="、"+"、"-"、"@"、制表符或回车开头的文本前加 "'"，
+// 防止任务标题等用户输入在电子表格中被当作公式执行（CSV 注入）；todo.Store.ImportCSV 导入时会去掉这个前缀。
+func csvCell(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// render 用 templates/<name>.<format>.tmpl 渲染 data。
+func render(name string, format string, lang string, data any) ([]byte, error) {
+	format, err := ParseFormat(format)
+	if err != nil {
+		return nil, err
+	}
+	file := "templates/" + name + "." + format + ".tmpl"
+
+	var buf bytes.Buffer
+	switch format {
+	case FormatHTML:
+		tpl, err := htmltemplate.New(name).Funcs(funcs(lang)).ParseFS(templateFS, file, "templates/style.css.tmpl")
+		if err != nil {
+			return nil, fmt.Errorf("parse template %s: %w", file, err)
+		}
+		if err := tpl.ExecuteTemplate(&buf, name+"."+format+".tmpl", data); err != nil {
+			return nil, fmt.Errorf("render %s: %w", file, err)
+		}
+	default:
+		tpl, err := texttemplate.New(name).Funcs(funcs(lang)).ParseFS(templateFS, file)
+		if err != nil {
+			return nil, fmt.Errorf("parse template %s: %w", file, err)
+		}
+		if err := tpl.ExecuteTemplate(&buf, name+"."+format+".tmpl", data); err != nil {
+			return nil, fmt.Errorf("render %s: %w", file, err)
+		}
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,9 @@
+package report
+
+import "spark-todo/internal/todo"
+
+// Snapshot 将分组快照渲染为单个自包含的只读 HTML 文件（样式内联、无外部资源、无脚本），
+// 可直接通过邮件发送或放在共享文件夹中用浏览器打开。
+func Snapshot(s todo.GroupSnapshot, lang string) ([]byte, error) {
+	return render("snapshot", FormatHTML, lang, s)
+}
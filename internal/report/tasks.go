@@ -0,0 +1,144 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"time"
+
+	"spark-todo/internal/i18n"
+	"spark-todo/internal/todo"
+)
+
+// TasksXLSX 将任务（含子任务，逐行平铺）导出为 xlsx。
+//
+// 分组、状态、重要/紧急、各时间字段都是独立的列并带有正确的单元格类型（布尔、日期时间），
+// 便于在 Excel 中直接筛选与排序；时间按 loc 时区输出。
+func TasksXLSX(tasks []todo.Task, groups []todo.Group, loc *time.Location, lang string) ([]byte, error) {
+	t := func(key string) string { return i18n.T(lang, "report.col."+key) }
+
+	groupNames := make(map[int64]string, len(groups))
+	for _, g := range groups {
+		groupNames[g.ID] = g.Name
+	}
+	ts := func(ms int64) any {
+		if ms <= 0 {
+			return nil
+		}
+		return time.UnixMilli(ms).In(loc)
+	}
+
+	sheet := Sheet{
+		Name: i18n.T(lang, "report.boardTitle"),
+		Columns: []Column{
+			{Header: t("id"), Width: 8},
+			{Header: t("group"), Width: 14},
+			{Header: t("parent"), Width: 10},
+			{Header: t("title"), Width: 40},
+			{Header: t("content"), Width: 50},
+			{Header: t("status"), Width: 10},
+			{Header: t("important"), Width: 8},
+			{Header: t("urgent"), Width: 8},
+			{Header: t("due"), Width: 17},
+			{Header: t("completed"), Width: 17},
+			{Header: t("created"), Width: 17},
+			{Header: t("updated"), Width: 17},
+		},
+	}
+
+	var add func(task todo.Task)
+	add = func(task todo.Task) {
+		var parent any
+		if task.ParentID > 0 {
+			parent = task.ParentID
+		}
+		sheet.Rows = append(sheet.Rows, []any{
+			task.ID,
+			csvCell(groupNames[task.GroupID]),
+			parent,
+			csvCell(task.Title),
+			csvCell(task.Content),
+			i18n.T(lang, "report.status."+string(task.Status)),
+			task.Important,
+			task.Urgent,
+			ts(task.DueAt),
+			ts(task.CompletedAt),
+			ts(task.CreatedAt),
+			ts(task.UpdatedAt),
+		})
+		for _, sub := range task.SubTasks {
+			add(sub)
+		}
+	}
+	for _, task := range tasks {
+		add(task)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteXLSX(&buf, sheet); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// csvTimeLayout 为 CSV 中的时间格式：固定为 ISO 形式（不跟随日期格式设置），便于再次导入（见 todo.Store.ImportCSV）。
+const csvTimeLayout = "2006-01-02 15:04"
+
+// TasksCSV 将任务（含子任务，逐行平铺）导出为 CSV，列与 TasksXLSX 相同。
+//
+// 带 UTF-8 BOM，便于 Excel 直接打开中文内容；布尔列为 true/false，时间按 loc 时区输出。
+// 文本列中可能被当作公式的内容前加 "'"（见 csvCell）。
+func TasksCSV(tasks []todo.Task, groups []todo.Group, loc *time.Location, lang string) ([]byte, error) {
+	t := func(key string) string { return i18n.T(lang, "report.col."+key) }
+
+	groupNames := make(map[int64]string, len(groups))
+	for _, g := range groups {
+		groupNames[g.ID] = g.Name
+	}
+	ts := func(ms int64) string {
+		if ms <= 0 {
+			return ""
+		}
+		return time.UnixMilli(ms).In(loc).Format(csvTimeLayout)
+	}
+
+	rows := [][]string{{
+		t("id"), t("group"), t("parent"), t("title"), t("content"), t("status"),
+		t("important"), t("urgent"), t("due"), t("completed"), t("created"), t("updated"),
+	}}
+	var add func(task todo.Task)
+	add = func(task todo.Task) {
+		parent := ""
+		if task.ParentID > 0 {
+			parent = strconv.FormatInt(task.ParentID, 10)
+		}
+		rows = append(rows, []string{
+			strconv.FormatInt(task.ID, 10),
+			csvCell(groupNames[task.GroupID]),
+			parent,
+			csvCell(task.Title),
+			csvCell(task.Content),
+			i18n.T(lang, "report.status."+string(task.Status)),
+			strconv.FormatBool(task.Important),
+			strconv.FormatBool(task.Urgent),
+			ts(task.DueAt),
+			ts(task.CompletedAt),
+			ts(task.CreatedAt),
+			ts(task.UpdatedAt),
+		})
+		for _, sub := range task.SubTasks {
+			add(sub)
+		}
+	}
+	for _, task := range tasks {
+		add(task)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("\ufeff")
+	if err := csv.NewWriter(&buf).WriteAll(rows); err != nil {
+		return nil, fmt.Errorf("write tasks csv: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,51 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"spark-todo/internal/i18n"
+	"spark-todo/internal/todo"
+)
+
+// Timesheet 将工时表渲染为 format（"md" | "csv"）格式的文档。
+//
+// CSV 每行为"某天 × 某任务"，带 UTF-8 BOM，便于 Excel 直接打开中文内容；任务名与分组名按 csvCell 转义。
+func Timesheet(ts todo.Timesheet, format string, lang string) ([]byte, error) {
+	if !strings.EqualFold(strings.TrimSpace(format), FormatCSV) {
+		return render("timesheet", format, lang, ts)
+	}
+
+	t := func(key string) string { return i18n.T(lang, "report.col."+key) }
+	var buf bytes.Buffer
+	buf.WriteString("\ufeff")
+	w := csv.NewWriter(&buf)
+	rows := [][]string{{t("date"), t("id"), t("task"), t("group"), t("minutes"), t("hours")}}
+	for _, d := range ts.Days {
+		for _, task := range d.Tasks {
+			rows = append(rows, []string{
+				d.Date,
+				strconv.FormatInt(task.TaskID, 10),
+				csvCell(taskLabel(task)),
+				csvCell(task.Group),
+				strconv.Itoa(task.Minutes),
+				formatHours(task.Minutes),
+			})
+		}
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return nil, fmt.Errorf("write timesheet csv: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// taskLabel 返回工时表中的任务名；任务已删除时用 "#ID" 代替。
+func taskLabel(task todo.TimesheetTask) string {
+	if task.Title == "" {
+		return "#" + strconv.FormatInt(task.TaskID, 10)
+	}
+	return task.Title
+}
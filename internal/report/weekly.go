@@ -0,0 +1,8 @@
+package report
+
+import "spark-todo/internal/todo"
+
+// Weekly 将周报渲染为 format（"md" | "html"）格式的文档。
+func Weekly(r todo.WeeklyReport, format string, lang string) ([]byte, error) {
+	return render("weekly", format, lang, r)
+}
@@ -0,0 +1,236 @@
+// Package restapi 为第三方脚本提供本地 REST 接口：只监听 127.0.0.1，每个请求都须携带 API 令牌（见 todo.APIToken）。
+package restapi
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"spark-todo/internal/todo"
+)
+
+// apiPrefix 为本地接口的路径前缀。
+const apiPrefix = "/api/v1"
+
+// maxRequestBytes 为请求体上限。
+const maxRequestBytes = 1 << 20
+
+// StatusRequest 为修改任务状态的请求。
+type StatusRequest struct {
+	Status todo.Status `json:"status"`
+}
+
+// ErrorResponse 为失败时的响应体：Code/Args 与 todo.Error 一致，Message 为按界面语言翻译的文案。
+type ErrorResponse struct {
+	Code    string `json:"code"`
+	Args    []any  `json:"args,omitempty"`
+	Message string `json:"message"`
+}
+
+// Server 为本地 REST 接口：
+//
+//	GET    /api/v1/groups              全部分组（→ []todo.Group）
+//	GET    /api/v1/tasks               全部任务，子任务挂在父任务下（→ []todo.Task）
+//	GET    /api/v1/tasks/{id}          单个任务，含完整内容（→ todo.Task）
+//	POST   /api/v1/tasks               新建任务（todo.Task → todo.Task）
+//	POST   /api/v1/tasks/{id}/status   修改任务状态（StatusRequest → todo.Task）
+//	DELETE /api/v1/tasks/{id}          删除任务（连同子任务放入回收站）
+//
+// 每个请求都需要 Authorization: Bearer <令牌>；GET 以外的请求需要读写权限（todo.APIScopeReadWrite）。
+// Host 不是本机地址的请求一律拒绝，防止网页通过 DNS 重绑定访问本接口。
+type Server struct {
+	store *todo.Store
+	mux   *http.ServeMux
+	lang  func() string
+	// OnChange 在请求修改了数据之后调用（如通知界面刷新）；可为 nil
+	OnChange func()
+	// Logger 记录内部错误；为 nil 时使用 log.Default()
+	Logger *log.Logger
+}
+
+// NewServer 创建使用 store 的本地接口，错误文案按 lang() 返回的语言翻译。
+func NewServer(store *todo.Store, lang func() string) *Server {
+	s := &Server{store: store, mux: http.NewServeMux(), lang: lang}
+	s.mux.HandleFunc("GET "+apiPrefix+"/groups", s.handleListGroups)
+	s.mux.HandleFunc("GET "+apiPrefix+"/tasks", s.handleListTasks)
+	s.mux.HandleFunc("GET "+apiPrefix+"/tasks/{id}", s.handleGetTask)
+	s.mux.HandleFunc("POST "+apiPrefix+"/tasks", s.handleCreateTask)
+	s.mux.HandleFunc("POST "+apiPrefix+"/tasks/{id}/status", s.handleSetStatus)
+	s.mux.HandleFunc("DELETE "+apiPrefix+"/tasks/{id}", s.handleDeleteTask)
+	return s
+}
+
+// ServeHTTP 实现 http.Handler：校验 Host 与令牌后交给各接口处理。
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !isLoopbackHost(r.Host) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+	token, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if _, err := s.store.AuthenticateAPIToken(r.Context(), token, r.Method != http.MethodGet, time.Now()); err != nil {
+		s.writeError(w, err)
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestBytes)
+	s.mux.ServeHTTP(w, r)
+}
+
+// isLoopbackHost 报告请求的 Host（可带端口）是否为 localhost 或回环地址。
+func isLoopbackHost(host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(strings.Trim(host, "[]"))
+	return ip != nil && ip.IsLoopback()
+}
+
+func (s *Server) handleListGroups(w http.ResponseWriter, r *http.Request) {
+	groups, err := s.store.ListGroups(r.Context())
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, groups)
+}
+
+func (s *Server) handleListTasks(w http.ResponseWriter, r *http.Request) {
+	tasks, err := s.store.ListTasks(r.Context(), todo.SortUpdated)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, tasks)
+}
+
+func (s *Server) handleGetTask(w http.ResponseWriter, r *http.Request) {
+	t, ok := s.task(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, t)
+}
+
+func (s *Server) handleCreateTask(w http.ResponseWriter, r *http.Request) {
+	var req todo.Task
+	if !s.readJSON(w, r, &req) {
+		return
+	}
+	req.ID = 0
+	if req.Status == "" {
+		req.Status = todo.StatusTodo
+	}
+	t, err := s.store.UpsertTask(r.Context(), req)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	s.changed()
+	writeJSON(w, http.StatusOK, t)
+}
+
+func (s *Server) handleSetStatus(w http.ResponseWriter, r *http.Request) {
+	var req StatusRequest
+	if !s.readJSON(w, r, &req) {
+		return
+	}
+	t, ok := s.task(w, r)
+	if !ok {
+		return
+	}
+	t.Status = req.Status
+	t, err := s.store.UpsertTask(r.Context(), t)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	s.changed()
+	writeJSON(w, http.StatusOK, t)
+}
+
+func (s *Server) handleDeleteTask(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		s.writeError(w, todo.ErrInvalidTaskID)
+		return
+	}
+	if err := s.store.DeleteTask(r.Context(), id); err != nil {
+		s.writeError(w, err)
+		return
+	}
+	s.changed()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// task 读取路径中 {id} 对应的任务（含完整内容）；失败时直接写出错误并返回 false。
+func (s *Server) task(w http.ResponseWriter, r *http.Request) (todo.Task, bool) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		s.writeError(w, todo.ErrInvalidTaskID)
+		return todo.Task{}, false
+	}
+	t, err := s.store.GetTask(r.Context(), id)
+	if err == nil {
+		t.Content, err = s.store.GetTaskContent(r.Context(), id)
+	}
+	if err != nil {
+		s.writeError(w, err)
+		return todo.Task{}, false
+	}
+	return t, true
+}
+
+// changed 通知数据已被修改。
+func (s *Server) changed() {
+	if s.OnChange != nil {
+		s.OnChange()
+	}
+}
+
+// readJSON 读取请求体；格式错误时直接写出 400 并返回 false。
+func (s *Server) readJSON(w http.ResponseWriter, r *http.Request, dst any) bool {
+	if err := json.NewDecoder(r.Body).Decode(dst); err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Code: "invalidRequest", Message: "invalid request body"})
+		return false
+	}
+	return true
+}
+
+// writeError 写出错误响应：业务错误返回错误码与翻译后的文案，其余错误只记日志，不把内部细节暴露给调用方。
+func (s *Server) writeError(w http.ResponseWriter, err error) {
+	var te *todo.Error
+	if !errors.As(err, &te) {
+		logger := s.Logger
+		if logger == nil {
+			logger = log.Default()
+		}
+		logger.Printf("local api: internal error: %v", err)
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Code: "internal", Message: "internal error"})
+		return
+	}
+
+	status := http.StatusBadRequest
+	switch {
+	case errors.Is(te, todo.ErrAPITokenInvalid):
+		status = http.StatusUnauthorized
+	case errors.Is(te, todo.ErrAPITokenForbidden), errors.Is(te, todo.ErrReadOnly):
+		status = http.StatusForbidden
+	case errors.Is(te, todo.ErrTaskNotFound), errors.Is(te, todo.ErrGroupNotFound):
+		status = http.StatusNotFound
+	}
+	writeJSON(w, status, ErrorResponse{Code: te.Code, Args: te.Args, Message: te.Localize(s.lang())})
+}
+
+// writeJSON 以 JSON 写出响应。
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
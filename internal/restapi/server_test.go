@@ -0,0 +1,133 @@
+package restapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"spark-todo/internal/todo"
+)
+
+// newTestServer 在临时目录中打开数据库并启动本地接口，返回接口地址、数据库与一个只读、一个读写令牌。
+func newTestServer(t *testing.T) (string, *todo.Store, string, string) {
+	t.Helper()
+	store, err := todo.Open(filepath.Join(t.TempDir(), "todo.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	ctx := context.Background()
+	_, read, err := store.CreateAPIToken(ctx, "read", todo.APIScopeRead, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, write, err := store.CreateAPIToken(ctx, "write", todo.APIScopeReadWrite, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(NewServer(store, func() string { return "en-US" }))
+	t.Cleanup(srv.Close)
+	return srv.URL + apiPrefix, store, read, write
+}
+
+// do 发送请求并把响应体解码到 out（可为 nil），返回状态码。
+func do(t *testing.T, method, url, token string, body, out any) int {
+	t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	req, err := http.NewRequest(method, url, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatalf("%s %s: decode: %v", method, url, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+func TestServerRequiresToken(t *testing.T) {
+	base, _, read, _ := newTestServer(t)
+	var e ErrorResponse
+	if code := do(t, http.MethodGet, base+"/tasks", "", nil, &e); code != http.StatusUnauthorized || e.Code != "apiTokenInvalid" {
+		t.Errorf("no token: %d %+v", code, e)
+	}
+	if code := do(t, http.MethodGet, base+"/tasks", "spk_wrong", nil, nil); code != http.StatusUnauthorized {
+		t.Errorf("unknown token: %d", code)
+	}
+	if code := do(t, http.MethodGet, base+"/groups", read, nil, nil); code != http.StatusOK {
+		t.Errorf("read token: %d", code)
+	}
+}
+
+func TestServerTaskWrites(t *testing.T) {
+	base, store, read, write := newTestServer(t)
+	var groups []todo.Group
+	if code := do(t, http.MethodGet, base+"/groups", read, nil, &groups); code != http.StatusOK || len(groups) == 0 {
+		t.Fatalf("groups: %d %+v", code, groups)
+	}
+	req := todo.Task{GroupID: groups[0].ID, Title: "from script"}
+
+	// 只读令牌不能修改数据
+	if code := do(t, http.MethodPost, base+"/tasks", read, req, nil); code != http.StatusForbidden {
+		t.Errorf("create with read token: %d", code)
+	}
+
+	var created todo.Task
+	if code := do(t, http.MethodPost, base+"/tasks", write, req, &created); code != http.StatusOK || created.ID == 0 || created.Status != todo.StatusTodo {
+		t.Fatalf("create: %d %+v", code, created)
+	}
+	var done todo.Task
+	url := base + "/tasks/" + strconv.FormatInt(created.ID, 10)
+	if code := do(t, http.MethodPost, url+"/status", write, StatusRequest{Status: todo.StatusDone}, &done); code != http.StatusOK || done.Status != todo.StatusDone {
+		t.Errorf("set status: %d %+v", code, done)
+	}
+	if got, err := store.GetTask(context.Background(), created.ID); err != nil || got.Status != todo.StatusDone {
+		t.Errorf("stored task = %+v, %v", got, err)
+	}
+
+	if code := do(t, http.MethodDelete, url, write, nil, nil); code != http.StatusNoContent {
+		t.Errorf("delete: %d", code)
+	}
+	var e ErrorResponse
+	if code := do(t, http.MethodGet, url, read, nil, &e); code != http.StatusNotFound || e.Code != "taskNotFound" {
+		t.Errorf("get deleted task: %d %+v", code, e)
+	}
+}
+
+func TestServerRejectsForeignHost(t *testing.T) {
+	base, _, read, _ := newTestServer(t)
+	req, err := http.NewRequest(http.MethodGet, base+"/tasks", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Host = "attacker.example"
+	req.Header.Set("Authorization", "Bearer "+read)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("foreign host: %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,159 @@
+package share
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"time"
+
+	"spark-todo/internal/todo"
+)
+
+// Client 为桌面端访问同步服务端的客户端。
+//
+// 返回的错误均为 *todo.Error（服务端的业务错误码、ErrShareUnreachable 或 ErrShareServer），可直接按用户语言翻译。
+type Client struct {
+	BaseURL string // 已经 NormalizeServerURL 规范化的服务器地址
+	Token   string // 登录令牌；注册/登录时可为空
+	HTTP    *http.Client
+}
+
+// NewClient 创建客户端（请求超时 30 秒；不跟随重定向到非 https 地址）。
+func NewClient(baseURL, token string) *Client {
+	return &Client{BaseURL: baseURL, Token: token, HTTP: &http.Client{
+		Timeout: 30 * time.Second,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			if !todo.IsSecureWebURL(req.URL.String()) {
+				return todo.ErrInvalidShareServer.With(req.URL.Redacted())
+			}
+			return nil
+		},
+	}}
+}
+
+// Register 注册账号。
+func (c *Client) Register(ctx context.Context, username, password string) (LoginResponse, error) {
+	var out LoginResponse
+	err := c.do(ctx, http.MethodPost, "/register", Credentials{Username: username, Password: password}, &out)
+	return out, err
+}
+
+// Login 登录并返回新的登录令牌。
+func (c *Client) Login(ctx context.Context, username, password string) (LoginResponse, error) {
+	var out LoginResponse
+	err := c.do(ctx, http.MethodPost, "/login", Credentials{Username: username, Password: password}, &out)
+	return out, err
+}
+
+// Logout 撤销当前登录令牌。
+func (c *Client) Logout(ctx context.Context) error {
+	return c.do(ctx, http.MethodPost, "/logout", nil, nil)
+}
+
+// Groups 返回当前账号所在的共享分组。
+func (c *Client) Groups(ctx context.Context) ([]todo.HostedGroup, error) {
+	var out []todo.HostedGroup
+	err := c.do(ctx, http.MethodGet, "/groups", nil, &out)
+	return out, err
+}
+
+// CreateGroup 在服务端新建共享分组。
+func (c *Client) CreateGroup(ctx context.Context, name string) (todo.HostedGroup, error) {
+	var out todo.HostedGroup
+	err := c.do(ctx, http.MethodPost, "/groups", CreateGroupRequest{Name: name}, &out)
+	return out, err
+}
+
+// AddMember 邀请 username 加入共享分组。
+func (c *Client) AddMember(ctx context.Context, shareID, username string) (todo.HostedGroup, error) {
+	var out todo.HostedGroup
+	err := c.do(ctx, http.MethodPost, "/groups/"+url.PathEscape(shareID)+"/members", AddMemberRequest{Username: username}, &out)
+	return out, err
+}
+
+// Sync 推送变更并拉取服务端变更。
+func (c *Client) Sync(ctx context.Context, shareID string, req SyncRequest) (SyncResponse, error) {
+	var out SyncResponse
+	err := c.do(ctx, http.MethodPost, "/groups/"+url.PathEscape(shareID)+"/sync", req, &out)
+	return out, err
+}
+
+// do 发送 JSON 请求并解析响应。
+//
+// 地址不安全（如早期保存的 http 会话）时不发送任何请求，避免凭据与令牌以明文传输。
+func (c *Client) do(ctx context.Context, method, path string, in any, out any) error {
+	if !todo.IsSecureWebURL(c.BaseURL) {
+		return todo.ErrInvalidShareServer.With(c.BaseURL)
+	}
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+apiPrefix+path, body)
+	if err != nil {
+		return todo.ErrShareUnreachable.With(err.Error())
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return todo.ErrShareUnreachable.With(err.Error())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		var e ErrorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&e); err != nil || e.Code == "" {
+			return todo.ErrShareServer.With(resp.Status)
+		}
+		return &todo.Error{Code: e.Code, Args: wholeNumbers(e.Args)}
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return todo.ErrShareServer.With(fmt.Sprintf("decode response: %v", err))
+	}
+	return nil
+}
+
+// wholeNumbers 把 JSON 解码得到的整数值 float64 还原为 int64，使 %d 格式的错误文案正常显示。
+func wholeNumbers(args []any) []any {
+	for i, a := range args {
+		if f, ok := a.(float64); ok && f == math.Trunc(f) {
+			args[i] = int64(f)
+		}
+	}
+	return args
+}
+
+// SyncGroup 同步一个共享分组：推送本地变更、拉取并应用服务端变更，返回本次应用的远端记录数。
+func SyncGroup(ctx context.Context, c *Client, store *todo.Store, g todo.SharedGroup) (int, error) {
+	pending, seq, err := store.PendingSharedTasks(ctx, g.GroupID)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := c.Sync(ctx, g.ShareID, SyncRequest{Since: g.Rev, Changes: pending})
+	if err != nil {
+		return 0, err
+	}
+	return store.MergeSharedTasks(ctx, g.GroupID, resp.Changes, resp.Rev, seq)
+}
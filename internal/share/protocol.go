@@ -0,0 +1,66 @@
+// Package share 实现多人共享分组的同步：同步服务端（cmd/spark-sync）的 HTTP 接口与桌面端使用的客户端。
+//
+// 服务端与桌面端复用 internal/todo 的存储：共享分组在两端都是普通分组，任务通过 uid 对应，
+// 双方交换 todo.SharedTask 记录，冲突按服务端修订号处理（先推送到服务端者获胜）。一次同步（SyncGroup）先推送本地变更，
+// 再拉取服务端修订号大于上次同步的记录。
+package share
+
+import (
+	"strings"
+
+	"spark-todo/internal/todo"
+)
+
+// apiPrefix 为同步接口的路径前缀。
+const apiPrefix = "/api/v1"
+
+// Credentials 为注册/登录请求。
+type Credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// LoginResponse 为注册/登录成功后返回的账号与登录令牌（之后放在 Authorization: Bearer 头中）。
+type LoginResponse struct {
+	Token   string          `json:"token"`
+	Account todo.HubAccount `json:"account"`
+}
+
+// CreateGroupRequest 为新建共享分组请求。
+type CreateGroupRequest struct {
+	Name string `json:"name"`
+}
+
+// AddMemberRequest 为邀请成员请求。
+type AddMemberRequest struct {
+	Username string `json:"username"`
+}
+
+// SyncRequest 推送本地变更，并拉取服务端修订号大于 Since 的记录。
+type SyncRequest struct {
+	Since   int64             `json:"since"`
+	Changes []todo.SharedTask `json:"changes"`
+}
+
+// SyncResponse 为服务端的变更与分组当前的修订号（下次同步作为 Since）。
+type SyncResponse struct {
+	Rev     int64             `json:"rev"`
+	Changes []todo.SharedTask `json:"changes"`
+}
+
+// ErrorResponse 为失败时的响应体：Code/Args 与 todo.Error 一致，由客户端按用户语言翻译。
+type ErrorResponse struct {
+	Code string `json:"code"`
+	Args []any  `json:"args,omitempty"`
+}
+
+// NormalizeServerURL 校验并规范化同步服务器地址，去掉末尾的 "/"。
+//
+// 登录密码与令牌都经此地址发送，因此必须是 https；http 只允许指向本机（见 todo.IsSecureWebURL）。
+func NormalizeServerURL(raw string) (string, error) {
+	raw = strings.TrimRight(strings.TrimSpace(raw), "/")
+	if !todo.IsSecureWebURL(raw) {
+		return "", todo.ErrInvalidShareServer.With(raw)
+	}
+	return raw, nil
+}
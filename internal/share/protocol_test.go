@@ -0,0 +1,28 @@
+package share
+
+import (
+	"errors"
+	"testing"
+
+	"spark-todo/internal/todo"
+)
+
+func TestNormalizeServerURL(t *testing.T) {
+	valid := map[string]string{
+		"https://example.com/":   "https://example.com",
+		" https://example.com ":  "https://example.com",
+		"http://127.0.0.1:8080/": "http://127.0.0.1:8080",
+		"http://localhost:8080":  "http://localhost:8080",
+		"http://[::1]:8080":      "http://[::1]:8080",
+	}
+	for raw, want := range valid {
+		if got, err := NormalizeServerURL(raw); err != nil || got != want {
+			t.Errorf("NormalizeServerURL(%q) = %q, %v; want %q", raw, got, err, want)
+		}
+	}
+	for _, raw := range []string{"http://example.com", "http://192.168.1.2:8080", "ftp://example.com", "https://", "example.com"} {
+		if _, err := NormalizeServerURL(raw); !errors.Is(err, todo.ErrInvalidShareServer) {
+			t.Errorf("NormalizeServerURL(%q) err = %v, want ErrInvalidShareServer", raw, err)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package share
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"math"
+	"net/http"
+	"strings"
+
+	"spark-todo/internal/todo"
+)
+
+// maxRequestBytes 为请求体上限（一次推送的全部变更）。
+const maxRequestBytes = 8 << 20
+
+// Server 为同步服务端的 HTTP 接口：
+//
+//	POST /api/v1/register                 注册（Credentials → LoginResponse）
+//	POST /api/v1/login                    登录（Credentials → LoginResponse）
+//	POST /api/v1/logout                   撤销当前登录令牌
+//	POST /api/v1/logout-all               撤销当前账号的全部登录令牌（所有设备退出登录）
+//	GET  /api/v1/groups                   我所在的共享分组（→ []todo.HostedGroup）
+//	POST /api/v1/groups                   新建共享分组（CreateGroupRequest → todo.HostedGroup）
+//	POST /api/v1/groups/{id}/members      邀请成员（AddMemberRequest → todo.HostedGroup）
+//	POST /api/v1/groups/{id}/sync         同步（SyncRequest → SyncResponse）
+//
+// 除注册/登录外都需要 Authorization: Bearer <token>。登录令牌连续 30 天未使用即失效；
+// 同一来源 IP 或用户名短时间内多次登录失败后暂时拒绝登录（见 loginLimiter）。
+type Server struct {
+	store   *todo.Store
+	mux     *http.ServeMux
+	limiter *loginLimiter
+	// Logger 记录内部错误；为 nil 时使用 log.Default()
+	Logger *log.Logger
+	// TrustProxy 为 true 时以 X-Forwarded-For 的最后一项作为来源 IP（部署在反向代理之后时开启）
+	TrustProxy bool
+}
+
+// NewServer 创建使用 store（由 todo.OpenHub 打开）的同步服务端。
+func NewServer(store *todo.Store) *Server {
+	s := &Server{store: store, mux: http.NewServeMux(), limiter: newLoginLimiter()}
+	s.mux.HandleFunc("POST "+apiPrefix+"/register", s.handleRegister)
+	s.mux.HandleFunc("POST "+apiPrefix+"/login", s.handleLogin)
+	s.mux.HandleFunc("POST "+apiPrefix+"/logout", s.authed(s.handleLogout))
+	s.mux.HandleFunc("POST "+apiPrefix+"/logout-all", s.authed(s.handleLogoutAll))
+	s.mux.HandleFunc("GET "+apiPrefix+"/groups", s.authed(s.handleListGroups))
+	s.mux.HandleFunc("POST "+apiPrefix+"/groups", s.authed(s.handleCreateGroup))
+	s.mux.HandleFunc("POST "+apiPrefix+"/groups/{id}/members", s.authed(s.handleAddMember))
+	s.mux.HandleFunc("POST "+apiPrefix+"/groups/{id}/sync", s.authed(s.handleSync))
+	return s
+}
+
+// ServeHTTP 实现 http.Handler。
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestBytes)
+	s.mux.ServeHTTP(w, r)
+}
+
+// authedHandler 为需要登录的接口，account 为令牌对应的账号。
+type authedHandler func(w http.ResponseWriter, r *http.Request, account todo.HubAccount)
+
+// bearerToken 返回 Authorization 头中的登录令牌；没有时返回空字符串。
+func bearerToken(r *http.Request) string {
+	token, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return token
+}
+
+// authed 校验 Authorization 头中的登录令牌。
+func (s *Server) authed(next authedHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := bearerToken(r)
+		if token == "" {
+			s.writeError(w, todo.ErrShareUnauthorized)
+			return
+		}
+		account, err := s.store.HubAccountByToken(r.Context(), token)
+		if err != nil {
+			s.writeError(w, err)
+			return
+		}
+		next(w, r, account)
+	}
+}
+
+func (s *Server) handleRegister(w http.ResponseWriter, r *http.Request) {
+	s.handleCredentials(w, r, s.store.RegisterHubAccount)
+}
+
+func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
+	s.handleCredentials(w, r, s.store.LoginHubAccount)
+}
+
+// handleCredentials 处理注册与登录（两者请求/响应格式相同）。
+//
+// 失败的尝试按来源 IP 与用户名分别计数，达到上限后在窗口结束前直接返回 ErrShareTooManyAttempts。
+func (s *Server) handleCredentials(w http.ResponseWriter, r *http.Request,
+	fn func(ctx context.Context, username, password string) (todo.HubAccount, string, error)) {
+	var req Credentials
+	if !s.readJSON(w, r, &req) {
+		return
+	}
+	ipKey := "ip:" + clientIP(r, s.TrustProxy)
+	userKey := "user:" + strings.ToLower(strings.TrimSpace(req.Username))
+	if wait := s.limiter.blocked(ipKey, userKey); wait > 0 {
+		s.writeError(w, todo.ErrShareTooManyAttempts.With(int(math.Ceil(wait.Minutes()))))
+		return
+	}
+	account, token, err := fn(r.Context(), req.Username, req.Password)
+	if err != nil {
+		var te *todo.Error
+		if errors.As(err, &te) {
+			s.limiter.fail(ipKey, userKey)
+		}
+		s.writeError(w, err)
+		return
+	}
+	s.limiter.reset(userKey)
+	writeJSON(w, http.StatusOK, LoginResponse{Token: token, Account: account})
+}
+
+func (s *Server) handleLogout(w http.ResponseWriter, r *http.Request, account todo.HubAccount) {
+	if err := s.store.RevokeHubToken(r.Context(), bearerToken(r)); err != nil {
+		s.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleLogoutAll(w http.ResponseWriter, r *http.Request, account todo.HubAccount) {
+	if _, err := s.store.RevokeHubTokens(r.Context(), account.ID); err != nil {
+		s.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleListGroups(w http.ResponseWriter, r *http.Request, account todo.HubAccount) {
+	groups, err := s.store.ListHostedGroups(r.Context(), account.ID)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, groups)
+}
+
+func (s *Server) handleCreateGroup(w http.ResponseWriter, r *http.Request, account todo.HubAccount) {
+	var req CreateGroupRequest
+	if !s.readJSON(w, r, &req) {
+		return
+	}
+	g, err := s.store.CreateHostedGroup(r.Context(), account.ID, req.Name)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, g)
+}
+
+func (s *Server) handleAddMember(w http.ResponseWriter, r *http.Request, account todo.HubAccount) {
+	var req AddMemberRequest
+	if !s.readJSON(w, r, &req) {
+		return
+	}
+	g, err := s.store.AddHostedMember(r.Context(), account.ID, r.PathValue("id"), req.Username)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, g)
+}
+
+func (s *Server) handleSync(w http.ResponseWriter, r *http.Request, account todo.HubAccount) {
+	var req SyncRequest
+	if !s.readJSON(w, r, &req) {
+		return
+	}
+	ctx := r.Context()
+	groupID, err := s.store.HostedGroupID(ctx, account.ID, r.PathValue("id"))
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	if len(req.Changes) > 0 {
+		if _, err := s.store.AcceptSharedTasks(ctx, groupID, req.Changes); err != nil {
+			s.writeError(w, err)
+			return
+		}
+	}
+	changes, rev, err := s.store.SharedTasksSince(ctx, groupID, req.Since)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, SyncResponse{Rev: rev, Changes: changes})
+}
+
+// readJSON 读取请求体；格式错误时直接写出 400 并返回 false。
+func (s *Server) readJSON(w http.ResponseWriter, r *http.Request, dst any) bool {
+	if err := json.NewDecoder(r.Body).Decode(dst); err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Code: todo.ErrShareServer.Code, Args: []any{"invalid request body"}})
+		return false
+	}
+	return true
+}
+
+// writeError 写出错误响应：业务错误原样返回错误码，其余错误只记日志，不把内部细节暴露给客户端。
+func (s *Server) writeError(w http.ResponseWriter, err error) {
+	var te *todo.Error
+	if !errors.As(err, &te) {
+		logger := s.Logger
+		if logger == nil {
+			logger = log.Default()
+		}
+		logger.Printf("internal error: %v", err)
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Code: todo.ErrShareServer.Code, Args: []any{"internal error"}})
+		return
+	}
+
+	status := http.StatusBadRequest
+	switch {
+	case errors.Is(te, todo.ErrShareUnauthorized), errors.Is(te, todo.ErrShareBadCredentials):
+		status = http.StatusUnauthorized
+	case errors.Is(te, todo.ErrShareNotMember), errors.Is(te, todo.ErrShareAccountNotFound):
+		status = http.StatusNotFound
+	case errors.Is(te, todo.ErrShareUsernameTaken):
+		status = http.StatusConflict
+	case errors.Is(te, todo.ErrShareTooManyAttempts):
+		status = http.StatusTooManyRequests
+	}
+	writeJSON(w, status, ErrorResponse{Code: te.Code, Args: te.Args})
+}
+
+// writeJSON 以 JSON 写出响应。
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
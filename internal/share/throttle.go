@@ -0,0 +1,96 @@
+package share
+
+import (
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 登录限流：同一来源 IP 或同一用户名在 loginWindow 内失败 maxLoginFailures 次后，窗口结束前拒绝其登录/注册。
+const (
+	maxLoginFailures = 10
+	loginWindow      = 15 * time.Minute
+	// maxLoginEntries 为记录的失败来源数上限，超过时先清理过期的记录
+	maxLoginEntries = 100000
+)
+
+// loginFailures 为一个来源在当前窗口内的失败次数。
+type loginFailures struct {
+	count int
+	start time.Time
+}
+
+// loginLimiter 记录登录/注册的失败次数（只在内存中，重启后清零）。
+type loginLimiter struct {
+	mu       sync.Mutex
+	failures map[string]*loginFailures
+	now      func() time.Time
+}
+
+func newLoginLimiter() *loginLimiter {
+	return &loginLimiter{failures: map[string]*loginFailures{}, now: time.Now}
+}
+
+// blocked 返回 keys 中任一来源须等待的时间；都未达到上限时返回 0。
+func (l *loginLimiter) blocked(keys ...string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	var wait time.Duration
+	for _, key := range keys {
+		f := l.failures[key]
+		if f == nil || f.count < maxLoginFailures {
+			continue
+		}
+		wait = max(wait, f.start.Add(loginWindow).Sub(now))
+	}
+	return wait
+}
+
+// fail 为 keys 各记一次失败。
+func (l *loginLimiter) fail(keys ...string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	if len(l.failures) >= maxLoginEntries {
+		for key, f := range l.failures {
+			if now.Sub(f.start) >= loginWindow {
+				delete(l.failures, key)
+			}
+		}
+	}
+	for _, key := range keys {
+		f := l.failures[key]
+		if f == nil || now.Sub(f.start) >= loginWindow {
+			f = &loginFailures{start: now}
+			l.failures[key] = f
+		}
+		f.count++
+	}
+}
+
+// reset 清除 key 的失败记录（登录成功后清除用户名的记录，来源 IP 的记录保留）。
+func (l *loginLimiter) reset(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.failures, key)
+}
+
+// clientIP 返回请求的来源 IP。trustProxy 为 true 时取 X-Forwarded-For 的最后一项（由前面的反向代理追加）。
+func clientIP(r *http.Request, trustProxy bool) string {
+	if trustProxy {
+		if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+			parts := strings.Split(fwd, ",")
+			if ip := strings.TrimSpace(parts[len(parts)-1]); ip != "" {
+				return ip
+			}
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
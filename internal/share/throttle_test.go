@@ -0,0 +1,37 @@
+package share
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoginLimiter(t *testing.T) {
+	now := time.Now()
+	l := newLoginLimiter()
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < maxLoginFailures-1; i++ {
+		l.fail("ip:1", "user:alice")
+	}
+	if wait := l.blocked("ip:1", "user:alice"); wait != 0 {
+		t.Fatalf("blocked after %d failures: %v", maxLoginFailures-1, wait)
+	}
+	l.fail("ip:1", "user:alice")
+	if wait := l.blocked("ip:2", "user:alice"); wait != loginWindow {
+		t.Errorf("same user from another IP: wait %v, want %v", wait, loginWindow)
+	}
+
+	// 用户名的记录在登录成功后清除，来源 IP 的记录保留
+	l.reset("user:alice")
+	if wait := l.blocked("ip:2", "user:alice"); wait != 0 {
+		t.Errorf("user still blocked after reset: %v", wait)
+	}
+	if wait := l.blocked("ip:1", "user:bob"); wait == 0 {
+		t.Error("IP no longer blocked after user reset")
+	}
+
+	now = now.Add(loginWindow)
+	if wait := l.blocked("ip:1", "user:bob"); wait != 0 {
+		t.Errorf("still blocked after the window: %v", wait)
+	}
+}
@@ -0,0 +1,96 @@
+// Package thumb 生成图片缩略图（仅依赖标准库）。
+package thumb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+
+	// 注册常见图片格式的解码器
+	_ "image/gif"
+	_ "image/png"
+)
+
+const (
+	// jpegQuality 为缩略图的 JPEG 质量。
+	jpegQuality = 80
+	// maxPixels 为可解码图片的最大像素数（宽×高）。解码后每像素约占 4-8 字节，
+	// 头部声明超大尺寸的图片（如"解压炸弹"）在解码前即被拒绝。
+	maxPixels = 50_000_000
+)
+
+// ErrTooLarge 表示图片尺寸超过 maxPixels。
+var ErrTooLarge = errors.New("image dimensions too large")
+
+// Make 解码 r 中的图片，等比缩小到长边不超过 maxSide 像素（小图不放大），
+// 透明区域铺白底后编码为 JPEG 返回。像素数超过 maxPixels 时返回 ErrTooLarge。
+func Make(r io.Reader, maxSide int) ([]byte, error) {
+	// 先只读头部取尺寸，读过的字节再与剩余部分拼接后完整解码
+	var head bytes.Buffer
+	cfg, _, err := image.DecodeConfig(io.TeeReader(r, &head))
+	if err != nil {
+		return nil, fmt.Errorf("decode image config: %w", err)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || int64(cfg.Width)*int64(cfg.Height) > maxPixels {
+		return nil, fmt.Errorf("%w: %dx%d", ErrTooLarge, cfg.Width, cfg.Height)
+	}
+	src, _, err := image.Decode(io.MultiReader(&head, r))
+	if err != nil {
+		return nil, fmt.Errorf("decode image: %w", err)
+	}
+	dst := scale(src, maxSide)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, fmt.Errorf("encode thumbnail: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// scale 用区域平均（box filter）把 src 缩小到长边不超过 maxSide，结果为不透明的 RGBA 图。
+func scale(src image.Image, maxSide int) *image.RGBA {
+	b := src.Bounds()
+	sw, sh := b.Dx(), b.Dy()
+	dw, dh := sw, sh
+	if maxSide > 0 && max(sw, sh) > maxSide {
+		if sw >= sh {
+			dw, dh = maxSide, max(1, sh*maxSide/sw)
+		} else {
+			dw, dh = max(1, sw*maxSide/sh), maxSide
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		y0 := b.Min.Y + y*sh/dh
+		y1 := max(y0+1, b.Min.Y+(y+1)*sh/dh)
+		for x := 0; x < dw; x++ {
+			x0 := b.Min.X + x*sw/dw
+			x1 := max(x0+1, b.Min.X+(x+1)*sw/dw)
+
+			var r, g, bl, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					c := color.NRGBA64Model.Convert(src.At(sx, sy)).(color.NRGBA64)
+					// 按 alpha 与白色混合
+					a := uint64(c.A)
+					r += (uint64(c.R)*a + 0xffff*(0xffff-a)) / 0xffff
+					g += (uint64(c.G)*a + 0xffff*(0xffff-a)) / 0xffff
+					bl += (uint64(c.B)*a + 0xffff*(0xffff-a)) / 0xffff
+					n++
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(bl / n >> 8),
+				A: 0xff,
+			})
+		}
+	}
+	return dst
+}
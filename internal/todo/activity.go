@@ -0,0 +1,146 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+)
+
+// 动态类型（activity_log.action）。
+const (
+	ActivityTaskCreated   = "task.created"
+	ActivityTaskRestored  = "task.restored" // 从回收站恢复
+	ActivityTaskStarted   = "task.started"  // 变为进行中
+	ActivityTaskCompleted = "task.completed"
+	ActivityTaskReopened  = "task.reopened" // 从已完成改回待办/进行中
+	ActivityTaskMoved     = "task.moved"    // 移到其他分组，Detail 为目标分组名
+	ActivityTaskRenamed   = "task.renamed"  // Detail 为原标题
+	ActivityTaskDeleted   = "task.deleted"
+	ActivityGroupCreated  = "group.created"
+	ActivityGroupRenamed  = "group.renamed" // Detail 为原名称
+	ActivityGroupArchived = "group.archived"
+	ActivityGroupRestored = "group.unarchived"
+	ActivityGroupDeleted  = "group.deleted"
+)
+
+// 动态分页大小。
+const (
+	defaultActivityPage = 50
+	maxActivityPage     = 200
+)
+
+// activityTriggers 维护 activity_log。记录时保存当时的标题/分组名，任务或分组删除后动态仍可读。
+//
+// 子任务只记录状态变化（完成、开始、重新打开），新建、删除、移动、改名只记录主任务，避免动态被清单项刷屏。
+// 恢复回收站条目会以原创建时间重新插入，创建时间早于一分钟前的插入记为"恢复"。
+var activityTriggers = []string{
+	`CREATE TRIGGER IF NOT EXISTS trg_tasks_activity_insert AFTER INSERT ON tasks WHEN NEW.parent_id = 0 BEGIN
+		INSERT INTO activity_log(action, task_id, group_id, title, detail, created_at)
+		VALUES(CASE WHEN NEW.created_at < ` + nowMillisSQL + ` - 60000 THEN 'task.restored' ELSE 'task.created' END,
+		       NEW.id, NEW.group_id, NEW.title, '', ` + nowMillisSQL + `);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS trg_tasks_activity_status AFTER UPDATE OF status ON tasks WHEN OLD.status <> NEW.status BEGIN
+		INSERT INTO activity_log(action, task_id, group_id, title, detail, created_at)
+		VALUES(CASE WHEN NEW.status = 'done' THEN 'task.completed' WHEN OLD.status = 'done' THEN 'task.reopened' ELSE 'task.started' END,
+		       NEW.id, NEW.group_id, NEW.title, '', ` + nowMillisSQL + `);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS trg_tasks_activity_move AFTER UPDATE OF group_id ON tasks WHEN NEW.parent_id = 0 AND OLD.group_id <> NEW.group_id BEGIN
+		INSERT INTO activity_log(action, task_id, group_id, title, detail, created_at)
+		VALUES('task.moved', NEW.id, NEW.group_id, NEW.title, COALESCE((SELECT name FROM groups WHERE id = NEW.group_id), ''), ` + nowMillisSQL + `);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS trg_tasks_activity_rename AFTER UPDATE OF title ON tasks WHEN NEW.parent_id = 0 AND OLD.title <> NEW.title BEGIN
+		INSERT INTO activity_log(action, task_id, group_id, title, detail, created_at)
+		VALUES('task.renamed', NEW.id, NEW.group_id, NEW.title, OLD.title, ` + nowMillisSQL + `);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS trg_tasks_activity_delete AFTER DELETE ON tasks WHEN OLD.parent_id = 0 BEGIN
+		INSERT INTO activity_log(action, task_id, group_id, title, detail, created_at)
+		VALUES('task.deleted', OLD.id, OLD.group_id, OLD.title, '', ` + nowMillisSQL + `);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS trg_groups_activity_insert AFTER INSERT ON groups BEGIN
+		INSERT INTO activity_log(action, task_id, group_id, title, detail, created_at)
+		VALUES('group.created', 0, NEW.id, NEW.name, '', ` + nowMillisSQL + `);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS trg_groups_activity_rename AFTER UPDATE OF name ON groups WHEN OLD.name <> NEW.name BEGIN
+		INSERT INTO activity_log(action, task_id, group_id, title, detail, created_at)
+		VALUES('group.renamed', 0, NEW.id, NEW.name, OLD.name, ` + nowMillisSQL + `);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS trg_groups_activity_archive AFTER UPDATE OF archived ON groups WHEN OLD.archived <> NEW.archived BEGIN
+		INSERT INTO activity_log(action, task_id, group_id, title, detail, created_at)
+		VALUES(CASE WHEN NEW.archived = 1 THEN 'group.archived' ELSE 'group.unarchived' END, 0, NEW.id, NEW.name, '', ` + nowMillisSQL + `);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS trg_groups_activity_delete AFTER DELETE ON groups BEGIN
+		INSERT INTO activity_log(action, task_id, group_id, title, detail, created_at)
+		VALUES('group.deleted', 0, OLD.id, OLD.name, '', ` + nowMillisSQL + `);
+	END`,
+}
+
+// Activity 为动态流中的一条记录。
+type Activity struct {
+	ID        int64  `json:"id"`
+	Action    string `json:"action"`    // Activity* 常量
+	TaskID    int64  `json:"taskId"`    // 分组动态为 0
+	GroupID   int64  `json:"groupId"`   // 任务当时所在（或移入）的分组
+	GroupName string `json:"groupName"` // 分组当前名称；分组已删除时为空
+	Title     string `json:"title"`     // 发生时的任务标题或分组名
+	Detail    string `json:"detail"`    // 附加信息，见各 Activity* 常量
+	Text      string `json:"text"`      // 按用户语言生成的描述（由 App 填充）
+	CreatedAt int64  `json:"createdAt"`
+}
+
+// ActivityPage 为一页动态（按时间倒序）。
+type ActivityPage struct {
+	Items []Activity `json:"items"`
+	// NextCursor 传给下一次 ListActivity 的 before 以继续向前翻页；没有更多时为 0
+	NextCursor int64 `json:"nextCursor"`
+}
+
+// ListActivity 返回 before（上一页的 NextCursor，0 表示从最新开始）之前的 limit 条动态，按时间倒序。
+// since > 0 时只返回该时间（UnixMilli）之后的动态，用于"今天做了什么"面板。
+func (s *Store) ListActivity(ctx context.Context, before int64, since int64, limit int) (ActivityPage, error) {
+	if limit <= 0 {
+		limit = defaultActivityPage
+	}
+	limit = min(limit, maxActivityPage)
+
+	where := `a.created_at >= ?`
+	args := []any{since}
+	if before > 0 {
+		where += ` AND a.id < ?`
+		args = append(args, before)
+	}
+	args = append(args, limit+1)
+
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT a.id, a.action, a.task_id, a.group_id, COALESCE(g.name, ''), a.title, a.detail, a.created_at
+		 FROM activity_log a LEFT JOIN groups g ON g.id = a.group_id
+		 WHERE `+where+` ORDER BY a.id DESC LIMIT ?`, args...)
+	if err != nil {
+		return ActivityPage{}, fmt.Errorf("list activity: %w", err)
+	}
+	defer rows.Close()
+
+	page := ActivityPage{Items: []Activity{}}
+	for rows.Next() {
+		var a Activity
+		if err := rows.Scan(&a.ID, &a.Action, &a.TaskID, &a.GroupID, &a.GroupName, &a.Title, &a.Detail, &a.CreatedAt); err != nil {
+			return ActivityPage{}, fmt.Errorf("scan activity: %w", err)
+		}
+		page.Items = append(page.Items, a)
+	}
+	if err := rows.Err(); err != nil {
+		return ActivityPage{}, fmt.Errorf("iterate activity: %w", err)
+	}
+	if len(page.Items) > limit {
+		page.Items = page.Items[:limit]
+		page.NextCursor = page.Items[limit-1].ID
+	}
+	return page, nil
+}
+
+// PruneActivity 删除 before（UnixMilli）之前的动态，返回删除条数。
+func (s *Store) PruneActivity(ctx context.Context, before int64) (int64, error) {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM activity_log WHERE created_at < ?`, before)
+	if err != nil {
+		return 0, fmt.Errorf("prune activity: %w", err)
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,102 @@
+package todo
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// AgendaItem 为日程视图中的一项：任务及其在当天的截止时刻。
+type AgendaItem struct {
+	Task Task   `json:"task"`
+	Time string `json:"time"` // 截止时刻（HH:MM，按时区设置）
+}
+
+// AgendaDay 为日程视图中的一天。
+type AgendaDay struct {
+	Date    string       `json:"date"`    // 本地日期（YYYY-MM-DD）
+	Weekday int          `json:"weekday"` // 0 = 周日 ... 6 = 周六
+	Label   string       `json:"label"`   // 按日期格式设置格式化后的日期
+	Items   []AgendaItem `json:"items"`   // 按截止时间升序
+}
+
+// WeekAgenda 为周视图数据（七天按每周第一天设置排列）。
+type WeekAgenda struct {
+	Start string      `json:"start"` // 本周第一天（YYYY-MM-DD）
+	End   string      `json:"end"`   // 本周最后一天（YYYY-MM-DD）
+	Days  []AgendaDay `json:"days"`
+}
+
+// GetWeekAgenda 返回 date 所在周的日程，按周一或周日开始（weekStart 设置）。
+//
+// date 为 YYYY-MM-DD；为空时使用 now 所在的日期。
+func (s *Store) GetWeekAgenda(ctx context.Context, date string, now time.Time, settings Settings, hideDone bool) (WeekAgenda, error) {
+	loc := Location(settings)
+	day := StartOfDay(now, loc)
+	if date = strings.TrimSpace(date); date != "" {
+		parsed, err := time.ParseInLocation(dayKeyLayout, date, loc)
+		if err != nil {
+			return WeekAgenda{}, ErrInvalidDate.with(date)
+		}
+		day = parsed
+	}
+
+	start := StartOfWeek(day, settings)
+	days, err := s.agendaDays(ctx, start, 7, settings, hideDone)
+	if err != nil {
+		return WeekAgenda{}, err
+	}
+	return WeekAgenda{
+		Start: days[0].Date,
+		End:   days[len(days)-1].Date,
+		Days:  days,
+	}, nil
+}
+
+// GetUpcoming 返回从 now 所在自然日起连续 days 天（含今天）内到期的任务，按天分组。
+//
+// 每一天都会返回（没有任务时 Items 为空数组），前端可以直接渲染成固定长度的日程条。
+// hideDone 为 true 时不返回已完成任务；已归档分组中的任务不返回。
+func (s *Store) GetUpcoming(ctx context.Context, now time.Time, settings Settings, days int, hideDone bool) ([]AgendaDay, error) {
+	loc := Location(settings)
+	return s.agendaDays(ctx, StartOfDay(now, loc), days, settings, hideDone)
+}
+
+// agendaDays 查询 [start, start+days) 内到期的任务，并按本地自然日分组。
+func (s *Store) agendaDays(ctx context.Context, start time.Time, days int, settings Settings, hideDone bool) ([]AgendaDay, error) {
+	if days <= 0 {
+		return []AgendaDay{}, nil
+	}
+	end := start.AddDate(0, 0, days)
+
+	query := `SELECT ` + taskColumns + ` FROM tasks WHERE due_at >= ? AND due_at < ? AND ` + activeGroupSQL
+	if hideDone {
+		query += ` AND status <> 'done'`
+	}
+	query += ` ORDER BY due_at, id`
+	tasks, err := s.queryTasks(ctx, query, start.UnixMilli(), end.UnixMilli())
+	if err != nil {
+		return nil, err
+	}
+
+	loc := start.Location()
+	out := make([]AgendaDay, days)
+	index := make(map[string]int, days)
+	for i := range out {
+		d := start.AddDate(0, 0, i)
+		out[i] = AgendaDay{
+			Date:    d.Format(dayKeyLayout),
+			Weekday: int(d.Weekday()),
+			Label:   FormatDate(d, settings),
+			Items:   []AgendaItem{},
+		}
+		index[out[i].Date] = i
+	}
+	for _, t := range tasks {
+		due := time.UnixMilli(t.DueAt).In(loc)
+		if i, ok := index[due.Format(dayKeyLayout)]; ok {
+			out[i].Items = append(out[i].Items, AgendaItem{Task: t, Time: due.Format("15:04")})
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,115 @@
+package todo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// statusHistoryTriggers 维护 task_status_history：新建任务记录初始状态，状态变化时记录一次转换。
+var statusHistoryTriggers = []string{
+	`CREATE TRIGGER IF NOT EXISTS trg_tasks_status_insert AFTER INSERT ON tasks BEGIN
+		INSERT INTO task_status_history(task_id, from_status, to_status, changed_at) VALUES(NEW.id, '', NEW.status, ` + nowMillisSQL + `);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS trg_tasks_status_update AFTER UPDATE OF status ON tasks WHEN OLD.status <> NEW.status BEGIN
+		INSERT INTO task_status_history(task_id, from_status, to_status, changed_at) VALUES(NEW.id, OLD.status, NEW.status, ` + nowMillisSQL + `);
+	END`,
+}
+
+// backfillStatusHistorySQL 为尚无状态记录的已有任务补一条初始记录：
+// 已完成的取完成时间，进行中的取最后修改时间，待办取创建时间（升级前没有记录，只能近似）。
+const backfillStatusHistorySQL = `INSERT INTO task_status_history(task_id, from_status, to_status, changed_at)
+	SELECT id, '', status,
+	       CASE status WHEN 'done' THEN (CASE WHEN completed_at > 0 THEN completed_at ELSE updated_at END)
+	                   WHEN 'doing' THEN updated_at
+	                   ELSE created_at END
+	FROM tasks WHERE NOT EXISTS (SELECT 1 FROM task_status_history h WHERE h.task_id = tasks.id)`
+
+// StatusChange 为一次状态转换。
+type StatusChange struct {
+	From      Status `json:"from"` // 新建任务的首条记录为空
+	To        Status `json:"to"`
+	ChangedAt int64  `json:"changedAt"`
+}
+
+// StatusHistory 返回任务的全部状态转换（按时间先后）。
+func (s *Store) StatusHistory(ctx context.Context, taskID int64) ([]StatusChange, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT from_status, to_status, changed_at FROM task_status_history WHERE task_id = ? ORDER BY changed_at, id`, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("list status history: %w", err)
+	}
+	defer rows.Close()
+
+	out := []StatusChange{}
+	for rows.Next() {
+		var c StatusChange
+		var from, to string
+		if err := rows.Scan(&from, &to, &c.ChangedAt); err != nil {
+			return nil, fmt.Errorf("scan status change: %w", err)
+		}
+		c.From, c.To = Status(from), Status(to)
+		out = append(out, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate status history: %w", err)
+	}
+	return out, nil
+}
+
+// FillAging 为 tasks（含子任务）填充 StatusSince 与 DaysInStatus（当前状态已持续的自然日天数，按 loc 计算），
+// 供前端按停留时长让卡片"变旧"。所有任务只用一次查询取最近的状态变化时间。
+func (s *Store) FillAging(ctx context.Context, tasks []Task, now time.Time, loc *time.Location) error {
+	var ids []int64
+	var collect func([]Task)
+	collect = func(ts []Task) {
+		for _, t := range ts {
+			ids = append(ids, t.ID)
+			collect(t.SubTasks)
+		}
+	}
+	collect(tasks)
+	if len(ids) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(ids)
+	if err != nil {
+		return err
+	}
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT task_id, MAX(changed_at) FROM task_status_history
+		 WHERE task_id IN (SELECT value FROM json_each(?)) GROUP BY task_id`, string(data))
+	if err != nil {
+		return fmt.Errorf("query status since: %w", err)
+	}
+	defer rows.Close()
+
+	since := make(map[int64]int64, len(ids))
+	for rows.Next() {
+		var id, at int64
+		if err := rows.Scan(&id, &at); err != nil {
+			return fmt.Errorf("scan status since: %w", err)
+		}
+		since[id] = at
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate status since: %w", err)
+	}
+
+	today := StartOfDay(now, loc)
+	var fill func([]Task)
+	fill = func(ts []Task) {
+		for i := range ts {
+			t := &ts[i]
+			if at, ok := since[t.ID]; ok {
+				t.StatusSince = at
+				t.DaysInStatus = daysBetween(StartOfDay(time.UnixMilli(at), loc), today)
+			}
+			fill(t.SubTasks)
+		}
+	}
+	fill(tasks)
+	return nil
+}
@@ -0,0 +1,162 @@
+package todo
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// API 令牌的权限范围。
+const (
+	APIScopeRead      = "read"      // 只能读取
+	APIScopeReadWrite = "readwrite" // 可以读取与修改
+)
+
+const (
+	// apiTokenPrefix 为令牌的固定前缀，便于在脚本与日志中识别（也便于密钥扫描工具发现泄露的令牌）
+	apiTokenPrefix = "spk_"
+	// apiTokenHintRunes 为列表中显示的令牌开头长度（不含前缀），用于区分同名令牌
+	apiTokenHintRunes = 6
+	// maxAPITokenNameRunes 为令牌名称的最大长度
+	maxAPITokenNameRunes = 50
+	// minAPIPort / maxAPIPort 为本地接口端口的取值范围（不使用需要管理员权限的低端口）
+	minAPIPort = 1024
+	maxAPIPort = 65535
+)
+
+// APIToken 为本地 REST 接口的访问令牌（不含令牌本身：库中只保存摘要，创建后无法再次查看）。
+type APIToken struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Scope      string `json:"scope"` // APIScope*
+	Hint       string `json:"hint"`  // 令牌开头部分（如 "spk_3f9a1c"），用于辨认
+	CreatedAt  int64  `json:"createdAt"`
+	LastUsedAt int64  `json:"lastUsedAt"` // 最近一次通过校验的时间；从未使用为 0
+}
+
+// normalizeAPIPort 校验本地接口端口：0 表示关闭，否则须在 1024-65535 之间。
+func normalizeAPIPort(v string) (string, error) {
+	n, err := strconv.Atoi(strings.TrimSpace(v))
+	if err != nil || (n != 0 && (n < minAPIPort || n > maxAPIPort)) {
+		return "", ErrInvalidAPIPort.with(minAPIPort, maxAPIPort)
+	}
+	return strconv.Itoa(n), nil
+}
+
+// hashAPIToken 返回令牌的摘要（api_tokens.token_hash）。令牌本身是 32 字节随机数，无需慢哈希。
+func hashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// CreateAPIToken 创建令牌，返回令牌信息与令牌本身；令牌只在此时返回一次，需提示用户妥善保存。
+func (s *Store) CreateAPIToken(ctx context.Context, name, scope string, now time.Time) (APIToken, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return APIToken{}, "", ErrAPITokenNameEmpty
+	}
+	if utf8.RuneCountInString(name) > maxAPITokenNameRunes {
+		return APIToken{}, "", ErrAPITokenNameTooLong.with(maxAPITokenNameRunes)
+	}
+	if scope != APIScopeRead && scope != APIScopeReadWrite {
+		return APIToken{}, "", ErrInvalidAPIScope.with(scope)
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return APIToken{}, "", fmt.Errorf("generate token: %w", err)
+	}
+	secret := apiTokenPrefix + hex.EncodeToString(raw)
+	t := APIToken{
+		Name:      name,
+		Scope:     scope,
+		Hint:      secret[:len(apiTokenPrefix)+apiTokenHintRunes],
+		CreatedAt: now.UnixMilli(),
+	}
+	res, err := s.db.ExecContext(ctx,
+		`INSERT INTO api_tokens(name, scope, hint, token_hash, created_at) VALUES(?, ?, ?, ?, ?)`,
+		t.Name, t.Scope, t.Hint, hashAPIToken(secret), t.CreatedAt,
+	)
+	if err != nil {
+		return APIToken{}, "", fmt.Errorf("create api token: %w", err)
+	}
+	if t.ID, err = res.LastInsertId(); err != nil {
+		return APIToken{}, "", fmt.Errorf("create api token: %w", err)
+	}
+	return t, secret, nil
+}
+
+// ListAPITokens 返回全部令牌，最新创建的在前。
+func (s *Store) ListAPITokens(ctx context.Context) ([]APIToken, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, name, scope, hint, created_at, last_used_at FROM api_tokens ORDER BY created_at DESC, id DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("list api tokens: %w", err)
+	}
+	defer rows.Close()
+
+	out := []APIToken{}
+	for rows.Next() {
+		var t APIToken
+		if err := rows.Scan(&t.ID, &t.Name, &t.Scope, &t.Hint, &t.CreatedAt, &t.LastUsedAt); err != nil {
+			return nil, fmt.Errorf("scan api token: %w", err)
+		}
+		out = append(out, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate api tokens: %w", err)
+	}
+	return out, nil
+}
+
+// RevokeAPIToken 吊销（删除）令牌，之后使用该令牌的请求立即被拒绝。
+func (s *Store) RevokeAPIToken(ctx context.Context, id int64) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM api_tokens WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("revoke api token: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrAPITokenNotFound.with(id)
+	}
+	return nil
+}
+
+// AuthenticateAPIToken 校验请求携带的令牌：write 为 true 表示请求会修改数据，需要 APIScopeReadWrite。
+//
+// 令牌不存在（或已吊销）返回 ErrAPITokenInvalid，权限不足返回 ErrAPITokenForbidden；
+// 通过校验时记录最近使用时间（只读模式下不记录）。
+func (s *Store) AuthenticateAPIToken(ctx context.Context, token string, write bool, now time.Time) (APIToken, error) {
+	if !strings.HasPrefix(token, apiTokenPrefix) {
+		return APIToken{}, ErrAPITokenInvalid
+	}
+	var t APIToken
+	err := s.db.QueryRowContext(ctx,
+		`SELECT id, name, scope, hint, created_at, last_used_at FROM api_tokens WHERE token_hash = ?`,
+		hashAPIToken(token),
+	).Scan(&t.ID, &t.Name, &t.Scope, &t.Hint, &t.CreatedAt, &t.LastUsedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return APIToken{}, ErrAPITokenInvalid
+	}
+	if err != nil {
+		return APIToken{}, fmt.Errorf("get api token: %w", err)
+	}
+	if write && t.Scope != APIScopeReadWrite {
+		return APIToken{}, ErrAPITokenForbidden
+	}
+
+	t.LastUsedAt = now.UnixMilli()
+	if !s.ReadOnly() {
+		if _, err := s.db.ExecContext(ctx, `UPDATE api_tokens SET last_used_at = ? WHERE id = ?`, t.LastUsedAt, t.ID); err != nil {
+			return APIToken{}, fmt.Errorf("touch api token: %w", err)
+		}
+	}
+	return t, nil
+}
@@ -0,0 +1,69 @@
+package todo
+
+import "context"
+
+// activeGroupSQL 为"任务所在分组未归档"的 SQL 条件（用于默认视图）。
+const activeGroupSQL = `group_id NOT IN (SELECT id FROM groups WHERE archived = 1)`
+
+// Archive 为已归档分组及其任务（子任务挂在 SubTasks 下），供归档页浏览、搜索与恢复。
+type Archive struct {
+	Groups []Group `json:"groups"`
+	Tasks  []Task  `json:"tasks"`
+}
+
+// SetGroupArchived 归档或取消归档分组，返回更新后的分组。
+//
+// 归档只是隐藏：分组与任务原样保留，取消归档即可恢复到看板中。
+func (s *Store) SetGroupArchived(ctx context.Context, id int64, archived bool) (Group, error) {
+	return s.setGroupFlag(ctx, id, "archived", archived)
+}
+
+// ListArchive 返回全部已归档分组及其任务，任务按 sort 排序（见 Sort* 常量）。
+func (s *Store) ListArchive(ctx context.Context, sort string) (Archive, error) {
+	sort, err := ParseSortMode(sort)
+	if err != nil {
+		return Archive{}, err
+	}
+	groups, err := s.ListGroups(ctx)
+	if err != nil {
+		return Archive{}, err
+	}
+	out := Archive{Groups: []Group{}}
+	for _, g := range groups {
+		if g.Archived {
+			out.Groups = append(out.Groups, g)
+		}
+	}
+
+	out.Tasks, err = s.queryTasks(ctx,
+		`SELECT `+taskColumns+` FROM tasks
+		 WHERE parent_id = 0 AND group_id IN (SELECT id FROM groups WHERE archived = 1)
+		 ORDER BY `+sortOrderSQL[sort])
+	if err != nil {
+		return Archive{}, err
+	}
+	if err := s.attachSubTasks(ctx, out.Tasks); err != nil {
+		return Archive{}, err
+	}
+	return out, nil
+}
+
+// ExcludeArchived 将分组拆分为未归档/已归档两部分，并从 tasks 中去掉已归档分组下的任务。
+func ExcludeArchived(groups []Group, tasks []Task) (active []Group, archived []Group, visible []Task) {
+	active, archived, visible = []Group{}, []Group{}, []Task{}
+	hidden := map[int64]bool{}
+	for _, g := range groups {
+		if g.Archived {
+			archived = append(archived, g)
+			hidden[g.ID] = true
+		} else {
+			active = append(active, g)
+		}
+	}
+	for _, t := range tasks {
+		if !hidden[t.GroupID] {
+			visible = append(visible, t)
+		}
+	}
+	return active, archived, visible
+}
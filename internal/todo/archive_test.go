@@ -0,0 +1,61 @@
+package todo
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDateViewsSkipArchivedGroups(t *testing.T) {
+	ctx := context.Background()
+	s := openTestStore(t)
+	now := time.Now()
+	loc := time.Local
+
+	active := firstGroupID(t, s)
+	archived, err := s.UpsertGroup(ctx, Group{Name: "archived"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, groupID := range []int64{active, archived.ID} {
+		createTask(t, s, Task{GroupID: groupID, Title: "overdue", DueAt: now.AddDate(0, 0, -2).UnixMilli()})
+		createTask(t, s, Task{GroupID: groupID, Title: "today", DueAt: now.UnixMilli()})
+	}
+	pinned := createTask(t, s, Task{GroupID: archived.ID, Title: "pinned"})
+	if err := s.AddToMyDay(ctx, pinned.ID, DayKey(now, loc)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.SetGroupArchived(ctx, archived.ID, true); err != nil {
+		t.Fatal(err)
+	}
+
+	today, err := s.GetToday(ctx, now, loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(today.Overdue) != 1 || len(today.DueToday) != 1 || len(today.MyDay) != 0 {
+		t.Errorf("today = %d overdue, %d due today, %d my day; want 1, 1, 0", len(today.Overdue), len(today.DueToday), len(today.MyDay))
+	}
+	overdue, err := s.GetOverdue(ctx, now, loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if overdue.Count != 1 {
+		t.Errorf("overdue count = %d, want 1", overdue.Count)
+	}
+	if tasks, err := s.ListOverdueTasks(ctx, now); err != nil || len(tasks) != 1 {
+		t.Errorf("overdue tasks = %d, %v; want 1", len(tasks), err)
+	}
+
+	settings, err := s.GetSettings(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	days, err := s.GetUpcoming(ctx, now, settings, 3, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if items := days[0].Items; len(items) != 1 || items[0].Task.GroupID != active {
+		t.Errorf("upcoming today = %+v, want only the active group's task", items)
+	}
+}
@@ -0,0 +1,144 @@
+package todo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Attachment 为任务附件的元数据；文件本身保存在数据目录的 attachments 文件夹中。
+type Attachment struct {
+	ID        int64  `json:"id"`
+	TaskID    int64  `json:"taskId"`
+	File      string `json:"file"` // attachments 目录下的文件名
+	Mime      string `json:"mime"`
+	Size      int64  `json:"size"`   // 字节数
+	Width     int    `json:"width"`  // 图片宽度（像素）；非图片为 0
+	Height    int    `json:"height"` // 图片高度（像素）；非图片为 0
+	CreatedAt int64  `json:"createdAt"`
+}
+
+// attachmentColumns 为读取 Attachment 时 SELECT 的列（顺序与 scanAttachment 一致）。
+const attachmentColumns = `id, task_id, file, mime, size, width, height, created_at`
+
+// scanAttachment 按 attachmentColumns 的列顺序读取一行附件。
+func scanAttachment(r rowScanner) (Attachment, error) {
+	var a Attachment
+	if err := r.Scan(&a.ID, &a.TaskID, &a.File, &a.Mime, &a.Size, &a.Width, &a.Height, &a.CreatedAt); err != nil {
+		return Attachment{}, fmt.Errorf("scan attachment: %w", err)
+	}
+	return a, nil
+}
+
+// AddAttachment 为任务登记一个已保存好的附件文件，返回落库后的附件。
+func (s *Store) AddAttachment(ctx context.Context, a Attachment) (Attachment, error) {
+	if _, err := s.GetTask(ctx, a.TaskID); err != nil {
+		return Attachment{}, err
+	}
+	a.CreatedAt = time.Now().UnixMilli()
+	res, err := s.db.ExecContext(ctx,
+		`INSERT INTO attachments(task_id, file, mime, size, width, height, created_at) VALUES(?, ?, ?, ?, ?, ?, ?)`,
+		a.TaskID, a.File, a.Mime, a.Size, a.Width, a.Height, a.CreatedAt,
+	)
+	if err != nil {
+		return Attachment{}, fmt.Errorf("add attachment: %w", err)
+	}
+	if a.ID, err = res.LastInsertId(); err != nil {
+		return Attachment{}, fmt.Errorf("get new attachment id: %w", err)
+	}
+	return a, nil
+}
+
+// GetAttachment 返回单个附件。
+func (s *Store) GetAttachment(ctx context.Context, id int64) (Attachment, error) {
+	a, err := scanAttachment(s.db.QueryRowContext(ctx, `SELECT `+attachmentColumns+` FROM attachments WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return Attachment{}, ErrAttachmentNotFound.with(id)
+	}
+	if err != nil {
+		return Attachment{}, err
+	}
+	return a, nil
+}
+
+// DeleteAttachment 删除附件记录（是任务封面时一并取消封面），返回被删除的附件，由调用方删除文件。
+func (s *Store) DeleteAttachment(ctx context.Context, id int64) (Attachment, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return Attachment{}, fmt.Errorf("begin delete attachment: %w", err)
+	}
+	defer tx.Rollback()
+
+	a, err := scanAttachment(tx.QueryRowContext(ctx, `SELECT `+attachmentColumns+` FROM attachments WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return Attachment{}, ErrAttachmentNotFound.with(id)
+	}
+	if err != nil {
+		return Attachment{}, err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM attachments WHERE id = ?`, id); err != nil {
+		return Attachment{}, fmt.Errorf("delete attachment: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE tasks SET cover_id = 0, updated_at = ? WHERE id = ? AND cover_id = ?`, time.Now().UnixMilli(), a.TaskID, id,
+	); err != nil {
+		return Attachment{}, fmt.Errorf("clear task cover: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return Attachment{}, fmt.Errorf("commit delete attachment: %w", err)
+	}
+	return a, nil
+}
+
+// SetTaskCover 将任务的某个附件设为封面（attachmentID 为 0 时取消封面），返回更新后的任务。
+func (s *Store) SetTaskCover(ctx context.Context, taskID, attachmentID int64) (Task, error) {
+	if attachmentID != 0 {
+		a, err := s.GetAttachment(ctx, attachmentID)
+		if err != nil {
+			return Task{}, err
+		}
+		if a.TaskID != taskID {
+			return Task{}, ErrAttachmentNotFound.with(attachmentID)
+		}
+	}
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE tasks SET cover_id = ?, updated_at = ? WHERE id = ?`,
+		attachmentID, time.Now().UnixMilli(), taskID,
+	)
+	if err != nil {
+		return Task{}, fmt.Errorf("set task cover: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return Task{}, fmt.Errorf("set task cover rows affected: %w", err)
+	}
+	if affected == 0 {
+		return Task{}, ErrTaskNotFound.with(taskID)
+	}
+	return s.GetTask(ctx, taskID)
+}
+
+// ListAttachments 返回任务的全部附件（按添加顺序）。
+func (s *Store) ListAttachments(ctx context.Context, taskID int64) ([]Attachment, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+attachmentColumns+` FROM attachments WHERE task_id = ? ORDER BY id`, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("list attachments: %w", err)
+	}
+	defer rows.Close()
+
+	out := []Attachment{}
+	for rows.Next() {
+		a, err := scanAttachment(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate attachments: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,31 @@
+package todo
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestDeleteAttachmentClearsCover(t *testing.T) {
+	ctx := context.Background()
+	s := openTestStore(t)
+	task := createTask(t, s, Task{GroupID: firstGroupID(t, s), Title: "task"})
+	att, err := s.AddAttachment(ctx, Attachment{TaskID: task.ID, File: "cover.png", Mime: "image/png"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.SetTaskCover(ctx, task.ID, att.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	deleted, err := s.DeleteAttachment(ctx, att.ID)
+	if err != nil || deleted.File != "cover.png" {
+		t.Fatalf("delete attachment = %+v, %v", deleted, err)
+	}
+	if got, err := s.GetTask(ctx, task.ID); err != nil || got.CoverID != 0 {
+		t.Errorf("cover = %d, %v; want 0", got.CoverID, err)
+	}
+	if _, err := s.DeleteAttachment(ctx, att.ID); !errors.Is(err, ErrAttachmentNotFound) {
+		t.Errorf("delete again: err = %v", err)
+	}
+}
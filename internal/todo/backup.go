@@ -0,0 +1,222 @@
+package todo
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	sqlite "modernc.org/sqlite"
+)
+
+// sqliteHeader 为 SQLite 数据库文件的文件头，用于在恢复前识别备份文件。
+const sqliteHeader = "SQLite format 3\x00"
+
+// maxBackupBytes 限制导入的备份文件大小。
+const maxBackupBytes = 1 << 30
+
+// Backup 将当前数据库（含 settings 表）完整快照到 destPath。
+//
+// 使用 `VACUUM INTO`：
+// - 在单个读事务内生成一致的快照，不受 WAL 中未 checkpoint 数据的影响
+// - 输出文件是紧凑的独立数据库，可直接替换 todo.db 恢复
+//
+// destPath 必须不存在（SQLite 会拒绝覆盖已有文件）。
+func (s *Store) Backup(ctx context.Context, destPath string) error {
+	if strings.TrimSpace(destPath) == "" {
+		return errors.New("backup path is empty")
+	}
+	if _, err := os.Stat(destPath); err == nil {
+		return fmt.Errorf("backup file already exists: %s", destPath)
+	}
+	if err := os.MkdirAll(filepath.Dir(destPath), 0o755); err != nil {
+		return fmt.Errorf("create backup dir: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `VACUUM INTO ?`, destPath); err != nil {
+		return fmt.Errorf("backup database: %w", err)
+	}
+	return nil
+}
+
+// BackupFileName 生成备份文件名：todo-<reason>-<yyyyMMdd-HHmmss>.db。
+//
+// reason 用于区分备份来源（例如 "pre-update-1.2.0"），便于用户在备份目录中辨认。
+// reason 可能来自外部（如更新源返回的版本号），字母、数字、"."、"-"、"_" 以外的字符都替换为 "_"，
+// 保证结果是备份目录下的单个文件名。
+func BackupFileName(reason string, at time.Time) string {
+	reason = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.' || r == '-' || r == '_' {
+			return r
+		}
+		return '_'
+	}, strings.TrimSpace(reason))
+	if reason == "" {
+		return fmt.Sprintf("todo-%s.db", at.Format("20060102-150405"))
+	}
+	return fmt.Sprintf("todo-%s-%s.db", reason, at.Format("20060102-150405"))
+}
+
+// ExportBackup 生成数据库的完整快照（同 Backup）并返回其内容；passphrase 非空时加密（见 EncryptBackup），
+// 便于把备份放在云盘等位置而不暴露任务内容。附件文件不在数据库中，不包含在备份内。
+func (s *Store) ExportBackup(ctx context.Context, passphrase string) ([]byte, error) {
+	if passphrase != "" {
+		// 先校验口令，避免生成快照后才报错
+		if err := ValidateBackupPassphrase(passphrase); err != nil {
+			return nil, err
+		}
+	}
+	dir, err := os.MkdirTemp("", "spark-todo-backup-")
+	if err != nil {
+		return nil, fmt.Errorf("create backup temp dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "todo.db")
+	if err := s.Backup(ctx, path); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read backup: %w", err)
+	}
+	if passphrase == "" {
+		return data, nil
+	}
+	return EncryptBackup(data, passphrase)
+}
+
+// RestoreBackupFile 用备份文件 path（ExportBackup 或 Backup 生成，可为加密备份）替换当前数据库的全部内容。
+//
+// 加密备份未提供口令时返回带 path 数据的 ErrBackupPassphraseRequired，前端据此询问口令后重试。
+// 恢复通过 SQLite 在线备份接口写入当前连接，完成后补齐旧版本备份缺少的表与列。
+func (s *Store) RestoreBackupFile(ctx context.Context, path string, passphrase string) error {
+	if s.ReadOnly() {
+		return ErrReadOnly
+	}
+	st, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("read backup: %w", err)
+	}
+	if st.Size() > maxBackupBytes {
+		return ErrInvalidBackup
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read backup: %w", err)
+	}
+	if IsEncryptedBackup(data) {
+		if passphrase == "" {
+			return ErrBackupPassphraseRequired.withData(map[string]any{"path": path})
+		}
+		if data, err = DecryptBackup(data, passphrase); err != nil {
+			return err
+		}
+	}
+	if !bytes.HasPrefix(data, []byte(sqliteHeader)) {
+		return ErrInvalidBackup
+	}
+
+	dir, err := os.MkdirTemp("", "spark-todo-restore-")
+	if err != nil {
+		return fmt.Errorf("create restore temp dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+	src := filepath.Join(dir, "todo.db")
+	if err := os.WriteFile(src, data, 0o600); err != nil {
+		return fmt.Errorf("write restore temp file: %w", err)
+	}
+	if err := checkBackupDB(ctx, src); err != nil {
+		return err
+	}
+	if err := s.restoreFrom(ctx, src); err != nil {
+		return err
+	}
+
+	if err := s.applyPragmas(ctx); err != nil {
+		return err
+	}
+	if err := s.migrate(ctx); err != nil {
+		return err
+	}
+	if err := s.ensureDefaultSettings(ctx); err != nil {
+		return err
+	}
+	return s.ensureDefaultGroup(ctx)
+}
+
+// RestoreSnapshot 用 Backup 生成的未加密快照 path 替换当前数据库的全部内容，但不执行迁移。
+//
+// 用于回滚更新：快照保持旧版本的表结构，交由随后启动的旧版本打开；
+// 调用方恢复后应尽快退出，不再通过本 Store 读写。
+func (s *Store) RestoreSnapshot(ctx context.Context, path string) error {
+	if s.ReadOnly() {
+		return ErrReadOnly
+	}
+	if err := checkBackupDB(ctx, path); err != nil {
+		return err
+	}
+	if err := s.restoreFrom(ctx, path); err != nil {
+		return err
+	}
+	return s.applyPragmas(ctx)
+}
+
+// checkBackupDB 确认 path 是完好的 Spark-Todo 数据库（通过完整性检查且含 tasks 表）。
+func checkBackupDB(ctx context.Context, path string) error {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return fmt.Errorf("open backup: %w", err)
+	}
+	defer db.Close()
+
+	var result string
+	if err := db.QueryRowContext(ctx, `PRAGMA quick_check`).Scan(&result); err != nil || result != "ok" {
+		return ErrInvalidBackup
+	}
+	var n int
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'tasks'`).Scan(&n); err != nil || n == 0 {
+		return ErrInvalidBackup
+	}
+	return nil
+}
+
+// restorer 为 modernc.org/sqlite 连接提供的在线恢复接口。
+type restorer interface {
+	NewRestore(srcURI string) (*sqlite.Backup, error)
+}
+
+// restoreFrom 把数据库文件 src 的全部页写入当前连接的主库。
+func (s *Store) restoreFrom(ctx context.Context, src string) error {
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("restore backup: %w", err)
+	}
+	defer conn.Close()
+	err = conn.Raw(func(driverConn any) error {
+		r, ok := driverConn.(restorer)
+		if !ok {
+			return errors.New("sqlite driver does not support restore")
+		}
+		b, err := r.NewRestore(src)
+		if err != nil {
+			return err
+		}
+		for more := true; more; {
+			if more, err = b.Step(-1); err != nil {
+				_ = b.Finish()
+				return err
+			}
+		}
+		return b.Finish()
+	})
+	if err != nil {
+		return fmt.Errorf("restore backup: %w", err)
+	}
+	return nil
+}
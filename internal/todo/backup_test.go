@@ -0,0 +1,57 @@
+package todo
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBackupFileNameStaysInDir(t *testing.T) {
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	cases := map[string]string{
+		"":                         "todo-20260102-030405.db",
+		"pre-update-1.2.0":         "todo-pre-update-1.2.0-20260102-030405.db",
+		"pre-update-../../x":       "todo-pre-update-.._.._x-20260102-030405.db",
+		`pre-update-..\..\x`:       "todo-pre-update-.._.._x-20260102-030405.db",
+		"pre-update-1.0 /etc:\x00": "todo-pre-update-1.0__etc__-20260102-030405.db",
+	}
+	dir := filepath.Join("data", "backups")
+	for reason, want := range cases {
+		name := BackupFileName(reason, at)
+		if name != want {
+			t.Errorf("BackupFileName(%q) = %q, want %q", reason, name, want)
+		}
+		if filepath.Dir(filepath.Join(dir, name)) != dir {
+			t.Errorf("BackupFileName(%q) = %q escapes the backup dir", reason, name)
+		}
+	}
+}
+
+func TestRestoreSnapshotSkipsMigrations(t *testing.T) {
+	ctx := context.Background()
+	s := openTestStore(t)
+	groupID := firstGroupID(t, s)
+	createTask(t, s, Task{GroupID: groupID, Title: "before update"})
+	snapshot := filepath.Join(t.TempDir(), "pre-update.db")
+	if err := s.Backup(ctx, snapshot); err != nil {
+		t.Fatal(err)
+	}
+
+	// 模拟新版本的迁移与更新后的写入
+	if _, err := s.db.ExecContext(ctx, `ALTER TABLE tasks ADD COLUMN future_column TEXT`); err != nil {
+		t.Fatal(err)
+	}
+	createTask(t, s, Task{GroupID: groupID, Title: "after update"})
+
+	if err := s.RestoreSnapshot(ctx, snapshot); err != nil {
+		t.Fatal(err)
+	}
+	var n int
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM pragma_table_info('tasks') WHERE name = 'future_column'`).Scan(&n); err != nil || n != 0 {
+		t.Errorf("restored schema still has the migrated column: %d, %v", n, err)
+	}
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM tasks WHERE title = 'after update'`).Scan(&n); err != nil || n != 0 {
+		t.Errorf("restored database still has tasks written after the snapshot: %d, %v", n, err)
+	}
+}
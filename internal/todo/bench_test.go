@@ -0,0 +1,155 @@
+package todo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// benchTasks 为性能基准使用的任务数量。在此数据量下搜索应保持在 100ms 以内；
+// 看板与四象限需要读出全部任务，耗时随任务数线性增长，基准用于发现回退。
+const benchTasks = 50000
+
+// benchWords 用于生成标题与内容，使搜索词在数据中有不同的命中率。
+var benchWords = []string{"report", "meeting", "review", "invoice", "deploy", "design", "refactor", "周报", "会议", "需求"}
+
+// openBenchStore 创建包含 benchTasks 个任务的数据库：20 个分组（1 个已归档），每 10 个任务中有 1 个子任务，
+// 状态、四象限与截止时间均匀分布，约 1/20 的任务带有溢出到 task_content 的长内容。
+func openBenchStore(tb testing.TB) *Store {
+	tb.Helper()
+	ctx := context.Background()
+	s, err := Open(filepath.Join(tb.TempDir(), "todo.db"))
+	if err != nil {
+		tb.Fatalf("open store: %v", err)
+	}
+	tb.Cleanup(func() { s.Close() })
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		tb.Fatalf("begin fixture: %v", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UnixMilli()
+	for i := 0; i < 20; i++ {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO groups(name, archived, sort_order, created_at, updated_at) VALUES(?, ?, ?, ?, ?)`,
+			fmt.Sprintf("bench-%02d", i), boolTo01Int(i == 19), i+100, now, now,
+		); err != nil {
+			tb.Fatalf("insert group: %v", err)
+		}
+	}
+
+	// 任务用一条 INSERT ... SELECT 生成：逐条插入时 task_fts 每条语句都要刷盘合并，生成 5 万条需要一分多钟
+	words, err := json.Marshal(benchWords)
+	if err != nil {
+		tb.Fatalf("encode words: %v", err)
+	}
+	preview := strings.Repeat("x", contentPreviewRunes)
+	if _, err := tx.ExecContext(ctx,
+		`WITH RECURSIVE seq(i) AS (SELECT 0 UNION ALL SELECT i + 1 FROM seq WHERE i + 1 < :n),
+		 base(id) AS (SELECT COALESCE(MAX(id), 0) FROM tasks)
+		 INSERT INTO tasks(group_id, parent_id, title, content, content_overflow, content_format, status, important, urgent, due_at, sort_order, completed_at, created_at, updated_at)
+		 SELECT (SELECT id FROM groups WHERE name = printf('bench-%02d', i % 20)),
+		        CASE WHEN i % 10 = 9 THEN base.id + 1 + i - i % 10 ELSE 0 END,
+		        json_extract(:words, '$[' || (i % 10) || ']') || ' ' || json_extract(:words, '$[' || (i / 7 % 10) || ']') || ' task ' || i,
+		        CASE WHEN i % 20 = 0 THEN :preview ELSE 'notes for ' || json_extract(:words, '$[' || (i % 10) || ']') || ' #' || i END,
+		        i % 20 = 0, :format,
+		        CASE i % 3 WHEN 0 THEN 'todo' WHEN 1 THEN 'doing' ELSE 'done' END,
+		        i % 2 = 0, i % 4 < 2,
+		        CASE WHEN i % 3 = 0 THEN :now + (i % 60 - 30) * :day ELSE 0 END,
+		        i,
+		        CASE WHEN i % 3 = 2 THEN :now ELSE 0 END,
+		        :now - i * 1000, :now - i * 500
+		 FROM seq, base`,
+		sql.Named("n", benchTasks), sql.Named("words", string(words)), sql.Named("preview", preview),
+		sql.Named("format", ContentFormatPlain), sql.Named("now", now), sql.Named("day", int64(24*time.Hour/time.Millisecond)),
+	); err != nil {
+		tb.Fatalf("insert tasks: %v", err)
+	}
+	rows, err := tx.QueryContext(ctx, `SELECT id FROM tasks WHERE content_overflow = 1`)
+	if err != nil {
+		tb.Fatalf("list long tasks: %v", err)
+	}
+	var long []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			tb.Fatalf("list long tasks: %v", err)
+		}
+		long = append(long, id)
+	}
+	rows.Close()
+	for _, id := range long {
+		if err := saveContentOverflow(ctx, tx, id, fmt.Sprintf("%s meeting minutes %d", preview, id), true); err != nil {
+			tb.Fatalf("insert task content: %v", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		tb.Fatalf("commit fixture: %v", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `ANALYZE`); err != nil {
+		tb.Fatalf("analyze: %v", err)
+	}
+	return s
+}
+
+// TestHotQueryPlansUseIndexes 检查看板、子任务与四象限查询在大数据量下通过索引读取 tasks，而不是全表扫描。
+func TestHotQueryPlansUseIndexes(t *testing.T) {
+	if testing.Short() {
+		t.Skip("generates a large fixture")
+	}
+	s := openBenchStore(t)
+	info, err := s.SchemaInfo(context.Background())
+	if err != nil {
+		t.Fatalf("schema info: %v", err)
+	}
+	for _, q := range hotQueries {
+		for _, step := range info.QueryPlans[q.name] {
+			if strings.HasPrefix(step, "SCAN tasks") && !strings.Contains(step, "USING") {
+				t.Errorf("%s scans tasks without an index:\n%s", q.name, strings.Join(info.QueryPlans[q.name], "\n"))
+			}
+		}
+	}
+}
+
+func BenchmarkListTasks(b *testing.B) {
+	s := openBenchStore(b)
+	ctx := context.Background()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := s.ListTasks(ctx, SortUpdated); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkListQuadrants(b *testing.B) {
+	s := openBenchStore(b)
+	ctx := context.Background()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := s.ListQuadrants(ctx, true, SortQuadrant); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSearchTasks(b *testing.B) {
+	s := openBenchStore(b)
+	ctx := context.Background()
+	for _, query := range []string{"invoice", "周报 deploy", "task 4999"} {
+		b.Run(query, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := s.SearchTasks(ctx, query, nil); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
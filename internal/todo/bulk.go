@@ -0,0 +1,252 @@
+package todo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// uniqueTaskIDs 校验并去重 ids（保持首次出现的顺序）。
+func uniqueTaskIDs(ids []int64) ([]int64, error) {
+	unique := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if id <= 0 {
+			return nil, ErrInvalidTaskID
+		}
+		if !slices.Contains(unique, id) {
+			unique = append(unique, id)
+		}
+	}
+	return unique, nil
+}
+
+// reloadTasks 按 ids 顺序重新读取任务（批量修改后返回给前端）。
+func (s *Store) reloadTasks(ctx context.Context, ids []int64) ([]Task, error) {
+	tasks := make([]Task, 0, len(ids))
+	for _, id := range ids {
+		t, err := s.GetTask(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, t)
+	}
+	return tasks, nil
+}
+
+// BulkUpdateStatus 在单个事务中把 ids 对应的任务改为 status，返回更新后的任务（按 ids 顺序，已去重）。
+//
+// 父子任务联动与 UpsertTask 相同：主任务完成时其子任务一并完成；子任务状态变化后同步父任务状态，
+// 重复任务完成后生成下一次的任务（这两步与状态修改在同一事务中）。任一任务不存在时整体失败，不做部分修改。
+func (s *Store) BulkUpdateStatus(ctx context.Context, ids []int64, status Status) ([]Task, error) {
+	if _, err := ParseStatus(string(status)); err != nil {
+		return nil, err
+	}
+	unique, err := uniqueTaskIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	if len(unique) == 0 {
+		return []Task{}, nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin bulk update status: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UnixMilli()
+	var parents, completed []int64
+	for _, id := range unique {
+		var oldStatus string
+		var parentID int64
+		err := tx.QueryRowContext(ctx, `SELECT status, parent_id FROM tasks WHERE id = ?`, id).Scan(&oldStatus, &parentID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTaskNotFound.with(id)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("get task status: %w", err)
+		}
+		if oldStatus == string(status) {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE tasks SET status = ?, updated_at = ?,
+			     completed_at = CASE WHEN ? = 'done' THEN (CASE WHEN completed_at > 0 THEN completed_at ELSE ? END) ELSE 0 END
+			 WHERE id = ?`,
+			string(status), now, string(status), now, id,
+		); err != nil {
+			return nil, fmt.Errorf("bulk update status: %w", err)
+		}
+		switch {
+		case parentID > 0:
+			if !slices.Contains(parents, parentID) {
+				parents = append(parents, parentID)
+			}
+		case status == StatusDone:
+			if _, err := tx.ExecContext(ctx,
+				`UPDATE tasks SET status = ?, updated_at = ?, `+completedAtSQL+` WHERE parent_id = ?`,
+				string(StatusDone), now, now, id,
+			); err != nil {
+				return nil, fmt.Errorf("complete subtasks: %w", err)
+			}
+			completed = append(completed, id)
+		}
+	}
+	for _, id := range completed {
+		if _, err := materializeRecurrenceTx(ctx, tx, id, now); err != nil {
+			return nil, err
+		}
+	}
+	for _, id := range parents {
+		if err := syncParentStatusTx(ctx, tx, id, now); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit bulk update status: %w", err)
+	}
+	return s.reloadTasks(ctx, unique)
+}
+
+// BulkMoveToGroup 在单个事务中把 ids 对应的任务（连同其子任务）移到分组 groupID，返回更新后的任务（按 ids 顺序，已去重）。
+//
+// 单独选中的子任务会脱离原父任务，成为目标分组中的主任务。任一任务或分组不存在时整体失败，不做部分修改。
+func (s *Store) BulkMoveToGroup(ctx context.Context, ids []int64, groupID int64) ([]Task, error) {
+	ok, err := s.groupExists(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrGroupNotFound.with(groupID)
+	}
+	unique, err := uniqueTaskIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	if len(unique) == 0 {
+		return []Task{}, nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin bulk move tasks: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UnixMilli()
+	var parents []int64
+	for _, id := range unique {
+		var parentID int64
+		err := tx.QueryRowContext(ctx, `SELECT parent_id FROM tasks WHERE id = ?`, id).Scan(&parentID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTaskNotFound.with(id)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("get task parent: %w", err)
+		}
+		// 父任务也在本次选中时，子任务随父任务移动，保持父子关系
+		if parentID > 0 && slices.Contains(unique, parentID) {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE tasks SET group_id = ?, parent_id = 0, updated_at = ? WHERE id = ?`, groupID, now, id,
+		); err != nil {
+			return nil, fmt.Errorf("bulk move task: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE tasks SET group_id = ?, updated_at = ? WHERE parent_id = ?`, groupID, now, id,
+		); err != nil {
+			return nil, fmt.Errorf("bulk move subtasks: %w", err)
+		}
+		if parentID > 0 && !slices.Contains(parents, parentID) {
+			parents = append(parents, parentID)
+		}
+	}
+	for _, id := range parents {
+		if err := syncParentStatusTx(ctx, tx, id, now); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit bulk move tasks: %w", err)
+	}
+	return s.reloadTasks(ctx, unique)
+}
+
+// BulkSetFlags 在单个事务中设置 ids 对应任务的"重要/紧急"标记（同 SetQuadrant）。
+func (s *Store) BulkSetFlags(ctx context.Context, ids []int64, important, urgent bool) ([]Task, error) {
+	return s.SetQuadrant(ctx, ids, important, urgent)
+}
+
+// BulkDelete 在单个事务中删除 ids 对应的任务（连同子任务），每个任务各自进入回收站，返回进入回收站的任务 ID。
+//
+// 父任务也在本次删除时，其子任务随父任务一起进入回收站，不单独返回。任一任务不存在时整体失败，不做部分修改。
+func (s *Store) BulkDelete(ctx context.Context, ids []int64) ([]int64, error) {
+	unique, err := uniqueTaskIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var targets []Task
+	for _, id := range unique {
+		t, err := s.GetTask(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if t.ParentID > 0 && slices.Contains(unique, t.ParentID) {
+			continue
+		}
+		targets = append(targets, t)
+	}
+	if len(targets) == 0 {
+		return []int64{}, nil
+	}
+	if err := s.attachSubTasks(ctx, targets); err != nil {
+		return nil, err
+	}
+	if err := s.LoadFullContent(ctx, targets); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin bulk delete tasks: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UnixMilli()
+	var parents []int64
+	for _, t := range targets {
+		if err := moveToTrash(ctx, tx, TrashKindTask, t.ID, t.Title, trashPayload{Tasks: []Task{t}}, now); err != nil {
+			return nil, err
+		}
+		if t.ParentID == 0 {
+			if _, err := tx.ExecContext(ctx, `DELETE FROM tasks WHERE parent_id = ?`, t.ID); err != nil {
+				return nil, fmt.Errorf("delete subtasks: %w", err)
+			}
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM tasks WHERE id = ?`, t.ID); err != nil {
+			return nil, fmt.Errorf("bulk delete task: %w", err)
+		}
+		if t.ParentID > 0 && !slices.Contains(parents, t.ParentID) {
+			parents = append(parents, t.ParentID)
+		}
+	}
+	for _, id := range parents {
+		if err := syncParentStatusTx(ctx, tx, id, now); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit bulk delete tasks: %w", err)
+	}
+	deleted := make([]int64, len(targets))
+	for i, t := range targets {
+		deleted[i] = t.ID
+	}
+	return deleted, nil
+}
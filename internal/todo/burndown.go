@@ -0,0 +1,150 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// 燃尽图时间范围。
+const (
+	BurndownWeek    = "week"    // 最近 7 天
+	BurndownMonth   = "month"   // 最近 30 天
+	BurndownQuarter = "quarter" // 最近 90 天
+)
+
+// burndownDays 为各时间范围覆盖的天数（含今天）。
+var burndownDays = map[string]int{
+	BurndownWeek:    7,
+	BurndownMonth:   30,
+	BurndownQuarter: 90,
+}
+
+// BurndownPoint 为燃尽图中某一天结束时的快照。
+type BurndownPoint struct {
+	Date      string `json:"date"`      // 本地日期（YYYY-MM-DD）
+	Open      int    `json:"open"`      // 当天结束时仍未完成的任务数
+	Closed    int    `json:"closed"`    // 截至当天结束累计完成的任务数
+	Created   int    `json:"created"`   // 当天新建的任务数
+	Completed int    `json:"completed"` // 当天完成的任务数
+}
+
+// VelocityWeek 为某一周的完成数量。
+type VelocityWeek struct {
+	WeekStart string `json:"weekStart"` // 该周第一天（按每周第一天设置）
+	Completed int    `json:"completed"`
+}
+
+// Burndown 为燃尽图与速度统计数据。
+type Burndown struct {
+	GroupID       int64           `json:"groupId"` // 0 表示全部分组
+	Range         string          `json:"range"`
+	Points        []BurndownPoint `json:"points"`
+	Weeks         []VelocityWeek  `json:"weeks"`
+	AvgPerWeek    float64         `json:"avgPerWeek"`    // 范围内平均每周完成数
+	CompletionPct float64         `json:"completionPct"` // 范围结束时的完成率（0-100）
+}
+
+// parseStatsRange 解析统计时间范围（week / month / quarter，空字符串为 month），返回规范写法与覆盖天数。
+func parseStatsRange(rng string) (string, int, error) {
+	rng = strings.ToLower(strings.TrimSpace(rng))
+	if rng == "" {
+		rng = BurndownMonth
+	}
+	days, ok := burndownDays[rng]
+	if !ok {
+		return "", 0, ErrInvalidRange.with(rng)
+	}
+	return rng, days, nil
+}
+
+// GetBurndown 基于任务的创建/完成时间计算燃尽图与每周完成速度。
+//
+// 只统计主任务（子任务视为父任务的一部分）；groupID 为 0 时统计全部分组。
+// 已删除的任务不在统计范围内。日期按时区设置与每周第一天设置计算。
+func (s *Store) GetBurndown(ctx context.Context, groupID int64, rng string, now time.Time, settings Settings) (Burndown, error) {
+	rng, days, err := parseStatsRange(rng)
+	if err != nil {
+		return Burndown{}, err
+	}
+	if groupID < 0 {
+		return Burndown{}, ErrInvalidGroupID
+	}
+
+	loc := Location(settings)
+	first := StartOfDay(now, loc).AddDate(0, 0, -(days - 1))
+	end := first.AddDate(0, 0, days)
+
+	query := `SELECT created_at, completed_at FROM tasks WHERE parent_id = 0 AND created_at < ?`
+	args := []any{end.UnixMilli()}
+	if groupID > 0 {
+		query += ` AND group_id = ?`
+		args = append(args, groupID)
+	}
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return Burndown{}, fmt.Errorf("query burndown: %w", err)
+	}
+	defer rows.Close()
+
+	type span struct{ created, completed int64 }
+	var spans []span
+	for rows.Next() {
+		var sp span
+		if err := rows.Scan(&sp.created, &sp.completed); err != nil {
+			return Burndown{}, fmt.Errorf("scan burndown: %w", err)
+		}
+		spans = append(spans, sp)
+	}
+	if err := rows.Err(); err != nil {
+		return Burndown{}, fmt.Errorf("iterate burndown: %w", err)
+	}
+
+	out := Burndown{GroupID: groupID, Range: rng, Points: make([]BurndownPoint, days), Weeks: []VelocityWeek{}}
+	weekIndex := map[string]int{}
+	for i := range out.Points {
+		dayStart := first.AddDate(0, 0, i)
+		dayEnd := dayStart.AddDate(0, 0, 1)
+		ds, de := dayStart.UnixMilli(), dayEnd.UnixMilli()
+
+		p := BurndownPoint{Date: dayStart.Format(dayKeyLayout)}
+		for _, sp := range spans {
+			if sp.created >= de {
+				continue
+			}
+			done := sp.completed > 0 && sp.completed < de
+			if done {
+				p.Closed++
+			} else {
+				p.Open++
+			}
+			if sp.created >= ds {
+				p.Created++
+			}
+			if done && sp.completed >= ds {
+				p.Completed++
+			}
+		}
+		out.Points[i] = p
+
+		week := StartOfWeek(dayStart, settings).Format(dayKeyLayout)
+		wi, ok := weekIndex[week]
+		if !ok {
+			wi = len(out.Weeks)
+			weekIndex[week] = wi
+			out.Weeks = append(out.Weeks, VelocityWeek{WeekStart: week})
+		}
+		out.Weeks[wi].Completed += p.Completed
+	}
+
+	var completed int
+	for _, p := range out.Points {
+		completed += p.Completed
+	}
+	out.AvgPerWeek = float64(completed) * 7 / float64(days)
+	if last := out.Points[days-1]; last.Open+last.Closed > 0 {
+		out.CompletionPct = float64(last.Closed) * 100 / float64(last.Open+last.Closed)
+	}
+	return out, nil
+}
@@ -0,0 +1,202 @@
+package todo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+)
+
+// settings 表中保存云备份配置与最近一次备份结果的 key。
+// 访问密钥与加密口令不在这里，保存在系统凭据库中（见 internal/keychain）。
+const (
+	cloudBackupKey       = "cloudBackup"
+	cloudBackupStatusKey = "cloudBackupStatus"
+)
+
+// 云备份间隔（小时）与保留份数的取值范围。
+const (
+	defaultCloudBackupHours = 24
+	minCloudBackupHours     = 1
+	maxCloudBackupHours     = 24 * 7
+	defaultCloudBackupKeep  = 10
+	maxCloudBackupKeep      = 1000
+
+	// cloudBackupRetryAfter 为定时备份失败后的重试间隔，避免网络不通时反复重试。
+	cloudBackupRetryAfter = time.Hour
+)
+
+// CloudBackupConfig 为 S3 兼容对象存储（AWS S3、MinIO、Backblaze B2 等）云备份的配置。
+type CloudBackupConfig struct {
+	Enabled   bool   `json:"enabled"`   // 是否启用定时备份（关闭时仍可手动备份与恢复）
+	Endpoint  string `json:"endpoint"`  // 服务地址，如 https://s3.us-west-004.backblazeb2.com
+	Region    string `json:"region"`    // 区域；为空时使用 us-east-1
+	Bucket    string `json:"bucket"`    // 存储桶
+	Prefix    string `json:"prefix"`    // 对象名前缀（目录），如 "spark-todo/"
+	AccessKey string `json:"accessKey"` // 访问密钥 ID（Secret Key 保存在系统凭据库）
+	PathStyle bool   `json:"pathStyle"` // 使用 <endpoint>/<bucket> 形式的地址（MinIO 等需要）
+	Encrypt   bool   `json:"encrypt"`   // 用口令加密上传的备份（口令保存在系统凭据库）
+	Hours     int    `json:"hours"`     // 定时备份间隔（小时）
+	Keep      int    `json:"keep"`      // 远端保留的最近备份份数；0 表示全部保留
+}
+
+// CloudBackupStatus 为最近一次云备份的结果。
+type CloudBackupStatus struct {
+	LastBackupAt int64  `json:"lastBackupAt"` // 最近一次成功备份的时间（UnixMilli）；从未备份为 0
+	LastKey      string `json:"lastKey"`      // 最近一次成功备份的对象名
+	LastError    string `json:"lastError"`    // 最近一次失败的原因；成功后清空
+	LastErrorAt  int64  `json:"lastErrorAt"`
+}
+
+// Configured 报告是否填写了连接存储桶所需的配置。
+func (c CloudBackupConfig) Configured() bool {
+	return c.Endpoint != "" && c.Bucket != "" && c.AccessKey != ""
+}
+
+// Due 报告定时备份在 now 时是否应当执行。
+func (c CloudBackupConfig) Due(status CloudBackupStatus, now time.Time) bool {
+	if !c.Enabled || !c.Configured() {
+		return false
+	}
+	if status.LastErrorAt > status.LastBackupAt && now.Sub(time.UnixMilli(status.LastErrorAt)) < cloudBackupRetryAfter {
+		return false
+	}
+	return now.Sub(time.UnixMilli(status.LastBackupAt)) >= time.Duration(c.Hours)*time.Hour
+}
+
+// ObjectKey 返回 at 时刻备份的对象名：<prefix>spark-todo-<yyyyMMdd-HHmmss>.db（加密备份为 .sparkbak）。
+func (c CloudBackupConfig) ObjectKey(at time.Time, encrypted bool) string {
+	ext := ".db"
+	if encrypted {
+		ext = ".sparkbak"
+	}
+	return c.Prefix + "spark-todo-" + at.UTC().Format("20060102-150405") + ext
+}
+
+// IsBackupKey 报告对象名 key 是否为本应用上传的备份（列出远端备份与清理旧备份时只处理这些对象）。
+func (c CloudBackupConfig) IsBackupKey(key string) bool {
+	name, ok := strings.CutPrefix(key, c.Prefix)
+	if !ok || strings.Contains(name, "/") || !strings.HasPrefix(name, "spark-todo-") {
+		return false
+	}
+	return strings.HasSuffix(name, ".db") || strings.HasSuffix(name, ".sparkbak")
+}
+
+// defaultCloudBackupConfig 为未配置时的默认值。
+func defaultCloudBackupConfig() CloudBackupConfig {
+	return CloudBackupConfig{Prefix: "spark-todo/", Hours: defaultCloudBackupHours, Keep: defaultCloudBackupKeep}
+}
+
+// NormalizeCloudBackupConfig 校验并规范化云备份配置（不保存）。
+func NormalizeCloudBackupConfig(c CloudBackupConfig) (CloudBackupConfig, error) {
+	c.Endpoint = strings.TrimRight(strings.TrimSpace(c.Endpoint), "/")
+	c.Region = strings.TrimSpace(c.Region)
+	c.Bucket = strings.TrimSpace(c.Bucket)
+	c.AccessKey = strings.TrimSpace(c.AccessKey)
+	if !isWebURL(c.Endpoint) {
+		return CloudBackupConfig{}, ErrInvalidCloudEndpoint.with(c.Endpoint)
+	}
+	if c.Bucket == "" || strings.ContainsAny(c.Bucket, "/ ") {
+		return CloudBackupConfig{}, ErrInvalidCloudBucket.with(c.Bucket)
+	}
+	if c.AccessKey == "" {
+		return CloudBackupConfig{}, ErrCloudAccessKeyEmpty
+	}
+	// 前缀按目录处理：去掉首尾的 "/" 与多余的分隔符，非空时以 "/" 结尾
+	prefix := strings.Trim(strings.TrimSpace(c.Prefix), "/")
+	if prefix != "" {
+		prefix = path.Clean(prefix) + "/"
+	}
+	c.Prefix = prefix
+	if c.Hours == 0 {
+		c.Hours = defaultCloudBackupHours
+	}
+	if c.Hours < minCloudBackupHours || c.Hours > maxCloudBackupHours {
+		return CloudBackupConfig{}, ErrInvalidCloudBackupHours.with(minCloudBackupHours, maxCloudBackupHours)
+	}
+	if c.Keep < 0 || c.Keep > maxCloudBackupKeep {
+		return CloudBackupConfig{}, ErrInvalidCloudBackupKeep.with(0, maxCloudBackupKeep)
+	}
+	return c, nil
+}
+
+// GetCloudBackupConfig 返回云备份配置；未配置时返回默认值（Configured 为 false）。
+func (s *Store) GetCloudBackupConfig(ctx context.Context) (CloudBackupConfig, error) {
+	out := defaultCloudBackupConfig()
+	if err := s.getJSONSetting(ctx, cloudBackupKey, &out); err != nil {
+		return CloudBackupConfig{}, fmt.Errorf("get cloud backup config: %w", err)
+	}
+	return out, nil
+}
+
+// SetCloudBackupConfig 校验并保存云备份配置，返回规范化后的配置。
+func (s *Store) SetCloudBackupConfig(ctx context.Context, c CloudBackupConfig) (CloudBackupConfig, error) {
+	c, err := NormalizeCloudBackupConfig(c)
+	if err != nil {
+		return CloudBackupConfig{}, err
+	}
+	raw, err := json.Marshal(c)
+	if err != nil {
+		return CloudBackupConfig{}, fmt.Errorf("encode cloud backup config: %w", err)
+	}
+	if err := s.setSetting(ctx, cloudBackupKey, string(raw)); err != nil {
+		return CloudBackupConfig{}, err
+	}
+	return c, nil
+}
+
+// DeleteCloudBackupConfig 删除云备份配置与备份结果（远端已有的备份保留）。
+func (s *Store) DeleteCloudBackupConfig(ctx context.Context) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM settings WHERE key IN (?, ?)`, cloudBackupKey, cloudBackupStatusKey); err != nil {
+		return fmt.Errorf("delete cloud backup config: %w", err)
+	}
+	return nil
+}
+
+// GetCloudBackupStatus 返回最近一次云备份的结果。
+func (s *Store) GetCloudBackupStatus(ctx context.Context) (CloudBackupStatus, error) {
+	var out CloudBackupStatus
+	if err := s.getJSONSetting(ctx, cloudBackupStatusKey, &out); err != nil {
+		return CloudBackupStatus{}, fmt.Errorf("get cloud backup status: %w", err)
+	}
+	return out, nil
+}
+
+// RecordCloudBackup 记录一次云备份的结果：backupErr 为 nil 时记为成功（对象名 key），否则记录失败原因。
+func (s *Store) RecordCloudBackup(ctx context.Context, at time.Time, key string, backupErr error) (CloudBackupStatus, error) {
+	status, err := s.GetCloudBackupStatus(ctx)
+	if err != nil {
+		return CloudBackupStatus{}, err
+	}
+	if backupErr == nil {
+		status.LastBackupAt, status.LastKey = at.UnixMilli(), key
+		status.LastError, status.LastErrorAt = "", 0
+	} else {
+		status.LastError, status.LastErrorAt = backupErr.Error(), at.UnixMilli()
+	}
+	raw, err := json.Marshal(status)
+	if err != nil {
+		return CloudBackupStatus{}, fmt.Errorf("encode cloud backup status: %w", err)
+	}
+	if err := s.setSetting(ctx, cloudBackupStatusKey, string(raw)); err != nil {
+		return CloudBackupStatus{}, err
+	}
+	return status, nil
+}
+
+// getJSONSetting 读取以 JSON 保存的内部设置到 dst；key 不存在时保持 dst 不变。
+func (s *Store) getJSONSetting(ctx context.Context, key string, dst any) error {
+	var raw string
+	err := s.db.QueryRowContext(ctx, `SELECT value FROM settings WHERE key = ?`, key).Scan(&raw)
+	if errors.Is(err, sql.ErrNoRows) || raw == "" {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal([]byte(raw), dst)
+}
@@ -0,0 +1,251 @@
+package todo
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"spark-todo/internal/markdown"
+)
+
+const (
+	// contentPreviewRunes 为 tasks.content 中保存的内容长度：超出部分的完整内容存放在 task_content 表，
+	// 看板等列表查询只读取预览，避免长笔记拖慢 GetBoard。
+	contentPreviewRunes = maxTaskContentRunes
+
+	// compressThresholdBytes 为压缩保存完整内容的阈值：粘贴的长日志、会议记录等压缩率通常很高，
+	// 较短的内容压缩收益有限，原样保存。
+	compressThresholdBytes = 4096
+
+	// boardPreviewRunes 为 GetBoard 中每个任务携带的内容预览长度；完整内容与附件等通过 GetTaskDetail 按需加载。
+	boardPreviewRunes = 120
+
+	// minContentLimit / maxContentLimit 为可配置的任务内容上限（contentLimit 设置）的取值范围。
+	minContentLimit = maxTaskContentRunes
+	maxContentLimit = 100000
+
+	// maxDecompressedContentBytes 为解压后完整内容的上限（内容上限的最大值按每字 4 字节计），
+	// 损坏或被篡改的数据不会解压出超大内容占满内存。
+	maxDecompressedContentBytes = maxContentLimit * utf8.UTFMax
+)
+
+// 任务内容格式。
+const (
+	ContentFormatPlain    = "plain"    // 纯文本（默认）
+	ContentFormatMarkdown = "markdown" // Markdown，由后端渲染为安全的 HTML
+)
+
+// ParseContentFormat 校验内容格式（忽略大小写与首尾空白）；空字符串视为 ContentFormatPlain。
+func ParseContentFormat(format string) (string, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	switch format {
+	case "", ContentFormatPlain:
+		return ContentFormatPlain, nil
+	case ContentFormatMarkdown:
+		return ContentFormatMarkdown, nil
+	}
+	return "", ErrInvalidContentFormat.with(format)
+}
+
+// RenderContent 将任务内容渲染为可直接显示的 HTML：Markdown 经安全子集渲染，纯文本转义后保留换行。
+func RenderContent(content, format string) string {
+	if format == ContentFormatMarkdown {
+		return markdown.Render(content)
+	}
+	return strings.ReplaceAll(html.EscapeString(content), "\n", "<br>")
+}
+
+// normalizeContentLimit 校验任务内容上限设置。
+func normalizeContentLimit(v string) (string, error) {
+	return normalizeLimit(v, minContentLimit, maxContentLimit, ErrInvalidContentLimit)
+}
+
+// splitContent 返回 content 的预览部分，以及是否需要溢出存储完整内容。
+func splitContent(content string) (string, bool) {
+	runes := []rune(content)
+	if len(runes) <= contentPreviewRunes {
+		return content, false
+	}
+	return string(runes[:contentPreviewRunes]), true
+}
+
+// SlimContent 将 tasks（含子任务）中较长的内容截断为 boardPreviewRunes 的预览并标记 ContentTruncated，
+// 用于看板等只需摘要的列表接口，显著减小序列化体积。
+func SlimContent(tasks []Task) {
+	for i := range tasks {
+		t := &tasks[i]
+		if preview := truncateRunes(t.Content, boardPreviewRunes); preview != t.Content {
+			t.Content = preview
+			t.ContentTruncated = true
+		}
+		SlimContent(t.SubTasks)
+	}
+}
+
+// truncateRunes 返回 s 的前 n 个字符。
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
+
+// isContentPreview 判断 content 是否为已存内容 stored（tasks.content 列）原样或截断后的预览，
+// 即前端提交的是未加载完整内容、也未修改过的预览。
+func isContentPreview(content, stored string) bool {
+	return content == stored || content == truncateRunes(stored, boardPreviewRunes)
+}
+
+// contentExecer 为 *sql.DB 与 *sql.Tx 的公共执行接口。
+type contentExecer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// saveContentOverflow 写入（overflow 为 true）或清除任务的完整内容，并让全文索引（task_fts）索引完整内容。
+func saveContentOverflow(ctx context.Context, ex contentExecer, taskID int64, content string, overflow bool) error {
+	if !overflow {
+		res, err := ex.ExecContext(ctx, `DELETE FROM task_content WHERE task_id = ?`, taskID)
+		if err != nil {
+			return fmt.Errorf("clear task content: %w", err)
+		}
+		// 原来有溢出内容时，索引中的完整内容换回 tasks.content
+		if n, _ := res.RowsAffected(); n > 0 {
+			if _, err := ex.ExecContext(ctx,
+				`UPDATE task_fts SET content = (SELECT content FROM tasks WHERE id = ?) WHERE rowid = ?`, taskID, taskID,
+			); err != nil {
+				return fmt.Errorf("index task content: %w", err)
+			}
+		}
+		return nil
+	}
+	if _, err := ex.ExecContext(ctx, `UPDATE task_fts SET content = ? WHERE rowid = ?`, content, taskID); err != nil {
+		return fmt.Errorf("index task content: %w", err)
+	}
+	var value any = content
+	compressed := false
+	if len(content) > compressThresholdBytes {
+		packed, err := compressContent(content)
+		if err != nil {
+			return err
+		}
+		if len(packed) < len(content) {
+			value, compressed = packed, true
+		}
+	}
+	if _, err := ex.ExecContext(ctx,
+		`INSERT INTO task_content(task_id, content, compressed) VALUES(?, ?, ?)
+		 ON CONFLICT(task_id) DO UPDATE SET content = excluded.content, compressed = excluded.compressed`,
+		taskID, value, boolTo01Int(compressed),
+	); err != nil {
+		return fmt.Errorf("save task content: %w", err)
+	}
+	return nil
+}
+
+// rebuildTaskFTS 按现有任务重建全文索引：先索引标题与预览，再把有溢出内容的任务换成完整内容。
+func (s *Store) rebuildTaskFTS(ctx context.Context) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM task_fts`); err != nil {
+		return fmt.Errorf("clear task_fts: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `INSERT INTO task_fts(rowid, title, content) SELECT id, title, content FROM tasks`); err != nil {
+		return fmt.Errorf("build task_fts index: %w", err)
+	}
+	ids, err := queryAll(ctx, s.db, func(r rowScanner) (int64, error) {
+		var id int64
+		return id, r.Scan(&id)
+	}, `SELECT task_id FROM task_content`)
+	if err != nil {
+		return err
+	}
+	// 完整内容逐个读取（可能较大），不一次性载入
+	for _, id := range ids {
+		tasks := []Task{{ID: id, ContentTruncated: true}}
+		if err := s.LoadFullContent(ctx, tasks); err != nil {
+			return err
+		}
+		if _, err := s.db.ExecContext(ctx, `UPDATE task_fts SET content = ? WHERE rowid = ?`, tasks[0].Content, id); err != nil {
+			return fmt.Errorf("index task content: %w", err)
+		}
+	}
+	return nil
+}
+
+// compressContent 以 DEFLATE 压缩内容。
+func compressContent(content string) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, flate.BestCompression)
+	if err != nil {
+		return nil, fmt.Errorf("compress task content: %w", err)
+	}
+	if _, err := io.WriteString(w, content); err != nil {
+		return nil, fmt.Errorf("compress task content: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("compress task content: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// decompressContent 解压 compressContent 的结果；解压后超过 maxDecompressedContentBytes 时返回错误。
+func decompressContent(data []byte) (string, error) {
+	r := flate.NewReader(bytes.NewReader(data))
+	defer r.Close()
+	out, err := io.ReadAll(io.LimitReader(r, maxDecompressedContentBytes+1))
+	if err != nil {
+		return "", fmt.Errorf("decompress task content: %w", err)
+	}
+	if len(out) > maxDecompressedContentBytes {
+		return "", fmt.Errorf("decompress task content: exceeds %d bytes", maxDecompressedContentBytes)
+	}
+	return string(out), nil
+}
+
+// GetTaskContent 返回任务的完整内容（供详情/编辑时按需加载）。
+func (s *Store) GetTaskContent(ctx context.Context, id int64) (string, error) {
+	t, err := s.GetTask(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	tasks := []Task{t}
+	if err := s.LoadFullContent(ctx, tasks); err != nil {
+		return "", err
+	}
+	return tasks[0].Content, nil
+}
+
+// LoadFullContent 将 tasks（含子任务）中仅有预览的内容替换为完整内容，用于导出、详情与回收站快照。
+func (s *Store) LoadFullContent(ctx context.Context, tasks []Task) error {
+	for i := range tasks {
+		t := &tasks[i]
+		if t.ContentTruncated {
+			var raw []byte
+			var compressed int
+			err := s.db.QueryRowContext(ctx,
+				`SELECT content, compressed FROM task_content WHERE task_id = ?`, t.ID,
+			).Scan(&raw, &compressed)
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+			case err != nil:
+				return fmt.Errorf("load task content: %w", err)
+			case compressed == 1:
+				if t.Content, err = decompressContent(raw); err != nil {
+					return err
+				}
+			default:
+				t.Content = string(raw)
+			}
+			t.ContentTruncated = false
+		}
+		if err := s.LoadFullContent(ctx, t.SubTasks); err != nil {
+			return err
+		}
+	}
+	return nil
+}
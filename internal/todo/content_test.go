@@ -0,0 +1,88 @@
+package todo
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// openTestStore 在临时目录中打开一个新的 Store，测试结束时关闭。
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+	s, err := Open(filepath.Join(t.TempDir(), "todo.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+// firstGroupID 返回默认分组的 ID。
+func firstGroupID(t *testing.T, s *Store) int64 {
+	t.Helper()
+	groups, err := s.ListGroups(context.Background())
+	if err != nil || len(groups) == 0 {
+		t.Fatalf("list groups: %v (%d groups)", err, len(groups))
+	}
+	return groups[0].ID
+}
+
+func TestUpsertTaskKeepsContentWhenSavingBoardPreview(t *testing.T) {
+	ctx := context.Background()
+	s := openTestStore(t)
+	groupID := firstGroupID(t, s)
+
+	for _, n := range []int{500, 3000} { // 仅存于 tasks.content / 溢出到 task_content
+		full := strings.Repeat("长", n)
+		task, err := s.UpsertTask(ctx, Task{GroupID: groupID, Title: "long", Content: full, Status: StatusTodo})
+		if err != nil {
+			t.Fatalf("create task: %v", err)
+		}
+
+		// 看板中的副本只带预览：原样保存（如编辑标题、切换完成）不应丢失完整内容
+		tasks := []Task{task}
+		SlimContent(tasks)
+		board := tasks[0]
+		if !board.ContentTruncated || board.Content == full {
+			t.Fatalf("board copy of %d runes is not a preview", n)
+		}
+		board.ContentTruncated = false // 前端可能不回传该字段
+		board.Title = "long (edited)"
+		if _, err := s.UpsertTask(ctx, board); err != nil {
+			t.Fatalf("save board copy: %v", err)
+		}
+		if got, err := s.GetTaskContent(ctx, task.ID); err != nil || got != full {
+			t.Fatalf("content of %d runes after saving board copy: got %d runes, err %v", n, len([]rune(got)), err)
+		}
+
+		// 明确标记为已修改时，与预览相同的内容覆盖完整内容
+		board.ContentChanged = true
+		if _, err := s.UpsertTask(ctx, board); err != nil {
+			t.Fatalf("save changed content: %v", err)
+		}
+		if got, err := s.GetTaskContent(ctx, task.ID); err != nil || got != board.Content {
+			t.Fatalf("content of %d runes after explicit change: got %d runes, err %v", n, len([]rune(got)), err)
+		}
+	}
+}
+
+func TestDecompressContentIsBounded(t *testing.T) {
+	full := strings.Repeat("a", maxDecompressedContentBytes)
+	data, err := compressContent(full)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := decompressContent(data); err != nil || got != full {
+		t.Fatalf("content at the limit: %d bytes, err %v", len(got), err)
+	}
+
+	// 体积很小、解压后超出上限的数据被拒绝
+	bomb, err := compressContent(full + "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := decompressContent(bomb); err == nil {
+		t.Errorf("decompressed %d compressed bytes past the limit", len(bomb))
+	}
+}
@@ -0,0 +1,91 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// countdownMilestones 为倒计时提醒的节点（剩余天数，从大到小）。
+var countdownMilestones = []int{30, 7, 1}
+
+// CountdownMilestone 为一次到达提醒节点的倒计时事项。
+type CountdownMilestone struct {
+	Task      Task `json:"task"`
+	DaysLeft  int  `json:"daysLeft"`  // 距截止日的自然日天数（当天为 0）
+	Milestone int  `json:"milestone"` // 到达的节点：30 / 7 / 1
+}
+
+// daysLeft 返回截止时间 dueAt 距 now 所在自然日的天数（按 loc 计算；当天为 0，已过为负数）。
+func daysLeft(dueAt int64, now time.Time, loc *time.Location) int {
+	return daysBetween(StartOfDay(now, loc), StartOfDay(time.UnixMilli(dueAt), loc))
+}
+
+// FillCountdown 为 tasks（含子任务）中设置了截止时间的倒计时事项填充 DaysLeft。
+func FillCountdown(tasks []Task, now time.Time, loc *time.Location) {
+	for i := range tasks {
+		t := &tasks[i]
+		if t.Countdown && t.DueAt > 0 {
+			n := daysLeft(t.DueAt, now, loc)
+			t.DaysLeft = &n
+		}
+		FillCountdown(t.SubTasks, now, loc)
+	}
+}
+
+// TakeCountdownMilestones 返回 now 时刚到达提醒节点、尚未提醒过的未完成倒计时事项，并记为已提醒。
+//
+// 剩余天数不超过某个节点即视为到达（应用没运行的那天错过的节点，下次打开时补提醒一次）；
+// 同时越过多个节点时只提醒最近的一个。提醒记录与截止时间绑定，修改截止时间后会重新提醒。
+func (s *Store) TakeCountdownMilestones(ctx context.Context, now time.Time, loc *time.Location) ([]CountdownMilestone, error) {
+	today := StartOfDay(now, loc)
+	tasks, err := s.queryTasks(ctx,
+		`SELECT `+taskColumns+` FROM tasks
+		 WHERE countdown = 1 AND status <> 'done' AND due_at >= ? AND due_at < ?
+		 ORDER BY due_at, id`,
+		today.UnixMilli(), today.AddDate(0, 0, countdownMilestones[0]+1).UnixMilli(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin countdown milestones: %w", err)
+	}
+	defer tx.Rollback()
+
+	out := []CountdownMilestone{}
+	for _, t := range tasks {
+		left := daysLeft(t.DueAt, now, loc)
+		// 剩余天数所在的节点为不小于 left 的最小节点
+		reached := 0
+		for _, m := range countdownMilestones {
+			if m < left {
+				break
+			}
+			reached = m
+		}
+		if reached == 0 {
+			continue
+		}
+
+		res, err := tx.ExecContext(ctx,
+			`INSERT OR IGNORE INTO countdown_notices(task_id, milestone, due_at, notified_at) VALUES(?, ?, ?, ?)`,
+			t.ID, reached, t.DueAt, now.UnixMilli(),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("record countdown milestone: %w", err)
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			continue
+		}
+
+		t.DaysLeft = &left
+		out = append(out, CountdownMilestone{Task: t, DaysLeft: left, Milestone: reached})
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit countdown milestones: %w", err)
+	}
+	return out, nil
+}
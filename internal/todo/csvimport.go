@@ -0,0 +1,413 @@
+package todo
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"spark-todo/internal/i18n"
+)
+
+// CSV 导入可映射的字段（ImportCSV 中 mapping 的键）。
+const (
+	CSVFieldID        = "id"     // 文件内的任务编号，仅用于 CSVFieldParent 引用
+	CSVFieldParent    = "parent" // 父任务在文件内的编号；为空表示主任务
+	CSVFieldTitle     = "title"
+	CSVFieldContent   = "content"
+	CSVFieldStatus    = "status"
+	CSVFieldGroup     = "group"
+	CSVFieldImportant = "important"
+	CSVFieldUrgent    = "urgent"
+	CSVFieldDue       = "due"
+)
+
+// csvFields 为全部可映射字段；字段名同时是 report.col.* 的列名 key（导出文件的表头）。
+var csvFields = []string{
+	CSVFieldID, CSVFieldParent, CSVFieldTitle, CSVFieldContent, CSVFieldStatus,
+	CSVFieldGroup, CSVFieldImportant, CSVFieldUrgent, CSVFieldDue,
+}
+
+// csvFieldAliases 为其它工具导出的 CSV 中常见的列名（小写），未指定映射时用于自动识别。
+var csvFieldAliases = map[string][]string{
+	CSVFieldTitle:   {"name", "task", "subject", "summary"},
+	CSVFieldContent: {"description", "notes", "note", "body"},
+	CSVFieldGroup:   {"list", "project", "category"},
+	CSVFieldDue:     {"due date", "due_date", "deadline"},
+}
+
+// csvTimeLayouts 为截止时间列可接受的格式（按设置中的时区解析）。
+var csvTimeLayouts = []string{
+	"2006-01-02 15:04", "2006-01-02 15:04:05", "2006-01-02",
+	"2006/01/02 15:04", "2006/01/02", time.RFC3339,
+}
+
+// ImportRowError 为任务导入（CSV / todo.txt）中无法导入的一行。
+type ImportRowError struct {
+	Row     int    `json:"row"` // 该行在文件中的行号（表头为第 1 行）
+	Message string `json:"message"`
+	// Err 为原始错误，App 层据此按界面语言重新生成 Message
+	Err error `json:"-"`
+}
+
+// TaskImportSummary 为一次任务导入（CSV / todo.txt）的结果。
+type TaskImportSummary struct {
+	Created       int              `json:"created"`       // 新建的任务数
+	Skipped       int              `json:"skipped"`       // 空行及导入前已存在（同分组、同标题、同截止时间）而跳过的行数
+	Errored       int              `json:"errored"`       // 校验失败未导入的行数，明细见 Errors
+	Errors        []ImportRowError `json:"errors"`        // 按行号排列
+	CreatedGroups int              `json:"createdGroups"` // 按文件中的分组名新建的分组数
+}
+
+// ImportCSV 从 CSV 导入任务，返回新建/跳过/出错的行数。
+//
+// 第一行为表头。mapping 将字段（CSVField* 常量）映射到表头中的列名；未指定的字段按列名自动识别
+// （字段名、各语言导出文件的表头与常见别名，忽略大小写），因此 TasksCSV 导出的文件可直接导入。
+// 必须有"标题"列。分组列为空时导入到 groupID（为 0 时为第一个分组），分组不存在时自动新建。
+//
+// 每行单独校验，不合法的行记入 Errors 并跳过，其余行在单个事务中写入；子任务导入后同步父任务状态（同 UpsertTask）。
+// 只与导入前已有的任务去重，文件中本身重复的行照常导入。
+func (s *Store) ImportCSV(ctx context.Context, data []byte, groupID int64, mapping map[string]string) (TaskImportSummary, error) {
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\ufeff"))))
+	r.FieldsPerRecord = -1
+	// lines 为每条记录在文件中的起始行号（带引号的单元格可能跨行）
+	var records [][]string
+	var lines []int
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return TaskImportSummary{}, ErrInvalidImportFile
+		}
+		line, _ := r.FieldPos(0)
+		records = append(records, record)
+		lines = append(lines, line)
+	}
+	if len(records) == 0 {
+		return TaskImportSummary{}, ErrInvalidImportFile
+	}
+	cols, err := csvColumns(records[0], mapping)
+	if err != nil {
+		return TaskImportSummary{}, err
+	}
+
+	settings, err := s.GetSettings(ctx)
+	if err != nil {
+		return TaskImportSummary{}, err
+	}
+	groupID, groupIDs, err := s.importGroups(ctx, groupID)
+	if err != nil {
+		return TaskImportSummary{}, err
+	}
+	existing, err := s.existingTaskKeys(ctx)
+	if err != nil {
+		return TaskImportSummary{}, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return TaskImportSummary{}, fmt.Errorf("begin import csv: %w", err)
+	}
+	defer tx.Rollback()
+
+	out := TaskImportSummary{Errors: []ImportRowError{}}
+	loc := Location(settings)
+	now := time.Now().UnixMilli()
+	// fileIDs 为文件内编号到任务 ID（新建的或已存在而跳过的）的映射，子任务据此找到父任务
+	fileIDs := map[string]csvParent{}
+	var parents []int64
+	for i, record := range records[1:] {
+		row := lines[i+1]
+		get := func(field string) string {
+			if c := cols[field]; c >= 0 && c < len(record) {
+				return unescapeCSVCell(strings.TrimSpace(record[c]))
+			}
+			return ""
+		}
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			out.Skipped++
+			continue
+		}
+
+		t, groupName, err := parseCSVTask(get, settings, loc)
+		if err == nil {
+			t.GroupID = groupID
+			if parent := get(CSVFieldParent); parent != "" {
+				p, ok := fileIDs[parent]
+				if !ok {
+					err = ErrParentTaskNotFound
+				}
+				t.ParentID, t.GroupID = p.id, p.groupID
+			} else if groupName != "" {
+				t.GroupID, err = importGroup(ctx, tx, groupIDs, groupName, settings, now, &out)
+			}
+		}
+		if err != nil {
+			out.Errored++
+			out.Errors = append(out.Errors, ImportRowError{Row: row, Message: err.Error(), Err: err})
+			continue
+		}
+
+		key := taskDedupKey(t.GroupID, t.ParentID, t.Title, t.DueAt)
+		if id, ok := existing[key]; ok {
+			out.Skipped++
+			if fileID := get(CSVFieldID); fileID != "" && t.ParentID == 0 {
+				fileIDs[fileID] = csvParent{id: id, groupID: t.GroupID}
+			}
+			continue
+		}
+		preview, overflow := splitContent(t.Content)
+		res, err := tx.ExecContext(ctx,
+			`INSERT INTO tasks(group_id, parent_id, title, content, content_overflow, content_format, status, important, urgent, due_at, sort_order, completed_at, created_at, updated_at)
+			 VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, (SELECT COALESCE(MAX(sort_order), 0) + 1 FROM tasks), ?, ?, ?)`,
+			t.GroupID, t.ParentID, t.Title, preview, boolTo01Int(overflow), ContentFormatPlain, string(t.Status),
+			boolTo01Int(t.Important), boolTo01Int(t.Urgent), t.DueAt, completedAtFor(t.Status, now), now, now,
+		)
+		if err != nil {
+			return TaskImportSummary{}, fmt.Errorf("import csv task: %w", err)
+		}
+		id, err := res.LastInsertId()
+		if err != nil {
+			return TaskImportSummary{}, fmt.Errorf("import csv task: %w", err)
+		}
+		if err := saveContentOverflow(ctx, tx, id, t.Content, overflow); err != nil {
+			return TaskImportSummary{}, err
+		}
+		if t.ParentID > 0 && !slices.Contains(parents, t.ParentID) {
+			parents = append(parents, t.ParentID)
+		}
+		if fileID := get(CSVFieldID); fileID != "" && t.ParentID == 0 {
+			fileIDs[fileID] = csvParent{id: id, groupID: t.GroupID}
+		}
+		out.Created++
+	}
+	if err := tx.Commit(); err != nil {
+		return TaskImportSummary{}, fmt.Errorf("commit import csv: %w", err)
+	}
+
+	for _, id := range parents {
+		if err := s.syncParentStatus(ctx, id, now); err != nil {
+			return TaskImportSummary{}, err
+		}
+	}
+	return out, nil
+}
+
+// csvParent 为 CSV 中可作为父任务的一行（只有主任务能作为父任务）。
+type csvParent struct {
+	id      int64
+	groupID int64
+}
+
+// csvColumns 返回各字段所在的列下标（-1 表示文件中没有该列）。
+func csvColumns(header []string, mapping map[string]string) (map[string]int, error) {
+	index := make(map[string]int, len(header))
+	for i, h := range header {
+		key := strings.ToLower(strings.TrimSpace(h))
+		if _, ok := index[key]; !ok {
+			index[key] = i
+		}
+	}
+
+	cols := make(map[string]int, len(csvFields))
+	for _, field := range csvFields {
+		cols[field] = -1
+	}
+	for field, name := range mapping {
+		if _, ok := cols[field]; !ok {
+			return nil, ErrInvalidCSVField.with(field)
+		}
+		if strings.TrimSpace(name) == "" {
+			continue
+		}
+		c, ok := index[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return nil, ErrCSVColumnNotFound.with(name)
+		}
+		cols[field] = c
+	}
+	for _, field := range csvFields {
+		if cols[field] >= 0 || strings.TrimSpace(mapping[field]) != "" {
+			continue
+		}
+		names := append([]string{field}, csvFieldAliases[field]...)
+		for _, lang := range i18n.Supported() {
+			names = append(names, strings.ToLower(i18n.T(lang, "report.col."+field)))
+		}
+		for _, name := range names {
+			if c, ok := index[name]; ok {
+				cols[field] = c
+				break
+			}
+		}
+	}
+	if cols[CSVFieldTitle] < 0 {
+		return nil, ErrCSVNoTitleColumn
+	}
+	return cols, nil
+}
+
+// parseCSVTask 校验并解析一行中的任务字段，返回任务与"分组"列的值。
+func parseCSVTask(get func(string) string, settings Settings, loc *time.Location) (Task, string, error) {
+	t := Task{Title: get(CSVFieldTitle), Content: get(CSVFieldContent)}
+	if t.Title == "" {
+		return Task{}, "", ErrTaskTitleEmpty
+	}
+	var err error
+	if t.Title, _, err = applyLengthLimit(LengthFieldTitle, t.Title, settings.TitleLimit, settings.LengthLimitMode, ErrTaskTitleTooLong); err != nil {
+		return Task{}, "", err
+	}
+	if t.Content, _, err = applyLengthLimit(LengthFieldContent, t.Content, settings.ContentLimit, settings.LengthLimitMode, ErrTaskContentTooLong); err != nil {
+		return Task{}, "", err
+	}
+	if t.Status, err = parseCSVStatus(get(CSVFieldStatus)); err != nil {
+		return Task{}, "", err
+	}
+	if t.Important, err = parseCSVBool(get(CSVFieldImportant)); err != nil {
+		return Task{}, "", err
+	}
+	if t.Urgent, err = parseCSVBool(get(CSVFieldUrgent)); err != nil {
+		return Task{}, "", err
+	}
+	if v := get(CSVFieldDue); v != "" {
+		due, err := parseCSVTime(v, loc)
+		if err != nil {
+			return Task{}, "", err
+		}
+		t.DueAt = due.UnixMilli()
+	}
+	return t, get(CSVFieldGroup), nil
+}
+
+// parseCSVStatus 解析状态列：接受状态值（todo/doing/done）与各语言导出文件中的状态名，空值为待办。
+func parseCSVStatus(v string) (Status, error) {
+	if v == "" {
+		return StatusTodo, nil
+	}
+	for _, st := range []Status{StatusTodo, StatusDoing, StatusDone} {
+		if strings.EqualFold(v, string(st)) {
+			return st, nil
+		}
+		for _, lang := range i18n.Supported() {
+			if strings.EqualFold(v, i18n.T(lang, "report.status."+string(st))) {
+				return st, nil
+			}
+		}
+	}
+	return "", ErrInvalidStatus.with(v)
+}
+
+// parseCSVBool 解析"重要/紧急"列：true/false、1/0、yes/no、是/否 等，空值为 false。
+func parseCSVBool(v string) (bool, error) {
+	switch strings.ToLower(v) {
+	case "", "0", "false", "no", "n", "否":
+		return false, nil
+	case "1", "true", "yes", "y", "x", "是", "✓":
+		return true, nil
+	default:
+		return false, ErrInvalidCSVBool.with(v)
+	}
+}
+
+// parseCSVTime 按 csvTimeLayouts 解析时间（不带时区的格式按 loc 解释）。
+func parseCSVTime(v string, loc *time.Location) (time.Time, error) {
+	for _, layout := range csvTimeLayouts {
+		if t, err := time.ParseInLocation(layout, v, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, ErrInvalidDate.with(v)
+}
+
+// importGroups 返回导入的默认分组（groupID 为 0 时为第一个分组）与已有分组的比较键（见 groupNameKey）到 ID 的映射。
+func (s *Store) importGroups(ctx context.Context, groupID int64) (int64, map[string]int64, error) {
+	if groupID < 0 {
+		return 0, nil, ErrInvalidGroupID
+	}
+	groups, err := s.ListGroups(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+	groupIDs := make(map[string]int64, len(groups))
+	for _, g := range groups {
+		groupIDs[groupNameKey(canonicalGroupName(g.Name))] = g.ID
+		if groupID == 0 {
+			groupID = g.ID
+		}
+	}
+	ok, err := s.groupExists(ctx, groupID)
+	if err != nil {
+		return 0, nil, err
+	}
+	if !ok {
+		return 0, nil, ErrGroupNotFound.with(groupID)
+	}
+	return groupID, groupIDs, nil
+}
+
+// importGroup 返回名为 name 的分组 ID（同名判断同 findGroupByName）；不存在时在 tx 中新建并计入 out.CreatedGroups。
+func importGroup(ctx context.Context, tx *sql.Tx, groupIDs map[string]int64, name string, settings Settings, now int64, out *TaskImportSummary) (int64, error) {
+	name, err := normalizeGroupName(name, settings.GroupNameLimit)
+	if err != nil {
+		return 0, err
+	}
+	key := groupNameKey(name)
+	if id, ok := groupIDs[key]; ok {
+		return id, nil
+	}
+	res, err := tx.ExecContext(ctx,
+		`INSERT INTO groups(name, sort_order, created_at, updated_at) VALUES(?, `+nextGroupOrderSQL+`, ?, ?)`, name, now, now)
+	if err != nil {
+		return 0, fmt.Errorf("create group: %w", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("create group: %w", err)
+	}
+	groupIDs[key] = id
+	out.CreatedGroups++
+	return id, nil
+}
+
+// taskDedupKey 返回导入时判断任务是否已存在的键：同一分组、同一父任务下标题与截止时间都相同。
+func taskDedupKey(groupID, parentID int64, title string, dueAt int64) string {
+	return fmt.Sprintf("%d/%d/%d/%s", groupID, parentID, dueAt, title)
+}
+
+// unescapeCSVCell 去掉导出时为防止 CSV 注入而加在公式字符前的 "'"（见 report.TasksCSV）。
+func unescapeCSVCell(v string) string {
+	if len(v) > 1 && v[0] == '\'' && strings.ContainsRune("=+-@\t\r", rune(v[1])) {
+		return strings.TrimSpace(v[1:])
+	}
+	return v
+}
+
+// existingTaskKeys 返回已有任务的 taskDedupKey 到任务 ID 的映射。
+func (s *Store) existingTaskKeys(ctx context.Context) (map[string]int64, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT id, group_id, parent_id, title, due_at FROM tasks`)
+	if err != nil {
+		return nil, fmt.Errorf("list existing tasks: %w", err)
+	}
+	defer rows.Close()
+	out := make(map[string]int64)
+	for rows.Next() {
+		var id, groupID, parentID, dueAt int64
+		var title string
+		if err := rows.Scan(&id, &groupID, &parentID, &title, &dueAt); err != nil {
+			return nil, fmt.Errorf("scan existing task: %w", err)
+		}
+		out[taskDedupKey(groupID, parentID, title, dueAt)] = id
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate existing tasks: %w", err)
+	}
+	return out, nil
+}
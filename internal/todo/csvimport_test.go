@@ -0,0 +1,90 @@
+package todo
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestImportCSVValidatesRows(t *testing.T) {
+	ctx := context.Background()
+	s := openTestStore(t)
+	groupID := firstGroupID(t, s)
+
+	data := "id,parent,title,status,important,due\n" +
+		"1,,ok,todo,true,2026-01-02\n" +
+		"2,,,todo,,\n" +
+		"3,,bad status,later,,\n" +
+		"4,,bad flag,,maybe,\n" +
+		"5,,bad due,,,tomorrow\n" +
+		",9,orphan,,,\n" +
+		",1,child,done,,\n" +
+		",,,,,\n"
+	sum, err := s.ImportCSV(ctx, []byte(data), groupID, nil)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if sum.Created != 2 || sum.Skipped != 1 || sum.Errored != 5 {
+		t.Errorf("summary = %+v, want 2 created, 1 skipped, 5 errored", sum)
+	}
+	want := []struct {
+		row int
+		err error
+	}{
+		{3, ErrTaskTitleEmpty},
+		{4, ErrInvalidStatus},
+		{5, ErrInvalidCSVBool},
+		{6, ErrInvalidDate},
+		{7, ErrParentTaskNotFound},
+	}
+	for i, w := range want {
+		if i >= len(sum.Errors) {
+			break
+		}
+		if e := sum.Errors[i]; e.Row != w.row || !errors.Is(e.Err, w.err) {
+			t.Errorf("error %d = row %d %v, want row %d %v", i, e.Row, e.Err, w.row, w.err)
+		}
+	}
+}
+
+func TestImportCSVDedupsOnlyExistingTasks(t *testing.T) {
+	ctx := context.Background()
+	s := openTestStore(t)
+	groupID := firstGroupID(t, s)
+	createTask(t, s, Task{GroupID: groupID, Title: "existing"})
+
+	// 已有的任务跳过；文件中本身重复的行都导入
+	data := "title\nexisting\nrepeated\nrepeated\n"
+	sum, err := s.ImportCSV(ctx, []byte(data), groupID, nil)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if sum.Created != 2 || sum.Skipped != 1 {
+		t.Errorf("summary = %+v, want 2 created, 1 skipped", sum)
+	}
+}
+
+func TestImportCSVUnescapesFormulaCells(t *testing.T) {
+	ctx := context.Background()
+	s := openTestStore(t)
+	groupID := firstGroupID(t, s)
+
+	data := "title,content\n'=SUM(A1),'+1\n'plain,x\n"
+	if _, err := s.ImportCSV(ctx, []byte(data), groupID, nil); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	tasks, err := s.ListTasks(ctx, SortUpdated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	titles := map[string]string{}
+	for _, task := range tasks {
+		titles[task.Title] = task.Content
+	}
+	if content, ok := titles["=SUM(A1)"]; !ok || content != "+1" {
+		t.Errorf("formula cell not unescaped: %v", titles)
+	}
+	if _, ok := titles["'plain"]; !ok {
+		t.Errorf("ordinary leading quote dropped: %v", titles)
+	}
+}
@@ -0,0 +1,718 @@
+package todo
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	sqlitelib "modernc.org/sqlite/lib"
+)
+
+const (
+	// DataExportFormat 标识 JSON 数据导出文件。
+	DataExportFormat = "spark-todo"
+	// DataExportVersion 为当前导出文件的结构版本；结构不兼容地变化时递增。
+	// 版本 2 增加了备注、重复规则、提醒与任务依赖，仍可导入版本 1 的文件。
+	DataExportVersion = 2
+)
+
+// 导入方式（ImportData 的 mode）。
+const (
+	ImportModeMerge   = "merge"   // 合并：保留现有数据，同名分组合并，已导入过的任务跳过
+	ImportModeReplace = "replace" // 替换：清空现有分组、任务与标签后导入，并恢复导出时的设置
+)
+
+// DataExport 为整个数据库的 JSON 导出文档（分组、任务、标签、备注、重复规则、提醒、任务依赖与设置）。
+//
+// Tasks 为扁平列表（子任务通过 ParentID 指向父任务），Content 为完整内容，Tags 为任务上的标签名；
+// 备注等通过 TaskID 指向 Tasks 中的任务。附件文件、目标、计时记录与积分不包含在内（见 ReplaceImportLoss）。
+type DataExport struct {
+	Format       string           `json:"format"`  // 固定为 DataExportFormat
+	Version      int              `json:"version"` // DataExportVersion
+	ExportedAt   int64            `json:"exportedAt"`
+	Settings     Settings         `json:"settings"`
+	Groups       []Group          `json:"groups"`
+	Tasks        []Task           `json:"tasks"`
+	Tags         []string         `json:"tags"` // 全部标签名（含未被任务使用的）
+	Notes        []TaskNote       `json:"notes"`
+	Recurrences  []Recurrence     `json:"recurrences"`
+	Reminders    []Reminder       `json:"reminders"`
+	Dependencies []TaskDependency `json:"dependencies"`
+}
+
+// TaskDependency 为一条任务依赖：TaskID 被 BlockedByID 阻塞（见 AddTaskDependency）。
+type TaskDependency struct {
+	TaskID      int64 `json:"taskId"`
+	BlockedByID int64 `json:"blockedById"`
+}
+
+// importExcludedScopes 为导入时不恢复的设置分类：更新源、全局快捷键与本地接口会影响下载安装的程序、
+// 系统级按键和本机开放的端口，不能由一个外来文件改写，导入后保留当前值。
+var importExcludedScopes = []string{SettingsScopeUpdate, SettingsScopeHotkeys, SettingsScopeAPI}
+
+// ImportSummary 为一次导入的结果。
+type ImportSummary struct {
+	Mode          string `json:"mode"`
+	Groups        int    `json:"groups"`        // 新建的分组数
+	MergedGroups  int    `json:"mergedGroups"`  // 合并到同名已有分组的分组数（仅合并导入）
+	Tasks         int    `json:"tasks"`         // 导入的任务数（含子任务）
+	SkippedTasks  int    `json:"skippedTasks"`  // 已存在而跳过的任务数（仅合并导入）
+	Tags          int    `json:"tags"`          // 文件中的标签数
+	SettingsSaved bool   `json:"settingsSaved"` // 是否恢复了设置（仅替换导入）
+	// SkippedSettings 为文件中与当前值不同、但出于安全考虑未导入的设置项（见 importExcludedScopes）
+	SkippedSettings []string `json:"skippedSettings"`
+	// Truncated 为标题或内容略超上限、按 lengthLimitMode 截断的任务数
+	Truncated int `json:"truncated"`
+}
+
+// ImportLoss 为替换导入会删除、且导出文件无法带回的现有数据的数量，供导入前提示用户确认。
+type ImportLoss struct {
+	Attachments int `json:"attachments"` // 任务附件（附件文件不在 JSON 导出中）
+	GoalLinks   int `json:"goalLinks"`   // 目标与任务的关联（目标本身保留）
+}
+
+// ReplaceImportLoss 统计替换导入（ImportModeReplace）将丢失的现有数据。
+func (s *Store) ReplaceImportLoss(ctx context.Context) (ImportLoss, error) {
+	var out ImportLoss
+	if err := s.db.QueryRowContext(ctx,
+		`SELECT (SELECT COUNT(*) FROM attachments), (SELECT COUNT(*) FROM goal_tasks)`,
+	).Scan(&out.Attachments, &out.GoalLinks); err != nil {
+		return ImportLoss{}, fmt.Errorf("count replace import loss: %w", err)
+	}
+	return out, nil
+}
+
+// queryer 为 *sql.DB（retryDB）与 *sql.Tx 的公共查询接口。
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// queryAll 执行 query 并用 scan 读取全部行。
+func queryAll[T any](ctx context.Context, q queryer, scan func(rowScanner) (T, error), query string, args ...any) ([]T, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := []T{}
+	for rows.Next() {
+		v, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
+
+// scanDependency 读取一行 task_id, blocked_by_id。
+func scanDependency(r rowScanner) (TaskDependency, error) {
+	var d TaskDependency
+	err := r.Scan(&d.TaskID, &d.BlockedByID)
+	return d, err
+}
+
+// ExportData 导出整个数据库的 JSON 文档：全部分组（含已归档）、任务（含完整内容与标签）、标签与设置。
+func (s *Store) ExportData(ctx context.Context, now time.Time) ([]byte, error) {
+	settings, err := s.GetSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := s.ListGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := s.queryTasks(ctx, `SELECT `+taskColumns+` FROM tasks ORDER BY parent_id > 0, sort_order, id`)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.LoadFullContent(ctx, tasks); err != nil {
+		return nil, err
+	}
+	if err := s.FillTags(ctx, tasks); err != nil {
+		return nil, err
+	}
+	tags, err := s.ListTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	notes, err := queryAll(ctx, s.db, scanNote, `SELECT `+noteColumns+` FROM task_notes ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("export notes: %w", err)
+	}
+	recurrences, err := queryAll(ctx, s.db, scanRecurrence, `SELECT `+recurrenceColumns+` FROM task_recurrence ORDER BY task_id`)
+	if err != nil {
+		return nil, fmt.Errorf("export recurrences: %w", err)
+	}
+	reminders, err := queryAll(ctx, s.db, scanReminder,
+		`SELECT `+reminderColumns+` FROM reminders r JOIN tasks t ON t.id = r.task_id ORDER BY r.id`)
+	if err != nil {
+		return nil, fmt.Errorf("export reminders: %w", err)
+	}
+	deps, err := queryAll(ctx, s.db, scanDependency, `SELECT task_id, blocked_by_id FROM task_dependencies ORDER BY task_id, blocked_by_id`)
+	if err != nil {
+		return nil, fmt.Errorf("export dependencies: %w", err)
+	}
+
+	doc := DataExport{
+		Format:       DataExportFormat,
+		Version:      DataExportVersion,
+		ExportedAt:   now.UnixMilli(),
+		Settings:     settings,
+		Groups:       groups,
+		Tasks:        tasks,
+		Tags:         make([]string, len(tags)),
+		Notes:        notes,
+		Recurrences:  recurrences,
+		Reminders:    reminders,
+		Dependencies: deps,
+	}
+	for i, t := range tags {
+		doc.Tags[i] = t.Name
+	}
+	for i := range doc.Tasks {
+		doc.Tasks[i].ContentTruncated = false
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encode data export: %w", err)
+	}
+	return data, nil
+}
+
+// ImportData 从 ExportData 生成的 JSON 文档导入数据，mode 为 ImportModeMerge（默认）或 ImportModeReplace。
+//
+// 导入前先完整校验文档（格式与版本、分组名、任务标题/内容长度/状态/颜色/链接、分组与任务引用、备注与重复规则），
+// 任一项不合法时返回对应错误，不做任何修改；写入在单个事务中完成，失败时整体回滚。
+// 标题与内容按 lengthLimitMode 处理：替换导入按文件中的上限，合并导入按当前上限。
+// 替换导入沿用文件中的分组与任务 ID，并恢复设置（importExcludedScopes 中的除外），
+// 现有的附件与目标关联会丢失，调用前应通过 ReplaceImportLoss 提示用户；合并导入为新数据分配新 ID，
+// 已存在而跳过的任务不导入其备注、重复规则与提醒。
+func (s *Store) ImportData(ctx context.Context, data []byte, mode string) (ImportSummary, error) {
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	if mode == "" {
+		mode = ImportModeMerge
+	}
+	if mode != ImportModeMerge && mode != ImportModeReplace {
+		return ImportSummary{}, ErrInvalidImportMode.with(mode)
+	}
+
+	current, err := s.GetSettings(ctx)
+	if err != nil {
+		return ImportSummary{}, err
+	}
+	// 旧版本导出的文件可能缺少新的设置项，缺少的保留当前值
+	doc := DataExport{Settings: current}
+	if err := json.Unmarshal(data, &doc); err != nil || doc.Format != DataExportFormat {
+		return ImportSummary{}, ErrInvalidImportFile
+	}
+	if doc.Version < 1 || doc.Version > DataExportVersion {
+		return ImportSummary{}, ErrUnsupportedImportVersion.with(doc.Version)
+	}
+	plan, err := s.planImport(ctx, &doc, mode, current)
+	if err != nil {
+		return ImportSummary{}, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return ImportSummary{}, fmt.Errorf("begin import data: %w", err)
+	}
+	defer tx.Rollback()
+
+	summary, err := plan.apply(ctx, tx, &doc)
+	if err != nil {
+		return ImportSummary{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return ImportSummary{}, fmt.Errorf("commit import data: %w", err)
+	}
+	return summary, nil
+}
+
+// importPlan 为校验通过后的导入计划（在事务外准备好，事务内只做写入）。
+type importPlan struct {
+	mode string
+	// settings 为替换导入时按 settingsSchema 编码好的设置值
+	settings []string
+	// skippedSettings / truncated 见 ImportSummary
+	skippedSettings []string
+	truncated       int
+	// groupIDs 为合并导入时文件中分组 ID 到已有同名分组 ID 的映射
+	groupIDs map[int64]int64
+	// existingTasks 为合并导入时已有任务的去重键（见 importTaskKey）到任务 ID 的映射
+	existingTasks map[string]int64
+}
+
+// importTaskKey 返回合并导入时判断任务是否已导入过的键：同一分组、同一父任务下标题与创建时间都相同。
+func importTaskKey(groupID, parentID int64, title string, createdAt int64) string {
+	return fmt.Sprintf("%d/%d/%d/%s", groupID, parentID, createdAt, title)
+}
+
+// planImport 校验并规范化 doc 中的数据，准备导入计划。
+func (s *Store) planImport(ctx context.Context, doc *DataExport, mode string, current Settings) (*importPlan, error) {
+	plan := &importPlan{mode: mode, groupIDs: map[int64]int64{}, existingTasks: map[string]int64{}, skippedSettings: []string{}}
+
+	limits := current
+	if mode == ImportModeReplace {
+		if len(doc.Groups) == 0 {
+			return nil, ErrInvalidImportFile
+		}
+		plan.settings = make([]string, len(settingsSchema))
+		for i, d := range settingsSchema {
+			v, err := d.encode(&doc.Settings)
+			if err != nil {
+				return nil, err
+			}
+			if slices.Contains(importExcludedScopes, d.scope) {
+				cur, err := d.encode(&current)
+				if err != nil {
+					return nil, err
+				}
+				if v != cur {
+					plan.skippedSettings = append(plan.skippedSettings, d.key)
+				}
+				v = cur
+			}
+			plan.settings[i] = v
+		}
+		limits = doc.Settings
+	}
+
+	fileGroups := map[int64]bool{}
+	names := map[string]bool{}
+	for i := range doc.Groups {
+		g := &doc.Groups[i]
+		if g.ID <= 0 || fileGroups[g.ID] {
+			return nil, ErrImportBrokenReference.with(g.ID)
+		}
+		fileGroups[g.ID] = true
+		name, err := normalizeGroupName(g.Name, current.GroupNameLimit)
+		if err != nil {
+			return nil, err
+		}
+		if names[groupNameKey(name)] {
+			return nil, ErrGroupNameTaken
+		}
+		names[groupNameKey(name)] = true
+		g.Name = name
+		if g.Color, err = normalizeHexColor(g.Color); err != nil {
+			return nil, err
+		}
+		if g.Icon, err = normalizeTaskEmoji(g.Icon); err != nil {
+			return nil, err
+		}
+	}
+	for _, g := range doc.Groups {
+		if g.ParentID != 0 && !fileGroups[g.ParentID] {
+			return nil, ErrImportBrokenReference.with(g.ParentID)
+		}
+	}
+
+	fileTasks := map[int64]Task{}
+	for i := range doc.Tasks {
+		t := &doc.Tasks[i]
+		if t.ID <= 0 {
+			return nil, ErrInvalidTaskID
+		}
+		if _, dup := fileTasks[t.ID]; dup {
+			return nil, ErrImportBrokenReference.with(t.ID)
+		}
+		if !fileGroups[t.GroupID] {
+			return nil, ErrImportBrokenReference.with(t.GroupID)
+		}
+		if t.Title = strings.TrimSpace(t.Title); t.Title == "" {
+			return nil, ErrTaskTitleEmpty
+		}
+		t.Content = strings.TrimSpace(t.Content)
+		var err error
+		truncated := false
+		for _, f := range []struct {
+			field   string
+			value   *string
+			limit   int
+			tooLong *Error
+		}{
+			{LengthFieldTitle, &t.Title, limits.TitleLimit, ErrTaskTitleTooLong},
+			{LengthFieldContent, &t.Content, limits.ContentLimit, ErrTaskContentTooLong},
+		} {
+			v, w, err := applyLengthLimit(f.field, *f.value, f.limit, limits.LengthLimitMode, f.tooLong)
+			if err != nil {
+				return nil, err
+			}
+			*f.value = v
+			truncated = truncated || (w != nil && w.Truncated)
+		}
+		if truncated {
+			plan.truncated++
+		}
+		if _, err = ParseStatus(string(t.Status)); err != nil {
+			return nil, err
+		}
+		if t.ContentFormat, err = ParseContentFormat(t.ContentFormat); err != nil {
+			return nil, err
+		}
+		if t.Color, err = normalizeHexColor(t.Color); err != nil {
+			return nil, err
+		}
+		if t.Emoji, err = normalizeTaskEmoji(t.Emoji); err != nil {
+			return nil, err
+		}
+		if t.URL, err = normalizeTaskURL(t.URL); err != nil {
+			return nil, err
+		}
+		for j, name := range t.Tags {
+			if t.Tags[j], err = normalizeTagName(name); err != nil {
+				return nil, err
+			}
+		}
+		if t.Status != StatusDone {
+			t.CompletedAt = 0
+		}
+		fileTasks[t.ID] = *t
+	}
+	for _, t := range doc.Tasks {
+		// 只支持一层子任务：父任务必须是同一文件中的主任务
+		if t.ParentID == 0 {
+			continue
+		}
+		if p, ok := fileTasks[t.ParentID]; !ok || p.ParentID != 0 {
+			return nil, ErrImportBrokenReference.with(t.ParentID)
+		}
+	}
+	if err := validateImportExtras(doc, fileTasks); err != nil {
+		return nil, err
+	}
+	// 主任务先插入，子任务才能找到父任务的新 ID
+	sort.SliceStable(doc.Tasks, func(i, j int) bool { return doc.Tasks[i].ParentID == 0 && doc.Tasks[j].ParentID != 0 })
+	for i, name := range doc.Tags {
+		var err error
+		if doc.Tags[i], err = normalizeTagName(name); err != nil {
+			return nil, err
+		}
+	}
+
+	if mode == ImportModeMerge {
+		existing, err := s.ListGroups(ctx)
+		if err != nil {
+			return nil, err
+		}
+		byName := map[string]int64{}
+		for _, g := range existing {
+			byName[groupNameKey(canonicalGroupName(g.Name))] = g.ID
+		}
+		for _, g := range doc.Groups {
+			if id, ok := byName[groupNameKey(g.Name)]; ok {
+				plan.groupIDs[g.ID] = id
+			}
+		}
+		rows, err := s.db.QueryContext(ctx, `SELECT id, group_id, parent_id, title, created_at FROM tasks`)
+		if err != nil {
+			return nil, fmt.Errorf("list existing tasks: %w", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var id, groupID, parentID, createdAt int64
+			var title string
+			if err := rows.Scan(&id, &groupID, &parentID, &title, &createdAt); err != nil {
+				return nil, fmt.Errorf("scan existing task: %w", err)
+			}
+			plan.existingTasks[importTaskKey(groupID, parentID, title, createdAt)] = id
+		}
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("iterate existing tasks: %w", err)
+		}
+	}
+	return plan, nil
+}
+
+// apply 在事务 tx 中按计划写入 doc。
+func (p *importPlan) apply(ctx context.Context, tx *sql.Tx, doc *DataExport) (ImportSummary, error) {
+	out := ImportSummary{Mode: p.mode, Tags: len(doc.Tags), SkippedSettings: p.skippedSettings, Truncated: p.truncated}
+	now := time.Now().UnixMilli()
+
+	if p.mode == ImportModeReplace {
+		// 任务、标签关联、备注等随分组/任务外键级联删除
+		for _, stmt := range []string{`DELETE FROM tasks`, `DELETE FROM groups`, `DELETE FROM tags`} {
+			if _, err := tx.ExecContext(ctx, stmt); err != nil {
+				return ImportSummary{}, fmt.Errorf("clear data: %w", err)
+			}
+		}
+		for i, d := range settingsSchema {
+			if _, err := tx.ExecContext(ctx,
+				`INSERT INTO settings(key, value) VALUES(?, ?)
+				 ON CONFLICT(key) DO UPDATE SET value = excluded.value`,
+				d.key, p.settings[i],
+			); err != nil {
+				return ImportSummary{}, fmt.Errorf("set setting %q: %w", d.key, err)
+			}
+		}
+		out.SettingsSaved = true
+	}
+
+	// 合并导入时 ID 与排序位置交给数据库分配（传 0，经 NULLIF 变为 NULL）
+	keepID := func(id int64) int64 {
+		if p.mode == ImportModeReplace {
+			return id
+		}
+		return 0
+	}
+
+	// 父分组可能排在子分组之后，parent_id 在插入全部分组后再补上；合并到已有分组的不改变其原有层级
+	groupIDs := p.groupIDs
+	var inserted []Group
+	for _, g := range doc.Groups {
+		if _, ok := groupIDs[g.ID]; ok {
+			out.MergedGroups++
+			continue
+		}
+		res, err := tx.ExecContext(ctx,
+			`INSERT INTO groups(id, name, parent_id, archived, favorite, color, icon, sort_order, created_at, updated_at)
+			 VALUES(NULLIF(?, 0), ?, 0, ?, ?, ?, ?, COALESCE(NULLIF(?, 0), `+nextGroupOrderSQL+`), ?, ?)`,
+			keepID(g.ID), g.Name, boolTo01Int(g.Archived), boolTo01Int(g.Favorite), g.Color, g.Icon,
+			keepID(g.SortOrder), max(g.CreatedAt, 0), max(g.UpdatedAt, g.CreatedAt, 0),
+		)
+		if err != nil {
+			if sqliteIsConstraint(err, sqlitelib.SQLITE_CONSTRAINT_UNIQUE) {
+				return ImportSummary{}, ErrGroupNameTaken
+			}
+			return ImportSummary{}, fmt.Errorf("import group: %w", err)
+		}
+		id, err := res.LastInsertId()
+		if err != nil {
+			return ImportSummary{}, fmt.Errorf("import group: %w", err)
+		}
+		groupIDs[g.ID] = id
+		inserted = append(inserted, g)
+		out.Groups++
+	}
+	for _, g := range inserted {
+		if g.ParentID == 0 {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE groups SET parent_id = ? WHERE id = ?`, groupIDs[g.ParentID], groupIDs[g.ID],
+		); err != nil {
+			return ImportSummary{}, fmt.Errorf("import group parent: %w", err)
+		}
+	}
+
+	taskIDs := map[int64]int64{}
+	created := map[int64]bool{} // 本次新插入的任务（按文件中的 ID），只为它们导入备注等
+	for _, t := range doc.Tasks {
+		groupID, parentID := groupIDs[t.GroupID], taskIDs[t.ParentID]
+		if p.mode == ImportModeMerge {
+			if id, ok := p.existingTasks[importTaskKey(groupID, parentID, t.Title, t.CreatedAt)]; ok {
+				taskIDs[t.ID] = id
+				out.SkippedTasks++
+				continue
+			}
+		}
+		preview, overflow := splitContent(t.Content)
+		res, err := tx.ExecContext(ctx,
+			`INSERT INTO tasks(id, group_id, parent_id, title, content, content_overflow, content_format, status, important, urgent, color, emoji, url, countdown, due_at, sort_order, completed_at, created_at, updated_at)
+			 VALUES(NULLIF(?, 0), ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, (SELECT COALESCE(MAX(sort_order), 0) + 1 FROM tasks), ?, ?, ?)`,
+			keepID(t.ID), groupID, parentID, t.Title, preview, boolTo01Int(overflow), t.ContentFormat, string(t.Status),
+			boolTo01Int(t.Important), boolTo01Int(t.Urgent), t.Color, t.Emoji, t.URL, boolTo01Int(t.Countdown), max(t.DueAt, 0),
+			max(t.CompletedAt, 0), max(t.CreatedAt, 0), max(t.UpdatedAt, t.CreatedAt, 0),
+		)
+		if err != nil {
+			return ImportSummary{}, fmt.Errorf("import task: %w", err)
+		}
+		id, err := res.LastInsertId()
+		if err != nil {
+			return ImportSummary{}, fmt.Errorf("import task: %w", err)
+		}
+		if err := saveContentOverflow(ctx, tx, id, t.Content, overflow); err != nil {
+			return ImportSummary{}, err
+		}
+		for _, name := range t.Tags {
+			if err := addTaskTag(ctx, tx, id, name, now); err != nil {
+				return ImportSummary{}, err
+			}
+		}
+		taskIDs[t.ID] = id
+		created[t.ID] = true
+		out.Tasks++
+	}
+	if err := importTaskExtras(ctx, tx, doc, taskIDs, created, now); err != nil {
+		return ImportSummary{}, err
+	}
+	for _, name := range doc.Tags {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO tags(name, created_at, updated_at) VALUES(?, ?, ?) ON CONFLICT(name) DO NOTHING`, name, now, now,
+		); err != nil {
+			return ImportSummary{}, fmt.Errorf("import tag: %w", err)
+		}
+	}
+	return out, nil
+}
+
+// addTaskTag 在事务中为任务添加标签 name（不存在时新建）。
+func addTaskTag(ctx context.Context, tx *sql.Tx, taskID int64, name string, now int64) error {
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO tags(name, created_at, updated_at) VALUES(?, ?, ?) ON CONFLICT(name) DO NOTHING`, name, now, now,
+	); err != nil {
+		return fmt.Errorf("create tag: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT OR IGNORE INTO task_tags(task_id, tag_id) SELECT ?, id FROM tags WHERE name = ?`, taskID, name,
+	); err != nil {
+		return fmt.Errorf("add task tag: %w", err)
+	}
+	return nil
+}
+
+// validateImportExtras 校验并规范化 doc 中的备注、重复规则、提醒与任务依赖：引用的任务都必须在文件中。
+func validateImportExtras(doc *DataExport, fileTasks map[int64]Task) error {
+	ref := func(id int64) error {
+		if _, ok := fileTasks[id]; !ok {
+			return ErrImportBrokenReference.with(id)
+		}
+		return nil
+	}
+	for i := range doc.Notes {
+		n := &doc.Notes[i]
+		if err := ref(n.TaskID); err != nil {
+			return err
+		}
+		var err error
+		if n.Body, err = normalizeNoteBody(n.Body); err != nil {
+			return err
+		}
+	}
+	recurring := map[int64]bool{}
+	for i := range doc.Recurrences {
+		r := &doc.Recurrences[i]
+		if err := ref(r.TaskID); err != nil {
+			return err
+		}
+		if fileTasks[r.TaskID].ParentID != 0 {
+			return ErrRecurSubTask
+		}
+		if recurring[r.TaskID] {
+			return ErrImportBrokenReference.with(r.TaskID)
+		}
+		recurring[r.TaskID] = true
+		var err error
+		if *r, err = r.normalize(); err != nil {
+			return err
+		}
+	}
+	reminded := map[int64]bool{}
+	for _, r := range doc.Reminders {
+		if err := ref(r.TaskID); err != nil {
+			return err
+		}
+		if reminded[r.TaskID] || r.RemindAt <= 0 {
+			return ErrImportBrokenReference.with(r.TaskID)
+		}
+		reminded[r.TaskID] = true
+	}
+	for _, d := range doc.Dependencies {
+		if err := ref(d.TaskID); err != nil {
+			return err
+		}
+		if err := ref(d.BlockedByID); err != nil {
+			return err
+		}
+		if d.TaskID == d.BlockedByID {
+			return ErrDependencySelf
+		}
+	}
+	return checkDependencyCycles(doc.Dependencies)
+}
+
+// checkDependencyCycles 检查 deps 中是否存在循环依赖。
+func checkDependencyCycles(deps []TaskDependency) error {
+	next := map[int64][]int64{}
+	for _, d := range deps {
+		next[d.TaskID] = append(next[d.TaskID], d.BlockedByID)
+	}
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := map[int64]int{}
+	var visit func(id int64) bool
+	visit = func(id int64) bool {
+		switch state[id] {
+		case visiting:
+			return false
+		case done:
+			return true
+		}
+		state[id] = visiting
+		for _, n := range next[id] {
+			if !visit(n) {
+				return false
+			}
+		}
+		state[id] = done
+		return true
+	}
+	for id := range next {
+		if !visit(id) {
+			return ErrDependencyCycle
+		}
+	}
+	return nil
+}
+
+// importTaskExtras 在事务 tx 中写入 doc 中新插入任务（created）的备注、重复规则与提醒，
+// 以及两端都是新插入任务的依赖；taskIDs 为文件中的任务 ID 到库中 ID 的映射。
+func importTaskExtras(ctx context.Context, tx *sql.Tx, doc *DataExport, taskIDs map[int64]int64, created map[int64]bool, now int64) error {
+	for _, n := range doc.Notes {
+		if !created[n.TaskID] {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO task_notes(task_id, body, created_at, updated_at) VALUES(?, ?, ?, ?)`,
+			taskIDs[n.TaskID], n.Body, max(n.CreatedAt, 0), max(n.UpdatedAt, n.CreatedAt, 0),
+		); err != nil {
+			return fmt.Errorf("import note: %w", err)
+		}
+	}
+	for _, r := range doc.Recurrences {
+		if !created[r.TaskID] {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO task_recurrence(task_id, freq, interval, mode, skip_holidays, lunar, weekdays, created_at, updated_at) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			taskIDs[r.TaskID], r.Freq, r.Interval, r.Mode, boolTo01Int(r.SkipHolidays), boolTo01Int(r.Lunar), formatWeekdays(r.Weekdays),
+			max(r.CreatedAt, 0), max(r.UpdatedAt, r.CreatedAt, 0),
+		); err != nil {
+			return fmt.Errorf("import recurrence: %w", err)
+		}
+	}
+	for _, r := range doc.Reminders {
+		if !created[r.TaskID] {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO reminders(task_id, remind_at, fired_at, created_at) VALUES(?, ?, ?, ?)`,
+			taskIDs[r.TaskID], r.RemindAt, max(r.FiredAt, 0), cmp.Or(max(r.CreatedAt, 0), now),
+		); err != nil {
+			return fmt.Errorf("import reminder: %w", err)
+		}
+	}
+	// 只连接两端都是新任务的依赖：文件中的依赖已检查过没有循环，也不会与已有任务之间的依赖形成循环
+	for _, d := range doc.Dependencies {
+		if !created[d.TaskID] || !created[d.BlockedByID] {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT OR IGNORE INTO task_dependencies(task_id, blocked_by_id, created_at) VALUES(?, ?, ?)`,
+			taskIDs[d.TaskID], taskIDs[d.BlockedByID], now,
+		); err != nil {
+			return fmt.Errorf("import dependency: %w", err)
+		}
+	}
+	return nil
+}
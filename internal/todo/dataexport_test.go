@@ -0,0 +1,191 @@
+package todo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// createTask 新建任务并在失败时终止测试。
+func createTask(t *testing.T, s *Store, task Task) Task {
+	t.Helper()
+	if task.Status == "" {
+		task.Status = StatusTodo
+	}
+	created, err := s.UpsertTask(context.Background(), task)
+	if err != nil {
+		t.Fatalf("create task %q: %v", task.Title, err)
+	}
+	return created
+}
+
+// exportDoc 导出当前数据并解码为 DataExport，便于测试修改后再导入。
+func exportDoc(t *testing.T, s *Store) DataExport {
+	t.Helper()
+	data, err := s.ExportData(context.Background(), time.Now())
+	if err != nil {
+		t.Fatalf("export data: %v", err)
+	}
+	var doc DataExport
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("decode export: %v", err)
+	}
+	return doc
+}
+
+// importDoc 编码 doc 并导入。
+func importDoc(s *Store, doc DataExport, mode string) (ImportSummary, error) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return ImportSummary{}, err
+	}
+	return s.ImportData(context.Background(), data, mode)
+}
+
+func TestImportDataReplaceRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	s := openTestStore(t)
+	groupID := firstGroupID(t, s)
+
+	content := strings.Repeat("内容", 800)
+	parent := createTask(t, s, Task{GroupID: groupID, Title: "parent", Content: content})
+	child := createTask(t, s, Task{GroupID: groupID, ParentID: parent.ID, Title: "child"})
+	other := createTask(t, s, Task{GroupID: groupID, Title: "other"})
+	if _, err := s.AddTaskNote(ctx, parent.ID, "a note"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.SetRecurrence(ctx, Recurrence{TaskID: parent.ID, Freq: RecurWeekly, Weekdays: []int{1, 3}}); err != nil {
+		t.Fatal(err)
+	}
+	remindAt := time.Now().Add(time.Hour).Truncate(time.Millisecond)
+	if _, err := s.SetTaskReminder(ctx, other.ID, remindAt); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddTaskDependency(ctx, other.ID, parent.ID); err != nil {
+		t.Fatal(err)
+	}
+	doc := exportDoc(t, s)
+
+	// 文件中的更新源与快捷键不应被导入
+	doc.Settings.UpdateURL = "https://evil.example.com/releases/latest"
+	doc.Settings.HotkeyShowWindow = "Ctrl+Alt+Q"
+	if _, err := s.UpsertTask(ctx, Task{GroupID: groupID, Title: "added after export", Status: StatusTodo}); err != nil {
+		t.Fatal(err)
+	}
+
+	summary, err := importDoc(s, doc, ImportModeReplace)
+	if err != nil {
+		t.Fatalf("replace import: %v", err)
+	}
+	if summary.Tasks != 3 || !summary.SettingsSaved {
+		t.Errorf("summary = %+v, want 3 tasks and settings saved", summary)
+	}
+	if !slices.Equal(summary.SkippedSettings, []string{"hotkeyShowWindow", "updateUrl"}) {
+		t.Errorf("skipped settings = %v", summary.SkippedSettings)
+	}
+	settings, err := s.GetSettings(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings.UpdateURL != "" || settings.HotkeyShowWindow != "" {
+		t.Errorf("security settings imported: updateUrl %q, hotkey %q", settings.UpdateURL, settings.HotkeyShowWindow)
+	}
+
+	if got, err := s.GetTaskContent(ctx, parent.ID); err != nil || got != content {
+		t.Errorf("full content lost: %d runes, %v", len([]rune(got)), err)
+	}
+	if notes, err := s.GetTaskNotes(ctx, parent.ID); err != nil || len(notes) != 1 || notes[0].Body != "a note" {
+		t.Errorf("notes = %+v, %v", notes, err)
+	}
+	if rec, err := s.GetRecurrence(ctx, parent.ID); err != nil || rec == nil || !slices.Equal(rec.Weekdays, []int{1, 3}) {
+		t.Errorf("recurrence = %+v, %v", rec, err)
+	}
+	if r, err := s.GetTaskReminder(ctx, other.ID); err != nil || r.RemindAt != remindAt.UnixMilli() {
+		t.Errorf("reminder = %+v, %v", r, err)
+	}
+	if deps, err := s.GetTaskDependencies(ctx, other.ID); err != nil || len(deps.BlockedBy) != 1 || deps.BlockedBy[0].ID != parent.ID {
+		t.Errorf("dependencies = %+v, %v", deps, err)
+	}
+	if _, err := s.GetTask(ctx, child.ID); err != nil {
+		t.Errorf("subtask lost: %v", err)
+	}
+}
+
+func TestImportDataMergeSkipsExtrasOfExistingTasks(t *testing.T) {
+	ctx := context.Background()
+	s := openTestStore(t)
+	task := createTask(t, s, Task{GroupID: firstGroupID(t, s), Title: "task"})
+	if _, err := s.AddTaskNote(ctx, task.ID, "only once"); err != nil {
+		t.Fatal(err)
+	}
+	doc := exportDoc(t, s)
+
+	summary, err := importDoc(s, doc, ImportModeMerge)
+	if err != nil {
+		t.Fatalf("merge import: %v", err)
+	}
+	if summary.Tasks != 0 || summary.SkippedTasks != 1 {
+		t.Errorf("summary = %+v, want the task skipped", summary)
+	}
+	if notes, err := s.GetTaskNotes(ctx, task.ID); err != nil || len(notes) != 1 {
+		t.Errorf("notes of skipped task = %+v, %v", notes, err)
+	}
+}
+
+func TestImportDataValidation(t *testing.T) {
+	s := openTestStore(t)
+	task := createTask(t, s, Task{GroupID: firstGroupID(t, s), Title: "task"})
+	other := createTask(t, s, Task{GroupID: task.GroupID, Title: "other"})
+	base := exportDoc(t, s)
+	limit := base.Settings.TitleLimit
+
+	cases := []struct {
+		name   string
+		mutate func(d *DataExport)
+		want   *Error
+	}{
+		{"bad format", func(d *DataExport) { d.Format = "other" }, ErrInvalidImportFile},
+		{"future version", func(d *DataExport) { d.Version = DataExportVersion + 1 }, ErrUnsupportedImportVersion},
+		{"missing group", func(d *DataExport) { d.Tasks[0].GroupID = 9999 }, ErrImportBrokenReference},
+		{"empty title", func(d *DataExport) { d.Tasks[0].Title = "  " }, ErrTaskTitleEmpty},
+		{"title far too long", func(d *DataExport) { d.Tasks[0].Title = strings.Repeat("x", 2*limit) }, ErrTaskTitleTooLong},
+		{"bad status", func(d *DataExport) { d.Tasks[0].Status = "later" }, ErrInvalidStatus},
+		{"note of missing task", func(d *DataExport) { d.Notes = []TaskNote{{TaskID: 9999, Body: "x"}} }, ErrImportBrokenReference},
+		{"bad recurrence", func(d *DataExport) { d.Recurrences = []Recurrence{{TaskID: task.ID, Freq: "hourly"}} }, ErrInvalidRecurFreq},
+		{"dependency cycle", func(d *DataExport) {
+			d.Dependencies = []TaskDependency{{TaskID: task.ID, BlockedByID: other.ID}, {TaskID: other.ID, BlockedByID: task.ID}}
+		}, ErrDependencyCycle},
+	}
+	for _, c := range cases {
+		doc := exportDoc(t, s)
+		c.mutate(&doc)
+		for _, mode := range []string{ImportModeMerge, ImportModeReplace} {
+			_, err := importDoc(s, doc, mode)
+			var got *Error
+			if !errors.As(err, &got) || got.Code != c.want.Code {
+				t.Errorf("%s (%s): err = %v, want %s", c.name, mode, err, c.want.Code)
+			}
+		}
+	}
+
+	// 无效的文件不做任何修改
+	if tasks, err := s.ListTasks(context.Background(), SortUpdated); err != nil || len(tasks) != 2 {
+		t.Errorf("tasks after rejected imports: %d, %v", len(tasks), err)
+	}
+
+	// 替换导入按文件中的 lengthLimitMode 处理略超上限的标题：截断并计数
+	doc := exportDoc(t, s)
+	doc.Settings.LengthLimitMode = LengthModeTruncate
+	doc.Tasks[0].Title = strings.Repeat("y", limit+1)
+	summary, err := importDoc(s, doc, ImportModeReplace)
+	if err != nil {
+		t.Fatalf("import slightly long title: %v", err)
+	}
+	if summary.Truncated != 1 {
+		t.Errorf("truncated = %d, want 1", summary.Truncated)
+	}
+}
@@ -0,0 +1,64 @@
+package todo
+
+import (
+	"fmt"
+	"time"
+)
+
+// 日期格式设置取值。
+const (
+	// DateFormatISO 固定使用 YYYY-MM-DD。
+	DateFormatISO = "iso"
+	// DateFormatLocale 跟随界面语言（例如 2024年3月5日 / Mar 5, 2024）。
+	DateFormatLocale = "locale"
+)
+
+// 每周第一天设置取值。
+const (
+	WeekStartMonday = "monday"
+	WeekStartSunday = "sunday"
+)
+
+// FormatDate 按用户的日期格式设置格式化日期（不含时间）。
+//
+// 后端生成的摘要、导出文件、周视图标题等统一经过这里，保证与前端显示一致。
+func FormatDate(t time.Time, settings Settings) string {
+	if settings.DateFormat != DateFormatLocale {
+		return t.Format("2006-01-02")
+	}
+	switch settings.Language {
+	case "en-US":
+		return t.Format("Jan 2, 2006")
+	default:
+		return fmt.Sprintf("%d年%d月%d日", t.Year(), int(t.Month()), t.Day())
+	}
+}
+
+// FormatDateTime 按用户的日期格式设置格式化日期与时间（精确到分钟）。
+func FormatDateTime(t time.Time, settings Settings) string {
+	return FormatDate(t, settings) + " " + t.Format("15:04")
+}
+
+// StartOfWeek 返回 t 所在周的第一天零点（按 weekStart 设置，时区沿用 t 的时区）。
+func StartOfWeek(t time.Time, settings Settings) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	first := time.Monday
+	if settings.WeekStart == WeekStartSunday {
+		first = time.Sunday
+	}
+	offset := (int(day.Weekday()) - int(first) + 7) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// Weekdays 返回按 weekStart 排序的一周七天。
+func Weekdays(settings Settings) []time.Weekday {
+	first := time.Monday
+	if settings.WeekStart == WeekStartSunday {
+		first = time.Sunday
+	}
+	out := make([]time.Weekday, 7)
+	for i := range out {
+		out[i] = time.Weekday((int(first) + i) % 7)
+	}
+	return out
+}
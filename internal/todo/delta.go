@@ -0,0 +1,137 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+)
+
+// 看板变更记录的对象类型（board_changes.kind）。
+const (
+	changeKindTask  = "task"
+	changeKindGroup = "group"
+)
+
+// nowMillisSQL 为触发器中使用的当前时间（UnixMilli）。
+const nowMillisSQL = `CAST((julianday('now') - 2440587.5) * 86400000 AS INTEGER)`
+
+// boardChangeTriggers 维护 board_changes：任务/分组的新增、修改、删除都会记录变化时间。
+//
+// 子任务删除或移到其他父任务时，原父任务也记为已变化，这样增量刷新拿到的父任务 SubTasks 是最新的；
+// 恢复回收站条目会重新插入原 ID，对应记录会从"已删除"变回"已变化"。
+// 任务依赖增删或前置任务状态变化时，被阻塞的任务也记为已变化（其 Blocked 可能随之改变）。
+var boardChangeTriggers = []string{
+	`CREATE TRIGGER IF NOT EXISTS trg_tasks_changes_insert AFTER INSERT ON tasks BEGIN
+		INSERT OR REPLACE INTO board_changes(kind, item_id, deleted, changed_at) VALUES('task', NEW.id, 0, ` + nowMillisSQL + `);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS trg_tasks_changes_update AFTER UPDATE ON tasks BEGIN
+		INSERT OR REPLACE INTO board_changes(kind, item_id, deleted, changed_at) VALUES('task', NEW.id, 0, ` + nowMillisSQL + `);
+		INSERT OR REPLACE INTO board_changes(kind, item_id, deleted, changed_at)
+			SELECT 'task', id, 0, ` + nowMillisSQL + ` FROM tasks WHERE id = OLD.parent_id AND OLD.parent_id <> NEW.parent_id;
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS trg_tasks_changes_delete AFTER DELETE ON tasks BEGIN
+		INSERT OR REPLACE INTO board_changes(kind, item_id, deleted, changed_at) VALUES('task', OLD.id, 1, ` + nowMillisSQL + `);
+		INSERT OR REPLACE INTO board_changes(kind, item_id, deleted, changed_at)
+			SELECT 'task', id, 0, ` + nowMillisSQL + ` FROM tasks WHERE id = OLD.parent_id;
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS trg_tasks_changes_blocking AFTER UPDATE OF status ON tasks WHEN OLD.status <> NEW.status BEGIN
+		INSERT OR REPLACE INTO board_changes(kind, item_id, deleted, changed_at)
+			SELECT 'task', task_id, 0, ` + nowMillisSQL + ` FROM task_dependencies WHERE blocked_by_id = NEW.id;
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS trg_task_dependencies_changes_insert AFTER INSERT ON task_dependencies BEGIN
+		INSERT OR REPLACE INTO board_changes(kind, item_id, deleted, changed_at) VALUES('task', NEW.task_id, 0, ` + nowMillisSQL + `);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS trg_task_dependencies_changes_delete AFTER DELETE ON task_dependencies BEGIN
+		INSERT OR REPLACE INTO board_changes(kind, item_id, deleted, changed_at)
+			SELECT 'task', id, 0, ` + nowMillisSQL + ` FROM tasks WHERE id = OLD.task_id;
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS trg_groups_changes_insert AFTER INSERT ON groups BEGIN
+		INSERT OR REPLACE INTO board_changes(kind, item_id, deleted, changed_at) VALUES('group', NEW.id, 0, ` + nowMillisSQL + `);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS trg_groups_changes_update AFTER UPDATE ON groups BEGIN
+		INSERT OR REPLACE INTO board_changes(kind, item_id, deleted, changed_at) VALUES('group', NEW.id, 0, ` + nowMillisSQL + `);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS trg_groups_changes_delete AFTER DELETE ON groups BEGIN
+		INSERT OR REPLACE INTO board_changes(kind, item_id, deleted, changed_at) VALUES('group', OLD.id, 1, ` + nowMillisSQL + `);
+	END`,
+}
+
+// BoardChanges 为 since 之后看板数据的变化。
+type BoardChanges struct {
+	Groups          []Group // 新增或修改过的分组（含已归档）
+	Tasks           []Task  // 自身或其子任务有变化的主任务（子任务挂在 SubTasks 下）；已归档分组下的任务除外
+	DeletedGroupIDs []int64
+	DeletedTaskIDs  []int64 // 含子任务
+}
+
+// ChangesSince 返回 since（UnixMilli）之后变化的分组与任务，以及已删除的 ID。
+//
+// 取消归档的分组下的任务即使自身没有变化也会一并返回，因为前端此前并未持有它们。
+// 主任务按 sort 排序（见 Sort* 常量）。
+func (s *Store) ChangesSince(ctx context.Context, since int64, sort string) (BoardChanges, error) {
+	sort, err := ParseSortMode(sort)
+	if err != nil {
+		return BoardChanges{}, err
+	}
+	out := BoardChanges{Groups: []Group{}, DeletedGroupIDs: []int64{}, DeletedTaskIDs: []int64{}}
+
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+groupColumns+` FROM groups
+		 WHERE id IN (SELECT item_id FROM board_changes WHERE kind = 'group' AND deleted = 0 AND changed_at > ?)
+		 ORDER BY `+groupOrderSQL, since)
+	if err != nil {
+		return BoardChanges{}, fmt.Errorf("list changed groups: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		g, err := scanGroup(rows)
+		if err != nil {
+			return BoardChanges{}, err
+		}
+		out.Groups = append(out.Groups, g)
+	}
+	if err := rows.Err(); err != nil {
+		return BoardChanges{}, fmt.Errorf("iterate changed groups: %w", err)
+	}
+
+	out.Tasks, err = s.queryTasks(ctx,
+		`SELECT `+taskColumns+` FROM tasks
+		 WHERE parent_id = 0 AND `+activeGroupSQL+` AND (
+			id IN (SELECT CASE WHEN parent_id = 0 THEN id ELSE parent_id END FROM tasks
+			       WHERE id IN (SELECT item_id FROM board_changes WHERE kind = 'task' AND deleted = 0 AND changed_at > ?))
+			OR group_id IN (SELECT item_id FROM board_changes WHERE kind = 'group' AND deleted = 0 AND changed_at > ?)
+		 )
+		 ORDER BY `+sortOrderSQL[sort], since, since)
+	if err != nil {
+		return BoardChanges{}, err
+	}
+	if err := s.attachSubTasks(ctx, out.Tasks); err != nil {
+		return BoardChanges{}, err
+	}
+	if err := s.FillBlocked(ctx, out.Tasks); err != nil {
+		return BoardChanges{}, err
+	}
+
+	deleted, err := s.db.QueryContext(ctx,
+		`SELECT kind, item_id FROM board_changes WHERE deleted = 1 AND changed_at > ? ORDER BY item_id`, since)
+	if err != nil {
+		return BoardChanges{}, fmt.Errorf("list deletions: %w", err)
+	}
+	defer deleted.Close()
+	for deleted.Next() {
+		var kind string
+		var id int64
+		if err := deleted.Scan(&kind, &id); err != nil {
+			return BoardChanges{}, fmt.Errorf("scan deletion: %w", err)
+		}
+		switch kind {
+		case changeKindGroup:
+			out.DeletedGroupIDs = append(out.DeletedGroupIDs, id)
+		case changeKindTask:
+			out.DeletedTaskIDs = append(out.DeletedTaskIDs, id)
+		}
+	}
+	if err := deleted.Err(); err != nil {
+		return BoardChanges{}, fmt.Errorf("iterate deletions: %w", err)
+	}
+	return out, nil
+}
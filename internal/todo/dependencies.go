@@ -0,0 +1,154 @@
+package todo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// TaskDependencies 为任务的前置关系。
+type TaskDependencies struct {
+	BlockedBy []Task `json:"blockedBy"` // 本任务的前置任务（需先完成）
+	Blocking  []Task `json:"blocking"`  // 以本任务为前置的任务
+}
+
+// AddTaskDependency 将 blockedByID 设为任务 taskID 的前置任务（taskID 被 blockedByID 阻塞）；已存在时不做任何事。
+//
+// 任务不能依赖自己（ErrDependencySelf），也不能形成循环依赖（ErrDependencyCycle）。
+func (s *Store) AddTaskDependency(ctx context.Context, taskID, blockedByID int64) error {
+	if taskID == blockedByID {
+		return ErrDependencySelf
+	}
+	for _, id := range []int64{taskID, blockedByID} {
+		if _, err := s.GetTask(ctx, id); err != nil {
+			return err
+		}
+	}
+	cycle, err := dependencyCreatesCycle(ctx, s.db, taskID, blockedByID)
+	if err != nil {
+		return err
+	}
+	if cycle {
+		return ErrDependencyCycle
+	}
+
+	if _, err := s.db.ExecContext(ctx,
+		`INSERT OR IGNORE INTO task_dependencies(task_id, blocked_by_id, created_at) VALUES(?, ?, ?)`,
+		taskID, blockedByID, time.Now().UnixMilli(),
+	); err != nil {
+		return fmt.Errorf("add task dependency: %w", err)
+	}
+	return nil
+}
+
+// dependencyCreatesCycle 报告新增依赖（taskID 被 blockedByID 阻塞）是否会成环：
+// blockedByID 已直接或间接依赖 taskID 时，再加这条边就成环了。
+func dependencyCreatesCycle(ctx context.Context, q queryer, taskID, blockedByID int64) (bool, error) {
+	rows, err := q.QueryContext(ctx,
+		`WITH RECURSIVE upstream(id) AS (
+		   SELECT blocked_by_id FROM task_dependencies WHERE task_id = ?
+		   UNION
+		   SELECT d.blocked_by_id FROM task_dependencies d JOIN upstream u ON d.task_id = u.id
+		 )
+		 SELECT 1 FROM upstream WHERE id = ? LIMIT 1`, blockedByID, taskID)
+	if err != nil {
+		return false, fmt.Errorf("check dependency cycle: %w", err)
+	}
+	defer rows.Close()
+	cycle := rows.Next()
+	if err := rows.Err(); err != nil {
+		return false, fmt.Errorf("check dependency cycle: %w", err)
+	}
+	return cycle, nil
+}
+
+// RemoveTaskDependency 取消任务 taskID 对 blockedByID 的依赖；依赖不存在时返回 ErrDependencyNotFound。
+func (s *Store) RemoveTaskDependency(ctx context.Context, taskID, blockedByID int64) error {
+	res, err := s.db.ExecContext(ctx,
+		`DELETE FROM task_dependencies WHERE task_id = ? AND blocked_by_id = ?`, taskID, blockedByID)
+	if err != nil {
+		return fmt.Errorf("remove task dependency: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrDependencyNotFound.with(taskID, blockedByID)
+	}
+	return nil
+}
+
+// GetTaskDependencies 返回任务 taskID 的前置任务与后续任务（各自按创建依赖的先后顺序，不含子任务）。
+func (s *Store) GetTaskDependencies(ctx context.Context, taskID int64) (TaskDependencies, error) {
+	if _, err := s.GetTask(ctx, taskID); err != nil {
+		return TaskDependencies{}, err
+	}
+	blockedBy, err := s.queryTasks(ctx,
+		`SELECT `+prefixColumns("t", taskColumns)+` FROM task_dependencies d JOIN tasks t ON t.id = d.blocked_by_id
+		 WHERE d.task_id = ? ORDER BY d.created_at, t.id`, taskID)
+	if err != nil {
+		return TaskDependencies{}, err
+	}
+	blocking, err := s.queryTasks(ctx,
+		`SELECT `+prefixColumns("t", taskColumns)+` FROM task_dependencies d JOIN tasks t ON t.id = d.task_id
+		 WHERE d.blocked_by_id = ? ORDER BY d.created_at, t.id`, taskID)
+	if err != nil {
+		return TaskDependencies{}, err
+	}
+	out := TaskDependencies{BlockedBy: blockedBy, Blocking: blocking}
+	if err := s.FillBlocked(ctx, out.BlockedBy); err != nil {
+		return TaskDependencies{}, err
+	}
+	if err := s.FillBlocked(ctx, out.Blocking); err != nil {
+		return TaskDependencies{}, err
+	}
+	return out, nil
+}
+
+// FillBlocked 为 tasks（含子任务）计算 Blocked：存在尚未完成的前置任务时为 true。
+func (s *Store) FillBlocked(ctx context.Context, tasks []Task) error {
+	var ids []int64
+	var collect func([]Task)
+	collect = func(ts []Task) {
+		for _, t := range ts {
+			ids = append(ids, t.ID)
+			collect(t.SubTasks)
+		}
+	}
+	collect(tasks)
+	if len(ids) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(ids)
+	if err != nil {
+		return err
+	}
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT DISTINCT d.task_id FROM task_dependencies d JOIN tasks b ON b.id = d.blocked_by_id
+		 WHERE d.task_id IN (SELECT value FROM json_each(?)) AND b.status <> ?`, string(data), StatusDone)
+	if err != nil {
+		return fmt.Errorf("query blocked tasks: %w", err)
+	}
+	defer rows.Close()
+
+	blocked := make(map[int64]bool)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return fmt.Errorf("scan blocked task: %w", err)
+		}
+		blocked[id] = true
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate blocked tasks: %w", err)
+	}
+
+	var fill func([]Task)
+	fill = func(ts []Task) {
+		for i := range ts {
+			ts[i].Blocked = blocked[ts[i].ID]
+			fill(ts[i].SubTasks)
+		}
+	}
+	fill(tasks)
+	return nil
+}
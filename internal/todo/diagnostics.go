@@ -0,0 +1,212 @@
+package todo
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"unicode/utf8"
+)
+
+// SchemaInfo 描述数据库结构与健康状况（不含任何用户内容）。
+type SchemaInfo struct {
+	SQLiteVersion string           `json:"sqliteVersion"`
+	JournalMode   string           `json:"journalMode"`
+	PageSize      int64            `json:"pageSize"`
+	PageCount     int64            `json:"pageCount"`
+	FreelistCount int64            `json:"freelistCount"`
+	QuickCheck    string           `json:"quickCheck"` // PRAGMA quick_check 结果，正常为 "ok"
+	Objects       []SchemaObject   `json:"objects"`
+	RowCounts     map[string]int64 `json:"rowCounts"`
+
+	// QueryPlans 为常用列表查询的 EXPLAIN QUERY PLAN 结果（查询名 -> 各步骤），用于排查大数据量下的慢查询
+	QueryPlans map[string][]string `json:"queryPlans"`
+}
+
+// hotQueries 为看板、四象限等高频接口实际执行的查询，诊断时输出其查询计划。
+var hotQueries = []struct {
+	name  string
+	query string
+	args  []any
+}{
+	{"board", `SELECT ` + taskColumns + ` FROM tasks ORDER BY ` + sortOrderSQL[SortUpdated], nil},
+	{"subTasks", subTasksSQL, []any{"[1]"}},
+	{"quadrantCounts", `SELECT important, urgent, COUNT(*) FROM tasks WHERE parent_id = 0 AND ` + activeGroupSQL + ` GROUP BY important, urgent`, nil},
+	{"quadrantTasks", `SELECT ` + taskColumns + ` FROM tasks WHERE parent_id = 0 AND ` + activeGroupSQL + ` ORDER BY important DESC, urgent DESC, ` + sortOrderSQL[SortQuadrant], nil},
+}
+
+// SchemaObject 为 sqlite_master 中的一个表/索引定义。
+type SchemaObject struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+	SQL  string `json:"sql"`
+}
+
+// RedactedGroup 为脱敏后的分组：名称只保留哈希与长度。
+type RedactedGroup struct {
+	ID        int64  `json:"id"`
+	NameHash  string `json:"nameHash"`
+	NameRunes int    `json:"nameRunes"`
+	CreatedAt int64  `json:"createdAt"`
+	UpdatedAt int64  `json:"updatedAt"`
+}
+
+// RedactedTask 为脱敏后的任务：标题/内容只保留哈希与长度，其余结构字段原样保留。
+type RedactedTask struct {
+	ID           int64  `json:"id"`
+	GroupID      int64  `json:"groupId"`
+	ParentID     int64  `json:"parentId"`
+	Status       Status `json:"status"`
+	Important    bool   `json:"important"`
+	Urgent       bool   `json:"urgent"`
+	TitleHash    string `json:"titleHash"`
+	TitleRunes   int    `json:"titleRunes"`
+	ContentRunes int    `json:"contentRunes"`
+	DueAt        int64  `json:"dueAt"`
+	CompletedAt  int64  `json:"completedAt"`
+	CreatedAt    int64  `json:"createdAt"`
+	UpdatedAt    int64  `json:"updatedAt"`
+}
+
+// RedactedData 为只含结构信息的数据快照。
+type RedactedData struct {
+	Groups []RedactedGroup `json:"groups"`
+	Tasks  []RedactedTask  `json:"tasks"`
+}
+
+// SchemaInfo 读取数据库结构、各表行数与健康检查结果。
+func (s *Store) SchemaInfo(ctx context.Context) (SchemaInfo, error) {
+	info := SchemaInfo{Objects: []SchemaObject{}, RowCounts: map[string]int64{}, QueryPlans: map[string][]string{}}
+
+	pragmas := []struct {
+		query string
+		dest  any
+	}{
+		{`SELECT sqlite_version()`, &info.SQLiteVersion},
+		{`PRAGMA journal_mode`, &info.JournalMode},
+		{`PRAGMA page_size`, &info.PageSize},
+		{`PRAGMA page_count`, &info.PageCount},
+		{`PRAGMA freelist_count`, &info.FreelistCount},
+		{`PRAGMA quick_check`, &info.QuickCheck},
+	}
+	for _, p := range pragmas {
+		if err := s.db.QueryRowContext(ctx, p.query).Scan(p.dest); err != nil {
+			return SchemaInfo{}, fmt.Errorf("%s: %w", p.query, err)
+		}
+	}
+
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT type, name, COALESCE(sql, '') FROM sqlite_master
+		 WHERE name NOT LIKE 'sqlite_%' ORDER BY type, name`)
+	if err != nil {
+		return SchemaInfo{}, fmt.Errorf("read schema: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var o SchemaObject
+		if err := rows.Scan(&o.Type, &o.Name, &o.SQL); err != nil {
+			return SchemaInfo{}, fmt.Errorf("scan schema: %w", err)
+		}
+		info.Objects = append(info.Objects, o)
+	}
+	if err := rows.Err(); err != nil {
+		return SchemaInfo{}, fmt.Errorf("iterate schema: %w", err)
+	}
+
+	for _, o := range info.Objects {
+		if o.Type != "table" {
+			continue
+		}
+		var n int64
+		// 表名来自 sqlite_master，用双引号包裹作为标识符
+		if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM "`+o.Name+`"`).Scan(&n); err != nil {
+			return SchemaInfo{}, fmt.Errorf("count %s: %w", o.Name, err)
+		}
+		info.RowCounts[o.Name] = n
+	}
+
+	for _, q := range hotQueries {
+		plan, err := s.queryPlan(ctx, q.query, q.args...)
+		if err != nil {
+			return SchemaInfo{}, fmt.Errorf("explain %s: %w", q.name, err)
+		}
+		info.QueryPlans[q.name] = plan
+	}
+
+	return info, nil
+}
+
+// queryPlan 返回 query 的 EXPLAIN QUERY PLAN 各步骤说明（如 "SEARCH tasks USING INDEX ..."）。
+func (s *Store) queryPlan(ctx context.Context, query string, args ...any) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `EXPLAIN QUERY PLAN `+query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	plan := []string{}
+	for rows.Next() {
+		var id, parent, notUsed int
+		var detail string
+		if err := rows.Scan(&id, &parent, &notUsed, &detail); err != nil {
+			return nil, err
+		}
+		plan = append(plan, detail)
+	}
+	return plan, rows.Err()
+}
+
+// RedactedData 导出只含结构信息的数据：组名、任务标题以加盐哈希代替，任务内容只保留长度。
+//
+// 盐在每次调用时随机生成且不随结果输出，因此哈希只能用于判断"同一份诊断包里两条是否相同"，
+// 无法通过字典反查原文。
+func (s *Store) RedactedData(ctx context.Context) (RedactedData, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return RedactedData{}, fmt.Errorf("generate salt: %w", err)
+	}
+	hash := func(v string) string {
+		h := sha256.New()
+		h.Write(salt)
+		h.Write([]byte(v))
+		return hex.EncodeToString(h.Sum(nil))[:12]
+	}
+
+	groups, err := s.ListGroups(ctx)
+	if err != nil {
+		return RedactedData{}, err
+	}
+	out := RedactedData{Groups: make([]RedactedGroup, 0, len(groups)), Tasks: []RedactedTask{}}
+	for _, g := range groups {
+		out.Groups = append(out.Groups, RedactedGroup{
+			ID:        g.ID,
+			NameHash:  hash(g.Name),
+			NameRunes: utf8.RuneCountInString(g.Name),
+			CreatedAt: g.CreatedAt,
+			UpdatedAt: g.UpdatedAt,
+		})
+	}
+
+	tasks, err := s.queryTasks(ctx, `SELECT `+taskColumns+` FROM tasks ORDER BY id`)
+	if err != nil {
+		return RedactedData{}, err
+	}
+	for _, t := range tasks {
+		out.Tasks = append(out.Tasks, RedactedTask{
+			ID:           t.ID,
+			GroupID:      t.GroupID,
+			ParentID:     t.ParentID,
+			Status:       t.Status,
+			Important:    t.Important,
+			Urgent:       t.Urgent,
+			TitleHash:    hash(t.Title),
+			TitleRunes:   utf8.RuneCountInString(t.Title),
+			ContentRunes: utf8.RuneCountInString(t.Content),
+			DueAt:        t.DueAt,
+			CompletedAt:  t.CompletedAt,
+			CreatedAt:    t.CreatedAt,
+			UpdatedAt:    t.UpdatedAt,
+		})
+	}
+	return out, nil
+}
@@ -0,0 +1,33 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ListOverdueTasks 返回截止时间早于 now 且未完成的任务（含子任务，不含已归档分组），最早到期的在前。
+//
+// 与按自然日判断的 GetOverdue 不同，这里精确到截止时刻：今天 9:00 到期、现在 10:00 的任务也算逾期，
+// 供卡片上的截止时间标记与"已逾期"筛选使用。
+func (s *Store) ListOverdueTasks(ctx context.Context, now time.Time) ([]Task, error) {
+	return s.queryTasks(ctx,
+		`SELECT `+taskColumns+` FROM tasks
+		 WHERE due_at > 0 AND due_at < ? AND status <> 'done' AND `+activeGroupSQL+`
+		 ORDER BY due_at, id`,
+		now.UnixMilli(),
+	)
+}
+
+// ListTasksDueBetween 返回截止时间在 [from, to)（UnixMilli）内的任务（含子任务，不含已归档分组），按截止时间升序；
+// hideDone 为 true 时不返回已完成任务。from 必须小于 to。
+func (s *Store) ListTasksDueBetween(ctx context.Context, from, to int64, hideDone bool) ([]Task, error) {
+	if from < 0 || from >= to {
+		return nil, ErrInvalidRange.with(fmt.Sprintf("%d-%d", from, to))
+	}
+	query := `SELECT ` + taskColumns + ` FROM tasks WHERE due_at > 0 AND due_at >= ? AND due_at < ? AND ` + activeGroupSQL
+	if hideDone {
+		query += ` AND status <> 'done'`
+	}
+	return s.queryTasks(ctx, query+` ORDER BY due_at, id`, from, to)
+}
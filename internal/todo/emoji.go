@@ -0,0 +1,37 @@
+package todo
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxTaskEmojiRunes 限制任务表情的长度：足以容纳肤色修饰、旗帜与 ZWJ 组合表情（如 👨‍👩‍👧），
+// 但不允许把一段文字塞进表情位。
+const maxTaskEmojiRunes = 10
+
+// normalizeTaskEmoji 校验任务表情：空字符串表示无表情；
+// 否则不得包含空白/控制字符，且至少含一个符号类字符（排除普通文字）。
+func normalizeTaskEmoji(v string) (string, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return "", nil
+	}
+	if utf8.RuneCountInString(v) > maxTaskEmojiRunes {
+		return "", ErrInvalidEmoji.with(v)
+	}
+	hasSymbol := false
+	for _, r := range v {
+		if unicode.IsSpace(r) || unicode.IsControl(r) {
+			return "", ErrInvalidEmoji.with(v)
+		}
+		// U+20E3 为键帽组合符（如 1️⃣），本身属于 Me 类
+		if unicode.Is(unicode.So, r) || r == '\u20e3' {
+			hasSymbol = true
+		}
+	}
+	if !hasSymbol {
+		return "", ErrInvalidEmoji.with(v)
+	}
+	return v, nil
+}
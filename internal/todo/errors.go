@@ -0,0 +1,213 @@
+package todo
+
+import (
+	"spark-todo/internal/i18n"
+)
+
+// Error 是 Store 返回的业务错误（输入校验失败、记录不存在、唯一约束冲突等）。
+//
+// Store 只产生错误码与参数，不负责文案：
+// - App 层通过 Localize 按用户语言翻译后返回给前端
+// - 调用方可用 errors.Is(err, ErrTaskNotFound) 判断错误类型（只比较 Code，不比较参数）
+//
+// 数据库/IO 等底层错误仍以 fmt.Errorf("...: %w") 包装返回，不属于 *Error。
+type Error struct {
+	Code string
+	Args []any
+	// Data 为随错误返回给前端的附加数据（如重名时已有分组的 ID），便于界面提供进一步操作
+	Data map[string]any
+}
+
+// Error 返回默认语言的文案，便于日志与未经翻译的调用方。
+func (e *Error) Error() string {
+	return i18n.T(i18n.Default, e.key(), e.Args...)
+}
+
+// Is 让 errors.Is 按错误码匹配，忽略参数。
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// Localize 返回指定语言的文案。
+func (e *Error) Localize(lang string) string {
+	return i18n.T(lang, e.key(), e.Args...)
+}
+
+// key 返回消息目录中的 key（统一加 "todo." 前缀）。
+func (e *Error) key() string {
+	return "todo." + e.Code
+}
+
+// with 基于哨兵错误创建带参数的错误实例。
+func (e *Error) with(args ...any) *Error {
+	return &Error{Code: e.Code, Args: args}
+}
+
+// With 与 with 相同，供 internal/share 等其他包使用。
+func (e *Error) With(args ...any) *Error {
+	return e.with(args...)
+}
+
+// withData 基于哨兵错误创建带附加数据的错误实例。
+func (e *Error) withData(data map[string]any) *Error {
+	return &Error{Code: e.Code, Args: e.Args, Data: data}
+}
+
+// Store 业务错误码。需要参数的错误在返回时通过 with 补充（见各处注释中的参数说明）。
+var (
+	ErrInvalidStatus = &Error{Code: "invalidStatus"} // 参数：状态值
+
+	ErrGroupNameEmpty   = &Error{Code: "groupNameEmpty"}
+	ErrGroupNameTooLong = &Error{Code: "groupNameTooLong"} // 参数：最大长度
+	ErrGroupNameTaken   = &Error{Code: "groupNameTaken"}
+	ErrGroupNotFound    = &Error{Code: "groupNotFound"} // 参数：组 ID
+	ErrInvalidGroupID   = &Error{Code: "invalidGroupId"}
+	ErrGroupRequired    = &Error{Code: "groupRequired"}
+	ErrGroupCycle       = &Error{Code: "groupCycle"}
+
+	ErrTaskTitleEmpty     = &Error{Code: "taskTitleEmpty"}
+	ErrTaskTitleTooLong   = &Error{Code: "taskTitleTooLong"}   // 参数：最大长度
+	ErrTaskContentTooLong = &Error{Code: "taskContentTooLong"} // 参数：最大长度
+	ErrParentTaskNotFound = &Error{Code: "parentTaskNotFound"}
+	ErrTaskNotFound       = &Error{Code: "taskNotFound"} // 参数：任务 ID
+	ErrInvalidTaskID      = &Error{Code: "invalidTaskId"}
+	ErrTaskNotInGroup     = &Error{Code: "taskNotInGroup"} // 参数：任务 ID、组 ID
+
+	ErrInvalidSettingsScope = &Error{Code: "invalidSettingsScope"} // 参数：分类名
+	ErrUpdateURLTooLong     = &Error{Code: "updateUrlTooLong"}     // 参数：最大长度
+	ErrInvalidUpdateURL     = &Error{Code: "invalidUpdateUrl"}
+	ErrInvalidTimezone      = &Error{Code: "invalidTimezone"} // 参数：时区名
+	ErrInvalidColor         = &Error{Code: "invalidColor"}    // 参数：颜色值
+	ErrInvalidHotkey        = &Error{Code: "invalidHotkey"}   // 参数：快捷键组合
+	ErrInvalidDate          = &Error{Code: "invalidDate"}     // 参数：日期字符串
+	ErrInvalidSortMode      = &Error{Code: "invalidSortMode"} // 参数：排序方式
+	ErrInvalidView          = &Error{Code: "invalidView"}     // 参数：视图名
+	ErrInvalidRange         = &Error{Code: "invalidRange"}    // 参数：时间范围
+
+	ErrInvalidRetention  = &Error{Code: "invalidRetention"}  // 参数：最小天数、最大天数
+	ErrTrashItemNotFound = &Error{Code: "trashItemNotFound"} // 参数：回收站条目 ID
+
+	ErrInvalidReviewAction = &Error{Code: "invalidReviewAction"} // 参数：动作名
+
+	ErrGoalTitleEmpty    = &Error{Code: "goalTitleEmpty"}
+	ErrGoalTitleTooLong  = &Error{Code: "goalTitleTooLong"}  // 参数：最大长度
+	ErrGoalNotFound      = &Error{Code: "goalNotFound"}      // 参数：目标 ID
+	ErrInvalidGoalTarget = &Error{Code: "invalidGoalTarget"} // 参数：目标完成数
+
+	ErrTagNameEmpty    = &Error{Code: "tagNameEmpty"}
+	ErrTagNameTooLong  = &Error{Code: "tagNameTooLong"} // 参数：最大长度
+	ErrTagNameTaken    = &Error{Code: "tagNameTaken"}   // 参数：标签名
+	ErrTagNotFound     = &Error{Code: "tagNotFound"}    // 参数：标签 ID
+	ErrTagMergeSelf    = &Error{Code: "tagMergeSelf"}
+	ErrTooManyTaskTags = &Error{Code: "tooManyTaskTags"} // 参数：最大数量
+
+	ErrInvalidEmoji = &Error{Code: "invalidEmoji"} // 参数：表情
+
+	ErrInvalidTaskURL = &Error{Code: "invalidTaskUrl"} // 参数：链接
+	ErrTaskURLTooLong = &Error{Code: "taskUrlTooLong"} // 参数：最大长度
+	ErrTaskNoLink     = &Error{Code: "taskNoLink"}     // 参数：任务 ID
+	ErrLinkNotInTask  = &Error{Code: "linkNotInTask"}  // 参数：链接
+
+	ErrInvalidContentLimit  = &Error{Code: "invalidContentLimit"}  // 参数：最小值、最大值
+	ErrInvalidContentFormat = &Error{Code: "invalidContentFormat"} // 参数：格式
+
+	ErrAttachmentNotFound = &Error{Code: "attachmentNotFound"} // 参数：附件 ID
+
+	ErrInvalidTitleLimit     = &Error{Code: "invalidTitleLimit"}     // 参数：最小值、最大值
+	ErrInvalidGroupNameLimit = &Error{Code: "invalidGroupNameLimit"} // 参数：最小值、最大值
+
+	ErrInvalidRecurFreq       = &Error{Code: "invalidRecurFreq"}     // 参数：周期
+	ErrInvalidRecurInterval   = &Error{Code: "invalidRecurInterval"} // 参数：最小值、最大值
+	ErrInvalidRecurMode       = &Error{Code: "invalidRecurMode"}     // 参数：方式
+	ErrRecurSubTask           = &Error{Code: "recurSubTask"}
+	ErrRecurLunarNotYearly    = &Error{Code: "recurLunarNotYearly"}
+	ErrInvalidRecurWeekday    = &Error{Code: "invalidRecurWeekday"} // 参数：星期值
+	ErrRecurWeekdaysNotWeekly = &Error{Code: "recurWeekdaysNotWeekly"}
+
+	ErrInvalidHolidayCalendar = &Error{Code: "invalidHolidayCalendar"} // 参数：解析错误
+
+	ErrInvalidLunarDate = &Error{Code: "invalidLunarDate"} // 参数：错误说明
+	ErrLunarOutOfRange  = &Error{Code: "lunarOutOfRange"}  // 参数：最小年份、最大年份
+
+	ErrInvalidSwimlaneBy = &Error{Code: "invalidSwimlaneBy"} // 参数：划分维度
+
+	ErrGroupNotShared     = &Error{Code: "groupNotShared"}     // 参数：组 ID
+	ErrGroupAlreadyShared = &Error{Code: "groupAlreadyShared"} // 参数：组 ID
+
+	ErrInvalidShareUsername  = &Error{Code: "invalidShareUsername"}
+	ErrSharePasswordTooShort = &Error{Code: "sharePasswordTooShort"} // 参数：最小长度
+	ErrShareUsernameTaken    = &Error{Code: "shareUsernameTaken"}    // 参数：用户名
+	ErrShareBadCredentials   = &Error{Code: "shareBadCredentials"}
+	ErrShareTooManyAttempts  = &Error{Code: "shareTooManyAttempts"} // 参数：需等待的分钟数
+	ErrShareUnauthorized     = &Error{Code: "shareUnauthorized"}
+	ErrShareAccountNotFound  = &Error{Code: "shareAccountNotFound"} // 参数：用户名
+	ErrShareNotMember        = &Error{Code: "shareNotMember"}       // 参数：共享 ID
+	ErrShareNotLoggedIn      = &Error{Code: "shareNotLoggedIn"}
+	ErrShareUnreachable      = &Error{Code: "shareUnreachable"}   // 参数：错误说明
+	ErrShareServer           = &Error{Code: "shareServer"}        // 参数：错误说明
+	ErrInvalidShareServer    = &Error{Code: "invalidShareServer"} // 参数：服务器地址
+
+	ErrReadOnly       = &Error{Code: "readOnly"}
+	ErrReadOnlyLocked = &Error{Code: "readOnlyLocked"}
+	ErrDatabaseBusy   = &Error{Code: "databaseBusy"}
+
+	ErrBackupPassphraseTooShort = &Error{Code: "backupPassphraseTooShort"} // 参数：最小长度
+	ErrBackupPassphraseRequired = &Error{Code: "backupPassphraseRequired"} // 附加数据：path（备份文件路径）
+	ErrBackupBadPassphrase      = &Error{Code: "backupBadPassphrase"}
+	ErrInvalidBackup            = &Error{Code: "invalidBackup"}
+
+	ErrInvalidCloudEndpoint     = &Error{Code: "invalidCloudEndpoint"} // 参数：服务地址
+	ErrInvalidCloudBucket       = &Error{Code: "invalidCloudBucket"}   // 参数：存储桶
+	ErrCloudAccessKeyEmpty      = &Error{Code: "cloudAccessKeyEmpty"}
+	ErrInvalidCloudBackupHours  = &Error{Code: "invalidCloudBackupHours"} // 参数：最小值、最大值
+	ErrInvalidCloudBackupKeep   = &Error{Code: "invalidCloudBackupKeep"}  // 参数：最小值、最大值
+	ErrCloudBackupNotConfigured = &Error{Code: "cloudBackupNotConfigured"}
+	ErrCloudSecretMissing       = &Error{Code: "cloudSecretMissing"}
+	ErrCloudPassphraseMissing   = &Error{Code: "cloudPassphraseMissing"}
+	ErrCloudStorage             = &Error{Code: "cloudStorage"} // 参数：错误说明
+	ErrKeychainUnsupported      = &Error{Code: "keychainUnsupported"}
+	ErrCloudBackupNotFound      = &Error{Code: "cloudBackupNotFound"} // 参数：对象名
+
+	ErrInvalidSearchScope = &Error{Code: "invalidSearchScope"} // 参数：搜索范围
+
+	ErrAPITokenNameEmpty   = &Error{Code: "apiTokenNameEmpty"}
+	ErrAPITokenNameTooLong = &Error{Code: "apiTokenNameTooLong"} // 参数：最大长度
+	ErrInvalidAPIScope     = &Error{Code: "invalidApiScope"}     // 参数：权限范围
+	ErrAPITokenNotFound    = &Error{Code: "apiTokenNotFound"}    // 参数：令牌 ID
+	ErrAPITokenInvalid     = &Error{Code: "apiTokenInvalid"}
+	ErrAPITokenForbidden   = &Error{Code: "apiTokenForbidden"}
+	ErrInvalidAPIPort      = &Error{Code: "invalidApiPort"} // 参数：最小端口、最大端口
+
+	ErrNotSubTask = &Error{Code: "notSubTask"} // 参数：任务 ID
+
+	ErrInvalidReminderTime = &Error{Code: "invalidReminderTime"}
+	ErrReminderNotFound    = &Error{Code: "reminderNotFound"} // 参数：任务 ID
+	ErrReminderGone        = &Error{Code: "reminderGone"}
+	ErrInvalidSnooze       = &Error{Code: "invalidSnooze"} // 参数：最小分钟数、最大分钟数
+
+	ErrInvalidPomodoroMinutes = &Error{Code: "invalidPomodoroMinutes"} // 参数：最小分钟数、最大分钟数
+	ErrPomodoroActive         = &Error{Code: "pomodoroActive"}
+	ErrNoPomodoro             = &Error{Code: "noPomodoro"}
+
+	ErrTimerRunning = &Error{Code: "timerRunning"}
+	ErrNoTimer      = &Error{Code: "noTimer"}
+
+	ErrNoteEmpty    = &Error{Code: "noteEmpty"}
+	ErrNoteTooLong  = &Error{Code: "noteTooLong"}  // 参数：最大长度
+	ErrNoteNotFound = &Error{Code: "noteNotFound"} // 参数：备注 ID
+
+	ErrDependencySelf     = &Error{Code: "dependencySelf"}
+	ErrDependencyCycle    = &Error{Code: "dependencyCycle"}
+	ErrDependencyNotFound = &Error{Code: "dependencyNotFound"} // 参数：任务 ID、前置任务 ID
+
+	ErrInvalidImportFile        = &Error{Code: "invalidImportFile"}
+	ErrUnsupportedImportVersion = &Error{Code: "unsupportedImportVersion"} // 参数：文件中的版本号
+	ErrInvalidImportMode        = &Error{Code: "invalidImportMode"}        // 参数：导入方式
+	ErrImportBrokenReference    = &Error{Code: "importBrokenReference"}    // 参数：无效的 ID
+
+	ErrCSVNoTitleColumn  = &Error{Code: "csvNoTitleColumn"}
+	ErrCSVColumnNotFound = &Error{Code: "csvColumnNotFound"} // 参数：列名
+	ErrInvalidCSVField   = &Error{Code: "invalidCsvField"}   // 参数：字段名
+	ErrInvalidCSVBool    = &Error{Code: "invalidCsvBool"}    // 参数：单元格内容
+)
@@ -0,0 +1,90 @@
+package todo
+
+import (
+	"context"
+	"time"
+)
+
+// ExportGroup 为导出文档中的一个分组。
+type ExportGroup struct {
+	ID    int64        `json:"id"`
+	Name  string       `json:"name"`
+	Open  int          `json:"open"` // 未完成主任务数
+	Done  int          `json:"done"` // 已完成主任务数
+	Tasks []ReportTask `json:"tasks"`
+}
+
+// BoardExport 为看板（或单个分组）的导出数据。
+type BoardExport struct {
+	Title       string        `json:"title"` // 单个分组时为组名，否则为空（由模板使用默认标题）
+	Groups      []ExportGroup `json:"groups"`
+	GeneratedAt string        `json:"generatedAt"`
+	AutoPrint   bool          `json:"autoPrint"` // HTML 打开后自动弹出打印对话框
+}
+
+// GetBoardExport 汇总导出所需的数据：groupID 为 0 时导出全部分组，否则只导出该分组。
+//
+// 主任务在组内按状态（进行中 > 待办 > 已完成）排序，子任务作为 Children 附在主任务下；
+// hideDone 为 true 时不导出已完成任务。
+func (s *Store) GetBoardExport(ctx context.Context, groupID int64, hideDone bool, now time.Time, settings Settings) (BoardExport, error) {
+	if groupID < 0 {
+		return BoardExport{}, ErrInvalidGroupID
+	}
+	groups, err := s.ListGroups(ctx)
+	if err != nil {
+		return BoardExport{}, err
+	}
+
+	out := BoardExport{
+		Groups:      []ExportGroup{},
+		GeneratedAt: FormatDateTime(now.In(Location(settings)), settings),
+	}
+	names := make(map[int64]string, len(groups))
+	for _, g := range groups {
+		names[g.ID] = g.Name
+	}
+	for _, g := range groups {
+		// 整个看板导出时跳过已归档分组；指定分组时即使已归档也照常导出
+		if (groupID > 0 && g.ID != groupID) || (groupID == 0 && g.Archived) {
+			continue
+		}
+		query := `SELECT ` + taskColumns + ` FROM tasks WHERE group_id = ? AND parent_id = 0`
+		if hideDone {
+			query += ` AND status <> 'done'`
+		}
+		tasks, err := s.queryTasks(ctx, query+` ORDER BY `+statusOrderSQL+`, sort_order, id`, g.ID)
+		if err != nil {
+			return BoardExport{}, err
+		}
+		if err := s.attachSubTasks(ctx, tasks); err != nil {
+			return BoardExport{}, err
+		}
+
+		eg := ExportGroup{ID: g.ID, Name: g.Name, Tasks: toReportTasks(tasks, names, settings)}
+		for i, t := range tasks {
+			if t.Status == StatusDone {
+				eg.Done++
+			} else {
+				eg.Open++
+			}
+			subs := t.SubTasks
+			if hideDone {
+				subs = subs[:0:0]
+				for _, sub := range t.SubTasks {
+					if sub.Status != StatusDone {
+						subs = append(subs, sub)
+					}
+				}
+			}
+			eg.Tasks[i].Children = toReportTasks(subs, names, settings)
+		}
+		out.Groups = append(out.Groups, eg)
+	}
+	if groupID > 0 {
+		if len(out.Groups) == 0 {
+			return BoardExport{}, ErrGroupNotFound.with(groupID)
+		}
+		out.Title = out.Groups[0].Name
+	}
+	return out, nil
+}
@@ -0,0 +1,80 @@
+package todo
+
+import (
+	"encoding/json"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// maxFilterSearchRunes 限制筛选中搜索文本的长度。
+	maxFilterSearchRunes = 200
+	// maxFilterTags 限制筛选中同时选择的标签数。
+	maxFilterTags = 20
+)
+
+// ViewFilter 为某个视图最近一次使用的筛选条件（零值表示不筛选）。
+type ViewFilter struct {
+	GroupID int64    `json:"groupId"` // 0 表示全部分组
+	Status  string   `json:"status"`  // "" 表示全部状态，否则为 todo / doing / done
+	Tags    []string `json:"tags"`    // 需同时包含的标签名；空表示不按标签筛选
+	Search  string   `json:"search"`  // 搜索文本
+}
+
+// normalize 校验并规范化筛选条件：去除首尾空白、标签去重、状态必须合法。
+func (f ViewFilter) normalize() (ViewFilter, error) {
+	if f.GroupID < 0 {
+		return ViewFilter{}, ErrInvalidGroupID
+	}
+	f.Status = strings.TrimSpace(f.Status)
+	if f.Status != "" {
+		if _, err := ParseStatus(f.Status); err != nil {
+			return ViewFilter{}, err
+		}
+	}
+	f.Search = strings.TrimSpace(f.Search)
+	if utf8.RuneCountInString(f.Search) > maxFilterSearchRunes {
+		f.Search = string([]rune(f.Search)[:maxFilterSearchRunes])
+	}
+
+	tags := make([]string, 0, len(f.Tags))
+	for _, t := range f.Tags {
+		t = strings.TrimSpace(t)
+		if t == "" || containsString(tags, t) {
+			continue
+		}
+		tags = append(tags, t)
+		if len(tags) == maxFilterTags {
+			break
+		}
+	}
+	f.Tags = tags
+	return f, nil
+}
+
+// normalizeViewFilters 校验 viewFilters 设置（JSON 对象：视图名 -> ViewFilter），返回规范化后的 JSON。
+func normalizeViewFilters(v string) (string, error) {
+	filters := map[string]ViewFilter{}
+	if strings.TrimSpace(v) != "" {
+		if err := json.Unmarshal([]byte(v), &filters); err != nil {
+			return "", ErrInvalidView.with(v)
+		}
+	}
+	out := make(map[string]ViewFilter, len(filters))
+	for view, f := range filters {
+		view, err := parseView(view)
+		if err != nil {
+			return "", err
+		}
+		f, err := f.normalize()
+		if err != nil {
+			return "", err
+		}
+		out[view] = f
+	}
+	data, err := json.Marshal(out)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
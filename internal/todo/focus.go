@@ -0,0 +1,176 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// 时间记录来源。
+const (
+	TimeSourceTimer    = "timer"    // 任务计时器
+	TimeSourcePomodoro = "pomodoro" // 番茄钟专注
+)
+
+// FocusDay 为某一天的专注时长。
+type FocusDay struct {
+	Date      string `json:"date"` // 本地日期（YYYY-MM-DD）
+	Minutes   int    `json:"minutes"`
+	Pomodoros int    `json:"pomodoros"` // 当天完成的番茄钟数
+}
+
+// FocusGroup 为某个分组的专注时长占比。
+type FocusGroup struct {
+	GroupID int64   `json:"groupId"` // 0 表示任务已被删除、无法归属分组
+	Name    string  `json:"name"`
+	Minutes int     `json:"minutes"`
+	Percent float64 `json:"percent"` // 占总时长的百分比（0-100）
+}
+
+// FocusStats 为一段时间内的专注统计。
+type FocusStats struct {
+	Range        string       `json:"range"`
+	TotalMinutes int          `json:"totalMinutes"`
+	Pomodoros    int          `json:"pomodoros"` // 完成的番茄钟数
+	Days         []FocusDay   `json:"days"`      // 范围内每一天（含没有记录的日子）
+	BestDay      *FocusDay    `json:"bestDay"`   // 专注最久的一天；没有记录时为 nil
+	Groups       []FocusGroup `json:"groups"`    // 按时长降序
+}
+
+// timeEntry 为 time_entries 表中的一段时间记录。
+type timeEntry struct {
+	TaskID    int64
+	TaskTitle string // 任务已删除时为空
+	GroupID   int64
+	GroupName string
+	StartedAt int64
+	EndedAt   int64
+}
+
+// listTimeEntries 返回与 [from, to) 有交集的时间记录；进行中的记录按 now 截止。
+func (s *Store) listTimeEntries(ctx context.Context, from, to, now int64) ([]timeEntry, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT e.task_id, COALESCE(t.title, ''), COALESCE(g.id, 0), COALESCE(g.name, ''), e.started_at,
+		        CASE WHEN e.ended_at > 0 THEN e.ended_at ELSE ? END
+		 FROM time_entries e
+		 LEFT JOIN tasks t ON t.id = e.task_id
+		 LEFT JOIN groups g ON g.id = t.group_id
+		 WHERE e.started_at < ? AND (e.ended_at = 0 OR e.ended_at > ?)
+		 ORDER BY e.started_at, e.id`,
+		now, to, from)
+	if err != nil {
+		return nil, fmt.Errorf("list time entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []timeEntry{}
+	for rows.Next() {
+		var e timeEntry
+		if err := rows.Scan(&e.TaskID, &e.TaskTitle, &e.GroupID, &e.GroupName, &e.StartedAt, &e.EndedAt); err != nil {
+			return nil, fmt.Errorf("scan time entry: %w", err)
+		}
+		// 裁剪到查询范围内
+		e.StartedAt = max(e.StartedAt, from)
+		e.EndedAt = min(e.EndedAt, to)
+		if e.EndedAt > e.StartedAt {
+			entries = append(entries, e)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate time entries: %w", err)
+	}
+	return entries, nil
+}
+
+// GetFocusStats 汇总 rng（week / month / quarter）范围内的计时与番茄钟记录：
+// 总专注分钟数、每日分布（含完成的番茄钟数）、最佳一天以及按分组的分布。跨零点的记录按天拆分。
+func (s *Store) GetFocusStats(ctx context.Context, rng string, now time.Time, settings Settings) (FocusStats, error) {
+	rng, days, err := parseStatsRange(rng)
+	if err != nil {
+		return FocusStats{}, err
+	}
+	loc := Location(settings)
+	first := StartOfDay(now, loc).AddDate(0, 0, -(days - 1))
+	end := first.AddDate(0, 0, days)
+
+	entries, err := s.listTimeEntries(ctx, first.UnixMilli(), end.UnixMilli(), now.UnixMilli())
+	if err != nil {
+		return FocusStats{}, err
+	}
+	completed, err := s.listCompletedPomodoros(ctx, first.UnixMilli(), end.UnixMilli())
+	if err != nil {
+		return FocusStats{}, err
+	}
+
+	out := FocusStats{Range: rng, Days: make([]FocusDay, days), Groups: []FocusGroup{}}
+	dayMillis := make([]int64, days)
+	for i := range out.Days {
+		dayStart := first.AddDate(0, 0, i)
+		ds, de := dayStart.UnixMilli(), dayStart.AddDate(0, 0, 1).UnixMilli()
+		out.Days[i].Date = dayStart.Format(dayKeyLayout)
+		for _, e := range entries {
+			if overlap := min(e.EndedAt, de) - max(e.StartedAt, ds); overlap > 0 {
+				dayMillis[i] += overlap
+			}
+		}
+		for _, endedAt := range completed {
+			if endedAt >= ds && endedAt < de {
+				out.Days[i].Pomodoros++
+			}
+		}
+		out.Pomodoros += out.Days[i].Pomodoros
+	}
+
+	var total int64
+	for i, ms := range dayMillis {
+		out.Days[i].Minutes = int(ms / time.Minute.Milliseconds())
+		total += ms
+		if ms > 0 && (out.BestDay == nil || out.Days[i].Minutes > out.BestDay.Minutes) {
+			best := out.Days[i]
+			out.BestDay = &best
+		}
+	}
+	out.TotalMinutes = int(total / time.Minute.Milliseconds())
+
+	groupMillis := map[int64]int64{}
+	groupNames := map[int64]string{}
+	for _, e := range entries {
+		groupMillis[e.GroupID] += e.EndedAt - e.StartedAt
+		groupNames[e.GroupID] = e.GroupName
+	}
+	for id, ms := range groupMillis {
+		g := FocusGroup{GroupID: id, Name: groupNames[id], Minutes: int(ms / time.Minute.Milliseconds())}
+		if total > 0 {
+			g.Percent = float64(ms) * 100 / float64(total)
+		}
+		out.Groups = append(out.Groups, g)
+	}
+	sort.Slice(out.Groups, func(i, j int) bool {
+		if out.Groups[i].Minutes != out.Groups[j].Minutes {
+			return out.Groups[i].Minutes > out.Groups[j].Minutes
+		}
+		return out.Groups[i].GroupID < out.Groups[j].GroupID
+	})
+	return out, nil
+}
+
+// listCompletedPomodoros 返回在 [from, to) 内完成的番茄钟的结束时间。
+func (s *Store) listCompletedPomodoros(ctx context.Context, from, to int64) ([]int64, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT ended_at FROM pomodoro_sessions WHERE state = 'completed' AND ended_at >= ? AND ended_at < ?`, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("list completed pomodoros: %w", err)
+	}
+	defer rows.Close()
+
+	var out []int64
+	for rows.Next() {
+		var endedAt int64
+		if err := rows.Scan(&endedAt); err != nil {
+			return nil, fmt.Errorf("scan completed pomodoro: %w", err)
+		}
+		out = append(out, endedAt)
+	}
+	return out, rows.Err()
+}
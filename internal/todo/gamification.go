@@ -0,0 +1,208 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// quadrantPoints 为完成各象限主任务获得的积分：
+// 重要不紧急（q2）最高，鼓励把时间花在真正重要的事情上。
+var quadrantPoints = map[string]int{
+	QuadrantDoFirst:  15,
+	QuadrantSchedule: 20,
+	QuadrantDelegate: 8,
+	QuadrantDrop:     5,
+}
+
+// subTaskPoints 为完成子任务获得的积分（不区分象限）。
+const subTaskPoints = 2
+
+// levelStepPoints 决定升级所需积分：第 n 级升到 n+1 级需要 n*levelStepPoints 分。
+const levelStepPoints = 100
+
+// achievementMetric 为成就的统计口径。
+type achievementMetric int
+
+const (
+	metricCompletions achievementMetric = iota // 累计完成的主任务数
+	metricStreak                               // 连续有完成任务的天数
+	metricSchedule                             // 累计完成的"重要不紧急"主任务数
+	metricPoints                               // 累计积分
+)
+
+// achievementDef 描述一个可解锁的成就。
+type achievementDef struct {
+	id     string
+	metric achievementMetric
+	goal   int
+}
+
+// achievementDefs 为全部成就（顺序即展示顺序）；文案由前端按 ID 提供。
+var achievementDefs = []achievementDef{
+	{id: "firstTask", metric: metricCompletions, goal: 1},
+	{id: "tasks10", metric: metricCompletions, goal: 10},
+	{id: "tasks100", metric: metricCompletions, goal: 100},
+	{id: "tasks500", metric: metricCompletions, goal: 500},
+	{id: "streak3", metric: metricStreak, goal: 3},
+	{id: "streak7", metric: metricStreak, goal: 7},
+	{id: "streak30", metric: metricStreak, goal: 30},
+	{id: "planner25", metric: metricSchedule, goal: 25},
+	{id: "points1000", metric: metricPoints, goal: 1000},
+}
+
+// Achievement 为一个成就的解锁状态。
+type Achievement struct {
+	ID         string `json:"id"`
+	Goal       int    `json:"goal"`
+	Progress   int    `json:"progress"` // 当前进度（不超过 Goal）
+	Unlocked   bool   `json:"unlocked"`
+	UnlockedAt int64  `json:"unlockedAt"` // 解锁时间（UnixMilli）；未解锁为 0
+}
+
+// GameStats 为积分、等级与成就的汇总。
+type GameStats struct {
+	Points          int           `json:"points"`
+	Level           int           `json:"level"`           // 从 1 开始
+	LevelPoints     int           `json:"levelPoints"`     // 当前等级内已获得的积分
+	NextLevelPoints int           `json:"nextLevelPoints"` // 升到下一级所需的积分（当前等级内）
+	Completions     int           `json:"completions"`     // 累计计分的主任务数
+	Streak          int           `json:"streak"`          // 截至今天（或昨天）的连续完成天数
+	Achievements    []Achievement `json:"achievements"`
+	NewlyUnlocked   []string      `json:"newlyUnlocked"` // 本次调用中新解锁的成就 ID
+}
+
+// taskQuadrant 返回任务所在象限标识。
+func taskQuadrant(t Task) string {
+	return newQuadrants()[quadrantIndex(t.Important, t.Urgent)].Key
+}
+
+// levelFor 由累计积分计算 (等级, 等级内积分, 升级所需积分)。
+func levelFor(points int) (int, int, int) {
+	level, need := 1, levelStepPoints
+	for points >= need {
+		points -= need
+		level++
+		need = level * levelStepPoints
+	}
+	return level, points, need
+}
+
+// AwardCompletion 为已完成的任务记一次积分并检查成就，返回最新统计（NewlyUnlocked 为本次解锁的成就）。
+//
+// 每个任务只计分一次：重新打开后再次完成不会重复得分；未完成的任务直接返回当前统计。
+func (s *Store) AwardCompletion(ctx context.Context, t Task, now time.Time, loc *time.Location) (GameStats, error) {
+	if t.Status == StatusDone && t.ID > 0 {
+		points, quadrant := subTaskPoints, ""
+		if t.ParentID == 0 {
+			quadrant = taskQuadrant(t)
+			points = quadrantPoints[quadrant]
+		}
+		if _, err := s.db.ExecContext(ctx,
+			`INSERT OR IGNORE INTO points(task_id, parent_id, quadrant, points, awarded_at) VALUES(?, ?, ?, ?, ?)`,
+			t.ID, t.ParentID, quadrant, points, now.UnixMilli(),
+		); err != nil {
+			return GameStats{}, fmt.Errorf("award points: %w", err)
+		}
+	}
+	return s.gameStats(ctx, now, loc, true)
+}
+
+// GetGameStats 返回积分、等级与成就（不解锁新成就）。
+func (s *Store) GetGameStats(ctx context.Context, now time.Time, loc *time.Location) (GameStats, error) {
+	return s.gameStats(ctx, now, loc, false)
+}
+
+// gameStats 汇总积分记录；unlock 为 true 时把达成条件但尚未记录的成就写入 achievements 表。
+func (s *Store) gameStats(ctx context.Context, now time.Time, loc *time.Location, unlock bool) (GameStats, error) {
+	st := GameStats{Achievements: []Achievement{}, NewlyUnlocked: []string{}}
+	var schedule int
+	if err := s.db.QueryRowContext(ctx,
+		`SELECT COALESCE(SUM(points), 0),
+		        COALESCE(SUM(CASE WHEN parent_id = 0 THEN 1 ELSE 0 END), 0),
+		        COALESCE(SUM(CASE WHEN quadrant = ? THEN 1 ELSE 0 END), 0)
+		 FROM points`, QuadrantSchedule,
+	).Scan(&st.Points, &st.Completions, &schedule); err != nil {
+		return GameStats{}, fmt.Errorf("sum points: %w", err)
+	}
+	st.Level, st.LevelPoints, st.NextLevelPoints = levelFor(st.Points)
+
+	streak, err := s.completionStreak(ctx, now, loc)
+	if err != nil {
+		return GameStats{}, err
+	}
+	st.Streak = streak
+
+	unlocked := map[string]int64{}
+	rows, err := s.db.QueryContext(ctx, `SELECT id, unlocked_at FROM achievements`)
+	if err != nil {
+		return GameStats{}, fmt.Errorf("list achievements: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id string
+		var at int64
+		if err := rows.Scan(&id, &at); err != nil {
+			return GameStats{}, fmt.Errorf("scan achievement: %w", err)
+		}
+		unlocked[id] = at
+	}
+	if err := rows.Err(); err != nil {
+		return GameStats{}, fmt.Errorf("iterate achievements: %w", err)
+	}
+
+	metrics := map[achievementMetric]int{
+		metricCompletions: st.Completions,
+		metricStreak:      st.Streak,
+		metricSchedule:    schedule,
+		metricPoints:      st.Points,
+	}
+	for _, d := range achievementDefs {
+		a := Achievement{ID: d.id, Goal: d.goal, Progress: min(metrics[d.metric], d.goal)}
+		if at, ok := unlocked[d.id]; ok {
+			a.Unlocked, a.UnlockedAt, a.Progress = true, at, d.goal
+		} else if unlock && a.Progress >= d.goal {
+			if _, err := s.db.ExecContext(ctx,
+				`INSERT OR IGNORE INTO achievements(id, unlocked_at) VALUES(?, ?)`, d.id, now.UnixMilli(),
+			); err != nil {
+				return GameStats{}, fmt.Errorf("unlock achievement %q: %w", d.id, err)
+			}
+			a.Unlocked, a.UnlockedAt = true, now.UnixMilli()
+			st.NewlyUnlocked = append(st.NewlyUnlocked, d.id)
+		}
+		st.Achievements = append(st.Achievements, a)
+	}
+	return st, nil
+}
+
+// completionStreak 计算截至今天的连续完成天数；今天还没有完成任务时从昨天开始数，不中断连续记录。
+func (s *Store) completionStreak(ctx context.Context, now time.Time, loc *time.Location) (int, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT awarded_at FROM points ORDER BY awarded_at DESC`)
+	if err != nil {
+		return 0, fmt.Errorf("list points: %w", err)
+	}
+	defer rows.Close()
+
+	days := map[string]bool{}
+	for rows.Next() {
+		var at int64
+		if err := rows.Scan(&at); err != nil {
+			return 0, fmt.Errorf("scan points: %w", err)
+		}
+		days[DayKey(time.UnixMilli(at), loc)] = true
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("iterate points: %w", err)
+	}
+
+	day := StartOfDay(now, loc)
+	if !days[DayKey(day, loc)] {
+		day = day.AddDate(0, 0, -1)
+	}
+	streak := 0
+	for days[DayKey(day, loc)] {
+		streak++
+		day = day.AddDate(0, 0, -1)
+	}
+	return streak, nil
+}
@@ -0,0 +1,223 @@
+package todo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxGoalTitleRunes 限制目标标题长度。
+const maxGoalTitleRunes = 100
+
+// Goal 为一个目标（轻量 OKR）：进度由关联任务的完成情况计算。
+//
+// TargetCount > 0 时以"完成的关联任务数 / TargetCount"计算进度（例如"本月读完 10 本书"）；
+// 否则以"完成的关联任务数 / 关联任务总数"计算。
+type Goal struct {
+	ID          int64   `json:"id"`
+	Title       string  `json:"title"`
+	TargetDate  int64   `json:"targetDate"`  // 目标日期（UnixMilli）；0 表示未设置
+	TargetCount int     `json:"targetCount"` // 目标完成数；0 表示以关联任务总数为目标
+	TaskIDs     []int64 `json:"taskIds"`     // 关联的任务 ID
+	Done        int     `json:"done"`        // 已完成的关联任务数
+	Target      int     `json:"target"`      // 进度分母（TargetCount 或关联任务数）
+	Percent     int     `json:"percent"`     // 0-100
+	CreatedAt   int64   `json:"createdAt"`
+	UpdatedAt   int64   `json:"updatedAt"`
+}
+
+// computeProgress 根据 TaskIDs 对应任务的完成情况计算 Target / Percent（Done 需事先统计好）。
+func (g *Goal) computeProgress() {
+	g.Target = g.TargetCount
+	if g.Target <= 0 {
+		g.Target = len(g.TaskIDs)
+	}
+	if g.Target > 0 {
+		g.Percent = min(100, g.Done*100/g.Target)
+	}
+}
+
+// ListGoals 返回全部目标（按目标日期升序，未设置日期的排在最后）及其进度。
+func (s *Store) ListGoals(ctx context.Context) ([]Goal, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, title, target_date, target_count, created_at, updated_at FROM goals
+		 ORDER BY target_date = 0, target_date, id`)
+	if err != nil {
+		return nil, fmt.Errorf("list goals: %w", err)
+	}
+	defer rows.Close()
+
+	goals := []Goal{}
+	index := map[int64]int{}
+	for rows.Next() {
+		g := Goal{TaskIDs: []int64{}}
+		if err := rows.Scan(&g.ID, &g.Title, &g.TargetDate, &g.TargetCount, &g.CreatedAt, &g.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan goal: %w", err)
+		}
+		index[g.ID] = len(goals)
+		goals = append(goals, g)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate goals: %w", err)
+	}
+	if err := s.attachGoalTasks(ctx, goals, index); err != nil {
+		return nil, err
+	}
+	return goals, nil
+}
+
+// GetGoal 返回单个目标及其进度。
+func (s *Store) GetGoal(ctx context.Context, id int64) (Goal, error) {
+	g := Goal{TaskIDs: []int64{}}
+	err := s.db.QueryRowContext(ctx,
+		`SELECT id, title, target_date, target_count, created_at, updated_at FROM goals WHERE id = ?`, id,
+	).Scan(&g.ID, &g.Title, &g.TargetDate, &g.TargetCount, &g.CreatedAt, &g.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Goal{}, ErrGoalNotFound.with(id)
+	}
+	if err != nil {
+		return Goal{}, fmt.Errorf("get goal: %w", err)
+	}
+	goals := []Goal{g}
+	if err := s.attachGoalTasks(ctx, goals, map[int64]int{g.ID: 0}); err != nil {
+		return Goal{}, err
+	}
+	return goals[0], nil
+}
+
+// attachGoalTasks 填充各目标的关联任务与进度；index 为 目标 ID -> goals 下标。
+func (s *Store) attachGoalTasks(ctx context.Context, goals []Goal, index map[int64]int) error {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT gt.goal_id, t.id, t.status FROM goal_tasks gt
+		 JOIN tasks t ON t.id = gt.task_id
+		 ORDER BY gt.goal_id, t.id`)
+	if err != nil {
+		return fmt.Errorf("list goal tasks: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var goalID, taskID int64
+		var status string
+		if err := rows.Scan(&goalID, &taskID, &status); err != nil {
+			return fmt.Errorf("scan goal task: %w", err)
+		}
+		i, ok := index[goalID]
+		if !ok {
+			continue
+		}
+		goals[i].TaskIDs = append(goals[i].TaskIDs, taskID)
+		if status == string(StatusDone) {
+			goals[i].Done++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate goal tasks: %w", err)
+	}
+	for i := range goals {
+		goals[i].computeProgress()
+	}
+	return nil
+}
+
+// UpsertGoal 新增（ID 为 0）或更新目标的标题、目标日期与目标完成数，返回含进度的目标。
+//
+// 关联任务通过 LinkGoalTask / UnlinkGoalTask 维护，这里不修改。
+func (s *Store) UpsertGoal(ctx context.Context, req Goal) (Goal, error) {
+	req.Title = strings.TrimSpace(req.Title)
+	if req.Title == "" {
+		return Goal{}, ErrGoalTitleEmpty
+	}
+	if utf8.RuneCountInString(req.Title) > maxGoalTitleRunes {
+		return Goal{}, ErrGoalTitleTooLong.with(maxGoalTitleRunes)
+	}
+	if req.TargetCount < 0 {
+		return Goal{}, ErrInvalidGoalTarget.with(req.TargetCount)
+	}
+	if req.TargetDate < 0 {
+		req.TargetDate = 0
+	}
+
+	now := time.Now().UnixMilli()
+	if req.ID == 0 {
+		res, err := s.db.ExecContext(ctx,
+			`INSERT INTO goals(title, target_date, target_count, created_at, updated_at) VALUES(?, ?, ?, ?, ?)`,
+			req.Title, req.TargetDate, req.TargetCount, now, now,
+		)
+		if err != nil {
+			return Goal{}, fmt.Errorf("create goal: %w", err)
+		}
+		id, err := res.LastInsertId()
+		if err != nil {
+			return Goal{}, fmt.Errorf("get new goal id: %w", err)
+		}
+		return s.GetGoal(ctx, id)
+	}
+
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE goals SET title = ?, target_date = ?, target_count = ?, updated_at = ? WHERE id = ?`,
+		req.Title, req.TargetDate, req.TargetCount, now, req.ID,
+	)
+	if err != nil {
+		return Goal{}, fmt.Errorf("update goal: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return Goal{}, fmt.Errorf("update goal rows affected: %w", err)
+	}
+	if affected == 0 {
+		return Goal{}, ErrGoalNotFound.with(req.ID)
+	}
+	return s.GetGoal(ctx, req.ID)
+}
+
+// DeleteGoal 删除目标（关联关系随之删除，任务本身不受影响）。
+func (s *Store) DeleteGoal(ctx context.Context, id int64) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM goals WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("delete goal: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete goal rows affected: %w", err)
+	}
+	if affected == 0 {
+		return ErrGoalNotFound.with(id)
+	}
+	return nil
+}
+
+// LinkGoalTask 将任务关联到目标（重复关联为空操作），返回更新后的目标。
+func (s *Store) LinkGoalTask(ctx context.Context, goalID, taskID int64) (Goal, error) {
+	if _, err := s.GetGoal(ctx, goalID); err != nil {
+		return Goal{}, err
+	}
+	var exists int
+	err := s.db.QueryRowContext(ctx, `SELECT 1 FROM tasks WHERE id = ?`, taskID).Scan(&exists)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Goal{}, ErrTaskNotFound.with(taskID)
+	}
+	if err != nil {
+		return Goal{}, fmt.Errorf("check task: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx,
+		`INSERT OR IGNORE INTO goal_tasks(goal_id, task_id) VALUES(?, ?)`, goalID, taskID,
+	); err != nil {
+		return Goal{}, fmt.Errorf("link goal task: %w", err)
+	}
+	return s.GetGoal(ctx, goalID)
+}
+
+// UnlinkGoalTask 取消任务与目标的关联（未关联时为空操作），返回更新后的目标。
+func (s *Store) UnlinkGoalTask(ctx context.Context, goalID, taskID int64) (Goal, error) {
+	if _, err := s.db.ExecContext(ctx,
+		`DELETE FROM goal_tasks WHERE goal_id = ? AND task_id = ?`, goalID, taskID,
+	); err != nil {
+		return Goal{}, fmt.Errorf("unlink goal task: %w", err)
+	}
+	return s.GetGoal(ctx, goalID)
+}
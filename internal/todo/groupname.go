@@ -0,0 +1,61 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+	"golang.org/x/text/width"
+)
+
+// normalizeGroupName 规范化分组名（见 canonicalGroupName），并校验不为空、不超过 limit 字。
+func normalizeGroupName(name string, limit int) (string, error) {
+	name = canonicalGroupName(name)
+	if name == "" {
+		return "", ErrGroupNameEmpty
+	}
+	if utf8.RuneCountInString(name) > limit {
+		return "", ErrGroupNameTooLong.with(limit)
+	}
+	return name, nil
+}
+
+// canonicalGroupName 返回分组名的规范形式：Unicode NFC、去除首尾空白、连续空白折叠为一个空格。
+//
+// 这样 "工作 " 与 "工作"、组合/分解形式的同一个字符不会产生看似重复的分组。
+func canonicalGroupName(name string) string {
+	return strings.Join(strings.Fields(norm.NFC.String(name)), " ")
+}
+
+// groupNameKey 返回用于判断分组重名的比较键：忽略大小写与全角/半角差异（"Work"、"work"、"Ｗｏｒｋ" 视为同名）。
+func groupNameKey(name string) string {
+	return strings.ToLower(width.Fold.String(name))
+}
+
+// findGroupByName 返回除 exceptID 外与 name（已规范化）重名的分组 ID；没有时返回 0。
+//
+// 旧版本保存的分组名可能未经规范化（或长于当前上限），因此在 Go 中逐个规范化后比较，而不只依赖 UNIQUE 约束。
+func (s *Store) findGroupByName(ctx context.Context, name string, exceptID int64) (int64, error) {
+	key := groupNameKey(name)
+	rows, err := s.db.QueryContext(ctx, `SELECT id, name FROM groups WHERE id <> ?`, exceptID)
+	if err != nil {
+		return 0, fmt.Errorf("list group names: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int64
+		var existing string
+		if err := rows.Scan(&id, &existing); err != nil {
+			return 0, fmt.Errorf("scan group name: %w", err)
+		}
+		if groupNameKey(canonicalGroupName(existing)) == key {
+			return id, nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("iterate group names: %w", err)
+	}
+	return 0, nil
+}
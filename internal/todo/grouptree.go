@@ -0,0 +1,93 @@
+package todo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// GroupNode 为分组树中的一个节点。
+type GroupNode struct {
+	Group
+	Children []GroupNode `json:"children"`
+}
+
+// ListGroupTree 返回分组的层级结构，供侧边栏渲染可折叠的分组。
+//
+// 同级分组的顺序与 ListGroups 一致；上级分组不存在的分组作为顶层分组返回。
+func (s *Store) ListGroupTree(ctx context.Context) ([]GroupNode, error) {
+	groups, err := s.ListGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	children := map[int64][]Group{}
+	exists := make(map[int64]bool, len(groups))
+	for _, g := range groups {
+		exists[g.ID] = true
+	}
+	for _, g := range groups {
+		parent := g.ParentID
+		if !exists[parent] {
+			parent = 0
+		}
+		children[parent] = append(children[parent], g)
+	}
+
+	visited := make(map[int64]bool, len(groups))
+	var build func(parentID int64) []GroupNode
+	build = func(parentID int64) []GroupNode {
+		nodes := []GroupNode{}
+		for _, g := range children[parentID] {
+			if visited[g.ID] {
+				continue
+			}
+			visited[g.ID] = true
+			nodes = append(nodes, GroupNode{Group: g, Children: build(g.ID)})
+		}
+		return nodes
+	}
+	roots := build(0)
+	// checkGroupParent 保证不会出现环；万一库中已有环（如手工修改），环上的分组作为顶层分组返回，不会丢失
+	for _, g := range groups {
+		if !visited[g.ID] {
+			visited[g.ID] = true
+			roots = append(roots, GroupNode{Group: g, Children: build(g.ID)})
+		}
+	}
+	return roots, nil
+}
+
+// checkGroupParent 校验分组 id（新增时为 0）的上级分组 parentID：
+// 0 表示顶层；否则须为已有分组，且不能是 id 自身或其下级分组。
+func (s *Store) checkGroupParent(ctx context.Context, id, parentID int64) error {
+	if parentID == 0 {
+		return nil
+	}
+	if parentID < 0 {
+		return ErrInvalidGroupID
+	}
+	var count int
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(1) FROM groups`).Scan(&count); err != nil {
+		return fmt.Errorf("count groups: %w", err)
+	}
+	// 沿上级链向上查找，最多走 count 步（库中已有环时也能结束）
+	for p, steps := parentID, 0; p != 0 && steps <= count; steps++ {
+		if p == id {
+			return ErrGroupCycle
+		}
+		var next int64
+		err := s.db.QueryRowContext(ctx, `SELECT parent_id FROM groups WHERE id = ?`, p).Scan(&next)
+		if errors.Is(err, sql.ErrNoRows) {
+			if p == parentID {
+				return ErrGroupNotFound.with(parentID)
+			}
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("get group parent: %w", err)
+		}
+		p = next
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package todo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"spark-todo/internal/holiday"
+)
+
+// holidayCalendarKey 为 settings 表中保存用户导入的节假日日历（原始 JSON）的 key。
+// 不属于 settingsSchema：它是数据而不是偏好，不随"恢复默认设置"清除。
+const holidayCalendarKey = "holidayCalendar"
+
+// HolidayCalendar 返回当前使用的节假日日历：内置日历合并用户导入的年份。
+// 导入的数据损坏时退回内置日历，不阻断截止时间推算。
+func (s *Store) HolidayCalendar(ctx context.Context) (*holiday.Calendar, error) {
+	return holidayCalendar(ctx, s.db)
+}
+
+// holidayCalendar 通过 q（数据库或事务）读取当前使用的节假日日历，见 HolidayCalendar。
+func holidayCalendar(ctx context.Context, q tagQuerier) (*holiday.Calendar, error) {
+	var raw string
+	err := q.QueryRowContext(ctx, `SELECT value FROM settings WHERE key = ?`, holidayCalendarKey).Scan(&raw)
+	if errors.Is(err, sql.ErrNoRows) {
+		return holiday.Bundled(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get holiday calendar: %w", err)
+	}
+	update, err := holiday.Parse([]byte(raw))
+	if err != nil {
+		return holiday.Bundled(), nil
+	}
+	return holiday.Bundled().Merge(update), nil
+}
+
+// ImportHolidayCalendar 校验并保存用户提供的节假日日历（与内置 cn.json 同格式），
+// 其中出现的年份整年替换内置数据。返回合并后的日历概要。
+func (s *Store) ImportHolidayCalendar(ctx context.Context, data []byte) (holiday.Info, error) {
+	update, err := holiday.Parse(data)
+	if err != nil {
+		return holiday.Info{}, ErrInvalidHolidayCalendar.with(err.Error())
+	}
+	if err := s.setSetting(ctx, holidayCalendarKey, string(data)); err != nil {
+		return holiday.Info{}, err
+	}
+	return holiday.Bundled().Merge(update).Info(), nil
+}
+
+// ResetHolidayCalendar 删除用户导入的日历，恢复使用内置日历。
+func (s *Store) ResetHolidayCalendar(ctx context.Context) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM settings WHERE key = ?`, holidayCalendarKey); err != nil {
+		return fmt.Errorf("reset holiday calendar: %w", err)
+	}
+	return nil
+}
+
+// NextWorkingDay 返回 from（UnixMilli，0 表示现在）所在日期之后的第一个工作日，保留 from 的时刻；
+// 用于"下一个工作日截止"这类快捷选项。日期按设置中的时区计算。
+func (s *Store) NextWorkingDay(ctx context.Context, from int64) (int64, error) {
+	settings, err := s.GetSettings(ctx)
+	if err != nil {
+		return 0, err
+	}
+	cal, err := s.HolidayCalendar(ctx)
+	if err != nil {
+		return 0, err
+	}
+	t := time.Now()
+	if from > 0 {
+		t = time.UnixMilli(from)
+	}
+	return cal.NextWorkday(t.In(Location(settings))).UnixMilli(), nil
+}
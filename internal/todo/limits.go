@@ -0,0 +1,83 @@
+package todo
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// 可配置的组名/任务标题上限（groupNameLimit / titleLimit 设置）的取值范围。
+const (
+	minGroupNameLimit = 10
+	maxGroupNameLimit = 200
+	minTitleLimit     = 50
+	maxTitleLimit     = 1000
+)
+
+// normalizeGroupNameLimit 校验组名上限设置。
+func normalizeGroupNameLimit(v string) (string, error) {
+	return normalizeLimit(v, minGroupNameLimit, maxGroupNameLimit, ErrInvalidGroupNameLimit)
+}
+
+// normalizeTitleLimit 校验任务标题上限设置。
+func normalizeTitleLimit(v string) (string, error) {
+	return normalizeLimit(v, minTitleLimit, maxTitleLimit, ErrInvalidTitleLimit)
+}
+
+// normalizeLimit 校验 v 为 [lo, hi] 内的整数，否则返回 invalid.with(lo, hi)。
+func normalizeLimit(v string, lo, hi int, invalid *Error) (string, error) {
+	n, err := strconv.Atoi(strings.TrimSpace(v))
+	if err != nil || n < lo || n > hi {
+		return "", invalid.with(lo, hi)
+	}
+	return strconv.Itoa(n), nil
+}
+
+// 超长输入的处理方式（lengthLimitMode 设置）。
+const (
+	LengthModeStrict   = "strict"   // 超过上限即报错（默认）
+	LengthModeTruncate = "truncate" // 略超上限时截断保存，并返回被截掉的部分
+	LengthModeLenient  = "lenient"  // 略超上限时原样保存，只返回提示
+)
+
+// 任务上可能产生长度提示的字段。
+const (
+	LengthFieldTitle   = "title"
+	LengthFieldContent = "content"
+)
+
+// LengthWarning 描述一次超过上限但未被拒绝的输入，供前端提示用户。
+type LengthWarning struct {
+	Field     string `json:"field"`              // LengthField*
+	Limit     int    `json:"limit"`              // 上限（字数）
+	Length    int    `json:"length"`             // 用户输入的字数
+	Truncated bool   `json:"truncated"`          // 是否已截断保存
+	Overflow  string `json:"overflow,omitempty"` // 被截掉的部分（截断时），前端可据此让用户找回
+}
+
+// lengthSlack 返回"略超上限"的容差：上限的 10%，至少 10 个字。
+// 超出容差的输入多半是误粘贴，仍按硬性错误处理。
+func lengthSlack(limit int) int {
+	return max(limit/10, 10)
+}
+
+// applyLengthLimit 按 mode 处理字段 field 的输入 v：
+// 未超上限时原样返回；略超上限时按 mode 截断或放行并返回提示；其余情况返回 tooLong.with(limit)。
+func applyLengthLimit(field, v string, limit int, mode string, tooLong *Error) (string, *LengthWarning, error) {
+	n := utf8.RuneCountInString(v)
+	if n <= limit {
+		return v, nil, nil
+	}
+	if mode == LengthModeStrict || n > limit+lengthSlack(limit) {
+		return "", nil, tooLong.with(limit)
+	}
+
+	w := &LengthWarning{Field: field, Limit: limit, Length: n}
+	if mode == LengthModeLenient {
+		return v, w, nil
+	}
+	runes := []rune(v)
+	w.Truncated = true
+	w.Overflow = string(runes[limit:])
+	return string(runes[:limit]), w, nil
+}
@@ -0,0 +1,178 @@
+package todo
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// maxTaskURLRunes 限制任务链接长度。
+	maxTaskURLRunes = 2000
+	// maxContentLinks 限制从任务内容中提取的链接数量。
+	maxContentLinks = 50
+)
+
+// contentLinkRe 匹配内容中的 http/https 链接（到空白、引号、尖括号或中文标点为止）。
+var contentLinkRe = regexp.MustCompile(`(?i)https?://[^\s<>"'\x60，。；：！？、（）【】「」『』《》]+`)
+
+// linkTrailingPunct 为链接末尾通常属于正文而非链接的标点。
+const linkTrailingPunct = `.,;:!?'"*_~)]}>`
+
+// TaskDetail 为任务详情：任务本身（含子任务与完整内容）、从内容中提取的链接及关联记录。
+type TaskDetail struct {
+	Task        Task         `json:"task"`
+	ContentHTML string       `json:"contentHtml"` // 渲染后的内容（已转义/清洗，可直接显示）
+	Links       []string     `json:"links"`       // 内容中出现的 http/https 链接（按出现顺序去重）
+	Tags        []Tag        `json:"tags"`
+	Attachments []Attachment `json:"attachments"`
+	Recurrence  *Recurrence  `json:"recurrence"` // 重复规则；未设置时为 null
+
+	StatusHistory []StatusChange `json:"statusHistory"` // 状态转换记录（按时间先后）
+}
+
+// isWebURL 判断 v 是否为带主机名的 http/https 绝对地址。
+func isWebURL(v string) bool {
+	u, err := url.Parse(v)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// IsSecureWebURL 报告 v 是否为 https 地址，或指向本机（localhost / 回环地址）的 http 地址。
+// 用于会发送凭据或下载可执行文件的地址（更新源、共享同步服务器），本机 http 便于本地调试。
+func IsSecureWebURL(v string) bool {
+	u, err := url.Parse(v)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	switch u.Scheme {
+	case "https":
+		return true
+	case "http":
+		host := u.Hostname()
+		if strings.EqualFold(host, "localhost") {
+			return true
+		}
+		ip := net.ParseIP(host)
+		return ip != nil && ip.IsLoopback()
+	}
+	return false
+}
+
+// normalizeTaskURL 校验任务链接：空字符串表示无链接，否则必须是 http/https 地址。
+func normalizeTaskURL(v string) (string, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return "", nil
+	}
+	if utf8.RuneCountInString(v) > maxTaskURLRunes {
+		return "", ErrTaskURLTooLong.with(maxTaskURLRunes)
+	}
+	if !isWebURL(v) {
+		return "", ErrInvalidTaskURL.with(v)
+	}
+	return v, nil
+}
+
+// TaskLink 返回任务的关联链接；任务没有链接时返回 ErrTaskNoLink。
+func (s *Store) TaskLink(ctx context.Context, id int64) (string, error) {
+	t, err := s.GetTask(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if t.URL == "" {
+		return "", ErrTaskNoLink.with(id)
+	}
+	return t.URL, nil
+}
+
+// ExtractLinks 提取文本中的 http/https 链接：按出现顺序去重，最多 maxContentLinks 个。
+//
+// 链接末尾的标点会被去掉，但与链接内左括号配对的右括号会保留（如维基百科链接）。
+func ExtractLinks(text string) []string {
+	links := []string{}
+	for _, m := range contentLinkRe.FindAllString(text, -1) {
+		m = trimLinkPunct(m)
+		if !isWebURL(m) || containsString(links, m) {
+			continue
+		}
+		links = append(links, m)
+		if len(links) == maxContentLinks {
+			break
+		}
+	}
+	return links
+}
+
+// trimLinkPunct 去掉链接末尾的标点。
+func trimLinkPunct(link string) string {
+	for link != "" {
+		r, size := utf8.DecodeLastRuneInString(link)
+		if !strings.ContainsRune(linkTrailingPunct, r) {
+			break
+		}
+		if r == ')' && strings.Count(link, "(") >= strings.Count(link, ")") {
+			break
+		}
+		link = link[:len(link)-size]
+	}
+	return link
+}
+
+// GetTaskDetail 返回任务详情（含子任务与完整内容）及其内容中的链接。
+func (s *Store) GetTaskDetail(ctx context.Context, id int64) (TaskDetail, error) {
+	t, err := s.GetTask(ctx, id)
+	if err != nil {
+		return TaskDetail{}, err
+	}
+	tasks := []Task{t}
+	if err := s.attachSubTasks(ctx, tasks); err != nil {
+		return TaskDetail{}, err
+	}
+	if err := s.LoadFullContent(ctx, tasks); err != nil {
+		return TaskDetail{}, err
+	}
+	t = tasks[0]
+	tags, err := s.ListTaskTags(ctx, id)
+	if err != nil {
+		return TaskDetail{}, err
+	}
+	attachments, err := s.ListAttachments(ctx, id)
+	if err != nil {
+		return TaskDetail{}, err
+	}
+	recurrence, err := s.GetRecurrence(ctx, id)
+	if err != nil {
+		return TaskDetail{}, err
+	}
+	history, err := s.StatusHistory(ctx, id)
+	if err != nil {
+		return TaskDetail{}, err
+	}
+	return TaskDetail{
+		Task:          t,
+		ContentHTML:   RenderContent(t.Content, t.ContentFormat),
+		Links:         ExtractLinks(t.Content),
+		Tags:          tags,
+		Attachments:   attachments,
+		Recurrence:    recurrence,
+		StatusHistory: history,
+	}, nil
+}
+
+// ContentLink 校验 link 确实出现在任务内容中（白名单），通过时返回该链接。
+//
+// 前端只能打开由后端从内容中提取出的链接，避免任意字符串被交给系统浏览器。
+func (s *Store) ContentLink(ctx context.Context, taskID int64, link string) (string, error) {
+	content, err := s.GetTaskContent(ctx, taskID)
+	if err != nil {
+		return "", err
+	}
+	link = strings.TrimSpace(link)
+	if !containsString(ExtractLinks(content), link) {
+		return "", ErrLinkNotInTask.with(link)
+	}
+	return link, nil
+}
@@ -0,0 +1,63 @@
+package todo
+
+import (
+	"time"
+
+	"spark-todo/internal/lunar"
+)
+
+// LunarDate 为公历/农历日期对照，供日期选择器按农历选择截止日期。
+type LunarDate struct {
+	lunar.Date
+	Solar    string `json:"solar"`    // 对应的公历日期（YYYY-MM-DD）
+	YearName string `json:"yearName"` // 干支纪年，如 "乙巳蛇年"
+	Text     string `json:"text"`     // 月日，如 "闰六月初五"
+	Festival string `json:"festival"` // 传统节日名称，不是节日时为空
+}
+
+// newLunarDate 组装公历日期 solar 与农历日期 d 的对照信息。
+func newLunarDate(solar time.Time, d lunar.Date) LunarDate {
+	return LunarDate{
+		Date:     d,
+		Solar:    solar.Format(dayKeyLayout),
+		YearName: d.YearName(),
+		Text:     d.String(),
+		Festival: d.Festival(),
+	}
+}
+
+// SolarToLunar 将公历日期（YYYY-MM-DD）转换为农历。
+func SolarToLunar(date string) (LunarDate, error) {
+	t, err := time.Parse(dayKeyLayout, date)
+	if err != nil {
+		return LunarDate{}, ErrInvalidDate.with(date)
+	}
+	d, err := lunar.FromSolar(t)
+	if err != nil {
+		return LunarDate{}, ErrLunarOutOfRange.with(lunar.MinYear, lunar.MaxYear)
+	}
+	return newLunarDate(t, d), nil
+}
+
+// LunarToSolar 将农历日期转换为公历；日期不存在（如当年没有该闰月、小月三十）时返回 ErrInvalidLunarDate。
+func LunarToSolar(d lunar.Date) (LunarDate, error) {
+	t, err := lunar.ToSolar(d, time.UTC)
+	if err != nil {
+		return LunarDate{}, ErrInvalidLunarDate.with(err.Error())
+	}
+	return newLunarDate(t, d), nil
+}
+
+// FillLunar 为 tasks（含子任务）中设置了截止时间的任务填充 DueLunar（按 loc 计算日期）；
+// 超出农历数据范围的日期留空。
+func FillLunar(tasks []Task, loc *time.Location) {
+	for i := range tasks {
+		t := &tasks[i]
+		if t.DueAt > 0 {
+			if d, err := lunar.FromSolar(time.UnixMilli(t.DueAt).In(loc)); err == nil {
+				t.DueLunar = d.Label()
+			}
+		}
+		FillLunar(t.SubTasks, loc)
+	}
+}
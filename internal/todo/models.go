@@ -1,7 +1,5 @@
 package todo
 
-import "fmt"
-
 // Status 表示任务状态。
 //
 // 为了与前端（JS/TS）对齐，这里使用 string 枚举值，并在数据库层通过 CHECK 约束保证合法性。
@@ -26,7 +24,7 @@ func ParseStatus(s string) (Status, error) {
 	case StatusTodo, StatusDoing, StatusDone:
 		return Status(s), nil
 	default:
-		return "", fmt.Errorf("无效的任务状态: %q", s)
+		return "", ErrInvalidStatus.with(s)
 	}
 }
 
@@ -38,6 +36,12 @@ func ParseStatus(s string) (Status, error) {
 type Group struct {
 	ID        int64  `json:"id"`
 	Name      string `json:"name"`
+	ParentID  int64  `json:"parentId"`  // 上级分组 ID（见 ListGroupTree）；0 表示顶层分组
+	Archived  bool   `json:"archived"`  // 已归档：不出现在默认看板中，但仍可搜索与恢复
+	Favorite  bool   `json:"favorite"`  // 常用分组：在分组列表与快速添加中排在最前
+	Color     string `json:"color"`     // 侧边栏中的分组颜色（#rrggbb）；空字符串表示不着色
+	Icon      string `json:"icon"`      // 分组图标（单个表情）；空字符串表示无图标
+	SortOrder int64  `json:"sortOrder"` // 手动排序位置（见 ReorderGroups），同为常用/非常用时升序排列
 	CreatedAt int64  `json:"createdAt"`
 	UpdatedAt int64  `json:"updatedAt"`
 }
@@ -54,17 +58,62 @@ type Group struct {
 // - ParentID == 0 => 主任务
 // - ParentID > 0  => 子任务，ParentID 指向父任务的 ID
 type Task struct {
-	ID        int64  `json:"id"`
-	GroupID   int64  `json:"groupId"`
-	ParentID  int64  `json:"parentId"`
-	Title     string `json:"title"`
-	Content   string `json:"content"`
-	Status    Status `json:"status"`
-	Important bool   `json:"important"`
-	Urgent    bool   `json:"urgent"`
-	CreatedAt int64  `json:"createdAt"`
-	UpdatedAt int64  `json:"updatedAt"`
-	SubTasks  []Task `json:"subTasks,omitempty"`
+	ID          int64  `json:"id"`
+	GroupID     int64  `json:"groupId"`
+	ParentID    int64  `json:"parentId"`
+	Title       string `json:"title"`
+	Content     string `json:"content"`
+	Status      Status `json:"status"`
+	Important   bool   `json:"important"`
+	Urgent      bool   `json:"urgent"`
+	Color       string `json:"color"`       // 卡片颜色（#rrggbb）；空表示默认
+	Emoji       string `json:"emoji"`       // 标题前显示的表情；空表示无
+	URL         string `json:"url"`         // 关联链接（工单/文档等，http/https）；空表示无
+	DueAt       int64  `json:"dueAt"`       // 截止时间（UnixMilli）；0 表示未设置
+	CompletedAt int64  `json:"completedAt"` // 完成时间（UnixMilli）；未完成为 0，重新打开时清零
+	CreatedAt   int64  `json:"createdAt"`
+	UpdatedAt   int64  `json:"updatedAt"`
+	SubTasks    []Task `json:"subTasks,omitempty"`
+
+	// ContentFormat 为内容格式："plain" | "markdown"（见 ContentFormat* 常量）
+	ContentFormat string `json:"contentFormat"`
+	// ContentTruncated 为 true 时 Content 只是预览，完整内容需通过 GetTaskContent / GetTaskDetail 加载
+	ContentTruncated bool `json:"contentTruncated"`
+	// ContentChanged 由前端在保存时设置：为 true 表示 Content 是用户改过的内容；
+	// 为 false 且 Content 与已存的预览相同时，UpsertTask 保留完整内容而不以预览覆盖
+	ContentChanged bool `json:"contentChanged,omitempty"`
+	// CoverID 为封面附件 ID（0 表示无封面）；只能通过 SetTaskCover 修改
+	CoverID int64 `json:"coverId"`
+	// Progress 为子任务清单的完成进度（由后端计算）；没有子任务时为 nil
+	Progress *Progress `json:"progress,omitempty"`
+	// Warnings 为保存时产生的长度提示（只出现在 UpsertTask 的返回值中）
+	Warnings []LengthWarning `json:"warnings,omitempty"`
+	// NextOccurrenceID 为重复任务完成时生成的下一次任务的 ID（只出现在 UpsertTask 的返回值中）
+	NextOccurrenceID int64 `json:"nextOccurrenceId,omitempty"`
+	// DueLunar 为截止日期对应的农历（节日显示节日名，如 "中秋节"，否则如 "六月初五"），
+	// 开启"显示农历"设置时由 GetBoard 填充
+	DueLunar string `json:"dueLunar,omitempty"`
+	// Countdown 标记为倒计时事项（如考试、纪念日）：看板显示距截止日还剩几天，并在剩 30/7/1 天时提醒
+	Countdown bool `json:"countdown"`
+	// DaysLeft 为倒计时事项距截止日的自然日天数（当天为 0，已过为负数），由 GetBoard 计算；非倒计时或无截止时间时为 nil
+	DaysLeft *int `json:"daysLeft,omitempty"`
+	// StatusSince 为进入当前状态的时间（UnixMilli），DaysInStatus 为当前状态已持续的自然日天数；由 GetBoard 填充
+	StatusSince  int64 `json:"statusSince,omitempty"`
+	DaysInStatus int   `json:"daysInStatus"`
+	// Tags 为任务上的标签名（按名称排序），由 GetBoard 通过 FillTags 填充；只能通过 SetTaskTags 修改
+	Tags []string `json:"tags,omitempty"`
+	// LatestNote 为最新的一条备注、NoteCount 为备注总数，由 GetBoard 通过 FillLatestNotes 填充；没有备注时为 nil / 0
+	LatestNote *TaskNote `json:"latestNote,omitempty"`
+	NoteCount  int       `json:"noteCount,omitempty"`
+	// Blocked 为 true 表示还有未完成的前置任务（见 AddTaskDependency），由 ListTasks / GetBoard 计算
+	Blocked bool `json:"blocked"`
+}
+
+// Progress 为父任务的子任务完成情况，前端据此直接绘制进度条。
+type Progress struct {
+	Done    int `json:"done"`
+	Total   int `json:"total"`
+	Percent int `json:"percent"` // 0-100
 }
 
 // Settings 为用户偏好设置（持久化到 SQLite settings 表）。
@@ -73,13 +122,60 @@ type Settings struct {
 	AlwaysOnTop bool   `json:"alwaysOnTop"`
 	ViewMode    string `json:"viewMode"`    // "list" | "cards"
 	ConciseMode bool   `json:"conciseMode"` // 简洁模式（控制窗口边框）
-	Theme       string `json:"theme"`       // "light" | "dark"
+	Theme       string `json:"theme"`       // "light" | "dark" | "system"（跟随系统）
+	UpdateURL   string `json:"updateUrl"`   // 自定义更新源（为空表示使用内置默认地址）
+	Language    string `json:"language"`    // 界面语言："zh-CN" | "en-US"
+	DateFormat  string `json:"dateFormat"`  // 日期格式："iso"（YYYY-MM-DD）| "locale"（跟随界面语言）
+	WeekStart   string `json:"weekStart"`   // 每周第一天："monday" | "sunday"
+	Timezone    string `json:"timezone"`    // IANA 时区名（如 "Asia/Shanghai"）；为空表示跟随系统
+	UIScale     string `json:"uiScale"`     // 界面缩放："small" | "normal" | "large"
+	ThemePreset string `json:"themePreset"` // 配色预设名（见 ThemePresets）
+	AccentColor string `json:"accentColor"` // 自定义强调色（#rrggbb）；为空表示使用预设颜色
+	// HighContrast / ReducedMotion："on" | "off" | "system"（跟随系统辅助功能设置）
+	HighContrast  string `json:"highContrast"`
+	ReducedMotion string `json:"reducedMotion"`
+	// 全局快捷键（规范写法如 "Ctrl+Alt+T"）；为空表示不注册
+	HotkeyShowWindow string `json:"hotkeyShowWindow"` // 显示并聚焦主窗口
+	HotkeyQuickAdd   string `json:"hotkeyQuickAdd"`   // 显示主窗口并打开快速添加
+	// ViewSorts 记录各视图的排序方式（视图名 -> "updated" | "created" | "due" | "title" | "manual" | "quadrant"）
+	ViewSorts map[string]string `json:"viewSorts"`
+	// ViewFilters 记录各视图最近一次使用的筛选条件（视图名 -> ViewFilter）
+	ViewFilters map[string]ViewFilter `json:"viewFilters"`
+	// Gamification 为 true 时完成任务会获得积分并解锁成就
+	Gamification bool `json:"gamification"`
+	// ShowLunar 为 true 时在截止日期旁显示农历日期与传统节日
+	ShowLunar bool `json:"showLunar"`
+	// CountdownReminders 为 true 时倒计时事项在剩 30/7/1 天时提醒
+	CountdownReminders bool `json:"countdownReminders"`
+	// TrashRetentionDays 为回收站保留天数，超过后自动永久删除；0 表示永不自动清理
+	TrashRetentionDays int `json:"trashRetentionDays"`
+	// ContentLimit / TitleLimit / GroupNameLimit 为任务内容、任务标题、组名的最大长度（字数）
+	ContentLimit   int `json:"contentLimit"`
+	TitleLimit     int `json:"titleLimit"`
+	GroupNameLimit int `json:"groupNameLimit"`
+	// LengthLimitMode 为标题/内容超过上限时的处理方式："strict"（默认）| "truncate" | "lenient"
+	LengthLimitMode string `json:"lengthLimitMode"`
+	// APIPort 为本地 REST 接口的端口；0 表示关闭
+	APIPort int `json:"apiPort"`
 }
 
 // Board 是前端渲染所需的聚合数据（一次请求拿到全部视图需要的数据）。
 type Board struct {
-	Groups   []Group  `json:"groups"`
-	Tasks    []Task   `json:"tasks"`
-	Settings Settings `json:"settings"`
-	Statuses []Status `json:"statuses"`
+	Groups         []Group       `json:"groups"`         // 未归档的分组
+	Tasks          []Task        `json:"tasks"`          // 未归档分组下的任务
+	ArchivedGroups []Group       `json:"archivedGroups"` // 已归档的分组（任务通过 GetArchive 获取）
+	Settings       Settings      `json:"settings"`
+	Statuses       []Status      `json:"statuses"`
+	Filter         ViewFilter    `json:"filter"`       // 当前视图（viewMode）上次使用的筛选条件
+	Accent         string        `json:"accent"`       // 实际生效的强调色
+	ThemePresets   []ThemePreset `json:"themePresets"` // 可选配色预设
+	Tags           []Tag         `json:"tags"`         // 全部标签（含任务数），用于标签筛选栏
+
+	// SyncedAt 为本次数据对应的时间点（UnixMilli），下次增量刷新时作为 since 传入
+	SyncedAt int64 `json:"syncedAt"`
+	// Delta 为 true 时为增量结果：Groups/ArchivedGroups/Tasks 只含 since 之后变化的部分，
+	// 另附已删除的分组/任务 ID（任务含子任务）
+	Delta           bool    `json:"delta"`
+	DeletedGroupIDs []int64 `json:"deletedGroupIds"`
+	DeletedTaskIDs  []int64 `json:"deletedTaskIds"`
 }
@@ -0,0 +1,246 @@
+package todo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxNoteRunes 限制一条备注的长度。
+const maxNoteRunes = 5000
+
+// TaskNote 为任务上一条带时间的备注（进展记录、相关链接等）。
+type TaskNote struct {
+	ID        int64  `json:"id"`
+	TaskID    int64  `json:"taskId"`
+	Body      string `json:"body"`
+	CreatedAt int64  `json:"createdAt"`
+	UpdatedAt int64  `json:"updatedAt"`
+}
+
+// noteColumns 为读取 TaskNote 时 SELECT 的列（顺序与 scanNote 一致）。
+const noteColumns = `id, task_id, body, created_at, updated_at`
+
+// scanNote 按 noteColumns 的列顺序读取一行备注。
+func scanNote(r rowScanner) (TaskNote, error) {
+	var n TaskNote
+	if err := r.Scan(&n.ID, &n.TaskID, &n.Body, &n.CreatedAt, &n.UpdatedAt); err != nil {
+		return TaskNote{}, err
+	}
+	return n, nil
+}
+
+// normalizeNoteBody 去掉首尾空白并校验长度。
+func normalizeNoteBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", ErrNoteEmpty
+	}
+	if utf8.RuneCountInString(body) > maxNoteRunes {
+		return "", ErrNoteTooLong.with(maxNoteRunes)
+	}
+	return body, nil
+}
+
+// touchTask 在事务中更新任务的 updated_at（备注变化时看板增量刷新能拿到最新备注）；任务不存在时返回 ErrTaskNotFound。
+func touchTask(ctx context.Context, tx *sql.Tx, taskID int64, now int64) error {
+	res, err := tx.ExecContext(ctx, `UPDATE tasks SET updated_at = ? WHERE id = ?`, now, taskID)
+	if err != nil {
+		return fmt.Errorf("touch task: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrTaskNotFound.with(taskID)
+	}
+	return nil
+}
+
+// AddTaskNote 为任务 taskID 添加一条备注，返回保存后的备注。
+func (s *Store) AddTaskNote(ctx context.Context, taskID int64, body string) (TaskNote, error) {
+	body, err := normalizeNoteBody(body)
+	if err != nil {
+		return TaskNote{}, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return TaskNote{}, fmt.Errorf("begin add task note: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UnixMilli()
+	if err := touchTask(ctx, tx, taskID, now); err != nil {
+		return TaskNote{}, err
+	}
+	res, err := tx.ExecContext(ctx,
+		`INSERT INTO task_notes(task_id, body, created_at, updated_at) VALUES(?, ?, ?, ?)`, taskID, body, now, now)
+	if err != nil {
+		return TaskNote{}, fmt.Errorf("add task note: %w", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return TaskNote{}, fmt.Errorf("add task note: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return TaskNote{}, fmt.Errorf("commit add task note: %w", err)
+	}
+	return TaskNote{ID: id, TaskID: taskID, Body: body, CreatedAt: now, UpdatedAt: now}, nil
+}
+
+// UpdateTaskNote 修改备注 id 的内容（保留原创建时间），返回更新后的备注；备注不存在时返回 ErrNoteNotFound。
+func (s *Store) UpdateTaskNote(ctx context.Context, id int64, body string) (TaskNote, error) {
+	body, err := normalizeNoteBody(body)
+	if err != nil {
+		return TaskNote{}, err
+	}
+	n, err := s.getTaskNote(ctx, id)
+	if err != nil {
+		return TaskNote{}, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return TaskNote{}, fmt.Errorf("begin update task note: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UnixMilli()
+	if err := touchTask(ctx, tx, n.TaskID, now); err != nil {
+		return TaskNote{}, err
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE task_notes SET body = ?, updated_at = ? WHERE id = ?`, body, now, id); err != nil {
+		return TaskNote{}, fmt.Errorf("update task note: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return TaskNote{}, fmt.Errorf("commit update task note: %w", err)
+	}
+	n.Body, n.UpdatedAt = body, now
+	return n, nil
+}
+
+// DeleteTaskNote 删除备注 id；备注不存在时返回 ErrNoteNotFound。
+func (s *Store) DeleteTaskNote(ctx context.Context, id int64) error {
+	n, err := s.getTaskNote(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin delete task note: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := touchTask(ctx, tx, n.TaskID, time.Now().UnixMilli()); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM task_notes WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("delete task note: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit delete task note: %w", err)
+	}
+	return nil
+}
+
+// getTaskNote 返回备注 id；不存在时返回 ErrNoteNotFound。
+func (s *Store) getTaskNote(ctx context.Context, id int64) (TaskNote, error) {
+	n, err := scanNote(s.db.QueryRowContext(ctx, `SELECT `+noteColumns+` FROM task_notes WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return TaskNote{}, ErrNoteNotFound.with(id)
+	}
+	if err != nil {
+		return TaskNote{}, fmt.Errorf("get task note: %w", err)
+	}
+	return n, nil
+}
+
+// GetTaskNotes 返回任务 taskID 的全部备注，最新的在前；任务不存在时返回 ErrTaskNotFound。
+func (s *Store) GetTaskNotes(ctx context.Context, taskID int64) ([]TaskNote, error) {
+	if _, err := s.GetTask(ctx, taskID); err != nil {
+		return nil, err
+	}
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+noteColumns+` FROM task_notes WHERE task_id = ? ORDER BY created_at DESC, id DESC`, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("list task notes: %w", err)
+	}
+	defer rows.Close()
+
+	out := []TaskNote{}
+	for rows.Next() {
+		n, err := scanNote(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan task note: %w", err)
+		}
+		out = append(out, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate task notes: %w", err)
+	}
+	return out, nil
+}
+
+// FillLatestNotes 为 tasks（含子任务）填充 LatestNote 与 NoteCount；全部备注通过 GetTaskNotes 按需加载。
+func (s *Store) FillLatestNotes(ctx context.Context, tasks []Task) error {
+	var ids []int64
+	var collect func([]Task)
+	collect = func(ts []Task) {
+		for _, t := range ts {
+			ids = append(ids, t.ID)
+			collect(t.SubTasks)
+		}
+	}
+	collect(tasks)
+	if len(ids) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(ids)
+	if err != nil {
+		return err
+	}
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+noteColumns+`, cnt FROM (
+		   SELECT `+noteColumns+`,
+		          ROW_NUMBER() OVER (PARTITION BY task_id ORDER BY created_at DESC, id DESC) AS rn,
+		          COUNT(*) OVER (PARTITION BY task_id) AS cnt
+		   FROM task_notes WHERE task_id IN (SELECT value FROM json_each(?))
+		 ) WHERE rn = 1`, string(data))
+	if err != nil {
+		return fmt.Errorf("query latest task notes: %w", err)
+	}
+	defer rows.Close()
+
+	latest := make(map[int64]TaskNote)
+	counts := make(map[int64]int)
+	for rows.Next() {
+		var n TaskNote
+		var cnt int
+		if err := rows.Scan(&n.ID, &n.TaskID, &n.Body, &n.CreatedAt, &n.UpdatedAt, &cnt); err != nil {
+			return fmt.Errorf("scan latest task note: %w", err)
+		}
+		latest[n.TaskID] = n
+		counts[n.TaskID] = cnt
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate latest task notes: %w", err)
+	}
+
+	var fill func([]Task)
+	fill = func(ts []Task) {
+		for i := range ts {
+			if n, ok := latest[ts[i].ID]; ok {
+				ts[i].LatestNote = &n
+				ts[i].NoteCount = counts[ts[i].ID]
+			}
+			fill(ts[i].SubTasks)
+		}
+	}
+	fill(tasks)
+	return nil
+}
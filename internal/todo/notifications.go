@@ -0,0 +1,171 @@
+package todo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// 通知类型（notifications.kind）。
+const (
+	NotificationWater       = "water"       // 喝水提醒
+	NotificationCountdown   = "countdown"   // 倒计时事项到达 30/7/1 天节点
+	NotificationAchievement = "achievement" // 解锁新成就
+	NotificationUpdate      = "update"      // 发现新版本
+	NotificationBackup      = "backup"      // 定时云备份失败
+	NotificationReminder    = "reminder"    // 任务提醒到时
+	NotificationPomodoro    = "pomodoro"    // 番茄钟专注完成
+)
+
+// 通知分页大小。
+const (
+	defaultNotificationPage = 50
+	maxNotificationPage     = 200
+)
+
+// Notification 为通知中心的一条通知。
+type Notification struct {
+	ID     int64  `json:"id"`
+	Kind   string `json:"kind"`  // Notification*
+	Title  string `json:"title"` // 生成时按用户语言写入
+	Body   string `json:"body"`
+	TaskID int64  `json:"taskId"` // 关联的任务；无关联为 0
+	// Data 为前端渲染所需的附加数据（如成就 ID、新版本号），可为空
+	Data map[string]any `json:"data,omitempty"`
+	// DedupeKey 非空时，相同 DedupeKey 的通知只保存一次（如同一个新版本只提醒一次）
+	DedupeKey string `json:"-"`
+	CreatedAt int64  `json:"createdAt"`
+	ReadAt    int64  `json:"readAt"` // 已读时间（UnixMilli）；未读为 0
+}
+
+// NotificationPage 为一页通知（按时间倒序）。
+type NotificationPage struct {
+	Items []Notification `json:"items"`
+	// NextCursor 传给下一次 ListNotifications 的 before 以继续向前翻页；没有更多时为 0
+	NextCursor int64 `json:"nextCursor"`
+	// Unread 为全部未读通知数（用于角标），与分页无关
+	Unread int `json:"unread"`
+}
+
+// AddNotification 保存一条通知，返回保存后的通知；created 为 false 表示已有相同 DedupeKey 的通知，未重复保存。
+func (s *Store) AddNotification(ctx context.Context, n Notification, now time.Time) (Notification, bool, error) {
+	n.Title = strings.TrimSpace(n.Title)
+	n.Body = strings.TrimSpace(n.Body)
+	data := ""
+	if len(n.Data) > 0 {
+		raw, err := json.Marshal(n.Data)
+		if err != nil {
+			return Notification{}, false, fmt.Errorf("encode notification data: %w", err)
+		}
+		data = string(raw)
+	}
+	n.CreatedAt, n.ReadAt = now.UnixMilli(), 0
+
+	res, err := s.db.ExecContext(ctx,
+		`INSERT INTO notifications(kind, title, body, task_id, data, dedupe_key, created_at, read_at)
+		 VALUES(?, ?, ?, ?, ?, ?, ?, 0)
+		 ON CONFLICT DO NOTHING`,
+		n.Kind, n.Title, n.Body, n.TaskID, data, n.DedupeKey, n.CreatedAt,
+	)
+	if err != nil {
+		return Notification{}, false, fmt.Errorf("add notification: %w", err)
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return Notification{}, false, nil
+	}
+	if n.ID, err = res.LastInsertId(); err != nil {
+		return Notification{}, false, fmt.Errorf("add notification: %w", err)
+	}
+	return n, true, nil
+}
+
+// ListNotifications 返回 before（上一页的 NextCursor，0 表示从最新开始）之前的 limit 条通知，按时间倒序；
+// unreadOnly 为 true 时只返回未读通知。
+func (s *Store) ListNotifications(ctx context.Context, before int64, unreadOnly bool, limit int) (NotificationPage, error) {
+	if limit <= 0 {
+		limit = defaultNotificationPage
+	}
+	limit = min(limit, maxNotificationPage)
+
+	where := `1 = 1`
+	args := []any{}
+	if before > 0 {
+		where += ` AND id < ?`
+		args = append(args, before)
+	}
+	if unreadOnly {
+		where += ` AND read_at = 0`
+	}
+	args = append(args, limit+1)
+
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, kind, title, body, task_id, data, created_at, read_at FROM notifications
+		 WHERE `+where+` ORDER BY id DESC LIMIT ?`, args...)
+	if err != nil {
+		return NotificationPage{}, fmt.Errorf("list notifications: %w", err)
+	}
+	defer rows.Close()
+
+	page := NotificationPage{Items: []Notification{}}
+	for rows.Next() {
+		var n Notification
+		var data string
+		if err := rows.Scan(&n.ID, &n.Kind, &n.Title, &n.Body, &n.TaskID, &data, &n.CreatedAt, &n.ReadAt); err != nil {
+			return NotificationPage{}, fmt.Errorf("scan notification: %w", err)
+		}
+		if data != "" {
+			// 附加数据损坏时忽略，通知本身仍可展示
+			_ = json.Unmarshal([]byte(data), &n.Data)
+		}
+		page.Items = append(page.Items, n)
+	}
+	if err := rows.Err(); err != nil {
+		return NotificationPage{}, fmt.Errorf("iterate notifications: %w", err)
+	}
+	if len(page.Items) > limit {
+		page.Items = page.Items[:limit]
+		page.NextCursor = page.Items[limit-1].ID
+	}
+
+	if page.Unread, err = s.CountUnreadNotifications(ctx); err != nil {
+		return NotificationPage{}, err
+	}
+	return page, nil
+}
+
+// CountUnreadNotifications 返回未读通知数。
+func (s *Store) CountUnreadNotifications(ctx context.Context) (int, error) {
+	var n int
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM notifications WHERE read_at = 0`).Scan(&n); err != nil {
+		return 0, fmt.Errorf("count unread notifications: %w", err)
+	}
+	return n, nil
+}
+
+// MarkNotificationsRead 将 ids 对应的通知标为已读（ids 为空时全部标为已读），返回最新的未读数。
+// 已读的通知保持原已读时间；不存在的 ID 被忽略。
+func (s *Store) MarkNotificationsRead(ctx context.Context, ids []int64, now time.Time) (int, error) {
+	query := `UPDATE notifications SET read_at = ? WHERE read_at = 0`
+	args := []any{now.UnixMilli()}
+	if len(ids) > 0 {
+		query += ` AND id IN (` + placeholders(len(ids)) + `)`
+		for _, id := range ids {
+			args = append(args, id)
+		}
+	}
+	if _, err := s.db.ExecContext(ctx, query, args...); err != nil {
+		return 0, fmt.Errorf("mark notifications read: %w", err)
+	}
+	return s.CountUnreadNotifications(ctx)
+}
+
+// PruneNotifications 删除 before（UnixMilli）之前的已读通知，返回删除条数；未读通知不会被清理。
+func (s *Store) PruneNotifications(ctx context.Context, before int64) (int64, error) {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM notifications WHERE read_at > 0 AND created_at < ?`, before)
+	if err != nil {
+		return 0, fmt.Errorf("prune notifications: %w", err)
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,269 @@
+package todo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// 番茄钟状态（pomodoro_sessions.state）。
+const (
+	PomodoroRunning   = "running"   // 专注中
+	PomodoroPaused    = "paused"    // 已暂停
+	PomodoroCompleted = "completed" // 专注满计划时长
+	PomodoroStopped   = "stopped"   // 提前结束
+)
+
+const (
+	// defaultPomodoroMinutes 为未指定时长时一个番茄钟的专注分钟数
+	defaultPomodoroMinutes = 25
+	// maxPomodoroMinutes 为一个番茄钟最长的专注分钟数
+	maxPomodoroMinutes = 180
+)
+
+// PomodoroSession 为一次番茄钟专注。
+//
+// 每段连续专注（开始/继续到暂停/结束）同时记为一条 time_entries（来源 TimeSourcePomodoro），
+// 因此暂停的时间不计入专注统计（见 GetFocusStats）。
+type PomodoroSession struct {
+	ID        int64  `json:"id"`
+	TaskID    int64  `json:"taskId"`
+	TaskTitle string `json:"taskTitle"` // 任务已删除时为空
+	State     string `json:"state"`     // Pomodoro*
+	// PlannedSeconds 为计划专注时长
+	PlannedSeconds int `json:"plannedSeconds"`
+	// FocusedSeconds 为已专注时长（进行中的一段按读取时间计算）
+	FocusedSeconds int `json:"focusedSeconds"`
+	// RemainingSeconds 为距离完成的剩余时长
+	RemainingSeconds int   `json:"remainingSeconds"`
+	StartedAt        int64 `json:"startedAt"`
+	EndedAt          int64 `json:"endedAt"` // 结束时间；未结束为 0
+}
+
+// pomodoroRow 为 pomodoro_sessions 中的一行。
+type pomodoroRow struct {
+	ID, TaskID                int64
+	TaskTitle, State          string
+	PlannedMs, FocusedMs      int64
+	SegmentStartedAt, EntryID int64
+	StartedAt, EndedAt        int64
+}
+
+// session 返回 now 时刻的番茄钟状态。
+func (r pomodoroRow) session(now int64) PomodoroSession {
+	focused := r.FocusedMs
+	if r.State == PomodoroRunning {
+		focused += max(now-r.SegmentStartedAt, 0)
+	}
+	focused = min(focused, r.PlannedMs)
+	return PomodoroSession{
+		ID:               r.ID,
+		TaskID:           r.TaskID,
+		TaskTitle:        r.TaskTitle,
+		State:            r.State,
+		PlannedSeconds:   int(r.PlannedMs / 1000),
+		FocusedSeconds:   int(focused / 1000),
+		RemainingSeconds: int((r.PlannedMs - focused + 999) / 1000),
+		StartedAt:        r.StartedAt,
+		EndedAt:          r.EndedAt,
+	}
+}
+
+// activePomodoro 在 q（数据库或事务）上读取进行中或已暂停的番茄钟；没有时返回 sql.ErrNoRows。
+func activePomodoro(ctx context.Context, q tagQuerier) (pomodoroRow, error) {
+	var r pomodoroRow
+	err := q.QueryRowContext(ctx,
+		`SELECT p.id, p.task_id, COALESCE(t.title, ''), p.state, p.planned_ms, p.focused_ms,
+		        p.segment_started_at, p.entry_id, p.started_at, p.ended_at
+		 FROM pomodoro_sessions p LEFT JOIN tasks t ON t.id = p.task_id
+		 WHERE p.state IN ('running', 'paused')
+		 ORDER BY p.id DESC LIMIT 1`,
+	).Scan(&r.ID, &r.TaskID, &r.TaskTitle, &r.State, &r.PlannedMs, &r.FocusedMs,
+		&r.SegmentStartedAt, &r.EntryID, &r.StartedAt, &r.EndedAt)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return pomodoroRow{}, fmt.Errorf("get active pomodoro: %w", err)
+	}
+	return r, err
+}
+
+// ActivePomodoro 返回进行中或已暂停的番茄钟；没有时返回 nil。
+func (s *Store) ActivePomodoro(ctx context.Context, now time.Time) (*PomodoroSession, error) {
+	r, err := activePomodoro(ctx, s.db)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	session := r.session(now.UnixMilli())
+	return &session, nil
+}
+
+// StartPomodoro 为任务 taskID 开始一个 minutes 分钟的番茄钟（0 为默认 25 分钟）。
+//
+// 同一时间只能有一个番茄钟：已有进行中或暂停的番茄钟时返回 ErrPomodoroActive；
+// 任务计时器进行中时返回 ErrTimerRunning（计时不能重叠）。
+func (s *Store) StartPomodoro(ctx context.Context, taskID int64, minutes int, now time.Time) (PomodoroSession, error) {
+	if minutes == 0 {
+		minutes = defaultPomodoroMinutes
+	}
+	if minutes < 1 || minutes > maxPomodoroMinutes {
+		return PomodoroSession{}, ErrInvalidPomodoroMinutes.with(1, maxPomodoroMinutes)
+	}
+	if _, err := s.GetTask(ctx, taskID); err != nil {
+		return PomodoroSession{}, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return PomodoroSession{}, fmt.Errorf("begin start pomodoro: %w", err)
+	}
+	defer tx.Rollback()
+
+	ms := now.UnixMilli()
+	if _, err := activePomodoro(ctx, tx); err == nil {
+		return PomodoroSession{}, ErrPomodoroActive
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return PomodoroSession{}, err
+	}
+	if err := checkNoOpenTimeEntry(ctx, tx, ms); err != nil {
+		return PomodoroSession{}, err
+	}
+	entryID, err := startTimeEntry(ctx, tx, taskID, TimeSourcePomodoro, ms)
+	if err != nil {
+		return PomodoroSession{}, err
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO pomodoro_sessions(task_id, state, planned_ms, focused_ms, segment_started_at, entry_id, started_at)
+		 VALUES(?, 'running', ?, 0, ?, ?, ?)`,
+		taskID, int64(minutes)*time.Minute.Milliseconds(), ms, entryID, ms,
+	); err != nil {
+		return PomodoroSession{}, fmt.Errorf("start pomodoro: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return PomodoroSession{}, fmt.Errorf("commit start pomodoro: %w", err)
+	}
+	session, err := s.ActivePomodoro(ctx, now)
+	if err != nil {
+		return PomodoroSession{}, err
+	}
+	return *session, nil
+}
+
+// PausePomodoro 暂停进行中的番茄钟；没有进行中的番茄钟时返回 ErrNoPomodoro。
+func (s *Store) PausePomodoro(ctx context.Context, now time.Time) (PomodoroSession, error) {
+	return s.updatePomodoro(ctx, now, func(r pomodoroRow) (string, bool) {
+		return PomodoroPaused, r.State == PomodoroRunning
+	})
+}
+
+// ResumePomodoro 继续已暂停的番茄钟；没有暂停的番茄钟时返回 ErrNoPomodoro，
+// 暂停期间开始的任务计时器仍在进行时返回 ErrTimerRunning。
+func (s *Store) ResumePomodoro(ctx context.Context, now time.Time) (PomodoroSession, error) {
+	return s.updatePomodoro(ctx, now, func(r pomodoroRow) (string, bool) {
+		return PomodoroRunning, r.State == PomodoroPaused
+	})
+}
+
+// StopPomodoro 结束进行中或已暂停的番茄钟：专注满计划时长记为 PomodoroCompleted，否则为 PomodoroStopped。
+// 没有番茄钟时返回 ErrNoPomodoro。
+//
+// 专注时长超过计划（如应用关闭期间到时）时，结束时间按计划完成的时刻记录，多出的时间不计入专注。
+func (s *Store) StopPomodoro(ctx context.Context, now time.Time) (PomodoroSession, error) {
+	return s.updatePomodoro(ctx, now, func(r pomodoroRow) (string, bool) {
+		if r.session(now.UnixMilli()).RemainingSeconds <= 0 {
+			return PomodoroCompleted, true
+		}
+		return PomodoroStopped, true
+	})
+}
+
+// updatePomodoro 将当前番茄钟转换到 next 返回的状态（ok 为 false 表示当前状态不允许该操作），
+// 同时结束或开始对应的时间记录，返回更新后的番茄钟。读取、检查与写入在同一事务中完成。
+func (s *Store) updatePomodoro(ctx context.Context, now time.Time, next func(r pomodoroRow) (string, bool)) (PomodoroSession, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return PomodoroSession{}, fmt.Errorf("begin update pomodoro: %w", err)
+	}
+	defer tx.Rollback()
+
+	r, err := activePomodoro(ctx, tx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return PomodoroSession{}, ErrNoPomodoro
+	}
+	if err != nil {
+		return PomodoroSession{}, err
+	}
+	state, ok := next(r)
+	if !ok {
+		return PomodoroSession{}, ErrNoPomodoro
+	}
+
+	ms := now.UnixMilli()
+	if r.State == PomodoroRunning {
+		// 结束当前这一段专注（不超过计划完成的时刻）
+		end := min(ms, r.SegmentStartedAt+r.PlannedMs-r.FocusedMs)
+		if _, err := tx.ExecContext(ctx, `UPDATE time_entries SET ended_at = ? WHERE id = ?`, end, r.EntryID); err != nil {
+			return PomodoroSession{}, fmt.Errorf("end pomodoro time entry: %w", err)
+		}
+		r.FocusedMs += max(end-r.SegmentStartedAt, 0)
+		r.SegmentStartedAt, r.EntryID = 0, 0
+		ms = end
+	}
+	if state == PomodoroRunning {
+		// 暂停期间可能开始了任务计时器
+		if err := checkNoOpenTimeEntry(ctx, tx, ms); err != nil {
+			return PomodoroSession{}, err
+		}
+		if r.EntryID, err = startTimeEntry(ctx, tx, r.TaskID, TimeSourcePomodoro, ms); err != nil {
+			return PomodoroSession{}, err
+		}
+		r.SegmentStartedAt = ms
+	}
+	if state == PomodoroCompleted || state == PomodoroStopped {
+		r.EndedAt = ms
+	}
+	r.State = state
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE pomodoro_sessions SET state = ?, focused_ms = ?, segment_started_at = ?, entry_id = ?, ended_at = ? WHERE id = ?`,
+		r.State, r.FocusedMs, r.SegmentStartedAt, r.EntryID, r.EndedAt, r.ID,
+	); err != nil {
+		return PomodoroSession{}, fmt.Errorf("update pomodoro: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return PomodoroSession{}, fmt.Errorf("commit update pomodoro: %w", err)
+	}
+	return r.session(ms), nil
+}
+
+// checkNoOpenTimeEntry 在 q（数据库或事务）上检查是否有进行中的时间记录：
+// 任务计时器进行中返回 ErrTimerRunning，番茄钟专注中返回 ErrPomodoroActive。
+func checkNoOpenTimeEntry(ctx context.Context, q tagQuerier, now int64) error {
+	open, err := openTimeEntry(ctx, q, now)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil
+	case err != nil:
+		return err
+	case open.Source == TimeSourcePomodoro:
+		return ErrPomodoroActive
+	default:
+		return ErrTimerRunning
+	}
+}
+
+// startTimeEntry 在事务中开始一条进行中的时间记录，返回记录 ID。
+func startTimeEntry(ctx context.Context, tx *sql.Tx, taskID int64, source string, startedAt int64) (int64, error) {
+	res, err := tx.ExecContext(ctx,
+		`INSERT INTO time_entries(task_id, source, started_at, ended_at) VALUES(?, ?, ?, 0)`, taskID, source, startedAt)
+	if err != nil {
+		return 0, fmt.Errorf("start time entry: %w", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("start time entry: %w", err)
+	}
+	return id, nil
+}
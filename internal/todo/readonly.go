@@ -0,0 +1,40 @@
+package todo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	sqlite "modernc.org/sqlite"
+	sqlitelib "modernc.org/sqlite/lib"
+)
+
+// SetReadOnly 开启或关闭只读模式。
+//
+// 只读模式通过 PRAGMA query_only 在数据库连接上生效（Store 只有一个连接），
+// 因此所有写操作（包括后续新增的接口）都会被 SQLite 拒绝，不依赖每个接口单独检查；
+// 被拒绝的写操作可用 IsReadOnly 识别并转换为 ErrReadOnly。
+func (s *Store) SetReadOnly(ctx context.Context, on bool) error {
+	if _, err := s.db.ExecContext(ctx, `PRAGMA query_only = `+boolTo01(on)); err != nil {
+		return fmt.Errorf("pragma query_only: %w", err)
+	}
+	s.readOnly.Store(on)
+	return nil
+}
+
+// ReadOnly 返回是否处于只读模式。
+func (s *Store) ReadOnly() bool {
+	return s.readOnly.Load()
+}
+
+// IsReadOnly 判断 err 是否为只读模式下被拒绝的写操作（ErrReadOnly 或 SQLite 的 SQLITE_READONLY）。
+func IsReadOnly(err error) bool {
+	if errors.Is(err, ErrReadOnly) {
+		return true
+	}
+	var se *sqlite.Error
+	if errors.As(err, &se) {
+		return se.Code()&0xff == sqlitelib.SQLITE_READONLY
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// 最近访问的方式（recent_tasks.kind）。
+const (
+	RecentViewed = "viewed" // 打开了任务详情
+	RecentEdited = "edited" // 新建或修改了任务
+)
+
+// 最近访问任务的数量。
+const (
+	defaultRecentTasks = 10
+	// maxRecentTasks 为 recent_tasks 保留的记录数，更早的访问记录会被清理
+	maxRecentTasks = 50
+)
+
+// RecentTask 为"最近访问"快速切换列表中的一项。
+type RecentTask struct {
+	Task      Task   `json:"task"`
+	GroupName string `json:"groupName"`
+	Kind      string `json:"kind"`      // 最近一次访问的方式（Recent*）
+	VisitedAt int64  `json:"visitedAt"` // 最近一次访问的时间（UnixMilli）
+}
+
+// RecordTaskVisit 记录一次任务访问（kind 为 Recent*），并只保留最近 maxRecentTasks 条记录。
+//
+// 任务不存在时不记录也不报错（例如详情打开后任务已在其他窗口中删除）。
+func (s *Store) RecordTaskVisit(ctx context.Context, taskID int64, kind string, now time.Time) error {
+	if kind != RecentViewed && kind != RecentEdited {
+		return fmt.Errorf("invalid visit kind %q", kind)
+	}
+	if _, err := s.db.ExecContext(ctx,
+		`INSERT INTO recent_tasks(task_id, kind, visited_at) SELECT id, ?, ? FROM tasks WHERE id = ?
+		 ON CONFLICT(task_id) DO UPDATE SET kind = excluded.kind, visited_at = excluded.visited_at`,
+		kind, now.UnixMilli(), taskID,
+	); err != nil {
+		return fmt.Errorf("record task visit: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx,
+		`DELETE FROM recent_tasks WHERE task_id NOT IN (
+			SELECT task_id FROM recent_tasks ORDER BY visited_at DESC, task_id DESC LIMIT ?
+		)`, maxRecentTasks,
+	); err != nil {
+		return fmt.Errorf("prune recent tasks: %w", err)
+	}
+	return nil
+}
+
+// ListRecentTasks 返回最近访问的 n 个任务，最近的在前；n <= 0 时使用默认值，超过上限时取上限。
+func (s *Store) ListRecentTasks(ctx context.Context, n int) ([]RecentTask, error) {
+	if n <= 0 {
+		n = defaultRecentTasks
+	}
+	n = min(n, maxRecentTasks)
+
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+taskColumns+`,
+		        COALESCE((SELECT name FROM groups WHERE groups.id = tasks.group_id), ''), r.kind, r.visited_at
+		 FROM recent_tasks r JOIN tasks ON tasks.id = r.task_id
+		 ORDER BY r.visited_at DESC, r.task_id DESC
+		 LIMIT ?`, n)
+	if err != nil {
+		return nil, fmt.Errorf("query recent tasks: %w", err)
+	}
+	defer rows.Close()
+
+	out := []RecentTask{}
+	for rows.Next() {
+		var item RecentTask
+		t, err := scanTask(extraScanner{r: rows, extra: []any{&item.GroupName, &item.Kind, &item.VisitedAt}})
+		if err != nil {
+			return nil, err
+		}
+		item.Task = t
+		out = append(out, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate recent tasks: %w", err)
+	}
+	return out, nil
+}
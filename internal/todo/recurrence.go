@@ -0,0 +1,390 @@
+package todo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"spark-todo/internal/holiday"
+	"spark-todo/internal/lunar"
+)
+
+// 重复周期。
+const (
+	RecurDaily   = "daily"
+	RecurWeekly  = "weekly"
+	RecurMonthly = "monthly"
+	RecurYearly  = "yearly"
+)
+
+// 重复方式：决定完成后下一次的截止时间如何计算。
+const (
+	// RecurModeSchedule 按原计划重复：下次截止 = 本次截止 + 周期（已错过的周期会被跳过），
+	// 适合"每月 1 号交报告"这类固定日程
+	RecurModeSchedule = "schedule"
+	// RecurModeCompletion 按完成时间重复：下次截止 = 完成当天 + 周期（沿用原截止时间的时刻），
+	// 适合"换滤芯后 30 天再换"这类以上次完成为准的事项
+	RecurModeCompletion = "completion"
+)
+
+// maxRecurInterval 限制重复间隔（"每 N 天/周/月/年"中的 N）。
+const maxRecurInterval = 365
+
+// Recurrence 为主任务的重复规则。任务完成时按规则生成下一次的新任务，规则随之转移到新任务上。
+type Recurrence struct {
+	TaskID    int64  `json:"taskId"`
+	Freq      string `json:"freq"`     // RecurDaily | RecurWeekly | RecurMonthly | RecurYearly
+	Interval  int    `json:"interval"` // 每隔几个周期，1-365
+	Mode      string `json:"mode"`     // RecurModeSchedule | RecurModeCompletion
+	CreatedAt int64  `json:"createdAt"`
+	UpdatedAt int64  `json:"updatedAt"`
+
+	// SkipHolidays 为 true 时，推算出的截止日若是周末或法定假日（调休上班日除外），顺延到下一个工作日；
+	// 节假日日历未收录的年份只跳过周末（见 holiday.Calendar.Covers）
+	SkipHolidays bool `json:"skipHolidays"`
+	// Lunar 为 true 时按农历推算（只用于每年重复，如农历生日、传统节日）：每年同一农历月日，
+	// 闰月按当年正常月份计算，三十在小月取廿九
+	Lunar bool `json:"lunar"`
+	// Weekdays 为每周重复时在哪几天重复（0 = 周日 ... 6 = 周六，如 [1, 3, 5] 为每周一、三、五）；
+	// 为空时按截止日所在的星期每 Interval 周重复一次
+	Weekdays []int `json:"weekdays,omitempty"`
+}
+
+// normalize 校验并规范化重复规则：周期/方式忽略大小写，间隔为 0 时视为 1，方式为空时视为按原计划。
+func (r Recurrence) normalize() (Recurrence, error) {
+	r.Freq = strings.ToLower(strings.TrimSpace(r.Freq))
+	switch r.Freq {
+	case RecurDaily, RecurWeekly, RecurMonthly, RecurYearly:
+	default:
+		return Recurrence{}, ErrInvalidRecurFreq.with(r.Freq)
+	}
+	if r.Interval == 0 {
+		r.Interval = 1
+	}
+	if r.Interval < 1 || r.Interval > maxRecurInterval {
+		return Recurrence{}, ErrInvalidRecurInterval.with(1, maxRecurInterval)
+	}
+	r.Mode = strings.ToLower(strings.TrimSpace(r.Mode))
+	switch r.Mode {
+	case "":
+		r.Mode = RecurModeSchedule
+	case RecurModeSchedule, RecurModeCompletion:
+	default:
+		return Recurrence{}, ErrInvalidRecurMode.with(r.Mode)
+	}
+	if r.Lunar && r.Freq != RecurYearly {
+		return Recurrence{}, ErrRecurLunarNotYearly
+	}
+	if len(r.Weekdays) > 0 {
+		if r.Freq != RecurWeekly {
+			return Recurrence{}, ErrRecurWeekdaysNotWeekly
+		}
+		for _, d := range r.Weekdays {
+			if d < 0 || d > 6 {
+				return Recurrence{}, ErrInvalidRecurWeekday.with(d)
+			}
+		}
+		r.Weekdays = slices.Compact(slices.Sorted(slices.Values(r.Weekdays)))
+	}
+	return r, nil
+}
+
+// advance 将 t 向后推进 n 个重复周期。按月/年推进时日期超出目标月天数则取月末（1 月 31 日的下个月为 2 月 28/29 日），
+// 且总是从 t 一次推算，不会因逐月取月末而越推越早。农历规则超出农历数据范围（2100 年以后）时按公历推算。
+func (r Recurrence) advance(t time.Time, n int) time.Time {
+	if r.Lunar {
+		if next, err := lunar.AddYears(t, n*r.Interval); err == nil {
+			return next
+		}
+	}
+	switch r.Freq {
+	case RecurDaily:
+		return t.AddDate(0, 0, n*r.Interval)
+	case RecurWeekly:
+		if len(r.Weekdays) > 0 {
+			return r.advanceWeekdays(t, n)
+		}
+		return t.AddDate(0, 0, n*7*r.Interval)
+	case RecurMonthly:
+		return addMonthsClamped(t, n*r.Interval)
+	default:
+		return addMonthsClamped(t, n*12*r.Interval)
+	}
+}
+
+// advanceWeekdays 将 t 向后推进到之后第 n 个落在 Weekdays 上的日期（时刻不变）。
+// 以 t 所在周（周日开始）为第一周，每 Interval 周中只有第一周参与重复。
+func (r Recurrence) advanceWeekdays(t time.Time, n int) time.Time {
+	var mask uint8
+	for _, d := range r.Weekdays {
+		mask |= 1 << d
+	}
+	weekStart := t.AddDate(0, 0, -int(t.Weekday()))
+	for n > 0 {
+		t = t.AddDate(0, 0, 1)
+		week := daysBetween(weekStart, t) / 7
+		if week%r.Interval == 0 && mask&(1<<t.Weekday()) != 0 {
+			n--
+		}
+	}
+	return t
+}
+
+// addMonthsClamped 返回 t 加 months 个月后的时间；目标月份没有对应日期时取该月最后一天。
+func addMonthsClamped(t time.Time, months int) time.Time {
+	y, m, d := t.Date()
+	first := time.Date(y, m+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(d, lastDay)-1)
+}
+
+// nextDue 计算任务在 completedAt 完成后下一次的截止时间（均为 UnixMilli，按 loc 计算日期）。
+//
+// 任务没有截止时间时两种方式都以完成时间为基准。开启 SkipHolidays 时按 cal 顺延到工作日，
+// 顺延只影响这一次的结果，下一次仍从原计划日期推算。
+func (r Recurrence) nextDue(dueAt, completedAt int64, loc *time.Location, cal *holiday.Calendar) int64 {
+	done := time.UnixMilli(completedAt).In(loc)
+	if dueAt <= 0 {
+		return r.skipHolidays(r.advance(done, 1), cal).UnixMilli()
+	}
+	due := time.UnixMilli(dueAt).In(loc)
+
+	if r.Mode == RecurModeCompletion {
+		y, m, d := done.Date()
+		base := time.Date(y, m, d, due.Hour(), due.Minute(), due.Second(), 0, loc)
+		return r.skipHolidays(r.advance(base, 1), cal).UnixMilli()
+	}
+
+	next := r.advance(due, 1)
+	for n := 2; !next.After(done); n++ {
+		next = r.advance(due, n)
+	}
+	return r.skipHolidays(next, cal).UnixMilli()
+}
+
+// skipHolidays 在开启 SkipHolidays 时把 t 顺延到当天或之后的第一个工作日。
+func (r Recurrence) skipHolidays(t time.Time, cal *holiday.Calendar) time.Time {
+	if !r.SkipHolidays || cal == nil {
+		return t
+	}
+	return cal.OnOrAfterWorkday(t)
+}
+
+// recurrenceColumns 为读取 Recurrence 时 SELECT 的列（顺序与 scanRecurrence 一致）。
+const recurrenceColumns = `task_id, freq, interval, mode, created_at, updated_at, skip_holidays, lunar, weekdays`
+
+// scanRecurrence 按 recurrenceColumns 的列顺序读取一行重复规则。
+func scanRecurrence(r rowScanner) (Recurrence, error) {
+	var rec Recurrence
+	var weekdays string
+	if err := r.Scan(&rec.TaskID, &rec.Freq, &rec.Interval, &rec.Mode, &rec.CreatedAt, &rec.UpdatedAt, &rec.SkipHolidays, &rec.Lunar, &weekdays); err != nil {
+		return Recurrence{}, err
+	}
+	rec.Weekdays = parseWeekdays(weekdays)
+	return rec, nil
+}
+
+// formatWeekdays 将星期列表编码为 task_recurrence.weekdays 的存储格式（如 "1,3,5"）。
+func formatWeekdays(days []int) string {
+	parts := make([]string, len(days))
+	for i, d := range days {
+		parts[i] = strconv.Itoa(d)
+	}
+	return strings.Join(parts, ",")
+}
+
+// parseWeekdays 解析 formatWeekdays 的结果；无法识别的值被忽略。
+func parseWeekdays(s string) []int {
+	var out []int
+	for _, p := range strings.Split(s, ",") {
+		if d, err := strconv.Atoi(strings.TrimSpace(p)); err == nil && d >= 0 && d <= 6 {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// GetRecurrence 返回任务的重复规则；未设置时返回 (nil, nil)。
+func (s *Store) GetRecurrence(ctx context.Context, taskID int64) (*Recurrence, error) {
+	return getRecurrence(ctx, s.db, taskID)
+}
+
+// getRecurrence 通过 q（数据库或事务）读取任务的重复规则，没有时返回 nil。
+func getRecurrence(ctx context.Context, q tagQuerier, taskID int64) (*Recurrence, error) {
+	rec, err := scanRecurrence(q.QueryRowContext(ctx,
+		`SELECT `+recurrenceColumns+` FROM task_recurrence WHERE task_id = ?`, taskID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get recurrence: %w", err)
+	}
+	return &rec, nil
+}
+
+// SetRecurrence 为主任务设置（或替换）重复规则，返回落库后的规则。子任务不能单独重复。
+func (s *Store) SetRecurrence(ctx context.Context, r Recurrence) (Recurrence, error) {
+	r, err := r.normalize()
+	if err != nil {
+		return Recurrence{}, err
+	}
+	t, err := s.GetTask(ctx, r.TaskID)
+	if err != nil {
+		return Recurrence{}, err
+	}
+	if t.ParentID > 0 {
+		return Recurrence{}, ErrRecurSubTask
+	}
+
+	now := time.Now().UnixMilli()
+	if _, err := s.db.ExecContext(ctx,
+		`INSERT INTO task_recurrence(task_id, freq, interval, mode, skip_holidays, lunar, weekdays, created_at, updated_at) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?)
+		 ON CONFLICT(task_id) DO UPDATE SET freq = excluded.freq, interval = excluded.interval, mode = excluded.mode,
+		   skip_holidays = excluded.skip_holidays, lunar = excluded.lunar, weekdays = excluded.weekdays, updated_at = excluded.updated_at`,
+		r.TaskID, r.Freq, r.Interval, r.Mode, boolTo01Int(r.SkipHolidays), boolTo01Int(r.Lunar), formatWeekdays(r.Weekdays), now, now,
+	); err != nil {
+		return Recurrence{}, fmt.Errorf("set recurrence: %w", err)
+	}
+	rec, err := s.GetRecurrence(ctx, r.TaskID)
+	if err != nil {
+		return Recurrence{}, err
+	}
+	return *rec, nil
+}
+
+// ClearRecurrence 取消任务的重复规则（未设置时不报错）。
+func (s *Store) ClearRecurrence(ctx context.Context, taskID int64) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM task_recurrence WHERE task_id = ?`, taskID); err != nil {
+		return fmt.Errorf("clear recurrence: %w", err)
+	}
+	return nil
+}
+
+// materializeRecurrence 在重复任务 taskID 完成后生成下一次的任务（单独开启事务，见 materializeRecurrenceTx）。
+// 任务没有重复规则时什么也不做，返回 0。
+func (s *Store) materializeRecurrence(ctx context.Context, taskID int64, completedAt int64) (int64, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("begin materialize recurrence: %w", err)
+	}
+	defer tx.Rollback()
+
+	newID, err := materializeRecurrenceTx(ctx, tx, taskID, completedAt)
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit materialize recurrence: %w", err)
+	}
+	return newID, nil
+}
+
+// materializeRecurrenceTx 在事务 tx 中为完成的重复任务 taskID 生成下一次的任务：
+// 复制标题、内容、标记与标签，子任务重置为待办，截止时间按规则推算；重复规则随之转移到新任务。
+// 任务没有重复规则时什么也不做，返回 0。设置与节假日日历也经 tx 读取（连接只有一个）。
+func materializeRecurrenceTx(ctx context.Context, tx *sql.Tx, taskID int64, completedAt int64) (int64, error) {
+	rec, err := getRecurrence(ctx, tx, taskID)
+	if err != nil || rec == nil {
+		return 0, err
+	}
+	var dueAt int64
+	if err := tx.QueryRowContext(ctx, `SELECT due_at FROM tasks WHERE id = ?`, taskID).Scan(&dueAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrTaskNotFound.with(taskID)
+		}
+		return 0, fmt.Errorf("get task due: %w", err)
+	}
+	settings, err := getSettings(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+	var cal *holiday.Calendar
+	if rec.SkipHolidays {
+		if cal, err = holidayCalendar(ctx, tx); err != nil {
+			return 0, err
+		}
+	}
+	nextDue := rec.nextDue(dueAt, completedAt, Location(settings), cal)
+
+	now := time.Now().UnixMilli()
+	res, err := tx.ExecContext(ctx,
+		`INSERT INTO tasks(group_id, parent_id, title, content, content_overflow, content_format, status, important, urgent, color, emoji, url, countdown, due_at, sort_order, completed_at, created_at, updated_at)
+		 SELECT group_id, 0, title, content, content_overflow, content_format, 'todo', important, urgent, color, emoji, url, countdown, ?,
+		        (SELECT COALESCE(MAX(sort_order), 0) + 1 FROM tasks), 0, ?, ?
+		 FROM tasks WHERE id = ?`,
+		nextDue, now, now, taskID,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("create next occurrence: %w", err)
+	}
+	newID, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("get next occurrence id: %w", err)
+	}
+
+	copies := []struct {
+		name  string
+		query string
+		args  []any
+	}{
+		{"content", `INSERT INTO task_content(task_id, content, compressed) SELECT ?, content, compressed FROM task_content WHERE task_id = ?`, []any{newID, taskID}},
+		{"search index", `UPDATE task_fts SET content = (SELECT content FROM task_fts WHERE rowid = ?) WHERE rowid = ?`, []any{taskID, newID}},
+		{"tags", `INSERT INTO task_tags(task_id, tag_id) SELECT ?, tag_id FROM task_tags WHERE task_id = ?`, []any{newID, taskID}},
+		{"recurrence", `UPDATE task_recurrence SET task_id = ?, updated_at = ? WHERE task_id = ?`, []any{newID, now, taskID}},
+	}
+	for _, c := range copies {
+		if _, err := tx.ExecContext(ctx, c.query, c.args...); err != nil {
+			return 0, fmt.Errorf("copy %s to next occurrence: %w", c.name, err)
+		}
+	}
+	if err := copySubtasks(ctx, tx, taskID, newID, now); err != nil {
+		return 0, err
+	}
+	return newID, nil
+}
+
+// copySubtasks 把 fromID 的子任务重置为待办后复制到 toID 下，
+// 连同超长内容与搜索索引中的完整内容一起复制。
+func copySubtasks(ctx context.Context, tx *sql.Tx, fromID, toID, now int64) error {
+	subIDs, err := queryAll(ctx, tx, func(r rowScanner) (int64, error) {
+		var id int64
+		err := r.Scan(&id)
+		return id, err
+	}, `SELECT id FROM tasks WHERE parent_id = ? ORDER BY sort_order, id`, fromID)
+	if err != nil {
+		return fmt.Errorf("list subtasks to copy: %w", err)
+	}
+	for _, subID := range subIDs {
+		res, err := tx.ExecContext(ctx,
+			`INSERT INTO tasks(group_id, parent_id, title, content, content_overflow, content_format, status, important, urgent, color, emoji, url, due_at, sort_order, completed_at, created_at, updated_at)
+			 SELECT group_id, ?, title, content, content_overflow, content_format, 'todo', important, urgent, color, emoji, url, 0, sort_order, 0, ?, ?
+			 FROM tasks WHERE id = ?`,
+			toID, now, now, subID,
+		)
+		if err != nil {
+			return fmt.Errorf("copy subtask: %w", err)
+		}
+		newSubID, err := res.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("get copied subtask id: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO task_content(task_id, content, compressed) SELECT ?, content, compressed FROM task_content WHERE task_id = ?`,
+			newSubID, subID,
+		); err != nil {
+			return fmt.Errorf("copy subtask content: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE task_fts SET content = (SELECT content FROM task_fts WHERE rowid = ?) WHERE rowid = ?`,
+			subID, newSubID,
+		); err != nil {
+			return fmt.Errorf("copy subtask search index: %w", err)
+		}
+	}
+	return nil
+}
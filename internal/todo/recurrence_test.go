@@ -0,0 +1,114 @@
+package todo
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// nextOccurrence 返回 parent 完成后生成的下一次任务（未完成的同名主任务）。
+func nextOccurrence(t *testing.T, s *Store, parent Task) Task {
+	t.Helper()
+	tasks, err := s.ListTasks(context.Background(), SortUpdated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, task := range tasks {
+		if task.ID != parent.ID && task.ParentID == 0 && task.Title == parent.Title && task.Status == StatusTodo {
+			return task
+		}
+	}
+	t.Fatalf("no next occurrence of %q in %+v", parent.Title, tasks)
+	return Task{}
+}
+
+func TestRecurrenceCopiesLongSubtaskContent(t *testing.T) {
+	ctx := context.Background()
+	s := openTestStore(t)
+	groupID := firstGroupID(t, s)
+
+	parent := createTask(t, s, Task{GroupID: groupID, Title: "weekly", Status: StatusTodo})
+	long := strings.Repeat("子任务内容", contentPreviewRunes) + " needle"
+	createTask(t, s, Task{GroupID: groupID, ParentID: parent.ID, Title: "step", Content: long})
+	if _, err := s.SetRecurrence(ctx, Recurrence{TaskID: parent.ID, Freq: RecurDaily, Interval: 1, Mode: RecurModeCompletion}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.BulkUpdateStatus(ctx, []int64{parent.ID}, StatusDone); err != nil {
+		t.Fatal(err)
+	}
+
+	next := nextOccurrence(t, s, parent)
+	if len(next.SubTasks) != 1 {
+		t.Fatalf("next occurrence subtasks = %+v, want 1", next.SubTasks)
+	}
+	copied := next.SubTasks[0]
+	if !copied.ContentTruncated {
+		t.Error("copied subtask lost content overflow flag")
+	}
+	content, err := s.GetTaskContent(ctx, copied.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content != long {
+		t.Errorf("copied subtask content has %d runes, want %d", len([]rune(content)), len([]rune(long)))
+	}
+	if ids := searchIDs(t, s, "needle"); len(ids) != 2 {
+		t.Errorf("search copied subtask content = %v, want both subtasks", ids)
+	}
+}
+
+func TestReviewArchiveMaterializesRecurrence(t *testing.T) {
+	ctx := context.Background()
+	s := openTestStore(t)
+	task := createTask(t, s, Task{GroupID: firstGroupID(t, s), Title: "water plants", Status: StatusTodo})
+	if _, err := s.SetRecurrence(ctx, Recurrence{TaskID: task.ID, Freq: RecurWeekly, Interval: 1, Mode: RecurModeCompletion}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.ReviewTask(ctx, task.ID, ReviewArchive, 0, time.Now()); err != nil {
+		t.Fatalf("archive: %v", err)
+	}
+	archived, err := s.GetTask(ctx, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if archived.Status != StatusDone {
+		t.Errorf("archived status = %q", archived.Status)
+	}
+	next := nextOccurrence(t, s, task)
+	if rec, err := s.GetRecurrence(ctx, next.ID); err != nil || rec == nil {
+		t.Errorf("recurrence not moved to next occurrence: %v %v", rec, err)
+	}
+}
+
+func TestBulkCompleteSubtasksMaterializesRecurringParent(t *testing.T) {
+	ctx := context.Background()
+	s := openTestStore(t)
+	groupID := firstGroupID(t, s)
+
+	parent := createTask(t, s, Task{GroupID: groupID, Title: "checklist", Status: StatusTodo})
+	a := createTask(t, s, Task{GroupID: groupID, ParentID: parent.ID, Title: "a"})
+	b := createTask(t, s, Task{GroupID: groupID, ParentID: parent.ID, Title: "b"})
+	if _, err := s.SetRecurrence(ctx, Recurrence{TaskID: parent.ID, Freq: RecurDaily, Interval: 1, Mode: RecurModeCompletion, SkipHolidays: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.BulkUpdateStatus(ctx, []int64{a.ID, b.ID}, StatusDone); err != nil {
+		t.Fatalf("bulk complete subtasks: %v", err)
+	}
+	done, err := s.GetTask(ctx, parent.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if done.Status != StatusDone {
+		t.Errorf("parent status = %q, want done", done.Status)
+	}
+	next := nextOccurrence(t, s, parent)
+	if len(next.SubTasks) != 2 || next.DueAt == 0 {
+		t.Errorf("next occurrence = %+v, want 2 subtasks and a due date", next)
+	}
+	if rec, err := s.GetRecurrence(ctx, parent.ID); err != nil || rec != nil {
+		t.Errorf("recurrence still on completed parent: %v %v", rec, err)
+	}
+}
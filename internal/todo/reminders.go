@@ -0,0 +1,192 @@
+package todo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// maxSnoozeMinutes 为一次"稍后提醒"最多推迟的分钟数。
+const maxSnoozeMinutes = 24 * 60
+
+// snoozeUntil 返回从 now 起推迟 minutes 分钟后的时间（UnixMilli）；minutes 须在 1 到 maxSnoozeMinutes 之间。
+func snoozeUntil(now time.Time, minutes int) (int64, error) {
+	if minutes < 1 || minutes > maxSnoozeMinutes {
+		return 0, ErrInvalidSnooze.with(1, maxSnoozeMinutes)
+	}
+	return now.Add(time.Duration(minutes) * time.Minute).UnixMilli(), nil
+}
+
+// Reminder 为任务提醒。
+type Reminder struct {
+	ID        int64  `json:"id"`
+	TaskID    int64  `json:"taskId"`
+	TaskTitle string `json:"taskTitle"`
+	RemindAt  int64  `json:"remindAt"` // 提醒时间（UnixMilli）
+	FiredAt   int64  `json:"firedAt"`  // 实际提醒时间；尚未提醒为 0
+	CreatedAt int64  `json:"createdAt"`
+}
+
+// reminderColumns 为读取 Reminder 时 SELECT 的列（顺序与 scanReminder 一致，需 JOIN tasks）。
+const reminderColumns = `r.id, r.task_id, t.title, r.remind_at, r.fired_at, r.created_at`
+
+// scanReminder 按 reminderColumns 的列顺序读取一行提醒。
+func scanReminder(r rowScanner) (Reminder, error) {
+	var rm Reminder
+	if err := r.Scan(&rm.ID, &rm.TaskID, &rm.TaskTitle, &rm.RemindAt, &rm.FiredAt, &rm.CreatedAt); err != nil {
+		return Reminder{}, err
+	}
+	return rm, nil
+}
+
+// SetTaskReminder 设置任务的提醒时间（每个任务只有一个提醒，重复设置会覆盖并重新等待提醒），返回保存后的提醒。
+//
+// remindAt 早于当前时间时，后台会在下一次检查时立即提醒。
+func (s *Store) SetTaskReminder(ctx context.Context, taskID int64, remindAt time.Time) (Reminder, error) {
+	if taskID <= 0 {
+		return Reminder{}, ErrInvalidTaskID
+	}
+	if remindAt.IsZero() || remindAt.UnixMilli() <= 0 {
+		return Reminder{}, ErrInvalidReminderTime
+	}
+	if _, err := s.GetTask(ctx, taskID); err != nil {
+		return Reminder{}, err
+	}
+	if _, err := s.db.ExecContext(ctx,
+		`INSERT INTO reminders(task_id, remind_at, fired_at, created_at) VALUES(?, ?, 0, ?)
+		 ON CONFLICT(task_id) DO UPDATE SET remind_at = excluded.remind_at, fired_at = 0`,
+		taskID, remindAt.UnixMilli(), time.Now().UnixMilli(),
+	); err != nil {
+		return Reminder{}, fmt.Errorf("set task reminder: %w", err)
+	}
+	return s.GetTaskReminder(ctx, taskID)
+}
+
+// GetTaskReminder 返回任务的提醒；没有设置提醒时返回 ErrReminderNotFound。
+func (s *Store) GetTaskReminder(ctx context.Context, taskID int64) (Reminder, error) {
+	rm, err := scanReminder(s.db.QueryRowContext(ctx,
+		`SELECT `+reminderColumns+` FROM reminders r JOIN tasks t ON t.id = r.task_id WHERE r.task_id = ?`, taskID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return Reminder{}, ErrReminderNotFound.with(taskID)
+	}
+	if err != nil {
+		return Reminder{}, fmt.Errorf("get task reminder: %w", err)
+	}
+	return rm, nil
+}
+
+// ClearTaskReminder 取消任务的提醒；没有设置提醒时返回 ErrReminderNotFound。
+func (s *Store) ClearTaskReminder(ctx context.Context, taskID int64) error {
+	if taskID <= 0 {
+		return ErrInvalidTaskID
+	}
+	res, err := s.db.ExecContext(ctx, `DELETE FROM reminders WHERE task_id = ?`, taskID)
+	if err != nil {
+		return fmt.Errorf("clear task reminder: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrReminderNotFound.with(taskID)
+	}
+	return nil
+}
+
+// SnoozeReminder 将提醒 id 推迟到 now 之后 minutes 分钟再次提醒（通常在提醒弹出后调用），返回更新后的提醒。
+//
+// 提醒已被取消（或任务已删除）时返回 ErrReminderGone。
+func (s *Store) SnoozeReminder(ctx context.Context, id int64, now time.Time, minutes int) (Reminder, error) {
+	until, err := snoozeUntil(now, minutes)
+	if err != nil {
+		return Reminder{}, err
+	}
+	res, err := s.db.ExecContext(ctx, `UPDATE reminders SET remind_at = ?, fired_at = 0 WHERE id = ?`, until, id)
+	if err != nil {
+		return Reminder{}, fmt.Errorf("snooze reminder: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return Reminder{}, ErrReminderGone
+	}
+	rm, err := scanReminder(s.db.QueryRowContext(ctx,
+		`SELECT `+reminderColumns+` FROM reminders r JOIN tasks t ON t.id = r.task_id WHERE r.id = ?`, id))
+	if err != nil {
+		return Reminder{}, fmt.Errorf("reload reminder: %w", err)
+	}
+	return rm, nil
+}
+
+// ListReminders 返回尚未提醒的提醒，按提醒时间升序。
+func (s *Store) ListReminders(ctx context.Context) ([]Reminder, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+reminderColumns+` FROM reminders r JOIN tasks t ON t.id = r.task_id
+		 WHERE r.fired_at = 0 ORDER BY r.remind_at, r.id`)
+	if err != nil {
+		return nil, fmt.Errorf("list reminders: %w", err)
+	}
+	defer rows.Close()
+
+	out := []Reminder{}
+	for rows.Next() {
+		rm, err := scanReminder(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan reminder: %w", err)
+		}
+		out = append(out, rm)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate reminders: %w", err)
+	}
+	return out, nil
+}
+
+// TakeDueReminders 返回到时（remind_at 不晚于 now）且尚未提醒的提醒，并标记为已提醒（每个提醒只返回一次）。
+//
+// 任务已完成的提醒同样标记为已提醒，但不返回。
+func (s *Store) TakeDueReminders(ctx context.Context, now time.Time) ([]Reminder, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+reminderColumns+`, t.status FROM reminders r JOIN tasks t ON t.id = r.task_id
+		 WHERE r.fired_at = 0 AND r.remind_at <= ? ORDER BY r.remind_at, r.id`, now.UnixMilli())
+	if err != nil {
+		return nil, fmt.Errorf("list due reminders: %w", err)
+	}
+	var due []Reminder
+	var ids []int64
+	for rows.Next() {
+		var rm Reminder
+		var status string
+		if err := rows.Scan(&rm.ID, &rm.TaskID, &rm.TaskTitle, &rm.RemindAt, &rm.FiredAt, &rm.CreatedAt, &status); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan due reminder: %w", err)
+		}
+		ids = append(ids, rm.ID)
+		if Status(status) != StatusDone {
+			rm.FiredAt = now.UnixMilli()
+			due = append(due, rm)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate due reminders: %w", err)
+	}
+	if len(ids) == 0 {
+		return []Reminder{}, nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin take reminders: %w", err)
+	}
+	defer tx.Rollback()
+	for _, id := range ids {
+		if _, err := tx.ExecContext(ctx, `UPDATE reminders SET fired_at = ? WHERE id = ?`, now.UnixMilli(), id); err != nil {
+			return nil, fmt.Errorf("mark reminder fired: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit take reminders: %w", err)
+	}
+	if due == nil {
+		due = []Reminder{}
+	}
+	return due, nil
+}
@@ -0,0 +1,120 @@
+package todo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	sqlite "modernc.org/sqlite"
+	sqlitelib "modernc.org/sqlite/lib"
+)
+
+// busyRetryDelays 为写操作在 busy_timeout（5s）内仍拿不到写锁时的重试等待时间（逐次退避）。
+//
+// 同一进程内 Store 只有一个连接，不会自己和自己争锁；锁冲突来自同时写库的其他进程
+// （如命令行工具、REST 接口或同步盘另一端的程序），短暂退避后通常即可成功。
+var busyRetryDelays = []time.Duration{
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2 * time.Second,
+}
+
+// BusyEvent 描述一次写锁冲突（供前端提示"数据库正被其他程序占用"）。
+type BusyEvent struct {
+	Op       string `json:"op"`       // "exec"（单条写语句）或 "begin"（开始事务）
+	Attempt  int    `json:"attempt"`  // 第几次重试；GaveUp 时为总重试次数
+	WaitedMs int64  `json:"waitedMs"` // 截至目前的退避等待总时长（不含 busy_timeout）
+	GaveUp   bool   `json:"gaveUp"`   // 重试用尽，操作以 ErrDatabaseBusy 失败
+}
+
+// retryDB 包装 *sql.DB：写语句（ExecContext）与开始事务（BeginTx）遇到 SQLITE_BUSY 时按 busyRetryDelays 退避重试，
+// 重试用尽后返回 ErrDatabaseBusy（仍保留原始错误链）。
+//
+// 事务以 BEGIN IMMEDIATE 开始（见 Open 的 _txlock 参数），锁冲突只会出现在 BeginTx，
+// 因此事务内部的语句无需、也不应单独重试。
+type retryDB struct {
+	*sql.DB
+	onBusy atomic.Pointer[func(BusyEvent)]
+}
+
+// ExecContext 执行写语句，锁冲突时退避重试。
+func (d *retryDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	var res sql.Result
+	err := d.retry(ctx, "exec", func() error {
+		var err error
+		res, err = d.DB.ExecContext(ctx, query, args...)
+		return err
+	})
+	return res, err
+}
+
+// BeginTx 开始事务，锁冲突时退避重试。
+func (d *retryDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	var tx *sql.Tx
+	err := d.retry(ctx, "begin", func() error {
+		var err error
+		tx, err = d.DB.BeginTx(ctx, opts)
+		return err
+	})
+	return tx, err
+}
+
+// retry 执行 fn，遇到 SQLITE_BUSY 时退避重试并通知 onBusy。
+func (d *retryDB) retry(ctx context.Context, op string, fn func() error) error {
+	var waited time.Duration
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || !IsBusy(err) {
+			return err
+		}
+		if attempt == len(busyRetryDelays) {
+			d.notify(BusyEvent{Op: op, Attempt: attempt, WaitedMs: waited.Milliseconds(), GaveUp: true})
+			return fmt.Errorf("%w: %w", ErrDatabaseBusy, err)
+		}
+		delay := busyRetryDelays[attempt]
+		waited += delay
+		d.notify(BusyEvent{Op: op, Attempt: attempt + 1, WaitedMs: waited.Milliseconds()})
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w: %w", ErrDatabaseBusy, err)
+		case <-timer.C:
+		}
+	}
+}
+
+// notify 调用已设置的冲突回调。
+func (d *retryDB) notify(e BusyEvent) {
+	if fn := d.onBusy.Load(); fn != nil {
+		(*fn)(e)
+	}
+}
+
+// SetBusyHandler 设置写锁冲突时的回调（每次退避重试与最终放弃时各调用一次）；fn 为 nil 表示取消。
+// 回调在执行写操作的 goroutine 中同步调用，应尽快返回。
+func (s *Store) SetBusyHandler(fn func(BusyEvent)) {
+	if fn == nil {
+		s.db.onBusy.Store(nil)
+		return
+	}
+	s.db.onBusy.Store(&fn)
+}
+
+// IsBusy 判断 err 是否为锁冲突（SQLITE_BUSY 或重试用尽后的 ErrDatabaseBusy）。
+func IsBusy(err error) bool {
+	if errors.Is(err, ErrDatabaseBusy) {
+		return true
+	}
+	var se *sqlite.Error
+	if errors.As(err, &se) {
+		return se.Code()&0xff == sqlitelib.SQLITE_BUSY
+	}
+	return false
+}
@@ -0,0 +1,208 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// staleTaskDays 为"长期未动"的判定阈值：超过这么多天既未修改也未回顾的未完成任务需要回顾。
+const staleTaskDays = 14
+
+// 回顾原因（一个任务可同时命中多个）。
+const (
+	ReviewReasonOverdue  = "overdue"  // 已过截止时间
+	ReviewReasonStale    = "stale"    // 长期未修改、也未回顾
+	ReviewReasonUnsorted = "unsorted" // 既无重要/紧急标记也无截止时间，且从未回顾过
+)
+
+// 回顾动作。
+const (
+	ReviewKeep       = "keep"       // 保持不变，仅标记为已回顾
+	ReviewReschedule = "reschedule" // 修改截止时间
+	ReviewArchive    = "archive"    // 标记为已完成，不再出现在待办中
+	ReviewDelete     = "delete"     // 删除（进入回收站）
+)
+
+// ReviewItem 为回顾队列中的一个任务。
+type ReviewItem struct {
+	Task      Task     `json:"task"`
+	Reasons   []string `json:"reasons"`   // ReviewReason*
+	Remaining int      `json:"remaining"` // 队列中剩余的任务数（含当前这个）
+}
+
+// ReviewStatus 描述每周回顾的进度。
+type ReviewStatus struct {
+	LastReviewAt int64 `json:"lastReviewAt"` // 上次完成回顾的时间（UnixMilli）；从未完成为 0
+	StartedAt    int64 `json:"startedAt"`    // 进行中的回顾开始时间；未在回顾中为 0
+	Pending      int   `json:"pending"`      // 当前需要回顾的任务数
+}
+
+// extraScanner 在 taskColumns 之后追加读取额外的列。
+type extraScanner struct {
+	r     rowScanner
+	extra []any
+}
+
+func (e extraScanner) Scan(dest ...any) error {
+	return e.r.Scan(append(dest, e.extra...)...)
+}
+
+// reviewQueue 返回需要回顾的顶层未完成任务（逾期的在前，其余按最后修改时间升序）。
+//
+// 本轮回顾（StartReview 之后）已处理过的任务不再出现；未开始回顾时列出全部候选。
+func (s *Store) reviewQueue(ctx context.Context, now time.Time) ([]ReviewItem, error) {
+	since, err := s.getTimestampSetting(ctx, "reviewStartedAt")
+	if err != nil {
+		return nil, err
+	}
+	nowMs := now.UnixMilli()
+	if since <= 0 {
+		since = nowMs + 1
+	}
+	staleBefore := now.AddDate(0, 0, -staleTaskDays).UnixMilli()
+
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+taskColumns+`, reviewed_at FROM tasks
+		 WHERE parent_id = 0 AND status <> ? AND reviewed_at < ?
+		   AND ((due_at > 0 AND due_at < ?)
+		     OR MAX(updated_at, reviewed_at) < ?
+		     OR (important = 0 AND urgent = 0 AND due_at = 0 AND reviewed_at = 0))
+		 ORDER BY CASE WHEN due_at > 0 AND due_at < ? THEN 0 ELSE 1 END, due_at, updated_at, id`,
+		string(StatusDone), since, nowMs, staleBefore, nowMs)
+	if err != nil {
+		return nil, fmt.Errorf("query review queue: %w", err)
+	}
+	defer rows.Close()
+
+	items := []ReviewItem{}
+	for rows.Next() {
+		// reviewed_at 不属于 taskColumns，追加在末尾读取
+		var reviewedAt int64
+		t, err := scanTask(extraScanner{r: rows, extra: []any{&reviewedAt}})
+		if err != nil {
+			return nil, err
+		}
+
+		reasons := []string{}
+		if t.DueAt > 0 && t.DueAt < nowMs {
+			reasons = append(reasons, ReviewReasonOverdue)
+		}
+		if max(t.UpdatedAt, reviewedAt) < staleBefore {
+			reasons = append(reasons, ReviewReasonStale)
+		}
+		if !t.Important && !t.Urgent && t.DueAt == 0 && reviewedAt == 0 {
+			reasons = append(reasons, ReviewReasonUnsorted)
+		}
+		items = append(items, ReviewItem{Task: t, Reasons: reasons})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate review queue: %w", err)
+	}
+	for i := range items {
+		items[i].Remaining = len(items) - i
+	}
+	return items, nil
+}
+
+// NextReviewItem 返回回顾队列中的下一个任务；队列为空时返回 nil。
+func (s *Store) NextReviewItem(ctx context.Context, now time.Time) (*ReviewItem, error) {
+	items, err := s.reviewQueue(ctx, now)
+	if err != nil || len(items) == 0 {
+		return nil, err
+	}
+	return &items[0], nil
+}
+
+// GetReviewStatus 返回回顾进度与待回顾任务数。
+func (s *Store) GetReviewStatus(ctx context.Context, now time.Time) (ReviewStatus, error) {
+	var st ReviewStatus
+	var err error
+	if st.LastReviewAt, err = s.getTimestampSetting(ctx, "lastReviewAt"); err != nil {
+		return ReviewStatus{}, err
+	}
+	if st.StartedAt, err = s.getTimestampSetting(ctx, "reviewStartedAt"); err != nil {
+		return ReviewStatus{}, err
+	}
+	items, err := s.reviewQueue(ctx, now)
+	if err != nil {
+		return ReviewStatus{}, err
+	}
+	st.Pending = len(items)
+	return st, nil
+}
+
+// StartReview 开始一轮回顾：此后处理过的任务不再出现在队列中。
+// 已有进行中的回顾时继续沿用原开始时间。
+func (s *Store) StartReview(ctx context.Context, now time.Time) (ReviewStatus, error) {
+	started, err := s.getTimestampSetting(ctx, "reviewStartedAt")
+	if err != nil {
+		return ReviewStatus{}, err
+	}
+	if started <= 0 {
+		if err := s.setSetting(ctx, "reviewStartedAt", strconv.FormatInt(now.UnixMilli(), 10)); err != nil {
+			return ReviewStatus{}, err
+		}
+	}
+	return s.GetReviewStatus(ctx, now)
+}
+
+// FinishReview 结束本轮回顾并记录完成时间。
+func (s *Store) FinishReview(ctx context.Context, now time.Time) (ReviewStatus, error) {
+	if err := s.setSetting(ctx, "lastReviewAt", strconv.FormatInt(now.UnixMilli(), 10)); err != nil {
+		return ReviewStatus{}, err
+	}
+	if err := s.setSetting(ctx, "reviewStartedAt", "0"); err != nil {
+		return ReviewStatus{}, err
+	}
+	return s.GetReviewStatus(ctx, now)
+}
+
+// ReviewTask 对回顾中的任务执行动作（见 Review* 常量）。
+//
+// dueAt 仅用于 reschedule（UnixMilli；0 表示清除截止时间）。
+func (s *Store) ReviewTask(ctx context.Context, id int64, action string, dueAt int64, now time.Time) error {
+	if id <= 0 {
+		return ErrInvalidTaskID
+	}
+	action = strings.TrimSpace(strings.ToLower(action))
+	nowMs := now.UnixMilli()
+
+	switch action {
+	case ReviewKeep:
+		return s.markReviewed(ctx, id, `reviewed_at = ?`, nowMs)
+	case ReviewReschedule:
+		if dueAt < 0 {
+			dueAt = 0
+		}
+		return s.markReviewed(ctx, id, `due_at = ?, updated_at = ?, reviewed_at = ?`, dueAt, nowMs, nowMs)
+	case ReviewArchive:
+		// 与批量完成走同一条路径：子任务联动、重复任务生成下一次
+		if _, err := s.BulkUpdateStatus(ctx, []int64{id}, StatusDone); err != nil {
+			return err
+		}
+		return s.markReviewed(ctx, id, `reviewed_at = ?`, nowMs)
+	case ReviewDelete:
+		return s.DeleteTask(ctx, id)
+	default:
+		return ErrInvalidReviewAction.with(action)
+	}
+}
+
+// markReviewed 执行 UPDATE tasks SET <set> WHERE id = ?，任务不存在时返回 ErrTaskNotFound。
+func (s *Store) markReviewed(ctx context.Context, id int64, set string, args ...any) error {
+	res, err := s.db.ExecContext(ctx, `UPDATE tasks SET `+set+` WHERE id = ?`, append(args, id)...)
+	if err != nil {
+		return fmt.Errorf("review task: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("review task rows affected: %w", err)
+	}
+	if affected == 0 {
+		return ErrTaskNotFound.with(id)
+	}
+	return nil
+}
@@ -0,0 +1,270 @@
+package todo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// 搜索范围（可组合）。
+const (
+	SearchScopeActive   = "active"   // 未归档分组中的任务
+	SearchScopeArchived = "archived" // 已归档分组中的任务
+	SearchScopeTrash    = "trash"    // 回收站中的任务
+	SearchScopeAll      = "all"      // 以上全部
+)
+
+const (
+	// maxSearchHits 限制一次搜索返回的结果数。
+	maxSearchHits = 200
+	// maxSearchTerms 限制查询文本中参与匹配的词数。
+	maxSearchTerms = 10
+	// minTrigramRunes 为全文索引（trigram 分词）能匹配的最短词长；
+	// 含更短的词（如两个字的中文词）时改用 LIKE 逐行匹配，结果按最近修改排序
+	minTrigramRunes = 3
+)
+
+// SearchHit 为一条搜索结果。
+type SearchHit struct {
+	Task      Task   `json:"task"`
+	GroupName string `json:"groupName"`
+	// Location 为任务所在位置（SearchScopeActive / SearchScopeArchived / SearchScopeTrash），前端据此显示标记
+	Location string `json:"location"`
+	// TrashID 为回收站条目 ID（用于恢复）；不在回收站时为 0
+	TrashID   int64 `json:"trashId"`
+	DeletedAt int64 `json:"deletedAt"`
+}
+
+// SearchResult 为搜索结果：依次为进行中、已归档、回收站中的任务；
+// 进行中与已归档部分按相关度排序（标题命中优先），回收站部分按最近删除在前。
+type SearchResult struct {
+	Query string      `json:"query"`
+	Hits  []SearchHit `json:"hits"`
+	// Truncated 表示结果超过 maxSearchHits 条，只返回了前面部分
+	Truncated bool `json:"truncated"`
+}
+
+// parseSearchScopes 校验搜索范围；为空时只搜索进行中的任务，"all" 展开为全部范围。
+func parseSearchScopes(scopes []string) (map[string]bool, error) {
+	out := map[string]bool{}
+	for _, sc := range scopes {
+		switch sc = strings.ToLower(strings.TrimSpace(sc)); sc {
+		case SearchScopeActive, SearchScopeArchived, SearchScopeTrash:
+			out[sc] = true
+		case SearchScopeAll:
+			out[SearchScopeActive], out[SearchScopeArchived], out[SearchScopeTrash] = true, true, true
+		default:
+			return nil, ErrInvalidSearchScope.with(sc)
+		}
+	}
+	if len(out) == 0 {
+		out[SearchScopeActive] = true
+	}
+	return out, nil
+}
+
+// SearchTasks 在 scopes 指定的范围内按标题与内容搜索任务（含子任务，不区分大小写）。
+// 查询文本按空白拆分为多个词，任务需包含全部的词。
+//
+// 未删除的任务通过全文索引（task_fts）检索；回收站中的任务来自删除时的快照，可用 TrashID 恢复。
+// 查询文本为空时返回空结果。
+func (s *Store) SearchTasks(ctx context.Context, query string, scopes []string) (SearchResult, error) {
+	in, err := parseSearchScopes(scopes)
+	if err != nil {
+		return SearchResult{}, err
+	}
+	query = strings.TrimSpace(query)
+	if utf8.RuneCountInString(query) > maxFilterSearchRunes {
+		query = string([]rune(query)[:maxFilterSearchRunes])
+	}
+	res := SearchResult{Query: query, Hits: []SearchHit{}}
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return res, nil
+	}
+
+	for _, archived := range []bool{false, true} {
+		location := SearchScopeActive
+		if archived {
+			location = SearchScopeArchived
+		}
+		if !in[location] {
+			continue
+		}
+		if err := s.searchLiveTasks(ctx, terms, archived, location, &res); err != nil {
+			return SearchResult{}, err
+		}
+	}
+	if in[SearchScopeTrash] {
+		if err := s.searchTrash(ctx, terms, &res); err != nil {
+			return SearchResult{}, err
+		}
+	}
+	return res, nil
+}
+
+// searchTerms 将查询文本按空白拆分为词（不区分大小写去重，最多 maxSearchTerms 个）。
+func searchTerms(query string) []string {
+	var terms []string
+	for _, f := range strings.Fields(query) {
+		if !slices.ContainsFunc(terms, func(t string) bool { return strings.EqualFold(t, f) }) {
+			terms = append(terms, f)
+		}
+		if len(terms) == maxSearchTerms {
+			break
+		}
+	}
+	return terms
+}
+
+// ftsMatchExpr 返回 FTS5 MATCH 表达式：每个词作为短语（双引号包裹，内部双引号转义），词之间为 AND。
+func ftsMatchExpr(terms []string) string {
+	quoted := make([]string, len(terms))
+	for i, t := range terms {
+		quoted[i] = `"` + strings.ReplaceAll(t, `"`, `""`) + `"`
+	}
+	return strings.Join(quoted, " AND ")
+}
+
+// searchLiveTasks 搜索未删除的任务（archived 选择已归档或未归档分组），结果追加到 res。
+//
+// 所有词都不短于 minTrigramRunes 时走全文索引并按 bm25 相关度排序（标题权重更高），
+// 否则在索引表上逐行 LIKE 匹配，按最近修改排序。
+func (s *Store) searchLiveTasks(ctx context.Context, terms []string, archived bool, location string, res *SearchResult) error {
+	remaining := maxSearchHits - len(res.Hits)
+	if remaining <= 0 {
+		res.Truncated = true
+		return nil
+	}
+
+	where, order := `task_fts MATCH ?`, `bm25(task_fts, 10.0, 1.0), tasks.updated_at DESC, tasks.id DESC`
+	args := []any{ftsMatchExpr(terms)}
+	if slices.ContainsFunc(terms, func(t string) bool { return utf8.RuneCountInString(t) < minTrigramRunes }) {
+		where, order, args = `1 = 1`, `tasks.updated_at DESC, tasks.id DESC`, nil
+		for _, t := range terms {
+			pattern := "%" + escapeLike(t) + "%"
+			where += ` AND (task_fts.title LIKE ? ESCAPE '\' OR task_fts.content LIKE ? ESCAPE '\')`
+			args = append(args, pattern, pattern)
+		}
+	}
+	args = append(args, boolTo01Int(archived), remaining+1)
+
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+prefixColumns("tasks", taskColumns)+`, COALESCE((SELECT name FROM groups WHERE groups.id = tasks.group_id), '')
+		 FROM task_fts JOIN tasks ON tasks.id = task_fts.rowid
+		 WHERE `+where+`
+		   AND tasks.group_id IN (SELECT id FROM groups WHERE archived = ?)
+		 ORDER BY `+order+`
+		 LIMIT ?`,
+		args...)
+	if err != nil {
+		return fmt.Errorf("search tasks: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		hit := SearchHit{Location: location}
+		t, err := scanTask(extraScanner{r: rows, extra: []any{&hit.GroupName}})
+		if err != nil {
+			return err
+		}
+		if remaining == 0 {
+			res.Truncated = true
+			break
+		}
+		hit.Task = t
+		res.Hits = append(res.Hits, hit)
+		remaining--
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate search results: %w", err)
+	}
+	return nil
+}
+
+// searchTrash 在回收站快照中搜索任务（含子任务），结果追加到 res。
+func (s *Store) searchTrash(ctx context.Context, terms []string, res *SearchResult) error {
+	// 单独删除的任务快照中没有分组，按仍存在的分组补全名称（Store 只有一个连接，需在遍历回收站前读取）
+	groupNames, err := s.groupNames(ctx)
+	if err != nil {
+		return err
+	}
+	rows, err := s.db.QueryContext(ctx, `SELECT id, payload, deleted_at FROM trash ORDER BY deleted_at DESC, id DESC`)
+	if err != nil {
+		return fmt.Errorf("search trash: %w", err)
+	}
+	defer rows.Close()
+
+	matches := func(t Task) bool {
+		title, content := strings.ToLower(t.Title), strings.ToLower(t.Content)
+		for _, term := range terms {
+			term = strings.ToLower(term)
+			if !strings.Contains(title, term) && !strings.Contains(content, term) {
+				return false
+			}
+		}
+		return true
+	}
+	for rows.Next() {
+		var id, deletedAt int64
+		var raw string
+		if err := rows.Scan(&id, &raw, &deletedAt); err != nil {
+			return fmt.Errorf("scan trash: %w", err)
+		}
+		var payload trashPayload
+		if err := json.Unmarshal([]byte(raw), &payload); err != nil {
+			// 损坏的快照无法恢复，也不参与搜索
+			continue
+		}
+		for _, t := range payload.Tasks {
+			groupName := groupNames[t.GroupID]
+			if payload.Group != nil {
+				groupName = payload.Group.Name
+			}
+			candidates := append([]Task{t}, t.SubTasks...)
+			for _, c := range candidates {
+				if !matches(c) {
+					continue
+				}
+				if len(res.Hits) == maxSearchHits {
+					res.Truncated = true
+					return nil
+				}
+				c.SubTasks = nil
+				res.Hits = append(res.Hits, SearchHit{Task: c, GroupName: groupName, Location: SearchScopeTrash, TrashID: id, DeletedAt: deletedAt})
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate trash: %w", err)
+	}
+	return nil
+}
+
+// groupNames 返回全部分组的 ID -> 名称。
+func (s *Store) groupNames(ctx context.Context) (map[int64]string, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT id, name FROM groups`)
+	if err != nil {
+		return nil, fmt.Errorf("list group names: %w", err)
+	}
+	defer rows.Close()
+
+	out := map[int64]string{}
+	for rows.Next() {
+		var id int64
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return nil, fmt.Errorf("scan group name: %w", err)
+		}
+		out[id] = name
+	}
+	return out, rows.Err()
+}
+
+// escapeLike 转义 LIKE 模式中的通配符（配合 ESCAPE '\' 使用）。
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,91 @@
+package todo
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// searchIDs 返回搜索 query 命中的未删除任务 ID。
+func searchIDs(t *testing.T, s *Store, query string) []int64 {
+	t.Helper()
+	res, err := s.SearchTasks(context.Background(), query, nil)
+	if err != nil {
+		t.Fatalf("search %q: %v", query, err)
+	}
+	var ids []int64
+	for _, h := range res.Hits {
+		ids = append(ids, h.Task.ID)
+	}
+	return ids
+}
+
+func TestSearchIndexesOverflowContent(t *testing.T) {
+	ctx := context.Background()
+	s := openTestStore(t)
+	groupID := firstGroupID(t, s)
+
+	// 关键词在预览之后，且内容足够长会被压缩保存
+	long := strings.Repeat("填充内容", contentPreviewRunes) + " needle"
+	task := createTask(t, s, Task{GroupID: groupID, Title: "long", Content: long})
+	if ids := searchIDs(t, s, "needle"); len(ids) != 1 || ids[0] != task.ID {
+		t.Fatalf("search overflow content = %v, want [%d]", ids, task.ID)
+	}
+
+	// 只改标题时完整内容仍在索引中
+	task.Title = "renamed"
+	task.Content = long
+	if _, err := s.UpsertTask(ctx, task); err != nil {
+		t.Fatal(err)
+	}
+	if ids := searchIDs(t, s, "needle"); len(ids) != 1 {
+		t.Errorf("search after rename = %v", ids)
+	}
+
+	// 内容缩短后旧的完整内容不再命中
+	task.Content = "short"
+	if _, err := s.UpsertTask(ctx, task); err != nil {
+		t.Fatal(err)
+	}
+	if ids := searchIDs(t, s, "needle"); len(ids) != 0 {
+		t.Errorf("search stale content = %v, want none", ids)
+	}
+	if ids := searchIDs(t, s, "short"); len(ids) != 1 {
+		t.Errorf("search new content = %v", ids)
+	}
+}
+
+func TestMigrateRebuildsPreviewOnlySearchIndex(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "todo.db")
+	s, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	task := createTask(t, s, Task{GroupID: firstGroupID(t, s), Title: "long", Content: strings.Repeat("填充", contentPreviewRunes) + " needle"})
+
+	// 换回早期只索引预览的外部内容索引
+	for _, stmt := range []string{
+		`DROP TRIGGER task_fts_ai`, `DROP TRIGGER task_fts_ad`, `DROP TRIGGER task_fts_au`, `DROP TABLE task_fts`,
+		`CREATE VIRTUAL TABLE task_fts USING fts5(title, content, content='tasks', content_rowid='id', tokenize='trigram')`,
+		`INSERT INTO task_fts(task_fts) VALUES ('rebuild')`,
+	} {
+		if _, err := s.db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+	if ids := searchIDs(t, s, "needle"); len(ids) != 0 {
+		t.Fatalf("preview-only index found overflow content: %v", ids)
+	}
+	s.Close()
+
+	s, err = Open(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer s.Close()
+	if ids := searchIDs(t, s, "needle"); len(ids) != 1 || ids[0] != task.ID {
+		t.Errorf("search after upgrade = %v, want [%d]", ids, task.ID)
+	}
+}
@@ -0,0 +1,50 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// selfTestKey 为自检时写入的探测记录的 key（事务回滚后不会留在数据库中）。
+const selfTestKey = "selfTestProbe"
+
+// CheckReadWrite 自检数据库读写：执行 quick_check，再在事务中写入并读回一条探测记录，最后回滚，不留下任何数据。
+//
+// 只读模式下只检查读取，返回 ErrReadOnly。
+func (s *Store) CheckReadWrite(ctx context.Context) error {
+	var result string
+	if err := s.db.QueryRowContext(ctx, `PRAGMA quick_check`).Scan(&result); err != nil {
+		return fmt.Errorf("quick check: %w", err)
+	}
+	if result != "ok" {
+		return fmt.Errorf("quick check: %s", result)
+	}
+	if s.ReadOnly() {
+		return ErrReadOnly
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	probe := strconv.FormatInt(time.Now().UnixNano(), 36)
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO settings(key, value) VALUES(?, ?)
+		 ON CONFLICT(key) DO UPDATE SET value = excluded.value`,
+		selfTestKey, probe,
+	); err != nil {
+		return fmt.Errorf("write probe: %w", err)
+	}
+	var got string
+	if err := tx.QueryRowContext(ctx, `SELECT value FROM settings WHERE key = ?`, selfTestKey).Scan(&got); err != nil {
+		return fmt.Errorf("read probe: %w", err)
+	}
+	if got != probe {
+		return fmt.Errorf("read probe: got %q, want %q", got, probe)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package todo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// sessionKey 为 settings 表中保存上次界面状态的 key（内部状态，不在 settingsSchema 中，重置设置时不受影响）。
+const sessionKey = "session"
+
+// SessionState 为上次关闭窗口时的界面状态，启动时据此回到离开时的位置。
+type SessionState struct {
+	GroupID       int64  `json:"groupId"`       // 选中的分组；0 表示全部分组
+	View          string `json:"view"`          // 当前视图（Views 之一）；空字符串表示按 viewMode 设置
+	ScrollTaskID  int64  `json:"scrollTaskId"`  // 滚动定位的任务（列表顶部可见的任务）；0 表示未滚动
+	EditingTaskID int64  `json:"editingTaskId"` // 正在编辑的任务；0 表示未打开编辑器
+	SavedAt       int64  `json:"savedAt"`       // 保存时间（UnixMilli）；从未保存为 0
+}
+
+// SaveSession 校验并保存界面状态，返回保存后的状态。
+func (s *Store) SaveSession(ctx context.Context, st SessionState, now time.Time) (SessionState, error) {
+	if st.View != "" {
+		view, err := parseView(st.View)
+		if err != nil {
+			return SessionState{}, err
+		}
+		st.View = view
+	}
+	if st.GroupID < 0 {
+		st.GroupID = 0
+	}
+	if st.ScrollTaskID < 0 {
+		st.ScrollTaskID = 0
+	}
+	if st.EditingTaskID < 0 {
+		st.EditingTaskID = 0
+	}
+	st.SavedAt = now.UnixMilli()
+
+	raw, err := json.Marshal(st)
+	if err != nil {
+		return SessionState{}, fmt.Errorf("encode session: %w", err)
+	}
+	if err := s.setSetting(ctx, sessionKey, string(raw)); err != nil {
+		return SessionState{}, err
+	}
+	return st, nil
+}
+
+// GetSession 返回上次保存的界面状态；从未保存时返回零值。
+//
+// 之后被删除的分组或任务会被清除（置 0），前端不会定位到不存在的条目。
+func (s *Store) GetSession(ctx context.Context) (SessionState, error) {
+	var st SessionState
+	if err := s.getJSONSetting(ctx, sessionKey, &st); err != nil {
+		return SessionState{}, fmt.Errorf("get session: %w", err)
+	}
+	if st.View != "" {
+		if _, err := parseView(st.View); err != nil {
+			st.View = ""
+		}
+	}
+
+	var err error
+	if st.GroupID, err = s.existingID(ctx, `SELECT 1 FROM groups WHERE id = ?`, st.GroupID); err != nil {
+		return SessionState{}, fmt.Errorf("get session: %w", err)
+	}
+	if st.ScrollTaskID, err = s.existingID(ctx, `SELECT 1 FROM tasks WHERE id = ?`, st.ScrollTaskID); err != nil {
+		return SessionState{}, fmt.Errorf("get session: %w", err)
+	}
+	if st.EditingTaskID, err = s.existingID(ctx, `SELECT 1 FROM tasks WHERE id = ?`, st.EditingTaskID); err != nil {
+		return SessionState{}, fmt.Errorf("get session: %w", err)
+	}
+	return st, nil
+}
+
+// existingID 用 query 检查 id 对应的记录是否仍存在；不存在（或 id 为 0）时返回 0。
+func (s *Store) existingID(ctx context.Context, query string, id int64) (int64, error) {
+	if id == 0 {
+		return 0, nil
+	}
+	var one int
+	err := s.db.QueryRowContext(ctx, query, id).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
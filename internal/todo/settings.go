@@ -0,0 +1,358 @@
+package todo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"spark-todo/internal/hotkey"
+)
+
+// settingKind 表示设置项的值类型，决定库中 TEXT 值如何编码/解码。
+type settingKind int
+
+const (
+	// settingBool 以 "0"/"1" 存储（读取时兼容 "true"）。
+	settingBool settingKind = iota
+	// settingEnum 只允许 options 中的取值，非法值静默回退到默认值。
+	settingEnum
+	// settingString 为自由文本，由 validate 校验；写入非法值返回错误，读到非法值回退默认值。
+	settingString
+	// settingJSON 以 JSON 文本存储结构化值（map/struct），由 validate 校验并规范化 JSON 文本；
+	// 错误处理与 settingString 相同。
+	settingJSON
+	// settingInt 以十进制文本存储整数，由 validate 校验取值范围并规范化；错误处理与 settingString 相同。
+	settingInt
+)
+
+// 设置分类（用于按类别重置设置）。
+const (
+	SettingsScopeAll        = "all"
+	SettingsScopeWindow     = "window"
+	SettingsScopeAppearance = "appearance"
+	SettingsScopeReminders  = "reminders"
+	SettingsScopeUpdate     = "update"
+	SettingsScopeHotkeys    = "hotkeys"
+	SettingsScopeData       = "data"
+	SettingsScopeAPI        = "api"
+)
+
+// settingsScopes 为 ResetSettings 接受的分类（不含 all）。
+var settingsScopes = []string{SettingsScopeWindow, SettingsScopeAppearance, SettingsScopeReminders, SettingsScopeUpdate, SettingsScopeHotkeys, SettingsScopeData, SettingsScopeAPI}
+
+// settingDef 声明式描述一个设置项。
+//
+// settings 表中的每个 key 都必须在 settingsSchema 中登记：
+// 默认值、合法取值与 Settings 字段映射只在这里维护一份，
+// ensureDefaultSettings / GetSettings / SetSettings 都从这里派生，避免多处默认值漂移。
+type settingDef struct {
+	key      string
+	scope    string // 所属分类（SettingsScope*）
+	kind     settingKind
+	def      string                       // 默认值（与库中存储格式一致）
+	options  []string                     // settingEnum 的合法取值
+	validate func(string) (string, error) // settingString / settingJSON / settingInt 的校验/规范化
+	field    func(*Settings) any          // 返回 Settings 中对应字段的指针（*bool、*string、*int 或 settingJSON 的任意类型指针）
+}
+
+// settingsSchema 是全部用户设置的唯一定义来源。
+var settingsSchema = []settingDef{
+	// 默认置顶悬浮、显示已完成任务
+	{key: "alwaysOnTop", scope: SettingsScopeWindow, kind: settingBool, def: "1", field: func(s *Settings) any { return &s.AlwaysOnTop }},
+	{key: "hideDone", scope: SettingsScopeAppearance, kind: settingBool, def: "0", field: func(s *Settings) any { return &s.HideDone }},
+	{key: "viewMode", scope: SettingsScopeAppearance, kind: settingEnum, def: "cards", options: []string{"list", "cards"}, field: func(s *Settings) any { return &s.ViewMode }},
+	{key: "conciseMode", scope: SettingsScopeWindow, kind: settingBool, def: "0", field: func(s *Settings) any { return &s.ConciseMode }},
+	{key: "theme", scope: SettingsScopeAppearance, kind: settingEnum, def: "light", options: []string{"light", "dark", "system"}, field: func(s *Settings) any { return &s.Theme }},
+	{key: "language", scope: SettingsScopeAppearance, kind: settingEnum, def: "zh-CN", options: []string{"zh-CN", "en-US"}, field: func(s *Settings) any { return &s.Language }},
+	{key: "dateFormat", scope: SettingsScopeAppearance, kind: settingEnum, def: DateFormatISO, options: []string{DateFormatISO, DateFormatLocale}, field: func(s *Settings) any { return &s.DateFormat }},
+	{key: "weekStart", scope: SettingsScopeAppearance, kind: settingEnum, def: WeekStartMonday, options: []string{WeekStartMonday, WeekStartSunday}, field: func(s *Settings) any { return &s.WeekStart }},
+	{key: "uiScale", scope: SettingsScopeAppearance, kind: settingEnum, def: "normal", options: []string{"small", "normal", "large"}, field: func(s *Settings) any { return &s.UIScale }},
+	{key: "themePreset", scope: SettingsScopeAppearance, kind: settingEnum, def: "default", options: themePresetNames(), field: func(s *Settings) any { return &s.ThemePreset }},
+	{key: "highContrast", scope: SettingsScopeAppearance, kind: settingEnum, def: "system", options: []string{"on", "off", "system"}, field: func(s *Settings) any { return &s.HighContrast }},
+	// 积分与成就（默认关闭，纯自愿开启）
+	{key: "gamification", scope: SettingsScopeAppearance, kind: settingBool, def: "0", field: func(s *Settings) any { return &s.Gamification }},
+	// 截止日期旁显示农历（默认关闭）
+	{key: "showLunar", scope: SettingsScopeAppearance, kind: settingBool, def: "0", field: func(s *Settings) any { return &s.ShowLunar }},
+	// 倒计时事项的 30/7/1 天节点提醒（默认开启）
+	{key: "countdownReminders", scope: SettingsScopeReminders, kind: settingBool, def: "1", field: func(s *Settings) any { return &s.CountdownReminders }},
+	{key: "reducedMotion", scope: SettingsScopeAppearance, kind: settingEnum, def: "system", options: []string{"on", "off", "system"}, field: func(s *Settings) any { return &s.ReducedMotion }},
+	// 空字符串表示使用预设的强调色
+	{key: "accentColor", scope: SettingsScopeAppearance, kind: settingString, def: "", validate: normalizeHexColor, field: func(s *Settings) any { return &s.AccentColor }},
+	// 空字符串表示跟随系统时区
+	{key: "timezone", scope: SettingsScopeAppearance, kind: settingString, def: "", validate: normalizeTimezone, field: func(s *Settings) any { return &s.Timezone }},
+	// 全局快捷键（如 "Ctrl+Alt+T"）；空字符串表示不注册
+	{key: "hotkeyShowWindow", scope: SettingsScopeHotkeys, kind: settingString, def: "", validate: normalizeHotkey, field: func(s *Settings) any { return &s.HotkeyShowWindow }},
+	{key: "hotkeyQuickAdd", scope: SettingsScopeHotkeys, kind: settingString, def: "", validate: normalizeHotkey, field: func(s *Settings) any { return &s.HotkeyQuickAdd }},
+	// 各视图的排序方式（视图名 -> Sort*）；未记录的视图使用 SortUpdated
+	{key: "viewSorts", scope: SettingsScopeAppearance, kind: settingJSON, def: "{}", validate: normalizeViewSorts, field: func(s *Settings) any { return &s.ViewSorts }},
+	// 各视图最近一次使用的筛选条件（视图名 -> ViewFilter），重新打开窗口时恢复
+	{key: "viewFilters", scope: SettingsScopeAppearance, kind: settingJSON, def: "{}", validate: normalizeViewFilters, field: func(s *Settings) any { return &s.ViewFilters }},
+	// 回收站保留天数，超过后由维护任务永久删除；0 表示永不自动清理
+	{key: "trashRetentionDays", scope: SettingsScopeData, kind: settingInt, def: "30", validate: normalizeTrashRetention, field: func(s *Settings) any { return &s.TrashRetentionDays }},
+	{key: "contentLimit", scope: SettingsScopeData, kind: settingInt, def: "10000", validate: normalizeContentLimit, field: func(s *Settings) any { return &s.ContentLimit }},
+	{key: "titleLimit", scope: SettingsScopeData, kind: settingInt, def: "200", validate: normalizeTitleLimit, field: func(s *Settings) any { return &s.TitleLimit }},
+	{key: "groupNameLimit", scope: SettingsScopeData, kind: settingInt, def: "50", validate: normalizeGroupNameLimit, field: func(s *Settings) any { return &s.GroupNameLimit }},
+	// 标题/内容超过上限时直接报错（默认）、略超时截断保存或原样保存，见 LengthMode*。
+	// 默认报错：截断与放行的提示（Task.Warnings）需要界面展示，否则被截掉的内容会悄悄丢失
+	{key: "lengthLimitMode", scope: SettingsScopeData, kind: settingEnum, def: LengthModeStrict, options: []string{LengthModeStrict, LengthModeTruncate, LengthModeLenient}, field: func(s *Settings) any { return &s.LengthLimitMode }},
+	// 本地 REST 接口（只监听 127.0.0.1）的端口；0 表示关闭（默认）
+	{key: "apiPort", scope: SettingsScopeAPI, kind: settingInt, def: "0", validate: normalizeAPIPort, field: func(s *Settings) any { return &s.APIPort }},
+	// 空字符串表示使用内置默认更新源
+	{key: "updateUrl", scope: SettingsScopeUpdate, kind: settingString, def: "", validate: normalizeUpdateURL, field: func(s *Settings) any { return &s.UpdateURL }},
+}
+
+// settingDefByKey 按 key 查找设置项定义。
+func settingDefByKey(key string) (settingDef, bool) {
+	for _, d := range settingsSchema {
+		if d.key == key {
+			return d, true
+		}
+	}
+	return settingDef{}, false
+}
+
+// decode 将库中的原始值解码到 Settings 对应字段；非法值回退到默认值。
+func (d settingDef) decode(raw string, settings *Settings) {
+	switch d.kind {
+	case settingBool:
+		*d.field(settings).(*bool) = raw == "1" || strings.EqualFold(raw, "true")
+	case settingEnum:
+		*d.field(settings).(*string) = d.normalizeEnum(raw)
+	case settingString:
+		v, err := d.validate(raw)
+		if err != nil {
+			// 库中的非法值（例如手工改库）回退为默认值
+			v, _ = d.validate(d.def)
+		}
+		*d.field(settings).(*string) = v
+	case settingJSON:
+		v, err := d.validate(raw)
+		if err != nil {
+			v, _ = d.validate(d.def)
+		}
+		// 先清零再解码，避免 map 字段沿用上一次解码的键
+		ptr := reflect.ValueOf(d.field(settings))
+		ptr.Elem().Set(reflect.Zero(ptr.Elem().Type()))
+		_ = json.Unmarshal([]byte(v), ptr.Interface())
+	case settingInt:
+		v, err := d.validate(raw)
+		if err != nil {
+			v, _ = d.validate(d.def)
+		}
+		*d.field(settings).(*int), _ = strconv.Atoi(v)
+	}
+}
+
+// encode 将 Settings 对应字段编码为库中存储的字符串；自由文本不合法时返回错误。
+func (d settingDef) encode(settings *Settings) (string, error) {
+	switch d.kind {
+	case settingBool:
+		return boolTo01(*d.field(settings).(*bool)), nil
+	case settingEnum:
+		return d.normalizeEnum(*d.field(settings).(*string)), nil
+	case settingString:
+		return d.validate(*d.field(settings).(*string))
+	case settingJSON:
+		data, err := json.Marshal(d.field(settings))
+		if err != nil {
+			return "", fmt.Errorf("encode setting %q: %w", d.key, err)
+		}
+		return d.validate(string(data))
+	case settingInt:
+		return d.validate(strconv.Itoa(*d.field(settings).(*int)))
+	default:
+		return "", fmt.Errorf("unknown setting kind for %q", d.key)
+	}
+}
+
+// normalizeEnum 忽略大小写与首尾空白匹配 options，返回 options 中的规范写法；未命中时返回默认值。
+func (d settingDef) normalizeEnum(v string) string {
+	v = strings.TrimSpace(v)
+	for _, o := range d.options {
+		if strings.EqualFold(v, o) {
+			return o
+		}
+	}
+	return d.def
+}
+
+// DefaultSettings 返回全部设置项的默认值。
+func DefaultSettings() Settings {
+	var settings Settings
+	for _, d := range settingsSchema {
+		d.decode(d.def, &settings)
+	}
+	return settings
+}
+
+// ensureDefaultSettings 写入默认设置（仅在 key 不存在时插入，不覆盖用户已有选择）。
+func (s *Store) ensureDefaultSettings(ctx context.Context) error {
+	for _, d := range settingsSchema {
+		if _, err := s.db.ExecContext(ctx,
+			`INSERT OR IGNORE INTO settings(key, value) VALUES(?, ?)`,
+			d.key, d.def,
+		); err != nil {
+			return fmt.Errorf("init settings %q: %w", d.key, err)
+		}
+	}
+	return nil
+}
+
+// GetSettings 读取所有设置键值并返回 Settings 结构。
+//
+// 设计为"有默认值 + 部分覆盖"：
+// - 任何缺失的 key 会回落到默认值
+// - 不在 settingsSchema 中的 key 被忽略（例如 lastWaterReminderAt 这类内部状态）
+func (s *Store) GetSettings(ctx context.Context) (Settings, error) {
+	return getSettings(ctx, s.db)
+}
+
+// getSettings 通过 q（数据库或事务）读取全部设置，见 GetSettings。
+func getSettings(ctx context.Context, q queryer) (Settings, error) {
+	settings := DefaultSettings()
+
+	rows, err := q.QueryContext(ctx, `SELECT key, value FROM settings`)
+	if err != nil {
+		return Settings{}, fmt.Errorf("list settings: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return Settings{}, fmt.Errorf("scan settings: %w", err)
+		}
+		if d, ok := settingDefByKey(key); ok {
+			d.decode(value, &settings)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return Settings{}, fmt.Errorf("iterate settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// SetSettings 将 Settings 写回 settings 表。
+//
+// 先按 schema 编码并校验全部字段，任一不合法则不写入；
+// 全部合法后在同一事务内逐 key upsert，避免只写入一半设置。
+func (s *Store) SetSettings(ctx context.Context, settings Settings) error {
+	values := make([]string, len(settingsSchema))
+	for i, d := range settingsSchema {
+		v, err := d.encode(&settings)
+		if err != nil {
+			return err
+		}
+		values[i] = v
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin set settings: %w", err)
+	}
+	defer tx.Rollback()
+
+	for i, d := range settingsSchema {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO settings(key, value) VALUES(?, ?)
+			 ON CONFLICT(key) DO UPDATE SET value = excluded.value`,
+			d.key, values[i],
+		); err != nil {
+			return fmt.Errorf("set setting %q: %w", d.key, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit set settings: %w", err)
+	}
+	return nil
+}
+
+// ResetSettings 将指定分类的设置恢复为默认值，并返回恢复后的完整 Settings。
+//
+// scope 取值：all / window / appearance / reminders / update / hotkeys / data / api；
+// 其它分类的设置保持不变。用于从错误配置中恢复，而不必删除数据库。
+func (s *Store) ResetSettings(ctx context.Context, scope string) (Settings, error) {
+	scope = strings.TrimSpace(strings.ToLower(scope))
+	if scope == "" {
+		scope = SettingsScopeAll
+	}
+	if scope != SettingsScopeAll && !containsString(settingsScopes, scope) {
+		return Settings{}, ErrInvalidSettingsScope.with(scope)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return Settings{}, fmt.Errorf("begin reset settings: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, d := range settingsSchema {
+		if scope != SettingsScopeAll && d.scope != scope {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO settings(key, value) VALUES(?, ?)
+			 ON CONFLICT(key) DO UPDATE SET value = excluded.value`,
+			d.key, d.def,
+		); err != nil {
+			return Settings{}, fmt.Errorf("reset setting %q: %w", d.key, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return Settings{}, fmt.Errorf("commit reset settings: %w", err)
+	}
+	return s.GetSettings(ctx)
+}
+
+// setSetting 对单个 key 做 upsert（INSERT ... ON CONFLICT DO UPDATE）。
+func (s *Store) setSetting(ctx context.Context, key string, value string) error {
+	if _, err := s.db.ExecContext(ctx,
+		`INSERT INTO settings(key, value) VALUES(?, ?)
+		 ON CONFLICT(key) DO UPDATE SET value = excluded.value`,
+		key, value,
+	); err != nil {
+		return fmt.Errorf("set setting %q: %w", key, err)
+	}
+	return nil
+}
+
+// normalizeUpdateURL 校验自定义更新源地址：
+// - 空字符串表示使用默认更新源
+// - 否则必须是 https 绝对地址（更新包据此下载安装）；http 只允许指向本机，便于本地调试更新服务器
+func normalizeUpdateURL(v string) (string, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return "", nil
+	}
+	if utf8.RuneCountInString(v) > maxUpdateURLRunes {
+		return "", ErrUpdateURLTooLong.with(maxUpdateURLRunes)
+	}
+	if !IsSecureWebURL(v) {
+		return "", ErrInvalidUpdateURL
+	}
+	return v, nil
+}
+
+// normalizeHotkey 校验快捷键组合并返回规范写法；空字符串表示不注册。
+func normalizeHotkey(v string) (string, error) {
+	n, err := hotkey.Normalize(v)
+	if err != nil {
+		return "", ErrInvalidHotkey.with(strings.TrimSpace(v))
+	}
+	return n, nil
+}
+
+// containsString 判断 list 中是否包含 v。
+func containsString(list []string, v string) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package todo
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestNormalizeUpdateURLRequiresHTTPS(t *testing.T) {
+	cases := []struct {
+		url string
+		ok  bool
+	}{
+		{"", true},
+		{"https://updates.example.com/latest", true},
+		{"http://updates.example.com/latest", false},
+		{"http://localhost:8080/latest", true},
+		{"http://127.0.0.1/latest", true},
+		{"http://[::1]:9000/latest", true},
+		{"http://127.0.0.1.example.com/latest", false},
+		{"file:///tmp/latest", false},
+	}
+	for _, c := range cases {
+		_, err := normalizeUpdateURL(c.url)
+		if c.ok && err != nil {
+			t.Errorf("%q: err = %v", c.url, err)
+		}
+		if !c.ok && !errors.Is(err, ErrInvalidUpdateURL) {
+			t.Errorf("%q: err = %v, want ErrInvalidUpdateURL", c.url, err)
+		}
+	}
+}
+
+func TestResetRemindersSettings(t *testing.T) {
+	ctx := context.Background()
+	s := openTestStore(t)
+	settings, err := s.GetSettings(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	settings.CountdownReminders = false
+	settings.ShowLunar = true
+	if err := s.SetSettings(ctx, settings); err != nil {
+		t.Fatal(err)
+	}
+
+	reset, err := s.ResetSettings(ctx, SettingsScopeReminders)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reset.CountdownReminders {
+		t.Error("countdownReminders not restored to its default")
+	}
+	if !reset.ShowLunar {
+		t.Error("reminders reset also reset an appearance setting")
+	}
+}
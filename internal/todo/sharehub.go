@@ -0,0 +1,427 @@
+package todo
+
+import (
+	"context"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	sqlitelib "modernc.org/sqlite/lib"
+)
+
+// 同步服务端（cmd/spark-sync）的账号与共享分组。
+//
+// 服务端复用同一套存储：托管的共享分组就是服务端库中的普通分组（名称为 share_id，显示名称见 hub_groups），
+// 任务的 uid 映射与修订号同样保存在 shared_groups / shared_tasks 中。下面几张 hub_* 表只在 OpenHub 时创建。
+
+// 账号规则。
+const (
+	minHubPasswordRunes   = 8
+	maxHubGroupNameRunes  = 50
+	hubPasswordIterations = 210000
+	// hubTokenTTL 为登录令牌的有效期：连续这么久未使用的令牌失效，需要重新登录
+	hubTokenTTL = 30 * 24 * time.Hour
+	// hubTokenTouchInterval 为刷新令牌最近使用时间的最小间隔，避免每个请求都写库
+	hubTokenTouchInterval = time.Hour
+)
+
+// dummyHubSalt 用于用户名不存在时仍计算一次密码摘要，使其与密码错误耗时相同，避免按响应时间枚举账号。
+var dummyHubSalt = make([]byte, 16)
+
+// hubUsernamePattern 为用户名格式：3-32 位字母、数字、下划线、点或短横线。
+var hubUsernamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]{3,32}$`)
+
+// HubAccount 为同步服务端的账号。
+type HubAccount struct {
+	ID        int64  `json:"id"`
+	Username  string `json:"username"`
+	CreatedAt int64  `json:"createdAt"`
+}
+
+// HostedGroup 为同步服务端托管的共享分组。
+type HostedGroup struct {
+	ShareID   string   `json:"shareId"`
+	Name      string   `json:"name"`
+	Owner     string   `json:"owner"`
+	Members   []string `json:"members"` // 含创建者，按加入先后
+	CreatedAt int64    `json:"createdAt"`
+}
+
+// OpenHub 打开（或创建）同步服务端数据库：在 Open 的基础上补充账号、登录令牌与成员表。
+func OpenHub(dbPath string) (*Store, error) {
+	s, err := Open(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.migrateHub(context.Background()); err != nil {
+		_ = s.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// migrateHub 创建同步服务端专用的表（幂等）。
+func (s *Store) migrateHub(ctx context.Context) error {
+	stmts := []string{
+		`CREATE TABLE IF NOT EXISTS hub_accounts (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			username TEXT NOT NULL UNIQUE COLLATE NOCASE,
+			password_hash BLOB NOT NULL,
+			salt BLOB NOT NULL,
+			created_at INTEGER NOT NULL
+		)`,
+		// 登录令牌只保存 SHA-256 摘要，数据库泄露也拿不到可用的令牌
+		`CREATE TABLE IF NOT EXISTS hub_tokens (
+			token_hash TEXT PRIMARY KEY,
+			account_id INTEGER NOT NULL REFERENCES hub_accounts(id) ON DELETE CASCADE,
+			created_at INTEGER NOT NULL,
+			used_at INTEGER NOT NULL DEFAULT 0
+		)`,
+		`CREATE TABLE IF NOT EXISTS hub_groups (
+			group_id INTEGER PRIMARY KEY REFERENCES groups(id) ON DELETE CASCADE,
+			name TEXT NOT NULL,
+			owner_id INTEGER NOT NULL REFERENCES hub_accounts(id),
+			created_at INTEGER NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS hub_members (
+			group_id INTEGER NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+			account_id INTEGER NOT NULL REFERENCES hub_accounts(id) ON DELETE CASCADE,
+			joined_at INTEGER NOT NULL,
+			PRIMARY KEY (group_id, account_id)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_hub_members_account ON hub_members(account_id)`,
+	}
+	for _, stmt := range stmts {
+		if _, err := s.db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("migrate hub: %w", err)
+		}
+	}
+	// 旧版本的 hub_tokens 没有 used_at（令牌最近使用时间），补齐后按创建时间计算有效期
+	cols, err := s.tableColumns(ctx, "hub_tokens")
+	if err != nil {
+		return err
+	}
+	if !cols["used_at"] {
+		if _, err := s.db.ExecContext(ctx, `ALTER TABLE hub_tokens ADD COLUMN used_at INTEGER NOT NULL DEFAULT 0`); err != nil {
+			return fmt.Errorf("add hub_tokens.used_at: %w", err)
+		}
+	}
+	if _, err := s.db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_hub_tokens_account ON hub_tokens(account_id)`); err != nil {
+		return fmt.Errorf("create hub_tokens account index: %w", err)
+	}
+	return nil
+}
+
+// hashHubPassword 以 PBKDF2-SHA256 计算密码摘要。
+func hashHubPassword(password string, salt []byte) ([]byte, error) {
+	return pbkdf2.Key(sha256.New, password, salt, hubPasswordIterations, 32)
+}
+
+// hashHubToken 返回登录令牌的摘要（hub_tokens 的主键）。
+func hashHubToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// RegisterHubAccount 注册账号并返回新的登录令牌。
+func (s *Store) RegisterHubAccount(ctx context.Context, username, password string) (HubAccount, string, error) {
+	username = strings.TrimSpace(username)
+	if !hubUsernamePattern.MatchString(username) {
+		return HubAccount{}, "", ErrInvalidShareUsername
+	}
+	if utf8.RuneCountInString(password) < minHubPasswordRunes {
+		return HubAccount{}, "", ErrSharePasswordTooShort.with(minHubPasswordRunes)
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return HubAccount{}, "", fmt.Errorf("generate salt: %w", err)
+	}
+	hash, err := hashHubPassword(password, salt)
+	if err != nil {
+		return HubAccount{}, "", fmt.Errorf("hash password: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	res, err := s.db.ExecContext(ctx,
+		`INSERT INTO hub_accounts(username, password_hash, salt, created_at) VALUES(?, ?, ?, ?)`,
+		username, hash, salt, now,
+	)
+	if err != nil {
+		if sqliteIsConstraint(err, sqlitelib.SQLITE_CONSTRAINT_UNIQUE) {
+			return HubAccount{}, "", ErrShareUsernameTaken.with(username)
+		}
+		return HubAccount{}, "", fmt.Errorf("create hub account: %w", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return HubAccount{}, "", fmt.Errorf("get new hub account id: %w", err)
+	}
+	account := HubAccount{ID: id, Username: username, CreatedAt: now}
+	token, err := s.issueHubToken(ctx, id)
+	if err != nil {
+		return HubAccount{}, "", err
+	}
+	return account, token, nil
+}
+
+// LoginHubAccount 校验用户名与密码并返回新的登录令牌；用户名不存在与密码错误返回同一个错误。
+func (s *Store) LoginHubAccount(ctx context.Context, username, password string) (HubAccount, string, error) {
+	var account HubAccount
+	var hash, salt []byte
+	err := s.db.QueryRowContext(ctx,
+		`SELECT id, username, created_at, password_hash, salt FROM hub_accounts WHERE username = ?`,
+		strings.TrimSpace(username),
+	).Scan(&account.ID, &account.Username, &account.CreatedAt, &hash, &salt)
+	if errors.Is(err, sql.ErrNoRows) {
+		_, _ = hashHubPassword(password, dummyHubSalt)
+		return HubAccount{}, "", ErrShareBadCredentials
+	}
+	if err != nil {
+		return HubAccount{}, "", fmt.Errorf("get hub account: %w", err)
+	}
+	got, err := hashHubPassword(password, salt)
+	if err != nil {
+		return HubAccount{}, "", fmt.Errorf("hash password: %w", err)
+	}
+	if subtle.ConstantTimeCompare(got, hash) != 1 {
+		return HubAccount{}, "", ErrShareBadCredentials
+	}
+	token, err := s.issueHubToken(ctx, account.ID)
+	if err != nil {
+		return HubAccount{}, "", err
+	}
+	return account, token, nil
+}
+
+// issueHubToken 为账号生成新的随机登录令牌（明文只在此返回一次），并顺带清理该账号已过期的令牌。
+func (s *Store) issueHubToken(ctx context.Context, accountID int64) (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("generate token: %w", err)
+	}
+	token := hex.EncodeToString(raw)
+	now := time.Now().UnixMilli()
+	if _, err := s.db.ExecContext(ctx,
+		`DELETE FROM hub_tokens WHERE account_id = ? AND MAX(created_at, used_at) <= ?`,
+		accountID, now-hubTokenTTL.Milliseconds(),
+	); err != nil {
+		return "", fmt.Errorf("delete expired tokens: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx,
+		`INSERT INTO hub_tokens(token_hash, account_id, created_at, used_at) VALUES(?, ?, ?, ?)`,
+		hashHubToken(token), accountID, now, now,
+	); err != nil {
+		return "", fmt.Errorf("save token: %w", err)
+	}
+	return token, nil
+}
+
+// HubAccountByToken 返回登录令牌对应的账号；令牌无效、已撤销或超过 hubTokenTTL 未使用时返回 ErrShareUnauthorized。
+func (s *Store) HubAccountByToken(ctx context.Context, token string) (HubAccount, error) {
+	var account HubAccount
+	var lastUsed int64
+	hash := hashHubToken(token)
+	err := s.db.QueryRowContext(ctx,
+		`SELECT a.id, a.username, a.created_at, MAX(t.created_at, t.used_at) FROM hub_tokens t JOIN hub_accounts a ON a.id = t.account_id
+		 WHERE t.token_hash = ?`, hash,
+	).Scan(&account.ID, &account.Username, &account.CreatedAt, &lastUsed)
+	if errors.Is(err, sql.ErrNoRows) {
+		return HubAccount{}, ErrShareUnauthorized
+	}
+	if err != nil {
+		return HubAccount{}, fmt.Errorf("get hub token: %w", err)
+	}
+
+	now := time.Now()
+	if now.Sub(time.UnixMilli(lastUsed)) >= hubTokenTTL {
+		if _, err := s.db.ExecContext(ctx, `DELETE FROM hub_tokens WHERE token_hash = ?`, hash); err != nil {
+			return HubAccount{}, fmt.Errorf("delete expired token: %w", err)
+		}
+		return HubAccount{}, ErrShareUnauthorized
+	}
+	if now.Sub(time.UnixMilli(lastUsed)) >= hubTokenTouchInterval {
+		if _, err := s.db.ExecContext(ctx, `UPDATE hub_tokens SET used_at = ? WHERE token_hash = ?`, now.UnixMilli(), hash); err != nil {
+			return HubAccount{}, fmt.Errorf("touch token: %w", err)
+		}
+	}
+	return account, nil
+}
+
+// RevokeHubToken 撤销登录令牌（退出登录）；令牌不存在时不做任何事。
+func (s *Store) RevokeHubToken(ctx context.Context, token string) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM hub_tokens WHERE token_hash = ?`, hashHubToken(token)); err != nil {
+		return fmt.Errorf("revoke token: %w", err)
+	}
+	return nil
+}
+
+// RevokeHubTokens 撤销账号的全部登录令牌（在所有设备上退出登录），返回撤销的令牌数。
+func (s *Store) RevokeHubTokens(ctx context.Context, accountID int64) (int64, error) {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM hub_tokens WHERE account_id = ?`, accountID)
+	if err != nil {
+		return 0, fmt.Errorf("revoke tokens: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("revoke tokens rows affected: %w", err)
+	}
+	return n, nil
+}
+
+// CreateHostedGroup 创建由 accountID 拥有的共享分组。
+func (s *Store) CreateHostedGroup(ctx context.Context, accountID int64, name string) (HostedGroup, error) {
+	name, err := normalizeGroupName(name, maxHubGroupNameRunes)
+	if err != nil {
+		return HostedGroup{}, err
+	}
+	raw := make([]byte, 12)
+	if _, err := rand.Read(raw); err != nil {
+		return HostedGroup{}, fmt.Errorf("generate share id: %w", err)
+	}
+	shareID := hex.EncodeToString(raw)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return HostedGroup{}, fmt.Errorf("begin create hosted group: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UnixMilli()
+	res, err := tx.ExecContext(ctx, `INSERT INTO groups(name, sort_order, created_at, updated_at) VALUES(?, `+nextGroupOrderSQL+`, ?, ?)`, shareID, now, now)
+	if err != nil {
+		return HostedGroup{}, fmt.Errorf("create hosted group: %w", err)
+	}
+	groupID, err := res.LastInsertId()
+	if err != nil {
+		return HostedGroup{}, fmt.Errorf("get hosted group id: %w", err)
+	}
+	for _, stmt := range []struct {
+		query string
+		args  []any
+	}{
+		{`INSERT INTO shared_groups(group_id, share_id, created_at) VALUES(?, ?, ?)`, []any{groupID, shareID, now}},
+		{`INSERT INTO hub_groups(group_id, name, owner_id, created_at) VALUES(?, ?, ?, ?)`, []any{groupID, name, accountID, now}},
+		{`INSERT INTO hub_members(group_id, account_id, joined_at) VALUES(?, ?, ?)`, []any{groupID, accountID, now}},
+	} {
+		if _, err := tx.ExecContext(ctx, stmt.query, stmt.args...); err != nil {
+			return HostedGroup{}, fmt.Errorf("create hosted group: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return HostedGroup{}, fmt.Errorf("commit create hosted group: %w", err)
+	}
+	return s.hostedGroup(ctx, groupID)
+}
+
+// ListHostedGroups 返回 accountID 所属的全部共享分组（按创建先后）。
+func (s *Store) ListHostedGroups(ctx context.Context, accountID int64) ([]HostedGroup, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT m.group_id FROM hub_members m JOIN hub_groups h ON h.group_id = m.group_id
+		 WHERE m.account_id = ? ORDER BY h.created_at, h.group_id`, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("list hosted groups: %w", err)
+	}
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan hosted group: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate hosted groups: %w", err)
+	}
+
+	out := make([]HostedGroup, 0, len(ids))
+	for _, id := range ids {
+		g, err := s.hostedGroup(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, g)
+	}
+	return out, nil
+}
+
+// AddHostedMember 由分组成员 accountID 邀请 username 加入共享分组；对方已是成员时不做改动。
+func (s *Store) AddHostedMember(ctx context.Context, accountID int64, shareID string, username string) (HostedGroup, error) {
+	groupID, err := s.HostedGroupID(ctx, accountID, shareID)
+	if err != nil {
+		return HostedGroup{}, err
+	}
+	var memberID int64
+	err = s.db.QueryRowContext(ctx, `SELECT id FROM hub_accounts WHERE username = ?`, strings.TrimSpace(username)).Scan(&memberID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return HostedGroup{}, ErrShareAccountNotFound.with(strings.TrimSpace(username))
+	}
+	if err != nil {
+		return HostedGroup{}, fmt.Errorf("get hub account: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx,
+		`INSERT OR IGNORE INTO hub_members(group_id, account_id, joined_at) VALUES(?, ?, ?)`,
+		groupID, memberID, time.Now().UnixMilli(),
+	); err != nil {
+		return HostedGroup{}, fmt.Errorf("add hosted member: %w", err)
+	}
+	return s.hostedGroup(ctx, groupID)
+}
+
+// HostedGroupID 返回共享分组在服务端库中的分组 ID；分组不存在或 accountID 不是成员时返回 ErrShareNotMember。
+func (s *Store) HostedGroupID(ctx context.Context, accountID int64, shareID string) (int64, error) {
+	var groupID int64
+	err := s.db.QueryRowContext(ctx,
+		`SELECT sg.group_id FROM shared_groups sg JOIN hub_members m ON m.group_id = sg.group_id
+		 WHERE sg.share_id = ? AND m.account_id = ?`, shareID, accountID,
+	).Scan(&groupID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrShareNotMember.with(shareID)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("get hosted group: %w", err)
+	}
+	return groupID, nil
+}
+
+// hostedGroup 读取共享分组及其成员。
+func (s *Store) hostedGroup(ctx context.Context, groupID int64) (HostedGroup, error) {
+	var g HostedGroup
+	if err := s.db.QueryRowContext(ctx,
+		`SELECT sg.share_id, h.name, a.username, h.created_at
+		 FROM hub_groups h JOIN shared_groups sg ON sg.group_id = h.group_id JOIN hub_accounts a ON a.id = h.owner_id
+		 WHERE h.group_id = ?`, groupID,
+	).Scan(&g.ShareID, &g.Name, &g.Owner, &g.CreatedAt); err != nil {
+		return HostedGroup{}, fmt.Errorf("get hosted group: %w", err)
+	}
+
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT a.username FROM hub_members m JOIN hub_accounts a ON a.id = m.account_id
+		 WHERE m.group_id = ? ORDER BY m.joined_at, a.id`, groupID)
+	if err != nil {
+		return HostedGroup{}, fmt.Errorf("list hosted members: %w", err)
+	}
+	defer rows.Close()
+	g.Members = []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return HostedGroup{}, fmt.Errorf("scan hosted member: %w", err)
+		}
+		g.Members = append(g.Members, name)
+	}
+	if err := rows.Err(); err != nil {
+		return HostedGroup{}, fmt.Errorf("iterate hosted members: %w", err)
+	}
+	return g, nil
+}
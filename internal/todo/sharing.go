@@ -0,0 +1,545 @@
+package todo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	sqlitelib "modernc.org/sqlite/lib"
+)
+
+// shareSessionKeys 为 settings 表中保存同步服务器登录信息的 key。
+// 与 holidayCalendarKey 一样不属于 settingsSchema，不随"恢复默认设置"清除。
+// 登录令牌保存在系统凭据库中；shareTokenKey 只用于读取旧版本明文保存的令牌。
+const (
+	shareServerKey = "shareServer"
+	shareUserKey   = "shareUser"
+	shareTokenKey  = "shareToken"
+)
+
+// nextShareSeqSQL 返回下一个本地变更序号（shared_tasks.seq 单调递增，推送时只发送大于上次推送序号的记录）。
+const nextShareSeqSQL = `(SELECT COALESCE(MAX(seq), 0) + 1 FROM shared_tasks)`
+
+// sharedTaskTriggers 维护 shared_tasks：共享分组中任务的新增、修改、删除都会记下新的本地变更序号。
+//
+// 删除或移出共享分组的任务保留一条 task_id 为 0 的记录（墓碑），同步时告知其他成员删除；
+// 新增或移入共享分组的任务分配新的随机 uid。同步服务端托管的分组同样由这些触发器维护。
+var sharedTaskTriggers = []string{
+	`CREATE TRIGGER IF NOT EXISTS trg_tasks_shared_insert AFTER INSERT ON tasks
+		WHEN EXISTS (SELECT 1 FROM shared_groups WHERE group_id = NEW.group_id) BEGIN
+		INSERT INTO shared_tasks(uid, group_id, task_id, seq, updated_at)
+		VALUES(lower(hex(randomblob(16))), NEW.group_id, NEW.id, ` + nextShareSeqSQL + `, NEW.updated_at);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS trg_tasks_shared_update AFTER UPDATE ON tasks BEGIN
+		UPDATE shared_tasks SET task_id = CASE WHEN group_id = NEW.group_id THEN task_id ELSE 0 END,
+			seq = ` + nextShareSeqSQL + `, updated_at = ` + nowMillisSQL + `
+		WHERE task_id = NEW.id;
+		INSERT INTO shared_tasks(uid, group_id, task_id, seq, updated_at)
+		SELECT lower(hex(randomblob(16))), NEW.group_id, NEW.id, ` + nextShareSeqSQL + `, NEW.updated_at
+		WHERE EXISTS (SELECT 1 FROM shared_groups WHERE group_id = NEW.group_id)
+		  AND NOT EXISTS (SELECT 1 FROM shared_tasks WHERE task_id = NEW.id);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS trg_tasks_shared_delete AFTER DELETE ON tasks BEGIN
+		UPDATE shared_tasks SET task_id = 0, seq = ` + nextShareSeqSQL + `, updated_at = ` + nowMillisSQL + `
+		WHERE task_id = OLD.id;
+	END`,
+}
+
+// SharedGroup 为与同步服务器上的共享分组关联的本地分组。
+type SharedGroup struct {
+	GroupID   int64  `json:"groupId"`
+	GroupName string `json:"groupName"`
+	ShareID   string `json:"shareId"`
+	// Rev 为已拉取到的服务端修订号，下次同步只拉取之后的变更
+	Rev int64 `json:"rev"`
+	// PushedSeq 为已推送的本地变更序号
+	PushedSeq int64 `json:"-"`
+	// Pending 为尚未推送的本地变更数
+	Pending  int   `json:"pending"`
+	SyncedAt int64 `json:"syncedAt"` // 最近一次同步成功的时间；从未同步为 0
+}
+
+// SharedTask 为同步时交换的一条任务记录（按 uid 标识，本地 ID 在各设备上各不相同）。
+//
+// 冲突按服务端修订号处理，不比较各设备的时钟：成员推送的记录须基于服务端的最新修订（Rev 不低于服务端当前值），
+// 否则说明有人先一步修改了该任务，服务端保留已有数据，推送方在随后的拉取中收到它。
+// UpdatedAt 由服务端在接受记录时改写为服务端时间。
+type SharedTask struct {
+	UID           string `json:"uid"`
+	ParentUID     string `json:"parentUid,omitempty"` // 子任务所属主任务的 uid
+	Title         string `json:"title,omitempty"`
+	Content       string `json:"content,omitempty"`
+	ContentFormat string `json:"contentFormat,omitempty"`
+	Status        Status `json:"status,omitempty"`
+	Important     bool   `json:"important,omitempty"`
+	Urgent        bool   `json:"urgent,omitempty"`
+	DueAt         int64  `json:"dueAt,omitempty"`
+	CompletedAt   int64  `json:"completedAt,omitempty"`
+	Deleted       bool   `json:"deleted,omitempty"`
+	UpdatedAt     int64  `json:"updatedAt"`
+	// Rev 为服务端修订号：服务端下发的记录为该记录当前的修订号，成员推送的记录为本地修改所基于的修订号
+	Rev int64 `json:"rev,omitempty"`
+}
+
+// ShareSession 为桌面端保存的同步服务器登录信息。
+type ShareSession struct {
+	Server   string `json:"server"`
+	Username string `json:"username"`
+	Token    string `json:"-"` // 保存在系统凭据库中，不写入数据库
+}
+
+// LoggedIn 报告是否已登录同步服务器。
+func (s ShareSession) LoggedIn() bool {
+	return s.Server != "" && s.Token != ""
+}
+
+// GetShareSession 返回保存的同步服务器地址与用户名（Token 为空，由调用方从系统凭据库读取）；未登录时各字段为空。
+func (s *Store) GetShareSession(ctx context.Context) (ShareSession, error) {
+	var out ShareSession
+	for key, dst := range map[string]*string{shareServerKey: &out.Server, shareUserKey: &out.Username} {
+		err := s.db.QueryRowContext(ctx, `SELECT value FROM settings WHERE key = ?`, key).Scan(dst)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return ShareSession{}, fmt.Errorf("get share session: %w", err)
+		}
+	}
+	return out, nil
+}
+
+// SetShareSession 保存同步服务器地址与用户名（不保存 Token），并清除旧版本明文保存的令牌；
+// 传入空的 ShareSession 表示退出登录。
+func (s *Store) SetShareSession(ctx context.Context, session ShareSession) error {
+	for key, value := range map[string]string{shareServerKey: session.Server, shareUserKey: session.Username} {
+		if err := s.setSetting(ctx, key, value); err != nil {
+			return err
+		}
+	}
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM settings WHERE key = ?`, shareTokenKey); err != nil {
+		return fmt.Errorf("clear legacy share token: %w", err)
+	}
+	return nil
+}
+
+// LegacyShareToken 返回旧版本明文保存在数据库中的登录令牌（没有时为空），供迁移到系统凭据库；
+// 迁移后调用 SetShareSession 清除。
+func (s *Store) LegacyShareToken(ctx context.Context) (string, error) {
+	var token string
+	err := s.db.QueryRowContext(ctx, `SELECT value FROM settings WHERE key = ?`, shareTokenKey).Scan(&token)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("get legacy share token: %w", err)
+	}
+	return token, nil
+}
+
+// ListSharedGroups 返回全部共享分组（按分组列表顺序）。
+func (s *Store) ListSharedGroups(ctx context.Context) ([]SharedGroup, error) {
+	return s.querySharedGroups(ctx, ``)
+}
+
+// GetSharedGroup 返回分组的共享信息；分组未共享时返回 ErrGroupNotShared。
+func (s *Store) GetSharedGroup(ctx context.Context, groupID int64) (SharedGroup, error) {
+	groups, err := s.querySharedGroups(ctx, `WHERE sg.group_id = ?`, groupID)
+	if err != nil {
+		return SharedGroup{}, err
+	}
+	if len(groups) == 0 {
+		return SharedGroup{}, ErrGroupNotShared.with(groupID)
+	}
+	return groups[0], nil
+}
+
+// querySharedGroups 按 where 条件读取共享分组。
+func (s *Store) querySharedGroups(ctx context.Context, where string, args ...any) ([]SharedGroup, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT sg.group_id, g.name, sg.share_id, sg.rev, sg.pushed_seq, sg.synced_at,
+		        (SELECT COUNT(*) FROM shared_tasks st WHERE st.group_id = sg.group_id AND st.seq > sg.pushed_seq)
+		 FROM shared_groups sg JOIN groups g ON g.id = sg.group_id `+where+`
+		 ORDER BY g.favorite DESC, g.sort_order, g.id`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list shared groups: %w", err)
+	}
+	defer rows.Close()
+
+	out := []SharedGroup{}
+	for rows.Next() {
+		var g SharedGroup
+		if err := rows.Scan(&g.GroupID, &g.GroupName, &g.ShareID, &g.Rev, &g.PushedSeq, &g.SyncedAt, &g.Pending); err != nil {
+			return nil, fmt.Errorf("scan shared group: %w", err)
+		}
+		out = append(out, g)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate shared groups: %w", err)
+	}
+	return out, nil
+}
+
+// ShareGroup 将本地分组关联到服务端共享分组 shareID，分组中已有的任务都记为待推送。
+func (s *Store) ShareGroup(ctx context.Context, groupID int64, shareID string) (SharedGroup, error) {
+	ok, err := s.groupExists(ctx, groupID)
+	if err != nil {
+		return SharedGroup{}, err
+	}
+	if !ok {
+		return SharedGroup{}, ErrGroupNotFound.with(groupID)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return SharedGroup{}, fmt.Errorf("begin share group: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO shared_groups(group_id, share_id, created_at) VALUES(?, ?, ?)`,
+		groupID, shareID, time.Now().UnixMilli(),
+	); err != nil {
+		if sqliteIsConstraint(err, sqlitelib.SQLITE_CONSTRAINT_PRIMARYKEY) || sqliteIsConstraint(err, sqlitelib.SQLITE_CONSTRAINT_UNIQUE) {
+			return SharedGroup{}, ErrGroupAlreadyShared.with(groupID)
+		}
+		return SharedGroup{}, fmt.Errorf("share group: %w", err)
+	}
+	// 之前共享过又取消的分组可能留有旧记录
+	if _, err := tx.ExecContext(ctx, `DELETE FROM shared_tasks WHERE group_id = ?`, groupID); err != nil {
+		return SharedGroup{}, fmt.Errorf("clear shared tasks: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO shared_tasks(uid, group_id, task_id, seq, updated_at)
+		 SELECT lower(hex(randomblob(16))), group_id, id, `+nextShareSeqSQL+`, updated_at FROM tasks WHERE group_id = ?`,
+		groupID,
+	); err != nil {
+		return SharedGroup{}, fmt.Errorf("map shared tasks: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return SharedGroup{}, fmt.Errorf("commit share group: %w", err)
+	}
+	return s.GetSharedGroup(ctx, groupID)
+}
+
+// UnshareGroup 取消分组与服务端的关联（本地任务保留，不再同步）。
+func (s *Store) UnshareGroup(ctx context.Context, groupID int64) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM shared_groups WHERE group_id = ?`, groupID)
+	if err != nil {
+		return fmt.Errorf("unshare group: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrGroupNotShared.with(groupID)
+	}
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM shared_tasks WHERE group_id = ?`, groupID); err != nil {
+		return fmt.Errorf("clear shared tasks: %w", err)
+	}
+	return nil
+}
+
+// PendingSharedTasks 返回分组中尚未推送的本地变更（含删除），以及其中最大的本地变更序号；
+// 推送成功后把该序号传给 MergeSharedTasks。
+func (s *Store) PendingSharedTasks(ctx context.Context, groupID int64) ([]SharedTask, int64, error) {
+	g, err := s.GetSharedGroup(ctx, groupID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.sharedTasksWhere(ctx, groupID, `st.seq > ?`, g.PushedSeq)
+}
+
+// SharedTasksSince 返回分组中服务端修订号大于 rev 的记录，以及分组当前的最大修订号（服务端使用）。
+func (s *Store) SharedTasksSince(ctx context.Context, groupID int64, rev int64) ([]SharedTask, int64, error) {
+	tasks, _, err := s.sharedTasksWhere(ctx, groupID, `st.rev > ?`, rev)
+	if err != nil {
+		return nil, 0, err
+	}
+	var maxRev int64
+	if err := s.db.QueryRowContext(ctx,
+		`SELECT COALESCE(MAX(rev), 0) FROM shared_tasks WHERE group_id = ?`, groupID,
+	).Scan(&maxRev); err != nil {
+		return nil, 0, fmt.Errorf("get shared group rev: %w", err)
+	}
+	return tasks, max(maxRev, rev), nil
+}
+
+// sharedTasksWhere 读取分组中满足 cond 的共享记录（主任务在前），返回记录与其中最大的本地变更序号。
+func (s *Store) sharedTasksWhere(ctx context.Context, groupID int64, cond string, arg int64) ([]SharedTask, int64, error) {
+	var maxSeq int64
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+prefixColumns("t", taskColumns)+`, st.uid, st.rev, st.seq, COALESCE(p.uid, '')
+		 FROM shared_tasks st
+		 JOIN tasks t ON t.id = st.task_id
+		 LEFT JOIN shared_tasks p ON p.task_id = t.parent_id AND t.parent_id > 0
+		 WHERE st.group_id = ? AND `+cond+`
+		 ORDER BY t.parent_id <> 0, t.id`,
+		groupID, arg)
+	if err != nil {
+		return nil, 0, fmt.Errorf("query shared tasks: %w", err)
+	}
+	defer rows.Close()
+
+	var tasks []Task
+	var meta []SharedTask
+	for rows.Next() {
+		var m SharedTask
+		var seq int64
+		t, err := scanTask(extraScanner{r: rows, extra: []any{&m.UID, &m.Rev, &seq, &m.ParentUID}})
+		if err != nil {
+			return nil, 0, fmt.Errorf("scan shared task: %w", err)
+		}
+		maxSeq = max(maxSeq, seq)
+		tasks = append(tasks, t)
+		meta = append(meta, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("iterate shared tasks: %w", err)
+	}
+	rows.Close()
+	if err := s.LoadFullContent(ctx, tasks); err != nil {
+		return nil, 0, err
+	}
+
+	out := make([]SharedTask, 0, len(tasks))
+	for i, t := range tasks {
+		m := meta[i]
+		m.Title, m.Content, m.ContentFormat = t.Title, t.Content, t.ContentFormat
+		m.Status, m.Important, m.Urgent = t.Status, t.Important, t.Urgent
+		m.DueAt, m.CompletedAt, m.UpdatedAt = t.DueAt, t.CompletedAt, t.UpdatedAt
+		out = append(out, m)
+	}
+
+	// 墓碑
+	tombs, err := s.db.QueryContext(ctx,
+		`SELECT st.uid, st.rev, st.seq, st.updated_at FROM shared_tasks st
+		 WHERE st.group_id = ? AND st.task_id = 0 AND `+cond, groupID, arg)
+	if err != nil {
+		return nil, 0, fmt.Errorf("query shared tombstones: %w", err)
+	}
+	defer tombs.Close()
+	for tombs.Next() {
+		m := SharedTask{Deleted: true}
+		var seq int64
+		if err := tombs.Scan(&m.UID, &m.Rev, &seq, &m.UpdatedAt); err != nil {
+			return nil, 0, fmt.Errorf("scan shared tombstone: %w", err)
+		}
+		maxSeq = max(maxSeq, seq)
+		out = append(out, m)
+	}
+	if err := tombs.Err(); err != nil {
+		return nil, 0, fmt.Errorf("iterate shared tombstones: %w", err)
+	}
+	return out, maxSeq, nil
+}
+
+// MergeSharedTasks 应用从服务端拉取的记录（桌面端使用），并记下同步进度：
+// rev 为服务端返回的修订号，pushedSeq 为本次已推送的本地变更序号。返回实际生效的记录数。
+//
+// 服务端的记录覆盖本地数据，除非本地在推送之后又修改了该任务（这些修改下次同步时推送）。
+func (s *Store) MergeSharedTasks(ctx context.Context, groupID int64, records []SharedTask, rev int64, pushedSeq int64) (int, error) {
+	settings, err := s.GetSettings(ctx)
+	if err != nil {
+		return 0, err
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("begin merge shared tasks: %w", err)
+	}
+	defer tx.Rollback()
+
+	var seqBefore, groupPushed int64
+	if err := tx.QueryRowContext(ctx, `SELECT COALESCE(MAX(seq), 0) FROM shared_tasks`).Scan(&seqBefore); err != nil {
+		return 0, fmt.Errorf("get shared seq: %w", err)
+	}
+	if err := tx.QueryRowContext(ctx, `SELECT pushed_seq FROM shared_groups WHERE group_id = ?`, groupID).Scan(&groupPushed); err != nil {
+		return 0, fmt.Errorf("get shared pushed seq: %w", err)
+	}
+	rule := sharedApplyRule{pushedSeq: max(pushedSeq, groupPushed), titleLimit: settings.TitleLimit, contentLimit: settings.ContentLimit, lengthMode: settings.LengthLimitMode}
+	applied, err := applySharedTasks(ctx, tx, groupID, records, rule)
+	if err != nil {
+		return 0, err
+	}
+	// 应用远端记录时触发器记下的变更不需要再推送回去
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE shared_tasks SET seq = 0 WHERE group_id = ? AND seq > ?`, groupID, seqBefore,
+	); err != nil {
+		return 0, fmt.Errorf("reset shared seq: %w", err)
+	}
+	for _, r := range records {
+		if _, err := tx.ExecContext(ctx, `UPDATE shared_tasks SET rev = ? WHERE uid = ? AND group_id = ?`, r.Rev, r.UID, groupID); err != nil {
+			return 0, fmt.Errorf("record shared rev: %w", err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE shared_groups SET rev = ?, pushed_seq = MAX(pushed_seq, ?), synced_at = ? WHERE group_id = ?`,
+		rev, pushedSeq, time.Now().UnixMilli(), groupID,
+	); err != nil {
+		return 0, fmt.Errorf("update shared group: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit merge shared tasks: %w", err)
+	}
+	return applied, nil
+}
+
+// AcceptSharedTasks 应用成员推送的记录（服务端使用），生效的变更统一分配一个新的修订号。
+// 基于旧修订的记录被忽略（见 SharedTask），记录的修改时间改为服务端时间。
+func (s *Store) AcceptSharedTasks(ctx context.Context, groupID int64, records []SharedTask) (int, error) {
+	settings, err := s.GetSettings(ctx)
+	if err != nil {
+		return 0, err
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("begin accept shared tasks: %w", err)
+	}
+	defer tx.Rollback()
+
+	rule := sharedApplyRule{server: true, now: time.Now().UnixMilli(), titleLimit: settings.TitleLimit, contentLimit: settings.ContentLimit, lengthMode: settings.LengthLimitMode}
+	applied, err := applySharedTasks(ctx, tx, groupID, records, rule)
+	if err != nil {
+		return 0, err
+	}
+	// 服务端不在本地编辑任务，seq > 0 的记录都是本次推送带来的变化（含随主任务删除的子任务）
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE shared_tasks SET rev = (SELECT COALESCE(MAX(rev), 0) + 1 FROM shared_tasks), seq = 0
+		 WHERE group_id = ? AND seq > 0`, groupID,
+	); err != nil {
+		return 0, fmt.Errorf("assign shared rev: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit accept shared tasks: %w", err)
+	}
+	return applied, nil
+}
+
+// sharedApplyRule 为应用共享记录时的冲突规则与字段长度上限。
+type sharedApplyRule struct {
+	// server 为 true 时为服务端接受成员推送：只应用基于最新修订的记录，修改时间记为 now
+	server bool
+	now    int64
+	// pushedSeq 为桌面端已推送的本地变更序号：本地序号更大的任务在推送后又被修改过，不被服务端记录覆盖
+	pushedSeq int64
+
+	titleLimit, contentLimit int
+	lengthMode               string
+}
+
+// applySharedTasks 在事务中按 rule 应用记录，返回生效的记录数。
+// 字段不合法的记录直接跳过，不让一个成员的坏数据阻断整个分组的同步。
+func applySharedTasks(ctx context.Context, tx *sql.Tx, groupID int64, records []SharedTask, rule sharedApplyRule) (int, error) {
+	// 主任务先于子任务应用，子任务才能找到父任务
+	records = slices.Clone(records)
+	slices.SortStableFunc(records, func(a, b SharedTask) int {
+		return boolTo01Int(a.ParentUID != "") - boolTo01Int(b.ParentUID != "")
+	})
+
+	applied := 0
+	for _, r := range records {
+		ok, err := applySharedTask(ctx, tx, groupID, r, rule)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			applied++
+		}
+	}
+	return applied, nil
+}
+
+// applySharedTask 应用一条记录；按 rule 保留本地数据、记录不属于该分组或字段不合法时返回 false。
+func applySharedTask(ctx context.Context, tx *sql.Tx, groupID int64, r SharedTask, rule sharedApplyRule) (bool, error) {
+	var mappedGroup, taskID, localRev, localSeq int64
+	err := tx.QueryRowContext(ctx,
+		`SELECT group_id, task_id, rev, seq FROM shared_tasks WHERE uid = ?`, r.UID,
+	).Scan(&mappedGroup, &taskID, &localRev, &localSeq)
+	found := err == nil
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return false, fmt.Errorf("get shared task: %w", err)
+	}
+	if strings.TrimSpace(r.UID) == "" || (found && mappedGroup != groupID) {
+		return false, nil
+	}
+	if rule.server {
+		if found && r.Rev < localRev {
+			return false, nil
+		}
+		r.UpdatedAt = rule.now
+	} else if found && localSeq > rule.pushedSeq {
+		return false, nil
+	}
+
+	if r.Deleted {
+		if taskID == 0 {
+			return false, nil
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM tasks WHERE id = ? OR parent_id = ?`, taskID, taskID); err != nil {
+			return false, fmt.Errorf("delete shared task: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE shared_tasks SET updated_at = ? WHERE uid = ?`, r.UpdatedAt, r.UID); err != nil {
+			return false, fmt.Errorf("update shared tombstone: %w", err)
+		}
+		return true, nil
+	}
+
+	title := strings.TrimSpace(r.Title)
+	status, err := ParseStatus(string(r.Status))
+	if title == "" || err != nil {
+		return false, nil
+	}
+	if title, _, err = applyLengthLimit(LengthFieldTitle, title, rule.titleLimit, rule.lengthMode, ErrTaskTitleTooLong); err != nil {
+		return false, nil
+	}
+	if r.Content, _, err = applyLengthLimit(LengthFieldContent, r.Content, rule.contentLimit, rule.lengthMode, ErrTaskContentTooLong); err != nil {
+		return false, nil
+	}
+	format, err := ParseContentFormat(r.ContentFormat)
+	if err != nil {
+		return false, nil
+	}
+	var parentID int64
+	if r.ParentUID != "" {
+		err := tx.QueryRowContext(ctx,
+			`SELECT t.id FROM shared_tasks st JOIN tasks t ON t.id = st.task_id
+			 WHERE st.uid = ? AND st.group_id = ? AND t.parent_id = 0`, r.ParentUID, groupID,
+		).Scan(&parentID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return false, fmt.Errorf("get shared parent: %w", err)
+		}
+	}
+	completedAt := r.CompletedAt
+	if status != StatusDone {
+		completedAt = 0
+	}
+	preview, overflow := splitContent(r.Content)
+
+	if taskID == 0 {
+		now := time.Now().UnixMilli()
+		res, err := tx.ExecContext(ctx,
+			`INSERT INTO tasks(group_id, parent_id, title, content, content_overflow, content_format, status, important, urgent, due_at, sort_order, completed_at, created_at, updated_at)
+			 VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, (SELECT COALESCE(MAX(sort_order), 0) + 1 FROM tasks), ?, ?, ?)`,
+			groupID, parentID, title, preview, boolTo01Int(overflow), format, string(status), boolTo01Int(r.Important), boolTo01Int(r.Urgent), max(r.DueAt, 0), completedAt, now, r.UpdatedAt,
+		)
+		if err != nil {
+			return false, fmt.Errorf("create shared task: %w", err)
+		}
+		if taskID, err = res.LastInsertId(); err != nil {
+			return false, fmt.Errorf("get new shared task id: %w", err)
+		}
+		// 触发器为新任务分配了随机 uid，换成记录的 uid（先清掉同 uid 的墓碑）
+		if _, err := tx.ExecContext(ctx, `DELETE FROM shared_tasks WHERE uid = ?`, r.UID); err != nil {
+			return false, fmt.Errorf("clear shared tombstone: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE shared_tasks SET uid = ? WHERE task_id = ?`, r.UID, taskID); err != nil {
+			return false, fmt.Errorf("map shared task: %w", err)
+		}
+	} else {
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE tasks SET parent_id = ?, title = ?, content = ?, content_overflow = ?, content_format = ?, status = ?, important = ?, urgent = ?, due_at = ?, completed_at = ?, updated_at = ?
+			 WHERE id = ?`,
+			parentID, title, preview, boolTo01Int(overflow), format, string(status), boolTo01Int(r.Important), boolTo01Int(r.Urgent), max(r.DueAt, 0), completedAt, r.UpdatedAt, taskID,
+		); err != nil {
+			return false, fmt.Errorf("update shared task: %w", err)
+		}
+	}
+	if err := saveContentOverflow(ctx, tx, taskID, r.Content, overflow); err != nil {
+		return false, err
+	}
+	return true, nil
+}
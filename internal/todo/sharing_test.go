@@ -0,0 +1,185 @@
+package todo
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// openTestHub 在临时目录中打开同步服务端数据库，注册一个账号并创建一个托管分组，返回其本地分组 ID。
+func openTestHub(t *testing.T) (*Store, HubAccount, string, int64) {
+	t.Helper()
+	ctx := context.Background()
+	s, err := OpenHub(filepath.Join(t.TempDir(), "hub.db"))
+	if err != nil {
+		t.Fatalf("open hub: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	account, token, err := s.RegisterHubAccount(ctx, "alice", "password123")
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	g, err := s.CreateHostedGroup(ctx, account.ID, "team")
+	if err != nil {
+		t.Fatalf("create hosted group: %v", err)
+	}
+	groupID, err := s.HostedGroupID(ctx, account.ID, g.ShareID)
+	if err != nil {
+		t.Fatalf("hosted group id: %v", err)
+	}
+	return s, account, token, groupID
+}
+
+// hostedTask 返回服务端分组中 uid 对应的记录。
+func hostedTask(t *testing.T, s *Store, groupID int64, uid string) SharedTask {
+	t.Helper()
+	records, _, err := s.SharedTasksSince(context.Background(), groupID, 0)
+	if err != nil {
+		t.Fatalf("shared tasks: %v", err)
+	}
+	for _, r := range records {
+		if r.UID == uid {
+			return r
+		}
+	}
+	t.Fatalf("no shared task %s", uid)
+	return SharedTask{}
+}
+
+func TestAcceptSharedTasksUsesServerRevisions(t *testing.T) {
+	ctx := context.Background()
+	s, _, _, groupID := openTestHub(t)
+	future := time.Now().Add(365 * 24 * time.Hour).UnixMilli()
+
+	// 成员的时钟不影响冲突处理：修改时间改为服务端时间
+	n, err := s.AcceptSharedTasks(ctx, groupID, []SharedTask{{UID: "a", Title: "v1", Status: StatusTodo, UpdatedAt: future}})
+	if err != nil || n != 1 {
+		t.Fatalf("accept new task: %d, %v", n, err)
+	}
+	v1 := hostedTask(t, s, groupID, "a")
+	if v1.UpdatedAt >= future || v1.Rev == 0 {
+		t.Errorf("accepted record = %+v, want server time and a revision", v1)
+	}
+
+	// 基于旧修订的推送被忽略，即使修改时间更晚
+	if n, err := s.AcceptSharedTasks(ctx, groupID, []SharedTask{{UID: "a", Title: "stale", Status: StatusTodo, UpdatedAt: future}}); err != nil || n != 0 {
+		t.Errorf("accept stale change: %d, %v", n, err)
+	}
+	if n, err := s.AcceptSharedTasks(ctx, groupID, []SharedTask{{UID: "a", Title: "v2", Status: StatusTodo, Rev: v1.Rev}}); err != nil || n != 1 {
+		t.Errorf("accept change based on latest rev: %d, %v", n, err)
+	}
+	if got := hostedTask(t, s, groupID, "a"); got.Title != "v2" {
+		t.Errorf("title = %q, want v2", got.Title)
+	}
+
+	// 远超长度上限的记录跳过
+	settings, err := s.GetSettings(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	long := SharedTask{UID: "b", Title: strings.Repeat("x", 2*settings.TitleLimit), Status: StatusTodo}
+	if n, err := s.AcceptSharedTasks(ctx, groupID, []SharedTask{long}); err != nil || n != 0 {
+		t.Errorf("accept over-long title: %d, %v", n, err)
+	}
+}
+
+func TestMergeSharedTasksKeepsUnpushedLocalChanges(t *testing.T) {
+	ctx := context.Background()
+	s := openTestStore(t)
+	groupID := firstGroupID(t, s)
+	pushed := createTask(t, s, Task{GroupID: groupID, Title: "pushed"})
+	edited := createTask(t, s, Task{GroupID: groupID, Title: "edited"})
+	if _, err := s.ShareGroup(ctx, groupID, "share"); err != nil {
+		t.Fatal(err)
+	}
+	pending, seq, err := s.PendingSharedTasks(ctx, groupID)
+	if err != nil || len(pending) != 2 {
+		t.Fatalf("pending = %+v, %v", pending, err)
+	}
+	uids := map[int64]string{}
+	for _, r := range pending {
+		uids[map[string]int64{"pushed": pushed.ID, "edited": edited.ID}[r.Title]] = r.UID
+	}
+
+	// 推送之后本地又修改了 edited：服务端的旧版本不覆盖它；pushed 没有新修改，服务端版本覆盖本地，不比较修改时间
+	edited.Title = "edited again"
+	if _, err := s.UpsertTask(ctx, edited); err != nil {
+		t.Fatal(err)
+	}
+	records := []SharedTask{
+		{UID: uids[pushed.ID], Title: "from server", Status: StatusTodo, UpdatedAt: 1, Rev: 1},
+		{UID: uids[edited.ID], Title: "edited", Status: StatusTodo, UpdatedAt: 1, Rev: 2},
+	}
+	if _, err := s.MergeSharedTasks(ctx, groupID, records, 2, seq); err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+	if got, err := s.GetTask(ctx, pushed.ID); err != nil || got.Title != "from server" {
+		t.Errorf("pushed task = %q, %v; want the server version", got.Title, err)
+	}
+	if got, err := s.GetTask(ctx, edited.ID); err != nil || got.Title != "edited again" {
+		t.Errorf("edited task = %q, %v; want the unpushed local change", got.Title, err)
+	}
+}
+
+func TestHubTokensExpireAndRevoke(t *testing.T) {
+	ctx := context.Background()
+	s, account, token, _ := openTestHub(t)
+	if _, err := s.HubAccountByToken(ctx, token); err != nil {
+		t.Fatalf("fresh token: %v", err)
+	}
+
+	// 超过有效期未使用
+	old := time.Now().Add(-hubTokenTTL - time.Minute).UnixMilli()
+	if _, err := s.db.ExecContext(ctx, `UPDATE hub_tokens SET created_at = ?, used_at = ?`, old, old); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.HubAccountByToken(ctx, token); !errors.Is(err, ErrShareUnauthorized) {
+		t.Errorf("expired token: err = %v", err)
+	}
+
+	// 撤销单个令牌与全部令牌
+	_, t1, err := s.LoginHubAccount(ctx, "alice", "password123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, t2, err := s.LoginHubAccount(ctx, "alice", "password123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RevokeHubToken(ctx, t1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.HubAccountByToken(ctx, t1); !errors.Is(err, ErrShareUnauthorized) {
+		t.Errorf("revoked token: err = %v", err)
+	}
+	if _, err := s.HubAccountByToken(ctx, t2); err != nil {
+		t.Errorf("other token revoked too: %v", err)
+	}
+	if n, err := s.RevokeHubTokens(ctx, account.ID); err != nil || n != 1 {
+		t.Errorf("revoke all: %d, %v", n, err)
+	}
+	if _, err := s.HubAccountByToken(ctx, t2); !errors.Is(err, ErrShareUnauthorized) {
+		t.Errorf("token after revoke all: err = %v", err)
+	}
+}
+
+func TestHubLoginUnknownUserCostsAHash(t *testing.T) {
+	ctx := context.Background()
+	s, _, _, _ := openTestHub(t)
+	login := func(username string) time.Duration {
+		t.Helper()
+		start := time.Now()
+		if _, _, err := s.LoginHubAccount(ctx, username, "wrong-password"); !errors.Is(err, ErrShareBadCredentials) {
+			t.Fatalf("login %q: err = %v, want ErrShareBadCredentials", username, err)
+		}
+		return time.Since(start)
+	}
+	wrong, unknown := login("alice"), login("nobody")
+	// 用户名不存在时同样计算一次 PBKDF2，耗时应与密码错误同一量级
+	if unknown < wrong/4 {
+		t.Errorf("unknown user took %v, wrong password %v", unknown, wrong)
+	}
+}
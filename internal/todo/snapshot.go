@@ -0,0 +1,120 @@
+package todo
+
+import (
+	"context"
+	"time"
+)
+
+// SnapshotTask 为分组快照中的一个任务。
+type SnapshotTask struct {
+	Title       string         `json:"title"`
+	ContentHTML string         `json:"contentHTML"` // 经 RenderContent 渲染的安全 HTML；无内容为空
+	Status      Status         `json:"status"`
+	Important   bool           `json:"important"`
+	Urgent      bool           `json:"urgent"`
+	Due         string         `json:"due"`       // 截止时间；未设置为空
+	Completed   string         `json:"completed"` // 完成时间；未完成为空
+	Tags        []string       `json:"tags"`
+	SubTasks    []SnapshotTask `json:"subTasks"`
+}
+
+// SnapshotColumn 为分组快照中按状态划分的一列。
+type SnapshotColumn struct {
+	Status Status         `json:"status"`
+	Tasks  []SnapshotTask `json:"tasks"`
+}
+
+// GroupSnapshot 为单个分组当前状态的只读快照（用于导出可离线查看的 HTML）。
+type GroupSnapshot struct {
+	GroupName   string           `json:"groupName"`
+	Columns     []SnapshotColumn `json:"columns"` // 待办、进行中、已完成三列，顺序固定
+	Total       int              `json:"total"`   // 主任务总数
+	Done        int              `json:"done"`    // 已完成主任务数
+	GeneratedAt string           `json:"generatedAt"`
+}
+
+// GetGroupSnapshot 汇总分组 groupID 的全部主任务（含已完成任务、子任务、完整内容与标签），按状态分列。
+// 与看板导出不同，快照不受"隐藏已完成"设置影响，完整反映分组的当前状态。
+func (s *Store) GetGroupSnapshot(ctx context.Context, groupID int64, now time.Time, settings Settings) (GroupSnapshot, error) {
+	if groupID <= 0 {
+		return GroupSnapshot{}, ErrInvalidGroupID
+	}
+	group, err := s.GetGroup(ctx, groupID)
+	if err != nil {
+		return GroupSnapshot{}, err
+	}
+	tasks, err := s.queryTasks(ctx,
+		`SELECT `+taskColumns+` FROM tasks WHERE group_id = ? AND parent_id = 0 ORDER BY sort_order, id`, groupID)
+	if err != nil {
+		return GroupSnapshot{}, err
+	}
+	if err := s.attachSubTasks(ctx, tasks); err != nil {
+		return GroupSnapshot{}, err
+	}
+	if err := s.LoadFullContent(ctx, tasks); err != nil {
+		return GroupSnapshot{}, err
+	}
+
+	out := GroupSnapshot{
+		GroupName: group.Name,
+		Columns: []SnapshotColumn{
+			{Status: StatusTodo, Tasks: []SnapshotTask{}},
+			{Status: StatusDoing, Tasks: []SnapshotTask{}},
+			{Status: StatusDone, Tasks: []SnapshotTask{}},
+		},
+		GeneratedAt: FormatDateTime(now.In(Location(settings)), settings),
+	}
+	for _, t := range tasks {
+		st, err := s.snapshotTask(ctx, t, settings)
+		if err != nil {
+			return GroupSnapshot{}, err
+		}
+		for i := range out.Columns {
+			if out.Columns[i].Status == t.Status {
+				out.Columns[i].Tasks = append(out.Columns[i].Tasks, st)
+			}
+		}
+		out.Total++
+		if t.Status == StatusDone {
+			out.Done++
+		}
+	}
+	return out, nil
+}
+
+// snapshotTask 将任务（含子任务）转换为快照格式。
+func (s *Store) snapshotTask(ctx context.Context, t Task, settings Settings) (SnapshotTask, error) {
+	loc := Location(settings)
+	st := SnapshotTask{
+		Title:     t.Title,
+		Status:    t.Status,
+		Important: t.Important,
+		Urgent:    t.Urgent,
+		Tags:      []string{},
+		SubTasks:  []SnapshotTask{},
+	}
+	if t.Content != "" {
+		st.ContentHTML = RenderContent(t.Content, t.ContentFormat)
+	}
+	if t.DueAt > 0 {
+		st.Due = FormatDateTime(time.UnixMilli(t.DueAt).In(loc), settings)
+	}
+	if t.CompletedAt > 0 {
+		st.Completed = FormatDateTime(time.UnixMilli(t.CompletedAt).In(loc), settings)
+	}
+	tags, err := s.ListTaskTags(ctx, t.ID)
+	if err != nil {
+		return SnapshotTask{}, err
+	}
+	for _, tag := range tags {
+		st.Tags = append(st.Tags, tag.Name)
+	}
+	for _, sub := range t.SubTasks {
+		child, err := s.snapshotTask(ctx, sub, settings)
+		if err != nil {
+			return SnapshotTask{}, err
+		}
+		st.SubTasks = append(st.SubTasks, child)
+	}
+	return st, nil
+}
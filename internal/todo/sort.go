@@ -0,0 +1,92 @@
+package todo
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// 任务排序方式。
+const (
+	SortUpdated  = "updated"  // 最近修改在前（默认）
+	SortCreated  = "created"  // 最近创建在前
+	SortDue      = "due"      // 截止时间升序，未设置截止时间的垫底
+	SortTitle    = "title"    // 标题字母序（不区分大小写）
+	SortManual   = "manual"   // 手动排序（sort_order 升序）
+	SortQuadrant = "quadrant" // 四象限优先级：重要且紧急 > 重要 > 紧急 > 其它，同象限内进行中优先
+)
+
+// sortOrderSQL 为各排序方式对应的 ORDER BY 子句（id 兜底保证结果稳定）。
+var sortOrderSQL = map[string]string{
+	SortUpdated:  `updated_at DESC, id DESC`,
+	SortCreated:  `created_at DESC, id DESC`,
+	SortDue:      `CASE WHEN due_at = 0 THEN 1 ELSE 0 END, due_at, updated_at DESC, id DESC`,
+	SortTitle:    `title COLLATE NOCASE, id`,
+	SortManual:   `sort_order, id`,
+	SortQuadrant: `important DESC, urgent DESC, ` + statusOrderSQL + `, updated_at DESC, id DESC`,
+}
+
+// SortModes 返回全部支持的排序方式。
+func SortModes() []string {
+	return []string{SortUpdated, SortCreated, SortDue, SortTitle, SortManual, SortQuadrant}
+}
+
+// 可单独记住排序/筛选的视图。
+const (
+	ViewList      = "list"
+	ViewCards     = "cards"
+	ViewQuadrants = "quadrants"
+	ViewSwimlanes = "swimlanes"
+)
+
+// Views 返回可单独记住排序/筛选状态的视图。
+func Views() []string {
+	return []string{ViewList, ViewCards, ViewQuadrants, ViewSwimlanes}
+}
+
+// ParseSortMode 校验排序方式（忽略大小写与首尾空白）；空字符串视为 SortUpdated。
+func ParseSortMode(mode string) (string, error) {
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	if mode == "" {
+		return SortUpdated, nil
+	}
+	if _, ok := sortOrderSQL[mode]; !ok {
+		return "", ErrInvalidSortMode.with(mode)
+	}
+	return mode, nil
+}
+
+// parseView 校验视图名。
+func parseView(view string) (string, error) {
+	view = strings.TrimSpace(view)
+	if !containsString(Views(), view) {
+		return "", ErrInvalidView.with(view)
+	}
+	return view, nil
+}
+
+// normalizeViewSorts 校验 viewSorts 设置（JSON 对象：视图名 -> 排序方式），返回规范化后的 JSON。
+func normalizeViewSorts(v string) (string, error) {
+	sorts := map[string]string{}
+	if strings.TrimSpace(v) != "" {
+		if err := json.Unmarshal([]byte(v), &sorts); err != nil {
+			return "", ErrInvalidSortMode.with(v)
+		}
+	}
+	out := make(map[string]string, len(sorts))
+	for view, mode := range sorts {
+		view, err := parseView(view)
+		if err != nil {
+			return "", err
+		}
+		mode, err := ParseSortMode(mode)
+		if err != nil {
+			return "", err
+		}
+		out[view] = mode
+	}
+	data, err := json.Marshal(out)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -9,8 +9,8 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
-	"unicode/utf8"
 
 	// modernc.org/sqlite 是纯 Go 的 SQLite 驱动，方便跨平台打包（无需 CGO）。
 	sqlite "modernc.org/sqlite"
@@ -25,16 +25,16 @@ import (
 // 该应用是单用户桌面工具，因此这里将连接池限制为单连接（SetMaxOpenConns(1)），
 // 以降低 SQLite 锁/并发带来的复杂度，并配合 busy_timeout 做“温和等待”。
 type Store struct {
-	db *sql.DB
+	db *retryDB
+	// readOnly 表示只读模式（见 SetReadOnly）
+	readOnly atomic.Bool
 }
 
 const (
 	// 这些上限用 rune 数计数（而不是字节数），避免中文等多字节字符导致“看起来不长但字节很大”的体验问题。
-	maxGroupNameRunes   = 50
-	maxTaskTitleRunes   = 200
+	// 组名/任务标题/任务内容的上限由 groupNameLimit / titleLimit / contentLimit 设置决定。
 	maxTaskContentRunes = 1000
-	maxViewModeRunes    = 20
-	maxThemeRunes       = 10
+	maxUpdateURLRunes   = 500
 )
 
 // DefaultDBPath 返回默认数据库路径（并确保目录存在）。
@@ -65,7 +65,8 @@ func Open(dbPath string) (*Store, error) {
 		return nil, errors.New("db path is empty")
 	}
 
-	db, err := sql.Open("sqlite", dbPath)
+	// _txlock=immediate：事务一开始就获取写锁，锁冲突只出现在 BEGIN（由 retryDB 重试），不会发生在事务中途
+	db, err := sql.Open("sqlite", dbPath+"?_txlock=immediate")
 	if err != nil {
 		return nil, fmt.Errorf("open sqlite db: %w", err)
 	}
@@ -77,7 +78,7 @@ func Open(dbPath string) (*Store, error) {
 	db.SetMaxOpenConns(1)
 	db.SetMaxIdleConns(1)
 
-	s := &Store{db: db}
+	s := &Store{db: &retryDB{DB: db}}
 	if err := s.applyPragmas(context.Background()); err != nil {
 		_ = db.Close()
 		return nil, err
@@ -166,23 +167,454 @@ func (s *Store) migrate(ctx context.Context) error {
 	if err := s.ensureTasksColumns(ctx); err != nil {
 		return err
 	}
+	if err := s.ensureGroupsColumns(ctx); err != nil {
+		return err
+	}
 
 	if _, err := s.db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_tasks_important_urgent ON tasks(important, urgent)`); err != nil {
 		return fmt.Errorf("create tasks important/urgent index: %w", err)
 	}
+	if _, err := s.db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_tasks_due_at ON tasks(due_at) WHERE due_at > 0`); err != nil {
+		return fmt.Errorf("create tasks due_at index: %w", err)
+	}
+	// 挂载子任务时按 parent_id 查找，并沿用"最近修改在前"的顺序
+	if _, err := s.db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_tasks_parent_updated ON tasks(parent_id, updated_at DESC, id DESC)`); err != nil {
+		return fmt.Errorf("create tasks parent/updated index: %w", err)
+	}
+	// 看板默认排序（SortUpdated）可直接按索引顺序读取，无需临时排序
+	if _, err := s.db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_tasks_updated ON tasks(updated_at DESC, id DESC)`); err != nil {
+		return fmt.Errorf("create tasks updated_at index: %w", err)
+	}
+	// 四象限计数只需读取索引即可完成（覆盖索引）
+	if _, err := s.db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_tasks_quadrant ON tasks(parent_id, important, urgent, status, group_id)`); err != nil {
+		return fmt.Errorf("create tasks quadrant index: %w", err)
+	}
+	// "我的一天"：用户手动挑选的今日任务；day 为挑选当天的本地日期（YYYY-MM-DD），跨天后自动失效
+	if _, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS my_day (
+		task_id INTEGER PRIMARY KEY REFERENCES tasks(id) ON DELETE CASCADE,
+		day TEXT NOT NULL,
+		added_at INTEGER NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("create my_day table: %w", err)
+	}
+	// 回收站：删除的任务/分组以 JSON 快照保存，payload 足以原样恢复
+	if _, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS trash (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		kind TEXT NOT NULL CHECK (kind IN ('task','group')),
+		item_id INTEGER NOT NULL,
+		title TEXT NOT NULL,
+		task_count INTEGER NOT NULL DEFAULT 0,
+		payload TEXT NOT NULL,
+		deleted_at INTEGER NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("create trash table: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_trash_deleted_at ON trash(deleted_at)`); err != nil {
+		return fmt.Errorf("create trash deleted_at index: %w", err)
+	}
+	// 目标：进度由 goal_tasks 中关联任务的完成情况计算
+	if _, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS goals (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		title TEXT NOT NULL,
+		target_date INTEGER NOT NULL DEFAULT 0,
+		target_count INTEGER NOT NULL DEFAULT 0,
+		created_at INTEGER NOT NULL,
+		updated_at INTEGER NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("create goals table: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS goal_tasks (
+		goal_id INTEGER NOT NULL REFERENCES goals(id) ON DELETE CASCADE,
+		task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+		PRIMARY KEY (goal_id, task_id)
+	)`); err != nil {
+		return fmt.Errorf("create goal_tasks table: %w", err)
+	}
+	// 积分记录：每个任务最多计分一次（不随任务删除，避免删除已完成任务后积分倒退）；成就解锁后永久保留
+	if _, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS points (
+		task_id INTEGER PRIMARY KEY,
+		parent_id INTEGER NOT NULL DEFAULT 0,
+		quadrant TEXT NOT NULL DEFAULT '',
+		points INTEGER NOT NULL,
+		awarded_at INTEGER NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("create points table: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS achievements (
+		id TEXT PRIMARY KEY,
+		unlocked_at INTEGER NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("create achievements table: %w", err)
+	}
+	// 时间记录（任务计时器 / 番茄钟）：ended_at 为 0 表示进行中。
+	// task_id 不设外键：任务删除后记录仍保留，统计与工时表不会因此缺失
+	if _, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS time_entries (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		task_id INTEGER NOT NULL,
+		source TEXT NOT NULL DEFAULT 'timer',
+		started_at INTEGER NOT NULL,
+		ended_at INTEGER NOT NULL DEFAULT 0
+	)`); err != nil {
+		return fmt.Errorf("create time_entries table: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_time_entries_started_at ON time_entries(started_at)`); err != nil {
+		return fmt.Errorf("create time_entries started_at index: %w", err)
+	}
+	// 计时不能重叠：最多一条进行中的记录。早期版本并发开始计时可能留下多条，只保留最新的一条，其余按开始时间结束
+	if _, err := s.db.ExecContext(ctx, `UPDATE time_entries SET ended_at = started_at
+		WHERE ended_at = 0 AND id <> (SELECT MAX(id) FROM time_entries WHERE ended_at = 0)`); err != nil {
+		return fmt.Errorf("close overlapping time entries: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `CREATE UNIQUE INDEX IF NOT EXISTS idx_time_entries_open ON time_entries(ended_at) WHERE ended_at = 0`); err != nil {
+		return fmt.Errorf("create time_entries open index: %w", err)
+	}
+	// 番茄钟：同一时间最多一个 running/paused 的番茄钟；entry_id 为进行中那一段专注的时间记录（暂停时为 0）
+	if _, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS pomodoro_sessions (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		task_id INTEGER NOT NULL,
+		state TEXT NOT NULL,
+		planned_ms INTEGER NOT NULL,
+		focused_ms INTEGER NOT NULL DEFAULT 0,
+		segment_started_at INTEGER NOT NULL DEFAULT 0,
+		entry_id INTEGER NOT NULL DEFAULT 0,
+		started_at INTEGER NOT NULL,
+		ended_at INTEGER NOT NULL DEFAULT 0
+	)`); err != nil {
+		return fmt.Errorf("create pomodoro_sessions table: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_pomodoro_sessions_state ON pomodoro_sessions(state, ended_at)`); err != nil {
+		return fmt.Errorf("create pomodoro_sessions state index: %w", err)
+	}
+	// 标签：名称不区分大小写唯一；task_tags 随任务/标签删除级联清理
+	if _, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS tags (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL UNIQUE COLLATE NOCASE,
+		created_at INTEGER NOT NULL,
+		updated_at INTEGER NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("create tags table: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS task_tags (
+		task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+		tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+		PRIMARY KEY (task_id, tag_id)
+	)`); err != nil {
+		return fmt.Errorf("create task_tags table: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_task_tags_tag_id ON task_tags(tag_id)`); err != nil {
+		return fmt.Errorf("create task_tags tag_id index: %w", err)
+	}
+	// 任务备注：随任务删除级联清理
+	if _, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS task_notes (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+		body TEXT NOT NULL,
+		created_at INTEGER NOT NULL,
+		updated_at INTEGER NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("create task_notes table: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_task_notes_task_id ON task_notes(task_id, created_at)`); err != nil {
+		return fmt.Errorf("create task_notes task_id index: %w", err)
+	}
+	// 任务依赖：task_id 被 blocked_by_id 阻塞（前置任务完成前不宜开始）；任一任务删除时级联清理
+	if _, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS task_dependencies (
+		task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+		blocked_by_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+		created_at INTEGER NOT NULL,
+		PRIMARY KEY (task_id, blocked_by_id)
+	)`); err != nil {
+		return fmt.Errorf("create task_dependencies table: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_task_dependencies_blocked_by ON task_dependencies(blocked_by_id)`); err != nil {
+		return fmt.Errorf("create task_dependencies blocked_by index: %w", err)
+	}
+	// 长内容溢出存储：tasks.content 只保留预览，完整内容在此（见 contentPreviewRunes）
+	// compressed 为 1 时 content 为 DEFLATE 压缩后的字节（见 compressThresholdBytes）
+	if _, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS task_content (
+		task_id INTEGER PRIMARY KEY REFERENCES tasks(id) ON DELETE CASCADE,
+		content TEXT NOT NULL,
+		compressed INTEGER NOT NULL DEFAULT 0
+	)`); err != nil {
+		return fmt.Errorf("create task_content table: %w", err)
+	}
+	contentCols, err := s.tableColumns(ctx, "task_content")
+	if err != nil {
+		return err
+	}
+	if !contentCols["compressed"] {
+		if _, err := s.db.ExecContext(ctx, `ALTER TABLE task_content ADD COLUMN compressed INTEGER NOT NULL DEFAULT 0`); err != nil {
+			return fmt.Errorf("add task_content.compressed: %w", err)
+		}
+	}
+	// 附件：只记录元数据，文件保存在数据目录的 attachments 文件夹中
+	if _, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS attachments (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+		file TEXT NOT NULL,
+		mime TEXT NOT NULL,
+		size INTEGER NOT NULL DEFAULT 0,
+		width INTEGER NOT NULL DEFAULT 0,
+		height INTEGER NOT NULL DEFAULT 0,
+		created_at INTEGER NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("create attachments table: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_attachments_task_id ON attachments(task_id)`); err != nil {
+		return fmt.Errorf("create attachments task_id index: %w", err)
+	}
+	// 重复规则：每个主任务至多一条，完成时随下一次任务转移（见 materializeRecurrence）
+	if _, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS task_recurrence (
+		task_id INTEGER PRIMARY KEY REFERENCES tasks(id) ON DELETE CASCADE,
+		freq TEXT NOT NULL,
+		interval INTEGER NOT NULL DEFAULT 1,
+		mode TEXT NOT NULL DEFAULT 'schedule',
+		created_at INTEGER NOT NULL,
+		updated_at INTEGER NOT NULL,
+		skip_holidays INTEGER NOT NULL DEFAULT 0,
+		lunar INTEGER NOT NULL DEFAULT 0,
+		weekdays TEXT NOT NULL DEFAULT ''
+	)`); err != nil {
+		return fmt.Errorf("create task_recurrence table: %w", err)
+	}
+	recurCols, err := s.tableColumns(ctx, "task_recurrence")
+	if err != nil {
+		return err
+	}
+	if !recurCols["skip_holidays"] {
+		if _, err := s.db.ExecContext(ctx, `ALTER TABLE task_recurrence ADD COLUMN skip_holidays INTEGER NOT NULL DEFAULT 0`); err != nil {
+			return fmt.Errorf("add task_recurrence.skip_holidays: %w", err)
+		}
+	}
+	if !recurCols["lunar"] {
+		if _, err := s.db.ExecContext(ctx, `ALTER TABLE task_recurrence ADD COLUMN lunar INTEGER NOT NULL DEFAULT 0`); err != nil {
+			return fmt.Errorf("add task_recurrence.lunar: %w", err)
+		}
+	}
+	if !recurCols["weekdays"] {
+		if _, err := s.db.ExecContext(ctx, `ALTER TABLE task_recurrence ADD COLUMN weekdays TEXT NOT NULL DEFAULT ''`); err != nil {
+			return fmt.Errorf("add task_recurrence.weekdays: %w", err)
+		}
+	}
+	// 倒计时提醒记录：同一截止时间的每个节点只提醒一次，修改截止时间后重新提醒
+	if _, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS countdown_notices (
+		task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+		milestone INTEGER NOT NULL,
+		due_at INTEGER NOT NULL,
+		notified_at INTEGER NOT NULL,
+		PRIMARY KEY (task_id, milestone, due_at)
+	)`); err != nil {
+		return fmt.Errorf("create countdown_notices table: %w", err)
+	}
+	// 任务状态转换记录：由触发器维护，用于计算任务在当前状态停留的时长
+	if _, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS task_status_history (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+		from_status TEXT NOT NULL DEFAULT '',
+		to_status TEXT NOT NULL,
+		changed_at INTEGER NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("create task_status_history table: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_task_status_history_task ON task_status_history(task_id, changed_at)`); err != nil {
+		return fmt.Errorf("create task_status_history task index: %w", err)
+	}
+	for _, stmt := range statusHistoryTriggers {
+		if _, err := s.db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("create status history trigger: %w", err)
+		}
+	}
+	if _, err := s.db.ExecContext(ctx, backfillStatusHistorySQL); err != nil {
+		return fmt.Errorf("backfill status history: %w", err)
+	}
+	// 动态流：由触发器记录任务/分组的新建、完成、移动、改名、删除等操作，供"今天做了什么"面板
+	if _, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS activity_log (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		action TEXT NOT NULL,
+		task_id INTEGER NOT NULL DEFAULT 0,
+		group_id INTEGER NOT NULL DEFAULT 0,
+		title TEXT NOT NULL DEFAULT '',
+		detail TEXT NOT NULL DEFAULT '',
+		created_at INTEGER NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("create activity_log table: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_activity_log_created_at ON activity_log(created_at)`); err != nil {
+		return fmt.Errorf("create activity_log created_at index: %w", err)
+	}
+	for _, stmt := range activityTriggers {
+		if _, err := s.db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("create activity trigger: %w", err)
+		}
+	}
+	// 共享分组：与同步服务器上的分组关联，rev 为已拉取的服务端修订号，pushed_seq 为已推送的本地变更序号
+	if _, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS shared_groups (
+		group_id INTEGER PRIMARY KEY REFERENCES groups(id) ON DELETE CASCADE,
+		share_id TEXT NOT NULL UNIQUE,
+		rev INTEGER NOT NULL DEFAULT 0,
+		pushed_seq INTEGER NOT NULL DEFAULT 0,
+		synced_at INTEGER NOT NULL DEFAULT 0,
+		created_at INTEGER NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("create shared_groups table: %w", err)
+	}
+	// 共享任务：任务在各设备间的 uid 映射；task_id 为 0 表示已删除（墓碑），由触发器维护
+	if _, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS shared_tasks (
+		uid TEXT PRIMARY KEY,
+		group_id INTEGER NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+		task_id INTEGER NOT NULL DEFAULT 0,
+		rev INTEGER NOT NULL DEFAULT 0,
+		seq INTEGER NOT NULL DEFAULT 0,
+		updated_at INTEGER NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("create shared_tasks table: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_shared_tasks_task_id ON shared_tasks(task_id) WHERE task_id > 0`); err != nil {
+		return fmt.Errorf("create shared_tasks task_id index: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_shared_tasks_group ON shared_tasks(group_id, seq)`); err != nil {
+		return fmt.Errorf("create shared_tasks group index: %w", err)
+	}
+	for _, stmt := range sharedTaskTriggers {
+		if _, err := s.db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("create shared task trigger: %w", err)
+		}
+	}
+	// 看板变更记录：由触发器维护每个任务/分组最近一次变化（含删除）的时间，供 GetBoard 增量刷新
+	if _, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS board_changes (
+		kind TEXT NOT NULL,
+		item_id INTEGER NOT NULL,
+		deleted INTEGER NOT NULL DEFAULT 0,
+		changed_at INTEGER NOT NULL,
+		PRIMARY KEY (kind, item_id)
+	)`); err != nil {
+		return fmt.Errorf("create board_changes table: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_board_changes_changed_at ON board_changes(changed_at)`); err != nil {
+		return fmt.Errorf("create board_changes changed_at index: %w", err)
+	}
+	for _, stmt := range boardChangeTriggers {
+		if _, err := s.db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("create board change trigger: %w", err)
+		}
+	}
+	// 最近访问的任务：打开详情或修改任务时记录，供"最近访问"快速切换（只保留最近 maxRecentTasks 条）
+	if _, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS recent_tasks (
+		task_id INTEGER PRIMARY KEY REFERENCES tasks(id) ON DELETE CASCADE,
+		kind TEXT NOT NULL,
+		visited_at INTEGER NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("create recent_tasks table: %w", err)
+	}
+	// 通知中心：保存生成过的每条提醒（喝水、倒计时、成就、新版本等），错过的弹窗可在应用内回看
+	if _, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS notifications (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		kind TEXT NOT NULL,
+		title TEXT NOT NULL,
+		body TEXT NOT NULL DEFAULT '',
+		task_id INTEGER NOT NULL DEFAULT 0,
+		data TEXT NOT NULL DEFAULT '',
+		dedupe_key TEXT NOT NULL DEFAULT '',
+		created_at INTEGER NOT NULL,
+		read_at INTEGER NOT NULL DEFAULT 0
+	)`); err != nil {
+		return fmt.Errorf("create notifications table: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `CREATE UNIQUE INDEX IF NOT EXISTS idx_notifications_dedupe ON notifications(dedupe_key) WHERE dedupe_key <> ''`); err != nil {
+		return fmt.Errorf("create notifications dedupe index: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_notifications_unread ON notifications(read_at) WHERE read_at = 0`); err != nil {
+		return fmt.Errorf("create notifications unread index: %w", err)
+	}
+	// 本地 REST 接口的访问令牌：只保存令牌的 SHA-256 摘要，hint 为令牌开头部分，供列表中辨认
+	if _, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS api_tokens (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL,
+		scope TEXT NOT NULL CHECK (scope IN ('read','readwrite')),
+		hint TEXT NOT NULL,
+		token_hash TEXT NOT NULL UNIQUE,
+		created_at INTEGER NOT NULL,
+		last_used_at INTEGER NOT NULL DEFAULT 0
+	)`); err != nil {
+		return fmt.Errorf("create api_tokens table: %w", err)
+	}
+
+	// 全文索引：trigram 分词（中英文都能按子串检索）。索引表自行保存标题与完整内容的副本：
+	// 标题与预览由触发器与 tasks 保持同步，溢出到 task_content 的完整内容（可能已压缩）由 saveContentOverflow 写入。
+	// 早期版本以 tasks 为外部内容表、只索引预览，升级时重建。
+	var ftsSQL string
+	if err := s.db.QueryRowContext(ctx, `SELECT COALESCE(MAX(sql), '') FROM sqlite_master WHERE type = 'table' AND name = 'task_fts'`).Scan(&ftsSQL); err != nil {
+		return fmt.Errorf("read task_fts schema: %w", err)
+	}
+	rebuildFTS := ftsSQL == ""
+	if strings.Contains(ftsSQL, "content='tasks'") {
+		for _, stmt := range []string{
+			`DROP TRIGGER IF EXISTS task_fts_ai`, `DROP TRIGGER IF EXISTS task_fts_ad`, `DROP TRIGGER IF EXISTS task_fts_au`, `DROP TABLE task_fts`,
+		} {
+			if _, err := s.db.ExecContext(ctx, stmt); err != nil {
+				return fmt.Errorf("drop preview-only task_fts: %w", err)
+			}
+		}
+		rebuildFTS = true
+	}
+	if _, err := s.db.ExecContext(ctx, `CREATE VIRTUAL TABLE IF NOT EXISTS task_fts USING fts5(
+		title, content, tokenize='trigram'
+	)`); err != nil {
+		return fmt.Errorf("create task_fts table: %w", err)
+	}
+	for _, trigger := range []string{
+		`CREATE TRIGGER IF NOT EXISTS task_fts_ai AFTER INSERT ON tasks BEGIN
+			INSERT INTO task_fts(rowid, title, content) VALUES (new.id, new.title, new.content);
+		END`,
+		`CREATE TRIGGER IF NOT EXISTS task_fts_ad AFTER DELETE ON tasks BEGIN
+			DELETE FROM task_fts WHERE rowid = old.id;
+		END`,
+		// 有溢出内容时索引中已是完整内容，只更新标题（内容由 saveContentOverflow 更新）
+		`CREATE TRIGGER IF NOT EXISTS task_fts_au AFTER UPDATE OF title, content ON tasks BEGIN
+			UPDATE task_fts SET title = new.title,
+				content = CASE WHEN EXISTS (SELECT 1 FROM task_content WHERE task_id = new.id) THEN content ELSE new.content END
+			WHERE rowid = new.id;
+		END`,
+	} {
+		if _, err := s.db.ExecContext(ctx, trigger); err != nil {
+			return fmt.Errorf("create task_fts trigger: %w", err)
+		}
+	}
+	if rebuildFTS {
+		// 首次创建索引（新库或旧库升级）时按现有任务建立索引
+		if err := s.rebuildTaskFTS(ctx); err != nil {
+			return err
+		}
+	}
+	// 任务提醒：每个任务最多一个提醒，到时由后台定时检查并发出系统通知（fired_at 为实际提醒时间，未提醒为 0）
+	if _, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS reminders (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		task_id INTEGER NOT NULL UNIQUE REFERENCES tasks(id) ON DELETE CASCADE,
+		remind_at INTEGER NOT NULL,
+		fired_at INTEGER NOT NULL DEFAULT 0,
+		created_at INTEGER NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("create reminders table: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_reminders_pending ON reminders(fired_at, remind_at)`); err != nil {
+		return fmt.Errorf("create reminders pending index: %w", err)
+	}
+
+	// 让查询规划器按需刷新统计信息（数据量大时才会真正执行 ANALYZE，开销很小）
+	if _, err := s.db.ExecContext(ctx, `PRAGMA optimize = 0x10002`); err != nil {
+		return fmt.Errorf("pragma optimize: %w", err)
+	}
 
 	return nil
 }
 
-// ensureTasksColumns 用于向后兼容老版本数据库：
-// - 读取 tasks 表列信息
-// - 若缺少 important/urgent 列则补齐
-func (s *Store) ensureTasksColumns(ctx context.Context) error {
+// tableColumns 通过 PRAGMA table_info 读取表的列名集合。
+func (s *Store) tableColumns(ctx context.Context, table string) (map[string]bool, error) {
 	cols := map[string]bool{}
 
-	rows, err := s.db.QueryContext(ctx, `PRAGMA table_info(tasks)`)
+	rows, err := s.db.QueryContext(ctx, `PRAGMA table_info(`+table+`)`)
 	if err != nil {
-		return fmt.Errorf("read tasks schema: %w", err)
+		return nil, fmt.Errorf("read %s schema: %w", table, err)
 	}
 	defer rows.Close()
 
@@ -194,12 +626,66 @@ func (s *Store) ensureTasksColumns(ctx context.Context) error {
 		var dflt sql.NullString
 		var pk int
 		if err := rows.Scan(&cid, &name, &ctype, &notnull, &dflt, &pk); err != nil {
-			return fmt.Errorf("scan tasks schema: %w", err)
+			return nil, fmt.Errorf("scan %s schema: %w", table, err)
 		}
 		cols[name] = true
 	}
 	if err := rows.Err(); err != nil {
-		return fmt.Errorf("iterate tasks schema: %w", err)
+		return nil, fmt.Errorf("iterate %s schema: %w", table, err)
+	}
+	return cols, nil
+}
+
+// ensureGroupsColumns 为老版本数据库的 groups 表补齐 archived/favorite/sort_order/color/icon/parent_id 列。
+func (s *Store) ensureGroupsColumns(ctx context.Context) error {
+	cols, err := s.tableColumns(ctx, "groups")
+	if err != nil {
+		return err
+	}
+	if !cols["archived"] {
+		if _, err := s.db.ExecContext(ctx, `ALTER TABLE groups ADD COLUMN archived INTEGER NOT NULL DEFAULT 0 CHECK (archived IN (0,1))`); err != nil {
+			return fmt.Errorf("add groups.archived: %w", err)
+		}
+	}
+	if !cols["favorite"] {
+		if _, err := s.db.ExecContext(ctx, `ALTER TABLE groups ADD COLUMN favorite INTEGER NOT NULL DEFAULT 0 CHECK (favorite IN (0,1))`); err != nil {
+			return fmt.Errorf("add groups.favorite: %w", err)
+		}
+	}
+	if !cols["sort_order"] {
+		if _, err := s.db.ExecContext(ctx, `ALTER TABLE groups ADD COLUMN sort_order INTEGER NOT NULL DEFAULT 0`); err != nil {
+			return fmt.Errorf("add groups.sort_order: %w", err)
+		}
+		// 老数据按 id 排列，保持升级前的显示顺序
+		if _, err := s.db.ExecContext(ctx, `UPDATE groups SET sort_order = id`); err != nil {
+			return fmt.Errorf("init groups.sort_order: %w", err)
+		}
+	}
+	if !cols["color"] {
+		if _, err := s.db.ExecContext(ctx, `ALTER TABLE groups ADD COLUMN color TEXT NOT NULL DEFAULT ''`); err != nil {
+			return fmt.Errorf("add groups.color: %w", err)
+		}
+	}
+	if !cols["icon"] {
+		if _, err := s.db.ExecContext(ctx, `ALTER TABLE groups ADD COLUMN icon TEXT NOT NULL DEFAULT ''`); err != nil {
+			return fmt.Errorf("add groups.icon: %w", err)
+		}
+	}
+	if !cols["parent_id"] {
+		if _, err := s.db.ExecContext(ctx, `ALTER TABLE groups ADD COLUMN parent_id INTEGER NOT NULL DEFAULT 0`); err != nil {
+			return fmt.Errorf("add groups.parent_id: %w", err)
+		}
+	}
+	return nil
+}
+
+// ensureTasksColumns 用于向后兼容老版本数据库：
+// - 读取 tasks 表列信息
+// - 若缺少 important/urgent/parent_id/due_at/sort_order/completed_at/reviewed_at/color/emoji/url/content_overflow/content_format/cover_id/countdown 列则补齐
+func (s *Store) ensureTasksColumns(ctx context.Context) error {
+	cols, err := s.tableColumns(ctx, "tasks")
+	if err != nil {
+		return err
 	}
 
 	if !cols["important"] {
@@ -217,6 +703,69 @@ func (s *Store) ensureTasksColumns(ctx context.Context) error {
 			return fmt.Errorf("add tasks.parent_id: %w", err)
 		}
 	}
+	if !cols["sort_order"] {
+		if _, err := s.db.ExecContext(ctx, `ALTER TABLE tasks ADD COLUMN sort_order INTEGER NOT NULL DEFAULT 0`); err != nil {
+			return fmt.Errorf("add tasks.sort_order: %w", err)
+		}
+		// 老数据按创建顺序初始化手动排序
+		if _, err := s.db.ExecContext(ctx, `UPDATE tasks SET sort_order = id`); err != nil {
+			return fmt.Errorf("init tasks.sort_order: %w", err)
+		}
+	}
+	if !cols["completed_at"] {
+		if _, err := s.db.ExecContext(ctx, `ALTER TABLE tasks ADD COLUMN completed_at INTEGER NOT NULL DEFAULT 0`); err != nil {
+			return fmt.Errorf("add tasks.completed_at: %w", err)
+		}
+		// 老数据没有完成时间，已完成任务用最后修改时间近似
+		if _, err := s.db.ExecContext(ctx, `UPDATE tasks SET completed_at = updated_at WHERE status = 'done'`); err != nil {
+			return fmt.Errorf("init tasks.completed_at: %w", err)
+		}
+	}
+	if !cols["due_at"] {
+		if _, err := s.db.ExecContext(ctx, `ALTER TABLE tasks ADD COLUMN due_at INTEGER NOT NULL DEFAULT 0`); err != nil {
+			return fmt.Errorf("add tasks.due_at: %w", err)
+		}
+	}
+	if !cols["reviewed_at"] {
+		if _, err := s.db.ExecContext(ctx, `ALTER TABLE tasks ADD COLUMN reviewed_at INTEGER NOT NULL DEFAULT 0`); err != nil {
+			return fmt.Errorf("add tasks.reviewed_at: %w", err)
+		}
+	}
+	if !cols["color"] {
+		if _, err := s.db.ExecContext(ctx, `ALTER TABLE tasks ADD COLUMN color TEXT NOT NULL DEFAULT ''`); err != nil {
+			return fmt.Errorf("add tasks.color: %w", err)
+		}
+	}
+	if !cols["emoji"] {
+		if _, err := s.db.ExecContext(ctx, `ALTER TABLE tasks ADD COLUMN emoji TEXT NOT NULL DEFAULT ''`); err != nil {
+			return fmt.Errorf("add tasks.emoji: %w", err)
+		}
+	}
+	if !cols["url"] {
+		if _, err := s.db.ExecContext(ctx, `ALTER TABLE tasks ADD COLUMN url TEXT NOT NULL DEFAULT ''`); err != nil {
+			return fmt.Errorf("add tasks.url: %w", err)
+		}
+	}
+	if !cols["content_overflow"] {
+		if _, err := s.db.ExecContext(ctx, `ALTER TABLE tasks ADD COLUMN content_overflow INTEGER NOT NULL DEFAULT 0 CHECK (content_overflow IN (0,1))`); err != nil {
+			return fmt.Errorf("add tasks.content_overflow: %w", err)
+		}
+	}
+	if !cols["content_format"] {
+		if _, err := s.db.ExecContext(ctx, `ALTER TABLE tasks ADD COLUMN content_format TEXT NOT NULL DEFAULT 'plain'`); err != nil {
+			return fmt.Errorf("add tasks.content_format: %w", err)
+		}
+	}
+	if !cols["cover_id"] {
+		if _, err := s.db.ExecContext(ctx, `ALTER TABLE tasks ADD COLUMN cover_id INTEGER NOT NULL DEFAULT 0`); err != nil {
+			return fmt.Errorf("add tasks.cover_id: %w", err)
+		}
+	}
+	if !cols["countdown"] {
+		if _, err := s.db.ExecContext(ctx, `ALTER TABLE tasks ADD COLUMN countdown INTEGER NOT NULL DEFAULT 0 CHECK (countdown IN (0,1))`); err != nil {
+			return fmt.Errorf("add tasks.countdown: %w", err)
+		}
+	}
 
 	return nil
 }
@@ -237,7 +786,7 @@ func (s *Store) ensureDefaultGroup(ctx context.Context) error {
 
 	now := time.Now().UnixMilli()
 	_, err := s.db.ExecContext(ctx,
-		`INSERT INTO groups(name, created_at, updated_at) VALUES(?, ?, ?)`,
+		`INSERT INTO groups(name, sort_order, created_at, updated_at) VALUES(?, `+nextGroupOrderSQL+`, ?, ?)`,
 		defaultName, now, now,
 	)
 	if err != nil {
@@ -246,31 +795,9 @@ func (s *Store) ensureDefaultGroup(ctx context.Context) error {
 	return nil
 }
 
-// ensureDefaultSettings 写入默认设置（仅在 key 不存在时插入，不覆盖用户已有选择）。
-func (s *Store) ensureDefaultSettings(ctx context.Context) error {
-	// Defaults: floating always-on-top by default, show done by default.
-	defaults := map[string]string{
-		"alwaysOnTop": "1",
-		"hideDone":    "0",
-		"viewMode":    "cards",
-		"conciseMode": "0",
-		"theme":       "light",
-	}
-
-	for k, v := range defaults {
-		if _, err := s.db.ExecContext(ctx,
-			`INSERT OR IGNORE INTO settings(key, value) VALUES(?, ?)`,
-			k, v,
-		); err != nil {
-			return fmt.Errorf("init settings %q: %w", k, err)
-		}
-	}
-	return nil
-}
-
-// ListGroups 返回所有分组，按 id 升序排列（稳定、便于前端展示）。
+// ListGroups 返回所有分组：常用分组在前，其余按手动排序（sort_order）排列，id 兜底保证稳定。
 func (s *Store) ListGroups(ctx context.Context) ([]Group, error) {
-	rows, err := s.db.QueryContext(ctx, `SELECT id, name, created_at, updated_at FROM groups ORDER BY id`)
+	rows, err := s.db.QueryContext(ctx, `SELECT `+groupColumns+` FROM groups ORDER BY `+groupOrderSQL)
 	if err != nil {
 		return nil, fmt.Errorf("list groups: %w", err)
 	}
@@ -278,9 +805,9 @@ func (s *Store) ListGroups(ctx context.Context) ([]Group, error) {
 
 	var out []Group
 	for rows.Next() {
-		var g Group
-		if err := rows.Scan(&g.ID, &g.Name, &g.CreatedAt, &g.UpdatedAt); err != nil {
-			return nil, fmt.Errorf("scan group: %w", err)
+		g, err := scanGroup(rows)
+		if err != nil {
+			return nil, err
 		}
 		out = append(out, g)
 	}
@@ -290,48 +817,83 @@ func (s *Store) ListGroups(ctx context.Context) ([]Group, error) {
 	return out, nil
 }
 
-// UpsertGroup 新增或更新分组。
+// GetGroup 返回单个分组。
+func (s *Store) GetGroup(ctx context.Context, id int64) (Group, error) {
+	g, err := scanGroup(s.db.QueryRowContext(ctx, `SELECT `+groupColumns+` FROM groups WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return Group{}, ErrGroupNotFound.with(id)
+	}
+	if err != nil {
+		return Group{}, fmt.Errorf("get group: %w", err)
+	}
+	return g, nil
+}
+
+// UpsertGroup 新增或更新分组，返回落库后的分组。
 //
 // 约定：
-// - id==0 => 新增
-// - id>0  => 更新指定 id 的名称
+// - g.ID==0 => 新增（排在最后）
+// - g.ID>0  => 更新指定分组的名称、颜色、图标与上级分组
 //
-// 该表对 name 做了 UNIQUE 约束：出现重复时返回稳定的中文错误提示。
-func (s *Store) UpsertGroup(ctx context.Context, id int64, name string) (Group, error) {
-	name = strings.TrimSpace(name)
-	if name == "" {
-		return Group{}, errors.New("组名不能为空")
+// 归档、常用与排序位置有各自的接口，这里忽略 g 中对应的字段。
+// g.ParentID 须为已有分组，且不能是分组自身或其下级分组（否则形成环，返回 ErrGroupCycle）。
+// 名称先经 normalizeGroupName 规范化；与已有分组重名（忽略大小写与全角/半角）时返回 ErrGroupNameTaken，
+// 其 Data["groupId"] 为已有分组的 ID，界面可据此跳转（表上的 UNIQUE 约束作为最后一道防线）。
+// 颜色须为 #rgb/#rrggbb，图标须为单个表情，空字符串表示不设置。
+func (s *Store) UpsertGroup(ctx context.Context, g Group) (Group, error) {
+	settings, err := s.GetSettings(ctx)
+	if err != nil {
+		return Group{}, err
+	}
+	id := g.ID
+	name, err := normalizeGroupName(g.Name, settings.GroupNameLimit)
+	if err != nil {
+		return Group{}, err
+	}
+	color, err := normalizeHexColor(g.Color)
+	if err != nil {
+		return Group{}, err
+	}
+	icon, err := normalizeTaskEmoji(g.Icon)
+	if err != nil {
+		return Group{}, err
+	}
+	if err := s.checkGroupParent(ctx, id, g.ParentID); err != nil {
+		return Group{}, err
+	}
+	existingID, err := s.findGroupByName(ctx, name, id)
+	if err != nil {
+		return Group{}, err
 	}
-	if utf8.RuneCountInString(name) > maxGroupNameRunes {
-		return Group{}, fmt.Errorf("组名过长（最多 %d 字）", maxGroupNameRunes)
+	if existingID > 0 {
+		return Group{}, ErrGroupNameTaken.withData(map[string]any{"groupId": existingID})
 	}
 
 	now := time.Now().UnixMilli()
 	if id == 0 {
 		res, err := s.db.ExecContext(ctx,
-			`INSERT INTO groups(name, created_at, updated_at) VALUES(?, ?, ?)`,
-			name, now, now,
+			`INSERT INTO groups(name, parent_id, color, icon, sort_order, created_at, updated_at) VALUES(?, ?, ?, ?, `+nextGroupOrderSQL+`, ?, ?)`,
+			name, g.ParentID, color, icon, now, now,
 		)
 		if err != nil {
 			if sqliteIsConstraint(err, sqlitelib.SQLITE_CONSTRAINT_UNIQUE) {
-				return Group{}, errors.New("组名已存在")
+				return Group{}, ErrGroupNameTaken
 			}
 			return Group{}, fmt.Errorf("create group: %w", err)
 		}
-		newID, err := res.LastInsertId()
-		if err != nil {
+		if id, err = res.LastInsertId(); err != nil {
 			return Group{}, fmt.Errorf("get new group id: %w", err)
 		}
-		return Group{ID: newID, Name: name, CreatedAt: now, UpdatedAt: now}, nil
+		return s.GetGroup(ctx, id)
 	}
 
 	res, err := s.db.ExecContext(ctx,
-		`UPDATE groups SET name = ?, updated_at = ? WHERE id = ?`,
-		name, now, id,
+		`UPDATE groups SET name = ?, parent_id = ?, color = ?, icon = ?, updated_at = ? WHERE id = ?`,
+		name, g.ParentID, color, icon, now, id,
 	)
 	if err != nil {
 		if sqliteIsConstraint(err, sqlitelib.SQLITE_CONSTRAINT_UNIQUE) {
-			return Group{}, errors.New("组名已存在")
+			return Group{}, ErrGroupNameTaken
 		}
 		return Group{}, fmt.Errorf("update group: %w", err)
 	}
@@ -340,14 +902,42 @@ func (s *Store) UpsertGroup(ctx context.Context, id int64, name string) (Group,
 		return Group{}, fmt.Errorf("update group rows affected: %w", err)
 	}
 	if affected == 0 {
-		return Group{}, fmt.Errorf("组不存在（id=%d）", id)
+		return Group{}, ErrGroupNotFound.with(id)
 	}
 
-	var g Group
-	if err := s.db.QueryRowContext(ctx,
-		`SELECT id, name, created_at, updated_at FROM groups WHERE id = ?`,
-		id,
-	).Scan(&g.ID, &g.Name, &g.CreatedAt, &g.UpdatedAt); err != nil {
+	g, err = scanGroup(s.db.QueryRowContext(ctx, `SELECT `+groupColumns+` FROM groups WHERE id = ?`, id))
+	if err != nil {
+		return Group{}, fmt.Errorf("reload group: %w", err)
+	}
+	return g, nil
+}
+
+// SetGroupFavorite 标记或取消标记常用分组，返回更新后的分组。
+func (s *Store) SetGroupFavorite(ctx context.Context, id int64, favorite bool) (Group, error) {
+	return s.setGroupFlag(ctx, id, "favorite", favorite)
+}
+
+// setGroupFlag 更新分组的布尔列（archived/favorite），返回更新后的分组。
+func (s *Store) setGroupFlag(ctx context.Context, id int64, column string, on bool) (Group, error) {
+	if id <= 0 {
+		return Group{}, ErrInvalidGroupID
+	}
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE groups SET `+column+` = ?, updated_at = ? WHERE id = ?`,
+		boolTo01Int(on), time.Now().UnixMilli(), id,
+	)
+	if err != nil {
+		return Group{}, fmt.Errorf("update group %s: %w", column, err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return Group{}, fmt.Errorf("update group %s rows affected: %w", column, err)
+	}
+	if affected == 0 {
+		return Group{}, ErrGroupNotFound.with(id)
+	}
+	g, err := scanGroup(s.db.QueryRowContext(ctx, `SELECT `+groupColumns+` FROM groups WHERE id = ?`, id))
+	if err != nil {
 		return Group{}, fmt.Errorf("reload group: %w", err)
 	}
 	return g, nil
@@ -356,12 +946,41 @@ func (s *Store) UpsertGroup(ctx context.Context, id int64, name string) (Group,
 // DeleteGroup 删除分组。
 //
 // tasks 表通过外键 `REFERENCES groups(id) ON DELETE CASCADE` 绑定，
-// 因此删除分组会自动级联删除该组下的任务。
+// 因此删除分组会自动级联删除该组下的任务；下级分组不会被删除，而是上移一级。
+// 删除前会把分组及其任务整体放入回收站，可通过 RestoreTrash 恢复。
 func (s *Store) DeleteGroup(ctx context.Context, id int64) error {
 	if id <= 0 {
-		return errors.New("无效的组ID")
+		return ErrInvalidGroupID
+	}
+
+	g, err := scanGroup(s.db.QueryRowContext(ctx, `SELECT `+groupColumns+` FROM groups WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrGroupNotFound.with(id)
+	}
+	if err != nil {
+		return fmt.Errorf("get group: %w", err)
+	}
+	tasks, err := s.queryTasks(ctx, `SELECT `+taskColumns+` FROM tasks WHERE group_id = ? AND parent_id = 0 ORDER BY id`, id)
+	if err != nil {
+		return err
+	}
+	if err := s.attachSubTasks(ctx, tasks); err != nil {
+		return err
+	}
+	if err := s.LoadFullContent(ctx, tasks); err != nil {
+		return err
 	}
-	res, err := s.db.ExecContext(ctx, `DELETE FROM groups WHERE id = ?`, id)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin delete group: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := moveToTrash(ctx, tx, TrashKindGroup, g.ID, g.Name, trashPayload{Group: &g, Tasks: tasks}, time.Now().UnixMilli()); err != nil {
+		return err
+	}
+	res, err := tx.ExecContext(ctx, `DELETE FROM groups WHERE id = ?`, id)
 	if err != nil {
 		return fmt.Errorf("delete group: %w", err)
 	}
@@ -370,17 +989,30 @@ func (s *Store) DeleteGroup(ctx context.Context, id int64) error {
 		return fmt.Errorf("delete group rows affected: %w", err)
 	}
 	if affected == 0 {
-		return fmt.Errorf("组不存在（id=%d）", id)
+		return ErrGroupNotFound.with(id)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE groups SET parent_id = ?, updated_at = ? WHERE parent_id = ?`, g.ParentID, time.Now().UnixMilli(), id,
+	); err != nil {
+		return fmt.Errorf("reparent child groups: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit delete group: %w", err)
 	}
 	return nil
 }
 
-// ListTasks 返回任务列表，按 updated_at 倒序（最近修改的在前）。
+// ListTasks 返回任务列表，按 sort 指定的方式排序（见 Sort* 常量；空字符串为 SortUpdated，即最近修改的在前）。
+// 子任务在父任务内沿用同一排序方式。
 //
 // important/urgent 在库中以 0/1 保存，这里转换为 bool 方便前端使用。
 // 返回的任务列表会自动将子任务挂载到父任务的 SubTasks 字段下。
-func (s *Store) ListTasks(ctx context.Context) ([]Task, error) {
-	rows, err := s.db.QueryContext(ctx, `SELECT id, group_id, parent_id, title, content, status, important, urgent, created_at, updated_at FROM tasks ORDER BY updated_at DESC, id DESC`)
+func (s *Store) ListTasks(ctx context.Context, sort string) ([]Task, error) {
+	sort, err := ParseSortMode(sort)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.db.QueryContext(ctx, `SELECT `+taskColumns+` FROM tasks ORDER BY `+sortOrderSQL[sort])
 	if err != nil {
 		return nil, fmt.Errorf("list tasks: %w", err)
 	}
@@ -390,20 +1022,10 @@ func (s *Store) ListTasks(ctx context.Context) ([]Task, error) {
 	taskMap := make(map[int64]*Task)
 
 	for rows.Next() {
-		var t Task
-		var status string
-		var importantInt int
-		var urgentInt int
-		if err := rows.Scan(&t.ID, &t.GroupID, &t.ParentID, &t.Title, &t.Content, &status, &importantInt, &urgentInt, &t.CreatedAt, &t.UpdatedAt); err != nil {
-			return nil, fmt.Errorf("scan task: %w", err)
-		}
-		parsed, err := ParseStatus(status)
+		t, err := scanTask(rows)
 		if err != nil {
-			return nil, fmt.Errorf("parse task status: %w", err)
+			return nil, err
 		}
-		t.Status = parsed
-		t.Important = importantInt == 1
-		t.Urgent = urgentInt == 1
 		t.SubTasks = []Task{}
 		allTasks = append(allTasks, t)
 	}
@@ -438,6 +1060,10 @@ func (s *Store) ListTasks(ctx context.Context) ([]Task, error) {
 			rootTasks[i].SubTasks = ptr.SubTasks
 		}
 	}
+	fillProgress(rootTasks)
+	if err := s.FillBlocked(ctx, rootTasks); err != nil {
+		return nil, err
+	}
 
 	return rootTasks, nil
 }
@@ -445,7 +1071,7 @@ func (s *Store) ListTasks(ctx context.Context) ([]Task, error) {
 // UpsertTask 新增或更新任务，并返回落库后的完整任务对象。
 //
 // 这里做了"前置校验"，目的：
-// - 给前端更明确的错误信息（中文、可控）
+// - 给前端更明确的错误信息（返回 *Error 错误码，由 App 层按语言翻译）
 // - 避免依赖数据库层错误（不同平台/驱动可能文案不同）
 //
 // 父子任务状态联动规则：
@@ -456,34 +1082,67 @@ func (s *Store) UpsertTask(ctx context.Context, req Task) (Task, error) {
 	req.Content = strings.TrimSpace(req.Content)
 
 	if req.GroupID <= 0 {
-		return Task{}, errors.New("请选择一个组")
+		return Task{}, ErrGroupRequired
 	}
 	ok, err := s.groupExists(ctx, req.GroupID)
 	if err != nil {
 		return Task{}, err
 	}
 	if !ok {
-		return Task{}, fmt.Errorf("组不存在（id=%d）", req.GroupID)
+		return Task{}, ErrGroupNotFound.with(req.GroupID)
 	}
 	if req.Title == "" {
-		return Task{}, errors.New("任务标题不能为空")
+		return Task{}, ErrTaskTitleEmpty
 	}
-	if utf8.RuneCountInString(req.Title) > maxTaskTitleRunes {
-		return Task{}, fmt.Errorf("任务标题过长（最多 %d 字）", maxTaskTitleRunes)
+	settings, err := s.GetSettings(ctx)
+	if err != nil {
+		return Task{}, err
 	}
-	if utf8.RuneCountInString(req.Content) > maxTaskContentRunes {
-		return Task{}, fmt.Errorf("任务内容过长（最多 %d 字）", maxTaskContentRunes)
+	// 略超上限的标题/内容按 lengthLimitMode 截断或放行，提示随返回的任务一起带回
+	var warnings []LengthWarning
+	for _, f := range []struct {
+		field   string
+		value   *string
+		limit   int
+		tooLong *Error
+	}{
+		{LengthFieldTitle, &req.Title, settings.TitleLimit, ErrTaskTitleTooLong},
+		{LengthFieldContent, &req.Content, settings.ContentLimit, ErrTaskContentTooLong},
+	} {
+		v, w, err := applyLengthLimit(f.field, *f.value, f.limit, settings.LengthLimitMode, f.tooLong)
+		if err != nil {
+			return Task{}, err
+		}
+		*f.value = v
+		if w != nil {
+			warnings = append(warnings, *w)
+		}
 	}
 	if _, err := ParseStatus(string(req.Status)); err != nil {
 		return Task{}, err
 	}
+	if req.Color, err = normalizeHexColor(req.Color); err != nil {
+		return Task{}, err
+	}
+	if req.Emoji, err = normalizeTaskEmoji(req.Emoji); err != nil {
+		return Task{}, err
+	}
+	if req.URL, err = normalizeTaskURL(req.URL); err != nil {
+		return Task{}, err
+	}
+	if req.ContentFormat, err = ParseContentFormat(req.ContentFormat); err != nil {
+		return Task{}, err
+	}
+	if req.DueAt < 0 {
+		req.DueAt = 0
+	}
 
 	// 如果有 ParentID，验证父任务存在
 	if req.ParentID > 0 {
 		var parentExists int
 		err := s.db.QueryRowContext(ctx, `SELECT 1 FROM tasks WHERE id = ? AND parent_id = 0`, req.ParentID).Scan(&parentExists)
 		if errors.Is(err, sql.ErrNoRows) {
-			return Task{}, errors.New("父任务不存在")
+			return Task{}, ErrParentTaskNotFound
 		}
 		if err != nil {
 			return Task{}, fmt.Errorf("check parent task: %w", err)
@@ -492,9 +1151,11 @@ func (s *Store) UpsertTask(ctx context.Context, req Task) (Task, error) {
 
 	now := time.Now().UnixMilli()
 	if req.ID == 0 {
+		preview, overflow := splitContent(req.Content)
 		res, err := s.db.ExecContext(ctx,
-			`INSERT INTO tasks(group_id, parent_id, title, content, status, important, urgent, created_at, updated_at) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?)`,
-			req.GroupID, req.ParentID, req.Title, req.Content, string(req.Status), boolTo01Int(req.Important), boolTo01Int(req.Urgent), now, now,
+			`INSERT INTO tasks(group_id, parent_id, title, content, content_overflow, content_format, status, important, urgent, color, emoji, url, countdown, due_at, sort_order, completed_at, created_at, updated_at)
+			 VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, (SELECT COALESCE(MAX(sort_order), 0) + 1 FROM tasks), ?, ?, ?)`,
+			req.GroupID, req.ParentID, req.Title, preview, boolTo01Int(overflow), req.ContentFormat, string(req.Status), boolTo01Int(req.Important), boolTo01Int(req.Urgent), req.Color, req.Emoji, req.URL, boolTo01Int(req.Countdown), req.DueAt, completedAtFor(req.Status, now), now, now,
 		)
 		if err != nil {
 			return Task{}, fmt.Errorf("create task: %w", err)
@@ -504,8 +1165,15 @@ func (s *Store) UpsertTask(ctx context.Context, req Task) (Task, error) {
 			return Task{}, fmt.Errorf("get new task id: %w", err)
 		}
 		req.ID = newID
+		if err := saveContentOverflow(ctx, s.db, newID, req.Content, overflow); err != nil {
+			return Task{}, err
+		}
+		req.Content = preview
+		req.ContentTruncated = overflow
+		req.CompletedAt = completedAtFor(req.Status, now)
 		req.CreatedAt = now
 		req.UpdatedAt = now
+		req.Warnings = warnings
 
 		// 子任务创建后检查是否需要更新父任务状态
 		if req.ParentID > 0 {
@@ -520,21 +1188,33 @@ func (s *Store) UpsertTask(ctx context.Context, req Task) (Task, error) {
 	// 获取旧的任务状态用于判断状态变化
 	var oldStatus string
 	var oldParentID int64
+	var oldContent string
+	var oldOverflow int
 	if err := s.db.QueryRowContext(ctx,
-		`SELECT status, parent_id FROM tasks WHERE id = ?`,
+		`SELECT status, parent_id, content, content_overflow FROM tasks WHERE id = ?`,
 		req.ID,
-	).Scan(&oldStatus, &oldParentID); err != nil {
+	).Scan(&oldStatus, &oldParentID, &oldContent, &oldOverflow); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return Task{}, fmt.Errorf("任务不存在（id=%d）", req.ID)
+			return Task{}, ErrTaskNotFound.with(req.ID)
 		}
 		return Task{}, fmt.Errorf("get old task: %w", err)
 	}
 
+	// 前端提交的仍是未改动的预览（看板预览或溢出预览，未加载完整内容）时，保留已存的完整内容；
+	// 只有明确标记了 ContentChanged 才允许以与预览相同的内容覆盖（如有意删去预览之后的部分）
+	preview, overflow := splitContent(req.Content)
+	keepContent := !req.ContentChanged && isContentPreview(req.Content, oldContent)
+	if keepContent {
+		preview, overflow = oldContent, oldOverflow == 1
+	}
+
 	res, err := s.db.ExecContext(ctx,
 		`UPDATE tasks
-		 SET group_id = ?, parent_id = ?, title = ?, content = ?, status = ?, important = ?, urgent = ?, updated_at = ?
+		 SET group_id = ?, parent_id = ?, title = ?, content = ?, content_overflow = ?, content_format = ?, status = ?, important = ?, urgent = ?, color = ?, emoji = ?, url = ?, countdown = ?, due_at = ?, updated_at = ?,
+		     completed_at = CASE WHEN ? = 'done' THEN (CASE WHEN completed_at > 0 THEN completed_at ELSE ? END) ELSE 0 END
 		 WHERE id = ?`,
-		req.GroupID, req.ParentID, req.Title, req.Content, string(req.Status), boolTo01Int(req.Important), boolTo01Int(req.Urgent), now, req.ID,
+		req.GroupID, req.ParentID, req.Title, preview, boolTo01Int(overflow), req.ContentFormat, string(req.Status), boolTo01Int(req.Important), boolTo01Int(req.Urgent), req.Color, req.Emoji, req.URL, boolTo01Int(req.Countdown), req.DueAt, now,
+		string(req.Status), now, req.ID,
 	)
 	if err != nil {
 		return Task{}, fmt.Errorf("update task: %w", err)
@@ -544,21 +1224,33 @@ func (s *Store) UpsertTask(ctx context.Context, req Task) (Task, error) {
 		return Task{}, fmt.Errorf("update task rows affected: %w", err)
 	}
 	if affected == 0 {
-		return Task{}, fmt.Errorf("任务不存在（id=%d）", req.ID)
+		return Task{}, ErrTaskNotFound.with(req.ID)
+	}
+	if !keepContent {
+		if err := saveContentOverflow(ctx, s.db, req.ID, req.Content, overflow); err != nil {
+			return Task{}, err
+		}
 	}
 
 	// 状态联动处理
+	var nextID int64
 	statusChanged := oldStatus != string(req.Status)
 	if statusChanged {
 		// 如果这是父任务且状态变为完成，则所有子任务也完成
 		if oldParentID == 0 && req.Status == StatusDone {
 			if _, err := s.db.ExecContext(ctx,
-				`UPDATE tasks SET status = ?, updated_at = ? WHERE parent_id = ?`,
-				string(StatusDone), now, req.ID,
+				`UPDATE tasks SET status = ?, updated_at = ?, `+completedAtSQL+` WHERE parent_id = ?`,
+				string(StatusDone), now, now, req.ID,
 			); err != nil {
 				return Task{}, fmt.Errorf("complete subtasks: %w", err)
 			}
 		}
+		// 重复任务完成后生成下一次的任务
+		if req.ParentID == 0 && req.Status == StatusDone {
+			if nextID, err = s.materializeRecurrence(ctx, req.ID, now); err != nil {
+				return Task{}, err
+			}
+		}
 		// 如果这是子任务，检查是否需要更新父任务状态
 		if req.ParentID > 0 {
 			if err := s.syncParentStatus(ctx, req.ParentID, now); err != nil {
@@ -567,33 +1259,39 @@ func (s *Store) UpsertTask(ctx context.Context, req Task) (Task, error) {
 		}
 	}
 
-	var t Task
-	var status string
-	var importantInt int
-	var urgentInt int
-	if err := s.db.QueryRowContext(ctx,
-		`SELECT id, group_id, parent_id, title, content, status, important, urgent, created_at, updated_at FROM tasks WHERE id = ?`,
-		req.ID,
-	).Scan(&t.ID, &t.GroupID, &t.ParentID, &t.Title, &t.Content, &status, &importantInt, &urgentInt, &t.CreatedAt, &t.UpdatedAt); err != nil {
+	t, err := scanTask(s.db.QueryRowContext(ctx, `SELECT `+taskColumns+` FROM tasks WHERE id = ?`, req.ID))
+	if err != nil {
 		return Task{}, fmt.Errorf("reload task: %w", err)
 	}
-	parsed, err := ParseStatus(status)
+	t.Warnings = warnings
+	t.NextOccurrenceID = nextID
+	return t, nil
+}
+
+// syncParentStatus 检查并同步父任务状态（单独开启事务，见 syncParentStatusTx）。
+func (s *Store) syncParentStatus(ctx context.Context, parentID int64, now int64) error {
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		return Task{}, fmt.Errorf("parse task status: %w", err)
+		return fmt.Errorf("begin sync parent status: %w", err)
 	}
-	t.Status = parsed
-	t.Important = importantInt == 1
-	t.Urgent = urgentInt == 1
-	return t, nil
+	defer tx.Rollback()
+
+	if err := syncParentStatusTx(ctx, tx, parentID, now); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit sync parent status: %w", err)
+	}
+	return nil
 }
 
-// syncParentStatus 检查并同步父任务状态。
-// 如果所有子任务都完成，则父任务也自动完成。
+// syncParentStatusTx 在事务 tx 中检查并同步父任务状态。
+// 如果所有子任务都完成，则父任务也自动完成（重复任务随之生成下一次）。
 // 如果有子任务未完成，且父任务是完成状态，则保持父任务状态不变。
-func (s *Store) syncParentStatus(ctx context.Context, parentID int64, now int64) error {
+func syncParentStatusTx(ctx context.Context, tx *sql.Tx, parentID int64, now int64) error {
 	// 获取父任务当前状态
 	var parentStatus string
-	if err := s.db.QueryRowContext(ctx,
+	if err := tx.QueryRowContext(ctx,
 		`SELECT status FROM tasks WHERE id = ?`,
 		parentID,
 	).Scan(&parentStatus); err != nil {
@@ -605,8 +1303,8 @@ func (s *Store) syncParentStatus(ctx context.Context, parentID int64, now int64)
 
 	// 统计子任务完成情况
 	var totalSubtasks, doneSubtasks int
-	if err := s.db.QueryRowContext(ctx,
-		`SELECT COUNT(*), SUM(CASE WHEN status = 'done' THEN 1 ELSE 0 END) FROM tasks WHERE parent_id = ?`,
+	if err := tx.QueryRowContext(ctx,
+		`SELECT COUNT(*), COALESCE(SUM(CASE WHEN status = 'done' THEN 1 ELSE 0 END), 0) FROM tasks WHERE parent_id = ?`,
 		parentID,
 	).Scan(&totalSubtasks, &doneSubtasks); err != nil {
 		return fmt.Errorf("count subtasks: %w", err)
@@ -619,45 +1317,78 @@ func (s *Store) syncParentStatus(ctx context.Context, parentID int64, now int64)
 
 	// 如果所有子任务都完成，父任务也完成
 	if totalSubtasks > 0 && totalSubtasks == doneSubtasks && parentStatus != string(StatusDone) {
-		if _, err := s.db.ExecContext(ctx,
-			`UPDATE tasks SET status = ?, updated_at = ? WHERE id = ?`,
-			string(StatusDone), now, parentID,
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE tasks SET status = ?, updated_at = ?, `+completedAtSQL+` WHERE id = ?`,
+			string(StatusDone), now, now, parentID,
 		); err != nil {
 			return fmt.Errorf("complete parent task: %w", err)
 		}
+		if _, err := materializeRecurrenceTx(ctx, tx, parentID, now); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// GetTask 返回单个任务（不含子任务）。
+func (s *Store) GetTask(ctx context.Context, id int64) (Task, error) {
+	t, err := scanTask(s.db.QueryRowContext(ctx, `SELECT `+taskColumns+` FROM tasks WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return Task{}, ErrTaskNotFound.with(id)
+	}
+	if err != nil {
+		return Task{}, fmt.Errorf("get task: %w", err)
+	}
+	return t, nil
+}
+
 // DeleteTask 删除任务。
 // 如果删除的是父任务，会级联删除所有子任务。
 // 如果删除的是子任务，会检查并更新父任务状态。
+// 删除前会把任务（连同子任务）放入回收站，可通过 RestoreTrash 恢复。
 func (s *Store) DeleteTask(ctx context.Context, id int64) error {
 	if id <= 0 {
-		return errors.New("无效的任务ID")
+		return ErrInvalidTaskID
 	}
 
 	// 获取任务信息，判断是父任务还是子任务
-	var parentID int64
-	if err := s.db.QueryRowContext(ctx,
-		`SELECT parent_id FROM tasks WHERE id = ?`,
-		id,
-	).Scan(&parentID); err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return fmt.Errorf("任务不存在（id=%d）", id)
+	t, err := scanTask(s.db.QueryRowContext(ctx, `SELECT `+taskColumns+` FROM tasks WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrTaskNotFound.with(id)
+	}
+	if err != nil {
+		return fmt.Errorf("get task: %w", err)
+	}
+	parentID := t.ParentID
+	snapshot := []Task{t}
+	if parentID == 0 {
+		if err := s.attachSubTasks(ctx, snapshot); err != nil {
+			return err
 		}
-		return fmt.Errorf("get task parent: %w", err)
+	}
+	if err := s.LoadFullContent(ctx, snapshot); err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin delete task: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := moveToTrash(ctx, tx, TrashKindTask, t.ID, t.Title, trashPayload{Tasks: snapshot}, time.Now().UnixMilli()); err != nil {
+		return err
 	}
 
 	// 如果是父任务，先删除所有子任务
 	if parentID == 0 {
-		if _, err := s.db.ExecContext(ctx, `DELETE FROM tasks WHERE parent_id = ?`, id); err != nil {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM tasks WHERE parent_id = ?`, id); err != nil {
 			return fmt.Errorf("delete subtasks: %w", err)
 		}
 	}
 
-	res, err := s.db.ExecContext(ctx, `DELETE FROM tasks WHERE id = ?`, id)
+	res, err := tx.ExecContext(ctx, `DELETE FROM tasks WHERE id = ?`, id)
 	if err != nil {
 		return fmt.Errorf("delete task: %w", err)
 	}
@@ -666,7 +1397,10 @@ func (s *Store) DeleteTask(ctx context.Context, id int64) error {
 		return fmt.Errorf("delete task rows affected: %w", err)
 	}
 	if affected == 0 {
-		return fmt.Errorf("任务不存在（id=%d）", id)
+		return ErrTaskNotFound.with(id)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit delete task: %w", err)
 	}
 
 	// 如果是子任务，检查是否需要更新父任务状态
@@ -680,94 +1414,22 @@ func (s *Store) DeleteTask(ctx context.Context, id int64) error {
 	return nil
 }
 
-// GetSettings 读取所有设置键值并返回 Settings 结构。
-//
-// 设计为"有默认值 + 部分覆盖"：
-// - 任何缺失的 key 会回落到默认值
-// - 多余的 key 被忽略，方便未来扩展
-func (s *Store) GetSettings(ctx context.Context) (Settings, error) {
-	settings := Settings{
-		AlwaysOnTop: true,
-		HideDone:    false,
-		ViewMode:    "cards",
-		ConciseMode: false,
-		Theme:       "light",
-	}
-
-	rows, err := s.db.QueryContext(ctx, `SELECT key, value FROM settings`)
-	if err != nil {
-		return Settings{}, fmt.Errorf("list settings: %w", err)
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var key, value string
-		if err := rows.Scan(&key, &value); err != nil {
-			return Settings{}, fmt.Errorf("scan settings: %w", err)
-		}
-		switch key {
-		case "alwaysOnTop":
-			settings.AlwaysOnTop = value == "1" || strings.EqualFold(value, "true")
-		case "hideDone":
-			settings.HideDone = value == "1" || strings.EqualFold(value, "true")
-		case "viewMode":
-			settings.ViewMode = normalizeViewMode(value)
-		case "conciseMode":
-			settings.ConciseMode = value == "1" || strings.EqualFold(value, "true")
-		case "theme":
-			settings.Theme = normalizeTheme(value)
-		}
-	}
-	if err := rows.Err(); err != nil {
-		return Settings{}, fmt.Errorf("iterate settings: %w", err)
-	}
-
-	return settings, nil
-}
-
-// SetSettings 将 Settings 写回 settings 表（每个 key 单独 upsert）。
-func (s *Store) SetSettings(ctx context.Context, settings Settings) error {
-	if err := s.setSetting(ctx, "alwaysOnTop", boolTo01(settings.AlwaysOnTop)); err != nil {
-		return err
-	}
-	if err := s.setSetting(ctx, "hideDone", boolTo01(settings.HideDone)); err != nil {
-		return err
-	}
-	if err := s.setSetting(ctx, "viewMode", normalizeViewMode(settings.ViewMode)); err != nil {
-		return err
-	}
-	if err := s.setSetting(ctx, "conciseMode", boolTo01(settings.ConciseMode)); err != nil {
-		return err
-	}
-	if err := s.setSetting(ctx, "theme", normalizeTheme(settings.Theme)); err != nil {
-		return err
-	}
-	return nil
-}
-
-// setSetting 对单个 key 做 upsert（INSERT ... ON CONFLICT DO UPDATE）。
-func (s *Store) setSetting(ctx context.Context, key string, value string) error {
-	if _, err := s.db.ExecContext(ctx,
-		`INSERT INTO settings(key, value) VALUES(?, ?)
-		 ON CONFLICT(key) DO UPDATE SET value = excluded.value`,
-		key, value,
-	); err != nil {
-		return fmt.Errorf("set setting %q: %w", key, err)
-	}
-	return nil
-}
-
 // GetLastWaterReminderAt 返回上一次“喝水提醒”时间（UnixMilli）。
 //
 // 若从未记录过，则返回 0。
 func (s *Store) GetLastWaterReminderAt(ctx context.Context) (int64, error) {
+	return s.getTimestampSetting(ctx, "lastWaterReminderAt")
+}
+
+// getTimestampSetting 读取以 UnixMilli 文本保存的内部状态（不在 settingsSchema 中）；缺失或非正数时返回 0。
+func (s *Store) getTimestampSetting(ctx context.Context, key string) (int64, error) {
 	var value string
-	err := s.db.QueryRowContext(ctx, `SELECT value FROM settings WHERE key = ?`, "lastWaterReminderAt").Scan(&value)
+	err := s.db.QueryRowContext(ctx, `SELECT value FROM settings WHERE key = ?`, key).Scan(&value)
 	if errors.Is(err, sql.ErrNoRows) {
 		return 0, nil
 	}
 	if err != nil {
-		return 0, fmt.Errorf("get lastWaterReminderAt: %w", err)
+		return 0, fmt.Errorf("get %s: %w", key, err)
 	}
 
 	value = strings.TrimSpace(value)
@@ -777,7 +1439,7 @@ func (s *Store) GetLastWaterReminderAt(ctx context.Context) (int64, error) {
 
 	ts, err := strconv.ParseInt(value, 10, 64)
 	if err != nil {
-		return 0, fmt.Errorf("parse lastWaterReminderAt: %w", err)
+		return 0, fmt.Errorf("parse %s: %w", key, err)
 	}
 	if ts <= 0 {
 		return 0, nil
@@ -793,6 +1455,28 @@ func (s *Store) SetLastWaterReminderAt(ctx context.Context, unixMilli int64) err
 	return s.setSetting(ctx, "lastWaterReminderAt", strconv.FormatInt(unixMilli, 10))
 }
 
+// GetWaterSnoozeUntil 返回"喝水提醒"被推迟到的时间（UnixMilli）；未推迟时返回 0。
+func (s *Store) GetWaterSnoozeUntil(ctx context.Context) (int64, error) {
+	return s.getTimestampSetting(ctx, "waterSnoozeUntil")
+}
+
+// SnoozeWaterReminder 将"喝水提醒"推迟 minutes 分钟（见 snoozeUntil），返回下一次提醒时间（UnixMilli）。
+func (s *Store) SnoozeWaterReminder(ctx context.Context, now time.Time, minutes int) (int64, error) {
+	until, err := snoozeUntil(now, minutes)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.setSetting(ctx, "waterSnoozeUntil", strconv.FormatInt(until, 10)); err != nil {
+		return 0, err
+	}
+	return until, nil
+}
+
+// ClearWaterSnooze 取消"喝水提醒"的推迟（提醒送达后调用）。
+func (s *Store) ClearWaterSnooze(ctx context.Context) error {
+	return s.setSetting(ctx, "waterSnoozeUntil", "0")
+}
+
 // boolTo01 将 bool 编码为 "0"/"1"（便于与 SQLite 的 TEXT 设置表统一）。
 func boolTo01(b bool) string {
 	if b {
@@ -823,38 +1507,75 @@ func sqliteIsConstraint(err error, code int) bool {
 	return false
 }
 
-// boolTo01Int 将 bool 编码为 0/1（用于 tasks 表的整数列）。
-func boolTo01Int(b bool) int {
-	if b {
-		return 1
+// groupColumns 为读取 Group 时 SELECT 的列（顺序与 scanGroup 一致）。
+const groupColumns = `id, name, parent_id, archived, favorite, color, icon, sort_order, created_at, updated_at`
+
+// groupOrderSQL 为分组列表的 ORDER BY 子句：常用分组在前，其余按手动排序。
+const groupOrderSQL = `favorite DESC, sort_order, id`
+
+// nextGroupOrderSQL 为新分组的 sort_order（排在最后）。
+const nextGroupOrderSQL = `(SELECT COALESCE(MAX(sort_order), 0) + 1 FROM groups)`
+
+// scanGroup 按 groupColumns 的列顺序读取一行分组。
+func scanGroup(r rowScanner) (Group, error) {
+	var g Group
+	var archivedInt, favoriteInt int
+	if err := r.Scan(&g.ID, &g.Name, &g.ParentID, &archivedInt, &favoriteInt, &g.Color, &g.Icon, &g.SortOrder, &g.CreatedAt, &g.UpdatedAt); err != nil {
+		return Group{}, fmt.Errorf("scan group: %w", err)
+	}
+	g.Archived = archivedInt == 1
+	g.Favorite = favoriteInt == 1
+	return g, nil
+}
+
+// taskColumns 为读取 Task 时 SELECT 的列（顺序与 scanTask 一致）。
+//
+// 新增任务列时只需同时修改这里与 scanTask，各查询即可共用。
+const taskColumns = `id, group_id, parent_id, title, content, content_overflow, content_format, status, important, urgent, color, emoji, url, cover_id, countdown, due_at, completed_at, created_at, updated_at`
+
+// completedAtSQL 用于把任务标记为完成的 UPDATE：保留已有完成时间，否则记为参数中的当前时间。
+const completedAtSQL = `completed_at = CASE WHEN completed_at > 0 THEN completed_at ELSE ? END`
+
+// completedAtFor 返回新建任务的完成时间：已完成则为 now，否则为 0。
+func completedAtFor(status Status, now int64) int64 {
+	if status == StatusDone {
+		return now
 	}
 	return 0
 }
 
-// normalizeViewMode 将 viewMode 规范化为受支持的值（"list" 或 "cards"），其它输入回退到 "cards"。
-func normalizeViewMode(v string) string {
-	v = strings.TrimSpace(strings.ToLower(v))
-	if utf8.RuneCountInString(v) > maxViewModeRunes {
-		return "cards"
+// rowScanner 抽象 *sql.Row 与 *sql.Rows 的 Scan。
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanTask 按 taskColumns 的列顺序读取一行任务。
+func scanTask(r rowScanner) (Task, error) {
+	var t Task
+	var status string
+	var importantInt int
+	var urgentInt int
+	var overflowInt int
+	var countdownInt int
+	if err := r.Scan(&t.ID, &t.GroupID, &t.ParentID, &t.Title, &t.Content, &overflowInt, &t.ContentFormat, &status, &importantInt, &urgentInt, &t.Color, &t.Emoji, &t.URL, &t.CoverID, &countdownInt, &t.DueAt, &t.CompletedAt, &t.CreatedAt, &t.UpdatedAt); err != nil {
+		return Task{}, fmt.Errorf("scan task: %w", err)
 	}
-	switch v {
-	case "list", "cards":
-		return v
-	default:
-		return "cards"
+	parsed, err := ParseStatus(status)
+	if err != nil {
+		return Task{}, fmt.Errorf("parse task status: %w", err)
 	}
+	t.Status = parsed
+	t.Important = importantInt == 1
+	t.Urgent = urgentInt == 1
+	t.ContentTruncated = overflowInt == 1
+	t.Countdown = countdownInt == 1
+	return t, nil
 }
 
-// normalizeTheme 将主题规范化为受支持的值（"light" 或 "dark"），其它输入回退到 "light"。
-func normalizeTheme(v string) string {
-	v = strings.TrimSpace(strings.ToLower(v))
-	if utf8.RuneCountInString(v) > maxThemeRunes {
-		return "light"
-	}
-	switch v {
-	case "light", "dark":
-		return v
-	default:
-		return "light"
+// boolTo01Int 将 bool 编码为 0/1（用于 tasks 表的整数列）。
+func boolTo01Int(b bool) int {
+	if b {
+		return 1
 	}
+	return 0
 }
@@ -0,0 +1,96 @@
+package todo
+
+import (
+	"context"
+	"errors"
+)
+
+// 子任务即 parent_id 指向主任务的任务（只有一层），与主任务同组，随主任务一起删除、进入回收站。
+// 这里提供清单式的快捷操作：只改标题或勾选状态，其余字段保持不变；
+// 父子状态联动（全部勾选后主任务自动完成等）与 UpsertTask 相同。
+
+// UpsertSubTask 在主任务 parentID 下新增子任务（id 为 0）或修改子任务 id 的标题，返回更新后的主任务
+// （含全部子任务与清单进度，便于卡片直接刷新"3/5"）。
+func (s *Store) UpsertSubTask(ctx context.Context, parentID, id int64, title string) (Task, error) {
+	if id == 0 {
+		parent, err := s.GetTask(ctx, parentID)
+		if errors.Is(err, ErrTaskNotFound) || (err == nil && parent.ParentID > 0) {
+			return Task{}, ErrParentTaskNotFound
+		}
+		if err != nil {
+			return Task{}, err
+		}
+		if _, err := s.UpsertTask(ctx, Task{GroupID: parent.GroupID, ParentID: parentID, Title: title, Status: StatusTodo}); err != nil {
+			return Task{}, err
+		}
+		return s.taskWithSubTasks(ctx, parentID)
+	}
+
+	sub, err := s.getSubTask(ctx, id)
+	if err != nil {
+		return Task{}, err
+	}
+	sub.Title = title
+	if _, err := s.UpsertTask(ctx, sub); err != nil {
+		return Task{}, err
+	}
+	return s.taskWithSubTasks(ctx, sub.ParentID)
+}
+
+// ToggleSubTask 切换子任务的勾选状态（已完成 <-> 待办），返回切换后的子任务与更新后的主任务。
+func (s *Store) ToggleSubTask(ctx context.Context, id int64) (Task, Task, error) {
+	sub, err := s.getSubTask(ctx, id)
+	if err != nil {
+		return Task{}, Task{}, err
+	}
+	if sub.Status == StatusDone {
+		sub.Status = StatusTodo
+	} else {
+		sub.Status = StatusDone
+	}
+	if sub, err = s.UpsertTask(ctx, sub); err != nil {
+		return Task{}, Task{}, err
+	}
+	parent, err := s.taskWithSubTasks(ctx, sub.ParentID)
+	if err != nil {
+		return Task{}, Task{}, err
+	}
+	return sub, parent, nil
+}
+
+// DeleteSubTask 删除子任务（进入回收站），返回更新后的主任务。
+func (s *Store) DeleteSubTask(ctx context.Context, id int64) (Task, error) {
+	sub, err := s.getSubTask(ctx, id)
+	if err != nil {
+		return Task{}, err
+	}
+	if err := s.DeleteTask(ctx, id); err != nil {
+		return Task{}, err
+	}
+	return s.taskWithSubTasks(ctx, sub.ParentID)
+}
+
+// getSubTask 返回子任务 id；任务不存在返回 ErrTaskNotFound，是主任务返回 ErrNotSubTask。
+func (s *Store) getSubTask(ctx context.Context, id int64) (Task, error) {
+	t, err := s.GetTask(ctx, id)
+	if err != nil {
+		return Task{}, err
+	}
+	if t.ParentID == 0 {
+		return Task{}, ErrNotSubTask.with(id)
+	}
+	return t, nil
+}
+
+// taskWithSubTasks 返回主任务 id，并挂载子任务、计算清单进度。
+func (s *Store) taskWithSubTasks(ctx context.Context, id int64) (Task, error) {
+	t, err := s.GetTask(ctx, id)
+	if err != nil {
+		return Task{}, err
+	}
+	tasks := []Task{t}
+	if err := s.attachSubTasks(ctx, tasks); err != nil {
+		return Task{}, err
+	}
+	return tasks[0], nil
+}
@@ -0,0 +1,204 @@
+package todo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// 泳道的划分维度。
+const (
+	SwimlaneByGroup = "group" // 每个分组一条泳道
+	SwimlaneByTag   = "tag"   // 每个标签一条泳道；带多个标签的任务出现在多条泳道中，无标签的任务归入 ID 为 0 的泳道
+)
+
+// swimlaneStatuses 为泳道内各列的状态顺序。
+var swimlaneStatuses = []Status{StatusTodo, StatusDoing, StatusDone}
+
+// SwimlaneCell 为泳道中某一状态列的任务。
+type SwimlaneCell struct {
+	Status Status `json:"status"`
+	Count  int    `json:"count"` // 该格主任务总数（hideDone 时已完成列仍计数）
+	Tasks  []Task `json:"tasks"`
+}
+
+// Swimlane 为一条泳道（一个分组或一个标签）。
+type Swimlane struct {
+	ID    int64          `json:"id"`   // 分组/标签 ID；按标签划分时 0 表示无标签
+	Name  string         `json:"name"` // 分组名/标签名；无标签泳道为空
+	Count int            `json:"count"`
+	Cells []SwimlaneCell `json:"cells"` // 按 todo、doing、done 排列
+}
+
+// Swimlanes 为状态 × 分组/标签的二维划分结果。
+type Swimlanes struct {
+	By       string     `json:"by"`
+	Statuses []Status   `json:"statuses"`
+	Lanes    []Swimlane `json:"lanes"`
+}
+
+// newSwimlane 返回各状态列均为空的泳道。
+func newSwimlane(id int64, name string) Swimlane {
+	lane := Swimlane{ID: id, Name: name, Cells: make([]SwimlaneCell, len(swimlaneStatuses))}
+	for i, st := range swimlaneStatuses {
+		lane.Cells[i] = SwimlaneCell{Status: st, Tasks: []Task{}}
+	}
+	return lane
+}
+
+// cell 返回泳道中 status 对应的列。
+func (l *Swimlane) cell(status Status) *SwimlaneCell {
+	for i := range l.Cells {
+		if l.Cells[i].Status == status {
+			return &l.Cells[i]
+		}
+	}
+	return &l.Cells[0]
+}
+
+// ListSwimlanes 返回按 by（SwimlaneByGroup | SwimlaneByTag）与状态二维划分好的主任务（子任务挂在 SubTasks 下），
+// 前端无需在全部任务上再做两层分组：
+// - 计数与各格内的排序在 SQL 中完成；格内按 sort 排序（空字符串为 SortUpdated）
+// - hideDone 为 true 时不返回已完成任务，但 Count 仍统计全部任务
+// - 已归档分组及其任务不参与划分；按分组划分时没有任务的分组也会返回空泳道
+func (s *Store) ListSwimlanes(ctx context.Context, by string, hideDone bool, sort string) (Swimlanes, error) {
+	by = strings.ToLower(strings.TrimSpace(by))
+	if by != SwimlaneByGroup && by != SwimlaneByTag {
+		return Swimlanes{}, ErrInvalidSwimlaneBy.with(by)
+	}
+	sort, err := ParseSortMode(sort)
+	if err != nil {
+		return Swimlanes{}, err
+	}
+
+	// 泳道：分组按分组列表顺序，标签按名称，无标签泳道放最后
+	lanes := []Swimlane{}
+	index := map[int64]int{}
+	if by == SwimlaneByGroup {
+		groups, err := s.ListGroups(ctx)
+		if err != nil {
+			return Swimlanes{}, err
+		}
+		for _, g := range groups {
+			if !g.Archived {
+				index[g.ID] = len(lanes)
+				lanes = append(lanes, newSwimlane(g.ID, g.Name))
+			}
+		}
+	} else {
+		tags, err := s.ListTags(ctx)
+		if err != nil {
+			return Swimlanes{}, err
+		}
+		for _, t := range tags {
+			index[t.ID] = len(lanes)
+			lanes = append(lanes, newSwimlane(t.ID, t.Name))
+		}
+		index[0] = len(lanes)
+		lanes = append(lanes, newSwimlane(0, ""))
+	}
+
+	countSQL := `SELECT group_id, status, COUNT(*) FROM tasks WHERE parent_id = 0 AND ` + activeGroupSQL + ` GROUP BY group_id, status`
+	if by == SwimlaneByTag {
+		countSQL = `SELECT COALESCE(tt.tag_id, 0), t.status, COUNT(*)
+			FROM tasks t LEFT JOIN task_tags tt ON tt.task_id = t.id
+			WHERE t.parent_id = 0 AND t.` + activeGroupSQL + ` GROUP BY 1, 2`
+	}
+	counts, err := s.db.QueryContext(ctx, countSQL)
+	if err != nil {
+		return Swimlanes{}, fmt.Errorf("count swimlanes: %w", err)
+	}
+	defer counts.Close()
+	for counts.Next() {
+		var laneID int64
+		var status string
+		var n int
+		if err := counts.Scan(&laneID, &status, &n); err != nil {
+			return Swimlanes{}, fmt.Errorf("scan swimlane count: %w", err)
+		}
+		if i, ok := index[laneID]; ok {
+			lanes[i].Count += n
+			lanes[i].cell(Status(status)).Count += n
+		}
+	}
+	if err := counts.Err(); err != nil {
+		return Swimlanes{}, fmt.Errorf("iterate swimlane counts: %w", err)
+	}
+
+	where := `parent_id = 0 AND ` + activeGroupSQL
+	if hideDone {
+		where += ` AND status <> 'done'`
+	}
+	tasks, err := s.queryTasks(ctx, `SELECT `+taskColumns+` FROM tasks WHERE `+where+` ORDER BY `+sortOrderSQL[sort])
+	if err != nil {
+		return Swimlanes{}, err
+	}
+	if err := s.attachSubTasks(ctx, tasks); err != nil {
+		return Swimlanes{}, err
+	}
+
+	laneIDs, err := s.swimlaneKeys(ctx, by, tasks)
+	if err != nil {
+		return Swimlanes{}, err
+	}
+	for _, t := range tasks {
+		for _, id := range laneIDs[t.ID] {
+			if i, ok := index[id]; ok {
+				c := lanes[i].cell(t.Status)
+				c.Tasks = append(c.Tasks, t)
+			}
+		}
+	}
+
+	// 无标签泳道没有任务时不返回
+	if by == SwimlaneByTag && lanes[len(lanes)-1].Count == 0 {
+		lanes = lanes[:len(lanes)-1]
+	}
+	return Swimlanes{By: by, Statuses: swimlaneStatuses, Lanes: lanes}, nil
+}
+
+// swimlaneKeys 返回各任务所属泳道的 ID：按分组划分时为任务的分组，按标签划分时为任务的全部标签（无标签为 0）。
+func (s *Store) swimlaneKeys(ctx context.Context, by string, tasks []Task) (map[int64][]int64, error) {
+	keys := make(map[int64][]int64, len(tasks))
+	if by == SwimlaneByGroup {
+		for _, t := range tasks {
+			keys[t.ID] = []int64{t.GroupID}
+		}
+		return keys, nil
+	}
+	if len(tasks) == 0 {
+		return keys, nil
+	}
+
+	ids := make([]int64, len(tasks))
+	for i, t := range tasks {
+		ids[i] = t.ID
+	}
+	raw, err := json.Marshal(ids)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT task_id, tag_id FROM task_tags WHERE task_id IN (SELECT value FROM json_each(?))`, string(raw))
+	if err != nil {
+		return nil, fmt.Errorf("query swimlane tags: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var taskID, tagID int64
+		if err := rows.Scan(&taskID, &tagID); err != nil {
+			return nil, fmt.Errorf("scan swimlane tag: %w", err)
+		}
+		keys[taskID] = append(keys[taskID], tagID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate swimlane tags: %w", err)
+	}
+	for _, id := range ids {
+		if len(keys[id]) == 0 {
+			keys[id] = []int64{0}
+		}
+	}
+	return keys, nil
+}
@@ -0,0 +1,450 @@
+package todo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	sqlitelib "modernc.org/sqlite/lib"
+)
+
+const (
+	// maxTagNameRunes 限制标签名长度。
+	maxTagNameRunes = 30
+	// maxTaskTags 限制单个任务上的标签数。
+	maxTaskTags = 20
+)
+
+// Tag 为任务标签。标签名不区分大小写唯一。
+type Tag struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	TaskCount int    `json:"taskCount"` // 使用该标签的任务数
+	CreatedAt int64  `json:"createdAt"`
+	UpdatedAt int64  `json:"updatedAt"`
+}
+
+// normalizeTagName 去除首尾空白并校验长度。
+func normalizeTagName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", ErrTagNameEmpty
+	}
+	if utf8.RuneCountInString(name) > maxTagNameRunes {
+		return "", ErrTagNameTooLong.with(maxTagNameRunes)
+	}
+	return name, nil
+}
+
+// ListTags 返回全部标签（按名称排序）及各自的任务数。
+func (s *Store) ListTags(ctx context.Context) ([]Tag, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT t.id, t.name, COUNT(tt.task_id), t.created_at, t.updated_at
+		 FROM tags t LEFT JOIN task_tags tt ON tt.tag_id = t.id
+		 GROUP BY t.id ORDER BY t.name COLLATE NOCASE, t.id`)
+	if err != nil {
+		return nil, fmt.Errorf("list tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags := []Tag{}
+	for rows.Next() {
+		var t Tag
+		if err := rows.Scan(&t.ID, &t.Name, &t.TaskCount, &t.CreatedAt, &t.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan tag: %w", err)
+		}
+		tags = append(tags, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate tags: %w", err)
+	}
+	return tags, nil
+}
+
+// ListTaskTags 返回任务上的全部标签（按名称排序）。
+func (s *Store) ListTaskTags(ctx context.Context, taskID int64) ([]Tag, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT t.id, t.name, (SELECT COUNT(*) FROM task_tags WHERE tag_id = t.id), t.created_at, t.updated_at
+		 FROM task_tags tt JOIN tags t ON t.id = tt.tag_id
+		 WHERE tt.task_id = ? ORDER BY t.name COLLATE NOCASE, t.id`, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("list task tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags := []Tag{}
+	for rows.Next() {
+		var t Tag
+		if err := rows.Scan(&t.ID, &t.Name, &t.TaskCount, &t.CreatedAt, &t.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan tag: %w", err)
+		}
+		tags = append(tags, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate task tags: %w", err)
+	}
+	return tags, nil
+}
+
+// GetTag 返回单个标签及其任务数。
+func (s *Store) GetTag(ctx context.Context, id int64) (Tag, error) {
+	return getTag(ctx, s.db, id)
+}
+
+// tagQuerier 为 *sql.DB 与 *sql.Tx 的公共查询接口。
+type tagQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// getTag 在 q（数据库或事务）上读取单个标签。
+func getTag(ctx context.Context, q tagQuerier, id int64) (Tag, error) {
+	var t Tag
+	err := q.QueryRowContext(ctx,
+		`SELECT id, name, (SELECT COUNT(*) FROM task_tags WHERE tag_id = tags.id), created_at, updated_at
+		 FROM tags WHERE id = ?`, id,
+	).Scan(&t.ID, &t.Name, &t.TaskCount, &t.CreatedAt, &t.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Tag{}, ErrTagNotFound.with(id)
+	}
+	if err != nil {
+		return Tag{}, fmt.Errorf("get tag: %w", err)
+	}
+	return t, nil
+}
+
+// CreateTag 新建标签；与已有标签重名（不区分大小写）时返回 ErrTagNameTaken。
+func (s *Store) CreateTag(ctx context.Context, name string) (Tag, error) {
+	name, err := normalizeTagName(name)
+	if err != nil {
+		return Tag{}, err
+	}
+	now := time.Now().UnixMilli()
+	res, err := s.db.ExecContext(ctx, `INSERT INTO tags(name, created_at, updated_at) VALUES(?, ?, ?)`, name, now, now)
+	if err != nil {
+		if sqliteIsConstraint(err, sqlitelib.SQLITE_CONSTRAINT_UNIQUE) {
+			return Tag{}, ErrTagNameTaken.with(name)
+		}
+		return Tag{}, fmt.Errorf("create tag: %w", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return Tag{}, fmt.Errorf("get new tag id: %w", err)
+	}
+	return Tag{ID: id, Name: name, CreatedAt: now, UpdatedAt: now}, nil
+}
+
+// DeleteTag 删除标签：任务上的该标签随之移除（任务本身保留），视图筛选中的该标签也一并去掉。
+func (s *Store) DeleteTag(ctx context.Context, id int64) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin delete tag: %w", err)
+	}
+	defer tx.Rollback()
+
+	tag, err := getTag(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	// 标签变化也算任务的修改，增量刷新（ChangesSince）才能带回新的标签列表
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE tasks SET updated_at = ? WHERE id IN (SELECT task_id FROM task_tags WHERE tag_id = ?)`,
+		time.Now().UnixMilli(), id,
+	); err != nil {
+		return fmt.Errorf("touch tagged tasks: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM tags WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("delete tag: %w", err)
+	}
+	if err := replaceFilterTags(ctx, tx, tag.Name, ""); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit delete tag: %w", err)
+	}
+	return nil
+}
+
+// SetTaskTags 将任务的标签替换为 names（按名称匹配已有标签，不区分大小写；不存在的标签自动新建），
+// 返回任务上的全部标签。names 为空表示清除任务的所有标签。
+func (s *Store) SetTaskTags(ctx context.Context, taskID int64, names []string) ([]Tag, error) {
+	normalized := make([]string, 0, len(names))
+	for _, n := range names {
+		n, err := normalizeTagName(n)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.ContainsFunc(normalized, func(v string) bool { return strings.EqualFold(v, n) }) {
+			normalized = append(normalized, n)
+		}
+	}
+	if len(normalized) > maxTaskTags {
+		return nil, ErrTooManyTaskTags.with(maxTaskTags)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin set task tags: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UnixMilli()
+	res, err := tx.ExecContext(ctx, `UPDATE tasks SET updated_at = ? WHERE id = ?`, now, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("touch task: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, ErrTaskNotFound.with(taskID)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM task_tags WHERE task_id = ?`, taskID); err != nil {
+		return nil, fmt.Errorf("clear task tags: %w", err)
+	}
+	for _, name := range normalized {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO tags(name, created_at, updated_at) VALUES(?, ?, ?) ON CONFLICT(name) DO NOTHING`, name, now, now,
+		); err != nil {
+			return nil, fmt.Errorf("create tag: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT OR IGNORE INTO task_tags(task_id, tag_id) SELECT ?, id FROM tags WHERE name = ?`, taskID, name,
+		); err != nil {
+			return nil, fmt.Errorf("add task tag: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit set task tags: %w", err)
+	}
+	return s.ListTaskTags(ctx, taskID)
+}
+
+// FillTags 为 tasks（含子任务）填充 Tags。
+func (s *Store) FillTags(ctx context.Context, tasks []Task) error {
+	return fillTags(ctx, s.db, tasks)
+}
+
+// fillTags 同 FillTags，通过 q（数据库或事务）查询。
+func fillTags(ctx context.Context, q queryer, tasks []Task) error {
+	var ids []int64
+	var collect func([]Task)
+	collect = func(ts []Task) {
+		for _, t := range ts {
+			ids = append(ids, t.ID)
+			collect(t.SubTasks)
+		}
+	}
+	collect(tasks)
+	if len(ids) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(ids)
+	if err != nil {
+		return err
+	}
+	rows, err := q.QueryContext(ctx,
+		`SELECT tt.task_id, t.name FROM task_tags tt JOIN tags t ON t.id = tt.tag_id
+		 WHERE tt.task_id IN (SELECT value FROM json_each(?))
+		 ORDER BY t.name COLLATE NOCASE, t.id`, string(data))
+	if err != nil {
+		return fmt.Errorf("query task tags: %w", err)
+	}
+	defer rows.Close()
+
+	names := make(map[int64][]string)
+	for rows.Next() {
+		var id int64
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return fmt.Errorf("scan task tag: %w", err)
+		}
+		names[id] = append(names[id], name)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate task tags: %w", err)
+	}
+
+	var fill func([]Task)
+	fill = func(ts []Task) {
+		for i := range ts {
+			ts[i].Tags = names[ts[i].ID]
+			fill(ts[i].SubTasks)
+		}
+	}
+	fill(tasks)
+	return nil
+}
+
+// ListTasksByTags 与 ListTasks 相同，但只返回同时带有 tags 中全部标签（不区分大小写）的主任务，
+// 并填充 Tags；tags 为空时返回全部任务。
+func (s *Store) ListTasksByTags(ctx context.Context, sort string, tags []string) ([]Task, error) {
+	tasks, err := s.ListTasks(ctx, sort)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.FillTags(ctx, tasks); err != nil {
+		return nil, err
+	}
+	f, err := ViewFilter{Tags: tags}.normalize()
+	if err != nil {
+		return nil, err
+	}
+	if len(f.Tags) == 0 {
+		return tasks, nil
+	}
+
+	out := []Task{}
+	for _, t := range tasks {
+		if hasAllTags(t.Tags, f.Tags) {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+// hasAllTags 判断 have 是否包含 want 中的全部标签（不区分大小写）。
+func hasAllTags(have, want []string) bool {
+	for _, w := range want {
+		if !slices.ContainsFunc(have, func(h string) bool { return strings.EqualFold(h, w) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// RenameTag 重命名标签：所有任务上的该标签随之改名，视图筛选中保存的旧名也一并替换。
+//
+// 新名称与其他标签重名（不区分大小写）时返回 ErrTagNameTaken，此时应改用 MergeTags。
+func (s *Store) RenameTag(ctx context.Context, id int64, name string) (Tag, error) {
+	name, err := normalizeTagName(name)
+	if err != nil {
+		return Tag{}, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return Tag{}, fmt.Errorf("begin rename tag: %w", err)
+	}
+	defer tx.Rollback()
+
+	old, err := getTag(ctx, tx, id)
+	if err != nil {
+		return Tag{}, err
+	}
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE tags SET name = ?, updated_at = ? WHERE id = ?`, name, time.Now().UnixMilli(), id,
+	); err != nil {
+		if sqliteIsConstraint(err, sqlitelib.SQLITE_CONSTRAINT_UNIQUE) {
+			return Tag{}, ErrTagNameTaken.with(name)
+		}
+		return Tag{}, fmt.Errorf("rename tag: %w", err)
+	}
+	if err := replaceFilterTags(ctx, tx, old.Name, name); err != nil {
+		return Tag{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return Tag{}, fmt.Errorf("commit rename tag: %w", err)
+	}
+	return s.GetTag(ctx, id)
+}
+
+// MergeTags 在单个事务中将标签 fromID 合并到 intoID：
+// 原先带 from 的任务改为带 into（已同时带两者的任务不会重复），随后删除 from；
+// 视图筛选中保存的 from 名称替换为 into 的名称。返回合并后的目标标签。
+func (s *Store) MergeTags(ctx context.Context, fromID, intoID int64) (Tag, error) {
+	if fromID == intoID {
+		return Tag{}, ErrTagMergeSelf
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return Tag{}, fmt.Errorf("begin merge tags: %w", err)
+	}
+	defer tx.Rollback()
+
+	from, err := getTag(ctx, tx, fromID)
+	if err != nil {
+		return Tag{}, err
+	}
+	into, err := getTag(ctx, tx, intoID)
+	if err != nil {
+		return Tag{}, err
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT OR IGNORE INTO task_tags(task_id, tag_id) SELECT task_id, ? FROM task_tags WHERE tag_id = ?`,
+		intoID, fromID,
+	); err != nil {
+		return Tag{}, fmt.Errorf("merge task tags: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM task_tags WHERE tag_id = ?`, fromID); err != nil {
+		return Tag{}, fmt.Errorf("clear merged task tags: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM tags WHERE id = ?`, fromID); err != nil {
+		return Tag{}, fmt.Errorf("delete merged tag: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE tags SET updated_at = ? WHERE id = ?`, time.Now().UnixMilli(), intoID,
+	); err != nil {
+		return Tag{}, fmt.Errorf("touch merged tag: %w", err)
+	}
+	if err := replaceFilterTags(ctx, tx, from.Name, into.Name); err != nil {
+		return Tag{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return Tag{}, fmt.Errorf("commit merge tags: %w", err)
+	}
+	return s.GetTag(ctx, intoID)
+}
+
+// replaceFilterTags 将 viewFilters 设置中名为 from（不区分大小写）的标签替换为 to（to 为空时移除），
+// 避免重命名/合并/删除后已保存的筛选条件指向不存在的标签。
+func replaceFilterTags(ctx context.Context, tx *sql.Tx, from, to string) error {
+	var raw string
+	err := tx.QueryRowContext(ctx, `SELECT value FROM settings WHERE key = 'viewFilters'`).Scan(&raw)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read view filters: %w", err)
+	}
+	filters := map[string]ViewFilter{}
+	if err := json.Unmarshal([]byte(raw), &filters); err != nil {
+		// 设置损坏时由 GetSettings 回退默认值，这里不阻断标签操作
+		return nil
+	}
+
+	changed := false
+	for view, f := range filters {
+		tags := make([]string, 0, len(f.Tags))
+		for _, t := range f.Tags {
+			if strings.EqualFold(t, from) {
+				changed = true
+				if t = to; t == "" {
+					continue
+				}
+			}
+			if !containsString(tags, t) {
+				tags = append(tags, t)
+			}
+		}
+		f.Tags = tags
+		filters[view] = f
+	}
+	if !changed {
+		return nil
+	}
+
+	data, err := json.Marshal(filters)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE settings SET value = ? WHERE key = 'viewFilters'`, string(data)); err != nil {
+		return fmt.Errorf("update view filters: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package todo
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ThemePreset 是一组命名的配色预设。
+type ThemePreset struct {
+	Name   string `json:"name"`   // 预设标识（存储在 themePreset 设置中）
+	Accent string `json:"accent"` // 强调色（#rrggbb）
+}
+
+// themePresets 为内置配色预设；第一个为默认值，与前端默认 --accent 一致。
+var themePresets = []ThemePreset{
+	{Name: "default", Accent: "#2a9d8f"},
+	{Name: "ocean", Accent: "#3b82f6"},
+	{Name: "forest", Accent: "#2f855a"},
+	{Name: "sunset", Accent: "#e76f51"},
+	{Name: "rose", Accent: "#d6336c"},
+	{Name: "graphite", Accent: "#495057"},
+}
+
+// ThemePresets 返回全部内置配色预设（副本）。
+func ThemePresets() []ThemePreset {
+	return append([]ThemePreset(nil), themePresets...)
+}
+
+// themePresetNames 返回预设名列表，用作 themePreset 设置的合法取值。
+func themePresetNames() []string {
+	names := make([]string, len(themePresets))
+	for i, p := range themePresets {
+		names[i] = p.Name
+	}
+	return names
+}
+
+var hexColorRe = regexp.MustCompile(`^#([0-9a-f]{3}|[0-9a-f]{6})$`)
+
+// normalizeHexColor 校验颜色（自定义强调色、任务颜色）：
+// - 空字符串表示使用默认颜色（预设强调色 / 不着色）
+// - 否则必须是 #rgb 或 #rrggbb，统一转为小写 #rrggbb
+func normalizeHexColor(v string) (string, error) {
+	v = strings.ToLower(strings.TrimSpace(v))
+	if v == "" {
+		return "", nil
+	}
+	if !hexColorRe.MatchString(v) {
+		return "", ErrInvalidColor.with(v)
+	}
+	if len(v) == 4 {
+		v = "#" + strings.Repeat(v[1:2], 2) + strings.Repeat(v[2:3], 2) + strings.Repeat(v[3:4], 2)
+	}
+	return v, nil
+}
+
+// ResolveAccentColor 返回实际生效的强调色：自定义颜色优先，否则使用预设颜色。
+func ResolveAccentColor(settings Settings) string {
+	if settings.AccentColor != "" {
+		return settings.AccentColor
+	}
+	for _, p := range themePresets {
+		if p.Name == settings.ThemePreset {
+			return p.Accent
+		}
+	}
+	return themePresets[0].Accent
+}
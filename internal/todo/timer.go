@@ -0,0 +1,196 @@
+package todo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TimeEntry 为一段计时记录。
+type TimeEntry struct {
+	ID        int64  `json:"id"`
+	TaskID    int64  `json:"taskId"`
+	TaskTitle string `json:"taskTitle"` // 任务已删除时为空
+	Source    string `json:"source"`    // TimeSource*
+	StartedAt int64  `json:"startedAt"`
+	EndedAt   int64  `json:"endedAt"` // 结束时间；进行中为 0
+	Seconds   int    `json:"seconds"` // 时长（进行中的按读取时间计算）
+}
+
+// TimeSpent 为某个任务累计的计时（计时器与番茄钟）。
+type TimeSpent struct {
+	TaskID  int64 `json:"taskId"`
+	Seconds int   `json:"seconds"`
+	Entries int   `json:"entries"` // 记录条数
+	Running bool  `json:"running"` // 是否有进行中的记录
+}
+
+// TimeReportTask 为时间报表中某个任务的时长。
+type TimeReportTask struct {
+	TaskID  int64   `json:"taskId"`
+	Title   string  `json:"title"` // 任务已删除时为空
+	Group   string  `json:"group"`
+	Seconds int     `json:"seconds"`
+	Percent float64 `json:"percent"` // 占总时长的百分比（0-100）
+}
+
+// TimeReport 为一段日期内按任务汇总的计时。
+type TimeReport struct {
+	From         string           `json:"from"` // 第一天（YYYY-MM-DD）
+	To           string           `json:"to"`   // 最后一天（含）
+	TotalSeconds int              `json:"totalSeconds"`
+	Tasks        []TimeReportTask `json:"tasks"` // 按时长降序
+}
+
+// openTimeEntry 在 q（数据库或事务）上读取进行中的时间记录（计时器或番茄钟）；没有时返回 sql.ErrNoRows。
+func openTimeEntry(ctx context.Context, q tagQuerier, now int64) (TimeEntry, error) {
+	var e TimeEntry
+	err := q.QueryRowContext(ctx,
+		`SELECT e.id, e.task_id, COALESCE(t.title, ''), e.source, e.started_at
+		 FROM time_entries e LEFT JOIN tasks t ON t.id = e.task_id
+		 WHERE e.ended_at = 0 ORDER BY e.id DESC LIMIT 1`,
+	).Scan(&e.ID, &e.TaskID, &e.TaskTitle, &e.Source, &e.StartedAt)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return TimeEntry{}, fmt.Errorf("get open time entry: %w", err)
+	}
+	e.Seconds = int(max(now-e.StartedAt, 0) / 1000)
+	return e, err
+}
+
+// ActiveTimer 返回进行中的任务计时器；没有时返回 nil（番茄钟见 ActivePomodoro）。
+func (s *Store) ActiveTimer(ctx context.Context, now time.Time) (*TimeEntry, error) {
+	e, err := openTimeEntry(ctx, s.db, now.UnixMilli())
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && e.Source != TimeSourceTimer) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// StartTimer 开始为任务 taskID 计时。
+//
+// 计时不能重叠：已有进行中的计时器时返回 ErrTimerRunning，番茄钟专注中时返回 ErrPomodoroActive。
+// 检查与写入在同一事务中完成（另有 idx_time_entries_open 保证最多一条进行中的记录）。
+func (s *Store) StartTimer(ctx context.Context, taskID int64, now time.Time) (TimeEntry, error) {
+	if _, err := s.GetTask(ctx, taskID); err != nil {
+		return TimeEntry{}, err
+	}
+	ms := now.UnixMilli()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return TimeEntry{}, fmt.Errorf("begin start timer: %w", err)
+	}
+	defer tx.Rollback()
+	if err := checkNoOpenTimeEntry(ctx, tx, ms); err != nil {
+		return TimeEntry{}, err
+	}
+	if _, err := startTimeEntry(ctx, tx, taskID, TimeSourceTimer, ms); err != nil {
+		return TimeEntry{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return TimeEntry{}, fmt.Errorf("commit start timer: %w", err)
+	}
+	e, err := openTimeEntry(ctx, s.db, ms)
+	if err != nil {
+		return TimeEntry{}, err
+	}
+	return e, nil
+}
+
+// StopTimer 结束进行中的任务计时器，返回结束后的记录；没有进行中的计时器时返回 ErrNoTimer。
+func (s *Store) StopTimer(ctx context.Context, now time.Time) (TimeEntry, error) {
+	e, err := s.ActiveTimer(ctx, now)
+	if err != nil {
+		return TimeEntry{}, err
+	}
+	if e == nil {
+		return TimeEntry{}, ErrNoTimer
+	}
+	e.EndedAt = max(now.UnixMilli(), e.StartedAt)
+	if _, err := s.db.ExecContext(ctx, `UPDATE time_entries SET ended_at = ? WHERE id = ?`, e.EndedAt, e.ID); err != nil {
+		return TimeEntry{}, fmt.Errorf("stop timer: %w", err)
+	}
+	e.Seconds = int((e.EndedAt - e.StartedAt) / 1000)
+	return *e, nil
+}
+
+// GetTimeSpent 返回任务 taskID 累计的计时（含番茄钟，进行中的记录按 now 截止）。
+// 任务删除后记录仍保留，因此不要求任务存在。
+func (s *Store) GetTimeSpent(ctx context.Context, taskID int64, now time.Time) (TimeSpent, error) {
+	if taskID <= 0 {
+		return TimeSpent{}, ErrInvalidTaskID
+	}
+	out := TimeSpent{TaskID: taskID}
+	var ms int64
+	if err := s.db.QueryRowContext(ctx,
+		`SELECT COUNT(*),
+		        COALESCE(SUM(MAX(CASE WHEN ended_at > 0 THEN ended_at ELSE ? END - started_at, 0)), 0),
+		        COALESCE(MAX(ended_at = 0), 0)
+		 FROM time_entries WHERE task_id = ?`,
+		now.UnixMilli(), taskID,
+	).Scan(&out.Entries, &ms, &out.Running); err != nil {
+		return TimeSpent{}, fmt.Errorf("get time spent: %w", err)
+	}
+	out.Seconds = int(ms / 1000)
+	return out, nil
+}
+
+// GetTimeReport 按任务汇总 from 到 to（YYYY-MM-DD，含首尾两天）之间的计时。
+//
+// 为空的 to 表示今天，为空的 from 与 to 相同；跨越范围边界的记录只计入范围内的部分。
+func (s *Store) GetTimeReport(ctx context.Context, from, to string, now time.Time, settings Settings) (TimeReport, error) {
+	loc := Location(settings)
+	last := StartOfDay(now, loc)
+	if to = strings.TrimSpace(to); to != "" {
+		parsed, err := time.ParseInLocation(dayKeyLayout, to, loc)
+		if err != nil {
+			return TimeReport{}, ErrInvalidDate.with(to)
+		}
+		last = parsed
+	}
+	first := last
+	if from = strings.TrimSpace(from); from != "" {
+		parsed, err := time.ParseInLocation(dayKeyLayout, from, loc)
+		if err != nil {
+			return TimeReport{}, ErrInvalidDate.with(from)
+		}
+		first = parsed
+	}
+	if first.After(last) {
+		return TimeReport{}, ErrInvalidRange.with(from + " ~ " + to)
+	}
+
+	entries, err := s.listTimeEntries(ctx, first.UnixMilli(), last.AddDate(0, 0, 1).UnixMilli(), now.UnixMilli())
+	if err != nil {
+		return TimeReport{}, err
+	}
+	out := TimeReport{From: first.Format(dayKeyLayout), To: last.Format(dayKeyLayout), Tasks: []TimeReportTask{}}
+	perTask := map[int64]int64{}
+	var order []timeEntry
+	var total int64
+	for _, e := range entries {
+		if _, ok := perTask[e.TaskID]; !ok {
+			order = append(order, e)
+		}
+		perTask[e.TaskID] += e.EndedAt - e.StartedAt
+		total += e.EndedAt - e.StartedAt
+	}
+	for _, e := range order {
+		ms := perTask[e.TaskID]
+		t := TimeReportTask{TaskID: e.TaskID, Title: e.TaskTitle, Group: e.GroupName, Seconds: int(ms / 1000)}
+		if total > 0 {
+			t.Percent = float64(ms) * 100 / float64(total)
+		}
+		out.Tasks = append(out.Tasks, t)
+	}
+	sort.SliceStable(out.Tasks, func(i, j int) bool { return out.Tasks[i].Seconds > out.Tasks[j].Seconds })
+	out.TotalSeconds = int(total / 1000)
+	return out, nil
+}
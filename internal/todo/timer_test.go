@@ -0,0 +1,51 @@
+package todo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTimeEntriesDoNotOverlap(t *testing.T) {
+	ctx := context.Background()
+	s := openTestStore(t)
+	task := createTask(t, s, Task{GroupID: firstGroupID(t, s), Title: "task"})
+	now := time.Now()
+
+	if _, err := s.StartTimer(ctx, task.ID, now); err != nil {
+		t.Fatalf("start timer: %v", err)
+	}
+	if _, err := s.StartTimer(ctx, task.ID, now); !errors.Is(err, ErrTimerRunning) {
+		t.Errorf("second timer: err = %v", err)
+	}
+	if _, err := s.StartPomodoro(ctx, task.ID, 0, now); !errors.Is(err, ErrTimerRunning) {
+		t.Errorf("pomodoro while timing: err = %v", err)
+	}
+	if _, err := s.StopTimer(ctx, now.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.StartPomodoro(ctx, task.ID, 0, now.Add(time.Minute)); err != nil {
+		t.Fatalf("start pomodoro: %v", err)
+	}
+	if _, err := s.StartTimer(ctx, task.ID, now.Add(time.Minute)); !errors.Is(err, ErrPomodoroActive) {
+		t.Errorf("timer during pomodoro: err = %v", err)
+	}
+	if _, err := s.PausePomodoro(ctx, now.Add(2*time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	// 暂停期间可以计时，计时进行中不能继续番茄钟
+	if _, err := s.StartTimer(ctx, task.ID, now.Add(2*time.Minute)); err != nil {
+		t.Fatalf("timer while paused: %v", err)
+	}
+	if _, err := s.ResumePomodoro(ctx, now.Add(3*time.Minute)); !errors.Is(err, ErrTimerRunning) {
+		t.Errorf("resume while timing: err = %v", err)
+	}
+
+	// 索引兜底：绕过检查直接写入第二条进行中的记录也会失败
+	if _, err := s.db.ExecContext(ctx, `INSERT INTO time_entries(task_id, source, started_at, ended_at) VALUES(?, 'timer', ?, 0)`,
+		task.ID, now.UnixMilli()); err == nil {
+		t.Error("second open time entry inserted")
+	}
+}
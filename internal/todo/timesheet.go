@@ -0,0 +1,98 @@
+package todo
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// TimesheetTask 为某一天内某个任务的工时。
+type TimesheetTask struct {
+	TaskID  int64  `json:"taskId"`
+	Title   string `json:"title"` // 任务已删除时为空
+	Group   string `json:"group"`
+	Minutes int    `json:"minutes"`
+}
+
+// TimesheetDay 为某一天的工时（只包含有记录的日子）。
+type TimesheetDay struct {
+	Date    string          `json:"date"`  // 本地日期（YYYY-MM-DD）
+	Label   string          `json:"label"` // 按日期格式设置显示的日期
+	Minutes int             `json:"minutes"`
+	Tasks   []TimesheetTask `json:"tasks"` // 按时长降序
+}
+
+// Timesheet 为按天、按任务汇总的工时表。
+type Timesheet struct {
+	Range        string         `json:"range"`
+	From         string         `json:"from"` // 范围第一天（按日期格式设置）
+	To           string         `json:"to"`   // 范围最后一天
+	TotalMinutes int            `json:"totalMinutes"`
+	Days         []TimesheetDay `json:"days"`
+	GeneratedAt  string         `json:"generatedAt"`
+}
+
+// GetTimesheet 将 rng（week / month / quarter）范围内的时间记录按天、按任务汇总为工时表。
+// 跨零点的记录按天拆分；进行中的计时按 now 截止。
+func (s *Store) GetTimesheet(ctx context.Context, rng string, now time.Time, settings Settings) (Timesheet, error) {
+	rng, days, err := parseStatsRange(rng)
+	if err != nil {
+		return Timesheet{}, err
+	}
+	loc := Location(settings)
+	first := StartOfDay(now, loc).AddDate(0, 0, -(days - 1))
+	end := first.AddDate(0, 0, days)
+
+	entries, err := s.listTimeEntries(ctx, first.UnixMilli(), end.UnixMilli(), now.UnixMilli())
+	if err != nil {
+		return Timesheet{}, err
+	}
+
+	out := Timesheet{
+		Range:       rng,
+		From:        FormatDate(first, settings),
+		To:          FormatDate(end.AddDate(0, 0, -1), settings),
+		Days:        []TimesheetDay{},
+		GeneratedAt: FormatDateTime(now.In(loc), settings),
+	}
+	var total int64
+	for i := 0; i < days; i++ {
+		dayStart := first.AddDate(0, 0, i)
+		ds, de := dayStart.UnixMilli(), dayStart.AddDate(0, 0, 1).UnixMilli()
+
+		perTask := map[int64]int64{}
+		var order []timeEntry
+		for _, e := range entries {
+			overlap := min(e.EndedAt, de) - max(e.StartedAt, ds)
+			if overlap <= 0 {
+				continue
+			}
+			if _, ok := perTask[e.TaskID]; !ok {
+				order = append(order, e)
+			}
+			perTask[e.TaskID] += overlap
+		}
+		if len(order) == 0 {
+			continue
+		}
+
+		day := TimesheetDay{Date: dayStart.Format(dayKeyLayout), Label: FormatDate(dayStart, settings), Tasks: []TimesheetTask{}}
+		var dayMs int64
+		for _, e := range order {
+			ms := perTask[e.TaskID]
+			dayMs += ms
+			day.Tasks = append(day.Tasks, TimesheetTask{
+				TaskID:  e.TaskID,
+				Title:   e.TaskTitle,
+				Group:   e.GroupName,
+				Minutes: int(ms / time.Minute.Milliseconds()),
+			})
+		}
+		sort.SliceStable(day.Tasks, func(i, j int) bool { return day.Tasks[i].Minutes > day.Tasks[j].Minutes })
+		day.Minutes = int(dayMs / time.Minute.Milliseconds())
+		total += dayMs
+		out.Days = append(out.Days, day)
+	}
+	out.TotalMinutes = int(total / time.Minute.Milliseconds())
+	return out, nil
+}
@@ -0,0 +1,45 @@
+package todo
+
+import (
+	"strings"
+	"time"
+)
+
+// normalizeTimezone 校验时区设置：空字符串表示跟随系统，否则必须是可加载的 IANA 时区名。
+func normalizeTimezone(v string) (string, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return "", nil
+	}
+	if _, err := time.LoadLocation(v); err != nil {
+		return "", ErrInvalidTimezone.with(v)
+	}
+	return v, nil
+}
+
+// Location 返回设置对应的时区；未设置或无法加载时回退到系统时区（time.Local）。
+//
+// "今天"、"逾期"、连续打卡等按自然日计算的逻辑都必须基于这里返回的时区，
+// 否则跨时区出行时同一次完成会被计入错误的日期。
+func Location(settings Settings) *time.Location {
+	if settings.Timezone == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(settings.Timezone)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
+// StartOfDay 返回 t 在 loc 时区下所在自然日的零点。
+func StartOfDay(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+// DayRange 返回 t 在 loc 时区下所在自然日的 [开始, 结束) UnixMilli 区间，便于直接用于 SQL 查询。
+func DayRange(t time.Time, loc *time.Location) (int64, int64) {
+	start := StartOfDay(t, loc)
+	return start.UnixMilli(), start.AddDate(0, 0, 1).UnixMilli()
+}
@@ -0,0 +1,168 @@
+package todo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// dayKeyLayout 为"我的一天"等按自然日记录的日期格式。
+const dayKeyLayout = "2006-01-02"
+
+// Today 为"今天"视图的聚合数据。
+//
+// 三个列表互不重复，优先级依次为 Overdue > DueToday > MyDay：
+// 既逾期又被加入"我的一天"的任务只出现在 Overdue 中。
+type Today struct {
+	Date     string `json:"date"`     // 今天的本地日期（YYYY-MM-DD，按时区设置计算）
+	Overdue  []Task `json:"overdue"`  // 截止时间早于今天、尚未完成的任务（逾期结转）
+	DueToday []Task `json:"dueToday"` // 今天到期的任务（含已完成，便于查看当天进度）
+	MyDay    []Task `json:"myDay"`    // 今天手动加入"我的一天"的任务
+	Count    int    `json:"count"`    // 三个列表中未完成任务总数（用于角标）
+}
+
+// DayKey 返回 t 在 loc 时区下的日期键（YYYY-MM-DD）。
+func DayKey(t time.Time, loc *time.Location) string {
+	return t.In(loc).Format(dayKeyLayout)
+}
+
+// AddToMyDay 将任务加入 day 当天的"我的一天"（重复加入只刷新日期）。
+func (s *Store) AddToMyDay(ctx context.Context, taskID int64, day string) error {
+	if taskID <= 0 {
+		return ErrInvalidTaskID
+	}
+	var id int64
+	err := s.db.QueryRowContext(ctx, `SELECT id FROM tasks WHERE id = ?`, taskID).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrTaskNotFound.with(taskID)
+	}
+	if err != nil {
+		return fmt.Errorf("check task exists: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx,
+		`INSERT INTO my_day(task_id, day, added_at) VALUES(?, ?, ?)
+		 ON CONFLICT(task_id) DO UPDATE SET day = excluded.day, added_at = excluded.added_at`,
+		taskID, day, time.Now().UnixMilli(),
+	); err != nil {
+		return fmt.Errorf("add to my day: %w", err)
+	}
+	return nil
+}
+
+// RemoveFromMyDay 将任务移出"我的一天"（不在其中时无副作用）。
+func (s *Store) RemoveFromMyDay(ctx context.Context, taskID int64) error {
+	if taskID <= 0 {
+		return ErrInvalidTaskID
+	}
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM my_day WHERE task_id = ?`, taskID); err != nil {
+		return fmt.Errorf("remove from my day: %w", err)
+	}
+	return nil
+}
+
+// ResetMyDay 清除 day 之前的"我的一天"记录（每日零点自动重置）。
+func (s *Store) ResetMyDay(ctx context.Context, day string) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM my_day WHERE day < ?`, day); err != nil {
+		return fmt.Errorf("reset my day: %w", err)
+	}
+	return nil
+}
+
+// GetToday 返回 now 在 loc 时区下的"今天"视图。
+//
+// 读取前会先清理过期的"我的一天"记录，因此即使应用跨夜未运行，重新打开后也是新的一天。
+// 与看板一致，不包含已归档分组中的任务。
+func (s *Store) GetToday(ctx context.Context, now time.Time, loc *time.Location) (Today, error) {
+	day := DayKey(now, loc)
+	start, end := DayRange(now, loc)
+	if err := s.ResetMyDay(ctx, day); err != nil {
+		return Today{}, err
+	}
+
+	today := Today{Date: day}
+	var err error
+	if today.Overdue, err = s.queryTasks(ctx,
+		`SELECT `+taskColumns+` FROM tasks
+		 WHERE due_at > 0 AND due_at < ? AND status <> 'done' AND `+activeGroupSQL+`
+		 ORDER BY due_at, id`,
+		start,
+	); err != nil {
+		return Today{}, err
+	}
+	if today.DueToday, err = s.queryTasks(ctx,
+		`SELECT `+taskColumns+` FROM tasks
+		 WHERE due_at >= ? AND due_at < ? AND `+activeGroupSQL+`
+		 ORDER BY `+statusOrderSQL+`, due_at, id`,
+		start, end,
+	); err != nil {
+		return Today{}, err
+	}
+	if today.MyDay, err = s.queryTasks(ctx,
+		`SELECT `+prefixColumns("t", taskColumns)+` FROM tasks t
+		 JOIN my_day m ON m.task_id = t.id
+		 WHERE m.day = ? AND t.`+activeGroupSQL+`
+		   AND NOT (t.due_at > 0 AND t.due_at < ? AND t.status <> 'done')
+		   AND NOT (t.due_at >= ? AND t.due_at < ?)
+		 ORDER BY `+statusOrderSQL+`, m.added_at, t.id`,
+		day, start, start, end,
+	); err != nil {
+		return Today{}, err
+	}
+
+	for _, list := range [][]Task{today.Overdue, today.DueToday, today.MyDay} {
+		for _, t := range list {
+			if t.Status != StatusDone {
+				today.Count++
+			}
+		}
+	}
+	return today, nil
+}
+
+// OverdueItem 为逾期视图中的一项。
+type OverdueItem struct {
+	Task        Task `json:"task"`
+	DaysOverdue int  `json:"daysOverdue"` // 截止日期距今天的自然日天数（>= 1）
+}
+
+// Overdue 为逾期视图数据。
+type Overdue struct {
+	Count int           `json:"count"` // 逾期任务数（用于角标）
+	Items []OverdueItem `json:"items"` // 按逾期时长降序（最早到期的在前）
+}
+
+// GetOverdue 返回截止日期早于今天且未完成的任务，附带逾期天数。
+//
+// 与 GetToday 一致按自然日判断（不含已归档分组）：今天到期的任务不算逾期；
+// 天数按时区设置下的日历日计算，而不是简单地用毫秒差除以 24 小时（避免夏令时切换造成偏差）。
+func (s *Store) GetOverdue(ctx context.Context, now time.Time, loc *time.Location) (Overdue, error) {
+	today := StartOfDay(now, loc)
+	tasks, err := s.queryTasks(ctx,
+		`SELECT `+taskColumns+` FROM tasks
+		 WHERE due_at > 0 AND due_at < ? AND status <> 'done' AND `+activeGroupSQL+`
+		 ORDER BY due_at, id`,
+		today.UnixMilli(),
+	)
+	if err != nil {
+		return Overdue{}, err
+	}
+
+	out := Overdue{Count: len(tasks), Items: make([]OverdueItem, 0, len(tasks))}
+	for _, t := range tasks {
+		out.Items = append(out.Items, OverdueItem{
+			Task:        t,
+			DaysOverdue: daysBetween(StartOfDay(time.UnixMilli(t.DueAt), loc), today),
+		})
+	}
+	return out, nil
+}
+
+// daysBetween 返回两个同时区零点之间相差的自然日数（to - from）。
+func daysBetween(from, to time.Time) int {
+	// 用 UTC 日期做差，避免夏令时导致某天只有 23/25 小时
+	f := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	t := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(t.Sub(f).Hours() / 24)
+}
@@ -0,0 +1,328 @@
+package todo
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// todo.txt 格式（https://github.com/todotxt/todo.txt）与任务字段的对应关系：
+//
+//   - 优先级 (A) = 重要且紧急，(B) = 重要，(C) = 紧急，其余优先级或没有优先级 = 都不是；
+//     已完成任务按惯例不写优先级，而是写为 pri:A 这样的键值
+//   - 最后一个 +项目 = 分组（导出时写在标题之后，分组名中的空格写为 "_"），@上下文 = 标签（同样以 "_" 代替空格）
+//   - x 完成日期 = 已完成，创建日期 = 创建时间，due:YYYY-MM-DD = 截止日期
+//
+// todo.txt 没有内容、进行中状态与子任务：导出时内容被丢弃、子任务作为独立的一行（数量见 TodoTxtExportSummary），
+// 导入的任务都是主任务。
+//
+// 标题中会被当作上述语法的词（开头的 "x"、"(A)" 与日期，任意位置的 +项目、@上下文、due:、pri:）
+// 以及本身以 "\" 开头的词，导出时在前面加 "\"，导入时去掉，保证标题原样往返。
+
+// todoTxtDateLayout 为 todo.txt 中的日期格式。
+const todoTxtDateLayout = "2006-01-02"
+
+// todoTxtPriority 返回重要/紧急标记对应的 todo.txt 优先级；都不是时返回空字符串。
+func todoTxtPriority(important, urgent bool) string {
+	switch {
+	case important && urgent:
+		return "A"
+	case important:
+		return "B"
+	case urgent:
+		return "C"
+	default:
+		return ""
+	}
+}
+
+// todoTxtToken 将分组名/标签名写为 todo.txt 中的 +项目 / @上下文（空白替换为 "_"）。
+func todoTxtToken(name string) string {
+	return strings.Join(strings.Fields(name), "_")
+}
+
+// todoTxtTitleToken 按需转义标题中的词 f（见文件开头）；first 表示 f 是标题的第一个词。
+func todoTxtTitleToken(f string, first bool) string {
+	escape := strings.HasPrefix(f, `\`) || strings.HasPrefix(f, "+") || strings.HasPrefix(f, "@") ||
+		strings.HasPrefix(f, "due:") || strings.HasPrefix(f, "pri:")
+	if first && !escape {
+		_, err := time.Parse(todoTxtDateLayout, f)
+		escape = f == "x" || err == nil || (len(f) == 3 && f[0] == '(' && f[2] == ')' && f[1] >= 'A' && f[1] <= 'Z')
+	}
+	if escape {
+		return `\` + f
+	}
+	return f
+}
+
+// TodoTxtExportSummary 为 todo.txt 导出中 todo.txt 无法表示、因而丢失的信息。
+type TodoTxtExportSummary struct {
+	Tasks             int `json:"tasks"`             // 导出的任务数（含子任务）
+	ContentDropped    int `json:"contentDropped"`    // 内容不为空、导出时丢弃了内容的任务数
+	SubtasksFlattened int `json:"subtasksFlattened"` // 作为独立任务导出（失去父子关系）的子任务数
+}
+
+// ExportTodoTxt 将全部任务（含子任务，每个任务一行，按创建时间排序）导出为 todo.txt 格式，
+// 同时返回导出中丢失的信息（内容与父子关系）的统计。
+func (s *Store) ExportTodoTxt(ctx context.Context) ([]byte, TodoTxtExportSummary, error) {
+	settings, err := s.GetSettings(ctx)
+	if err != nil {
+		return nil, TodoTxtExportSummary{}, err
+	}
+	groups, err := s.ListGroups(ctx)
+	if err != nil {
+		return nil, TodoTxtExportSummary{}, err
+	}
+	tasks, err := s.queryTasks(ctx, `SELECT `+taskColumns+` FROM tasks ORDER BY created_at, id`)
+	if err != nil {
+		return nil, TodoTxtExportSummary{}, err
+	}
+	if err := s.FillTags(ctx, tasks); err != nil {
+		return nil, TodoTxtExportSummary{}, err
+	}
+
+	names := make(map[int64]string, len(groups))
+	for _, g := range groups {
+		names[g.ID] = g.Name
+	}
+	loc := Location(settings)
+	date := func(ms int64) string { return time.UnixMilli(ms).In(loc).Format(todoTxtDateLayout) }
+
+	var buf bytes.Buffer
+	sum := TodoTxtExportSummary{Tasks: len(tasks)}
+	for _, t := range tasks {
+		if t.Content != "" {
+			sum.ContentDropped++
+		}
+		if t.ParentID > 0 {
+			sum.SubtasksFlattened++
+		}
+		var parts []string
+		pri := todoTxtPriority(t.Important, t.Urgent)
+		if t.Status == StatusDone {
+			parts = append(parts, "x")
+			if t.CompletedAt > 0 {
+				parts = append(parts, date(t.CompletedAt))
+			}
+		} else if pri != "" {
+			parts = append(parts, "("+pri+")")
+		}
+		if t.CreatedAt > 0 && (t.Status != StatusDone || t.CompletedAt > 0) {
+			parts = append(parts, date(t.CreatedAt))
+		}
+		for i, f := range strings.Fields(t.Title) {
+			parts = append(parts, todoTxtTitleToken(f, i == 0))
+		}
+		if name := todoTxtToken(names[t.GroupID]); name != "" {
+			parts = append(parts, "+"+name)
+		}
+		for _, tag := range t.Tags {
+			parts = append(parts, "@"+todoTxtToken(tag))
+		}
+		if t.DueAt > 0 {
+			parts = append(parts, "due:"+date(t.DueAt))
+		}
+		if t.Status == StatusDone && pri != "" {
+			parts = append(parts, "pri:"+pri)
+		}
+		buf.WriteString(strings.Join(parts, " "))
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), sum, nil
+}
+
+// todoTxtTask 为 todo.txt 中解析出的一行；Tags 为 @上下文（原样，未把 "_" 还原为空格）。
+type todoTxtTask struct {
+	Task
+	Project string // 最后一个 +项目（同上）
+}
+
+// parseTodoTxtLine 解析 todo.txt 中的一行（已去除首尾空白且非空）。
+//
+// 除最后一个 +项目、@上下文、due: 与 pri: 以外的内容（包括其它项目与键值）都保留在标题中；
+// 标题中以 "\" 开头的词去掉这个转义符（见 todoTxtTitleToken）。
+func parseTodoTxtLine(line string, loc *time.Location, now int64) (todoTxtTask, error) {
+	fields := strings.Fields(line)
+	out := todoTxtTask{Task: Task{Status: StatusTodo, CreatedAt: now}}
+	parseDate := func() (int64, bool) {
+		if len(fields) == 0 {
+			return 0, false
+		}
+		d, err := time.ParseInLocation(todoTxtDateLayout, fields[0], loc)
+		if err != nil {
+			return 0, false
+		}
+		fields = fields[1:]
+		return d.UnixMilli(), true
+	}
+
+	pri := ""
+	if fields[0] == "x" {
+		fields = fields[1:]
+		out.Status = StatusDone
+		out.CompletedAt = now
+		if d, ok := parseDate(); ok {
+			out.CompletedAt = d
+			if d, ok := parseDate(); ok {
+				out.CreatedAt = d
+			}
+		}
+	} else {
+		if f := fields[0]; len(f) == 3 && f[0] == '(' && f[2] == ')' && f[1] >= 'A' && f[1] <= 'Z' {
+			pri = f[1:2]
+			fields = fields[1:]
+		}
+		if d, ok := parseDate(); ok {
+			out.CreatedAt = d
+		}
+	}
+
+	project := -1
+	for i, f := range fields {
+		if len(f) > 1 && f[0] == '+' {
+			project = i
+		}
+	}
+	var title []string
+	for i, f := range fields {
+		switch {
+		case i == project:
+			out.Project = f[1:]
+		case len(f) > 1 && f[0] == '\\':
+			title = append(title, f[1:])
+		case len(f) > 1 && f[0] == '@':
+			out.Tags = append(out.Tags, f[1:])
+		case strings.HasPrefix(f, "due:") && len(f) > len("due:"):
+			d, err := time.ParseInLocation(todoTxtDateLayout, f[len("due:"):], loc)
+			if err != nil {
+				return todoTxtTask{}, ErrInvalidDate.with(f[len("due:"):])
+			}
+			out.DueAt = d.UnixMilli()
+		case strings.HasPrefix(f, "pri:") && len(f) == len("pri:")+1 && out.Status == StatusDone:
+			pri = strings.ToUpper(f[len("pri:"):])
+		default:
+			title = append(title, f)
+		}
+	}
+	out.Title = strings.Join(title, " ")
+	if out.Title == "" {
+		return todoTxtTask{}, ErrTaskTitleEmpty
+	}
+	out.Important = pri == "A" || pri == "B"
+	out.Urgent = pri == "A" || pri == "C"
+	return out, nil
+}
+
+// ImportTodoTxt 从 todo.txt 格式导入任务，返回新建/跳过/出错的行数（对应关系见文件开头）。
+//
+// 没有 +项目 的任务导入到 groupID（为 0 时为第一个分组），项目对应的分组不存在时自动新建；
+// 空行与导入前已存在（同分组、同标题、同截止日期）的任务跳过，文件中本身重复的行照常导入。每行单独校验，不合法的行记入 Errors 并跳过，
+// 其余行在单个事务中写入。
+func (s *Store) ImportTodoTxt(ctx context.Context, data []byte, groupID int64) (TaskImportSummary, error) {
+	settings, err := s.GetSettings(ctx)
+	if err != nil {
+		return TaskImportSummary{}, err
+	}
+	groupID, groupIDs, err := s.importGroups(ctx, groupID)
+	if err != nil {
+		return TaskImportSummary{}, err
+	}
+	existing, err := s.existingTaskKeys(ctx)
+	if err != nil {
+		return TaskImportSummary{}, err
+	}
+	tags, err := s.ListTags(ctx)
+	if err != nil {
+		return TaskImportSummary{}, err
+	}
+	tagNames := make(map[string]bool, len(tags))
+	for _, t := range tags {
+		tagNames[t.Name] = true
+	}
+	// 导出时名称中的空格写为 "_"：原样的名称不存在而还原空格后的名称存在时，使用后者
+	restore := func(name string, exists func(string) bool) string {
+		if spaced := strings.ReplaceAll(name, "_", " "); !exists(name) && exists(spaced) {
+			return spaced
+		}
+		return name
+	}
+	groupExists := func(name string) bool {
+		_, ok := groupIDs[groupNameKey(canonicalGroupName(name))]
+		return ok
+	}
+	tagExists := func(name string) bool { return tagNames[name] }
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return TaskImportSummary{}, fmt.Errorf("begin import todo.txt: %w", err)
+	}
+	defer tx.Rollback()
+
+	out := TaskImportSummary{Errors: []ImportRowError{}}
+	loc := Location(settings)
+	now := time.Now().UnixMilli()
+	sc := bufio.NewScanner(bytes.NewReader(bytes.TrimPrefix(data, []byte("\ufeff"))))
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for row := 1; sc.Scan(); row++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			out.Skipped++
+			continue
+		}
+
+		t, err := parseTodoTxtLine(line, loc, now)
+		if err == nil {
+			t.Title, _, err = applyLengthLimit(LengthFieldTitle, t.Title, settings.TitleLimit, settings.LengthLimitMode, ErrTaskTitleTooLong)
+		}
+		for i := 0; err == nil && i < len(t.Tags); i++ {
+			t.Tags[i], err = normalizeTagName(restore(t.Tags[i], tagExists))
+		}
+		if err == nil {
+			t.GroupID = groupID
+			if t.Project != "" {
+				t.GroupID, err = importGroup(ctx, tx, groupIDs, restore(t.Project, groupExists), settings, now, &out)
+			}
+		}
+		if err != nil {
+			out.Errored++
+			out.Errors = append(out.Errors, ImportRowError{Row: row, Message: err.Error(), Err: err})
+			continue
+		}
+
+		key := taskDedupKey(t.GroupID, 0, t.Title, t.DueAt)
+		if _, ok := existing[key]; ok {
+			out.Skipped++
+			continue
+		}
+		res, err := tx.ExecContext(ctx,
+			`INSERT INTO tasks(group_id, parent_id, title, content, content_format, status, important, urgent, due_at, sort_order, completed_at, created_at, updated_at)
+			 VALUES(?, 0, ?, '', ?, ?, ?, ?, ?, (SELECT COALESCE(MAX(sort_order), 0) + 1 FROM tasks), ?, ?, ?)`,
+			t.GroupID, t.Title, ContentFormatPlain, string(t.Status), boolTo01Int(t.Important), boolTo01Int(t.Urgent),
+			t.DueAt, t.CompletedAt, t.CreatedAt, now,
+		)
+		if err != nil {
+			return TaskImportSummary{}, fmt.Errorf("import todo.txt task: %w", err)
+		}
+		id, err := res.LastInsertId()
+		if err != nil {
+			return TaskImportSummary{}, fmt.Errorf("import todo.txt task: %w", err)
+		}
+		for _, name := range t.Tags {
+			if err := addTaskTag(ctx, tx, id, name, now); err != nil {
+				return TaskImportSummary{}, err
+			}
+			tagNames[name] = true
+		}
+		out.Created++
+	}
+	if err := sc.Err(); err != nil {
+		return TaskImportSummary{}, ErrInvalidImportFile
+	}
+	if err := tx.Commit(); err != nil {
+		return TaskImportSummary{}, fmt.Errorf("commit import todo.txt: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,75 @@
+package todo
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTodoTxtRoundTripsTitles(t *testing.T) {
+	ctx := context.Background()
+	s := openTestStore(t)
+	groupID := firstGroupID(t, s)
+
+	titles := []string{
+		"x marks the spot",
+		"(A) not a priority",
+		"2025-01-02 not a date",
+		"email +bob @home due:friday pri:A",
+		`\backslash stays`,
+	}
+	for _, title := range titles {
+		createTask(t, s, Task{GroupID: groupID, Title: title, Status: StatusTodo})
+	}
+	parent := createTask(t, s, Task{GroupID: groupID, Title: "parent", Content: "dropped"})
+	createTask(t, s, Task{GroupID: groupID, ParentID: parent.ID, Title: "child"})
+
+	data, sum, err := s.ExportTodoTxt(ctx)
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	if sum.Tasks != len(titles)+2 || sum.ContentDropped != 1 || sum.SubtasksFlattened != 1 {
+		t.Errorf("summary = %+v", sum)
+	}
+
+	other := openTestStore(t)
+	imported, err := other.ImportTodoTxt(ctx, data, 0)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if imported.Created != len(titles)+2 || imported.Errored != 0 {
+		t.Fatalf("import summary = %+v", imported)
+	}
+	tasks, err := other.ListTasks(ctx, SortUpdated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]Task{}
+	for _, task := range tasks {
+		got[task.Title] = task
+	}
+	for _, title := range titles {
+		task, ok := got[title]
+		if !ok {
+			t.Errorf("title %q not round-tripped; got %v", title, tasks)
+			continue
+		}
+		if task.Status != StatusTodo || task.Important || task.DueAt != 0 || len(task.Tags) != 0 {
+			t.Errorf("title %q parsed as syntax: %+v", title, task)
+		}
+	}
+}
+
+func TestImportTodoTxtDedupsOnlyExistingTasks(t *testing.T) {
+	ctx := context.Background()
+	s := openTestStore(t)
+	groupID := firstGroupID(t, s)
+	createTask(t, s, Task{GroupID: groupID, Title: "existing"})
+
+	sum, err := s.ImportTodoTxt(ctx, []byte("existing\nrepeated\nrepeated\n\n"), groupID)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if sum.Created != 2 || sum.Skipped != 2 {
+		t.Errorf("summary = %+v, want 2 created, 2 skipped (existing task and blank line)", sum)
+	}
+}
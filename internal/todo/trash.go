@@ -0,0 +1,440 @@
+package todo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	sqlitelib "modernc.org/sqlite/lib"
+)
+
+// 回收站条目类型。
+const (
+	TrashKindTask  = "task"
+	TrashKindGroup = "group"
+)
+
+const (
+	// minTrashRetentionDays / maxTrashRetentionDays 为回收站保留天数的取值范围；0 表示永不自动清理。
+	minTrashRetentionDays = 1
+	maxTrashRetentionDays = 3650
+)
+
+// TrashItem 为回收站中的一条记录（删除的任务连同子任务，或删除的分组连同其下任务）。
+type TrashItem struct {
+	ID        int64  `json:"id"`
+	Kind      string `json:"kind"`      // TrashKindTask | TrashKindGroup
+	ItemID    int64  `json:"itemId"`    // 原任务/分组 ID
+	Title     string `json:"title"`     // 任务标题或分组名
+	TaskCount int    `json:"taskCount"` // 条目内包含的任务数（含子任务）
+	DeletedAt int64  `json:"deletedAt"`
+}
+
+// trashPayload 为回收站条目的完整快照（以 JSON 保存在 trash.payload 中），恢复时据此重建。
+//
+// 备注、重复规则等附属数据由 moveToTrash 在删除前读取；旧版本的快照中没有这些字段，恢复时视为空。
+type trashPayload struct {
+	Group *Group `json:"group,omitempty"`
+	Tasks []Task `json:"tasks"` // 顶层任务（含标签），子任务挂在 SubTasks 下；删除子任务时为单个子任务
+
+	Notes        []TaskNote       `json:"notes,omitempty"`
+	Recurrences  []Recurrence     `json:"recurrences,omitempty"`
+	Reminders    []Reminder       `json:"reminders,omitempty"`
+	Dependencies []TaskDependency `json:"dependencies,omitempty"` // 至少一端为快照中任务的依赖
+	Attachments  []Attachment     `json:"attachments,omitempty"`
+	GoalLinks    []trashGoalLink  `json:"goalLinks,omitempty"`
+}
+
+// trashGoalLink 为快照中任务与目标的关联（goal_tasks 中的一行）。
+type trashGoalLink struct {
+	GoalID int64 `json:"goalId"`
+	TaskID int64 `json:"taskId"`
+}
+
+// taskIDs 返回快照中全部任务的 ID（含子任务）。
+func (p trashPayload) taskIDs() []int64 {
+	var ids []int64
+	for _, t := range p.Tasks {
+		ids = append(ids, t.ID)
+		for _, sub := range t.SubTasks {
+			ids = append(ids, sub.ID)
+		}
+	}
+	return ids
+}
+
+// taskCount 统计快照中的任务数（含子任务）。
+func (p trashPayload) taskCount() int {
+	n := 0
+	for _, t := range p.Tasks {
+		n += 1 + len(t.SubTasks)
+	}
+	return n
+}
+
+// normalizeTrashRetention 校验回收站保留天数：0 表示永不自动清理，否则须在 1-3650 之间。
+func normalizeTrashRetention(v string) (string, error) {
+	n, err := strconv.Atoi(strings.TrimSpace(v))
+	if err != nil || (n != 0 && (n < minTrashRetentionDays || n > maxTrashRetentionDays)) {
+		return "", ErrInvalidRetention.with(minTrashRetentionDays, maxTrashRetentionDays)
+	}
+	return strconv.Itoa(n), nil
+}
+
+// moveToTrash 在事务 tx 中写入一条回收站记录；须在删除任务之前调用，以便一并记下任务的标签与附属数据。
+func moveToTrash(ctx context.Context, tx *sql.Tx, kind string, itemID int64, title string, payload trashPayload, now int64) error {
+	if err := snapshotTaskExtras(ctx, tx, &payload); err != nil {
+		return err
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encode trash payload: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO trash(kind, item_id, title, task_count, payload, deleted_at) VALUES(?, ?, ?, ?, ?, ?)`,
+		kind, itemID, title, payload.taskCount(), string(data), now,
+	); err != nil {
+		return fmt.Errorf("insert trash: %w", err)
+	}
+	return nil
+}
+
+// snapshotTaskExtras 通过事务 tx 读取快照中任务的标签、备注、重复规则、提醒、依赖（两个方向）、附件与目标关联，写入 p。
+func snapshotTaskExtras(ctx context.Context, tx *sql.Tx, p *trashPayload) error {
+	ids := p.taskIDs()
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := fillTags(ctx, tx, p.Tasks); err != nil {
+		return err
+	}
+	data, err := json.Marshal(ids)
+	if err != nil {
+		return err
+	}
+	in := string(data)
+
+	if p.Notes, err = queryAll(ctx, tx, scanNote,
+		`SELECT `+noteColumns+` FROM task_notes WHERE task_id IN (SELECT value FROM json_each(?)) ORDER BY id`, in); err != nil {
+		return fmt.Errorf("snapshot notes: %w", err)
+	}
+	if p.Recurrences, err = queryAll(ctx, tx, scanRecurrence,
+		`SELECT `+recurrenceColumns+` FROM task_recurrence WHERE task_id IN (SELECT value FROM json_each(?))`, in); err != nil {
+		return fmt.Errorf("snapshot recurrences: %w", err)
+	}
+	if p.Reminders, err = queryAll(ctx, tx, scanReminder,
+		`SELECT `+reminderColumns+` FROM reminders r JOIN tasks t ON t.id = r.task_id
+		 WHERE r.task_id IN (SELECT value FROM json_each(?)) ORDER BY r.id`, in); err != nil {
+		return fmt.Errorf("snapshot reminders: %w", err)
+	}
+	if p.Dependencies, err = queryAll(ctx, tx, scanDependency,
+		`SELECT task_id, blocked_by_id FROM task_dependencies
+		 WHERE task_id IN (SELECT value FROM json_each(?1)) OR blocked_by_id IN (SELECT value FROM json_each(?1))
+		 ORDER BY task_id, blocked_by_id`, in); err != nil {
+		return fmt.Errorf("snapshot dependencies: %w", err)
+	}
+	if p.Attachments, err = queryAll(ctx, tx, scanAttachment,
+		`SELECT `+attachmentColumns+` FROM attachments WHERE task_id IN (SELECT value FROM json_each(?)) ORDER BY id`, in); err != nil {
+		return fmt.Errorf("snapshot attachments: %w", err)
+	}
+	if p.GoalLinks, err = queryAll(ctx, tx, func(r rowScanner) (trashGoalLink, error) {
+		var l trashGoalLink
+		err := r.Scan(&l.GoalID, &l.TaskID)
+		return l, err
+	}, `SELECT goal_id, task_id FROM goal_tasks WHERE task_id IN (SELECT value FROM json_each(?)) ORDER BY goal_id, task_id`, in); err != nil {
+		return fmt.Errorf("snapshot goal links: %w", err)
+	}
+	return nil
+}
+
+// ListTrash 返回回收站内容，最近删除的在前。
+func (s *Store) ListTrash(ctx context.Context) ([]TrashItem, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, kind, item_id, title, task_count, deleted_at FROM trash ORDER BY deleted_at DESC, id DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("list trash: %w", err)
+	}
+	defer rows.Close()
+
+	items := []TrashItem{}
+	for rows.Next() {
+		var it TrashItem
+		if err := rows.Scan(&it.ID, &it.Kind, &it.ItemID, &it.Title, &it.TaskCount, &it.DeletedAt); err != nil {
+			return nil, fmt.Errorf("scan trash: %w", err)
+		}
+		items = append(items, it)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate trash: %w", err)
+	}
+	return items, nil
+}
+
+// RestoreTrash 将回收站条目恢复到原位置（沿用原 ID），恢复后从回收站移除。
+//
+// - 任务：所属分组必须仍存在；子任务的父任务也必须仍存在
+// - 分组：分组名不能已被占用
+func (s *Store) RestoreTrash(ctx context.Context, id int64) error {
+	var kind, raw string
+	err := s.db.QueryRowContext(ctx, `SELECT kind, payload FROM trash WHERE id = ?`, id).Scan(&kind, &raw)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrTrashItemNotFound.with(id)
+	}
+	if err != nil {
+		return fmt.Errorf("get trash item: %w", err)
+	}
+	var payload trashPayload
+	if err := json.Unmarshal([]byte(raw), &payload); err != nil {
+		return fmt.Errorf("decode trash payload: %w", err)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin restore trash: %w", err)
+	}
+	defer tx.Rollback()
+
+	if kind == TrashKindGroup && payload.Group != nil {
+		g := payload.Group
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO groups(id, name, parent_id, archived, favorite, color, icon, sort_order, created_at, updated_at)
+			 VALUES(?, ?, COALESCE((SELECT id FROM groups WHERE id = ?), 0), ?, ?, ?, ?, COALESCE(NULLIF(?, 0), `+nextGroupOrderSQL+`), ?, ?)`,
+			g.ID, g.Name, g.ParentID, boolTo01Int(g.Archived), boolTo01Int(g.Favorite), g.Color, g.Icon, g.SortOrder, g.CreatedAt, g.UpdatedAt,
+		); err != nil {
+			if sqliteIsConstraint(err, sqlitelib.SQLITE_CONSTRAINT_UNIQUE) {
+				return ErrGroupNameTaken
+			}
+			return fmt.Errorf("restore group: %w", err)
+		}
+	}
+	now := time.Now().UnixMilli()
+	for _, t := range payload.Tasks {
+		if err := restoreTask(ctx, tx, t, now); err != nil {
+			return err
+		}
+		for _, sub := range t.SubTasks {
+			if err := restoreTask(ctx, tx, sub, now); err != nil {
+				return err
+			}
+		}
+	}
+	if err := restoreTaskExtras(ctx, tx, payload, now); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM trash WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("delete trash item: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit restore trash: %w", err)
+	}
+
+	// 恢复的子任务可能改变父任务的完成状态
+	if kind == TrashKindTask && len(payload.Tasks) == 1 && payload.Tasks[0].ParentID > 0 {
+		return s.syncParentStatus(ctx, payload.Tasks[0].ParentID, now)
+	}
+	return nil
+}
+
+// restoreTask 按快照重新插入一条任务及其标签（沿用原 ID，排在手动排序末尾）；已删除的标签重新创建。
+func restoreTask(ctx context.Context, tx *sql.Tx, t Task, now int64) error {
+	var exists int
+	err := tx.QueryRowContext(ctx, `SELECT 1 FROM groups WHERE id = ?`, t.GroupID).Scan(&exists)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrGroupNotFound.with(t.GroupID)
+	}
+	if err != nil {
+		return fmt.Errorf("check group: %w", err)
+	}
+	if t.ParentID > 0 {
+		err := tx.QueryRowContext(ctx, `SELECT 1 FROM tasks WHERE id = ? AND parent_id = 0`, t.ParentID).Scan(&exists)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrParentTaskNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("check parent task: %w", err)
+		}
+	}
+
+	preview, overflow := splitContent(t.Content)
+	// 旧版本的快照没有内容格式
+	if t.ContentFormat == "" {
+		t.ContentFormat = ContentFormatPlain
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO tasks(id, group_id, parent_id, title, content, content_overflow, content_format, status, important, urgent, color, emoji, url, cover_id, countdown, due_at, sort_order, completed_at, created_at, updated_at)
+		 VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, (SELECT COALESCE(MAX(sort_order), 0) + 1 FROM tasks), ?, ?, ?)`,
+		t.ID, t.GroupID, t.ParentID, t.Title, preview, boolTo01Int(overflow), t.ContentFormat, string(t.Status), boolTo01Int(t.Important), boolTo01Int(t.Urgent),
+		t.Color, t.Emoji, t.URL, t.CoverID, boolTo01Int(t.Countdown), t.DueAt, t.CompletedAt, t.CreatedAt, t.UpdatedAt,
+	); err != nil {
+		return fmt.Errorf("restore task: %w", err)
+	}
+	if err := saveContentOverflow(ctx, tx, t.ID, t.Content, overflow); err != nil {
+		return err
+	}
+	for _, name := range t.Tags {
+		if err := addTaskTag(ctx, tx, t.ID, name, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// restoreTaskExtras 按快照恢复任务的附属数据（备注、提醒与附件沿用原 ID）。
+//
+// 已删除的目标不再关联；依赖只在另一端任务仍存在、且不会与删除后新增的依赖形成循环时恢复。
+func restoreTaskExtras(ctx context.Context, tx *sql.Tx, p trashPayload, now int64) error {
+	for _, n := range p.Notes {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO task_notes(id, task_id, body, created_at, updated_at) VALUES(?, ?, ?, ?, ?)`,
+			n.ID, n.TaskID, n.Body, n.CreatedAt, n.UpdatedAt,
+		); err != nil {
+			return fmt.Errorf("restore note: %w", err)
+		}
+	}
+	for _, r := range p.Recurrences {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO task_recurrence(task_id, freq, interval, mode, skip_holidays, lunar, weekdays, created_at, updated_at) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			r.TaskID, r.Freq, r.Interval, r.Mode, boolTo01Int(r.SkipHolidays), boolTo01Int(r.Lunar), formatWeekdays(r.Weekdays), r.CreatedAt, r.UpdatedAt,
+		); err != nil {
+			return fmt.Errorf("restore recurrence: %w", err)
+		}
+	}
+	for _, r := range p.Reminders {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO reminders(id, task_id, remind_at, fired_at, created_at) VALUES(?, ?, ?, ?, ?)`,
+			r.ID, r.TaskID, r.RemindAt, r.FiredAt, r.CreatedAt,
+		); err != nil {
+			return fmt.Errorf("restore reminder: %w", err)
+		}
+	}
+	for _, a := range p.Attachments {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO attachments(id, task_id, file, mime, size, width, height, created_at) VALUES(?, ?, ?, ?, ?, ?, ?, ?)`,
+			a.ID, a.TaskID, a.File, a.Mime, a.Size, a.Width, a.Height, a.CreatedAt,
+		); err != nil {
+			return fmt.Errorf("restore attachment: %w", err)
+		}
+	}
+	for _, l := range p.GoalLinks {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT OR IGNORE INTO goal_tasks(goal_id, task_id) SELECT ?, ? WHERE EXISTS (SELECT 1 FROM goals WHERE id = ?)`,
+			l.GoalID, l.TaskID, l.GoalID,
+		); err != nil {
+			return fmt.Errorf("restore goal link: %w", err)
+		}
+	}
+	for _, d := range p.Dependencies {
+		var n int
+		if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM tasks WHERE id IN (?, ?)`, d.TaskID, d.BlockedByID).Scan(&n); err != nil {
+			return fmt.Errorf("check dependency tasks: %w", err)
+		}
+		if n < 2 {
+			continue
+		}
+		cycle, err := dependencyCreatesCycle(ctx, tx, d.TaskID, d.BlockedByID)
+		if err != nil {
+			return err
+		}
+		if cycle {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT OR IGNORE INTO task_dependencies(task_id, blocked_by_id, created_at) VALUES(?, ?, ?)`,
+			d.TaskID, d.BlockedByID, now,
+		); err != nil {
+			return fmt.Errorf("restore dependency: %w", err)
+		}
+	}
+	return nil
+}
+
+// LatestTrashItem 返回任务或分组 itemID（kind 为 TrashKind*）最近一次删除时生成的回收站条目，
+// 供撤销删除时恢复；回收站中没有对应条目时返回 ErrTrashItemNotFound。
+func (s *Store) LatestTrashItem(ctx context.Context, kind string, itemID int64) (TrashItem, error) {
+	var it TrashItem
+	err := s.db.QueryRowContext(ctx,
+		`SELECT id, kind, item_id, title, task_count, deleted_at FROM trash
+		 WHERE kind = ? AND item_id = ? ORDER BY deleted_at DESC, id DESC LIMIT 1`, kind, itemID,
+	).Scan(&it.ID, &it.Kind, &it.ItemID, &it.Title, &it.TaskCount, &it.DeletedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return TrashItem{}, ErrTrashItemNotFound.with(itemID)
+	}
+	if err != nil {
+		return TrashItem{}, fmt.Errorf("get latest trash item: %w", err)
+	}
+	return it, nil
+}
+
+// DeleteTrashItem 从回收站永久删除一条记录，返回随之不再被引用的附件文件名（由调用方从附件目录删除）。
+func (s *Store) DeleteTrashItem(ctx context.Context, id int64) ([]string, error) {
+	n, files, err := s.purgeTrash(ctx, `trash.id = ?`, id)
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, ErrTrashItemNotFound.with(id)
+	}
+	return files, nil
+}
+
+// PurgeTrash 永久删除 before（UnixMilli）之前进入回收站的记录，返回删除条数与不再被引用的附件文件名；
+// before <= 0 时清空整个回收站。
+func (s *Store) PurgeTrash(ctx context.Context, before int64) (int64, []string, error) {
+	if before > 0 {
+		return s.purgeTrash(ctx, `trash.deleted_at < ?`, before)
+	}
+	return s.purgeTrash(ctx, `1 = 1`)
+}
+
+// trashAttachmentFilesSQL 读取回收站条目快照中附件的文件名（须接 WHERE 条件）。
+const trashAttachmentFilesSQL = `SELECT json_extract(a.value, '$.file') FROM trash, json_each(trash.payload, '$.attachments') a`
+
+// purgeTrash 删除满足 where（列名须带 "trash." 前缀）的回收站记录，返回删除条数与其快照中、已不被任何附件或其它回收站条目引用的附件文件名。
+func (s *Store) purgeTrash(ctx context.Context, where string, args ...any) (int64, []string, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, nil, fmt.Errorf("begin purge trash: %w", err)
+	}
+	defer tx.Rollback()
+
+	scanFile := func(r rowScanner) (string, error) {
+		var f sql.NullString
+		return f.String, r.Scan(&f)
+	}
+	files, err := queryAll(ctx, tx, scanFile, trashAttachmentFilesSQL+` WHERE `+where, args...)
+	if err != nil {
+		return 0, nil, fmt.Errorf("list purged attachments: %w", err)
+	}
+	res, err := tx.ExecContext(ctx, `DELETE FROM trash WHERE `+where, args...)
+	if err != nil {
+		return 0, nil, fmt.Errorf("purge trash: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, nil, fmt.Errorf("purge trash rows affected: %w", err)
+	}
+	var orphans []string
+	if len(files) > 0 {
+		// 同一文件可能仍被恢复后的任务或另一条回收站记录引用
+		used, err := queryAll(ctx, tx, scanFile, `SELECT file FROM attachments UNION `+trashAttachmentFilesSQL)
+		if err != nil {
+			return 0, nil, fmt.Errorf("list used attachments: %w", err)
+		}
+		for _, f := range files {
+			if f != "" && !slices.Contains(used, f) && !slices.Contains(orphans, f) {
+				orphans = append(orphans, f)
+			}
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, nil, fmt.Errorf("commit purge trash: %w", err)
+	}
+	return n, orphans, nil
+}
@@ -0,0 +1,178 @@
+package todo
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRestoreTrashKeepsTaskExtras(t *testing.T) {
+	ctx := context.Background()
+	s := openTestStore(t)
+	groupID := firstGroupID(t, s)
+
+	content := strings.Repeat("内容", 800)
+	task := createTask(t, s, Task{GroupID: groupID, Title: "task", Content: content})
+	sub := createTask(t, s, Task{GroupID: groupID, ParentID: task.ID, Title: "sub"})
+	before := createTask(t, s, Task{GroupID: groupID, Title: "before"})
+	after := createTask(t, s, Task{GroupID: groupID, Title: "after"})
+
+	if _, err := s.SetTaskTags(ctx, task.ID, []string{"work"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.SetTaskTags(ctx, sub.ID, []string{"later"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.AddTaskNote(ctx, task.ID, "a note"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.SetRecurrence(ctx, Recurrence{TaskID: task.ID, Freq: RecurWeekly, Weekdays: []int{2}}); err != nil {
+		t.Fatal(err)
+	}
+	remindAt := time.Now().Add(time.Hour).Truncate(time.Millisecond)
+	if _, err := s.SetTaskReminder(ctx, task.ID, remindAt); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddTaskDependency(ctx, task.ID, before.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddTaskDependency(ctx, after.ID, task.ID); err != nil {
+		t.Fatal(err)
+	}
+	att, err := s.AddAttachment(ctx, Attachment{TaskID: task.ID, File: "cover.png", Mime: "image/png", Size: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.SetTaskCover(ctx, task.ID, att.ID); err != nil {
+		t.Fatal(err)
+	}
+	goal, err := s.UpsertGoal(ctx, Goal{Title: "goal"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.LinkGoalTask(ctx, goal.ID, task.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.DeleteTask(ctx, task.ID); err != nil {
+		t.Fatalf("delete task: %v", err)
+	}
+	item, err := s.LatestTrashItem(ctx, TrashKindTask, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RestoreTrash(ctx, item.ID); err != nil {
+		t.Fatalf("restore task: %v", err)
+	}
+
+	got, err := s.GetTask(ctx, task.ID)
+	if err != nil || got.CoverID != att.ID {
+		t.Errorf("restored task = %+v, %v; want cover %d", got, err, att.ID)
+	}
+	if full, err := s.GetTaskContent(ctx, task.ID); err != nil || full != content {
+		t.Errorf("full content lost: %d runes, %v", len([]rune(full)), err)
+	}
+	tasks := []Task{got, {ID: sub.ID}}
+	if err := s.FillTags(ctx, tasks); err != nil || !slices.Equal(tasks[0].Tags, []string{"work"}) || !slices.Equal(tasks[1].Tags, []string{"later"}) {
+		t.Errorf("tags = %v / %v, %v", tasks[0].Tags, tasks[1].Tags, err)
+	}
+	if notes, err := s.GetTaskNotes(ctx, task.ID); err != nil || len(notes) != 1 || notes[0].Body != "a note" {
+		t.Errorf("notes = %+v, %v", notes, err)
+	}
+	if rec, err := s.GetRecurrence(ctx, task.ID); err != nil || rec == nil || !slices.Equal(rec.Weekdays, []int{2}) {
+		t.Errorf("recurrence = %+v, %v", rec, err)
+	}
+	if r, err := s.GetTaskReminder(ctx, task.ID); err != nil || r.RemindAt != remindAt.UnixMilli() {
+		t.Errorf("reminder = %+v, %v", r, err)
+	}
+	if deps, err := s.GetTaskDependencies(ctx, task.ID); err != nil || len(deps.BlockedBy) != 1 || deps.BlockedBy[0].ID != before.ID ||
+		len(deps.Blocking) != 1 || deps.Blocking[0].ID != after.ID {
+		t.Errorf("dependencies = %+v, %v", deps, err)
+	}
+	if atts, err := s.ListAttachments(ctx, task.ID); err != nil || len(atts) != 1 || atts[0].ID != att.ID {
+		t.Errorf("attachments = %+v, %v", atts, err)
+	}
+	if g, err := s.GetGoal(ctx, goal.ID); err != nil || !slices.Equal(g.TaskIDs, []int64{task.ID}) {
+		t.Errorf("goal tasks = %v, %v", g.TaskIDs, err)
+	}
+}
+
+func TestRestoreTrashSkipsDependencyCycle(t *testing.T) {
+	ctx := context.Background()
+	s := openTestStore(t)
+	groupID := firstGroupID(t, s)
+	a := createTask(t, s, Task{GroupID: groupID, Title: "a"})
+	b := createTask(t, s, Task{GroupID: groupID, Title: "b"})
+	task := createTask(t, s, Task{GroupID: groupID, Title: "task"})
+
+	// task 被 a 阻塞、b 被 task 阻塞；删除 task 后再让 a 被 b 阻塞，恢复两条旧依赖会成环
+	if err := s.AddTaskDependency(ctx, task.ID, a.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddTaskDependency(ctx, b.ID, task.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.DeleteTask(ctx, task.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddTaskDependency(ctx, a.ID, b.ID); err != nil {
+		t.Fatal(err)
+	}
+	item, err := s.LatestTrashItem(ctx, TrashKindTask, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RestoreTrash(ctx, item.ID); err != nil {
+		t.Fatalf("restore task: %v", err)
+	}
+	deps, err := s.GetTaskDependencies(ctx, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(deps.BlockedBy) + len(deps.Blocking); n != 1 {
+		t.Errorf("restored %d dependencies, want 1 (the other would close a cycle): %+v", n, deps)
+	}
+}
+
+func TestPurgeTrashReturnsUnreferencedAttachments(t *testing.T) {
+	ctx := context.Background()
+	s := openTestStore(t)
+	groupID := firstGroupID(t, s)
+	a := createTask(t, s, Task{GroupID: groupID, Title: "a"})
+	b := createTask(t, s, Task{GroupID: groupID, Title: "b"})
+	for _, att := range []Attachment{
+		{TaskID: a.ID, File: "only-a.png"},
+		{TaskID: a.ID, File: "shared.png"},
+		{TaskID: b.ID, File: "shared.png"},
+	} {
+		if _, err := s.AddAttachment(ctx, att); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.DeleteTask(ctx, a.ID); err != nil {
+		t.Fatal(err)
+	}
+	item, err := s.LatestTrashItem(ctx, TrashKindTask, a.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// shared.png 仍被任务 b 使用，不能删除
+	files, err := s.DeleteTrashItem(ctx, item.ID)
+	if err != nil {
+		t.Fatalf("delete trash item: %v", err)
+	}
+	if !slices.Equal(files, []string{"only-a.png"}) {
+		t.Errorf("orphan files = %v, want [only-a.png]", files)
+	}
+
+	if err := s.DeleteTask(ctx, b.ID); err != nil {
+		t.Fatal(err)
+	}
+	n, files, err := s.PurgeTrash(ctx, 0)
+	if err != nil || n != 1 || !slices.Equal(files, []string{"shared.png"}) {
+		t.Errorf("purge = %d, %v, %v; want 1 item and [shared.png]", n, files, err)
+	}
+}
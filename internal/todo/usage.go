@@ -0,0 +1,67 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+)
+
+// UsageStats 为数据库内的数据量统计，用于设置页"存储"一栏。
+type UsageStats struct {
+	Groups        int64 `json:"groups"`
+	Tasks         int64 `json:"tasks"`         // 顶层任务数
+	SubTasks      int64 `json:"subTasks"`      // 子任务数
+	DoneTasks     int64 `json:"doneTasks"`     // 已完成任务数（含子任务）
+	DatabaseBytes int64 `json:"databaseBytes"` // 数据库页占用（page_count * page_size）
+	FreeBytes     int64 `json:"freeBytes"`     // 空闲页占用（可通过 VACUUM 回收）
+	OldestTaskAt  int64 `json:"oldestTaskAt"`  // 最早创建任务的时间（UnixMilli）；没有任务时为 0
+	NewestTaskAt  int64 `json:"newestTaskAt"`  // 最近创建任务的时间（UnixMilli）；没有任务时为 0
+	OldestDoneAt  int64 `json:"oldestDoneAt"`  // 最早完成任务的完成时间；没有已完成任务时为 0
+	Goals         int64 `json:"goals"`         // 目标数
+	GoalsAchieved int64 `json:"goalsAchieved"` // 进度达到 100% 的目标数
+}
+
+// GetUsageStats 统计分组/任务/目标数量、数据库占用与最早/最新记录时间。
+func (s *Store) GetUsageStats(ctx context.Context) (UsageStats, error) {
+	var u UsageStats
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM groups`).Scan(&u.Groups); err != nil {
+		return UsageStats{}, fmt.Errorf("count groups: %w", err)
+	}
+	err := s.db.QueryRowContext(ctx, `
+		SELECT
+			COALESCE(SUM(CASE WHEN parent_id = 0 THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN parent_id <> 0 THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN status = ? THEN 1 ELSE 0 END), 0),
+			COALESCE(MIN(created_at), 0),
+			COALESCE(MAX(created_at), 0),
+			COALESCE(MIN(CASE WHEN completed_at > 0 THEN completed_at END), 0)
+		FROM tasks`, string(StatusDone),
+	).Scan(&u.Tasks, &u.SubTasks, &u.DoneTasks, &u.OldestTaskAt, &u.NewestTaskAt, &u.OldestDoneAt)
+	if err != nil {
+		return UsageStats{}, fmt.Errorf("count tasks: %w", err)
+	}
+
+	goals, err := s.ListGoals(ctx)
+	if err != nil {
+		return UsageStats{}, err
+	}
+	u.Goals = int64(len(goals))
+	for _, g := range goals {
+		if g.Target > 0 && g.Percent >= 100 {
+			u.GoalsAchieved++
+		}
+	}
+
+	var pageSize, pageCount, freeCount int64
+	if err := s.db.QueryRowContext(ctx, `PRAGMA page_size`).Scan(&pageSize); err != nil {
+		return UsageStats{}, fmt.Errorf("read page_size: %w", err)
+	}
+	if err := s.db.QueryRowContext(ctx, `PRAGMA page_count`).Scan(&pageCount); err != nil {
+		return UsageStats{}, fmt.Errorf("read page_count: %w", err)
+	}
+	if err := s.db.QueryRowContext(ctx, `PRAGMA freelist_count`).Scan(&freeCount); err != nil {
+		return UsageStats{}, fmt.Errorf("read freelist_count: %w", err)
+	}
+	u.DatabaseBytes = pageSize * pageCount
+	u.FreeBytes = pageSize * freeCount
+	return u, nil
+}
@@ -0,0 +1,383 @@
+package todo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// 四象限标识（按艾森豪威尔矩阵的常见编号）。
+const (
+	QuadrantDoFirst  = "q1" // 重要且紧急
+	QuadrantSchedule = "q2" // 重要不紧急
+	QuadrantDelegate = "q3" // 不重要但紧急
+	QuadrantDrop     = "q4" // 不重要不紧急
+)
+
+// Quadrant 为四象限视图中的一个象限。
+type Quadrant struct {
+	Key       string `json:"key"` // QuadrantDoFirst ... QuadrantDrop
+	Important bool   `json:"important"`
+	Urgent    bool   `json:"urgent"`
+	Count     int    `json:"count"`     // 该象限主任务总数（含已完成）
+	OpenCount int    `json:"openCount"` // 未完成主任务数
+	Tasks     []Task `json:"tasks"`
+}
+
+// newQuadrants 返回按 q1..q4 排列的空象限。
+func newQuadrants() []Quadrant {
+	return []Quadrant{
+		{Key: QuadrantDoFirst, Important: true, Urgent: true, Tasks: []Task{}},
+		{Key: QuadrantSchedule, Important: true, Urgent: false, Tasks: []Task{}},
+		{Key: QuadrantDelegate, Important: false, Urgent: true, Tasks: []Task{}},
+		{Key: QuadrantDrop, Important: false, Urgent: false, Tasks: []Task{}},
+	}
+}
+
+// quadrantIndex 返回 important/urgent 对应的象限下标（与 newQuadrants 顺序一致）。
+func quadrantIndex(important, urgent bool) int {
+	switch {
+	case important && urgent:
+		return 0
+	case important:
+		return 1
+	case urgent:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// statusOrderSQL 为视图内的状态排序：进行中在前，其次待办，已完成垫底。
+const statusOrderSQL = `CASE status WHEN 'doing' THEN 0 WHEN 'todo' THEN 1 ELSE 2 END`
+
+// ListQuadrants 返回按四象限划分好的主任务（子任务挂在 SubTasks 下）。
+//
+// 划分、计数与排序都在 SQL 中完成，前端无需每次渲染都遍历全部任务：
+// - 象限内按 sort 排序；空字符串为 SortQuadrant（进行中 > 待办 > 已完成，再按更新时间倒序）
+// - hideDone 为 true 时不返回已完成任务，但 Count 仍统计全部任务
+// - 已归档分组下的任务不参与划分与计数
+func (s *Store) ListQuadrants(ctx context.Context, hideDone bool, sort string) ([]Quadrant, error) {
+	if sort == "" {
+		sort = SortQuadrant
+	}
+	sort, err := ParseSortMode(sort)
+	if err != nil {
+		return nil, err
+	}
+	quadrants := newQuadrants()
+
+	counts, err := s.db.QueryContext(ctx,
+		`SELECT important, urgent, COUNT(*), SUM(CASE WHEN status <> 'done' THEN 1 ELSE 0 END)
+		 FROM tasks WHERE parent_id = 0 AND `+activeGroupSQL+` GROUP BY important, urgent`)
+	if err != nil {
+		return nil, fmt.Errorf("count quadrants: %w", err)
+	}
+	defer counts.Close()
+	for counts.Next() {
+		var importantInt, urgentInt, total, open int
+		if err := counts.Scan(&importantInt, &urgentInt, &total, &open); err != nil {
+			return nil, fmt.Errorf("scan quadrant count: %w", err)
+		}
+		q := &quadrants[quadrantIndex(importantInt == 1, urgentInt == 1)]
+		q.Count, q.OpenCount = total, open
+	}
+	if err := counts.Err(); err != nil {
+		return nil, fmt.Errorf("iterate quadrant counts: %w", err)
+	}
+
+	where := `parent_id = 0 AND ` + activeGroupSQL
+	if hideDone {
+		where += ` AND status <> 'done'`
+	}
+	tasks, err := s.queryTasks(ctx,
+		`SELECT `+taskColumns+` FROM tasks WHERE `+where+`
+		 ORDER BY important DESC, urgent DESC, `+sortOrderSQL[sort])
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attachSubTasks(ctx, tasks); err != nil {
+		return nil, err
+	}
+	for _, t := range tasks {
+		q := &quadrants[quadrantIndex(t.Important, t.Urgent)]
+		q.Tasks = append(q.Tasks, t)
+	}
+	return quadrants, nil
+}
+
+// SetQuadrant 在单个事务中把 ids 对应的任务移到 important/urgent 指定的象限，返回更新后的任务（按 ids 顺序，已去重）。
+//
+// 任一任务不存在时整体失败，不做部分修改。
+func (s *Store) SetQuadrant(ctx context.Context, ids []int64, important, urgent bool) ([]Task, error) {
+	unique, err := uniqueTaskIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	if len(unique) == 0 {
+		return []Task{}, nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin set quadrant: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UnixMilli()
+	for _, id := range unique {
+		res, err := tx.ExecContext(ctx,
+			`UPDATE tasks SET important = ?, urgent = ?, updated_at = ? WHERE id = ?`,
+			boolTo01Int(important), boolTo01Int(urgent), now, id,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("set quadrant: %w", err)
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return nil, fmt.Errorf("set quadrant rows affected: %w", err)
+		}
+		if affected == 0 {
+			return nil, ErrTaskNotFound.with(id)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit set quadrant: %w", err)
+	}
+
+	return s.reloadTasks(ctx, unique)
+}
+
+// ReorderTasks 在单个事务中按 orderedIDs 重排分组 groupID 的顶层任务（拖动排序，SortManual 按此顺序显示）。
+//
+// orderedIDs 中重复的 ID 只取第一次出现；未列出的任务保持原有相对顺序排在最后。
+// 分组原有的 sort_order 取值按新顺序重新分配，因此不影响与其它分组任务的相对位置，也不修改 updated_at。
+// 任一 ID 不是该分组的顶层任务时整体失败，不做部分修改。
+func (s *Store) ReorderTasks(ctx context.Context, groupID int64, orderedIDs []int64) error {
+	if groupID <= 0 {
+		return ErrInvalidGroupID
+	}
+	if ok, err := s.groupExists(ctx, groupID); err != nil {
+		return err
+	} else if !ok {
+		return ErrGroupNotFound.with(groupID)
+	}
+
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, sort_order FROM tasks WHERE group_id = ? AND parent_id = 0 ORDER BY sort_order, id`, groupID)
+	if err != nil {
+		return fmt.Errorf("list task order: %w", err)
+	}
+	var current []int64
+	var slots []int64
+	for rows.Next() {
+		var id, order int64
+		if err := rows.Scan(&id, &order); err != nil {
+			rows.Close()
+			return fmt.Errorf("scan task order: %w", err)
+		}
+		current = append(current, id)
+		slots = append(slots, order)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate task order: %w", err)
+	}
+
+	order := make([]int64, 0, len(current))
+	for _, id := range orderedIDs {
+		if id <= 0 {
+			return ErrInvalidTaskID
+		}
+		if !slices.Contains(current, id) {
+			return ErrTaskNotInGroup.with(id, groupID)
+		}
+		if !slices.Contains(order, id) {
+			order = append(order, id)
+		}
+	}
+	for _, id := range current {
+		if !slices.Contains(order, id) {
+			order = append(order, id)
+		}
+	}
+	// 旧数据中可能存在相同的 sort_order，保证重新分配后严格递增
+	for i := 1; i < len(slots); i++ {
+		slots[i] = max(slots[i], slots[i-1]+1)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin reorder tasks: %w", err)
+	}
+	defer tx.Rollback()
+
+	for i, id := range order {
+		if _, err := tx.ExecContext(ctx, `UPDATE tasks SET sort_order = ? WHERE id = ?`, slots[i], id); err != nil {
+			return fmt.Errorf("reorder tasks: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit reorder tasks: %w", err)
+	}
+	return nil
+}
+
+// ReorderGroups 在单个事务中按 orderedIDs 重排分组（侧边栏拖动排序），返回重排后的分组列表。
+//
+// orderedIDs 中重复的 ID 只取第一次出现；未列出的分组（如已归档的分组）保持原有相对顺序排在最后。
+// 常用分组仍排在最前（见 ListGroups），手动顺序只在常用/非常用分组内部生效。
+// 任一分组不存在时整体失败，不做部分修改。
+func (s *Store) ReorderGroups(ctx context.Context, orderedIDs []int64) ([]Group, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT id FROM groups ORDER BY sort_order, id`)
+	if err != nil {
+		return nil, fmt.Errorf("list group order: %w", err)
+	}
+	var current []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan group order: %w", err)
+		}
+		current = append(current, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate group order: %w", err)
+	}
+
+	order := make([]int64, 0, len(current))
+	for _, id := range orderedIDs {
+		if id <= 0 {
+			return nil, ErrInvalidGroupID
+		}
+		if !slices.Contains(current, id) {
+			return nil, ErrGroupNotFound.with(id)
+		}
+		if !slices.Contains(order, id) {
+			order = append(order, id)
+		}
+	}
+	for _, id := range current {
+		if !slices.Contains(order, id) {
+			order = append(order, id)
+		}
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin reorder groups: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 只改动位置发生变化的分组，避免无谓地触发看板增量刷新
+	for i, id := range order {
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE groups SET sort_order = ? WHERE id = ? AND sort_order <> ?`, i+1, id, i+1,
+		); err != nil {
+			return nil, fmt.Errorf("reorder groups: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit reorder groups: %w", err)
+	}
+	return s.ListGroups(ctx)
+}
+
+// queryTasks 执行返回 taskColumns 的查询并读取全部任务。
+func (s *Store) queryTasks(ctx context.Context, query string, args ...any) ([]Task, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query tasks: %w", err)
+	}
+	defer rows.Close()
+
+	tasks := []Task{}
+	for rows.Next() {
+		t, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate tasks: %w", err)
+	}
+	return tasks, nil
+}
+
+// subTasksSQL 查询一批父任务（以 JSON 数组传入 id）的全部子任务。
+const subTasksSQL = `SELECT ` + taskColumns + ` FROM tasks WHERE parent_id IN (SELECT value FROM json_each(?))
+	ORDER BY updated_at DESC, id DESC`
+
+// attachSubTasks 为 tasks 中的主任务挂载子任务（与 ListTasks 相同，按更新时间倒序），并计算清单进度。
+func (s *Store) attachSubTasks(ctx context.Context, tasks []Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	ids := make([]int64, len(tasks))
+	index := make(map[int64]int, len(tasks))
+	for i := range tasks {
+		ids[i] = tasks[i].ID
+		index[tasks[i].ID] = i
+		tasks[i].SubTasks = []Task{}
+	}
+
+	// 主任务可能上万条：以单个 JSON 数组参数传入 id，避免拼接超长 IN (?, ?, ...) 带来的解析开销
+	// 与 SQLite 变量个数上限
+	raw, err := json.Marshal(ids)
+	if err != nil {
+		return err
+	}
+	subs, err := s.queryTasks(ctx, subTasksSQL, string(raw))
+	if err != nil {
+		return err
+	}
+	for _, sub := range subs {
+		if i, ok := index[sub.ParentID]; ok {
+			tasks[i].SubTasks = append(tasks[i].SubTasks, sub)
+		}
+	}
+	fillProgress(tasks)
+	return nil
+}
+
+// fillProgress 根据已挂载的 SubTasks 计算 tasks 中各父任务的 Progress。
+func fillProgress(tasks []Task) {
+	for i := range tasks {
+		t := &tasks[i]
+		t.Progress = nil
+		if len(t.SubTasks) == 0 {
+			continue
+		}
+		p := &Progress{Total: len(t.SubTasks)}
+		for _, sub := range t.SubTasks {
+			if sub.Status == StatusDone {
+				p.Done++
+			}
+		}
+		p.Percent = p.Done * 100 / p.Total
+		t.Progress = p
+	}
+}
+
+// prefixColumns 为逗号分隔的列名加上表别名前缀（用于 JOIN 查询）。
+func prefixColumns(alias string, columns string) string {
+	parts := strings.Split(columns, ",")
+	for i, p := range parts {
+		parts[i] = alias + "." + strings.TrimSpace(p)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// placeholders 返回 n 个以逗号分隔的 "?"，用于 IN (...) 查询。
+func placeholders(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	return strings.Repeat("?,", n-1) + "?"
+}
@@ -0,0 +1,179 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ReportTask 为报表中的一条任务（已按用户设置格式化好日期，模板直接输出即可）。
+type ReportTask struct {
+	ID        int64  `json:"id"`
+	Title     string `json:"title"`
+	Group     string `json:"group"`
+	Status    Status `json:"status"`
+	Important bool   `json:"important"`
+	Urgent    bool   `json:"urgent"`
+	Due       string `json:"due"`       // 截止时间；未设置为空
+	Completed string `json:"completed"` // 完成时间；未完成为空
+	SubTasks  int    `json:"subTasks"`  // 子任务总数
+	SubDone   int    `json:"subDone"`   // 已完成子任务数
+	// Children 为子任务明细（仅看板导出填充，周报只显示进度）
+	Children []ReportTask `json:"children,omitempty"`
+}
+
+// WeeklyReport 为周报数据。
+type WeeklyReport struct {
+	WeekStart   string       `json:"weekStart"`   // 本周第一天（YYYY-MM-DD）
+	WeekEnd     string       `json:"weekEnd"`     // 本周最后一天（YYYY-MM-DD）
+	Title       string       `json:"title"`       // 按日期格式设置格式化的区间，如 "2024-03-04 ~ 2024-03-10"
+	Completed   []ReportTask `json:"completed"`   // 本周完成的主任务
+	InProgress  []ReportTask `json:"inProgress"`  // 进行中的主任务
+	CarriedOver []ReportTask `json:"carriedOver"` // 截止时间不晚于本周、但仍未完成的待办主任务（结转到下周）
+	Created     int          `json:"created"`     // 本周新建的主任务数
+	// TrackedMinutes 为本周计时器与番茄钟记录的总时长；Tracked 为各任务的记录时长（按时长降序）
+	TrackedMinutes int             `json:"trackedMinutes"`
+	Tracked        []TimesheetTask `json:"tracked"`
+	GeneratedAt    string          `json:"generatedAt"`
+}
+
+// GetWeeklyReport 汇总 weekStart（YYYY-MM-DD，为空表示本周）所在周的周报数据。
+//
+// 周的起止按时区与每周第一天设置计算；只统计主任务，子任务以完成进度的形式附在主任务上。
+// 时间记录（计时器与番茄钟）按任务汇总，跨周的记录只计入本周内的部分，进行中的计时按 now 截止。
+func (s *Store) GetWeeklyReport(ctx context.Context, weekStart string, now time.Time, settings Settings) (WeeklyReport, error) {
+	loc := Location(settings)
+	day := StartOfDay(now, loc)
+	if weekStart = strings.TrimSpace(weekStart); weekStart != "" {
+		parsed, err := time.ParseInLocation(dayKeyLayout, weekStart, loc)
+		if err != nil {
+			return WeeklyReport{}, ErrInvalidDate.with(weekStart)
+		}
+		day = parsed
+	}
+	start := StartOfWeek(day, settings)
+	end := start.AddDate(0, 0, 7)
+	ws, we := start.UnixMilli(), end.UnixMilli()
+
+	groups, err := s.ListGroups(ctx)
+	if err != nil {
+		return WeeklyReport{}, err
+	}
+	groupNames := make(map[int64]string, len(groups))
+	for _, g := range groups {
+		groupNames[g.ID] = g.Name
+	}
+
+	completed, err := s.queryTasks(ctx,
+		`SELECT `+taskColumns+` FROM tasks
+		 WHERE parent_id = 0 AND status = 'done' AND completed_at >= ? AND completed_at < ?
+		 ORDER BY completed_at, id`, ws, we)
+	if err != nil {
+		return WeeklyReport{}, err
+	}
+	inProgress, err := s.queryTasks(ctx,
+		`SELECT `+taskColumns+` FROM tasks
+		 WHERE parent_id = 0 AND status = 'doing' AND created_at < ?
+		 ORDER BY updated_at DESC, id DESC`, we)
+	if err != nil {
+		return WeeklyReport{}, err
+	}
+	carried, err := s.queryTasks(ctx,
+		`SELECT `+taskColumns+` FROM tasks
+		 WHERE parent_id = 0 AND status = 'todo' AND due_at > 0 AND due_at < ?
+		 ORDER BY due_at, id`, we)
+	if err != nil {
+		return WeeklyReport{}, err
+	}
+
+	var created int
+	if err := s.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM tasks WHERE parent_id = 0 AND created_at >= ? AND created_at < ?`, ws, we,
+	).Scan(&created); err != nil {
+		return WeeklyReport{}, fmt.Errorf("count created tasks: %w", err)
+	}
+
+	entries, err := s.listTimeEntries(ctx, ws, we, now.UnixMilli())
+	if err != nil {
+		return WeeklyReport{}, err
+	}
+	tracked, trackedMs := trackedByTask(entries)
+
+	all := [][]Task{completed, inProgress, carried}
+	for _, list := range all {
+		if err := s.attachSubTasks(ctx, list); err != nil {
+			return WeeklyReport{}, err
+		}
+	}
+
+	last := end.AddDate(0, 0, -1)
+	return WeeklyReport{
+		WeekStart:      start.Format(dayKeyLayout),
+		WeekEnd:        last.Format(dayKeyLayout),
+		Title:          FormatDate(start, settings) + " ~ " + FormatDate(last, settings),
+		Completed:      toReportTasks(completed, groupNames, settings),
+		InProgress:     toReportTasks(inProgress, groupNames, settings),
+		CarriedOver:    toReportTasks(carried, groupNames, settings),
+		Created:        created,
+		TrackedMinutes: int(trackedMs / time.Minute.Milliseconds()),
+		Tracked:        tracked,
+		GeneratedAt:    FormatDateTime(now.In(loc), settings),
+	}, nil
+}
+
+// trackedByTask 按任务汇总时间记录（已裁剪到统计范围内），返回按时长降序的列表与总毫秒数。
+func trackedByTask(entries []timeEntry) ([]TimesheetTask, int64) {
+	perTask := map[int64]int64{}
+	var order []timeEntry
+	var total int64
+	for _, e := range entries {
+		if _, ok := perTask[e.TaskID]; !ok {
+			order = append(order, e)
+		}
+		perTask[e.TaskID] += e.EndedAt - e.StartedAt
+		total += e.EndedAt - e.StartedAt
+	}
+	out := make([]TimesheetTask, 0, len(order))
+	for _, e := range order {
+		out = append(out, TimesheetTask{
+			TaskID:  e.TaskID,
+			Title:   e.TaskTitle,
+			Group:   e.GroupName,
+			Minutes: int(perTask[e.TaskID] / time.Minute.Milliseconds()),
+		})
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Minutes > out[j].Minutes })
+	return out, total
+}
+
+// toReportTasks 将任务转为报表行，日期按用户设置格式化。
+func toReportTasks(tasks []Task, groupNames map[int64]string, settings Settings) []ReportTask {
+	loc := Location(settings)
+	out := make([]ReportTask, 0, len(tasks))
+	for _, t := range tasks {
+		rt := ReportTask{
+			ID:        t.ID,
+			Title:     t.Title,
+			Group:     groupNames[t.GroupID],
+			Status:    t.Status,
+			Important: t.Important,
+			Urgent:    t.Urgent,
+			SubTasks:  len(t.SubTasks),
+		}
+		if t.DueAt > 0 {
+			rt.Due = FormatDateTime(time.UnixMilli(t.DueAt).In(loc), settings)
+		}
+		if t.CompletedAt > 0 {
+			rt.Completed = FormatDateTime(time.UnixMilli(t.CompletedAt).In(loc), settings)
+		}
+		for _, sub := range t.SubTasks {
+			if sub.Status == StatusDone {
+				rt.SubDone++
+			}
+		}
+		out = append(out, rt)
+	}
+	return out
+}
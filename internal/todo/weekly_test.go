@@ -0,0 +1,48 @@
+package todo
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWeeklyReportIncludesTrackedTime(t *testing.T) {
+	ctx := context.Background()
+	s := openTestStore(t)
+	groupID := firstGroupID(t, s)
+	settings, err := s.GetSettings(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loc := Location(settings)
+	weekStart := StartOfWeek(StartOfDay(time.Now(), loc), settings)
+
+	short := createTask(t, s, Task{GroupID: groupID, Title: "short"})
+	long := createTask(t, s, Task{GroupID: groupID, Title: "long"})
+	track := func(taskID int64, from time.Time, d time.Duration) {
+		t.Helper()
+		if _, err := s.StartTimer(ctx, taskID, from); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := s.StopTimer(ctx, from.Add(d)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 跨周的记录只计入本周内的 30 分钟
+	track(long.ID, weekStart.Add(-30*time.Minute), time.Hour)
+	track(long.ID, weekStart.Add(2*time.Hour), 90*time.Minute)
+	track(short.ID, weekStart.Add(5*time.Hour), 15*time.Minute)
+	// 下一周的记录不计入
+	track(short.ID, weekStart.AddDate(0, 0, 7).Add(time.Hour), time.Hour)
+
+	r, err := s.GetWeeklyReport(ctx, weekStart.Format(dayKeyLayout), weekStart.Add(24*time.Hour), settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.TrackedMinutes != 135 {
+		t.Errorf("tracked minutes = %d, want 135", r.TrackedMinutes)
+	}
+	if len(r.Tracked) != 2 || r.Tracked[0].TaskID != long.ID || r.Tracked[0].Minutes != 120 || r.Tracked[1].Minutes != 15 {
+		t.Errorf("tracked = %+v, want long 120 then short 15", r.Tracked)
+	}
+}
@@ -0,0 +1,60 @@
+package version
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// 构建信息，由发布脚本在构建时通过 ldflags 注入，例如：
+//
+//	wails build -ldflags "-X spark-todo/internal/version.Commit=$(git rev-parse --short HEAD) \
+//	  -X spark-todo/internal/version.BuildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ) \
+//	  -X spark-todo/internal/version.Builder=$(whoami)"
+//
+// 未注入时为空；Commit 会尝试回退到 Go 工具链记录的 vcs 信息。
+var (
+	Commit    = ""
+	BuildDate = ""
+	Builder   = ""
+)
+
+// BuildInfo 描述当前运行的构建，便于在问题反馈中准确定位版本。
+type BuildInfo struct {
+	Version   string `json:"version"`   // 应用版本号
+	Commit    string `json:"commit"`    // 构建所用的提交哈希
+	Dirty     bool   `json:"dirty"`     // 构建时工作区是否有未提交修改（仅在 vcs 信息可用时有效）
+	BuildDate string `json:"buildDate"` // 构建时间（UTC, RFC3339）
+	Builder   string `json:"builder"`   // 构建者/构建机器
+	GoVersion string `json:"goVersion"` // Go 版本
+	Platform  string `json:"platform"`  // GOOS/GOARCH
+}
+
+// GetBuildInfo 汇总 ldflags 注入的信息与 Go 运行时信息。
+func GetBuildInfo() BuildInfo {
+	info := BuildInfo{
+		Version:   Version,
+		Commit:    Commit,
+		BuildDate: BuildDate,
+		Builder:   Builder,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, s := range bi.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = s.Value
+				}
+			case "vcs.time":
+				if info.BuildDate == "" {
+					info.BuildDate = s.Value
+				}
+			case "vcs.modified":
+				info.Dirty = s.Value == "true"
+			}
+		}
+	}
+	return info
+}
@@ -0,0 +1,185 @@
+package version
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// changelogCacheTTL 为版本列表缓存有效期：发布说明极少变化，避免反复请求 API。
+const changelogCacheTTL = time.Hour
+
+// changelogCache 缓存某个更新源的全部发布信息。
+type changelogCache struct {
+	mu        sync.Mutex
+	url       string
+	fetchedAt time.Time
+	releases  []ReleaseInfo
+}
+
+// changelogPageSize 为每页请求的发布数（GitHub 上限 100）；changelogMaxPages 限制翻页次数，
+// 即最多读取最近 changelogPageSize*changelogMaxPages 个发布。
+const (
+	changelogPageSize = 100
+	changelogMaxPages = 10
+)
+
+// releasesURL 由 UpdateURL 推导"全部发布列表"的地址：
+// GitHub 的 /releases/latest 对应列表接口为 /releases；自定义地址原样使用。
+// 没有指定 per_page 时按 changelogPageSize 请求，减少翻页次数。
+func (uc *UpdateChecker) releasesURL() string {
+	listURL := strings.TrimSuffix(strings.TrimRight(uc.UpdateURL, "/"), "/latest")
+	u, err := url.Parse(listURL)
+	if err != nil {
+		return listURL
+	}
+	q := u.Query()
+	if q.Get("per_page") == "" {
+		q.Set("per_page", strconv.Itoa(changelogPageSize))
+		u.RawQuery = q.Encode()
+	}
+	return u.String()
+}
+
+// rawRelease 为发布列表接口返回的单个发布（GitHub releases API 格式）。
+type rawRelease struct {
+	TagName     string `json:"tag_name"`
+	Name        string `json:"name"`
+	Body        string `json:"body"`
+	PublishedAt string `json:"published_at"`
+	HTMLURL     string `json:"html_url"`
+	Draft       bool   `json:"draft"`
+}
+
+// ListReleases 返回更新源的全部正式发布（按版本号降序），结果缓存 changelogCacheTTL。
+//
+// 按 Link 响应头的 rel="next" 翻页，最多读取 changelogMaxPages 页；无法解析版本号的 tag 与草稿会被忽略。
+func (uc *UpdateChecker) ListReleases(ctx context.Context) ([]ReleaseInfo, error) {
+	listURL := uc.releasesURL()
+
+	uc.changelog.mu.Lock()
+	defer uc.changelog.mu.Unlock()
+	if uc.changelog.url == listURL && time.Since(uc.changelog.fetchedAt) < changelogCacheTTL {
+		return uc.changelog.releases, nil
+	}
+
+	client := httpClient(uc.Timeout)
+	var raw []rawRelease
+	for page, next := 0, listURL; next != "" && page < changelogMaxPages; page++ {
+		items, nextURL, err := uc.fetchReleasePage(ctx, client, next)
+		if err != nil {
+			return nil, err
+		}
+		raw = append(raw, items...)
+		next = nextURL
+	}
+
+	releases := make([]ReleaseInfo, 0, len(raw))
+	for _, r := range raw {
+		v := strings.TrimPrefix(strings.TrimSpace(r.TagName), "v")
+		if r.Draft {
+			continue
+		}
+		if _, err := ParseSemver(v); err != nil {
+			continue
+		}
+		releases = append(releases, ReleaseInfo{
+			Version:     v,
+			Name:        r.Name,
+			Description: r.Body,
+			PublishedAt: r.PublishedAt,
+			PageURL:     r.HTMLURL,
+		})
+	}
+	sort.SliceStable(releases, func(i, j int) bool {
+		return compareVersion(releases[i].Version, releases[j].Version) > 0
+	})
+
+	uc.changelog.url = listURL
+	uc.changelog.fetchedAt = time.Now()
+	uc.changelog.releases = releases
+	return releases, nil
+}
+
+// fetchReleasePage 读取一页发布列表，返回其中的发布与下一页地址（没有下一页时为空）。
+func (uc *UpdateChecker) fetchReleasePage(ctx context.Context, client *http.Client, pageURL string) ([]rawRelease, string, error) {
+	req, err := uc.newRequest(ctx, pageURL)
+	if err != nil {
+		return nil, "", err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("fetch releases: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("update server returned status %d", resp.StatusCode)
+	}
+
+	var raw []rawRelease
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, "", fmt.Errorf("parse releases: %w", err)
+	}
+	return raw, nextPageURL(resp.Request.URL, resp.Header.Get("Link")), nil
+}
+
+// nextPageURL 从 Link 响应头（如 `<https://...&page=2>; rel="next", <...>; rel="last"`）中取出下一页地址，
+// 相对地址按 base 解析；没有 rel="next" 时返回空字符串。
+func nextPageURL(base *url.URL, link string) string {
+	for _, part := range strings.Split(link, ",") {
+		target, params, ok := strings.Cut(strings.TrimSpace(part), ";")
+		if !ok || !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+			continue
+		}
+		isNext := false
+		for _, p := range strings.Split(params, ";") {
+			if k, v, ok := strings.Cut(strings.TrimSpace(p), "="); ok && k == "rel" && slices.Contains(strings.Fields(strings.Trim(v, `"`)), "next") {
+				isNext = true
+			}
+		}
+		if !isNext {
+			continue
+		}
+		next, err := base.Parse(strings.TrimSuffix(strings.TrimPrefix(target, "<"), ">"))
+		if err != nil {
+			return ""
+		}
+		return next.String()
+	}
+	return ""
+}
+
+// GetChangelog 返回 (sinceVersion, 最新版本] 区间内所有版本的发布说明（按版本号降序）。
+//
+// sinceVersion 为空时使用当前运行版本（即列出尚未安装的新版本）；
+// 升级完成后传入升级前的版本号，即可拿到本次跨越的全部版本说明，而不只是最新一条。
+func (uc *UpdateChecker) GetChangelog(ctx context.Context, sinceVersion string) ([]ReleaseInfo, error) {
+	sinceVersion = strings.TrimSpace(sinceVersion)
+	if sinceVersion == "" {
+		sinceVersion = Version
+	}
+	if _, err := ParseSemver(sinceVersion); err != nil {
+		return nil, err
+	}
+
+	releases, err := uc.ListReleases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []ReleaseInfo{}
+	for _, r := range releases {
+		if compareVersion(r.Version, sinceVersion) > 0 {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
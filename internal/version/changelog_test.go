@@ -0,0 +1,46 @@
+package version
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListReleasesFollowsPagination(t *testing.T) {
+	pages := map[string][]map[string]any{
+		"":  {{"tag_name": "v1.3.0"}, {"tag_name": "v1.2.0"}},
+		"2": {{"tag_name": "v1.1.0"}, {"tag_name": "draft", "draft": true}},
+		"3": {{"tag_name": "v1.0.0"}},
+	}
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("per_page") == "" {
+			t.Errorf("request without per_page: %s", r.URL)
+		}
+		page := r.URL.Query().Get("page")
+		switch page {
+		case "":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/releases?per_page=100&page=2>; rel="next", <%s/releases?per_page=100&page=3>; rel="last"`, srv.URL, srv.URL))
+		case "2":
+			// 相对地址也按请求地址解析
+			w.Header().Set("Link", `</releases?per_page=100&page=1>; rel="prev", </releases?per_page=100&page=3>; rel="next"`)
+		}
+		json.NewEncoder(w).Encode(pages[page])
+	}))
+	t.Cleanup(srv.Close)
+
+	releases, err := NewUpdateChecker(srv.URL + "/releases/latest").ListReleases(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, r := range releases {
+		got = append(got, r.Version)
+	}
+	if fmt.Sprint(got) != "[1.3.0 1.2.0 1.1.0 1.0.0]" {
+		t.Errorf("releases = %v, want all pages without drafts", got)
+	}
+}
@@ -0,0 +1,253 @@
+package version
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// maxMetaFileBytes 限制校验文件/签名的大小，避免异常响应占用过多内存。
+const maxMetaFileBytes = 1 << 20
+
+// progressStepBytes 在总大小未知时，每下载这么多字节上报一次进度。
+const progressStepBytes = 256 << 10
+
+// DownloadProgress 表示安装包下载进度（通过 Wails 事件 `update:progress` 推送给前端）。
+type DownloadProgress struct {
+	Downloaded int64   `json:"downloaded"` // 已下载字节数
+	Total      int64   `json:"total"`      // 总字节数（服务器未返回 Content-Length 时为 0）
+	Percent    float64 `json:"percent"`    // 0-100；总大小未知时为 0
+}
+
+// ProgressFunc 接收下载进度回调。
+type ProgressFunc func(DownloadProgress)
+
+// progressWriter 统计写入字节数，并按"百分比变化/固定步长"节流回调，避免事件刷屏。
+type progressWriter struct {
+	total      int64
+	downloaded int64
+	lastPct    int
+	lastBytes  int64
+	onProgress ProgressFunc
+}
+
+func (pw *progressWriter) Write(p []byte) (int, error) {
+	pw.downloaded += int64(len(p))
+	if pw.onProgress == nil {
+		return len(p), nil
+	}
+	if pw.total > 0 {
+		pct := int(pw.downloaded * 100 / pw.total)
+		if pct == pw.lastPct && pw.downloaded < pw.total {
+			return len(p), nil
+		}
+		pw.lastPct = pct
+	} else if pw.downloaded-pw.lastBytes < progressStepBytes {
+		return len(p), nil
+	}
+	pw.lastBytes = pw.downloaded
+	pw.onProgress(pw.progress())
+	return len(p), nil
+}
+
+func (pw *progressWriter) progress() DownloadProgress {
+	p := DownloadProgress{Downloaded: pw.downloaded, Total: pw.total}
+	if pw.total > 0 {
+		p.Percent = float64(pw.downloaded) * 100 / float64(pw.total)
+	}
+	return p
+}
+
+// DownloadUpdate 下载 release 对应的安装包到 destDir，并在返回前完成校验：
+//  1. 下载 SHA256SUMS（缺失则拒绝：无法确认安装包完整性）
+//  2. 若配置了 PublicKey：下载并校验 SHA256SUMS 的 ed25519 签名（注入的公钥无法解析时直接拒绝）
+//  3. 边下载边计算安装包 SHA256，与 SHA256SUMS 中的条目比对
+//
+// 只有全部校验通过才返回最终文件路径；任何失败（包括 ctx 被取消）都会删除已下载的临时文件。
+// onProgress 可为 nil；非 nil 时在下载过程中按节流频率回调。
+func (uc *UpdateChecker) DownloadUpdate(ctx context.Context, rel *ReleaseInfo, destDir string, onProgress ProgressFunc) (string, error) {
+	if rel == nil || rel.DownloadURL == "" || rel.AssetName == "" {
+		return "", errors.New("no downloadable asset in release")
+	}
+	if uc.publicKeyErr != nil {
+		return "", fmt.Errorf("update public key: %w", uc.publicKeyErr)
+	}
+	if rel.ChecksumURL == "" {
+		return "", errors.New("release has no SHA256SUMS asset")
+	}
+	if filepath.Base(rel.AssetName) != rel.AssetName {
+		return "", fmt.Errorf("invalid asset name %q", rel.AssetName)
+	}
+
+	sums, err := uc.fetchSmall(ctx, rel.ChecksumURL)
+	if err != nil {
+		return "", fmt.Errorf("fetch checksums: %w", err)
+	}
+
+	if len(uc.PublicKey) > 0 {
+		if rel.SignatureURL == "" {
+			return "", errors.New("release has no checksum signature")
+		}
+		sig, err := uc.fetchSmall(ctx, rel.SignatureURL)
+		if err != nil {
+			return "", fmt.Errorf("fetch signature: %w", err)
+		}
+		if err := verifySignature(uc.PublicKey, sums, sig); err != nil {
+			return "", fmt.Errorf("verify checksum signature: %w", err)
+		}
+	}
+
+	table, err := parseChecksums(sums)
+	if err != nil {
+		return "", err
+	}
+	want, ok := table[rel.AssetName]
+	if !ok {
+		return "", fmt.Errorf("asset %q not listed in checksums", rel.AssetName)
+	}
+
+	if err := os.MkdirAll(destDir, 0o755); err != nil {
+		return "", fmt.Errorf("create download dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(destDir, rel.AssetName+".*.part")
+	if err != nil {
+		return "", fmt.Errorf("create temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	keep := false
+	defer func() {
+		if !keep {
+			_ = os.Remove(tmpPath)
+		}
+	}()
+
+	h := sha256.New()
+	if err := uc.fetchTo(ctx, rel.DownloadURL, io.MultiWriter(tmp, h), onProgress); err != nil {
+		_ = tmp.Close()
+		return "", fmt.Errorf("download asset: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("close temp file: %w", err)
+	}
+
+	got := hex.EncodeToString(h.Sum(nil))
+	if got != want {
+		return "", fmt.Errorf("checksum mismatch for %q: got %s, want %s", rel.AssetName, got, want)
+	}
+
+	finalPath := filepath.Join(destDir, rel.AssetName)
+	_ = os.Remove(finalPath)
+	if err := os.Rename(tmpPath, finalPath); err != nil {
+		return "", fmt.Errorf("move downloaded asset: %w", err)
+	}
+	keep = true
+	return finalPath, nil
+}
+
+// ErrInsecureURL 表示更新相关的地址（更新源、安装包、校验文件）不是 https。
+var ErrInsecureURL = errors.New("update URL must use https")
+
+// isSecureURL 报告 u 是否为 https 地址，或指向本机（localhost / 回环地址）的 http 地址（本地调试更新服务器）。
+func isSecureURL(u *url.URL) bool {
+	switch u.Scheme {
+	case "https":
+		return true
+	case "http":
+		host := u.Hostname()
+		if strings.EqualFold(host, "localhost") {
+			return true
+		}
+		ip := net.ParseIP(host)
+		return ip != nil && ip.IsLoopback()
+	}
+	return false
+}
+
+// httpClient 返回拒绝重定向到非 https 地址的 HTTP 客户端；timeout 为 0 表示不限时。
+func httpClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout: timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			if !isSecureURL(req.URL) {
+				return ErrInsecureURL
+			}
+			return nil
+		},
+	}
+}
+
+// newRequest 创建带统一 User-Agent 的 GET 请求；非 https 地址返回 ErrInsecureURL。
+func (uc *UpdateChecker) newRequest(ctx context.Context, url string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	if !isSecureURL(req.URL) {
+		return nil, ErrInsecureURL
+	}
+	req.Header.Set("User-Agent", fmt.Sprintf("%s/%s (%s)", Name, Version, runtime.GOOS))
+	return req, nil
+}
+
+// fetchSmall 下载小文件（校验文件/签名）到内存。
+func (uc *UpdateChecker) fetchSmall(ctx context.Context, url string) ([]byte, error) {
+	req, err := uc.newRequest(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	client := httpClient(uc.Timeout)
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server returned status %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxMetaFileBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxMetaFileBytes {
+		return nil, errors.New("file too large")
+	}
+	return data, nil
+}
+
+// fetchTo 将 url 的内容流式写入 w，并通过 onProgress 上报进度。
+//
+// 安装包可能较大，这里不设置整体 Timeout，由调用方通过 ctx 控制取消。
+func (uc *UpdateChecker) fetchTo(ctx context.Context, url string, w io.Writer, onProgress ProgressFunc) error {
+	req, err := uc.newRequest(ctx, url)
+	if err != nil {
+		return err
+	}
+	resp, err := httpClient(0).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("server returned status %d", resp.StatusCode)
+	}
+	pw := &progressWriter{total: resp.ContentLength, lastPct: -1, onProgress: onProgress}
+	if pw.total < 0 {
+		pw.total = 0
+	}
+	_, err = io.Copy(io.MultiWriter(w, pw), resp.Body)
+	return err
+}
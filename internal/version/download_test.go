@@ -0,0 +1,31 @@
+package version
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestNewRequestRequiresHTTPS(t *testing.T) {
+	uc := NewUpdateChecker("")
+	cases := []struct {
+		url string
+		ok  bool
+	}{
+		{"https://example.com/releases/latest", true},
+		{"http://example.com/releases/latest", false},
+		{"http://127.0.0.1:8080/latest", true},
+		{"http://[::1]/latest", true},
+		{"http://localhost/latest", true},
+		{"ftp://example.com/app.exe", false},
+	}
+	for _, c := range cases {
+		_, err := uc.newRequest(context.Background(), c.url)
+		if c.ok && err != nil {
+			t.Errorf("%s: err = %v", c.url, err)
+		}
+		if !c.ok && !errors.Is(err, ErrInsecureURL) {
+			t.Errorf("%s: err = %v, want ErrInsecureURL", c.url, err)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package version
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// maxReleaseBodyBytes 限制单次 Release API 响应大小。
+const maxReleaseBodyBytes = 5 << 20
+
+// ErrRateLimited 表示更新源（GitHub API）已触发限流，且本地没有可用缓存。
+var ErrRateLimited = errors.New("update server rate limit exceeded")
+
+// latestCache 缓存"最新版本"接口的响应：
+// - etag/body：配合 If-None-Match，内容未变时服务器返回 304，不消耗下载流量
+// - resetAt：限流解除时间；在此之前不再发请求，直接使用缓存
+//
+// GitHub 对未认证请求的配额是 60 次/小时，304 响应不计入配额。
+type latestCache struct {
+	mu      sync.Mutex
+	url     string
+	etag    string
+	body    []byte
+	resetAt time.Time
+}
+
+// fetchLatest 获取 UpdateURL 的响应体，优先复用缓存。
+func (uc *UpdateChecker) fetchLatest(ctx context.Context) ([]byte, error) {
+	c := &uc.latest
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// 更新源变化时丢弃旧缓存
+	if c.url != uc.UpdateURL {
+		*c = latestCache{url: uc.UpdateURL}
+	}
+
+	if time.Now().Before(c.resetAt) {
+		if c.body != nil {
+			return c.body, nil
+		}
+		return nil, fmt.Errorf("%w (resets at %s)", ErrRateLimited, c.resetAt.Format(time.RFC3339))
+	}
+
+	req, err := uc.newRequest(ctx, uc.UpdateURL)
+	if err != nil {
+		return nil, err
+	}
+	if c.etag != "" && c.body != nil {
+		req.Header.Set("If-None-Match", c.etag)
+	}
+
+	client := httpClient(uc.Timeout)
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch update info: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if reset, ok := rateLimitReset(resp); ok {
+		c.resetAt = reset
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxReleaseBodyBytes))
+		if err != nil {
+			return nil, fmt.Errorf("read response: %w", err)
+		}
+		c.etag = resp.Header.Get("ETag")
+		c.body = body
+		return body, nil
+	case http.StatusNotModified:
+		if c.body != nil {
+			return c.body, nil
+		}
+		return nil, errors.New("update server returned 304 without cached data")
+	case http.StatusForbidden, http.StatusTooManyRequests:
+		if c.resetAt.IsZero() || time.Now().After(c.resetAt) {
+			// 没有明确的解除时间时，保守地退避一段时间
+			c.resetAt = time.Now().Add(10 * time.Minute)
+		}
+		if c.body != nil {
+			return c.body, nil
+		}
+		return nil, fmt.Errorf("%w (resets at %s)", ErrRateLimited, c.resetAt.Format(time.RFC3339))
+	default:
+		return nil, fmt.Errorf("update server returned status %d", resp.StatusCode)
+	}
+}
+
+// rateLimitReset 解析限流相关响应头，返回限流解除时间：
+// - X-RateLimit-Remaining: 0 + X-RateLimit-Reset（Unix 秒）
+// - Retry-After（秒）
+func rateLimitReset(resp *http.Response) (time.Time, bool) {
+	if s := resp.Header.Get("Retry-After"); s != "" {
+		if secs, err := strconv.Atoi(s); err == nil && secs > 0 {
+			return time.Now().Add(time.Duration(secs) * time.Second), true
+		}
+	}
+	if resp.Header.Get("X-RateLimit-Remaining") != "0" {
+		return time.Time{}, false
+	}
+	reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil || reset <= 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(reset, 0), true
+}
@@ -0,0 +1,156 @@
+package version
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// rollbackManifest 为回滚目录中记录上一版本信息的文件名。
+const rollbackManifest = "previous.json"
+
+// RollbackInfo 描述可回滚到的上一版本。
+type RollbackInfo struct {
+	Version  string `json:"version"`            // 上一版本号
+	SavedAt  int64  `json:"savedAt"`            // 保存时间（UnixMilli）
+	Path     string `json:"path"`               // 备份的可执行文件路径
+	Database string `json:"database,omitempty"` // 更新前的数据库快照路径（新版本可能已迁移数据库，回滚时一并恢复）
+}
+
+// SaveRollback 在安装更新前，把当前运行的可执行文件复制到 dir 中，作为回滚备份；
+// database 为同时拍下的数据库快照路径，记录在清单中供 Rollback 恢复。
+//
+// 只保留一份（最近一次升级前的版本），重复调用会覆盖。
+func SaveRollback(dir, database string) (RollbackInfo, error) {
+	exe, err := currentExecutable()
+	if err != nil {
+		return RollbackInfo{}, err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return RollbackInfo{}, fmt.Errorf("create rollback dir: %w", err)
+	}
+
+	info := RollbackInfo{
+		Version:  Version,
+		SavedAt:  time.Now().UnixMilli(),
+		Path:     filepath.Join(dir, "previous"+filepath.Ext(exe)),
+		Database: database,
+	}
+	if err := copyFile(exe, info.Path); err != nil {
+		return RollbackInfo{}, fmt.Errorf("backup executable: %w", err)
+	}
+
+	data, err := json.Marshal(info)
+	if err != nil {
+		return RollbackInfo{}, fmt.Errorf("encode rollback manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, rollbackManifest), data, 0o644); err != nil {
+		return RollbackInfo{}, fmt.Errorf("write rollback manifest: %w", err)
+	}
+	return info, nil
+}
+
+// LoadRollback 读取 dir 中的回滚备份信息；没有备份时返回 (nil, nil)。
+func LoadRollback(dir string) (*RollbackInfo, error) {
+	data, err := os.ReadFile(filepath.Join(dir, rollbackManifest))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read rollback manifest: %w", err)
+	}
+
+	var info RollbackInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, fmt.Errorf("parse rollback manifest: %w", err)
+	}
+	if _, err := os.Stat(info.Path); err != nil {
+		return nil, nil
+	}
+	return &info, nil
+}
+
+// Rollback 用 dir 中的备份替换当前可执行文件，并调用 restoreDB 恢复更新前的数据库快照，
+// 返回替换后的可执行文件路径（供调用方重启）。
+//
+// 新版本可能已迁移数据库，旧版本不一定能读，因此只回滚可执行文件是不安全的：
+// 清单中没有记录数据库快照（或快照已被删除）时拒绝回滚。
+//
+// Windows 下运行中的 exe 不能被覆盖但可以重命名，因此先把当前文件改名为 *.old，
+// 再把备份复制到原路径；复制或 restoreDB 失败时会把原文件改回去。
+func Rollback(dir string, restoreDB func(path string) error) (string, error) {
+	info, err := LoadRollback(dir)
+	if err != nil {
+		return "", err
+	}
+	if info == nil {
+		return "", errors.New("no previous version to roll back to")
+	}
+	if info.Database == "" {
+		return "", errors.New("no database snapshot recorded for the previous version")
+	}
+	if _, err := os.Stat(info.Database); err != nil {
+		return "", fmt.Errorf("database snapshot: %w", err)
+	}
+
+	exe, err := currentExecutable()
+	if err != nil {
+		return "", err
+	}
+
+	old := exe + ".old"
+	_ = os.Remove(old)
+	if err := os.Rename(exe, old); err != nil {
+		return "", fmt.Errorf("move current executable: %w", err)
+	}
+	if err := copyFile(info.Path, exe); err != nil {
+		_ = os.Rename(old, exe)
+		return "", fmt.Errorf("restore previous executable: %w", err)
+	}
+	if err := restoreDB(info.Database); err != nil {
+		_ = os.Remove(exe)
+		_ = os.Rename(old, exe)
+		return "", fmt.Errorf("restore database snapshot: %w", err)
+	}
+	return exe, nil
+}
+
+// currentExecutable 返回当前进程可执行文件的真实路径（解析符号链接）。
+func currentExecutable() (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("get executable path: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+	return exe, nil
+}
+
+// copyFile 复制文件内容并保留权限位。
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	st, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, st.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
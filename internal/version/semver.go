@@ -0,0 +1,173 @@
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Semver 表示一个解析后的语义化版本号（https://semver.org）。
+//
+// 支持的格式：
+//   - 可选的 v/V 前缀：v1.2.3
+//   - 缺省的次版本/修订号：1.2 视为 1.2.0
+//   - 预发布标识：1.2.0-rc.1
+//   - 构建元数据：1.2.0+20240101（比较时忽略）
+type Semver struct {
+	Major      int
+	Minor      int
+	Patch      int
+	PreRelease []string
+	Build      string
+}
+
+// ParseSemver 解析版本字符串。
+//
+// 解析失败时返回错误（例如非数字的主版本号、空的预发布标识段）。
+// 预发布标识与构建元数据的各段只能包含 [0-9A-Za-z-]：版本号会出现在备份文件名等位置，不能带路径分隔符。
+func ParseSemver(s string) (Semver, error) {
+	raw := s
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "v"), "V")
+	if s == "" {
+		return Semver{}, fmt.Errorf("invalid version %q: empty", raw)
+	}
+
+	var v Semver
+
+	// 构建元数据（+ 之后）不参与比较，但保留以便展示
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		v.Build = s[i+1:]
+		s = s[:i]
+		if v.Build == "" {
+			return Semver{}, fmt.Errorf("invalid version %q: empty build metadata", raw)
+		}
+		for _, id := range strings.Split(v.Build, ".") {
+			if !isSemverIdentifier(id) {
+				return Semver{}, fmt.Errorf("invalid version %q: bad build identifier %q", raw, id)
+			}
+		}
+	}
+
+	// 预发布标识（- 之后，按 . 分段）
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		pre := s[i+1:]
+		s = s[:i]
+		if pre == "" {
+			return Semver{}, fmt.Errorf("invalid version %q: empty pre-release", raw)
+		}
+		v.PreRelease = strings.Split(pre, ".")
+		for _, id := range v.PreRelease {
+			if id == "" {
+				return Semver{}, fmt.Errorf("invalid version %q: empty pre-release identifier", raw)
+			}
+			if !isSemverIdentifier(id) {
+				return Semver{}, fmt.Errorf("invalid version %q: bad pre-release identifier %q", raw, id)
+			}
+		}
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return Semver{}, fmt.Errorf("invalid version %q: too many components", raw)
+	}
+	nums := [3]int{}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return Semver{}, fmt.Errorf("invalid version %q: bad component %q", raw, p)
+		}
+		nums[i] = n
+	}
+	v.Major, v.Minor, v.Patch = nums[0], nums[1], nums[2]
+	return v, nil
+}
+
+// isSemverIdentifier 判断 id 是否为非空、只含 [0-9A-Za-z-] 的标识段。
+func isSemverIdentifier(id string) bool {
+	if id == "" {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		c := id[i]
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// String 返回规范化后的版本字符串（不带 v 前缀）。
+func (v Semver) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.PreRelease) > 0 {
+		s += "-" + strings.Join(v.PreRelease, ".")
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// Compare 按 semver 规则比较两个版本。
+// 返回值：1 表示 v > o，-1 表示 v < o，0 表示相等（忽略构建元数据）。
+func (v Semver) Compare(o Semver) int {
+	if c := compareInt(v.Major, o.Major); c != 0 {
+		return c
+	}
+	if c := compareInt(v.Minor, o.Minor); c != 0 {
+		return c
+	}
+	if c := compareInt(v.Patch, o.Patch); c != 0 {
+		return c
+	}
+
+	// 有预发布标识的版本低于对应的正式版本：1.0.0-rc.1 < 1.0.0
+	switch {
+	case len(v.PreRelease) == 0 && len(o.PreRelease) == 0:
+		return 0
+	case len(v.PreRelease) == 0:
+		return 1
+	case len(o.PreRelease) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(v.PreRelease) && i < len(o.PreRelease); i++ {
+		if c := comparePreReleaseID(v.PreRelease[i], o.PreRelease[i]); c != 0 {
+			return c
+		}
+	}
+	// 前缀相同时，段数多的更大：1.0.0-alpha < 1.0.0-alpha.1
+	return compareInt(len(v.PreRelease), len(o.PreRelease))
+}
+
+// comparePreReleaseID 比较单个预发布标识段：
+// - 纯数字按数值比较
+// - 数字段低于字母段
+// - 字母段按 ASCII 字典序比较
+func comparePreReleaseID(a, b string) int {
+	an, aErr := strconv.Atoi(a)
+	bn, bErr := strconv.Atoi(b)
+	aNum, bNum := aErr == nil, bErr == nil
+	switch {
+	case aNum && bNum:
+		return compareInt(an, bn)
+	case aNum:
+		return -1
+	case bNum:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a > b:
+		return 1
+	case a < b:
+		return -1
+	default:
+		return 0
+	}
+}
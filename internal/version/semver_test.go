@@ -0,0 +1,37 @@
+package version
+
+import "testing"
+
+func TestParseSemver(t *testing.T) {
+	valid := map[string]string{
+		"1.2.3":               "1.2.3",
+		"v1.2":                "1.2.0",
+		"1.2.0-rc.1":          "1.2.0-rc.1",
+		"1.2.0-x-y.2+build.7": "1.2.0-x-y.2+build.7",
+	}
+	for in, want := range valid {
+		v, err := ParseSemver(in)
+		if err != nil || v.String() != want {
+			t.Errorf("ParseSemver(%q) = %q, %v; want %q", in, v.String(), err, want)
+		}
+	}
+
+	for _, in := range []string{
+		"", "1.x", "1.2.3.4", "1.2.3-", "1.2.3-rc..1", "1.2.3+",
+		"1.2.3-../../evil", `1.2.3-a\b`, "1.2.3-rc 1", "1.2.3+build/1", "1.2.3-测试",
+	} {
+		if _, err := ParseSemver(in); err == nil {
+			t.Errorf("ParseSemver(%q) succeeded", in)
+		}
+	}
+}
+
+func TestCompareVersionRejectsUnsafeTags(t *testing.T) {
+	// 带路径字符的 tag 无法解析，不会被当作新版本（进而用于备份文件名）
+	if compareVersion("99.0.0-../../x", "1.0.0") > 0 {
+		t.Error("tag with path characters compared as newer")
+	}
+	if compareVersion("1.0.0", "1.0.0-rc.1") <= 0 {
+		t.Error("release not newer than its pre-release")
+	}
+}
@@ -0,0 +1,117 @@
+package version
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// UpdatePublicKey 是发布签名所用 ed25519 公钥（base64 编码）。
+//
+// 默认为空：只校验 SHA256SUMS，不要求签名。
+// 发布构建时可通过 ldflags 注入：
+//
+//	-ldflags "-X spark-todo/internal/version.UpdatePublicKey=<base64>"
+//
+// 一旦配置了公钥，SHA256SUMS 必须附带有效签名，否则拒绝安装；公钥本身无法解析时同样拒绝安装。
+var UpdatePublicKey = ""
+
+// checksumAssetNames 为 Release 中可识别的校验文件名（不区分大小写）。
+var checksumAssetNames = []string{"sha256sums", "sha256sums.txt", "checksums.txt"}
+
+// isChecksumAsset 判断是否为校验文件。
+func isChecksumAsset(name string) bool {
+	name = strings.ToLower(name)
+	for _, n := range checksumAssetNames {
+		if name == n {
+			return true
+		}
+	}
+	return false
+}
+
+// isSignatureAsset 判断是否为校验文件的签名（如 SHA256SUMS.sig）。
+func isSignatureAsset(name string) bool {
+	name = strings.ToLower(name)
+	for _, n := range checksumAssetNames {
+		if name == n+".sig" {
+			return true
+		}
+	}
+	return false
+}
+
+// parsePublicKey 解析 base64 编码的 ed25519 公钥；空字符串表示未配置。
+func parsePublicKey(s string) (ed25519.PublicKey, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	raw, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("decode public key: %w", err)
+	}
+	if len(raw) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid public key size %d", len(raw))
+	}
+	return ed25519.PublicKey(raw), nil
+}
+
+// parseChecksums 解析 sha256sum 格式的校验文件：
+//
+//	<hex>  <filename>
+//	<hex> *<filename>
+//
+// 返回 文件名 -> 小写 hex 摘要。
+func parseChecksums(data []byte) (map[string]string, error) {
+	out := map[string]string{}
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("malformed checksum line %q", line)
+		}
+		sum := strings.ToLower(fields[0])
+		if len(sum) != sha256.Size*2 {
+			return nil, fmt.Errorf("malformed checksum %q", fields[0])
+		}
+		if _, err := hex.DecodeString(sum); err != nil {
+			return nil, fmt.Errorf("malformed checksum %q", fields[0])
+		}
+		out[strings.TrimPrefix(fields[1], "*")] = sum
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read checksums: %w", err)
+	}
+	return out, nil
+}
+
+// verifySignature 用 ed25519 公钥校验 data 的签名。
+//
+// 签名文件可以是原始 64 字节，也可以是 base64 文本。
+func verifySignature(pub ed25519.PublicKey, data, sig []byte) error {
+	if len(sig) != ed25519.SignatureSize {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(sig)))
+		if err != nil {
+			return fmt.Errorf("decode signature: %w", err)
+		}
+		sig = decoded
+	}
+	if len(sig) != ed25519.SignatureSize {
+		return errors.New("invalid signature size")
+	}
+	if !ed25519.Verify(pub, data, sig) {
+		return errors.New("signature mismatch")
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package version
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// releaseServer 提供一个安装包、其 SHA256SUMS 以及（sign 非 nil 时）由 sign 生成的 SHA256SUMS.sig。
+func releaseServer(t *testing.T, sign func(sums []byte) []byte) *ReleaseInfo {
+	t.Helper()
+	asset := []byte("installer")
+	sum := sha256.Sum256(asset)
+	sums := []byte(hex.EncodeToString(sum[:]) + "  app.exe\n")
+	var sig []byte
+	if sign != nil {
+		sig = sign(sums)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/app.exe", func(w http.ResponseWriter, r *http.Request) { w.Write(asset) })
+	mux.HandleFunc("/SHA256SUMS", func(w http.ResponseWriter, r *http.Request) { w.Write(sums) })
+	mux.HandleFunc("/SHA256SUMS.sig", func(w http.ResponseWriter, r *http.Request) { w.Write(sig) })
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	rel := &ReleaseInfo{AssetName: "app.exe", DownloadURL: srv.URL + "/app.exe", ChecksumURL: srv.URL + "/SHA256SUMS"}
+	if sign != nil {
+		rel.SignatureURL = srv.URL + "/SHA256SUMS.sig"
+	}
+	return rel
+}
+
+// withPublicKey 在测试期间把 UpdatePublicKey 设为 key。
+func withPublicKey(t *testing.T, key string) {
+	t.Helper()
+	old := UpdatePublicKey
+	UpdatePublicKey = key
+	t.Cleanup(func() { UpdatePublicKey = old })
+}
+
+func TestDownloadUpdateFailsClosedOnBadPublicKey(t *testing.T) {
+	withPublicKey(t, "not a key")
+	rel := releaseServer(t, nil)
+	if _, err := NewUpdateChecker("").DownloadUpdate(context.Background(), rel, t.TempDir(), nil); err == nil {
+		t.Fatal("download succeeded with a malformed public key")
+	}
+}
+
+func TestDownloadUpdateVerifiesSignature(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	withPublicKey(t, base64.StdEncoding.EncodeToString(pub))
+	ctx := context.Background()
+
+	// 缺少签名、签名不匹配时拒绝，有效签名时通过
+	cases := []struct {
+		name string
+		sign func([]byte) []byte
+		ok   bool
+	}{
+		{"missing", nil, false},
+		{"wrong", func([]byte) []byte { return make([]byte, ed25519.SignatureSize) }, false},
+		{"valid", func(sums []byte) []byte { return ed25519.Sign(priv, sums) }, true},
+		{"valid base64", func(sums []byte) []byte { return []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(priv, sums))) }, true},
+	}
+	for _, c := range cases {
+		_, err := NewUpdateChecker("").DownloadUpdate(ctx, releaseServer(t, c.sign), t.TempDir(), nil)
+		if (err == nil) != c.ok {
+			t.Errorf("%s signature: err = %v", c.name, err)
+		}
+	}
+}
@@ -2,10 +2,11 @@ package version
 
 import (
 	"context"
+	"crypto/ed25519"
 	"encoding/json"
 	"fmt"
-	"net/http"
 	"runtime"
+	"strings"
 	"time"
 )
 
@@ -17,13 +18,16 @@ const (
 
 // ReleaseInfo 表示一个发布版本的信息
 type ReleaseInfo struct {
-	Version     string `json:"version"`     // 版本号，如 "1.1.0"
-	Name        string `json:"name"`        // 版本名称，如 "v1.1.0 - 简洁模式更新"
-	Description string `json:"description"` // 版本描述/更新内容
-	PublishedAt string `json:"publishedAt"` // 发布时间
-	DownloadURL string `json:"downloadUrl"` // 下载链接（exe 或安装包）
-	PageURL     string `json:"pageUrl"`     // Release 页面链接
-	Required    bool   `json:"required"`    // 是否强制更新
+	Version      string `json:"version"`      // 版本号，如 "1.1.0"
+	Name         string `json:"name"`         // 版本名称，如 "v1.1.0 - 简洁模式更新"
+	Description  string `json:"description"`  // 版本描述/更新内容
+	PublishedAt  string `json:"publishedAt"`  // 发布时间
+	DownloadURL  string `json:"downloadUrl"`  // 下载链接（exe 或安装包）
+	AssetName    string `json:"assetName"`    // 下载文件名（用于匹配 SHA256SUMS 中的条目）
+	ChecksumURL  string `json:"checksumUrl"`  // SHA256SUMS 下载链接
+	SignatureURL string `json:"signatureUrl"` // SHA256SUMS 签名下载链接（可选）
+	PageURL      string `json:"pageUrl"`      // Release 页面链接
+	Required     bool   `json:"required"`     // 是否强制更新
 }
 
 // UpdateCheckResult 表示更新检查结果
@@ -40,18 +44,34 @@ type UpdateChecker struct {
 	UpdateURL string
 	// Timeout 是 HTTP 请求超时时间
 	Timeout time.Duration
+	// PublicKey 是发布签名公钥；非空时下载的更新必须通过签名校验
+	PublicKey ed25519.PublicKey
+	// publicKeyErr 为注入的 UpdatePublicKey 格式错误；非 nil 时拒绝下载任何更新
+	publicKeyErr error
+
+	// latest 缓存最新版本接口的响应（ETag/限流）
+	latest latestCache
+	// changelog 缓存版本列表，供 GetChangelog 复用
+	changelog changelogCache
 }
 
-// NewUpdateChecker 创建更新检查器
+// DefaultUpdateURL 是默认更新源（GitHub Releases API）。
+//
+// 实际部署时替换为你的 GitHub 仓库；自建更新服务器或 fork 可通过 updateUrl 设置覆盖，无需重新编译。
+const DefaultUpdateURL = "https://api.github.com/repos/yourusername/Spark-Todo/releases/latest"
+
+// NewUpdateChecker 创建更新检查器；updateURL 为空时使用 DefaultUpdateURL。
 func NewUpdateChecker(updateURL string) *UpdateChecker {
 	if updateURL == "" {
-		// 默认使用 GitHub Releases API
-		// 实际部署时替换为你的 GitHub 仓库
-		updateURL = "https://api.github.com/repos/yourusername/Spark-Todo/releases/latest"
+		updateURL = DefaultUpdateURL
 	}
+	// 公钥格式错误时仍可检查更新，但 DownloadUpdate 会拒绝下载：不能退化为只做 SHA256 校验
+	pub, err := parsePublicKey(UpdatePublicKey)
 	return &UpdateChecker{
-		UpdateURL: updateURL,
-		Timeout:   10 * time.Second,
+		UpdateURL:    updateURL,
+		Timeout:      10 * time.Second,
+		PublicKey:    pub,
+		publicKeyErr: err,
 	}
 }
 
@@ -62,25 +82,10 @@ func (uc *UpdateChecker) CheckUpdate(ctx context.Context) (*UpdateCheckResult, e
 		HasUpdate:      false,
 	}
 
-	// 创建 HTTP 请求
-	req, err := http.NewRequestWithContext(ctx, "GET", uc.UpdateURL, nil)
-	if err != nil {
-		return result, fmt.Errorf("create request: %w", err)
-	}
-
-	// 设置 User-Agent
-	req.Header.Set("User-Agent", fmt.Sprintf("%s/%s (%s)", Name, Version, runtime.GOOS))
-
-	// 发送请求
-	client := &http.Client{Timeout: uc.Timeout}
-	resp, err := client.Do(req)
+	// 获取最新版本信息（带 ETag 缓存与限流退避）
+	body, err := uc.fetchLatest(ctx)
 	if err != nil {
-		return result, fmt.Errorf("fetch update info: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return result, fmt.Errorf("update server returned status %d", resp.StatusCode)
+		return result, err
 	}
 
 	// 解析 GitHub Release 响应
@@ -96,39 +101,49 @@ func (uc *UpdateChecker) CheckUpdate(ctx context.Context) (*UpdateCheckResult, e
 		} `json:"assets"`
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&githubRelease); err != nil {
+	if err := json.Unmarshal(body, &githubRelease); err != nil {
 		return result, fmt.Errorf("parse response: %w", err)
 	}
 
 	// 提取版本号（去掉 v 前缀）
-	latestVersion := githubRelease.TagName
-	if len(latestVersion) > 0 && latestVersion[0] == 'v' {
-		latestVersion = latestVersion[1:]
-	}
+	latestVersion := strings.TrimPrefix(strings.TrimSpace(githubRelease.TagName), "v")
 
 	// 比较版本
 	if compareVersion(latestVersion, Version) > 0 {
 		result.HasUpdate = true
 
-		// 查找合适的下载链接
-		downloadURL := ""
+		// 查找合适的下载链接，以及校验文件/签名
+		downloadURL, assetName := "", ""
+		checksumURL, signatureURL := "", ""
 		for _, asset := range githubRelease.Assets {
+			switch {
+			case isChecksumAsset(asset.Name):
+				checksumURL = asset.BrowserDownloadURL
+				continue
+			case isSignatureAsset(asset.Name):
+				signatureURL = asset.BrowserDownloadURL
+				continue
+			}
 			// 优先选择安装包，其次选择 exe
 			if runtime.GOOS == "windows" {
 				if len(downloadURL) == 0 || isInstallerAsset(asset.Name) {
 					downloadURL = asset.BrowserDownloadURL
+					assetName = asset.Name
 				}
 			}
 		}
 
 		result.LatestRelease = &ReleaseInfo{
-			Version:     latestVersion,
-			Name:        githubRelease.Name,
-			Description: githubRelease.Body,
-			PublishedAt: githubRelease.PublishedAt,
-			DownloadURL: downloadURL,
-			PageURL:     githubRelease.HTMLURL,
-			Required:    false, // 可以根据版本号规则判断是否强制更新
+			Version:      latestVersion,
+			Name:         githubRelease.Name,
+			Description:  githubRelease.Body,
+			PublishedAt:  githubRelease.PublishedAt,
+			DownloadURL:  downloadURL,
+			AssetName:    assetName,
+			ChecksumURL:  checksumURL,
+			SignatureURL: signatureURL,
+			PageURL:      githubRelease.HTMLURL,
+			Required:     false, // 可以根据版本号规则判断是否强制更新
 		}
 	}
 
@@ -140,33 +155,20 @@ func isInstallerAsset(name string) bool {
 	return len(name) > 13 && name[len(name)-13:] == "-installer.exe"
 }
 
-// compareVersion 比较两个版本号
+// compareVersion 比较两个版本号（按 semver 规则，支持预发布标识与构建元数据）
 // 返回值：1 表示 v1 > v2，-1 表示 v1 < v2，0 表示相等
+//
+// 无法解析的版本号视为低于任何合法版本，避免异常 tag 被误判为"新版本"。
 func compareVersion(v1, v2 string) int {
-	// 简化版本比较，支持 x.y.z 格式
-	var major1, minor1, patch1 int
-	var major2, minor2, patch2 int
-
-	fmt.Sscanf(v1, "%d.%d.%d", &major1, &minor1, &patch1)
-	fmt.Sscanf(v2, "%d.%d.%d", &major2, &minor2, &patch2)
-
-	if major1 != major2 {
-		if major1 > major2 {
-			return 1
-		}
-		return -1
-	}
-	if minor1 != minor2 {
-		if minor1 > minor2 {
-			return 1
-		}
-		return -1
-	}
-	if patch1 != patch2 {
-		if patch1 > patch2 {
-			return 1
-		}
+	s1, err1 := ParseSemver(v1)
+	s2, err2 := ParseSemver(v2)
+	switch {
+	case err1 != nil && err2 != nil:
+		return 0
+	case err1 != nil:
 		return -1
+	case err2 != nil:
+		return 1
 	}
-	return 0
+	return s1.Compare(s2)
 }
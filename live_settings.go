@@ -0,0 +1,108 @@
+package main
+
+import (
+	"slices"
+
+	"spark-todo/internal/todo"
+	"spark-todo/internal/version"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// settingsChange 描述一次设置变化。
+type settingsChange struct {
+	prev todo.Settings // 变化前（已应用）的设置；initial 时可能为零值
+	next todo.Settings
+	// initial 表示启动或恢复备份后第一次应用设置，各子系统应无条件按 next 配置
+	initial bool
+}
+
+// settingsSubscribers 为需要在设置变化时重新配置的后端子系统，按顺序执行。
+//
+// 所有修改设置的接口都经由 reloadSettings 到达这里，新增运行时生效的设置只需在此登记，
+// 无需在每个接口中单独应用，也不需要重启应用（窗口边框除外，见 SetConciseMode）。
+var settingsSubscribers = []struct {
+	name  string
+	apply func(a *App, c settingsChange)
+}{
+	{"language", func(a *App, c settingsChange) {
+		a.language.Store(c.next.Language)
+	}},
+	{"window", func(a *App, c settingsChange) {
+		if c.initial || c.prev.AlwaysOnTop != c.next.AlwaysOnTop {
+			runtime.WindowSetAlwaysOnTop(a.ctx, c.next.AlwaysOnTop)
+		}
+	}},
+	{"update", func(a *App, c settingsChange) {
+		if c.initial || c.prev.UpdateURL != c.next.UpdateURL {
+			a.updateChecker.Store(version.NewUpdateChecker(c.next.UpdateURL))
+		}
+	}},
+	{"hotkeys", func(a *App, c settingsChange) {
+		// 与当前注册结果比较，而不是与 prev 比较：SetHotkey 在保存前已完成注册
+		if c.initial || a.hotkeysOutdated(c.next) {
+			a.applyHotkeys(c.next)
+		}
+	}},
+	{"theme", func(a *App, c settingsChange) {
+		if !c.initial && (c.prev.Theme != c.next.Theme || c.prev.HighContrast != c.next.HighContrast || c.prev.ReducedMotion != c.next.ReducedMotion) {
+			a.emitThemeChanged(c.next)
+		}
+	}},
+	{"day", func(a *App, c settingsChange) {
+		// 时区变化后，跨天监听按新时区重新计算下一个零点
+		if !c.initial && c.prev.Timezone != c.next.Timezone {
+			a.wakeDayWatcher()
+		}
+	}},
+	{"trash", func(a *App, c settingsChange) {
+		if !c.initial && c.prev.TrashRetentionDays != c.next.TrashRetentionDays {
+			a.purgeExpiredTrash(a.ctx)
+		}
+	}},
+	{"api", func(a *App, c settingsChange) {
+		if c.initial || c.prev.APIPort != c.next.APIPort {
+			a.applyAPIPort(c.next.APIPort)
+		}
+	}},
+	{"countdown", func(a *App, c settingsChange) {
+		// 重新开启倒计时提醒时立即检查一次，不必等到下一次跨天
+		if !c.initial && !c.prev.CountdownReminders && c.next.CountdownReminders {
+			go a.notifyCountdownMilestones(a.ctx)
+		}
+	}},
+}
+
+// applySettings 让各子系统按 next 重新配置，并广播 settings:changed 事件，让其它窗口同步最新设置。
+func (a *App) applySettings(next todo.Settings, initial bool) {
+	a.settingsMu.Lock()
+	defer a.settingsMu.Unlock()
+
+	c := settingsChange{prev: a.appliedSettings, next: next, initial: initial || !a.settingsApplied}
+	for _, s := range settingsSubscribers {
+		s.apply(a, c)
+	}
+	a.appliedSettings, a.settingsApplied = next, true
+	runtime.EventsEmit(a.ctx, "settings:changed", next)
+}
+
+// hotkeysOutdated 报告当前注册的快捷键是否与 settings 中的组合不一致。
+func (a *App) hotkeysOutdated(settings todo.Settings) bool {
+	combos := make([]string, 0, len(hotkeyActions))
+	for _, st := range a.GetHotkeys() {
+		combos = append(combos, st.Combo)
+	}
+	want := make([]string, 0, len(hotkeyActions))
+	for _, h := range hotkeyActions {
+		want = append(want, *h.combo(&settings))
+	}
+	return !slices.Equal(combos, want)
+}
+
+// wakeDayWatcher 让 watchDayChange 立即重新计算下一次跨天的时间。
+func (a *App) wakeDayWatcher() {
+	select {
+	case a.dayWake <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"spark-todo/internal/lunar"
+	"spark-todo/internal/todo"
+)
+
+// SetShowLunar 开关"截止日期旁显示农历"（GetBoard 中任务的 dueLunar 字段）。
+func (a *App) SetShowLunar(on bool) (todo.Settings, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.Settings{}, err
+	}
+
+	settings, err := a.store.GetSettings(a.ctx)
+	if err != nil {
+		return todo.Settings{}, a.localize(err)
+	}
+	settings.ShowLunar = on
+	if err := a.store.SetSettings(a.ctx, settings); err != nil {
+		return todo.Settings{}, a.localize(err)
+	}
+	return a.reloadSettings()
+}
+
+// SolarToLunar 将公历日期（YYYY-MM-DD）转换为农历，供日期选择器显示对照。
+func (a *App) SolarToLunar(date string) (todo.LunarDate, error) {
+	d, err := todo.SolarToLunar(date)
+	return d, a.localize(err)
+}
+
+// LunarToSolar 将农历日期转换为公历，供按农历选择截止日期（如农历生日）；
+// 返回的 solar 为 YYYY-MM-DD，前端据此设置 dueAt。
+func (a *App) LunarToSolar(date lunar.Date) (todo.LunarDate, error) {
+	d, err := todo.LunarToSolar(date)
+	return d, a.localize(err)
+}
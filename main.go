@@ -3,10 +3,15 @@ package main
 import (
 	"context"
 	"embed"
+	"os"
+	"path/filepath"
+	// 内嵌 IANA 时区数据库：Windows 没有系统 zoneinfo，时区设置依赖 time.LoadLocation。
+	_ "time/tzdata"
 
 	"spark-todo/internal/todo"
 
 	"github.com/wailsapp/wails/v2"
+	"github.com/wailsapp/wails/v2/pkg/logger"
 	"github.com/wailsapp/wails/v2/pkg/options"
 	"github.com/wailsapp/wails/v2/pkg/options/assetserver"
 )
@@ -44,15 +49,32 @@ func readConciseModeSetting() bool {
 	return settings.ConciseMode
 }
 
+// hasArg 判断命令行参数中是否包含 name（不使用 flag 包，避免与 Wails 开发模式传入的参数冲突）。
+func hasArg(name string) bool {
+	for _, arg := range os.Args[1:] {
+		if arg == name {
+			return true
+		}
+	}
+	return false
+}
+
 func main() {
 	// NewApp 创建应用的后端实例：
 	// - 持有运行时上下文（用于调用 Wails runtime API）
 	// - 持有 Store（SQLite 持久化），并对外暴露给前端调用的方法（Bind）
 	app := NewApp()
+	app.readOnlyFlag = hasArg("--readonly")
 
 	// 读取 conciseMode 设置以决定窗口是否使用无边框模式
 	frameless := readConciseModeSetting()
 
+	// 运行时日志写入数据目录 logs/（诊断包会一并收集）；生产环境也记录警告（如数据库忙重试）
+	appLog := logger.NewDefaultLogger()
+	if dbPath, err := todo.DefaultDBPath("Spark-Todo"); err == nil {
+		appLog = newAppLogger(filepath.Dir(dbPath))
+	}
+
 	// wails.Run 启动 GUI 事件循环，并将后端对象绑定到前端 JS：
 	// - Window 配置：尺寸偏"小挂件"，适合常驻桌面角落
 	// - Frameless：根据用户的 conciseMode 设置决定是否显示窗口边框
@@ -69,9 +91,12 @@ func main() {
 		AssetServer: &assetserver.Options{
 			Assets: assets,
 		},
-		BackgroundColour: &options.RGBA{R: 247, G: 249, B: 251, A: 1},
-		OnStartup:        app.startup,
-		OnShutdown:       app.shutdown,
+		BackgroundColour:   &options.RGBA{R: 247, G: 249, B: 251, A: 1},
+		OnStartup:          app.startup,
+		OnShutdown:         app.shutdown,
+		ErrorFormatter:     app.formatError,
+		Logger:             appLog,
+		LogLevelProduction: logger.WARNING,
 		Bind: []interface{}{
 			app,
 		},
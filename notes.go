@@ -0,0 +1,46 @@
+package main
+
+import "spark-todo/internal/todo"
+
+// GetTaskNotes 返回任务的全部备注，最新的在前（看板只带最新一条，详情页按需加载全部）。
+func (a *App) GetTaskNotes(taskID int64) ([]todo.TaskNote, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return nil, err
+	}
+	notes, err := a.store.GetTaskNotes(a.ctx, taskID)
+	return notes, a.localize(err)
+}
+
+// AddTaskNote 为任务添加一条带时间的备注（进展记录、相关链接等）。
+func (a *App) AddTaskNote(taskID int64, body string) (todo.TaskNote, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.TaskNote{}, err
+	}
+	n, err := a.store.AddTaskNote(a.ctx, taskID, body)
+	if err != nil {
+		return todo.TaskNote{}, a.localize(err)
+	}
+	a.recordTaskVisit(taskID, todo.RecentEdited)
+	return n, nil
+}
+
+// UpdateTaskNote 修改备注内容。
+func (a *App) UpdateTaskNote(id int64, body string) (todo.TaskNote, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.TaskNote{}, err
+	}
+	n, err := a.store.UpdateTaskNote(a.ctx, id, body)
+	if err != nil {
+		return todo.TaskNote{}, a.localize(err)
+	}
+	a.recordTaskVisit(n.TaskID, todo.RecentEdited)
+	return n, nil
+}
+
+// DeleteTaskNote 删除备注。
+func (a *App) DeleteTaskNote(id int64) error {
+	if err := a.ensureStoreReady(); err != nil {
+		return err
+	}
+	return a.localize(a.store.DeleteTaskNote(a.ctx, id))
+}
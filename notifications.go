@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"spark-todo/internal/todo"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// notificationRetentionDays 为已读通知的保留天数，更早的已读通知由维护任务清理（未读通知一直保留）。
+const notificationRetentionDays = 90
+
+// ListNotifications 返回通知中心的通知（按时间倒序），以及全部未读数：
+// - before：上一页返回的 nextCursor，0 表示从最新开始
+// - unreadOnly：只返回未读通知
+// - limit：每页条数，0 为默认 50，最多 200
+func (a *App) ListNotifications(before int64, unreadOnly bool, limit int) (todo.NotificationPage, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.NotificationPage{}, err
+	}
+	page, err := a.store.ListNotifications(a.ctx, before, unreadOnly, limit)
+	if err != nil {
+		return todo.NotificationPage{}, a.localize(err)
+	}
+	return page, nil
+}
+
+// MarkNotificationsRead 将通知标为已读（ids 为空时全部标为已读），返回最新的未读数。
+func (a *App) MarkNotificationsRead(ids []int64) (int, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return 0, err
+	}
+	unread, err := a.store.MarkNotificationsRead(a.ctx, ids, time.Now())
+	if err != nil {
+		return 0, a.localize(err)
+	}
+	return unread, nil
+}
+
+// notify 将一条通知保存到通知中心，并通过 notification:new 事件推送给前端（用于弹出提示与刷新角标）。
+//
+// 保存失败只记录日志；只读模式下不保存，但仍推送事件；DedupeKey 重复的通知既不保存也不推送。
+func (a *App) notify(ctx context.Context, n todo.Notification) {
+	if a.store == nil || a.store.ReadOnly() {
+		n.CreatedAt = time.Now().UnixMilli()
+		runtime.EventsEmit(a.ctx, "notification:new", n)
+		return
+	}
+	saved, created, err := a.store.AddNotification(ctx, n, time.Now())
+	if err != nil {
+		runtime.LogErrorf(a.ctx, "failed to save notification: %v", err)
+		return
+	}
+	if created {
+		runtime.EventsEmit(a.ctx, "notification:new", saved)
+	}
+}
+
+// pruneNotifications 清理超过保留天数的已读通知，失败只记录日志。
+func (a *App) pruneNotifications(ctx context.Context) {
+	if a.store == nil || a.store.ReadOnly() {
+		return
+	}
+	before := time.Now().AddDate(0, 0, -notificationRetentionDays)
+	if _, err := a.store.PruneNotifications(ctx, before.UnixMilli()); err != nil {
+		runtime.LogErrorf(a.ctx, "failed to prune notifications: %v", err)
+	}
+}
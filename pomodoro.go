@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"spark-todo/internal/todo"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// StartPomodoro 为任务开始一个 minutes 分钟的番茄钟（0 为默认 25 分钟）。
+//
+// 专注期间每秒发出 "pomodoro:tick" 事件（参数为 todo.PomodoroSession），到时自动结束并发出 "pomodoro:finished" 事件。
+func (a *App) StartPomodoro(taskID int64, minutes int) (todo.PomodoroSession, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.PomodoroSession{}, err
+	}
+	session, err := a.store.StartPomodoro(a.ctx, taskID, minutes, time.Now())
+	if err != nil {
+		return todo.PomodoroSession{}, a.localize(err)
+	}
+	a.startPomodoroTicker()
+	return session, nil
+}
+
+// PausePomodoro 暂停进行中的番茄钟，暂停期间不计入专注时长。
+func (a *App) PausePomodoro() (todo.PomodoroSession, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.PomodoroSession{}, err
+	}
+	a.stopPomodoroTicker()
+	session, err := a.store.PausePomodoro(a.ctx, time.Now())
+	if err != nil {
+		return todo.PomodoroSession{}, a.localize(err)
+	}
+	runtime.EventsEmit(a.ctx, "pomodoro:tick", session)
+	return session, nil
+}
+
+// ResumePomodoro 继续已暂停的番茄钟。
+func (a *App) ResumePomodoro() (todo.PomodoroSession, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.PomodoroSession{}, err
+	}
+	session, err := a.store.ResumePomodoro(a.ctx, time.Now())
+	if err != nil {
+		return todo.PomodoroSession{}, a.localize(err)
+	}
+	a.startPomodoroTicker()
+	return session, nil
+}
+
+// StopPomodoro 提前结束番茄钟（已专注的时间仍计入统计）。
+func (a *App) StopPomodoro() (todo.PomodoroSession, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.PomodoroSession{}, err
+	}
+	a.stopPomodoroTicker()
+	session, err := a.store.StopPomodoro(a.ctx, time.Now())
+	if err != nil {
+		return todo.PomodoroSession{}, a.localize(err)
+	}
+	a.pomodoroFinished(session)
+	return session, nil
+}
+
+// GetPomodoro 返回进行中或已暂停的番茄钟；没有时返回 nil。
+func (a *App) GetPomodoro() (*todo.PomodoroSession, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return nil, err
+	}
+	session, err := a.store.ActivePomodoro(a.ctx, time.Now())
+	return session, a.localize(err)
+}
+
+// resumePomodoroTicker 在启动时为上次未结束的番茄钟恢复计时（应用关闭期间已到时的会立即结束）。
+func (a *App) resumePomodoroTicker() {
+	session, err := a.store.ActivePomodoro(a.ctx, time.Now())
+	if err != nil {
+		runtime.LogErrorf(a.ctx, "failed to load pomodoro: %v", err)
+		return
+	}
+	if session != nil && session.State == todo.PomodoroRunning {
+		a.startPomodoroTicker()
+	}
+}
+
+// startPomodoroTicker 开始每秒推送番茄钟进度，替换之前的计时。
+func (a *App) startPomodoroTicker() {
+	ctx, cancel := context.WithCancel(a.ctx)
+	a.pomodoroMu.Lock()
+	if a.pomodoroCancel != nil {
+		a.pomodoroCancel()
+	}
+	a.pomodoroCancel = cancel
+	a.pomodoroMu.Unlock()
+	go a.runPomodoroTicker(ctx)
+}
+
+// stopPomodoroTicker 停止推送番茄钟进度。
+func (a *App) stopPomodoroTicker() {
+	a.pomodoroMu.Lock()
+	defer a.pomodoroMu.Unlock()
+	if a.pomodoroCancel != nil {
+		a.pomodoroCancel()
+		a.pomodoroCancel = nil
+	}
+}
+
+// runPomodoroTicker 每秒发出 pomodoro:tick 事件；专注满计划时长时结束番茄钟，直到 ctx 取消。
+func (a *App) runPomodoroTicker(ctx context.Context) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		session, err := a.store.ActivePomodoro(ctx, time.Now())
+		if err != nil {
+			if ctx.Err() == nil {
+				runtime.LogErrorf(a.ctx, "failed to load pomodoro: %v", err)
+			}
+			return
+		}
+		if session == nil || session.State != todo.PomodoroRunning {
+			return
+		}
+		if session.RemainingSeconds <= 0 {
+			done, err := a.store.StopPomodoro(ctx, time.Now())
+			if err != nil {
+				runtime.LogErrorf(a.ctx, "failed to finish pomodoro: %v", err)
+				return
+			}
+			a.pomodoroFinished(done)
+			return
+		}
+		runtime.EventsEmit(a.ctx, "pomodoro:tick", *session)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// pomodoroFinished 发出 pomodoro:finished 事件；专注满计划时长时同时记入通知中心。
+func (a *App) pomodoroFinished(session todo.PomodoroSession) {
+	runtime.EventsEmit(a.ctx, "pomodoro:finished", session)
+	if session.State == todo.PomodoroCompleted {
+		a.notify(a.ctx, todo.Notification{
+			Kind:   todo.NotificationPomodoro,
+			Title:  a.tr("notify.pomodoroTitle"),
+			Body:   a.tr("notify.pomodoroBody", session.TaskTitle, session.FocusedSeconds/60),
+			TaskID: session.TaskID,
+		})
+	}
+}
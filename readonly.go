@@ -0,0 +1,42 @@
+package main
+
+import (
+	"spark-todo/internal/todo"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ReadOnlyStatus 为只读模式的状态。
+type ReadOnlyStatus struct {
+	ReadOnly bool `json:"readOnly"`
+	// Locked 表示以 --readonly 参数启动，界面中不能关闭只读模式
+	Locked bool `json:"locked"`
+}
+
+// GetReadOnly 返回只读模式的状态。
+func (a *App) GetReadOnly() (ReadOnlyStatus, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return ReadOnlyStatus{}, err
+	}
+	return ReadOnlyStatus{ReadOnly: a.store.ReadOnly(), Locked: a.readOnlyFlag}, nil
+}
+
+// SetReadOnly 开启或关闭只读模式（适合共享屏幕时防止误操作，或数据库位于正被其他设备编辑的同步盘时）。
+//
+// 只读模式下所有修改数据的接口都返回"只读模式"错误；后台的回收站清理、共享分组同步等写操作暂停。
+// 该开关只在本次运行期间有效，不写入设置（只读模式下也无法写入）。
+// 变更后发出 readonly:changed 事件，便于其他窗口同步状态。
+func (a *App) SetReadOnly(on bool) (ReadOnlyStatus, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return ReadOnlyStatus{}, err
+	}
+	if !on && a.readOnlyFlag {
+		return ReadOnlyStatus{}, a.localize(todo.ErrReadOnlyLocked)
+	}
+	if err := a.store.SetReadOnly(a.ctx, on); err != nil {
+		return ReadOnlyStatus{}, a.localize(err)
+	}
+	status := ReadOnlyStatus{ReadOnly: on, Locked: a.readOnlyFlag}
+	runtime.EventsEmit(a.ctx, "readonly:changed", status)
+	return status, nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"time"
+
+	"spark-todo/internal/todo"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// GetRecentTasks 返回最近打开或修改过的 n 个任务（最近的在前），供"最近访问"快速切换；
+// n <= 0 时返回默认数量。
+func (a *App) GetRecentTasks(n int) ([]todo.RecentTask, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return nil, err
+	}
+	items, err := a.store.ListRecentTasks(a.ctx, n)
+	if err != nil {
+		return nil, a.localize(err)
+	}
+	return items, nil
+}
+
+// recordTaskVisit 记录一次任务访问（kind 为 todo.Recent*）。
+// 记录失败只写日志，不影响打开或保存任务；只读模式下不记录。
+func (a *App) recordTaskVisit(taskID int64, kind string) {
+	if a.store.ReadOnly() {
+		return
+	}
+	if err := a.store.RecordTaskVisit(a.ctx, taskID, kind, time.Now()); err != nil {
+		runtime.LogWarningf(a.ctx, "failed to record task visit: %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"spark-todo/internal/todo"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// reminderCheckInterval 为检查到时提醒的间隔（提醒最多延迟这么久）。
+const reminderCheckInterval = 30 * time.Second
+
+// SetTaskReminder 设置任务的提醒时间（remindAt 为 UnixMilli），到时弹出系统提示并记入通知中心。
+func (a *App) SetTaskReminder(taskID int64, remindAt int64) (todo.Reminder, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.Reminder{}, err
+	}
+	rm, err := a.store.SetTaskReminder(a.ctx, taskID, time.UnixMilli(remindAt))
+	return rm, a.localize(err)
+}
+
+// ClearTaskReminder 取消任务的提醒。
+func (a *App) ClearTaskReminder(taskID int64) error {
+	if err := a.ensureStoreReady(); err != nil {
+		return err
+	}
+	return a.localize(a.store.ClearTaskReminder(a.ctx, taskID))
+}
+
+// SnoozeReminder 将提醒 id 推迟 minutes 分钟后再次提醒（提醒弹出后"稍后提醒"），返回更新后的提醒。
+func (a *App) SnoozeReminder(id int64, minutes int) (todo.Reminder, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.Reminder{}, err
+	}
+	rm, err := a.store.SnoozeReminder(a.ctx, id, time.Now(), minutes)
+	return rm, a.localize(err)
+}
+
+// ListReminders 返回尚未提醒的任务提醒，按提醒时间升序。
+func (a *App) ListReminders() ([]todo.Reminder, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return nil, err
+	}
+	items, err := a.store.ListReminders(a.ctx)
+	return items, a.localize(err)
+}
+
+// runReminders 在后台定期检查到时的任务提醒，直到 ctx 取消。
+func (a *App) runReminders(ctx context.Context) {
+	ticker := time.NewTicker(reminderCheckInterval)
+	defer ticker.Stop()
+	for {
+		a.fireDueReminders(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// fireDueReminders 取出到时的提醒：逐条记入通知中心，发出 reminders:fired 事件，并合并为一个系统提示框。
+//
+// 只读模式下无法标记已提醒，为避免重复提醒暂不检查。
+func (a *App) fireDueReminders(ctx context.Context) {
+	if a.store == nil || a.store.ReadOnly() {
+		return
+	}
+	items, err := a.store.TakeDueReminders(ctx, time.Now())
+	if err != nil {
+		runtime.LogErrorf(a.ctx, "failed to check reminders: %v", err)
+		return
+	}
+	if len(items) == 0 {
+		return
+	}
+	titles := make([]string, len(items))
+	for i, rm := range items {
+		a.notify(ctx, todo.Notification{
+			Kind:   todo.NotificationReminder,
+			Title:  a.tr("notify.reminderTitle"),
+			Body:   rm.TaskTitle,
+			TaskID: rm.TaskID,
+			Data:   map[string]any{"reminderId": rm.ID},
+		})
+		titles[i] = rm.TaskTitle
+	}
+	runtime.EventsEmit(a.ctx, "reminders:fired", items)
+	// 系统提示框会阻塞到用户关闭，期间到时的提醒在关闭后的下一次检查中送达
+	if err := showSystemMessage(a.ctx, a.tr("notify.reminderTitle"), strings.Join(titles, "\n")); err != nil {
+		runtime.LogErrorf(a.ctx, "failed to show reminder: %v", err)
+	}
+}
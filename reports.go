@@ -0,0 +1,354 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"spark-todo/internal/report"
+	"spark-todo/internal/todo"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// GetBurndown 返回燃尽图与每周完成速度数据：
+// - groupID：0 表示全部分组
+// - rng："week"（7 天）| "month"（30 天，默认）| "quarter"（90 天）
+func (a *App) GetBurndown(groupID int64, rng string) (todo.Burndown, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.Burndown{}, err
+	}
+
+	settings, err := a.store.GetSettings(a.ctx)
+	if err != nil {
+		return todo.Burndown{}, a.localize(err)
+	}
+	burndown, err := a.store.GetBurndown(a.ctx, groupID, rng, time.Now(), settings)
+	if err != nil {
+		return todo.Burndown{}, a.localize(err)
+	}
+	return burndown, nil
+}
+
+// GetFocusStats 返回 rng（"week" | "month" | "quarter"，空字符串为 month）范围内的专注统计：
+// 总专注分钟数、每日分布、最佳一天与按分组的分布。
+func (a *App) GetFocusStats(rng string) (todo.FocusStats, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.FocusStats{}, err
+	}
+
+	settings, err := a.store.GetSettings(a.ctx)
+	if err != nil {
+		return todo.FocusStats{}, a.localize(err)
+	}
+	stats, err := a.store.GetFocusStats(a.ctx, rng, time.Now(), settings)
+	if err != nil {
+		return todo.FocusStats{}, a.localize(err)
+	}
+	return stats, nil
+}
+
+// GenerateWeeklyReport 生成 weekStart（YYYY-MM-DD，为空表示本周）所在周的周报，
+// 并通过系统"另存为"对话框保存：
+// - format："md"（Markdown，默认）| "html" | "pdf"
+// 返回保存的文件路径；用户取消对话框时返回空字符串且不报错。
+func (a *App) GenerateWeeklyReport(weekStart string, format string) (string, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return "", err
+	}
+	format, render, err := a.exportFormat(format, report.FormatMarkdown)
+	if err != nil {
+		return "", err
+	}
+
+	settings, err := a.store.GetSettings(a.ctx)
+	if err != nil {
+		return "", a.localize(err)
+	}
+	weekly, err := a.store.GetWeeklyReport(a.ctx, weekStart, time.Now(), settings)
+	if err != nil {
+		return "", a.localize(err)
+	}
+	data, err := report.Weekly(weekly, render, a.lang())
+	if err != nil {
+		return "", a.wrapErr("report.failed", err)
+	}
+	if data, err = a.toPDF(format, data); err != nil {
+		return "", err
+	}
+
+	name := fmt.Sprintf("%s-%s.%s", a.tr("report.weeklyTitle"), weekly.WeekStart, format)
+	return a.saveExport(name, format, data)
+}
+
+// exportFilters 为"另存为"对话框中各导出格式的文件类型过滤器。
+var exportFilters = map[string]runtime.FileFilter{
+	report.FormatMarkdown: {DisplayName: "Markdown (*.md)", Pattern: "*.md"},
+	report.FormatHTML:     {DisplayName: "HTML (*.html)", Pattern: "*.html;*.htm"},
+	report.FormatXLSX:     {DisplayName: "Excel (*.xlsx)", Pattern: "*.xlsx"},
+	report.FormatPDF:      {DisplayName: "PDF (*.pdf)", Pattern: "*.pdf"},
+	report.FormatCSV:      {DisplayName: "CSV (*.csv)", Pattern: "*.csv"},
+	"zip":                 {DisplayName: "ZIP (*.zip)", Pattern: "*.zip"},
+	"json":                {DisplayName: "JSON (*.json)", Pattern: "*.json"},
+	"txt":                 {DisplayName: "todo.txt (*.txt)", Pattern: "*.txt"},
+	backupExt:             {DisplayName: "Spark-Todo backup (*.db)", Pattern: "*.db"},
+	encryptedBackupExt:    {DisplayName: "Spark-Todo encrypted backup (*.sparkbak)", Pattern: "*.sparkbak"},
+}
+
+// exportFormat 解析导出格式（空字符串使用 def），返回 (输出格式, 模板渲染格式)：
+// PDF 由 HTML 转换而来，因此其渲染格式为 HTML。
+func (a *App) exportFormat(format string, def string) (string, string, error) {
+	if strings.TrimSpace(format) == "" {
+		format = def
+	}
+	if strings.EqualFold(strings.TrimSpace(format), report.FormatPDF) {
+		return report.FormatPDF, report.FormatHTML, nil
+	}
+	f, err := report.ParseFormat(format)
+	if err != nil {
+		return "", "", a.wrapErr("report.failed", err)
+	}
+	return f, f, nil
+}
+
+// toPDF 在 format 为 PDF 时将渲染好的 HTML 转换为 PDF，否则原样返回。
+func (a *App) toPDF(format string, html []byte) ([]byte, error) {
+	if format != report.FormatPDF {
+		return html, nil
+	}
+	data, err := report.PDF(a.ctx, html)
+	if errors.Is(err, report.ErrNoPDFRenderer) {
+		return nil, errors.New(a.tr("report.pdfUnavailable"))
+	}
+	if err != nil {
+		return nil, a.wrapErr("report.failed", err)
+	}
+	return data, nil
+}
+
+// saveExport 弹出"另存为"对话框并写入 data；用户取消时返回 ("", nil)。
+func (a *App) saveExport(defaultName string, format string, data []byte) (string, error) {
+	opts := runtime.SaveDialogOptions{
+		Title:           a.tr("report.saveTitle"),
+		DefaultFilename: defaultName,
+	}
+	if f, ok := exportFilters[format]; ok {
+		opts.Filters = []runtime.FileFilter{f}
+	}
+	path, err := runtime.SaveFileDialog(a.ctx, opts)
+	if err != nil {
+		return "", a.wrapErr("report.saveFailed", err)
+	}
+	if path == "" {
+		return "", nil
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", a.wrapErr("report.saveFailed", err)
+	}
+	return path, nil
+}
+
+// boardExport 读取导出数据：groupID 为 0 表示整个看板（遵循"隐藏已完成"设置）。
+func (a *App) boardExport(groupID int64) (todo.BoardExport, error) {
+	settings, err := a.store.GetSettings(a.ctx)
+	if err != nil {
+		return todo.BoardExport{}, a.localize(err)
+	}
+	b, err := a.store.GetBoardExport(a.ctx, groupID, settings.HideDone, time.Now(), settings)
+	if err != nil {
+		return todo.BoardExport{}, a.localize(err)
+	}
+	return b, nil
+}
+
+// ExportBoard 将整个看板（groupID 为 0）或单个分组导出为适合打印/分享的文档，
+// 并通过"另存为"对话框保存：format 为 "html"（默认，针对打印排版）、"md" 或 "pdf"。
+// 用户取消对话框时返回空字符串且不报错。
+func (a *App) ExportBoard(groupID int64, format string) (string, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return "", err
+	}
+	format, render, err := a.exportFormat(format, report.FormatHTML)
+	if err != nil {
+		return "", err
+	}
+
+	b, err := a.boardExport(groupID)
+	if err != nil {
+		return "", err
+	}
+	data, err := report.Board(b, render, a.lang())
+	if err != nil {
+		return "", a.wrapErr("report.failed", err)
+	}
+	if data, err = a.toPDF(format, data); err != nil {
+		return "", err
+	}
+
+	title := b.Title
+	if title == "" {
+		title = a.tr("report.boardTitle")
+	}
+	name := fmt.Sprintf("%s-%s.%s", title, time.Now().Format("20060102"), format)
+	return a.saveExport(name, format, data)
+}
+
+// ExportMarkdown 将整个看板（groupID 为 0）或单个分组导出为 Markdown（按分组分节的复选框清单，带重要/紧急标记与截止/完成时间），
+// 便于粘贴到笔记或站会消息中；通过"另存为"对话框保存，用户取消时返回空字符串且不报错。
+func (a *App) ExportMarkdown(groupID int64) (string, error) {
+	return a.ExportBoard(groupID, report.FormatMarkdown)
+}
+
+// ExportGroupAsHTML 将分组当前的全部任务（含已完成任务、子任务、内容与标签）导出为单个自包含的只读 HTML 文件，
+// 可直接通过邮件发送或放在共享文件夹中，用浏览器打开即可查看，无需任何服务端。
+// 用户取消对话框时返回空字符串且不报错。
+func (a *App) ExportGroupAsHTML(groupID int64) (string, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return "", err
+	}
+	settings, err := a.store.GetSettings(a.ctx)
+	if err != nil {
+		return "", a.localize(err)
+	}
+	snapshot, err := a.store.GetGroupSnapshot(a.ctx, groupID, time.Now(), settings)
+	if err != nil {
+		return "", a.localize(err)
+	}
+	data, err := report.Snapshot(snapshot, a.lang())
+	if err != nil {
+		return "", a.wrapErr("report.failed", err)
+	}
+
+	name := fmt.Sprintf("%s-%s.%s", snapshot.GroupName, time.Now().Format("20060102"), report.FormatHTML)
+	return a.saveExport(name, report.FormatHTML, data)
+}
+
+// PrintBoard 将看板（groupID 为 0）或单个分组渲染为打印版 HTML，
+// 写入临时文件后用系统默认浏览器打开，并自动弹出打印对话框
+// （小窗口本身不适合直接打印）。
+func (a *App) PrintBoard(groupID int64) error {
+	if err := a.ensureStoreReady(); err != nil {
+		return err
+	}
+
+	b, err := a.boardExport(groupID)
+	if err != nil {
+		return err
+	}
+	b.AutoPrint = true
+	data, err := report.Board(b, report.FormatHTML, a.lang())
+	if err != nil {
+		return a.wrapErr("report.failed", err)
+	}
+
+	f, err := os.CreateTemp("", "spark-todo-print-*.html")
+	if err != nil {
+		return a.wrapErr("report.saveFailed", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return a.wrapErr("report.saveFailed", err)
+	}
+	if err := f.Close(); err != nil {
+		return a.wrapErr("report.saveFailed", err)
+	}
+	runtime.BrowserOpenURL(a.ctx, report.FileURL(f.Name()))
+	return nil
+}
+
+// ExportXLSX 将全部任务（含子任务）导出为 Excel 表格，并通过"另存为"对话框保存。
+// 用户取消对话框时返回空字符串且不报错。
+func (a *App) ExportXLSX() (string, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return "", err
+	}
+
+	settings, err := a.store.GetSettings(a.ctx)
+	if err != nil {
+		return "", a.localize(err)
+	}
+	groups, err := a.store.ListGroups(a.ctx)
+	if err != nil {
+		return "", a.localize(err)
+	}
+	tasks, err := a.store.ListTasks(a.ctx, todo.SortCreated)
+	if err != nil {
+		return "", a.localize(err)
+	}
+	if err := a.store.LoadFullContent(a.ctx, tasks); err != nil {
+		return "", a.localize(err)
+	}
+	data, err := report.TasksXLSX(tasks, groups, todo.Location(settings), a.lang())
+	if err != nil {
+		return "", a.wrapErr("report.failed", err)
+	}
+
+	name := fmt.Sprintf("%s-%s.%s", a.tr("report.boardTitle"), time.Now().Format("20060102"), report.FormatXLSX)
+	return a.saveExport(name, report.FormatXLSX, data)
+}
+
+// ExportCSV 将全部任务（含子任务、完整内容，逐行平铺）导出为 CSV，并通过"另存为"对话框保存；
+// 导出的文件可通过 ImportCSV 再次导入。用户取消对话框时返回空字符串且不报错。
+func (a *App) ExportCSV() (string, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return "", err
+	}
+
+	settings, err := a.store.GetSettings(a.ctx)
+	if err != nil {
+		return "", a.localize(err)
+	}
+	groups, err := a.store.ListGroups(a.ctx)
+	if err != nil {
+		return "", a.localize(err)
+	}
+	tasks, err := a.store.ListTasks(a.ctx, todo.SortCreated)
+	if err != nil {
+		return "", a.localize(err)
+	}
+	if err := a.store.LoadFullContent(a.ctx, tasks); err != nil {
+		return "", a.localize(err)
+	}
+	data, err := report.TasksCSV(tasks, groups, todo.Location(settings), a.lang())
+	if err != nil {
+		return "", a.wrapErr("report.failed", err)
+	}
+
+	name := fmt.Sprintf("%s-%s.%s", a.tr("report.boardTitle"), time.Now().Format("20060102"), report.FormatCSV)
+	return a.saveExport(name, report.FormatCSV, data)
+}
+
+// ExportTimesheet 将 rng（"week" | "month" | "quarter"）范围内的计时记录按天、按任务汇总为工时表，
+// 并通过"另存为"对话框保存：format 为 "md"（默认）| "csv"。用户取消对话框时返回空字符串且不报错。
+func (a *App) ExportTimesheet(rng string, format string) (string, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return "", err
+	}
+
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format != report.FormatCSV {
+		f, err := report.ParseFormat(format)
+		if err != nil || f != report.FormatMarkdown {
+			return "", a.wrapErr("report.failed", fmt.Errorf("unsupported timesheet format %q", format))
+		}
+		format = f
+	}
+
+	settings, err := a.store.GetSettings(a.ctx)
+	if err != nil {
+		return "", a.localize(err)
+	}
+	ts, err := a.store.GetTimesheet(a.ctx, rng, time.Now(), settings)
+	if err != nil {
+		return "", a.localize(err)
+	}
+	data, err := report.Timesheet(ts, format, a.lang())
+	if err != nil {
+		return "", a.wrapErr("report.failed", err)
+	}
+
+	name := fmt.Sprintf("%s-%s.%s", a.tr("report.timesheetTitle"), time.Now().Format("20060102"), format)
+	return a.saveExport(name, format, data)
+}
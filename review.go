@@ -0,0 +1,57 @@
+package main
+
+import (
+	"time"
+
+	"spark-todo/internal/todo"
+)
+
+// GetReviewStatus 返回每周回顾的进度（上次完成时间、是否进行中、待回顾任务数）。
+func (a *App) GetReviewStatus() (todo.ReviewStatus, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.ReviewStatus{}, err
+	}
+	st, err := a.store.GetReviewStatus(a.ctx, time.Now())
+	return st, a.localize(err)
+}
+
+// StartReview 开始（或继续）一轮每周回顾。
+func (a *App) StartReview() (todo.ReviewStatus, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.ReviewStatus{}, err
+	}
+	st, err := a.store.StartReview(a.ctx, time.Now())
+	return st, a.localize(err)
+}
+
+// NextReviewItem 返回下一个需要回顾的任务（逾期、长期未动或未分类）；全部处理完时返回 nil。
+func (a *App) NextReviewItem() (*todo.ReviewItem, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return nil, err
+	}
+	item, err := a.store.NextReviewItem(a.ctx, time.Now())
+	return item, a.localize(err)
+}
+
+// ReviewTask 对回顾中的任务执行动作，并返回下一个待回顾任务（没有时为 nil）。
+//
+// action："keep" | "reschedule" | "archive" | "delete"；dueAt 仅用于 reschedule（UnixMilli，0 表示清除）。
+func (a *App) ReviewTask(id int64, action string, dueAt int64) (*todo.ReviewItem, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return nil, err
+	}
+	if err := a.store.ReviewTask(a.ctx, id, action, dueAt, time.Now()); err != nil {
+		return nil, a.localize(err)
+	}
+	item, err := a.store.NextReviewItem(a.ctx, time.Now())
+	return item, a.localize(err)
+}
+
+// FinishReview 结束本轮回顾并记录完成时间。
+func (a *App) FinishReview() (todo.ReviewStatus, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.ReviewStatus{}, err
+	}
+	st, err := a.store.FinishReview(a.ctx, time.Now())
+	return st, a.localize(err)
+}
@@ -0,0 +1,16 @@
+package main
+
+import "spark-todo/internal/todo"
+
+// SearchTasks 按标题与内容搜索任务，scopes 为搜索范围的组合（"active" / "archived" / "trash" / "all"，
+// 为空时只搜索进行中的任务）；结果按相关度排序，每条结果的 location 标明任务所在位置，回收站中的结果可用 trashId 恢复。
+func (a *App) SearchTasks(query string, scopes []string) (todo.SearchResult, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.SearchResult{}, err
+	}
+	res, err := a.store.SearchTasks(a.ctx, query, scopes)
+	if err != nil {
+		return todo.SearchResult{}, a.localize(err)
+	}
+	return res, nil
+}
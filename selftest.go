@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"spark-todo/internal/todo"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// 自检项的结果。
+const (
+	SelfTestPass = "pass"
+	SelfTestFail = "fail"
+	SelfTestSkip = "skip" // 当前环境下不适用（如只读模式下的写入检查、未设置快捷键）
+)
+
+// 自检项。
+const (
+	SelfTestDatabase     = "database"     // 数据库读写
+	SelfTestNotification = "notification" // 提醒能否送达界面
+	SelfTestHotkeys      = "hotkeys"      // 全局快捷键注册
+	SelfTestUpdate       = "update"       // 更新服务器连通性
+)
+
+// 自检的超时时间。
+const (
+	// selfTestNotifyTimeout 为等待前端确认收到测试通知的时间
+	selfTestNotifyTimeout = 3 * time.Second
+	// selfTestUpdateTimeout 为连接更新服务器的时间
+	selfTestUpdateTimeout = 10 * time.Second
+)
+
+// SelfTestCheck 为一项自检的结果。
+type SelfTestCheck struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`  // pass | fail | skip
+	Message    string `json:"message"` // 本地化的说明（成功时的概要或失败原因）
+	DurationMs int64  `json:"durationMs"`
+}
+
+// SelfTestReport 为自检报告。
+type SelfTestReport struct {
+	// Passed 表示没有失败的检查项（跳过的不算失败）
+	Passed bool            `json:"passed"`
+	Checks []SelfTestCheck `json:"checks"`
+	RanAt  int64           `json:"ranAt"`
+}
+
+// RunSelfTest 检查数据库读写、提醒送达、全局快捷键注册与更新服务器连通性，
+// 返回逐项的结果供设置界面展示；各项并行执行，最长约 10 秒（受更新检查超时限制）。
+//
+// 提醒送达检查会发出 selfTest:notification 事件（携带 token），
+// 前端收到后应以同一 token 发出 selfTest:notificationAck 事件；超时未确认视为失败。
+func (a *App) RunSelfTest() (SelfTestReport, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return SelfTestReport{}, err
+	}
+
+	tests := []struct {
+		name string
+		run  func(ctx context.Context) (string, string)
+	}{
+		{SelfTestDatabase, a.selfTestDatabase},
+		{SelfTestNotification, a.selfTestNotification},
+		{SelfTestHotkeys, a.selfTestHotkeys},
+		{SelfTestUpdate, a.selfTestUpdate},
+	}
+	report := SelfTestReport{Passed: true, Checks: make([]SelfTestCheck, len(tests)), RanAt: time.Now().UnixMilli()}
+	var wg sync.WaitGroup
+	for i, t := range tests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			status, msg := t.run(a.ctx)
+			report.Checks[i] = SelfTestCheck{Name: t.name, Status: status, Message: msg, DurationMs: time.Since(start).Milliseconds()}
+		}()
+	}
+	wg.Wait()
+
+	for _, c := range report.Checks {
+		if c.Status == SelfTestFail {
+			report.Passed = false
+			runtime.LogWarningf(a.ctx, "self-test %s failed: %s", c.Name, c.Message)
+		}
+	}
+	return report, nil
+}
+
+// selfTestDatabase 检查数据库完整性与读写（写入的探测记录会回滚）。
+func (a *App) selfTestDatabase(ctx context.Context) (string, string) {
+	err := a.store.CheckReadWrite(ctx)
+	switch {
+	case err == nil:
+		return SelfTestPass, a.tr("selfTest.databaseOk")
+	case errors.Is(err, todo.ErrReadOnly):
+		return SelfTestSkip, a.tr("selfTest.databaseReadOnly")
+	default:
+		return SelfTestFail, a.wrapErr("selfTest.databaseFailed", a.localize(err)).Error()
+	}
+}
+
+// selfTestNotification 发出测试通知并等待前端确认，确认界面能收到后端推送的提醒。
+func (a *App) selfTestNotification(ctx context.Context) (string, string) {
+	token := strconv.FormatInt(time.Now().UnixNano(), 36)
+	acked := make(chan struct{})
+	var once sync.Once
+	cancel := runtime.EventsOn(ctx, "selfTest:notificationAck", func(data ...any) {
+		if len(data) > 0 && data[0] == token {
+			once.Do(func() { close(acked) })
+		}
+	})
+	defer cancel()
+
+	runtime.EventsEmit(ctx, "selfTest:notification", token)
+	timer := time.NewTimer(selfTestNotifyTimeout)
+	defer timer.Stop()
+	select {
+	case <-acked:
+		return SelfTestPass, a.tr("selfTest.notificationOk")
+	case <-timer.C:
+		return SelfTestFail, a.tr("selfTest.notificationTimeout", int(selfTestNotifyTimeout/time.Second))
+	case <-ctx.Done():
+		return SelfTestFail, a.tr("selfTest.notificationTimeout", int(selfTestNotifyTimeout/time.Second))
+	}
+}
+
+// selfTestHotkeys 检查已设置的全局快捷键是否都注册成功（使用最近一次的注册结果，不会重新注册）。
+func (a *App) selfTestHotkeys(context.Context) (string, string) {
+	registered := 0
+	for _, st := range a.GetHotkeys() {
+		switch {
+		case st.Combo == "":
+		case st.Unsupported:
+			return SelfTestSkip, st.Error
+		case !st.Registered:
+			return SelfTestFail, st.Error
+		default:
+			registered++
+		}
+	}
+	if registered == 0 {
+		return SelfTestSkip, a.tr("selfTest.hotkeysNone")
+	}
+	return SelfTestPass, a.tr("selfTest.hotkeysOk", registered)
+}
+
+// selfTestUpdate 检查能否连接更新服务器并取得最新版本信息。
+func (a *App) selfTestUpdate(ctx context.Context) (string, string) {
+	ctx, cancel := context.WithTimeout(ctx, selfTestUpdateTimeout)
+	defer cancel()
+	result, err := a.updateChecker.Load().CheckUpdate(ctx)
+	if err != nil {
+		return SelfTestFail, a.wrapErr("selfTest.updateFailed", err).Error()
+	}
+	if result.HasUpdate && result.LatestRelease != nil {
+		return SelfTestPass, a.tr("selfTest.updateAvailable", result.LatestRelease.Version)
+	}
+	return SelfTestPass, a.tr("selfTest.updateOk")
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"time"
+
+	"spark-todo/internal/todo"
+)
+
+// GetSession 返回上次离开时的界面状态（选中的分组、视图、滚动定位的任务、打开的编辑器），
+// 前端启动时据此恢复；之后被删除的分组或任务会被清除。
+func (a *App) GetSession() (todo.SessionState, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.SessionState{}, err
+	}
+	st, err := a.store.GetSession(a.ctx)
+	if err != nil {
+		return todo.SessionState{}, a.localize(err)
+	}
+	return st, nil
+}
+
+// SaveSession 保存当前界面状态，前端在切换分组/视图、滚动停止、打开或关闭编辑器时调用（可自行节流）。
+//
+// 只读模式下不保存，原样返回且不报错，避免每次切换视图都弹出只读提示。
+func (a *App) SaveSession(st todo.SessionState) (todo.SessionState, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.SessionState{}, err
+	}
+	if a.store.ReadOnly() {
+		return st, nil
+	}
+	saved, err := a.store.SaveSession(a.ctx, st, time.Now())
+	if err != nil {
+		return todo.SessionState{}, a.localize(err)
+	}
+	return saved, nil
+}
@@ -0,0 +1,326 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"spark-todo/internal/keychain"
+	"spark-todo/internal/share"
+	"spark-todo/internal/todo"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	// shareSyncInterval 为共享分组的后台同步间隔（登录后才会同步）。
+	shareSyncInterval = 2 * time.Minute
+	// shareTokenAccount 为同步服务器登录令牌在系统凭据库中的名称。
+	shareTokenAccount = "share.token"
+)
+
+// ShareStatus 为同步服务器的登录状态与本机的共享分组。
+type ShareStatus struct {
+	Server   string             `json:"server"`
+	Username string             `json:"username"`
+	LoggedIn bool               `json:"loggedIn"`
+	Groups   []todo.SharedGroup `json:"groups"`
+}
+
+// ShareSyncResult 为一次同步的结果。
+type ShareSyncResult struct {
+	Groups  int      `json:"groups"`  // 同步成功的分组数
+	Applied int      `json:"applied"` // 应用的远端变更数
+	Errors  []string `json:"errors"`  // 同步失败的分组及原因（按用户语言）
+}
+
+// GetShareStatus 返回同步服务器的登录状态与本机的共享分组。
+func (a *App) GetShareStatus() (ShareStatus, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return ShareStatus{}, err
+	}
+	session, err := a.shareSession(a.ctx)
+	if err != nil {
+		return ShareStatus{}, err
+	}
+	groups, err := a.store.ListSharedGroups(a.ctx)
+	if err != nil {
+		return ShareStatus{}, a.localize(err)
+	}
+	return ShareStatus{Server: session.Server, Username: session.Username, LoggedIn: session.LoggedIn(), Groups: groups}, nil
+}
+
+// ShareSignIn 登录同步服务器（register 为 true 时先注册账号），成功后保存登录信息（令牌保存在系统凭据库中）。
+// 同步服务端见 cmd/spark-sync。
+func (a *App) ShareSignIn(server string, username string, password string, register bool) (ShareStatus, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return ShareStatus{}, err
+	}
+	server, err := share.NormalizeServerURL(server)
+	if err != nil {
+		return ShareStatus{}, a.localize(err)
+	}
+	c := share.NewClient(server, "")
+	signIn := c.Login
+	if register {
+		signIn = c.Register
+	}
+	resp, err := signIn(a.ctx, username, password)
+	if err != nil {
+		return ShareStatus{}, a.localize(err)
+	}
+	if err := keychain.Set(keychainService, shareTokenAccount, resp.Token); err != nil {
+		// 令牌无处保存，撤销它以免留下无人使用的有效令牌
+		_ = share.NewClient(server, resp.Token).Logout(a.ctx)
+		return ShareStatus{}, a.localize(keychainError(err))
+	}
+	if err := a.store.SetShareSession(a.ctx, todo.ShareSession{Server: server, Username: resp.Account.Username}); err != nil {
+		return ShareStatus{}, a.localize(err)
+	}
+	return a.GetShareStatus()
+}
+
+// ShareSignOut 退出同步服务器：撤销服务器上的登录令牌（服务器不可达时忽略）并删除本机保存的令牌。
+// 共享分组的关联保留，重新登录后继续同步。
+func (a *App) ShareSignOut() error {
+	if err := a.ensureStoreReady(); err != nil {
+		return err
+	}
+	session, err := a.shareSession(a.ctx)
+	if err != nil {
+		return err
+	}
+	if session.LoggedIn() {
+		ctx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+		_ = share.NewClient(session.Server, session.Token).Logout(ctx)
+		cancel()
+	}
+	if err := keychain.Delete(keychainService, shareTokenAccount); err != nil {
+		return a.localize(keychainError(err))
+	}
+	return a.localize(a.store.SetShareSession(a.ctx, todo.ShareSession{}))
+}
+
+// shareSession 返回保存的登录信息，令牌从系统凭据库读取（当前系统不支持凭据库时视为未登录）。
+//
+// 旧版本把令牌明文保存在数据库中：读到时迁移到凭据库并从数据库中清除；凭据库不可用时直接清除，需要重新登录。
+func (a *App) shareSession(ctx context.Context) (todo.ShareSession, error) {
+	session, err := a.store.GetShareSession(ctx)
+	if err != nil {
+		return todo.ShareSession{}, a.localize(err)
+	}
+	legacy, err := a.store.LegacyShareToken(ctx)
+	if err != nil {
+		return todo.ShareSession{}, a.localize(err)
+	}
+	if legacy != "" {
+		if err := keychain.Set(keychainService, shareTokenAccount, legacy); err != nil && !errors.Is(err, keychain.ErrUnsupported) {
+			return todo.ShareSession{}, a.localize(err)
+		}
+		if err := a.store.SetShareSession(ctx, session); err != nil {
+			return todo.ShareSession{}, a.localize(err)
+		}
+	}
+	if session.Server == "" {
+		return session, nil
+	}
+	token, err := keychain.Get(keychainService, shareTokenAccount)
+	if err != nil && !errors.Is(err, keychain.ErrNotFound) && !errors.Is(err, keychain.ErrUnsupported) {
+		return todo.ShareSession{}, a.localize(err)
+	}
+	session.Token = token
+	return session, nil
+}
+
+// GetRemoteSharedGroups 返回服务器上当前账号所在的共享分组（用于加入别人邀请的分组）。
+func (a *App) GetRemoteSharedGroups() ([]todo.HostedGroup, error) {
+	c, err := a.shareClient()
+	if err != nil {
+		return nil, err
+	}
+	groups, err := c.Groups(a.ctx)
+	return groups, a.localize(err)
+}
+
+// ShareGroup 在服务器上新建共享分组并与本地分组关联，随后立即同步一次（上传分组中已有的任务）。
+func (a *App) ShareGroup(groupID int64) (todo.SharedGroup, error) {
+	c, err := a.shareClient()
+	if err != nil {
+		return todo.SharedGroup{}, err
+	}
+	if _, err := a.store.GetSharedGroup(a.ctx, groupID); err == nil {
+		return todo.SharedGroup{}, a.localize(todo.ErrGroupAlreadyShared.With(groupID))
+	}
+	group, err := a.store.GetGroup(a.ctx, groupID)
+	if err != nil {
+		return todo.SharedGroup{}, a.localize(err)
+	}
+
+	hosted, err := c.CreateGroup(a.ctx, group.Name)
+	if err != nil {
+		return todo.SharedGroup{}, a.localize(err)
+	}
+	g, err := a.store.ShareGroup(a.ctx, groupID, hosted.ShareID)
+	if err != nil {
+		return todo.SharedGroup{}, a.localize(err)
+	}
+	return a.syncSharedGroup(c, g)
+}
+
+// JoinSharedGroup 加入服务器上的共享分组 shareID：新建本地分组（name 为空时使用共享分组的名称）并同步。
+// 已加入的分组直接返回。
+func (a *App) JoinSharedGroup(shareID string, name string) (todo.SharedGroup, error) {
+	c, err := a.shareClient()
+	if err != nil {
+		return todo.SharedGroup{}, err
+	}
+	local, err := a.store.ListSharedGroups(a.ctx)
+	if err != nil {
+		return todo.SharedGroup{}, a.localize(err)
+	}
+	for _, g := range local {
+		if g.ShareID == shareID {
+			return g, nil
+		}
+	}
+	if name == "" {
+		remote, err := c.Groups(a.ctx)
+		if err != nil {
+			return todo.SharedGroup{}, a.localize(err)
+		}
+		for _, g := range remote {
+			if g.ShareID == shareID {
+				name = g.Name
+			}
+		}
+		if name == "" {
+			return todo.SharedGroup{}, a.localize(todo.ErrShareNotMember.With(shareID))
+		}
+	}
+
+	group, err := a.store.UpsertGroup(a.ctx, todo.Group{Name: name})
+	if err != nil {
+		return todo.SharedGroup{}, a.localize(err)
+	}
+	g, err := a.store.ShareGroup(a.ctx, group.ID, shareID)
+	if err != nil {
+		return todo.SharedGroup{}, a.localize(err)
+	}
+	return a.syncSharedGroup(c, g)
+}
+
+// InviteToSharedGroup 邀请同步服务器上的用户 username 加入共享分组。
+func (a *App) InviteToSharedGroup(groupID int64, username string) (todo.HostedGroup, error) {
+	c, err := a.shareClient()
+	if err != nil {
+		return todo.HostedGroup{}, err
+	}
+	g, err := a.store.GetSharedGroup(a.ctx, groupID)
+	if err != nil {
+		return todo.HostedGroup{}, a.localize(err)
+	}
+	hosted, err := c.AddMember(a.ctx, g.ShareID, username)
+	return hosted, a.localize(err)
+}
+
+// LeaveSharedGroup 取消本地分组与共享分组的关联：本地任务保留，之后不再同步。
+func (a *App) LeaveSharedGroup(groupID int64) error {
+	if err := a.ensureStoreReady(); err != nil {
+		return err
+	}
+	return a.localize(a.store.UnshareGroup(a.ctx, groupID))
+}
+
+// SyncSharedGroups 立即同步全部共享分组；单个分组失败不影响其他分组，失败原因在 Errors 中返回。
+func (a *App) SyncSharedGroups() (ShareSyncResult, error) {
+	c, err := a.shareClient()
+	if err != nil {
+		return ShareSyncResult{}, err
+	}
+	return a.syncAllSharedGroups(a.ctx, c)
+}
+
+// shareClient 返回使用已保存登录信息的客户端；未登录时返回 ErrShareNotLoggedIn。
+func (a *App) shareClient() (*share.Client, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return nil, err
+	}
+	session, err := a.shareSession(a.ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !session.LoggedIn() {
+		return nil, a.localize(todo.ErrShareNotLoggedIn)
+	}
+	return share.NewClient(session.Server, session.Token), nil
+}
+
+// syncSharedGroup 同步单个分组并返回同步后的状态。
+func (a *App) syncSharedGroup(c *share.Client, g todo.SharedGroup) (todo.SharedGroup, error) {
+	a.shareMu.Lock()
+	_, err := share.SyncGroup(a.ctx, c, a.store, g)
+	a.shareMu.Unlock()
+	if err != nil {
+		return todo.SharedGroup{}, a.localize(err)
+	}
+	g, err = a.store.GetSharedGroup(a.ctx, g.GroupID)
+	return g, a.localize(err)
+}
+
+// syncAllSharedGroups 依次同步全部共享分组（同一时间只有一轮同步在进行）。
+func (a *App) syncAllSharedGroups(ctx context.Context, c *share.Client) (ShareSyncResult, error) {
+	a.shareMu.Lock()
+	defer a.shareMu.Unlock()
+
+	groups, err := a.store.ListSharedGroups(ctx)
+	if err != nil {
+		return ShareSyncResult{}, a.localize(err)
+	}
+	result := ShareSyncResult{Errors: []string{}}
+	for _, g := range groups {
+		n, err := share.SyncGroup(ctx, c, a.store, g)
+		if err != nil {
+			result.Errors = append(result.Errors, g.GroupName+": "+a.localize(err).Error())
+			// 登录失效时其余分组也会失败，不再继续
+			if errors.Is(err, todo.ErrShareUnauthorized) {
+				break
+			}
+			continue
+		}
+		result.Groups++
+		result.Applied += n
+	}
+	return result, nil
+}
+
+// runShareSync 定期在后台同步共享分组；收到远端变更时通知前端刷新（share:synced）。
+func (a *App) runShareSync(ctx context.Context) {
+	ticker := time.NewTicker(shareSyncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		// 只读模式下不会应用远端变更，暂停后台同步
+		if a.store.ReadOnly() {
+			continue
+		}
+		session, err := a.shareSession(ctx)
+		if err != nil || !session.LoggedIn() {
+			continue
+		}
+		result, err := a.syncAllSharedGroups(ctx, share.NewClient(session.Server, session.Token))
+		if err != nil {
+			runtime.LogErrorf(a.ctx, "failed to sync shared groups: %v", err)
+			continue
+		}
+		for _, msg := range result.Errors {
+			runtime.LogWarningf(a.ctx, "shared group sync failed: %s", msg)
+		}
+		if result.Applied > 0 {
+			runtime.EventsEmit(a.ctx, "share:synced", result)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"spark-todo/internal/todo"
+)
+
+// 存储清理建议的触发阈值。
+const (
+	suggestBackupBytes     = 100 << 20
+	suggestBackupCount     = 20
+	suggestAttachmentBytes = 200 << 20
+	suggestFreeBytes       = 1 << 20
+	suggestDoneTasks       = 500
+)
+
+// 存储清理建议代码（由前端负责展示文案）。
+const (
+	SuggestCleanBackups     = "cleanBackups"     // 备份文件过多/过大
+	SuggestVacuum           = "vacuum"           // 数据库空闲页较多，可压缩
+	SuggestArchiveDone      = "archiveDone"      // 已完成任务较多，可清理
+	SuggestCleanAttachments = "cleanAttachments" // 附件占用较大
+)
+
+// FolderUsage 为某个目录下的文件数与总大小。
+type FolderUsage struct {
+	Path  string `json:"path"`
+	Files int64  `json:"files"`
+	Bytes int64  `json:"bytes"`
+}
+
+// StorageUsage 为设置页"存储"一栏的数据：数据量、各目录占用以及清理建议。
+type StorageUsage struct {
+	Data          todo.UsageStats `json:"data"`
+	DataDir       string          `json:"dataDir"`
+	DatabaseBytes int64           `json:"databaseBytes"` // todo.db 及其 -wal/-shm 文件的总大小
+	Backups       FolderUsage     `json:"backups"`
+	Attachments   FolderUsage     `json:"attachments"`
+	Rollback      FolderUsage     `json:"rollback"`
+	Suggestions   []string        `json:"suggestions"`
+}
+
+// GetStorageUsage 统计数据库与数据目录下各文件夹的占用，并给出清理建议。
+func (a *App) GetStorageUsage() (StorageUsage, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return StorageUsage{}, err
+	}
+
+	stats, err := a.store.GetUsageStats(a.ctx)
+	if err != nil {
+		return StorageUsage{}, a.localize(err)
+	}
+
+	u := StorageUsage{
+		Data:        stats,
+		DataDir:     a.dataDir,
+		Backups:     folderUsage(a.backupDir()),
+		Attachments: folderUsage(a.attachmentDir()),
+		Rollback:    folderUsage(a.rollbackDir()),
+		Suggestions: []string{},
+	}
+	dbPath := filepath.Join(a.dataDir, "todo.db")
+	for _, p := range []string{dbPath, dbPath + "-wal", dbPath + "-shm"} {
+		if st, err := os.Stat(p); err == nil {
+			u.DatabaseBytes += st.Size()
+		}
+	}
+
+	if u.Backups.Bytes > suggestBackupBytes || u.Backups.Files > suggestBackupCount {
+		u.Suggestions = append(u.Suggestions, SuggestCleanBackups)
+	}
+	if stats.FreeBytes > suggestFreeBytes && stats.FreeBytes*4 > stats.DatabaseBytes {
+		u.Suggestions = append(u.Suggestions, SuggestVacuum)
+	}
+	if stats.DoneTasks > suggestDoneTasks {
+		u.Suggestions = append(u.Suggestions, SuggestArchiveDone)
+	}
+	if u.Attachments.Bytes > suggestAttachmentBytes {
+		u.Suggestions = append(u.Suggestions, SuggestCleanAttachments)
+	}
+	return u, nil
+}
+
+// attachmentDir 返回附件（图片等）存放目录。
+func (a *App) attachmentDir() string {
+	return filepath.Join(a.dataDir, "attachments")
+}
+
+// folderUsage 递归统计 dir 下的文件数与总大小；目录不存在或无法读取的条目会被忽略。
+func folderUsage(dir string) FolderUsage {
+	u := FolderUsage{Path: dir}
+	_ = filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			u.Files++
+			u.Bytes += info.Size()
+		}
+		return nil
+	})
+	return u
+}
@@ -0,0 +1,50 @@
+package main
+
+import "spark-todo/internal/todo"
+
+// SubTaskToggle 为勾选子任务的结果。
+type SubTaskToggle struct {
+	SubTask todo.Task `json:"subTask"`
+	// Parent 为更新后的主任务（含全部子任务与清单进度）；全部勾选后主任务会自动完成
+	Parent todo.Task `json:"parent"`
+}
+
+// UpsertSubtask 在主任务 parentID 下新增子任务（id 为 0）或修改子任务 id 的标题，返回更新后的主任务。
+func (a *App) UpsertSubtask(parentID int64, id int64, title string) (todo.Task, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.Task{}, err
+	}
+	parent, err := a.store.UpsertSubTask(a.ctx, parentID, id, title)
+	if err != nil {
+		return todo.Task{}, a.localize(err)
+	}
+	a.recordTaskVisit(parent.ID, todo.RecentEdited)
+	return parent, nil
+}
+
+// ToggleSubtask 切换子任务的勾选状态（已完成 <-> 待办）。
+func (a *App) ToggleSubtask(id int64) (SubTaskToggle, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return SubTaskToggle{}, err
+	}
+	sub, parent, err := a.store.ToggleSubTask(a.ctx, id)
+	if err != nil {
+		return SubTaskToggle{}, a.localize(err)
+	}
+	if sub.Status == todo.StatusDone {
+		a.awardCompletion(sub)
+	}
+	return SubTaskToggle{SubTask: sub, Parent: parent}, nil
+}
+
+// DeleteSubtask 删除子任务（进入回收站），返回更新后的主任务。
+func (a *App) DeleteSubtask(id int64) (todo.Task, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.Task{}, err
+	}
+	parent, err := a.store.DeleteSubTask(a.ctx, id)
+	if err != nil {
+		return todo.Task{}, a.localize(err)
+	}
+	return parent, nil
+}
@@ -8,7 +8,8 @@ import (
 	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
-func showWaterReminderSystemCentered(ctx context.Context, title, message string) error {
+// showSystemMessage 在屏幕中央弹出系统级提示框（喝水提醒、任务提醒），用户关闭后返回。
+func showSystemMessage(ctx context.Context, title, message string) error {
 	_, err := runtime.MessageDialog(ctx, runtime.MessageDialogOptions{
 		Type:    runtime.InfoDialog,
 		Title:   title,
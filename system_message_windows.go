@@ -10,7 +10,8 @@ import (
 	"golang.org/x/sys/windows"
 )
 
-func showWaterReminderSystemCentered(_ context.Context, title, message string) error {
+// showSystemMessage 在屏幕中央弹出系统级提示框（喝水提醒、任务提醒），用户关闭后返回。
+func showSystemMessage(_ context.Context, title, message string) error {
 	titleUTF16, err := syscall.UTF16PtrFromString(title)
 	if err != nil {
 		return err
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"spark-todo/internal/todo"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ThemeChange 为 theme:changed 事件的负载。
+type ThemeChange struct {
+	Theme         string `json:"theme"`         // 实际应使用的主题："light" | "dark"
+	System        string `json:"system"`        // 当前系统外观："light" | "dark"
+	HighContrast  bool   `json:"highContrast"`  // 实际是否使用高对比度
+	ReducedMotion bool   `json:"reducedMotion"` // 实际是否减少动画
+}
+
+// AccessibilityPrefs 为系统的辅助功能偏好。
+type AccessibilityPrefs struct {
+	HighContrast  bool `json:"highContrast"`  // 系统开启了高对比度/增强对比度
+	ReducedMotion bool `json:"reducedMotion"` // 系统要求减少动画
+}
+
+// RefreshSystemTheme 重新读取系统外观与辅助功能偏好。
+//
+// 前端在 prefers-color-scheme / prefers-contrast / prefers-reduced-motion 等媒体查询变化时调用
+// （WebView 会随系统设置触发这些事件，省去各平台不同的系统通知接口与后台轮询）。
+// 当用户选择跟随系统（theme / highContrast / reducedMotion 为 "system"）且对应系统设置改变时，
+// 发出 theme:changed 事件。
+func (a *App) RefreshSystemTheme() error {
+	if err := a.ensureStoreReady(); err != nil {
+		return err
+	}
+	themeChanged, a11yChanged := a.detectSystemAppearance()
+	if !themeChanged && !a11yChanged {
+		return nil
+	}
+	settings, err := a.store.GetSettings(a.ctx)
+	if err != nil {
+		return a.localize(err)
+	}
+	followA11y := settings.HighContrast == "system" || settings.ReducedMotion == "system"
+	if (themeChanged && settings.Theme == "system") || (a11yChanged && followA11y) {
+		a.emitThemeChanged(settings)
+	}
+	return nil
+}
+
+// detectSystemAppearance 检测并缓存系统外观与辅助功能偏好，返回两者相对上次缓存是否变化
+// （首次检测不算变化）。
+func (a *App) detectSystemAppearance() (themeChanged, a11yChanged bool) {
+	cur := detectSystemTheme()
+	curA11y := detectAccessibility()
+	last, hadTheme := a.systemTheme.Swap(cur).(string)
+	lastA11y, hadA11y := a.systemA11y.Swap(curA11y).(AccessibilityPrefs)
+	return hadTheme && cur != last, hadA11y && curA11y != lastA11y
+}
+
+// GetSystemTheme 返回当前系统外观（"light" 或 "dark"）。
+func (a *App) GetSystemTheme() string {
+	if v, ok := a.systemTheme.Load().(string); ok {
+		return v
+	}
+	return detectSystemTheme()
+}
+
+// GetSystemAccessibility 返回当前系统的辅助功能偏好（高对比度/减少动画）。
+func (a *App) GetSystemAccessibility() AccessibilityPrefs {
+	if v, ok := a.systemA11y.Load().(AccessibilityPrefs); ok {
+		return v
+	}
+	return detectAccessibility()
+}
+
+// resolveTheme 将设置中的主题解析为实际主题："system" 跟随系统外观。
+func (a *App) resolveTheme(settings todo.Settings) string {
+	if settings.Theme == "system" {
+		return a.GetSystemTheme()
+	}
+	return settings.Theme
+}
+
+// resolveToggle 将 "on" / "off" / "system" 三态设置解析为实际开关，"system" 使用 sys。
+func resolveToggle(mode string, sys bool) bool {
+	switch mode {
+	case "on":
+		return true
+	case "off":
+		return false
+	default:
+		return sys
+	}
+}
+
+// themeChange 计算当前设置下实际生效的外观。
+func (a *App) themeChange(settings todo.Settings) ThemeChange {
+	sys := a.GetSystemAccessibility()
+	return ThemeChange{
+		Theme:         a.resolveTheme(settings),
+		System:        a.GetSystemTheme(),
+		HighContrast:  resolveToggle(settings.HighContrast, sys.HighContrast),
+		ReducedMotion: resolveToggle(settings.ReducedMotion, sys.ReducedMotion),
+	}
+}
+
+// GetEffectiveTheme 返回当前实际生效的外观（主题、高对比度、减少动画），供前端启动时初始化。
+func (a *App) GetEffectiveTheme() (ThemeChange, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return ThemeChange{}, err
+	}
+	settings, err := a.store.GetSettings(a.ctx)
+	if err != nil {
+		return ThemeChange{}, a.localize(err)
+	}
+	return a.themeChange(settings), nil
+}
+
+// emitThemeChanged 广播 theme:changed 事件。
+func (a *App) emitThemeChanged(settings todo.Settings) {
+	runtime.EventsEmit(a.ctx, "theme:changed", a.themeChange(settings))
+}
@@ -0,0 +1,57 @@
+//go:build !windows
+
+package main
+
+import (
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// detectSystemTheme 读取系统外观：
+// - macOS：`defaults read -g AppleInterfaceStyle` 输出 Dark 表示深色（浅色时该键不存在）
+// - Linux（GNOME）：org.gnome.desktop.interface color-scheme 为 prefer-dark 表示深色
+// 无法判断时按浅色处理。
+func detectSystemTheme() string {
+	switch runtime.GOOS {
+	case "darwin":
+		out, err := exec.Command("defaults", "read", "-g", "AppleInterfaceStyle").Output()
+		if err == nil && strings.EqualFold(strings.TrimSpace(string(out)), "dark") {
+			return "dark"
+		}
+		return "light"
+	default:
+		out, err := exec.Command("gsettings", "get", "org.gnome.desktop.interface", "color-scheme").Output()
+		if err == nil && strings.Contains(string(out), "dark") {
+			return "dark"
+		}
+		return "light"
+	}
+}
+
+// detectAccessibility 读取系统辅助功能偏好：
+//   - macOS：com.apple.universalaccess 的 increaseContrast / reduceMotion 为 1
+//   - Linux（GNOME）：org.gnome.desktop.a11y.interface high-contrast 为 true；
+//     org.gnome.desktop.interface enable-animations 为 false 表示减少动画
+//
+// 无法判断时视为未开启。
+func detectAccessibility() AccessibilityPrefs {
+	switch runtime.GOOS {
+	case "darwin":
+		return AccessibilityPrefs{
+			HighContrast:  commandOutputIs("1", "defaults", "read", "com.apple.universalaccess", "increaseContrast"),
+			ReducedMotion: commandOutputIs("1", "defaults", "read", "com.apple.universalaccess", "reduceMotion"),
+		}
+	default:
+		return AccessibilityPrefs{
+			HighContrast:  commandOutputIs("true", "gsettings", "get", "org.gnome.desktop.a11y.interface", "high-contrast"),
+			ReducedMotion: commandOutputIs("false", "gsettings", "get", "org.gnome.desktop.interface", "enable-animations"),
+		}
+	}
+}
+
+// commandOutputIs 执行命令并判断其输出（去掉首尾空白）是否等于 want；命令失败时返回 false。
+func commandOutputIs(want string, name string, args ...string) bool {
+	out, err := exec.Command(name, args...).Output()
+	return err == nil && strings.TrimSpace(string(out)) == want
+}
@@ -0,0 +1,64 @@
+//go:build windows
+// +build windows
+
+package main
+
+import (
+	"strconv"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+// detectSystemTheme 读取 Windows "应用模式"（设置 > 个性化 > 颜色）。
+//
+// AppsUseLightTheme = 0 表示深色；键不存在（老版本系统）按浅色处理。
+func detectSystemTheme() string {
+	k, err := registry.OpenKey(registry.CURRENT_USER,
+		`Software\Microsoft\Windows\CurrentVersion\Themes\Personalize`,
+		registry.QUERY_VALUE,
+	)
+	if err != nil {
+		return "light"
+	}
+	defer k.Close()
+
+	v, _, err := k.GetIntegerValue("AppsUseLightTheme")
+	if err != nil {
+		return "light"
+	}
+	if v == 0 {
+		return "dark"
+	}
+	return "light"
+}
+
+// detectAccessibility 读取 Windows 辅助功能设置：
+// - 高对比度：Control Panel\Accessibility\HighContrast 的 Flags 含 HCF_HIGHCONTRASTON(0x1)
+// - 减少动画：Control Panel\Desktop\WindowMetrics 的 MinAnimate 为 "0"（"显示 Windows 动画"已关闭）
+func detectAccessibility() AccessibilityPrefs {
+	var prefs AccessibilityPrefs
+
+	if k, err := registry.OpenKey(registry.CURRENT_USER,
+		`Control Panel\Accessibility\HighContrast`,
+		registry.QUERY_VALUE,
+	); err == nil {
+		if v, _, err := k.GetStringValue("Flags"); err == nil {
+			if n, err := strconv.Atoi(v); err == nil && n&0x1 != 0 {
+				prefs.HighContrast = true
+			}
+		}
+		k.Close()
+	}
+
+	if k, err := registry.OpenKey(registry.CURRENT_USER,
+		`Control Panel\Desktop\WindowMetrics`,
+		registry.QUERY_VALUE,
+	); err == nil {
+		if v, _, err := k.GetStringValue("MinAnimate"); err == nil && v == "0" {
+			prefs.ReducedMotion = true
+		}
+		k.Close()
+	}
+
+	return prefs
+}
@@ -0,0 +1,91 @@
+package main
+
+import "spark-todo/internal/todo"
+
+// GetTags 返回全部标签及各自的任务数。
+func (a *App) GetTags() ([]todo.Tag, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return nil, err
+	}
+	tags, err := a.store.ListTags(a.ctx)
+	return tags, a.localize(err)
+}
+
+// CreateTag 新建标签。
+func (a *App) CreateTag(name string) (todo.Tag, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.Tag{}, err
+	}
+	t, err := a.store.CreateTag(a.ctx, name)
+	return t, a.localize(err)
+}
+
+// DeleteTag 删除标签（任务保留，只移除该标签），并从已保存的视图筛选中去掉。
+func (a *App) DeleteTag(id int64) error {
+	if err := a.ensureStoreReady(); err != nil {
+		return err
+	}
+	if err := a.store.DeleteTag(a.ctx, id); err != nil {
+		return a.localize(err)
+	}
+	// 视图筛选中的标签可能已被移除，通知前端刷新设置
+	_, err := a.reloadSettings()
+	return err
+}
+
+// SetTaskTags 将任务的标签替换为 names（不存在的标签自动新建），返回任务上的全部标签。
+func (a *App) SetTaskTags(taskID int64, names []string) ([]todo.Tag, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return nil, err
+	}
+	tags, err := a.store.SetTaskTags(a.ctx, taskID, names)
+	if err != nil {
+		return nil, a.localize(err)
+	}
+	a.recordTaskVisit(taskID, todo.RecentEdited)
+	return tags, nil
+}
+
+// GetTasksByTags 返回同时带有 tags 中全部标签的主任务（按当前视图的排序设置），tags 为空时返回全部任务。
+func (a *App) GetTasksByTags(tags []string) ([]todo.Task, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return nil, err
+	}
+	settings, err := a.store.GetSettings(a.ctx)
+	if err != nil {
+		return nil, a.localize(err)
+	}
+	tasks, err := a.store.ListTasksByTags(a.ctx, settings.ViewSorts[settings.ViewMode], tags)
+	if err != nil {
+		return nil, a.localize(err)
+	}
+	return tasks, nil
+}
+
+// RenameTag 重命名标签，所有任务与已保存的视图筛选同步生效。
+func (a *App) RenameTag(id int64, name string) (todo.Tag, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.Tag{}, err
+	}
+	t, err := a.store.RenameTag(a.ctx, id, name)
+	if err != nil {
+		return todo.Tag{}, a.localize(err)
+	}
+	// 视图筛选中的标签名可能已被替换，通知前端刷新设置
+	_, err = a.reloadSettings()
+	return t, err
+}
+
+// MergeTags 将标签 fromID 合并到 intoID（单个事务），返回合并后的标签。
+func (a *App) MergeTags(fromID int64, intoID int64) (todo.Tag, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.Tag{}, err
+	}
+	t, err := a.store.MergeTags(a.ctx, fromID, intoID)
+	if err != nil {
+		return todo.Tag{}, a.localize(err)
+	}
+	// 视图筛选中的标签名可能已被替换，通知前端刷新设置
+	_, err = a.reloadSettings()
+	return t, err
+}
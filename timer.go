@@ -0,0 +1,69 @@
+package main
+
+import (
+	"time"
+
+	"spark-todo/internal/todo"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// StartTimer 开始为任务计时（同一时间只能有一个计时器或番茄钟），并发出 "timer:changed" 事件。
+func (a *App) StartTimer(taskID int64) (todo.TimeEntry, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.TimeEntry{}, err
+	}
+	e, err := a.store.StartTimer(a.ctx, taskID, time.Now())
+	if err != nil {
+		return todo.TimeEntry{}, a.localize(err)
+	}
+	runtime.EventsEmit(a.ctx, "timer:changed", e)
+	return e, nil
+}
+
+// StopTimer 停止进行中的计时器，返回这段计时记录，并发出 "timer:changed" 事件。
+func (a *App) StopTimer() (todo.TimeEntry, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.TimeEntry{}, err
+	}
+	e, err := a.store.StopTimer(a.ctx, time.Now())
+	if err != nil {
+		return todo.TimeEntry{}, a.localize(err)
+	}
+	runtime.EventsEmit(a.ctx, "timer:changed", e)
+	return e, nil
+}
+
+// GetTimer 返回进行中的计时器；没有时返回 nil。
+func (a *App) GetTimer() (*todo.TimeEntry, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return nil, err
+	}
+	e, err := a.store.ActiveTimer(a.ctx, time.Now())
+	return e, a.localize(err)
+}
+
+// GetTimeSpent 返回任务累计的计时（含番茄钟专注）。
+func (a *App) GetTimeSpent(taskID int64) (todo.TimeSpent, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.TimeSpent{}, err
+	}
+	spent, err := a.store.GetTimeSpent(a.ctx, taskID, time.Now())
+	return spent, a.localize(err)
+}
+
+// GetTimeReport 按任务汇总 from 到 to（YYYY-MM-DD，含首尾两天；to 为空表示今天，from 为空与 to 相同）之间的计时。
+func (a *App) GetTimeReport(from string, to string) (todo.TimeReport, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.TimeReport{}, err
+	}
+	settings, err := a.store.GetSettings(a.ctx)
+	if err != nil {
+		return todo.TimeReport{}, a.localize(err)
+	}
+	report, err := a.store.GetTimeReport(a.ctx, from, to, time.Now(), settings)
+	if err != nil {
+		return todo.TimeReport{}, a.localize(err)
+	}
+	return report, nil
+}
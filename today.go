@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"spark-todo/internal/todo"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// GetToday 返回"今天"视图：逾期结转、今天到期、以及手动加入"我的一天"的任务。
+//
+// "今天"按时区设置计算。
+func (a *App) GetToday() (todo.Today, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.Today{}, err
+	}
+
+	settings, err := a.store.GetSettings(a.ctx)
+	if err != nil {
+		return todo.Today{}, a.localize(err)
+	}
+	today, err := a.store.GetToday(a.ctx, time.Now(), todo.Location(settings))
+	if err != nil {
+		return todo.Today{}, a.localize(err)
+	}
+	return today, nil
+}
+
+// AddToMyDay 将任务加入今天的"我的一天"，次日零点自动移出。
+func (a *App) AddToMyDay(taskID int64) error {
+	if err := a.ensureStoreReady(); err != nil {
+		return err
+	}
+
+	settings, err := a.store.GetSettings(a.ctx)
+	if err != nil {
+		return a.localize(err)
+	}
+	return a.localize(a.store.AddToMyDay(a.ctx, taskID, todo.DayKey(time.Now(), todo.Location(settings))))
+}
+
+// RemoveFromMyDay 将任务移出"我的一天"。
+func (a *App) RemoveFromMyDay(taskID int64) error {
+	if err := a.ensureStoreReady(); err != nil {
+		return err
+	}
+	return a.localize(a.store.RemoveFromMyDay(a.ctx, taskID))
+}
+
+// watchDayChange 在每个本地自然日零点清理"我的一天"，并发出 day:changed 事件（负载为新日期），
+// 让前端刷新"今天"等按日期计算的视图；随后检查倒计时事项的提醒节点与节假日日历是否过期。
+//
+// 每次都重新读取时区设置；修改时区时经 wakeDayWatcher 唤醒，立即按新时区重新计算下一个零点。
+// 在 startup 中数据库打开后启动。
+func (a *App) watchDayChange(ctx context.Context) {
+	for {
+		loc := time.Local
+		if settings, err := a.store.GetSettings(ctx); err == nil {
+			loc = todo.Location(settings)
+		}
+		now := time.Now()
+		_, next := todo.DayRange(now, loc)
+		// 多等一秒，避免时钟抖动导致在零点前醒来
+		wait := time.Until(time.UnixMilli(next)) + time.Second
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-a.dayWake:
+			timer.Stop()
+			continue
+		case <-timer.C:
+		}
+
+		day := todo.DayKey(time.Now(), loc)
+		// 只读模式下不清空"我的一天"，前端仍按新的一天刷新
+		if !a.store.ReadOnly() {
+			if err := a.store.ResetMyDay(ctx, day); err != nil {
+				runtime.LogErrorf(a.ctx, "failed to reset my day: %v", err)
+			}
+		}
+		runtime.EventsEmit(a.ctx, "day:changed", day)
+		a.notifyCountdownMilestones(ctx)
+		a.checkHolidayCoverage(ctx)
+	}
+}
+
+// upcomingDays 为"即将到期"日程条覆盖的天数（含今天）。
+const upcomingDays = 7
+
+// GetUpcoming 返回今天起 7 天内到期的任务，按天分组（遵循"隐藏已完成"设置）。
+func (a *App) GetUpcoming() ([]todo.AgendaDay, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return nil, err
+	}
+
+	settings, err := a.store.GetSettings(a.ctx)
+	if err != nil {
+		return nil, a.localize(err)
+	}
+	days, err := a.store.GetUpcoming(a.ctx, time.Now(), settings, upcomingDays, settings.HideDone)
+	if err != nil {
+		return nil, a.localize(err)
+	}
+	return days, nil
+}
+
+// GetOverdue 返回逾期未完成的任务（按逾期时长降序）及逾期天数，用于逾期视图与角标。
+func (a *App) GetOverdue() (todo.Overdue, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.Overdue{}, err
+	}
+
+	settings, err := a.store.GetSettings(a.ctx)
+	if err != nil {
+		return todo.Overdue{}, a.localize(err)
+	}
+	overdue, err := a.store.GetOverdue(a.ctx, time.Now(), todo.Location(settings))
+	if err != nil {
+		return todo.Overdue{}, a.localize(err)
+	}
+	return overdue, nil
+}
+
+// ListOverdueTasks 返回截止时刻已过且未完成的任务（精确到时刻，最早到期的在前），用于截止时间标记与"已逾期"筛选。
+func (a *App) ListOverdueTasks() ([]todo.Task, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return nil, err
+	}
+	tasks, err := a.store.ListOverdueTasks(a.ctx, time.Now())
+	if err != nil {
+		return nil, a.localize(err)
+	}
+	return tasks, nil
+}
+
+// ListTasksDueBetween 返回截止时间在 [from, to)（UnixMilli）内的任务，按截止时间升序（遵循"隐藏已完成"设置）。
+func (a *App) ListTasksDueBetween(from int64, to int64) ([]todo.Task, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return nil, err
+	}
+
+	settings, err := a.store.GetSettings(a.ctx)
+	if err != nil {
+		return nil, a.localize(err)
+	}
+	tasks, err := a.store.ListTasksDueBetween(a.ctx, from, to, settings.HideDone)
+	if err != nil {
+		return nil, a.localize(err)
+	}
+	return tasks, nil
+}
+
+// GetWeekAgenda 返回 startDate（YYYY-MM-DD，为空表示今天）所在周的日程，
+// 按每周第一天设置排列七天，每项附带截止时刻（遵循"隐藏已完成"设置）。
+func (a *App) GetWeekAgenda(startDate string) (todo.WeekAgenda, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.WeekAgenda{}, err
+	}
+
+	settings, err := a.store.GetSettings(a.ctx)
+	if err != nil {
+		return todo.WeekAgenda{}, a.localize(err)
+	}
+	week, err := a.store.GetWeekAgenda(a.ctx, startDate, time.Now(), settings, settings.HideDone)
+	if err != nil {
+		return todo.WeekAgenda{}, a.localize(err)
+	}
+	return week, nil
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"spark-todo/internal/todo"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// trashPurgeInterval 为回收站自动清理的检查间隔（启动时也会立即检查一次）。
+const trashPurgeInterval = 6 * time.Hour
+
+// GetTrash 返回回收站内容（最近删除的在前）。
+func (a *App) GetTrash() ([]todo.TrashItem, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return nil, err
+	}
+	items, err := a.store.ListTrash(a.ctx)
+	return items, a.localize(err)
+}
+
+// RestoreTrashItem 将回收站条目恢复到原分组/原父任务下。
+func (a *App) RestoreTrashItem(id int64) error {
+	if err := a.ensureStoreReady(); err != nil {
+		return err
+	}
+	return a.localize(a.store.RestoreTrash(a.ctx, id))
+}
+
+// DeleteTrashItem 从回收站永久删除一条记录，连同其中任务不再被引用的附件文件。
+func (a *App) DeleteTrashItem(id int64) error {
+	if err := a.ensureStoreReady(); err != nil {
+		return err
+	}
+	files, err := a.store.DeleteTrashItem(a.ctx, id)
+	if err != nil {
+		return a.localize(err)
+	}
+	a.removeAttachmentFiles(files)
+	return nil
+}
+
+// EmptyTrash 清空回收站（连同其中任务不再被引用的附件文件），返回永久删除的条目数。
+func (a *App) EmptyTrash() (int64, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return 0, err
+	}
+	n, files, err := a.store.PurgeTrash(a.ctx, 0)
+	if err != nil {
+		return 0, a.localize(err)
+	}
+	a.removeAttachmentFiles(files)
+	return n, nil
+}
+
+// SetTrashRetention 设置回收站保留天数（0 表示永不自动清理），并立即按新设置清理一次。
+func (a *App) SetTrashRetention(days int) (todo.Settings, error) {
+	if err := a.ensureStoreReady(); err != nil {
+		return todo.Settings{}, err
+	}
+
+	settings, err := a.store.GetSettings(a.ctx)
+	if err != nil {
+		return todo.Settings{}, a.localize(err)
+	}
+	settings.TrashRetentionDays = days
+	if err := a.store.SetSettings(a.ctx, settings); err != nil {
+		return todo.Settings{}, a.localize(err)
+	}
+	return a.reloadSettings()
+}
+
+// runTrashMaintenance 定期永久删除超过保留天数的回收站条目（并清理过期的动态与已读通知），直到 ctx 取消。
+func (a *App) runTrashMaintenance(ctx context.Context) {
+	ticker := time.NewTicker(trashPurgeInterval)
+	defer ticker.Stop()
+	for {
+		a.purgeExpiredTrash(ctx)
+		a.pruneActivity(ctx)
+		a.pruneNotifications(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// purgeExpiredTrash 按当前设置清理一次回收站；有条目被删除时记录日志并通知前端（trash:purged）。
+func (a *App) purgeExpiredTrash(ctx context.Context) {
+	if a.store == nil || a.store.ReadOnly() {
+		return
+	}
+	settings, err := a.store.GetSettings(ctx)
+	if err != nil {
+		runtime.LogErrorf(a.ctx, "failed to load settings for trash purge: %v", err)
+		return
+	}
+	if settings.TrashRetentionDays <= 0 {
+		return
+	}
+
+	before := time.Now().AddDate(0, 0, -settings.TrashRetentionDays)
+	n, files, err := a.store.PurgeTrash(ctx, before.UnixMilli())
+	if err != nil {
+		runtime.LogErrorf(a.ctx, "failed to purge trash: %v", err)
+		return
+	}
+	a.removeAttachmentFiles(files)
+	if n > 0 {
+		runtime.LogInfof(a.ctx, "purged %d trash item(s) deleted before %s (retention %d days)",
+			n, before.Format(time.RFC3339), settings.TrashRetentionDays)
+		runtime.EventsEmit(a.ctx, "trash:purged", n)
+	}
+}